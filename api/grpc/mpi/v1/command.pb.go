@@ -19,6 +19,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -99,19 +100,28 @@ const (
 	DataPlaneResponse_API_ACTION_REQUEST          DataPlaneResponse_RequestType = 5
 	DataPlaneResponse_COMMAND_STATUS_REQUEST      DataPlaneResponse_RequestType = 6
 	DataPlaneResponse_UPDATE_AGENT_CONFIG_REQUEST DataPlaneResponse_RequestType = 7
+	DataPlaneResponse_CONFIG_VALIDATE_REQUEST     DataPlaneResponse_RequestType = 8
+	DataPlaneResponse_CONFIG_DIFF_REQUEST         DataPlaneResponse_RequestType = 9
+	DataPlaneResponse_CONFIG_HISTORY_REQUEST      DataPlaneResponse_RequestType = 10
+	// Not a response to a request, the response carries an instance event detected by the agent
+	DataPlaneResponse_INSTANCE_EVENT DataPlaneResponse_RequestType = 11
 )
 
 // Enum value maps for DataPlaneResponse_RequestType.
 var (
 	DataPlaneResponse_RequestType_name = map[int32]string{
-		0: "UNSPECIFIED_REQUEST",
-		1: "CONFIG_APPLY_REQUEST",
-		2: "CONFIG_UPLOAD_REQUEST",
-		3: "HEALTH_REQUEST",
-		4: "STATUS_REQUEST",
-		5: "API_ACTION_REQUEST",
-		6: "COMMAND_STATUS_REQUEST",
-		7: "UPDATE_AGENT_CONFIG_REQUEST",
+		0:  "UNSPECIFIED_REQUEST",
+		1:  "CONFIG_APPLY_REQUEST",
+		2:  "CONFIG_UPLOAD_REQUEST",
+		3:  "HEALTH_REQUEST",
+		4:  "STATUS_REQUEST",
+		5:  "API_ACTION_REQUEST",
+		6:  "COMMAND_STATUS_REQUEST",
+		7:  "UPDATE_AGENT_CONFIG_REQUEST",
+		8:  "CONFIG_VALIDATE_REQUEST",
+		9:  "CONFIG_DIFF_REQUEST",
+		10: "CONFIG_HISTORY_REQUEST",
+		11: "INSTANCE_EVENT",
 	}
 	DataPlaneResponse_RequestType_value = map[string]int32{
 		"UNSPECIFIED_REQUEST":         0,
@@ -122,6 +132,10 @@ var (
 		"API_ACTION_REQUEST":          5,
 		"COMMAND_STATUS_REQUEST":      6,
 		"UPDATE_AGENT_CONFIG_REQUEST": 7,
+		"CONFIG_VALIDATE_REQUEST":     8,
+		"CONFIG_DIFF_REQUEST":         9,
+		"CONFIG_HISTORY_REQUEST":      10,
+		"INSTANCE_EVENT":              11,
 	}
 )
 
@@ -152,6 +166,83 @@ func (DataPlaneResponse_RequestType) EnumDescriptor() ([]byte, []int) {
 	return file_mpi_v1_command_proto_rawDescGZIP(), []int{12, 0}
 }
 
+// the types of changes to an instance
+type InstanceEvent_InstanceEventType int32
+
+const (
+	// Unspecified event
+	InstanceEvent_INSTANCE_EVENT_TYPE_UNSPECIFIED InstanceEvent_InstanceEventType = 0
+	// The instance was started
+	InstanceEvent_INSTANCE_EVENT_TYPE_STARTED InstanceEvent_InstanceEventType = 1
+	// The instance was stopped
+	InstanceEvent_INSTANCE_EVENT_TYPE_STOPPED InstanceEvent_InstanceEventType = 2
+	// The process ID of the master process changed
+	InstanceEvent_INSTANCE_EVENT_TYPE_PROCESS_ID_CHANGED InstanceEvent_InstanceEventType = 3
+	// The version of the instance binary changed
+	InstanceEvent_INSTANCE_EVENT_TYPE_VERSION_CHANGED InstanceEvent_InstanceEventType = 4
+	// The loadable or dynamic modules of the instance changed
+	InstanceEvent_INSTANCE_EVENT_TYPE_MODULES_CHANGED InstanceEvent_InstanceEventType = 5
+	// The number of worker processes changed
+	InstanceEvent_INSTANCE_EVENT_TYPE_WORKER_COUNT_CHANGED InstanceEvent_InstanceEventType = 6
+	// The configuration was reloaded without a config apply from the agent
+	InstanceEvent_INSTANCE_EVENT_TYPE_CONFIG_RELOADED InstanceEvent_InstanceEventType = 7
+	// The NGINX App Protect attack signature version changed
+	InstanceEvent_INSTANCE_EVENT_TYPE_ATTACK_SIGNATURE_VERSION_CHANGED InstanceEvent_InstanceEventType = 8
+)
+
+// Enum value maps for InstanceEvent_InstanceEventType.
+var (
+	InstanceEvent_InstanceEventType_name = map[int32]string{
+		0: "INSTANCE_EVENT_TYPE_UNSPECIFIED",
+		1: "INSTANCE_EVENT_TYPE_STARTED",
+		2: "INSTANCE_EVENT_TYPE_STOPPED",
+		3: "INSTANCE_EVENT_TYPE_PROCESS_ID_CHANGED",
+		4: "INSTANCE_EVENT_TYPE_VERSION_CHANGED",
+		5: "INSTANCE_EVENT_TYPE_MODULES_CHANGED",
+		6: "INSTANCE_EVENT_TYPE_WORKER_COUNT_CHANGED",
+		7: "INSTANCE_EVENT_TYPE_CONFIG_RELOADED",
+		8: "INSTANCE_EVENT_TYPE_ATTACK_SIGNATURE_VERSION_CHANGED",
+	}
+	InstanceEvent_InstanceEventType_value = map[string]int32{
+		"INSTANCE_EVENT_TYPE_UNSPECIFIED":                      0,
+		"INSTANCE_EVENT_TYPE_STARTED":                          1,
+		"INSTANCE_EVENT_TYPE_STOPPED":                          2,
+		"INSTANCE_EVENT_TYPE_PROCESS_ID_CHANGED":               3,
+		"INSTANCE_EVENT_TYPE_VERSION_CHANGED":                  4,
+		"INSTANCE_EVENT_TYPE_MODULES_CHANGED":                  5,
+		"INSTANCE_EVENT_TYPE_WORKER_COUNT_CHANGED":             6,
+		"INSTANCE_EVENT_TYPE_CONFIG_RELOADED":                  7,
+		"INSTANCE_EVENT_TYPE_ATTACK_SIGNATURE_VERSION_CHANGED": 8,
+	}
+)
+
+func (x InstanceEvent_InstanceEventType) Enum() *InstanceEvent_InstanceEventType {
+	p := new(InstanceEvent_InstanceEventType)
+	*p = x
+	return p
+}
+
+func (x InstanceEvent_InstanceEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InstanceEvent_InstanceEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_mpi_v1_command_proto_enumTypes[2].Descriptor()
+}
+
+func (InstanceEvent_InstanceEventType) Type() protoreflect.EnumType {
+	return &file_mpi_v1_command_proto_enumTypes[2]
+}
+
+func (x InstanceEvent_InstanceEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InstanceEvent_InstanceEventType.Descriptor instead.
+func (InstanceEvent_InstanceEventType) EnumDescriptor() ([]byte, []int) {
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{13, 0}
+}
+
 // the types of instances possible
 type InstanceMeta_InstanceType int32
 
@@ -201,11 +292,11 @@ func (x InstanceMeta_InstanceType) String() string {
 }
 
 func (InstanceMeta_InstanceType) Descriptor() protoreflect.EnumDescriptor {
-	return file_mpi_v1_command_proto_enumTypes[2].Descriptor()
+	return file_mpi_v1_command_proto_enumTypes[3].Descriptor()
 }
 
 func (InstanceMeta_InstanceType) Type() protoreflect.EnumType {
-	return &file_mpi_v1_command_proto_enumTypes[2]
+	return &file_mpi_v1_command_proto_enumTypes[3]
 }
 
 func (x InstanceMeta_InstanceType) Number() protoreflect.EnumNumber {
@@ -214,7 +305,7 @@ func (x InstanceMeta_InstanceType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use InstanceMeta_InstanceType.Descriptor instead.
 func (InstanceMeta_InstanceType) EnumDescriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{27, 0}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{34, 0}
 }
 
 type Log_LogLevel int32
@@ -257,11 +348,11 @@ func (x Log_LogLevel) String() string {
 }
 
 func (Log_LogLevel) Descriptor() protoreflect.EnumDescriptor {
-	return file_mpi_v1_command_proto_enumTypes[3].Descriptor()
+	return file_mpi_v1_command_proto_enumTypes[4].Descriptor()
 }
 
 func (Log_LogLevel) Type() protoreflect.EnumType {
-	return &file_mpi_v1_command_proto_enumTypes[3]
+	return &file_mpi_v1_command_proto_enumTypes[4]
 }
 
 func (x Log_LogLevel) Number() protoreflect.EnumNumber {
@@ -270,7 +361,7 @@ func (x Log_LogLevel) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Log_LogLevel.Descriptor instead.
 func (Log_LogLevel) EnumDescriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{37, 0}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{45, 0}
 }
 
 // The connection request is an initial handshake to establish a connection, sending NGINX Agent instance information
@@ -1008,7 +1099,23 @@ type DataPlaneResponse struct {
 	// The instance identifier, if applicable, for this response
 	InstanceId string `protobuf:"bytes,3,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
 	// The management plane request type that is being responded to
-	RequestType   DataPlaneResponse_RequestType `protobuf:"varint,4,opt,name=request_type,json=requestType,proto3,enum=mpi.v1.DataPlaneResponse_RequestType" json:"request_type,omitempty"`
+	RequestType DataPlaneResponse_RequestType `protobuf:"varint,4,opt,name=request_type,json=requestType,proto3,enum=mpi.v1.DataPlaneResponse_RequestType" json:"request_type,omitempty"`
+	// Acknowledges that the management plane request with this index, and every request before it, was processed
+	AckIndex int64 `protobuf:"varint,5,opt,name=ack_index,json=ackIndex,proto3" json:"ack_index,omitempty"`
+	// The result of the configuration test, only populated for responses to a ConfigValidateRequest
+	ConfigValidateResult *ConfigValidateResult `protobuf:"bytes,6,opt,name=config_validate_result,json=configValidateResult,proto3" json:"config_validate_result,omitempty"`
+	// The changes a config apply would make, only populated for responses to a ConfigDiffRequest
+	ConfigChangeSet *ConfigChangeSet `protobuf:"bytes,7,opt,name=config_change_set,json=configChangeSet,proto3" json:"config_change_set,omitempty"`
+	// The config versions stored by the agent, only populated for responses to a ConfigHistoryRequest
+	ConfigHistory *ConfigHistory `protobuf:"bytes,8,opt,name=config_history,json=configHistory,proto3" json:"config_history,omitempty"`
+	// The results of the hooks run during a config apply, only populated for responses to a ConfigApplyRequest
+	HookResults []*ConfigApplyHookResult `protobuf:"bytes,9,rep,name=hook_results,json=hookResults,proto3" json:"hook_results,omitempty"`
+	// The files rendered from templates, with the hash and size of the rendered contents,
+	// only populated for responses to a ConfigApplyRequest
+	RenderedFiles []*FileMeta `protobuf:"bytes,10,rep,name=rendered_files,json=renderedFiles,proto3" json:"rendered_files,omitempty"`
+	// A change to an instance detected by the agent, only populated for instance events that are not a response
+	// to a management plane request
+	InstanceEvent *InstanceEvent `protobuf:"bytes,11,opt,name=instance_event,json=instanceEvent,proto3" json:"instance_event,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1071,6 +1178,193 @@ func (x *DataPlaneResponse) GetRequestType() DataPlaneResponse_RequestType {
 	return DataPlaneResponse_UNSPECIFIED_REQUEST
 }
 
+func (x *DataPlaneResponse) GetAckIndex() int64 {
+	if x != nil {
+		return x.AckIndex
+	}
+	return 0
+}
+
+func (x *DataPlaneResponse) GetConfigValidateResult() *ConfigValidateResult {
+	if x != nil {
+		return x.ConfigValidateResult
+	}
+	return nil
+}
+
+func (x *DataPlaneResponse) GetConfigChangeSet() *ConfigChangeSet {
+	if x != nil {
+		return x.ConfigChangeSet
+	}
+	return nil
+}
+
+func (x *DataPlaneResponse) GetConfigHistory() *ConfigHistory {
+	if x != nil {
+		return x.ConfigHistory
+	}
+	return nil
+}
+
+func (x *DataPlaneResponse) GetHookResults() []*ConfigApplyHookResult {
+	if x != nil {
+		return x.HookResults
+	}
+	return nil
+}
+
+func (x *DataPlaneResponse) GetRenderedFiles() []*FileMeta {
+	if x != nil {
+		return x.RenderedFiles
+	}
+	return nil
+}
+
+func (x *DataPlaneResponse) GetInstanceEvent() *InstanceEvent {
+	if x != nil {
+		return x.InstanceEvent
+	}
+	return nil
+}
+
+// A change to an instance detected by the agent, used to build a timeline of the lifecycle of an instance
+type InstanceEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the identifier of the instance
+	InstanceId string `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	// the type of change
+	EventType InstanceEvent_InstanceEventType `protobuf:"varint,2,opt,name=event_type,json=eventType,proto3,enum=mpi.v1.InstanceEvent_InstanceEventType" json:"event_type,omitempty"`
+	// the time the change was detected
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// the values that changed, the before values of a started instance and the after values of a stopped
+	// instance are empty
+	Changes       []*InstanceEventChange `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceEvent) Reset() {
+	*x = InstanceEvent{}
+	mi := &file_mpi_v1_command_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceEvent) ProtoMessage() {}
+
+func (x *InstanceEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_command_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceEvent.ProtoReflect.Descriptor instead.
+func (*InstanceEvent) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *InstanceEvent) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *InstanceEvent) GetEventType() InstanceEvent_InstanceEventType {
+	if x != nil {
+		return x.EventType
+	}
+	return InstanceEvent_INSTANCE_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *InstanceEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *InstanceEvent) GetChanges() []*InstanceEventChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// A value of an instance before and after an instance event
+type InstanceEventChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the name of the value, e.g. process_id or version
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the value before the change
+	Before string `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	// the value after the change
+	After         string `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceEventChange) Reset() {
+	*x = InstanceEventChange{}
+	mi := &file_mpi_v1_command_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceEventChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceEventChange) ProtoMessage() {}
+
+func (x *InstanceEventChange) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_command_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceEventChange.ProtoReflect.Descriptor instead.
+func (*InstanceEventChange) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *InstanceEventChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InstanceEventChange) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *InstanceEventChange) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
 // A Management Plane request for information, triggers an associated rpc on the Data Plane
 type ManagementPlaneRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1085,6 +1379,9 @@ type ManagementPlaneRequest struct {
 	//	*ManagementPlaneRequest_ActionRequest
 	//	*ManagementPlaneRequest_CommandStatusRequest
 	//	*ManagementPlaneRequest_UpdateAgentConfigRequest
+	//	*ManagementPlaneRequest_ConfigValidateRequest
+	//	*ManagementPlaneRequest_ConfigDiffRequest
+	//	*ManagementPlaneRequest_ConfigHistoryRequest
 	Request       isManagementPlaneRequest_Request `protobuf_oneof:"request"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -1092,7 +1389,7 @@ type ManagementPlaneRequest struct {
 
 func (x *ManagementPlaneRequest) Reset() {
 	*x = ManagementPlaneRequest{}
-	mi := &file_mpi_v1_command_proto_msgTypes[13]
+	mi := &file_mpi_v1_command_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1104,7 +1401,7 @@ func (x *ManagementPlaneRequest) String() string {
 func (*ManagementPlaneRequest) ProtoMessage() {}
 
 func (x *ManagementPlaneRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[13]
+	mi := &file_mpi_v1_command_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1117,7 +1414,7 @@ func (x *ManagementPlaneRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ManagementPlaneRequest.ProtoReflect.Descriptor instead.
 func (*ManagementPlaneRequest) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{13}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ManagementPlaneRequest) GetMessageMeta() *MessageMeta {
@@ -1197,6 +1494,33 @@ func (x *ManagementPlaneRequest) GetUpdateAgentConfigRequest() *UpdateAgentConfi
 	return nil
 }
 
+func (x *ManagementPlaneRequest) GetConfigValidateRequest() *ConfigValidateRequest {
+	if x != nil {
+		if x, ok := x.Request.(*ManagementPlaneRequest_ConfigValidateRequest); ok {
+			return x.ConfigValidateRequest
+		}
+	}
+	return nil
+}
+
+func (x *ManagementPlaneRequest) GetConfigDiffRequest() *ConfigDiffRequest {
+	if x != nil {
+		if x, ok := x.Request.(*ManagementPlaneRequest_ConfigDiffRequest); ok {
+			return x.ConfigDiffRequest
+		}
+	}
+	return nil
+}
+
+func (x *ManagementPlaneRequest) GetConfigHistoryRequest() *ConfigHistoryRequest {
+	if x != nil {
+		if x, ok := x.Request.(*ManagementPlaneRequest_ConfigHistoryRequest); ok {
+			return x.ConfigHistoryRequest
+		}
+	}
+	return nil
+}
+
 type isManagementPlaneRequest_Request interface {
 	isManagementPlaneRequest_Request()
 }
@@ -1237,6 +1561,24 @@ type ManagementPlaneRequest_UpdateAgentConfigRequest struct {
 	UpdateAgentConfigRequest *UpdateAgentConfigRequest `protobuf:"bytes,9,opt,name=update_agent_config_request,json=updateAgentConfigRequest,proto3,oneof"`
 }
 
+type ManagementPlaneRequest_ConfigValidateRequest struct {
+	// triggers a rpc GetFile(FileRequest) for overview list into a staging directory and a configuration test,
+	// the files on disk are not changed
+	ConfigValidateRequest *ConfigValidateRequest `protobuf:"bytes,10,opt,name=config_validate_request,json=configValidateRequest,proto3,oneof"`
+}
+
+type ManagementPlaneRequest_ConfigDiffRequest struct {
+	// triggers a DataPlaneResponse with the changes a config apply of the overview would make,
+	// the files on disk are not changed
+	ConfigDiffRequest *ConfigDiffRequest `protobuf:"bytes,11,opt,name=config_diff_request,json=configDiffRequest,proto3,oneof"`
+}
+
+type ManagementPlaneRequest_ConfigHistoryRequest struct {
+	// triggers a DataPlaneResponse with the config versions stored by the agent,
+	// or a config apply of a stored config version if a rollback version is set
+	ConfigHistoryRequest *ConfigHistoryRequest `protobuf:"bytes,12,opt,name=config_history_request,json=configHistoryRequest,proto3,oneof"`
+}
+
 func (*ManagementPlaneRequest_StatusRequest) isManagementPlaneRequest_Request() {}
 
 func (*ManagementPlaneRequest_HealthRequest) isManagementPlaneRequest_Request() {}
@@ -1251,6 +1593,12 @@ func (*ManagementPlaneRequest_CommandStatusRequest) isManagementPlaneRequest_Req
 
 func (*ManagementPlaneRequest_UpdateAgentConfigRequest) isManagementPlaneRequest_Request() {}
 
+func (*ManagementPlaneRequest_ConfigValidateRequest) isManagementPlaneRequest_Request() {}
+
+func (*ManagementPlaneRequest_ConfigDiffRequest) isManagementPlaneRequest_Request() {}
+
+func (*ManagementPlaneRequest_ConfigHistoryRequest) isManagementPlaneRequest_Request() {}
+
 // Additional information associated with a StatusRequest
 type StatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1260,7 +1608,7 @@ type StatusRequest struct {
 
 func (x *StatusRequest) Reset() {
 	*x = StatusRequest{}
-	mi := &file_mpi_v1_command_proto_msgTypes[14]
+	mi := &file_mpi_v1_command_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1272,7 +1620,7 @@ func (x *StatusRequest) String() string {
 func (*StatusRequest) ProtoMessage() {}
 
 func (x *StatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[14]
+	mi := &file_mpi_v1_command_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1285,7 +1633,7 @@ func (x *StatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
 func (*StatusRequest) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{14}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{16}
 }
 
 // Additional information associated with a HealthRequest
@@ -1297,19 +1645,262 @@ type HealthRequest struct {
 
 func (x *HealthRequest) Reset() {
 	*x = HealthRequest{}
-	mi := &file_mpi_v1_command_proto_msgTypes[15]
+	mi := &file_mpi_v1_command_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthRequest) ProtoMessage() {}
+
+func (x *HealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_command_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{17}
+}
+
+// Additional information associated with a ConfigApplyRequest
+type ConfigApplyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// set of files related to the request
+	Overview      *FileOverview `protobuf:"bytes,1,opt,name=overview,proto3" json:"overview,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigApplyRequest) Reset() {
+	*x = ConfigApplyRequest{}
+	mi := &file_mpi_v1_command_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigApplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigApplyRequest) ProtoMessage() {}
+
+func (x *ConfigApplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_command_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigApplyRequest.ProtoReflect.Descriptor instead.
+func (*ConfigApplyRequest) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ConfigApplyRequest) GetOverview() *FileOverview {
+	if x != nil {
+		return x.Overview
+	}
+	return nil
+}
+
+// Additional information associated with a ConfigValidateRequest
+type ConfigValidateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// set of files related to the request
+	Overview      *FileOverview `protobuf:"bytes,1,opt,name=overview,proto3" json:"overview,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigValidateRequest) Reset() {
+	*x = ConfigValidateRequest{}
+	mi := &file_mpi_v1_command_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigValidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigValidateRequest) ProtoMessage() {}
+
+func (x *ConfigValidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_command_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigValidateRequest.ProtoReflect.Descriptor instead.
+func (*ConfigValidateRequest) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ConfigValidateRequest) GetOverview() *FileOverview {
+	if x != nil {
+		return x.Overview
+	}
+	return nil
+}
+
+// Additional information associated with a ConfigDiffRequest
+type ConfigDiffRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// set of files related to the request
+	Overview *FileOverview `protobuf:"bytes,1,opt,name=overview,proto3" json:"overview,omitempty"`
+	// include a unified diff for text files that are under the size limit
+	IncludeTextDiff bool `protobuf:"varint,2,opt,name=include_text_diff,json=includeTextDiff,proto3" json:"include_text_diff,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ConfigDiffRequest) Reset() {
+	*x = ConfigDiffRequest{}
+	mi := &file_mpi_v1_command_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigDiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigDiffRequest) ProtoMessage() {}
+
+func (x *ConfigDiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_command_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigDiffRequest.ProtoReflect.Descriptor instead.
+func (*ConfigDiffRequest) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ConfigDiffRequest) GetOverview() *FileOverview {
+	if x != nil {
+		return x.Overview
+	}
+	return nil
+}
+
+func (x *ConfigDiffRequest) GetIncludeTextDiff() bool {
+	if x != nil {
+		return x.IncludeTextDiff
+	}
+	return false
+}
+
+// Additional information associated with a ConfigHistoryRequest
+type ConfigHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the id of the instance
+	InstanceId string `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	// the version to roll back to, if empty the stored config versions are listed and nothing is changed
+	RollbackVersion string `protobuf:"bytes,2,opt,name=rollback_version,json=rollbackVersion,proto3" json:"rollback_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ConfigHistoryRequest) Reset() {
+	*x = ConfigHistoryRequest{}
+	mi := &file_mpi_v1_command_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigHistoryRequest) ProtoMessage() {}
+
+func (x *ConfigHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_command_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ConfigHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ConfigHistoryRequest) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *ConfigHistoryRequest) GetRollbackVersion() string {
+	if x != nil {
+		return x.RollbackVersion
+	}
+	return ""
+}
+
+// The result of a configuration test performed for a ConfigValidateRequest
+type ConfigValidateResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the full output of the configuration test
+	Output string `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
+	// the warnings reported by the configuration test
+	Warnings      []string `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigValidateResult) Reset() {
+	*x = ConfigValidateResult{}
+	mi := &file_mpi_v1_command_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HealthRequest) String() string {
+func (x *ConfigValidateResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthRequest) ProtoMessage() {}
+func (*ConfigValidateResult) ProtoMessage() {}
 
-func (x *HealthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[15]
+func (x *ConfigValidateResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_command_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1320,35 +1911,56 @@ func (x *HealthRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
-func (*HealthRequest) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ConfigValidateResult.ProtoReflect.Descriptor instead.
+func (*ConfigValidateResult) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{22}
 }
 
-// Additional information associated with a ConfigApplyRequest
-type ConfigApplyRequest struct {
+func (x *ConfigValidateResult) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *ConfigValidateResult) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+// The result of a hook run during a config apply
+type ConfigApplyHookResult struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// set of files related to the request
-	Overview      *FileOverview `protobuf:"bytes,1,opt,name=overview,proto3" json:"overview,omitempty"`
+	// the phase of the config apply the hook was run at, one of pre_validate, pre_reload, post_reload
+	// or post_rollback
+	Phase string `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"`
+	// the path of the hook executable
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// the exit code of the hook, -1 if the hook could not be run or was stopped after its timeout
+	ExitCode int32 `protobuf:"varint,3,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// the combined standard output and standard error of the hook, truncated
+	Output        string `protobuf:"bytes,4,opt,name=output,proto3" json:"output,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConfigApplyRequest) Reset() {
-	*x = ConfigApplyRequest{}
-	mi := &file_mpi_v1_command_proto_msgTypes[16]
+func (x *ConfigApplyHookResult) Reset() {
+	*x = ConfigApplyHookResult{}
+	mi := &file_mpi_v1_command_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConfigApplyRequest) String() string {
+func (x *ConfigApplyHookResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfigApplyRequest) ProtoMessage() {}
+func (*ConfigApplyHookResult) ProtoMessage() {}
 
-func (x *ConfigApplyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[16]
+func (x *ConfigApplyHookResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_command_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1359,16 +1971,37 @@ func (x *ConfigApplyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfigApplyRequest.ProtoReflect.Descriptor instead.
-func (*ConfigApplyRequest) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use ConfigApplyHookResult.ProtoReflect.Descriptor instead.
+func (*ConfigApplyHookResult) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *ConfigApplyRequest) GetOverview() *FileOverview {
+func (x *ConfigApplyHookResult) GetPhase() string {
 	if x != nil {
-		return x.Overview
+		return x.Phase
 	}
-	return nil
+	return ""
+}
+
+func (x *ConfigApplyHookResult) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ConfigApplyHookResult) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *ConfigApplyHookResult) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
 }
 
 // Additional information associated with a ConfigUploadRequest
@@ -1382,7 +2015,7 @@ type ConfigUploadRequest struct {
 
 func (x *ConfigUploadRequest) Reset() {
 	*x = ConfigUploadRequest{}
-	mi := &file_mpi_v1_command_proto_msgTypes[17]
+	mi := &file_mpi_v1_command_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1394,7 +2027,7 @@ func (x *ConfigUploadRequest) String() string {
 func (*ConfigUploadRequest) ProtoMessage() {}
 
 func (x *ConfigUploadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[17]
+	mi := &file_mpi_v1_command_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1407,7 +2040,7 @@ func (x *ConfigUploadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigUploadRequest.ProtoReflect.Descriptor instead.
 func (*ConfigUploadRequest) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{17}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ConfigUploadRequest) GetOverview() *FileOverview {
@@ -1434,7 +2067,7 @@ type APIActionRequest struct {
 
 func (x *APIActionRequest) Reset() {
 	*x = APIActionRequest{}
-	mi := &file_mpi_v1_command_proto_msgTypes[18]
+	mi := &file_mpi_v1_command_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1446,7 +2079,7 @@ func (x *APIActionRequest) String() string {
 func (*APIActionRequest) ProtoMessage() {}
 
 func (x *APIActionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[18]
+	mi := &file_mpi_v1_command_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1459,7 +2092,7 @@ func (x *APIActionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use APIActionRequest.ProtoReflect.Descriptor instead.
 func (*APIActionRequest) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{18}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *APIActionRequest) GetInstanceId() string {
@@ -1514,7 +2147,7 @@ type NGINXPlusAction struct {
 
 func (x *NGINXPlusAction) Reset() {
 	*x = NGINXPlusAction{}
-	mi := &file_mpi_v1_command_proto_msgTypes[19]
+	mi := &file_mpi_v1_command_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1526,7 +2159,7 @@ func (x *NGINXPlusAction) String() string {
 func (*NGINXPlusAction) ProtoMessage() {}
 
 func (x *NGINXPlusAction) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[19]
+	mi := &file_mpi_v1_command_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1539,7 +2172,7 @@ func (x *NGINXPlusAction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NGINXPlusAction.ProtoReflect.Descriptor instead.
 func (*NGINXPlusAction) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{19}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *NGINXPlusAction) GetAction() isNGINXPlusAction_Action {
@@ -1641,7 +2274,7 @@ type UpdateHTTPUpstreamServers struct {
 
 func (x *UpdateHTTPUpstreamServers) Reset() {
 	*x = UpdateHTTPUpstreamServers{}
-	mi := &file_mpi_v1_command_proto_msgTypes[20]
+	mi := &file_mpi_v1_command_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1653,7 +2286,7 @@ func (x *UpdateHTTPUpstreamServers) String() string {
 func (*UpdateHTTPUpstreamServers) ProtoMessage() {}
 
 func (x *UpdateHTTPUpstreamServers) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[20]
+	mi := &file_mpi_v1_command_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1666,7 +2299,7 @@ func (x *UpdateHTTPUpstreamServers) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateHTTPUpstreamServers.ProtoReflect.Descriptor instead.
 func (*UpdateHTTPUpstreamServers) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{20}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *UpdateHTTPUpstreamServers) GetHttpUpstreamName() string {
@@ -1694,7 +2327,7 @@ type GetHTTPUpstreamServers struct {
 
 func (x *GetHTTPUpstreamServers) Reset() {
 	*x = GetHTTPUpstreamServers{}
-	mi := &file_mpi_v1_command_proto_msgTypes[21]
+	mi := &file_mpi_v1_command_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1706,7 +2339,7 @@ func (x *GetHTTPUpstreamServers) String() string {
 func (*GetHTTPUpstreamServers) ProtoMessage() {}
 
 func (x *GetHTTPUpstreamServers) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[21]
+	mi := &file_mpi_v1_command_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1719,7 +2352,7 @@ func (x *GetHTTPUpstreamServers) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetHTTPUpstreamServers.ProtoReflect.Descriptor instead.
 func (*GetHTTPUpstreamServers) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{21}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *GetHTTPUpstreamServers) GetHttpUpstreamName() string {
@@ -1742,7 +2375,7 @@ type UpdateStreamServers struct {
 
 func (x *UpdateStreamServers) Reset() {
 	*x = UpdateStreamServers{}
-	mi := &file_mpi_v1_command_proto_msgTypes[22]
+	mi := &file_mpi_v1_command_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1754,7 +2387,7 @@ func (x *UpdateStreamServers) String() string {
 func (*UpdateStreamServers) ProtoMessage() {}
 
 func (x *UpdateStreamServers) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[22]
+	mi := &file_mpi_v1_command_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1767,7 +2400,7 @@ func (x *UpdateStreamServers) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateStreamServers.ProtoReflect.Descriptor instead.
 func (*UpdateStreamServers) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{22}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *UpdateStreamServers) GetUpstreamStreamName() string {
@@ -1793,7 +2426,7 @@ type GetUpstreams struct {
 
 func (x *GetUpstreams) Reset() {
 	*x = GetUpstreams{}
-	mi := &file_mpi_v1_command_proto_msgTypes[23]
+	mi := &file_mpi_v1_command_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1805,7 +2438,7 @@ func (x *GetUpstreams) String() string {
 func (*GetUpstreams) ProtoMessage() {}
 
 func (x *GetUpstreams) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[23]
+	mi := &file_mpi_v1_command_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1818,7 +2451,7 @@ func (x *GetUpstreams) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUpstreams.ProtoReflect.Descriptor instead.
 func (*GetUpstreams) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{23}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{30}
 }
 
 // Get Stream Upstream Servers for an instance
@@ -1830,7 +2463,7 @@ type GetStreamUpstreams struct {
 
 func (x *GetStreamUpstreams) Reset() {
 	*x = GetStreamUpstreams{}
-	mi := &file_mpi_v1_command_proto_msgTypes[24]
+	mi := &file_mpi_v1_command_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1842,7 +2475,7 @@ func (x *GetStreamUpstreams) String() string {
 func (*GetStreamUpstreams) ProtoMessage() {}
 
 func (x *GetStreamUpstreams) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[24]
+	mi := &file_mpi_v1_command_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1855,19 +2488,21 @@ func (x *GetStreamUpstreams) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetStreamUpstreams.ProtoReflect.Descriptor instead.
 func (*GetStreamUpstreams) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{24}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{31}
 }
 
 // Request an update on a particular command
 type CommandStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The correlation ID of the command to report on. If empty, the correlation ID of the request message meta is used
+	CorrelationId string `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CommandStatusRequest) Reset() {
 	*x = CommandStatusRequest{}
-	mi := &file_mpi_v1_command_proto_msgTypes[25]
+	mi := &file_mpi_v1_command_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1879,7 +2514,7 @@ func (x *CommandStatusRequest) String() string {
 func (*CommandStatusRequest) ProtoMessage() {}
 
 func (x *CommandStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[25]
+	mi := &file_mpi_v1_command_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1892,7 +2527,14 @@ func (x *CommandStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommandStatusRequest.ProtoReflect.Descriptor instead.
 func (*CommandStatusRequest) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{25}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CommandStatusRequest) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
 }
 
 // This represents an instance being reported on
@@ -1910,7 +2552,7 @@ type Instance struct {
 
 func (x *Instance) Reset() {
 	*x = Instance{}
-	mi := &file_mpi_v1_command_proto_msgTypes[26]
+	mi := &file_mpi_v1_command_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1922,7 +2564,7 @@ func (x *Instance) String() string {
 func (*Instance) ProtoMessage() {}
 
 func (x *Instance) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[26]
+	mi := &file_mpi_v1_command_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1935,7 +2577,7 @@ func (x *Instance) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Instance.ProtoReflect.Descriptor instead.
 func (*Instance) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{26}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *Instance) GetInstanceMeta() *InstanceMeta {
@@ -1974,7 +2616,7 @@ type InstanceMeta struct {
 
 func (x *InstanceMeta) Reset() {
 	*x = InstanceMeta{}
-	mi := &file_mpi_v1_command_proto_msgTypes[27]
+	mi := &file_mpi_v1_command_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1986,7 +2628,7 @@ func (x *InstanceMeta) String() string {
 func (*InstanceMeta) ProtoMessage() {}
 
 func (x *InstanceMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[27]
+	mi := &file_mpi_v1_command_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1999,7 +2641,7 @@ func (x *InstanceMeta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceMeta.ProtoReflect.Descriptor instead.
 func (*InstanceMeta) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{27}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *InstanceMeta) GetInstanceId() string {
@@ -2038,7 +2680,7 @@ type InstanceConfig struct {
 
 func (x *InstanceConfig) Reset() {
 	*x = InstanceConfig{}
-	mi := &file_mpi_v1_command_proto_msgTypes[28]
+	mi := &file_mpi_v1_command_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2050,7 +2692,7 @@ func (x *InstanceConfig) String() string {
 func (*InstanceConfig) ProtoMessage() {}
 
 func (x *InstanceConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[28]
+	mi := &file_mpi_v1_command_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2063,7 +2705,7 @@ func (x *InstanceConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceConfig.ProtoReflect.Descriptor instead.
 func (*InstanceConfig) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{28}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *InstanceConfig) GetActions() []*InstanceAction {
@@ -2115,16 +2757,20 @@ type InstanceRuntime struct {
 	//	*InstanceRuntime_NginxRuntimeInfo
 	//	*InstanceRuntime_NginxPlusRuntimeInfo
 	//	*InstanceRuntime_NginxAppProtectRuntimeInfo
+	//	*InstanceRuntime_UnitRuntimeInfo
 	Details isInstanceRuntime_Details `protobuf_oneof:"details"`
 	// List of worker processes
 	InstanceChildren []*InstanceChild `protobuf:"bytes,6,rep,name=instance_children,json=instanceChildren,proto3" json:"instance_children,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// the ID of the container the instance runs in, set if the instance runs in another container than the agent
+	// and shares its PID namespace with the agent
+	ContainerId   string `protobuf:"bytes,9,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *InstanceRuntime) Reset() {
 	*x = InstanceRuntime{}
-	mi := &file_mpi_v1_command_proto_msgTypes[29]
+	mi := &file_mpi_v1_command_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2136,7 +2782,7 @@ func (x *InstanceRuntime) String() string {
 func (*InstanceRuntime) ProtoMessage() {}
 
 func (x *InstanceRuntime) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[29]
+	mi := &file_mpi_v1_command_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2149,7 +2795,7 @@ func (x *InstanceRuntime) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceRuntime.ProtoReflect.Descriptor instead.
 func (*InstanceRuntime) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{29}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *InstanceRuntime) GetProcessId() int32 {
@@ -2207,6 +2853,15 @@ func (x *InstanceRuntime) GetNginxAppProtectRuntimeInfo() *NGINXAppProtectRuntim
 	return nil
 }
 
+func (x *InstanceRuntime) GetUnitRuntimeInfo() *UnitRuntimeInfo {
+	if x != nil {
+		if x, ok := x.Details.(*InstanceRuntime_UnitRuntimeInfo); ok {
+			return x.UnitRuntimeInfo
+		}
+	}
+	return nil
+}
+
 func (x *InstanceRuntime) GetInstanceChildren() []*InstanceChild {
 	if x != nil {
 		return x.InstanceChildren
@@ -2214,6 +2869,13 @@ func (x *InstanceRuntime) GetInstanceChildren() []*InstanceChild {
 	return nil
 }
 
+func (x *InstanceRuntime) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
 type isInstanceRuntime_Details interface {
 	isInstanceRuntime_Details()
 }
@@ -2233,12 +2895,19 @@ type InstanceRuntime_NginxAppProtectRuntimeInfo struct {
 	NginxAppProtectRuntimeInfo *NGINXAppProtectRuntimeInfo `protobuf:"bytes,7,opt,name=nginx_app_protect_runtime_info,json=nginxAppProtectRuntimeInfo,proto3,oneof"`
 }
 
+type InstanceRuntime_UnitRuntimeInfo struct {
+	// NGINX Unit runtime information, read from the NGINX Unit process
+	UnitRuntimeInfo *UnitRuntimeInfo `protobuf:"bytes,8,opt,name=unit_runtime_info,json=unitRuntimeInfo,proto3,oneof"`
+}
+
 func (*InstanceRuntime_NginxRuntimeInfo) isInstanceRuntime_Details() {}
 
 func (*InstanceRuntime_NginxPlusRuntimeInfo) isInstanceRuntime_Details() {}
 
 func (*InstanceRuntime_NginxAppProtectRuntimeInfo) isInstanceRuntime_Details() {}
 
+func (*InstanceRuntime_UnitRuntimeInfo) isInstanceRuntime_Details() {}
+
 type InstanceChild struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// the process identifier
@@ -2249,7 +2918,7 @@ type InstanceChild struct {
 
 func (x *InstanceChild) Reset() {
 	*x = InstanceChild{}
-	mi := &file_mpi_v1_command_proto_msgTypes[30]
+	mi := &file_mpi_v1_command_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2261,7 +2930,7 @@ func (x *InstanceChild) String() string {
 func (*InstanceChild) ProtoMessage() {}
 
 func (x *InstanceChild) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[30]
+	mi := &file_mpi_v1_command_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2274,7 +2943,7 @@ func (x *InstanceChild) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceChild.ProtoReflect.Descriptor instead.
 func (*InstanceChild) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{30}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *InstanceChild) GetProcessId() int32 {
@@ -2303,7 +2972,7 @@ type NGINXRuntimeInfo struct {
 
 func (x *NGINXRuntimeInfo) Reset() {
 	*x = NGINXRuntimeInfo{}
-	mi := &file_mpi_v1_command_proto_msgTypes[31]
+	mi := &file_mpi_v1_command_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2315,7 +2984,7 @@ func (x *NGINXRuntimeInfo) String() string {
 func (*NGINXRuntimeInfo) ProtoMessage() {}
 
 func (x *NGINXRuntimeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[31]
+	mi := &file_mpi_v1_command_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2328,7 +2997,7 @@ func (x *NGINXRuntimeInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NGINXRuntimeInfo.ProtoReflect.Descriptor instead.
 func (*NGINXRuntimeInfo) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{31}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *NGINXRuntimeInfo) GetStubStatus() *APIDetails {
@@ -2387,7 +3056,7 @@ type NGINXPlusRuntimeInfo struct {
 
 func (x *NGINXPlusRuntimeInfo) Reset() {
 	*x = NGINXPlusRuntimeInfo{}
-	mi := &file_mpi_v1_command_proto_msgTypes[32]
+	mi := &file_mpi_v1_command_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2399,7 +3068,7 @@ func (x *NGINXPlusRuntimeInfo) String() string {
 func (*NGINXPlusRuntimeInfo) ProtoMessage() {}
 
 func (x *NGINXPlusRuntimeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[32]
+	mi := &file_mpi_v1_command_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2412,7 +3081,7 @@ func (x *NGINXPlusRuntimeInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NGINXPlusRuntimeInfo.ProtoReflect.Descriptor instead.
 func (*NGINXPlusRuntimeInfo) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{32}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *NGINXPlusRuntimeInfo) GetStubStatus() *APIDetails {
@@ -2471,7 +3140,7 @@ type APIDetails struct {
 
 func (x *APIDetails) Reset() {
 	*x = APIDetails{}
-	mi := &file_mpi_v1_command_proto_msgTypes[33]
+	mi := &file_mpi_v1_command_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2483,7 +3152,7 @@ func (x *APIDetails) String() string {
 func (*APIDetails) ProtoMessage() {}
 
 func (x *APIDetails) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[33]
+	mi := &file_mpi_v1_command_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2496,7 +3165,7 @@ func (x *APIDetails) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use APIDetails.ProtoReflect.Descriptor instead.
 func (*APIDetails) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{33}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *APIDetails) GetLocation() string {
@@ -2537,7 +3206,7 @@ type NGINXAppProtectRuntimeInfo struct {
 
 func (x *NGINXAppProtectRuntimeInfo) Reset() {
 	*x = NGINXAppProtectRuntimeInfo{}
-	mi := &file_mpi_v1_command_proto_msgTypes[34]
+	mi := &file_mpi_v1_command_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2549,7 +3218,7 @@ func (x *NGINXAppProtectRuntimeInfo) String() string {
 func (*NGINXAppProtectRuntimeInfo) ProtoMessage() {}
 
 func (x *NGINXAppProtectRuntimeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[34]
+	mi := &file_mpi_v1_command_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2562,7 +3231,7 @@ func (x *NGINXAppProtectRuntimeInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NGINXAppProtectRuntimeInfo.ProtoReflect.Descriptor instead.
 func (*NGINXAppProtectRuntimeInfo) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{34}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *NGINXAppProtectRuntimeInfo) GetRelease() string {
@@ -2593,6 +3262,70 @@ func (x *NGINXAppProtectRuntimeInfo) GetEnforcerEngineVersion() string {
 	return ""
 }
 
+// A set of runtime NGINX Unit settings
+type UnitRuntimeInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the control API socket, e.g. unix:/var/run/control.unit.sock
+	ControlSocket string `protobuf:"bytes,1,opt,name=control_socket,json=controlSocket,proto3" json:"control_socket,omitempty"`
+	// NGINX Unit version
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// List of NGINX Unit language modules
+	Modules       []string `protobuf:"bytes,3,rep,name=modules,proto3" json:"modules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnitRuntimeInfo) Reset() {
+	*x = UnitRuntimeInfo{}
+	mi := &file_mpi_v1_command_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnitRuntimeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnitRuntimeInfo) ProtoMessage() {}
+
+func (x *UnitRuntimeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_command_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnitRuntimeInfo.ProtoReflect.Descriptor instead.
+func (*UnitRuntimeInfo) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *UnitRuntimeInfo) GetControlSocket() string {
+	if x != nil {
+		return x.ControlSocket
+	}
+	return ""
+}
+
+func (x *UnitRuntimeInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *UnitRuntimeInfo) GetModules() []string {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
 // A set of actions that can be performed on an instance
 type InstanceAction struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2602,7 +3335,7 @@ type InstanceAction struct {
 
 func (x *InstanceAction) Reset() {
 	*x = InstanceAction{}
-	mi := &file_mpi_v1_command_proto_msgTypes[35]
+	mi := &file_mpi_v1_command_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2614,7 +3347,7 @@ func (x *InstanceAction) String() string {
 func (*InstanceAction) ProtoMessage() {}
 
 func (x *InstanceAction) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[35]
+	mi := &file_mpi_v1_command_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2627,7 +3360,7 @@ func (x *InstanceAction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstanceAction.ProtoReflect.Descriptor instead.
 func (*InstanceAction) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{35}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{43}
 }
 
 // This contains a series of NGINX Agent configurations
@@ -2655,7 +3388,7 @@ type AgentConfig struct {
 
 func (x *AgentConfig) Reset() {
 	*x = AgentConfig{}
-	mi := &file_mpi_v1_command_proto_msgTypes[36]
+	mi := &file_mpi_v1_command_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2667,7 +3400,7 @@ func (x *AgentConfig) String() string {
 func (*AgentConfig) ProtoMessage() {}
 
 func (x *AgentConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[36]
+	mi := &file_mpi_v1_command_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2680,7 +3413,7 @@ func (x *AgentConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentConfig.ProtoReflect.Descriptor instead.
 func (*AgentConfig) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{36}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *AgentConfig) GetCommand() *CommandServer {
@@ -2750,7 +3483,7 @@ type Log struct {
 
 func (x *Log) Reset() {
 	*x = Log{}
-	mi := &file_mpi_v1_command_proto_msgTypes[37]
+	mi := &file_mpi_v1_command_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2762,7 +3495,7 @@ func (x *Log) String() string {
 func (*Log) ProtoMessage() {}
 
 func (x *Log) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[37]
+	mi := &file_mpi_v1_command_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2775,7 +3508,7 @@ func (x *Log) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Log.ProtoReflect.Descriptor instead.
 func (*Log) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{37}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *Log) GetLogLevel() Log_LogLevel {
@@ -2807,7 +3540,7 @@ type CommandServer struct {
 
 func (x *CommandServer) Reset() {
 	*x = CommandServer{}
-	mi := &file_mpi_v1_command_proto_msgTypes[38]
+	mi := &file_mpi_v1_command_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2819,7 +3552,7 @@ func (x *CommandServer) String() string {
 func (*CommandServer) ProtoMessage() {}
 
 func (x *CommandServer) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[38]
+	mi := &file_mpi_v1_command_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2832,7 +3565,7 @@ func (x *CommandServer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommandServer.ProtoReflect.Descriptor instead.
 func (*CommandServer) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{38}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *CommandServer) GetServer() *ServerSettings {
@@ -2871,7 +3604,7 @@ type AuxiliaryCommandServer struct {
 
 func (x *AuxiliaryCommandServer) Reset() {
 	*x = AuxiliaryCommandServer{}
-	mi := &file_mpi_v1_command_proto_msgTypes[39]
+	mi := &file_mpi_v1_command_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2883,7 +3616,7 @@ func (x *AuxiliaryCommandServer) String() string {
 func (*AuxiliaryCommandServer) ProtoMessage() {}
 
 func (x *AuxiliaryCommandServer) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[39]
+	mi := &file_mpi_v1_command_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2896,7 +3629,7 @@ func (x *AuxiliaryCommandServer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuxiliaryCommandServer.ProtoReflect.Descriptor instead.
 func (*AuxiliaryCommandServer) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{39}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *AuxiliaryCommandServer) GetServer() *ServerSettings {
@@ -2929,7 +3662,7 @@ type MetricsServer struct {
 
 func (x *MetricsServer) Reset() {
 	*x = MetricsServer{}
-	mi := &file_mpi_v1_command_proto_msgTypes[40]
+	mi := &file_mpi_v1_command_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2941,7 +3674,7 @@ func (x *MetricsServer) String() string {
 func (*MetricsServer) ProtoMessage() {}
 
 func (x *MetricsServer) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[40]
+	mi := &file_mpi_v1_command_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2954,7 +3687,7 @@ func (x *MetricsServer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsServer.ProtoReflect.Descriptor instead.
 func (*MetricsServer) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{40}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{48}
 }
 
 // The file settings associated with file server for configurations
@@ -2966,7 +3699,7 @@ type FileServer struct {
 
 func (x *FileServer) Reset() {
 	*x = FileServer{}
-	mi := &file_mpi_v1_command_proto_msgTypes[41]
+	mi := &file_mpi_v1_command_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2978,7 +3711,7 @@ func (x *FileServer) String() string {
 func (*FileServer) ProtoMessage() {}
 
 func (x *FileServer) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_command_proto_msgTypes[41]
+	mi := &file_mpi_v1_command_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2991,14 +3724,14 @@ func (x *FileServer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileServer.ProtoReflect.Descriptor instead.
 func (*FileServer) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_command_proto_rawDescGZIP(), []int{41}
+	return file_mpi_v1_command_proto_rawDescGZIP(), []int{49}
 }
 
 var File_mpi_v1_command_proto protoreflect.FileDescriptor
 
 const file_mpi_v1_command_proto_rawDesc = "" +
 	"\n" +
-	"\x14mpi/v1/command.proto\x12\x06mpi.v1\x1a\x13mpi/v1/common.proto\x1a\x12mpi/v1/files.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1bbuf/validate/validate.proto\"\x7f\n" +
+	"\x14mpi/v1/command.proto\x12\x06mpi.v1\x1a\x13mpi/v1/common.proto\x1a\x12mpi/v1/files.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bbuf/validate/validate.proto\"\x7f\n" +
 	"\x17CreateConnectionRequest\x126\n" +
 	"\fmessage_meta\x18\x01 \x01(\v2\x13.mpi.v1.MessageMetaR\vmessageMeta\x12,\n" +
 	"\bresource\x18\x02 \x01(\v2\x10.mpi.v1.ResourceR\bresource\"\xde\x01\n" +
@@ -3047,13 +3780,21 @@ const file_mpi_v1_command_proto_rawDesc = "" +
 	"\x1cUpdateDataPlaneHealthRequest\x126\n" +
 	"\fmessage_meta\x18\x01 \x01(\v2\x13.mpi.v1.MessageMetaR\vmessageMeta\x12A\n" +
 	"\x10instance_healths\x18\x02 \x03(\v2\x16.mpi.v1.InstanceHealthR\x0finstanceHealths\"\x1f\n" +
-	"\x1dUpdateDataPlaneHealthResponse\"\xd5\x03\n" +
+	"\x1dUpdateDataPlaneHealthResponse\"\xe8\a\n" +
 	"\x11DataPlaneResponse\x126\n" +
 	"\fmessage_meta\x18\x01 \x01(\v2\x13.mpi.v1.MessageMetaR\vmessageMeta\x12B\n" +
 	"\x10command_response\x18\x02 \x01(\v2\x17.mpi.v1.CommandResponseR\x0fcommandResponse\x12\x1f\n" +
 	"\vinstance_id\x18\x03 \x01(\tR\n" +
 	"instanceId\x12H\n" +
-	"\frequest_type\x18\x04 \x01(\x0e2%.mpi.v1.DataPlaneResponse.RequestTypeR\vrequestType\"\xd8\x01\n" +
+	"\frequest_type\x18\x04 \x01(\x0e2%.mpi.v1.DataPlaneResponse.RequestTypeR\vrequestType\x12\x1b\n" +
+	"\tack_index\x18\x05 \x01(\x03R\backIndex\x12R\n" +
+	"\x16config_validate_result\x18\x06 \x01(\v2\x1c.mpi.v1.ConfigValidateResultR\x14configValidateResult\x12C\n" +
+	"\x11config_change_set\x18\a \x01(\v2\x17.mpi.v1.ConfigChangeSetR\x0fconfigChangeSet\x12<\n" +
+	"\x0econfig_history\x18\b \x01(\v2\x15.mpi.v1.ConfigHistoryR\rconfigHistory\x12@\n" +
+	"\fhook_results\x18\t \x03(\v2\x1d.mpi.v1.ConfigApplyHookResultR\vhookResults\x127\n" +
+	"\x0erendered_files\x18\n" +
+	" \x03(\v2\x10.mpi.v1.FileMetaR\rrenderedFiles\x12<\n" +
+	"\x0einstance_event\x18\v \x01(\v2\x15.mpi.v1.InstanceEventR\rinstanceEvent\"\xbe\x02\n" +
 	"\vRequestType\x12\x17\n" +
 	"\x13UNSPECIFIED_REQUEST\x10\x00\x12\x18\n" +
 	"\x14CONFIG_APPLY_REQUEST\x10\x01\x12\x19\n" +
@@ -3062,7 +3803,33 @@ const file_mpi_v1_command_proto_rawDesc = "" +
 	"\x0eSTATUS_REQUEST\x10\x04\x12\x16\n" +
 	"\x12API_ACTION_REQUEST\x10\x05\x12\x1a\n" +
 	"\x16COMMAND_STATUS_REQUEST\x10\x06\x12\x1f\n" +
-	"\x1bUPDATE_AGENT_CONFIG_REQUEST\x10\a\"\xfa\x04\n" +
+	"\x1bUPDATE_AGENT_CONFIG_REQUEST\x10\a\x12\x1b\n" +
+	"\x17CONFIG_VALIDATE_REQUEST\x10\b\x12\x17\n" +
+	"\x13CONFIG_DIFF_REQUEST\x10\t\x12\x1a\n" +
+	"\x16CONFIG_HISTORY_REQUEST\x10\n" +
+	"\x12\x12\n" +
+	"\x0eINSTANCE_EVENT\x10\v\"\xf5\x04\n" +
+	"\rInstanceEvent\x12\x1f\n" +
+	"\vinstance_id\x18\x01 \x01(\tR\n" +
+	"instanceId\x12F\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\x0e2'.mpi.v1.InstanceEvent.InstanceEventTypeR\teventType\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x125\n" +
+	"\achanges\x18\x04 \x03(\v2\x1b.mpi.v1.InstanceEventChangeR\achanges\"\x89\x03\n" +
+	"\x11InstanceEventType\x12#\n" +
+	"\x1fINSTANCE_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bINSTANCE_EVENT_TYPE_STARTED\x10\x01\x12\x1f\n" +
+	"\x1bINSTANCE_EVENT_TYPE_STOPPED\x10\x02\x12*\n" +
+	"&INSTANCE_EVENT_TYPE_PROCESS_ID_CHANGED\x10\x03\x12'\n" +
+	"#INSTANCE_EVENT_TYPE_VERSION_CHANGED\x10\x04\x12'\n" +
+	"#INSTANCE_EVENT_TYPE_MODULES_CHANGED\x10\x05\x12,\n" +
+	"(INSTANCE_EVENT_TYPE_WORKER_COUNT_CHANGED\x10\x06\x12'\n" +
+	"#INSTANCE_EVENT_TYPE_CONFIG_RELOADED\x10\a\x128\n" +
+	"4INSTANCE_EVENT_TYPE_ATTACK_SIGNATURE_VERSION_CHANGED\x10\b\"W\n" +
+	"\x13InstanceEventChange\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06before\x18\x02 \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\x03 \x01(\tR\x05after\"\xf6\x06\n" +
 	"\x16ManagementPlaneRequest\x126\n" +
 	"\fmessage_meta\x18\x01 \x01(\v2\x13.mpi.v1.MessageMetaR\vmessageMeta\x12>\n" +
 	"\x0estatus_request\x18\x02 \x01(\v2\x15.mpi.v1.StatusRequestH\x00R\rstatusRequest\x12>\n" +
@@ -3071,12 +3838,33 @@ const file_mpi_v1_command_proto_rawDesc = "" +
 	"\x15config_upload_request\x18\x06 \x01(\v2\x1b.mpi.v1.ConfigUploadRequestH\x00R\x13configUploadRequest\x12A\n" +
 	"\x0eaction_request\x18\a \x01(\v2\x18.mpi.v1.APIActionRequestH\x00R\ractionRequest\x12T\n" +
 	"\x16command_status_request\x18\b \x01(\v2\x1c.mpi.v1.CommandStatusRequestH\x00R\x14commandStatusRequest\x12a\n" +
-	"\x1bupdate_agent_config_request\x18\t \x01(\v2 .mpi.v1.UpdateAgentConfigRequestH\x00R\x18updateAgentConfigRequestB\t\n" +
+	"\x1bupdate_agent_config_request\x18\t \x01(\v2 .mpi.v1.UpdateAgentConfigRequestH\x00R\x18updateAgentConfigRequest\x12W\n" +
+	"\x17config_validate_request\x18\n" +
+	" \x01(\v2\x1d.mpi.v1.ConfigValidateRequestH\x00R\x15configValidateRequest\x12K\n" +
+	"\x13config_diff_request\x18\v \x01(\v2\x19.mpi.v1.ConfigDiffRequestH\x00R\x11configDiffRequest\x12T\n" +
+	"\x16config_history_request\x18\f \x01(\v2\x1c.mpi.v1.ConfigHistoryRequestH\x00R\x14configHistoryRequestB\t\n" +
 	"\arequest\"\x0f\n" +
 	"\rStatusRequest\"\x0f\n" +
 	"\rHealthRequest\"F\n" +
 	"\x12ConfigApplyRequest\x120\n" +
-	"\boverview\x18\x01 \x01(\v2\x14.mpi.v1.FileOverviewR\boverview\"G\n" +
+	"\boverview\x18\x01 \x01(\v2\x14.mpi.v1.FileOverviewR\boverview\"I\n" +
+	"\x15ConfigValidateRequest\x120\n" +
+	"\boverview\x18\x01 \x01(\v2\x14.mpi.v1.FileOverviewR\boverview\"q\n" +
+	"\x11ConfigDiffRequest\x120\n" +
+	"\boverview\x18\x01 \x01(\v2\x14.mpi.v1.FileOverviewR\boverview\x12*\n" +
+	"\x11include_text_diff\x18\x02 \x01(\bR\x0fincludeTextDiff\"b\n" +
+	"\x14ConfigHistoryRequest\x12\x1f\n" +
+	"\vinstance_id\x18\x01 \x01(\tR\n" +
+	"instanceId\x12)\n" +
+	"\x10rollback_version\x18\x02 \x01(\tR\x0frollbackVersion\"J\n" +
+	"\x14ConfigValidateResult\x12\x16\n" +
+	"\x06output\x18\x01 \x01(\tR\x06output\x12\x1a\n" +
+	"\bwarnings\x18\x02 \x03(\tR\bwarnings\"v\n" +
+	"\x15ConfigApplyHookResult\x12\x14\n" +
+	"\x05phase\x18\x01 \x01(\tR\x05phase\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1b\n" +
+	"\texit_code\x18\x03 \x01(\x05R\bexitCode\x12\x16\n" +
+	"\x06output\x18\x04 \x01(\tR\x06output\"G\n" +
 	"\x13ConfigUploadRequest\x120\n" +
 	"\boverview\x18\x01 \x01(\v2\x14.mpi.v1.FileOverviewR\boverview\"\x84\x01\n" +
 	"\x10APIActionRequest\x12\x1f\n" +
@@ -3100,8 +3888,9 @@ const file_mpi_v1_command_proto_rawDesc = "" +
 	"\x14upstream_stream_name\x18\x01 \x01(\tR\x12upstreamStreamName\x121\n" +
 	"\aservers\x18\x02 \x03(\v2\x17.google.protobuf.StructR\aservers\"\x0e\n" +
 	"\fGetUpstreams\"\x14\n" +
-	"\x12GetStreamUpstreams\"\x16\n" +
-	"\x14CommandStatusRequest\"\xca\x01\n" +
+	"\x12GetStreamUpstreams\"=\n" +
+	"\x14CommandStatusRequest\x12%\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\"\xca\x01\n" +
 	"\bInstance\x129\n" +
 	"\rinstance_meta\x18\x01 \x01(\v2\x14.mpi.v1.InstanceMetaR\finstanceMeta\x12?\n" +
 	"\x0finstance_config\x18\x02 \x01(\v2\x16.mpi.v1.InstanceConfigR\x0einstanceConfig\x12B\n" +
@@ -3121,7 +3910,7 @@ const file_mpi_v1_command_proto_rawDesc = "" +
 	"\x0eInstanceConfig\x120\n" +
 	"\aactions\x18\x01 \x03(\v2\x16.mpi.v1.InstanceActionR\aactions\x128\n" +
 	"\fagent_config\x18\x02 \x01(\v2\x13.mpi.v1.AgentConfigH\x00R\vagentConfigB\b\n" +
-	"\x06config\"\xee\x03\n" +
+	"\x06config\"\xd8\x04\n" +
 	"\x0fInstanceRuntime\x12\x1d\n" +
 	"\n" +
 	"process_id\x18\x01 \x01(\x05R\tprocessId\x120\n" +
@@ -3133,8 +3922,10 @@ const file_mpi_v1_command_proto_rawDesc = "" +
 	"configPath\x12H\n" +
 	"\x12nginx_runtime_info\x18\x04 \x01(\v2\x18.mpi.v1.NGINXRuntimeInfoH\x00R\x10nginxRuntimeInfo\x12U\n" +
 	"\x17nginx_plus_runtime_info\x18\x05 \x01(\v2\x1c.mpi.v1.NGINXPlusRuntimeInfoH\x00R\x14nginxPlusRuntimeInfo\x12h\n" +
-	"\x1enginx_app_protect_runtime_info\x18\a \x01(\v2\".mpi.v1.NGINXAppProtectRuntimeInfoH\x00R\x1anginxAppProtectRuntimeInfo\x12B\n" +
-	"\x11instance_children\x18\x06 \x03(\v2\x15.mpi.v1.InstanceChildR\x10instanceChildrenB\t\n" +
+	"\x1enginx_app_protect_runtime_info\x18\a \x01(\v2\".mpi.v1.NGINXAppProtectRuntimeInfoH\x00R\x1anginxAppProtectRuntimeInfo\x12E\n" +
+	"\x11unit_runtime_info\x18\b \x01(\v2\x17.mpi.v1.UnitRuntimeInfoH\x00R\x0funitRuntimeInfo\x12B\n" +
+	"\x11instance_children\x18\x06 \x03(\v2\x15.mpi.v1.InstanceChildR\x10instanceChildren\x12!\n" +
+	"\fcontainer_id\x18\t \x01(\tR\vcontainerIdB\t\n" +
 	"\adetails\".\n" +
 	"\rInstanceChild\x12\x1d\n" +
 	"\n" +
@@ -3167,7 +3958,11 @@ const file_mpi_v1_command_proto_rawDesc = "" +
 	"\arelease\x18\x01 \x01(\tR\arelease\x128\n" +
 	"\x18attack_signature_version\x18\x02 \x01(\tR\x16attackSignatureVersion\x126\n" +
 	"\x17threat_campaign_version\x18\x03 \x01(\tR\x15threatCampaignVersion\x126\n" +
-	"\x17enforcer_engine_version\x18\x04 \x01(\tR\x15enforcerEngineVersion\"\x10\n" +
+	"\x17enforcer_engine_version\x18\x04 \x01(\tR\x15enforcerEngineVersion\"l\n" +
+	"\x0fUnitRuntimeInfo\x12%\n" +
+	"\x0econtrol_socket\x18\x01 \x01(\tR\rcontrolSocket\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x18\n" +
+	"\amodules\x18\x03 \x03(\tR\amodules\"\x10\n" +
 	"\x0eInstanceAction\"\x80\x03\n" +
 	"\vAgentConfig\x12/\n" +
 	"\acommand\x18\x01 \x01(\v2\x15.mpi.v1.CommandServerR\acommand\x12/\n" +
@@ -3215,140 +4010,168 @@ func file_mpi_v1_command_proto_rawDescGZIP() []byte {
 	return file_mpi_v1_command_proto_rawDescData
 }
 
-var file_mpi_v1_command_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_mpi_v1_command_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
+var file_mpi_v1_command_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_mpi_v1_command_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
 var file_mpi_v1_command_proto_goTypes = []any{
 	(InstanceHealth_InstanceHealthStatus)(0), // 0: mpi.v1.InstanceHealth.InstanceHealthStatus
 	(DataPlaneResponse_RequestType)(0),       // 1: mpi.v1.DataPlaneResponse.RequestType
-	(InstanceMeta_InstanceType)(0),           // 2: mpi.v1.InstanceMeta.InstanceType
-	(Log_LogLevel)(0),                        // 3: mpi.v1.Log.LogLevel
-	(*CreateConnectionRequest)(nil),          // 4: mpi.v1.CreateConnectionRequest
-	(*Resource)(nil),                         // 5: mpi.v1.Resource
-	(*HostInfo)(nil),                         // 6: mpi.v1.HostInfo
-	(*ReleaseInfo)(nil),                      // 7: mpi.v1.ReleaseInfo
-	(*ContainerInfo)(nil),                    // 8: mpi.v1.ContainerInfo
-	(*CreateConnectionResponse)(nil),         // 9: mpi.v1.CreateConnectionResponse
-	(*UpdateDataPlaneStatusRequest)(nil),     // 10: mpi.v1.UpdateDataPlaneStatusRequest
-	(*UpdateDataPlaneStatusResponse)(nil),    // 11: mpi.v1.UpdateDataPlaneStatusResponse
-	(*UpdateAgentConfigRequest)(nil),         // 12: mpi.v1.UpdateAgentConfigRequest
-	(*InstanceHealth)(nil),                   // 13: mpi.v1.InstanceHealth
-	(*UpdateDataPlaneHealthRequest)(nil),     // 14: mpi.v1.UpdateDataPlaneHealthRequest
-	(*UpdateDataPlaneHealthResponse)(nil),    // 15: mpi.v1.UpdateDataPlaneHealthResponse
-	(*DataPlaneResponse)(nil),                // 16: mpi.v1.DataPlaneResponse
-	(*ManagementPlaneRequest)(nil),           // 17: mpi.v1.ManagementPlaneRequest
-	(*StatusRequest)(nil),                    // 18: mpi.v1.StatusRequest
-	(*HealthRequest)(nil),                    // 19: mpi.v1.HealthRequest
-	(*ConfigApplyRequest)(nil),               // 20: mpi.v1.ConfigApplyRequest
-	(*ConfigUploadRequest)(nil),              // 21: mpi.v1.ConfigUploadRequest
-	(*APIActionRequest)(nil),                 // 22: mpi.v1.APIActionRequest
-	(*NGINXPlusAction)(nil),                  // 23: mpi.v1.NGINXPlusAction
-	(*UpdateHTTPUpstreamServers)(nil),        // 24: mpi.v1.UpdateHTTPUpstreamServers
-	(*GetHTTPUpstreamServers)(nil),           // 25: mpi.v1.GetHTTPUpstreamServers
-	(*UpdateStreamServers)(nil),              // 26: mpi.v1.UpdateStreamServers
-	(*GetUpstreams)(nil),                     // 27: mpi.v1.GetUpstreams
-	(*GetStreamUpstreams)(nil),               // 28: mpi.v1.GetStreamUpstreams
-	(*CommandStatusRequest)(nil),             // 29: mpi.v1.CommandStatusRequest
-	(*Instance)(nil),                         // 30: mpi.v1.Instance
-	(*InstanceMeta)(nil),                     // 31: mpi.v1.InstanceMeta
-	(*InstanceConfig)(nil),                   // 32: mpi.v1.InstanceConfig
-	(*InstanceRuntime)(nil),                  // 33: mpi.v1.InstanceRuntime
-	(*InstanceChild)(nil),                    // 34: mpi.v1.InstanceChild
-	(*NGINXRuntimeInfo)(nil),                 // 35: mpi.v1.NGINXRuntimeInfo
-	(*NGINXPlusRuntimeInfo)(nil),             // 36: mpi.v1.NGINXPlusRuntimeInfo
-	(*APIDetails)(nil),                       // 37: mpi.v1.APIDetails
-	(*NGINXAppProtectRuntimeInfo)(nil),       // 38: mpi.v1.NGINXAppProtectRuntimeInfo
-	(*InstanceAction)(nil),                   // 39: mpi.v1.InstanceAction
-	(*AgentConfig)(nil),                      // 40: mpi.v1.AgentConfig
-	(*Log)(nil),                              // 41: mpi.v1.Log
-	(*CommandServer)(nil),                    // 42: mpi.v1.CommandServer
-	(*AuxiliaryCommandServer)(nil),           // 43: mpi.v1.AuxiliaryCommandServer
-	(*MetricsServer)(nil),                    // 44: mpi.v1.MetricsServer
-	(*FileServer)(nil),                       // 45: mpi.v1.FileServer
-	(*MessageMeta)(nil),                      // 46: mpi.v1.MessageMeta
-	(*CommandResponse)(nil),                  // 47: mpi.v1.CommandResponse
-	(*FileOverview)(nil),                     // 48: mpi.v1.FileOverview
-	(*structpb.Struct)(nil),                  // 49: google.protobuf.Struct
-	(*ServerSettings)(nil),                   // 50: mpi.v1.ServerSettings
-	(*AuthSettings)(nil),                     // 51: mpi.v1.AuthSettings
-	(*TLSSettings)(nil),                      // 52: mpi.v1.TLSSettings
+	(InstanceEvent_InstanceEventType)(0),     // 2: mpi.v1.InstanceEvent.InstanceEventType
+	(InstanceMeta_InstanceType)(0),           // 3: mpi.v1.InstanceMeta.InstanceType
+	(Log_LogLevel)(0),                        // 4: mpi.v1.Log.LogLevel
+	(*CreateConnectionRequest)(nil),          // 5: mpi.v1.CreateConnectionRequest
+	(*Resource)(nil),                         // 6: mpi.v1.Resource
+	(*HostInfo)(nil),                         // 7: mpi.v1.HostInfo
+	(*ReleaseInfo)(nil),                      // 8: mpi.v1.ReleaseInfo
+	(*ContainerInfo)(nil),                    // 9: mpi.v1.ContainerInfo
+	(*CreateConnectionResponse)(nil),         // 10: mpi.v1.CreateConnectionResponse
+	(*UpdateDataPlaneStatusRequest)(nil),     // 11: mpi.v1.UpdateDataPlaneStatusRequest
+	(*UpdateDataPlaneStatusResponse)(nil),    // 12: mpi.v1.UpdateDataPlaneStatusResponse
+	(*UpdateAgentConfigRequest)(nil),         // 13: mpi.v1.UpdateAgentConfigRequest
+	(*InstanceHealth)(nil),                   // 14: mpi.v1.InstanceHealth
+	(*UpdateDataPlaneHealthRequest)(nil),     // 15: mpi.v1.UpdateDataPlaneHealthRequest
+	(*UpdateDataPlaneHealthResponse)(nil),    // 16: mpi.v1.UpdateDataPlaneHealthResponse
+	(*DataPlaneResponse)(nil),                // 17: mpi.v1.DataPlaneResponse
+	(*InstanceEvent)(nil),                    // 18: mpi.v1.InstanceEvent
+	(*InstanceEventChange)(nil),              // 19: mpi.v1.InstanceEventChange
+	(*ManagementPlaneRequest)(nil),           // 20: mpi.v1.ManagementPlaneRequest
+	(*StatusRequest)(nil),                    // 21: mpi.v1.StatusRequest
+	(*HealthRequest)(nil),                    // 22: mpi.v1.HealthRequest
+	(*ConfigApplyRequest)(nil),               // 23: mpi.v1.ConfigApplyRequest
+	(*ConfigValidateRequest)(nil),            // 24: mpi.v1.ConfigValidateRequest
+	(*ConfigDiffRequest)(nil),                // 25: mpi.v1.ConfigDiffRequest
+	(*ConfigHistoryRequest)(nil),             // 26: mpi.v1.ConfigHistoryRequest
+	(*ConfigValidateResult)(nil),             // 27: mpi.v1.ConfigValidateResult
+	(*ConfigApplyHookResult)(nil),            // 28: mpi.v1.ConfigApplyHookResult
+	(*ConfigUploadRequest)(nil),              // 29: mpi.v1.ConfigUploadRequest
+	(*APIActionRequest)(nil),                 // 30: mpi.v1.APIActionRequest
+	(*NGINXPlusAction)(nil),                  // 31: mpi.v1.NGINXPlusAction
+	(*UpdateHTTPUpstreamServers)(nil),        // 32: mpi.v1.UpdateHTTPUpstreamServers
+	(*GetHTTPUpstreamServers)(nil),           // 33: mpi.v1.GetHTTPUpstreamServers
+	(*UpdateStreamServers)(nil),              // 34: mpi.v1.UpdateStreamServers
+	(*GetUpstreams)(nil),                     // 35: mpi.v1.GetUpstreams
+	(*GetStreamUpstreams)(nil),               // 36: mpi.v1.GetStreamUpstreams
+	(*CommandStatusRequest)(nil),             // 37: mpi.v1.CommandStatusRequest
+	(*Instance)(nil),                         // 38: mpi.v1.Instance
+	(*InstanceMeta)(nil),                     // 39: mpi.v1.InstanceMeta
+	(*InstanceConfig)(nil),                   // 40: mpi.v1.InstanceConfig
+	(*InstanceRuntime)(nil),                  // 41: mpi.v1.InstanceRuntime
+	(*InstanceChild)(nil),                    // 42: mpi.v1.InstanceChild
+	(*NGINXRuntimeInfo)(nil),                 // 43: mpi.v1.NGINXRuntimeInfo
+	(*NGINXPlusRuntimeInfo)(nil),             // 44: mpi.v1.NGINXPlusRuntimeInfo
+	(*APIDetails)(nil),                       // 45: mpi.v1.APIDetails
+	(*NGINXAppProtectRuntimeInfo)(nil),       // 46: mpi.v1.NGINXAppProtectRuntimeInfo
+	(*UnitRuntimeInfo)(nil),                  // 47: mpi.v1.UnitRuntimeInfo
+	(*InstanceAction)(nil),                   // 48: mpi.v1.InstanceAction
+	(*AgentConfig)(nil),                      // 49: mpi.v1.AgentConfig
+	(*Log)(nil),                              // 50: mpi.v1.Log
+	(*CommandServer)(nil),                    // 51: mpi.v1.CommandServer
+	(*AuxiliaryCommandServer)(nil),           // 52: mpi.v1.AuxiliaryCommandServer
+	(*MetricsServer)(nil),                    // 53: mpi.v1.MetricsServer
+	(*FileServer)(nil),                       // 54: mpi.v1.FileServer
+	(*MessageMeta)(nil),                      // 55: mpi.v1.MessageMeta
+	(*CommandResponse)(nil),                  // 56: mpi.v1.CommandResponse
+	(*ConfigChangeSet)(nil),                  // 57: mpi.v1.ConfigChangeSet
+	(*ConfigHistory)(nil),                    // 58: mpi.v1.ConfigHistory
+	(*FileMeta)(nil),                         // 59: mpi.v1.FileMeta
+	(*timestamppb.Timestamp)(nil),            // 60: google.protobuf.Timestamp
+	(*FileOverview)(nil),                     // 61: mpi.v1.FileOverview
+	(*structpb.Struct)(nil),                  // 62: google.protobuf.Struct
+	(*ServerSettings)(nil),                   // 63: mpi.v1.ServerSettings
+	(*AuthSettings)(nil),                     // 64: mpi.v1.AuthSettings
+	(*TLSSettings)(nil),                      // 65: mpi.v1.TLSSettings
 }
 var file_mpi_v1_command_proto_depIdxs = []int32{
-	46, // 0: mpi.v1.CreateConnectionRequest.message_meta:type_name -> mpi.v1.MessageMeta
-	5,  // 1: mpi.v1.CreateConnectionRequest.resource:type_name -> mpi.v1.Resource
-	30, // 2: mpi.v1.Resource.instances:type_name -> mpi.v1.Instance
-	6,  // 3: mpi.v1.Resource.host_info:type_name -> mpi.v1.HostInfo
-	8,  // 4: mpi.v1.Resource.container_info:type_name -> mpi.v1.ContainerInfo
-	7,  // 5: mpi.v1.HostInfo.release_info:type_name -> mpi.v1.ReleaseInfo
-	7,  // 6: mpi.v1.ContainerInfo.release_info:type_name -> mpi.v1.ReleaseInfo
-	47, // 7: mpi.v1.CreateConnectionResponse.response:type_name -> mpi.v1.CommandResponse
-	40, // 8: mpi.v1.CreateConnectionResponse.agent_config:type_name -> mpi.v1.AgentConfig
-	46, // 9: mpi.v1.UpdateDataPlaneStatusRequest.message_meta:type_name -> mpi.v1.MessageMeta
-	5,  // 10: mpi.v1.UpdateDataPlaneStatusRequest.resource:type_name -> mpi.v1.Resource
-	46, // 11: mpi.v1.UpdateAgentConfigRequest.message_meta:type_name -> mpi.v1.MessageMeta
-	40, // 12: mpi.v1.UpdateAgentConfigRequest.agent_config:type_name -> mpi.v1.AgentConfig
+	55, // 0: mpi.v1.CreateConnectionRequest.message_meta:type_name -> mpi.v1.MessageMeta
+	6,  // 1: mpi.v1.CreateConnectionRequest.resource:type_name -> mpi.v1.Resource
+	38, // 2: mpi.v1.Resource.instances:type_name -> mpi.v1.Instance
+	7,  // 3: mpi.v1.Resource.host_info:type_name -> mpi.v1.HostInfo
+	9,  // 4: mpi.v1.Resource.container_info:type_name -> mpi.v1.ContainerInfo
+	8,  // 5: mpi.v1.HostInfo.release_info:type_name -> mpi.v1.ReleaseInfo
+	8,  // 6: mpi.v1.ContainerInfo.release_info:type_name -> mpi.v1.ReleaseInfo
+	56, // 7: mpi.v1.CreateConnectionResponse.response:type_name -> mpi.v1.CommandResponse
+	49, // 8: mpi.v1.CreateConnectionResponse.agent_config:type_name -> mpi.v1.AgentConfig
+	55, // 9: mpi.v1.UpdateDataPlaneStatusRequest.message_meta:type_name -> mpi.v1.MessageMeta
+	6,  // 10: mpi.v1.UpdateDataPlaneStatusRequest.resource:type_name -> mpi.v1.Resource
+	55, // 11: mpi.v1.UpdateAgentConfigRequest.message_meta:type_name -> mpi.v1.MessageMeta
+	49, // 12: mpi.v1.UpdateAgentConfigRequest.agent_config:type_name -> mpi.v1.AgentConfig
 	0,  // 13: mpi.v1.InstanceHealth.instance_health_status:type_name -> mpi.v1.InstanceHealth.InstanceHealthStatus
-	46, // 14: mpi.v1.UpdateDataPlaneHealthRequest.message_meta:type_name -> mpi.v1.MessageMeta
-	13, // 15: mpi.v1.UpdateDataPlaneHealthRequest.instance_healths:type_name -> mpi.v1.InstanceHealth
-	46, // 16: mpi.v1.DataPlaneResponse.message_meta:type_name -> mpi.v1.MessageMeta
-	47, // 17: mpi.v1.DataPlaneResponse.command_response:type_name -> mpi.v1.CommandResponse
+	55, // 14: mpi.v1.UpdateDataPlaneHealthRequest.message_meta:type_name -> mpi.v1.MessageMeta
+	14, // 15: mpi.v1.UpdateDataPlaneHealthRequest.instance_healths:type_name -> mpi.v1.InstanceHealth
+	55, // 16: mpi.v1.DataPlaneResponse.message_meta:type_name -> mpi.v1.MessageMeta
+	56, // 17: mpi.v1.DataPlaneResponse.command_response:type_name -> mpi.v1.CommandResponse
 	1,  // 18: mpi.v1.DataPlaneResponse.request_type:type_name -> mpi.v1.DataPlaneResponse.RequestType
-	46, // 19: mpi.v1.ManagementPlaneRequest.message_meta:type_name -> mpi.v1.MessageMeta
-	18, // 20: mpi.v1.ManagementPlaneRequest.status_request:type_name -> mpi.v1.StatusRequest
-	19, // 21: mpi.v1.ManagementPlaneRequest.health_request:type_name -> mpi.v1.HealthRequest
-	20, // 22: mpi.v1.ManagementPlaneRequest.config_apply_request:type_name -> mpi.v1.ConfigApplyRequest
-	21, // 23: mpi.v1.ManagementPlaneRequest.config_upload_request:type_name -> mpi.v1.ConfigUploadRequest
-	22, // 24: mpi.v1.ManagementPlaneRequest.action_request:type_name -> mpi.v1.APIActionRequest
-	29, // 25: mpi.v1.ManagementPlaneRequest.command_status_request:type_name -> mpi.v1.CommandStatusRequest
-	12, // 26: mpi.v1.ManagementPlaneRequest.update_agent_config_request:type_name -> mpi.v1.UpdateAgentConfigRequest
-	48, // 27: mpi.v1.ConfigApplyRequest.overview:type_name -> mpi.v1.FileOverview
-	48, // 28: mpi.v1.ConfigUploadRequest.overview:type_name -> mpi.v1.FileOverview
-	23, // 29: mpi.v1.APIActionRequest.nginx_plus_action:type_name -> mpi.v1.NGINXPlusAction
-	24, // 30: mpi.v1.NGINXPlusAction.update_http_upstream_servers:type_name -> mpi.v1.UpdateHTTPUpstreamServers
-	25, // 31: mpi.v1.NGINXPlusAction.get_http_upstream_servers:type_name -> mpi.v1.GetHTTPUpstreamServers
-	26, // 32: mpi.v1.NGINXPlusAction.update_stream_servers:type_name -> mpi.v1.UpdateStreamServers
-	27, // 33: mpi.v1.NGINXPlusAction.get_upstreams:type_name -> mpi.v1.GetUpstreams
-	28, // 34: mpi.v1.NGINXPlusAction.get_stream_upstreams:type_name -> mpi.v1.GetStreamUpstreams
-	49, // 35: mpi.v1.UpdateHTTPUpstreamServers.servers:type_name -> google.protobuf.Struct
-	49, // 36: mpi.v1.UpdateStreamServers.servers:type_name -> google.protobuf.Struct
-	31, // 37: mpi.v1.Instance.instance_meta:type_name -> mpi.v1.InstanceMeta
-	32, // 38: mpi.v1.Instance.instance_config:type_name -> mpi.v1.InstanceConfig
-	33, // 39: mpi.v1.Instance.instance_runtime:type_name -> mpi.v1.InstanceRuntime
-	2,  // 40: mpi.v1.InstanceMeta.instance_type:type_name -> mpi.v1.InstanceMeta.InstanceType
-	39, // 41: mpi.v1.InstanceConfig.actions:type_name -> mpi.v1.InstanceAction
-	40, // 42: mpi.v1.InstanceConfig.agent_config:type_name -> mpi.v1.AgentConfig
-	35, // 43: mpi.v1.InstanceRuntime.nginx_runtime_info:type_name -> mpi.v1.NGINXRuntimeInfo
-	36, // 44: mpi.v1.InstanceRuntime.nginx_plus_runtime_info:type_name -> mpi.v1.NGINXPlusRuntimeInfo
-	38, // 45: mpi.v1.InstanceRuntime.nginx_app_protect_runtime_info:type_name -> mpi.v1.NGINXAppProtectRuntimeInfo
-	34, // 46: mpi.v1.InstanceRuntime.instance_children:type_name -> mpi.v1.InstanceChild
-	37, // 47: mpi.v1.NGINXRuntimeInfo.stub_status:type_name -> mpi.v1.APIDetails
-	37, // 48: mpi.v1.NGINXPlusRuntimeInfo.stub_status:type_name -> mpi.v1.APIDetails
-	37, // 49: mpi.v1.NGINXPlusRuntimeInfo.plus_api:type_name -> mpi.v1.APIDetails
-	42, // 50: mpi.v1.AgentConfig.command:type_name -> mpi.v1.CommandServer
-	44, // 51: mpi.v1.AgentConfig.metrics:type_name -> mpi.v1.MetricsServer
-	45, // 52: mpi.v1.AgentConfig.file:type_name -> mpi.v1.FileServer
-	49, // 53: mpi.v1.AgentConfig.labels:type_name -> google.protobuf.Struct
-	43, // 54: mpi.v1.AgentConfig.auxiliary_command:type_name -> mpi.v1.AuxiliaryCommandServer
-	41, // 55: mpi.v1.AgentConfig.log:type_name -> mpi.v1.Log
-	3,  // 56: mpi.v1.Log.log_level:type_name -> mpi.v1.Log.LogLevel
-	50, // 57: mpi.v1.CommandServer.server:type_name -> mpi.v1.ServerSettings
-	51, // 58: mpi.v1.CommandServer.auth:type_name -> mpi.v1.AuthSettings
-	52, // 59: mpi.v1.CommandServer.tls:type_name -> mpi.v1.TLSSettings
-	50, // 60: mpi.v1.AuxiliaryCommandServer.server:type_name -> mpi.v1.ServerSettings
-	51, // 61: mpi.v1.AuxiliaryCommandServer.auth:type_name -> mpi.v1.AuthSettings
-	52, // 62: mpi.v1.AuxiliaryCommandServer.tls:type_name -> mpi.v1.TLSSettings
-	4,  // 63: mpi.v1.CommandService.CreateConnection:input_type -> mpi.v1.CreateConnectionRequest
-	10, // 64: mpi.v1.CommandService.UpdateDataPlaneStatus:input_type -> mpi.v1.UpdateDataPlaneStatusRequest
-	14, // 65: mpi.v1.CommandService.UpdateDataPlaneHealth:input_type -> mpi.v1.UpdateDataPlaneHealthRequest
-	16, // 66: mpi.v1.CommandService.Subscribe:input_type -> mpi.v1.DataPlaneResponse
-	9,  // 67: mpi.v1.CommandService.CreateConnection:output_type -> mpi.v1.CreateConnectionResponse
-	11, // 68: mpi.v1.CommandService.UpdateDataPlaneStatus:output_type -> mpi.v1.UpdateDataPlaneStatusResponse
-	15, // 69: mpi.v1.CommandService.UpdateDataPlaneHealth:output_type -> mpi.v1.UpdateDataPlaneHealthResponse
-	17, // 70: mpi.v1.CommandService.Subscribe:output_type -> mpi.v1.ManagementPlaneRequest
-	67, // [67:71] is the sub-list for method output_type
-	63, // [63:67] is the sub-list for method input_type
-	63, // [63:63] is the sub-list for extension type_name
-	63, // [63:63] is the sub-list for extension extendee
-	0,  // [0:63] is the sub-list for field type_name
+	27, // 19: mpi.v1.DataPlaneResponse.config_validate_result:type_name -> mpi.v1.ConfigValidateResult
+	57, // 20: mpi.v1.DataPlaneResponse.config_change_set:type_name -> mpi.v1.ConfigChangeSet
+	58, // 21: mpi.v1.DataPlaneResponse.config_history:type_name -> mpi.v1.ConfigHistory
+	28, // 22: mpi.v1.DataPlaneResponse.hook_results:type_name -> mpi.v1.ConfigApplyHookResult
+	59, // 23: mpi.v1.DataPlaneResponse.rendered_files:type_name -> mpi.v1.FileMeta
+	18, // 24: mpi.v1.DataPlaneResponse.instance_event:type_name -> mpi.v1.InstanceEvent
+	2,  // 25: mpi.v1.InstanceEvent.event_type:type_name -> mpi.v1.InstanceEvent.InstanceEventType
+	60, // 26: mpi.v1.InstanceEvent.timestamp:type_name -> google.protobuf.Timestamp
+	19, // 27: mpi.v1.InstanceEvent.changes:type_name -> mpi.v1.InstanceEventChange
+	55, // 28: mpi.v1.ManagementPlaneRequest.message_meta:type_name -> mpi.v1.MessageMeta
+	21, // 29: mpi.v1.ManagementPlaneRequest.status_request:type_name -> mpi.v1.StatusRequest
+	22, // 30: mpi.v1.ManagementPlaneRequest.health_request:type_name -> mpi.v1.HealthRequest
+	23, // 31: mpi.v1.ManagementPlaneRequest.config_apply_request:type_name -> mpi.v1.ConfigApplyRequest
+	29, // 32: mpi.v1.ManagementPlaneRequest.config_upload_request:type_name -> mpi.v1.ConfigUploadRequest
+	30, // 33: mpi.v1.ManagementPlaneRequest.action_request:type_name -> mpi.v1.APIActionRequest
+	37, // 34: mpi.v1.ManagementPlaneRequest.command_status_request:type_name -> mpi.v1.CommandStatusRequest
+	13, // 35: mpi.v1.ManagementPlaneRequest.update_agent_config_request:type_name -> mpi.v1.UpdateAgentConfigRequest
+	24, // 36: mpi.v1.ManagementPlaneRequest.config_validate_request:type_name -> mpi.v1.ConfigValidateRequest
+	25, // 37: mpi.v1.ManagementPlaneRequest.config_diff_request:type_name -> mpi.v1.ConfigDiffRequest
+	26, // 38: mpi.v1.ManagementPlaneRequest.config_history_request:type_name -> mpi.v1.ConfigHistoryRequest
+	61, // 39: mpi.v1.ConfigApplyRequest.overview:type_name -> mpi.v1.FileOverview
+	61, // 40: mpi.v1.ConfigValidateRequest.overview:type_name -> mpi.v1.FileOverview
+	61, // 41: mpi.v1.ConfigDiffRequest.overview:type_name -> mpi.v1.FileOverview
+	61, // 42: mpi.v1.ConfigUploadRequest.overview:type_name -> mpi.v1.FileOverview
+	31, // 43: mpi.v1.APIActionRequest.nginx_plus_action:type_name -> mpi.v1.NGINXPlusAction
+	32, // 44: mpi.v1.NGINXPlusAction.update_http_upstream_servers:type_name -> mpi.v1.UpdateHTTPUpstreamServers
+	33, // 45: mpi.v1.NGINXPlusAction.get_http_upstream_servers:type_name -> mpi.v1.GetHTTPUpstreamServers
+	34, // 46: mpi.v1.NGINXPlusAction.update_stream_servers:type_name -> mpi.v1.UpdateStreamServers
+	35, // 47: mpi.v1.NGINXPlusAction.get_upstreams:type_name -> mpi.v1.GetUpstreams
+	36, // 48: mpi.v1.NGINXPlusAction.get_stream_upstreams:type_name -> mpi.v1.GetStreamUpstreams
+	62, // 49: mpi.v1.UpdateHTTPUpstreamServers.servers:type_name -> google.protobuf.Struct
+	62, // 50: mpi.v1.UpdateStreamServers.servers:type_name -> google.protobuf.Struct
+	39, // 51: mpi.v1.Instance.instance_meta:type_name -> mpi.v1.InstanceMeta
+	40, // 52: mpi.v1.Instance.instance_config:type_name -> mpi.v1.InstanceConfig
+	41, // 53: mpi.v1.Instance.instance_runtime:type_name -> mpi.v1.InstanceRuntime
+	3,  // 54: mpi.v1.InstanceMeta.instance_type:type_name -> mpi.v1.InstanceMeta.InstanceType
+	48, // 55: mpi.v1.InstanceConfig.actions:type_name -> mpi.v1.InstanceAction
+	49, // 56: mpi.v1.InstanceConfig.agent_config:type_name -> mpi.v1.AgentConfig
+	43, // 57: mpi.v1.InstanceRuntime.nginx_runtime_info:type_name -> mpi.v1.NGINXRuntimeInfo
+	44, // 58: mpi.v1.InstanceRuntime.nginx_plus_runtime_info:type_name -> mpi.v1.NGINXPlusRuntimeInfo
+	46, // 59: mpi.v1.InstanceRuntime.nginx_app_protect_runtime_info:type_name -> mpi.v1.NGINXAppProtectRuntimeInfo
+	47, // 60: mpi.v1.InstanceRuntime.unit_runtime_info:type_name -> mpi.v1.UnitRuntimeInfo
+	42, // 61: mpi.v1.InstanceRuntime.instance_children:type_name -> mpi.v1.InstanceChild
+	45, // 62: mpi.v1.NGINXRuntimeInfo.stub_status:type_name -> mpi.v1.APIDetails
+	45, // 63: mpi.v1.NGINXPlusRuntimeInfo.stub_status:type_name -> mpi.v1.APIDetails
+	45, // 64: mpi.v1.NGINXPlusRuntimeInfo.plus_api:type_name -> mpi.v1.APIDetails
+	51, // 65: mpi.v1.AgentConfig.command:type_name -> mpi.v1.CommandServer
+	53, // 66: mpi.v1.AgentConfig.metrics:type_name -> mpi.v1.MetricsServer
+	54, // 67: mpi.v1.AgentConfig.file:type_name -> mpi.v1.FileServer
+	62, // 68: mpi.v1.AgentConfig.labels:type_name -> google.protobuf.Struct
+	52, // 69: mpi.v1.AgentConfig.auxiliary_command:type_name -> mpi.v1.AuxiliaryCommandServer
+	50, // 70: mpi.v1.AgentConfig.log:type_name -> mpi.v1.Log
+	4,  // 71: mpi.v1.Log.log_level:type_name -> mpi.v1.Log.LogLevel
+	63, // 72: mpi.v1.CommandServer.server:type_name -> mpi.v1.ServerSettings
+	64, // 73: mpi.v1.CommandServer.auth:type_name -> mpi.v1.AuthSettings
+	65, // 74: mpi.v1.CommandServer.tls:type_name -> mpi.v1.TLSSettings
+	63, // 75: mpi.v1.AuxiliaryCommandServer.server:type_name -> mpi.v1.ServerSettings
+	64, // 76: mpi.v1.AuxiliaryCommandServer.auth:type_name -> mpi.v1.AuthSettings
+	65, // 77: mpi.v1.AuxiliaryCommandServer.tls:type_name -> mpi.v1.TLSSettings
+	5,  // 78: mpi.v1.CommandService.CreateConnection:input_type -> mpi.v1.CreateConnectionRequest
+	11, // 79: mpi.v1.CommandService.UpdateDataPlaneStatus:input_type -> mpi.v1.UpdateDataPlaneStatusRequest
+	15, // 80: mpi.v1.CommandService.UpdateDataPlaneHealth:input_type -> mpi.v1.UpdateDataPlaneHealthRequest
+	17, // 81: mpi.v1.CommandService.Subscribe:input_type -> mpi.v1.DataPlaneResponse
+	10, // 82: mpi.v1.CommandService.CreateConnection:output_type -> mpi.v1.CreateConnectionResponse
+	12, // 83: mpi.v1.CommandService.UpdateDataPlaneStatus:output_type -> mpi.v1.UpdateDataPlaneStatusResponse
+	16, // 84: mpi.v1.CommandService.UpdateDataPlaneHealth:output_type -> mpi.v1.UpdateDataPlaneHealthResponse
+	20, // 85: mpi.v1.CommandService.Subscribe:output_type -> mpi.v1.ManagementPlaneRequest
+	82, // [82:86] is the sub-list for method output_type
+	78, // [78:82] is the sub-list for method input_type
+	78, // [78:78] is the sub-list for extension type_name
+	78, // [78:78] is the sub-list for extension extendee
+	0,  // [0:78] is the sub-list for field type_name
 }
 
 func init() { file_mpi_v1_command_proto_init() }
@@ -3362,7 +4185,7 @@ func file_mpi_v1_command_proto_init() {
 		(*Resource_HostInfo)(nil),
 		(*Resource_ContainerInfo)(nil),
 	}
-	file_mpi_v1_command_proto_msgTypes[13].OneofWrappers = []any{
+	file_mpi_v1_command_proto_msgTypes[15].OneofWrappers = []any{
 		(*ManagementPlaneRequest_StatusRequest)(nil),
 		(*ManagementPlaneRequest_HealthRequest)(nil),
 		(*ManagementPlaneRequest_ConfigApplyRequest)(nil),
@@ -3370,32 +4193,36 @@ func file_mpi_v1_command_proto_init() {
 		(*ManagementPlaneRequest_ActionRequest)(nil),
 		(*ManagementPlaneRequest_CommandStatusRequest)(nil),
 		(*ManagementPlaneRequest_UpdateAgentConfigRequest)(nil),
+		(*ManagementPlaneRequest_ConfigValidateRequest)(nil),
+		(*ManagementPlaneRequest_ConfigDiffRequest)(nil),
+		(*ManagementPlaneRequest_ConfigHistoryRequest)(nil),
 	}
-	file_mpi_v1_command_proto_msgTypes[18].OneofWrappers = []any{
+	file_mpi_v1_command_proto_msgTypes[25].OneofWrappers = []any{
 		(*APIActionRequest_NginxPlusAction)(nil),
 	}
-	file_mpi_v1_command_proto_msgTypes[19].OneofWrappers = []any{
+	file_mpi_v1_command_proto_msgTypes[26].OneofWrappers = []any{
 		(*NGINXPlusAction_UpdateHttpUpstreamServers)(nil),
 		(*NGINXPlusAction_GetHttpUpstreamServers)(nil),
 		(*NGINXPlusAction_UpdateStreamServers)(nil),
 		(*NGINXPlusAction_GetUpstreams)(nil),
 		(*NGINXPlusAction_GetStreamUpstreams)(nil),
 	}
-	file_mpi_v1_command_proto_msgTypes[28].OneofWrappers = []any{
+	file_mpi_v1_command_proto_msgTypes[35].OneofWrappers = []any{
 		(*InstanceConfig_AgentConfig)(nil),
 	}
-	file_mpi_v1_command_proto_msgTypes[29].OneofWrappers = []any{
+	file_mpi_v1_command_proto_msgTypes[36].OneofWrappers = []any{
 		(*InstanceRuntime_NginxRuntimeInfo)(nil),
 		(*InstanceRuntime_NginxPlusRuntimeInfo)(nil),
 		(*InstanceRuntime_NginxAppProtectRuntimeInfo)(nil),
+		(*InstanceRuntime_UnitRuntimeInfo)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mpi_v1_command_proto_rawDesc), len(file_mpi_v1_command_proto_rawDesc)),
-			NumEnums:      4,
-			NumMessages:   42,
+			NumEnums:      5,
+			NumMessages:   50,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1834,6 +1834,192 @@ func (m *DataPlaneResponse) validate(all bool) error {
 
 	// no validation rules for RequestType
 
+	// no validation rules for AckIndex
+
+	if all {
+		switch v := interface{}(m.GetConfigValidateResult()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataPlaneResponseValidationError{
+					field:  "ConfigValidateResult",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataPlaneResponseValidationError{
+					field:  "ConfigValidateResult",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetConfigValidateResult()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataPlaneResponseValidationError{
+				field:  "ConfigValidateResult",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetConfigChangeSet()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataPlaneResponseValidationError{
+					field:  "ConfigChangeSet",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataPlaneResponseValidationError{
+					field:  "ConfigChangeSet",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetConfigChangeSet()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataPlaneResponseValidationError{
+				field:  "ConfigChangeSet",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetConfigHistory()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataPlaneResponseValidationError{
+					field:  "ConfigHistory",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataPlaneResponseValidationError{
+					field:  "ConfigHistory",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetConfigHistory()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataPlaneResponseValidationError{
+				field:  "ConfigHistory",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetHookResults() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DataPlaneResponseValidationError{
+						field:  fmt.Sprintf("HookResults[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DataPlaneResponseValidationError{
+						field:  fmt.Sprintf("HookResults[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DataPlaneResponseValidationError{
+					field:  fmt.Sprintf("HookResults[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	for idx, item := range m.GetRenderedFiles() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DataPlaneResponseValidationError{
+						field:  fmt.Sprintf("RenderedFiles[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DataPlaneResponseValidationError{
+						field:  fmt.Sprintf("RenderedFiles[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DataPlaneResponseValidationError{
+					field:  fmt.Sprintf("RenderedFiles[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetInstanceEvent()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DataPlaneResponseValidationError{
+					field:  "InstanceEvent",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DataPlaneResponseValidationError{
+					field:  "InstanceEvent",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetInstanceEvent()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DataPlaneResponseValidationError{
+				field:  "InstanceEvent",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return DataPlaneResponseMultiError(errors)
 	}
@@ -1914,158 +2100,351 @@ var _ interface {
 	ErrorName() string
 } = DataPlaneResponseValidationError{}
 
-// Validate checks the field values on ManagementPlaneRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *ManagementPlaneRequest) Validate() error {
+// Validate checks the field values on InstanceEvent with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *InstanceEvent) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ManagementPlaneRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// ManagementPlaneRequestMultiError, or nil if none found.
-func (m *ManagementPlaneRequest) ValidateAll() error {
+// ValidateAll checks the field values on InstanceEvent with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in InstanceEventMultiError, or
+// nil if none found.
+func (m *InstanceEvent) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ManagementPlaneRequest) validate(all bool) error {
+func (m *InstanceEvent) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
+	// no validation rules for InstanceId
+
+	// no validation rules for EventType
+
 	if all {
-		switch v := interface{}(m.GetMessageMeta()).(type) {
+		switch v := interface{}(m.GetTimestamp()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, ManagementPlaneRequestValidationError{
-					field:  "MessageMeta",
+				errors = append(errors, InstanceEventValidationError{
+					field:  "Timestamp",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, ManagementPlaneRequestValidationError{
-					field:  "MessageMeta",
+				errors = append(errors, InstanceEventValidationError{
+					field:  "Timestamp",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		}
-	} else if v, ok := interface{}(m.GetMessageMeta()).(interface{ Validate() error }); ok {
+	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return ManagementPlaneRequestValidationError{
-				field:  "MessageMeta",
+			return InstanceEventValidationError{
+				field:  "Timestamp",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
 		}
 	}
 
-	switch v := m.Request.(type) {
-	case *ManagementPlaneRequest_StatusRequest:
-		if v == nil {
-			err := ManagementPlaneRequestValidationError{
-				field:  "Request",
-				reason: "oneof value cannot be a typed-nil",
-			}
-			if !all {
-				return err
-			}
-			errors = append(errors, err)
-		}
+	for idx, item := range m.GetChanges() {
+		_, _ = idx, item
 
 		if all {
-			switch v := interface{}(m.GetStatusRequest()).(type) {
+			switch v := interface{}(item).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, ManagementPlaneRequestValidationError{
-						field:  "StatusRequest",
+					errors = append(errors, InstanceEventValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
-					errors = append(errors, ManagementPlaneRequestValidationError{
-						field:  "StatusRequest",
+					errors = append(errors, InstanceEventValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			}
-		} else if v, ok := interface{}(m.GetStatusRequest()).(interface{ Validate() error }); ok {
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				return ManagementPlaneRequestValidationError{
-					field:  "StatusRequest",
+				return InstanceEventValidationError{
+					field:  fmt.Sprintf("Changes[%v]", idx),
 					reason: "embedded message failed validation",
 					cause:  err,
 				}
 			}
 		}
 
-	case *ManagementPlaneRequest_HealthRequest:
-		if v == nil {
-			err := ManagementPlaneRequestValidationError{
-				field:  "Request",
-				reason: "oneof value cannot be a typed-nil",
-			}
-			if !all {
-				return err
-			}
-			errors = append(errors, err)
-		}
+	}
 
-		if all {
-			switch v := interface{}(m.GetHealthRequest()).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, ManagementPlaneRequestValidationError{
-						field:  "HealthRequest",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, ManagementPlaneRequestValidationError{
-						field:  "HealthRequest",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(m.GetHealthRequest()).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return ManagementPlaneRequestValidationError{
-					field:  "HealthRequest",
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
+	if len(errors) > 0 {
+		return InstanceEventMultiError(errors)
+	}
 
-	case *ManagementPlaneRequest_ConfigApplyRequest:
-		if v == nil {
-			err := ManagementPlaneRequestValidationError{
-				field:  "Request",
-				reason: "oneof value cannot be a typed-nil",
-			}
-			if !all {
-				return err
-			}
-			errors = append(errors, err)
-		}
+	return nil
+}
 
-		if all {
-			switch v := interface{}(m.GetConfigApplyRequest()).(type) {
+// InstanceEventMultiError is an error wrapping multiple validation errors
+// returned by InstanceEvent.ValidateAll() if the designated constraints
+// aren't met.
+type InstanceEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m InstanceEventMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m InstanceEventMultiError) AllErrors() []error { return m }
+
+// InstanceEventValidationError is the validation error returned by
+// InstanceEvent.Validate if the designated constraints aren't met.
+type InstanceEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e InstanceEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e InstanceEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e InstanceEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e InstanceEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e InstanceEventValidationError) ErrorName() string { return "InstanceEventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e InstanceEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sInstanceEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = InstanceEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = InstanceEventValidationError{}
+
+// Validate checks the field values on InstanceEventChange with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *InstanceEventChange) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on InstanceEventChange with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// InstanceEventChangeMultiError, or nil if none found.
+func (m *InstanceEventChange) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *InstanceEventChange) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	// no validation rules for Before
+
+	// no validation rules for After
+
+	if len(errors) > 0 {
+		return InstanceEventChangeMultiError(errors)
+	}
+
+	return nil
+}
+
+// InstanceEventChangeMultiError is an error wrapping multiple validation
+// errors returned by InstanceEventChange.ValidateAll() if the designated
+// constraints aren't met.
+type InstanceEventChangeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m InstanceEventChangeMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m InstanceEventChangeMultiError) AllErrors() []error { return m }
+
+// InstanceEventChangeValidationError is the validation error returned by
+// InstanceEventChange.Validate if the designated constraints aren't met.
+type InstanceEventChangeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e InstanceEventChangeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e InstanceEventChangeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e InstanceEventChangeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e InstanceEventChangeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e InstanceEventChangeValidationError) ErrorName() string {
+	return "InstanceEventChangeValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e InstanceEventChangeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sInstanceEventChange.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = InstanceEventChangeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = InstanceEventChangeValidationError{}
+
+// Validate checks the field values on ManagementPlaneRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ManagementPlaneRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ManagementPlaneRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ManagementPlaneRequestMultiError, or nil if none found.
+func (m *ManagementPlaneRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ManagementPlaneRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetMessageMeta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ManagementPlaneRequestValidationError{
+					field:  "MessageMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ManagementPlaneRequestValidationError{
+					field:  "MessageMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMessageMeta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ManagementPlaneRequestValidationError{
+				field:  "MessageMeta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	switch v := m.Request.(type) {
+	case *ManagementPlaneRequest_StatusRequest:
+		if v == nil {
+			err := ManagementPlaneRequestValidationError{
+				field:  "Request",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetStatusRequest()).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
 					errors = append(errors, ManagementPlaneRequestValidationError{
-						field:  "ConfigApplyRequest",
+						field:  "StatusRequest",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
@@ -2073,23 +2452,23 @@ func (m *ManagementPlaneRequest) validate(all bool) error {
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
 					errors = append(errors, ManagementPlaneRequestValidationError{
-						field:  "ConfigApplyRequest",
+						field:  "StatusRequest",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			}
-		} else if v, ok := interface{}(m.GetConfigApplyRequest()).(interface{ Validate() error }); ok {
+		} else if v, ok := interface{}(m.GetStatusRequest()).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
 				return ManagementPlaneRequestValidationError{
-					field:  "ConfigApplyRequest",
+					field:  "StatusRequest",
 					reason: "embedded message failed validation",
 					cause:  err,
 				}
 			}
 		}
 
-	case *ManagementPlaneRequest_ConfigUploadRequest:
+	case *ManagementPlaneRequest_HealthRequest:
 		if v == nil {
 			err := ManagementPlaneRequestValidationError{
 				field:  "Request",
@@ -2102,11 +2481,11 @@ func (m *ManagementPlaneRequest) validate(all bool) error {
 		}
 
 		if all {
-			switch v := interface{}(m.GetConfigUploadRequest()).(type) {
+			switch v := interface{}(m.GetHealthRequest()).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
 					errors = append(errors, ManagementPlaneRequestValidationError{
-						field:  "ConfigUploadRequest",
+						field:  "HealthRequest",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
@@ -2114,23 +2493,23 @@ func (m *ManagementPlaneRequest) validate(all bool) error {
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
 					errors = append(errors, ManagementPlaneRequestValidationError{
-						field:  "ConfigUploadRequest",
+						field:  "HealthRequest",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			}
-		} else if v, ok := interface{}(m.GetConfigUploadRequest()).(interface{ Validate() error }); ok {
+		} else if v, ok := interface{}(m.GetHealthRequest()).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
 				return ManagementPlaneRequestValidationError{
-					field:  "ConfigUploadRequest",
+					field:  "HealthRequest",
 					reason: "embedded message failed validation",
 					cause:  err,
 				}
 			}
 		}
 
-	case *ManagementPlaneRequest_ActionRequest:
+	case *ManagementPlaneRequest_ConfigApplyRequest:
 		if v == nil {
 			err := ManagementPlaneRequestValidationError{
 				field:  "Request",
@@ -2143,11 +2522,11 @@ func (m *ManagementPlaneRequest) validate(all bool) error {
 		}
 
 		if all {
-			switch v := interface{}(m.GetActionRequest()).(type) {
+			switch v := interface{}(m.GetConfigApplyRequest()).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
 					errors = append(errors, ManagementPlaneRequestValidationError{
-						field:  "ActionRequest",
+						field:  "ConfigApplyRequest",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
@@ -2155,23 +2534,23 @@ func (m *ManagementPlaneRequest) validate(all bool) error {
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
 					errors = append(errors, ManagementPlaneRequestValidationError{
-						field:  "ActionRequest",
+						field:  "ConfigApplyRequest",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			}
-		} else if v, ok := interface{}(m.GetActionRequest()).(interface{ Validate() error }); ok {
+		} else if v, ok := interface{}(m.GetConfigApplyRequest()).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
 				return ManagementPlaneRequestValidationError{
-					field:  "ActionRequest",
+					field:  "ConfigApplyRequest",
 					reason: "embedded message failed validation",
 					cause:  err,
 				}
 			}
 		}
 
-	case *ManagementPlaneRequest_CommandStatusRequest:
+	case *ManagementPlaneRequest_ConfigUploadRequest:
 		if v == nil {
 			err := ManagementPlaneRequestValidationError{
 				field:  "Request",
@@ -2184,11 +2563,11 @@ func (m *ManagementPlaneRequest) validate(all bool) error {
 		}
 
 		if all {
-			switch v := interface{}(m.GetCommandStatusRequest()).(type) {
+			switch v := interface{}(m.GetConfigUploadRequest()).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
 					errors = append(errors, ManagementPlaneRequestValidationError{
-						field:  "CommandStatusRequest",
+						field:  "ConfigUploadRequest",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
@@ -2196,23 +2575,23 @@ func (m *ManagementPlaneRequest) validate(all bool) error {
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
 					errors = append(errors, ManagementPlaneRequestValidationError{
-						field:  "CommandStatusRequest",
+						field:  "ConfigUploadRequest",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			}
-		} else if v, ok := interface{}(m.GetCommandStatusRequest()).(interface{ Validate() error }); ok {
+		} else if v, ok := interface{}(m.GetConfigUploadRequest()).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
 				return ManagementPlaneRequestValidationError{
-					field:  "CommandStatusRequest",
+					field:  "ConfigUploadRequest",
 					reason: "embedded message failed validation",
 					cause:  err,
 				}
 			}
 		}
 
-	case *ManagementPlaneRequest_UpdateAgentConfigRequest:
+	case *ManagementPlaneRequest_ActionRequest:
 		if v == nil {
 			err := ManagementPlaneRequestValidationError{
 				field:  "Request",
@@ -2225,11 +2604,11 @@ func (m *ManagementPlaneRequest) validate(all bool) error {
 		}
 
 		if all {
-			switch v := interface{}(m.GetUpdateAgentConfigRequest()).(type) {
+			switch v := interface{}(m.GetActionRequest()).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
 					errors = append(errors, ManagementPlaneRequestValidationError{
-						field:  "UpdateAgentConfigRequest",
+						field:  "ActionRequest",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
@@ -2237,16 +2616,221 @@ func (m *ManagementPlaneRequest) validate(all bool) error {
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
 					errors = append(errors, ManagementPlaneRequestValidationError{
-						field:  "UpdateAgentConfigRequest",
+						field:  "ActionRequest",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			}
-		} else if v, ok := interface{}(m.GetUpdateAgentConfigRequest()).(interface{ Validate() error }); ok {
+		} else if v, ok := interface{}(m.GetActionRequest()).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
 				return ManagementPlaneRequestValidationError{
-					field:  "UpdateAgentConfigRequest",
+					field:  "ActionRequest",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *ManagementPlaneRequest_CommandStatusRequest:
+		if v == nil {
+			err := ManagementPlaneRequestValidationError{
+				field:  "Request",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetCommandStatusRequest()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ManagementPlaneRequestValidationError{
+						field:  "CommandStatusRequest",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ManagementPlaneRequestValidationError{
+						field:  "CommandStatusRequest",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetCommandStatusRequest()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ManagementPlaneRequestValidationError{
+					field:  "CommandStatusRequest",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *ManagementPlaneRequest_UpdateAgentConfigRequest:
+		if v == nil {
+			err := ManagementPlaneRequestValidationError{
+				field:  "Request",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetUpdateAgentConfigRequest()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ManagementPlaneRequestValidationError{
+						field:  "UpdateAgentConfigRequest",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ManagementPlaneRequestValidationError{
+						field:  "UpdateAgentConfigRequest",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetUpdateAgentConfigRequest()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ManagementPlaneRequestValidationError{
+					field:  "UpdateAgentConfigRequest",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *ManagementPlaneRequest_ConfigValidateRequest:
+		if v == nil {
+			err := ManagementPlaneRequestValidationError{
+				field:  "Request",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetConfigValidateRequest()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ManagementPlaneRequestValidationError{
+						field:  "ConfigValidateRequest",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ManagementPlaneRequestValidationError{
+						field:  "ConfigValidateRequest",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetConfigValidateRequest()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ManagementPlaneRequestValidationError{
+					field:  "ConfigValidateRequest",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *ManagementPlaneRequest_ConfigDiffRequest:
+		if v == nil {
+			err := ManagementPlaneRequestValidationError{
+				field:  "Request",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetConfigDiffRequest()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ManagementPlaneRequestValidationError{
+						field:  "ConfigDiffRequest",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ManagementPlaneRequestValidationError{
+						field:  "ConfigDiffRequest",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetConfigDiffRequest()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ManagementPlaneRequestValidationError{
+					field:  "ConfigDiffRequest",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *ManagementPlaneRequest_ConfigHistoryRequest:
+		if v == nil {
+			err := ManagementPlaneRequestValidationError{
+				field:  "Request",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetConfigHistoryRequest()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ManagementPlaneRequestValidationError{
+						field:  "ConfigHistoryRequest",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ManagementPlaneRequestValidationError{
+						field:  "ConfigHistoryRequest",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetConfigHistoryRequest()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ManagementPlaneRequestValidationError{
+					field:  "ConfigHistoryRequest",
 					reason: "embedded message failed validation",
 					cause:  err,
 				}
@@ -2300,15 +2884,610 @@ func (e ManagementPlaneRequestValidationError) Reason() string { return e.reason
 func (e ManagementPlaneRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ManagementPlaneRequestValidationError) Key() bool { return e.key }
+func (e ManagementPlaneRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ManagementPlaneRequestValidationError) ErrorName() string {
+	return "ManagementPlaneRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ManagementPlaneRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sManagementPlaneRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ManagementPlaneRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ManagementPlaneRequestValidationError{}
+
+// Validate checks the field values on StatusRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *StatusRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StatusRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in StatusRequestMultiError, or
+// nil if none found.
+func (m *StatusRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StatusRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return StatusRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// StatusRequestMultiError is an error wrapping multiple validation errors
+// returned by StatusRequest.ValidateAll() if the designated constraints
+// aren't met.
+type StatusRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StatusRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StatusRequestMultiError) AllErrors() []error { return m }
+
+// StatusRequestValidationError is the validation error returned by
+// StatusRequest.Validate if the designated constraints aren't met.
+type StatusRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StatusRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StatusRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StatusRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StatusRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StatusRequestValidationError) ErrorName() string { return "StatusRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e StatusRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStatusRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StatusRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StatusRequestValidationError{}
+
+// Validate checks the field values on HealthRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *HealthRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on HealthRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in HealthRequestMultiError, or
+// nil if none found.
+func (m *HealthRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *HealthRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return HealthRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// HealthRequestMultiError is an error wrapping multiple validation errors
+// returned by HealthRequest.ValidateAll() if the designated constraints
+// aren't met.
+type HealthRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m HealthRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m HealthRequestMultiError) AllErrors() []error { return m }
+
+// HealthRequestValidationError is the validation error returned by
+// HealthRequest.Validate if the designated constraints aren't met.
+type HealthRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e HealthRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e HealthRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e HealthRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e HealthRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e HealthRequestValidationError) ErrorName() string { return "HealthRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e HealthRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sHealthRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = HealthRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = HealthRequestValidationError{}
+
+// Validate checks the field values on ConfigApplyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ConfigApplyRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ConfigApplyRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ConfigApplyRequestMultiError, or nil if none found.
+func (m *ConfigApplyRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ConfigApplyRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetOverview()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfigApplyRequestValidationError{
+					field:  "Overview",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfigApplyRequestValidationError{
+					field:  "Overview",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOverview()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfigApplyRequestValidationError{
+				field:  "Overview",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ConfigApplyRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ConfigApplyRequestMultiError is an error wrapping multiple validation errors
+// returned by ConfigApplyRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ConfigApplyRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ConfigApplyRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ConfigApplyRequestMultiError) AllErrors() []error { return m }
+
+// ConfigApplyRequestValidationError is the validation error returned by
+// ConfigApplyRequest.Validate if the designated constraints aren't met.
+type ConfigApplyRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ConfigApplyRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ConfigApplyRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ConfigApplyRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ConfigApplyRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ConfigApplyRequestValidationError) ErrorName() string {
+	return "ConfigApplyRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ConfigApplyRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sConfigApplyRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ConfigApplyRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ConfigApplyRequestValidationError{}
+
+// Validate checks the field values on ConfigValidateRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ConfigValidateRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ConfigValidateRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ConfigValidateRequestMultiError, or nil if none found.
+func (m *ConfigValidateRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ConfigValidateRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetOverview()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfigValidateRequestValidationError{
+					field:  "Overview",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfigValidateRequestValidationError{
+					field:  "Overview",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOverview()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfigValidateRequestValidationError{
+				field:  "Overview",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ConfigValidateRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ConfigValidateRequestMultiError is an error wrapping multiple validation
+// errors returned by ConfigValidateRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ConfigValidateRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ConfigValidateRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ConfigValidateRequestMultiError) AllErrors() []error { return m }
+
+// ConfigValidateRequestValidationError is the validation error returned by
+// ConfigValidateRequest.Validate if the designated constraints aren't met.
+type ConfigValidateRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ConfigValidateRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ConfigValidateRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ConfigValidateRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ConfigValidateRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ConfigValidateRequestValidationError) ErrorName() string {
+	return "ConfigValidateRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ConfigValidateRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sConfigValidateRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ConfigValidateRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ConfigValidateRequestValidationError{}
+
+// Validate checks the field values on ConfigDiffRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ConfigDiffRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ConfigDiffRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ConfigDiffRequestMultiError, or nil if none found.
+func (m *ConfigDiffRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ConfigDiffRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetOverview()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfigDiffRequestValidationError{
+					field:  "Overview",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfigDiffRequestValidationError{
+					field:  "Overview",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOverview()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfigDiffRequestValidationError{
+				field:  "Overview",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for IncludeTextDiff
+
+	if len(errors) > 0 {
+		return ConfigDiffRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ConfigDiffRequestMultiError is an error wrapping multiple validation errors
+// returned by ConfigDiffRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ConfigDiffRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ConfigDiffRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ConfigDiffRequestMultiError) AllErrors() []error { return m }
+
+// ConfigDiffRequestValidationError is the validation error returned by
+// ConfigDiffRequest.Validate if the designated constraints aren't met.
+type ConfigDiffRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ConfigDiffRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ConfigDiffRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ConfigDiffRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ConfigDiffRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ManagementPlaneRequestValidationError) ErrorName() string {
-	return "ManagementPlaneRequestValidationError"
+func (e ConfigDiffRequestValidationError) ErrorName() string {
+	return "ConfigDiffRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ManagementPlaneRequestValidationError) Error() string {
+func (e ConfigDiffRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2320,14 +3499,14 @@ func (e ManagementPlaneRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sManagementPlaneRequest.%s: %s%s",
+		"invalid %sConfigDiffRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ManagementPlaneRequestValidationError{}
+var _ error = ConfigDiffRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2335,44 +3514,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ManagementPlaneRequestValidationError{}
+} = ConfigDiffRequestValidationError{}
 
-// Validate checks the field values on StatusRequest with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *StatusRequest) Validate() error {
+// Validate checks the field values on ConfigHistoryRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ConfigHistoryRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on StatusRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in StatusRequestMultiError, or
-// nil if none found.
-func (m *StatusRequest) ValidateAll() error {
+// ValidateAll checks the field values on ConfigHistoryRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ConfigHistoryRequestMultiError, or nil if none found.
+func (m *ConfigHistoryRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *StatusRequest) validate(all bool) error {
+func (m *ConfigHistoryRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
+	// no validation rules for InstanceId
+
+	// no validation rules for RollbackVersion
+
 	if len(errors) > 0 {
-		return StatusRequestMultiError(errors)
+		return ConfigHistoryRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// StatusRequestMultiError is an error wrapping multiple validation errors
-// returned by StatusRequest.ValidateAll() if the designated constraints
-// aren't met.
-type StatusRequestMultiError []error
+// ConfigHistoryRequestMultiError is an error wrapping multiple validation
+// errors returned by ConfigHistoryRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ConfigHistoryRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m StatusRequestMultiError) Error() string {
+func (m ConfigHistoryRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2381,11 +3564,11 @@ func (m StatusRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m StatusRequestMultiError) AllErrors() []error { return m }
+func (m ConfigHistoryRequestMultiError) AllErrors() []error { return m }
 
-// StatusRequestValidationError is the validation error returned by
-// StatusRequest.Validate if the designated constraints aren't met.
-type StatusRequestValidationError struct {
+// ConfigHistoryRequestValidationError is the validation error returned by
+// ConfigHistoryRequest.Validate if the designated constraints aren't met.
+type ConfigHistoryRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2393,22 +3576,24 @@ type StatusRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e StatusRequestValidationError) Field() string { return e.field }
+func (e ConfigHistoryRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e StatusRequestValidationError) Reason() string { return e.reason }
+func (e ConfigHistoryRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e StatusRequestValidationError) Cause() error { return e.cause }
+func (e ConfigHistoryRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e StatusRequestValidationError) Key() bool { return e.key }
+func (e ConfigHistoryRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e StatusRequestValidationError) ErrorName() string { return "StatusRequestValidationError" }
+func (e ConfigHistoryRequestValidationError) ErrorName() string {
+	return "ConfigHistoryRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e StatusRequestValidationError) Error() string {
+func (e ConfigHistoryRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2420,14 +3605,14 @@ func (e StatusRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sStatusRequest.%s: %s%s",
+		"invalid %sConfigHistoryRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = StatusRequestValidationError{}
+var _ error = ConfigHistoryRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -2435,44 +3620,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = StatusRequestValidationError{}
+} = ConfigHistoryRequestValidationError{}
 
-// Validate checks the field values on HealthRequest with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *HealthRequest) Validate() error {
+// Validate checks the field values on ConfigValidateResult with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ConfigValidateResult) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on HealthRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in HealthRequestMultiError, or
-// nil if none found.
-func (m *HealthRequest) ValidateAll() error {
+// ValidateAll checks the field values on ConfigValidateResult with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ConfigValidateResultMultiError, or nil if none found.
+func (m *ConfigValidateResult) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *HealthRequest) validate(all bool) error {
+func (m *ConfigValidateResult) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
+	// no validation rules for Output
+
 	if len(errors) > 0 {
-		return HealthRequestMultiError(errors)
+		return ConfigValidateResultMultiError(errors)
 	}
 
 	return nil
 }
 
-// HealthRequestMultiError is an error wrapping multiple validation errors
-// returned by HealthRequest.ValidateAll() if the designated constraints
-// aren't met.
-type HealthRequestMultiError []error
+// ConfigValidateResultMultiError is an error wrapping multiple validation
+// errors returned by ConfigValidateResult.ValidateAll() if the designated
+// constraints aren't met.
+type ConfigValidateResultMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m HealthRequestMultiError) Error() string {
+func (m ConfigValidateResultMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2481,11 +3668,11 @@ func (m HealthRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m HealthRequestMultiError) AllErrors() []error { return m }
+func (m ConfigValidateResultMultiError) AllErrors() []error { return m }
 
-// HealthRequestValidationError is the validation error returned by
-// HealthRequest.Validate if the designated constraints aren't met.
-type HealthRequestValidationError struct {
+// ConfigValidateResultValidationError is the validation error returned by
+// ConfigValidateResult.Validate if the designated constraints aren't met.
+type ConfigValidateResultValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2493,22 +3680,24 @@ type HealthRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e HealthRequestValidationError) Field() string { return e.field }
+func (e ConfigValidateResultValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e HealthRequestValidationError) Reason() string { return e.reason }
+func (e ConfigValidateResultValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e HealthRequestValidationError) Cause() error { return e.cause }
+func (e ConfigValidateResultValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e HealthRequestValidationError) Key() bool { return e.key }
+func (e ConfigValidateResultValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e HealthRequestValidationError) ErrorName() string { return "HealthRequestValidationError" }
+func (e ConfigValidateResultValidationError) ErrorName() string {
+	return "ConfigValidateResultValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e HealthRequestValidationError) Error() string {
+func (e ConfigValidateResultValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2520,14 +3709,14 @@ func (e HealthRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sHealthRequest.%s: %s%s",
+		"invalid %sConfigValidateResult.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = HealthRequestValidationError{}
+var _ error = ConfigValidateResultValidationError{}
 
 var _ interface {
 	Field() string
@@ -2535,73 +3724,52 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = HealthRequestValidationError{}
+} = ConfigValidateResultValidationError{}
 
-// Validate checks the field values on ConfigApplyRequest with the rules
+// Validate checks the field values on ConfigApplyHookResult with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *ConfigApplyRequest) Validate() error {
+func (m *ConfigApplyHookResult) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ConfigApplyRequest with the rules
+// ValidateAll checks the field values on ConfigApplyHookResult with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ConfigApplyRequestMultiError, or nil if none found.
-func (m *ConfigApplyRequest) ValidateAll() error {
+// ConfigApplyHookResultMultiError, or nil if none found.
+func (m *ConfigApplyHookResult) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ConfigApplyRequest) validate(all bool) error {
+func (m *ConfigApplyHookResult) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetOverview()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, ConfigApplyRequestValidationError{
-					field:  "Overview",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, ConfigApplyRequestValidationError{
-					field:  "Overview",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetOverview()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return ConfigApplyRequestValidationError{
-				field:  "Overview",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for Phase
+
+	// no validation rules for Path
+
+	// no validation rules for ExitCode
+
+	// no validation rules for Output
 
 	if len(errors) > 0 {
-		return ConfigApplyRequestMultiError(errors)
+		return ConfigApplyHookResultMultiError(errors)
 	}
 
 	return nil
 }
 
-// ConfigApplyRequestMultiError is an error wrapping multiple validation errors
-// returned by ConfigApplyRequest.ValidateAll() if the designated constraints
-// aren't met.
-type ConfigApplyRequestMultiError []error
+// ConfigApplyHookResultMultiError is an error wrapping multiple validation
+// errors returned by ConfigApplyHookResult.ValidateAll() if the designated
+// constraints aren't met.
+type ConfigApplyHookResultMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ConfigApplyRequestMultiError) Error() string {
+func (m ConfigApplyHookResultMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2610,11 +3778,11 @@ func (m ConfigApplyRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ConfigApplyRequestMultiError) AllErrors() []error { return m }
+func (m ConfigApplyHookResultMultiError) AllErrors() []error { return m }
 
-// ConfigApplyRequestValidationError is the validation error returned by
-// ConfigApplyRequest.Validate if the designated constraints aren't met.
-type ConfigApplyRequestValidationError struct {
+// ConfigApplyHookResultValidationError is the validation error returned by
+// ConfigApplyHookResult.Validate if the designated constraints aren't met.
+type ConfigApplyHookResultValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2622,24 +3790,24 @@ type ConfigApplyRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e ConfigApplyRequestValidationError) Field() string { return e.field }
+func (e ConfigApplyHookResultValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ConfigApplyRequestValidationError) Reason() string { return e.reason }
+func (e ConfigApplyHookResultValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ConfigApplyRequestValidationError) Cause() error { return e.cause }
+func (e ConfigApplyHookResultValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ConfigApplyRequestValidationError) Key() bool { return e.key }
+func (e ConfigApplyHookResultValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ConfigApplyRequestValidationError) ErrorName() string {
-	return "ConfigApplyRequestValidationError"
+func (e ConfigApplyHookResultValidationError) ErrorName() string {
+	return "ConfigApplyHookResultValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ConfigApplyRequestValidationError) Error() string {
+func (e ConfigApplyHookResultValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2651,14 +3819,14 @@ func (e ConfigApplyRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sConfigApplyRequest.%s: %s%s",
+		"invalid %sConfigApplyHookResult.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ConfigApplyRequestValidationError{}
+var _ error = ConfigApplyHookResultValidationError{}
 
 var _ interface {
 	Field() string
@@ -2666,7 +3834,7 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ConfigApplyRequestValidationError{}
+} = ConfigApplyHookResultValidationError{}
 
 // Validate checks the field values on ConfigUploadRequest with the rules
 // defined in the proto definition for this message. If any rules are
@@ -3860,6 +5028,8 @@ func (m *CommandStatusRequest) validate(all bool) error {
 
 	var errors []error
 
+	// no validation rules for CorrelationId
+
 	if len(errors) > 0 {
 		return CommandStatusRequestMultiError(errors)
 	}
@@ -4473,6 +5643,8 @@ func (m *InstanceRuntime) validate(all bool) error {
 
 	}
 
+	// no validation rules for ContainerId
+
 	switch v := m.Details.(type) {
 	case *InstanceRuntime_NginxRuntimeInfo:
 		if v == nil {
@@ -4597,6 +5769,47 @@ func (m *InstanceRuntime) validate(all bool) error {
 			}
 		}
 
+	case *InstanceRuntime_UnitRuntimeInfo:
+		if v == nil {
+			err := InstanceRuntimeValidationError{
+				field:  "Details",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetUnitRuntimeInfo()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, InstanceRuntimeValidationError{
+						field:  "UnitRuntimeInfo",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, InstanceRuntimeValidationError{
+						field:  "UnitRuntimeInfo",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetUnitRuntimeInfo()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return InstanceRuntimeValidationError{
+					field:  "UnitRuntimeInfo",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	default:
 		_ = v // ensures v is used
 	}
@@ -5285,6 +6498,110 @@ var _ interface {
 	ErrorName() string
 } = NGINXAppProtectRuntimeInfoValidationError{}
 
+// Validate checks the field values on UnitRuntimeInfo with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UnitRuntimeInfo) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UnitRuntimeInfo with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UnitRuntimeInfoMultiError, or nil if none found.
+func (m *UnitRuntimeInfo) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UnitRuntimeInfo) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ControlSocket
+
+	// no validation rules for Version
+
+	if len(errors) > 0 {
+		return UnitRuntimeInfoMultiError(errors)
+	}
+
+	return nil
+}
+
+// UnitRuntimeInfoMultiError is an error wrapping multiple validation errors
+// returned by UnitRuntimeInfo.ValidateAll() if the designated constraints
+// aren't met.
+type UnitRuntimeInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UnitRuntimeInfoMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UnitRuntimeInfoMultiError) AllErrors() []error { return m }
+
+// UnitRuntimeInfoValidationError is the validation error returned by
+// UnitRuntimeInfo.Validate if the designated constraints aren't met.
+type UnitRuntimeInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UnitRuntimeInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UnitRuntimeInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UnitRuntimeInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UnitRuntimeInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UnitRuntimeInfoValidationError) ErrorName() string { return "UnitRuntimeInfoValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UnitRuntimeInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUnitRuntimeInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UnitRuntimeInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UnitRuntimeInfoValidationError{}
+
 // Validate checks the field values on InstanceAction with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
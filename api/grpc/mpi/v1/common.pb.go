@@ -149,7 +149,9 @@ type MessageMeta struct {
 	// if 2 or more messages associated with the same workflow, use this field as an association
 	CorrelationId string `protobuf:"bytes,2,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
 	// timestamp for human readable timestamp in UTC format
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// monotonically increasing index of a management plane request in the management plane queue, 0 if not indexed
+	Index         int64 `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -205,6 +207,13 @@ func (x *MessageMeta) GetTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *MessageMeta) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
 // Represents a the status response of an command
 type CommandResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -456,12 +465,13 @@ var File_mpi_v1_common_proto protoreflect.FileDescriptor
 
 const file_mpi_v1_common_proto_rawDesc = "" +
 	"\n" +
-	"\x13mpi/v1/common.proto\x12\x06mpi.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bbuf/validate/validate.proto\"\x8d\x01\n" +
+	"\x13mpi/v1/common.proto\x12\x06mpi.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bbuf/validate/validate.proto\"\xa3\x01\n" +
 	"\vMessageMeta\x12\x1d\n" +
 	"\n" +
 	"message_id\x18\x01 \x01(\tR\tmessageId\x12%\n" +
 	"\x0ecorrelation_id\x18\x02 \x01(\tR\rcorrelationId\x128\n" +
-	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\x9f\x02\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x14\n" +
+	"\x05index\x18\x04 \x01(\x03R\x05index\"\x9f\x02\n" +
 	"\x0fCommandResponse\x12=\n" +
 	"\x06status\x18\x01 \x01(\x0e2%.mpi.v1.CommandResponse.CommandStatusR\x06status\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
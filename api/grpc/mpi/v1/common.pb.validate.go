@@ -90,6 +90,8 @@ func (m *MessageMeta) validate(all bool) error {
 		}
 	}
 
+	// no validation rules for Index
+
 	if len(errors) > 0 {
 		return MessageMetaMultiError(errors)
 	}
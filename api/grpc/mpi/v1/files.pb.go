@@ -15,6 +15,7 @@ import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -28,6 +29,124 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Enum to represent the actions a config apply can perform on a file
+type FileAction int32
+
+const (
+	// Unspecified file action
+	FileAction_FILE_ACTION_UNSPECIFIED FileAction = 0
+	// The file is not changed
+	FileAction_FILE_ACTION_UNCHANGED FileAction = 1
+	// The file is added
+	FileAction_FILE_ACTION_ADD FileAction = 2
+	// The file is updated
+	FileAction_FILE_ACTION_UPDATE FileAction = 3
+	// The file is deleted
+	FileAction_FILE_ACTION_DELETE FileAction = 4
+	// The file is downloaded from an external data source
+	FileAction_FILE_ACTION_EXTERNAL FileAction = 5
+)
+
+// Enum value maps for FileAction.
+var (
+	FileAction_name = map[int32]string{
+		0: "FILE_ACTION_UNSPECIFIED",
+		1: "FILE_ACTION_UNCHANGED",
+		2: "FILE_ACTION_ADD",
+		3: "FILE_ACTION_UPDATE",
+		4: "FILE_ACTION_DELETE",
+		5: "FILE_ACTION_EXTERNAL",
+	}
+	FileAction_value = map[string]int32{
+		"FILE_ACTION_UNSPECIFIED": 0,
+		"FILE_ACTION_UNCHANGED":   1,
+		"FILE_ACTION_ADD":         2,
+		"FILE_ACTION_UPDATE":      3,
+		"FILE_ACTION_DELETE":      4,
+		"FILE_ACTION_EXTERNAL":    5,
+	}
+)
+
+func (x FileAction) Enum() *FileAction {
+	p := new(FileAction)
+	*p = x
+	return p
+}
+
+func (x FileAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FileAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_mpi_v1_files_proto_enumTypes[0].Descriptor()
+}
+
+func (FileAction) Type() protoreflect.EnumType {
+	return &file_mpi_v1_files_proto_enumTypes[0]
+}
+
+func (x FileAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FileAction.Descriptor instead.
+func (FileAction) EnumDescriptor() ([]byte, []int) {
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{0}
+}
+
+// Enum to represent the compression of file contents
+type Compression int32
+
+const (
+	// The file contents are not compressed
+	Compression_COMPRESSION_UNSPECIFIED Compression = 0
+	// The file contents are compressed with gzip
+	Compression_COMPRESSION_GZIP Compression = 1
+	// The file contents are compressed with zstd
+	Compression_COMPRESSION_ZSTD Compression = 2
+)
+
+// Enum value maps for Compression.
+var (
+	Compression_name = map[int32]string{
+		0: "COMPRESSION_UNSPECIFIED",
+		1: "COMPRESSION_GZIP",
+		2: "COMPRESSION_ZSTD",
+	}
+	Compression_value = map[string]int32{
+		"COMPRESSION_UNSPECIFIED": 0,
+		"COMPRESSION_GZIP":        1,
+		"COMPRESSION_ZSTD":        2,
+	}
+)
+
+func (x Compression) Enum() *Compression {
+	p := new(Compression)
+	*p = x
+	return p
+}
+
+func (x Compression) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Compression) Descriptor() protoreflect.EnumDescriptor {
+	return file_mpi_v1_files_proto_enumTypes[1].Descriptor()
+}
+
+func (Compression) Type() protoreflect.EnumType {
+	return &file_mpi_v1_files_proto_enumTypes[1]
+}
+
+func (x Compression) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Compression.Descriptor instead.
+func (Compression) EnumDescriptor() ([]byte, []int) {
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{1}
+}
+
 // Enum to represent the possible signature algorithms used for certificates
 type SignatureAlgorithm int32
 
@@ -121,11 +240,11 @@ func (x SignatureAlgorithm) String() string {
 }
 
 func (SignatureAlgorithm) Descriptor() protoreflect.EnumDescriptor {
-	return file_mpi_v1_files_proto_enumTypes[0].Descriptor()
+	return file_mpi_v1_files_proto_enumTypes[2].Descriptor()
 }
 
 func (SignatureAlgorithm) Type() protoreflect.EnumType {
-	return &file_mpi_v1_files_proto_enumTypes[0]
+	return &file_mpi_v1_files_proto_enumTypes[2]
 }
 
 func (x SignatureAlgorithm) Number() protoreflect.EnumNumber {
@@ -134,20 +253,23 @@ func (x SignatureAlgorithm) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SignatureAlgorithm.Descriptor instead.
 func (SignatureAlgorithm) EnumDescriptor() ([]byte, []int) {
-	return file_mpi_v1_files_proto_rawDescGZIP(), []int{0}
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{2}
 }
 
 // Represents a data chunk for streaming file transfer.
 // For any Stream file transfer, following assumptions should be asserted (by implementation):
-// - invalid to contain more or less than one FileDataChunkHeaders
-// - invalid to have FileDataChunkContents before FileDataChunkHeaders
-// - invalid to have more/fewer FileDataChunkContents than FileDataChunkHeader.chunks
-// - invalid to have two FileDataChunkContents with same chunk_id
-// - invalid to have FileDataChunkContent with zero-length data
-// - invalid to have FileDataChunk message without either header or content
-// - hash of the combined contents should match FileDataChunkHeader.file_meta.hash
-// - total size of the combined contents should match FileDataChunkHeader.file_meta.size
-// - chunk_size should be less than the gRPC max message size
+//   - invalid to contain more or less than one FileDataChunkHeaders
+//   - invalid to have FileDataChunkContents before FileDataChunkHeaders
+//   - invalid to have more/fewer FileDataChunkContents than FileDataChunkHeader.chunks
+//   - invalid to have two FileDataChunkContents with same chunk_id
+//   - invalid to have FileDataChunkContent with zero-length data
+//   - invalid to have FileDataChunk message without either header or content
+//   - hash of the combined contents should match FileDataChunkHeader.file_meta.hash
+//   - total size of the combined contents should match FileDataChunkHeader.file_meta.size,
+//     less FileDataChunkHeader.offset if the stream is resumed
+//   - chunk_size should be less than the gRPC max message size
+//
+// For a delta file transfer, FileDataChunkDeltas are sent instead of FileDataChunkContents
 type FileDataChunk struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// meta regarding the transfer request
@@ -156,6 +278,7 @@ type FileDataChunk struct {
 	//
 	//	*FileDataChunk_Header
 	//	*FileDataChunk_Content
+	//	*FileDataChunk_Delta
 	Chunk         isFileDataChunk_Chunk `protobuf_oneof:"chunk"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -223,6 +346,15 @@ func (x *FileDataChunk) GetContent() *FileDataChunkContent {
 	return nil
 }
 
+func (x *FileDataChunk) GetDelta() *FileDataChunkDelta {
+	if x != nil {
+		if x, ok := x.Chunk.(*FileDataChunk_Delta); ok {
+			return x.Delta
+		}
+	}
+	return nil
+}
+
 type isFileDataChunk_Chunk interface {
 	isFileDataChunk_Chunk()
 }
@@ -237,10 +369,17 @@ type FileDataChunk_Content struct {
 	Content *FileDataChunkContent `protobuf:"bytes,3,opt,name=content,proto3,oneof"`
 }
 
+type FileDataChunk_Delta struct {
+	// Chunk delta instruction
+	Delta *FileDataChunkDelta `protobuf:"bytes,4,opt,name=delta,proto3,oneof"`
+}
+
 func (*FileDataChunk_Header) isFileDataChunk_Chunk() {}
 
 func (*FileDataChunk_Content) isFileDataChunk_Chunk() {}
 
+func (*FileDataChunk_Delta) isFileDataChunk_Chunk() {}
+
 // Represents a chunked resource Header
 type FileDataChunkHeader struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -250,7 +389,19 @@ type FileDataChunkHeader struct {
 	// total number of chunks expected in the transfer
 	Chunks uint32 `protobuf:"varint,2,opt,name=chunks,proto3" json:"chunks,omitempty"`
 	// max size of individual chunks, can be undersized if EOF
-	ChunkSize     uint32 `protobuf:"varint,3,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	ChunkSize uint32 `protobuf:"varint,3,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	// If true, the chunks are delta instructions that rebuild the file from the blocks of the copy of the file
+	// on the data plane, described by the signature of the GetFileRequest.
+	// Can only be set if the GetFileRequest contains a signature.
+	Delta bool `protobuf:"varint,4,opt,name=delta,proto3" json:"delta,omitempty"`
+	// The offset in bytes of the file content sent in the chunks, if the MP resumes an interrupted stream at the
+	// offset of the GetFileRequest. chunks only covers the file content after the offset.
+	// If 0, the full file content is sent.
+	Offset uint64 `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	// The compression of the data of each chunk. Each chunk is compressed independently, chunk_size is the max
+	// size of the decompressed data of a chunk.
+	// Can only be a compression accepted by the GetFileRequest, or any compression for an UpdateFileStream.
+	Compression   Compression `protobuf:"varint,6,opt,name=compression,proto3,enum=mpi.v1.Compression" json:"compression,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -306,12 +457,33 @@ func (x *FileDataChunkHeader) GetChunkSize() uint32 {
 	return 0
 }
 
+func (x *FileDataChunkHeader) GetDelta() bool {
+	if x != nil {
+		return x.Delta
+	}
+	return false
+}
+
+func (x *FileDataChunkHeader) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *FileDataChunkHeader) GetCompression() Compression {
+	if x != nil {
+		return x.Compression
+	}
+	return Compression_COMPRESSION_UNSPECIFIED
+}
+
 // Represents a chunked resource chunk
 type FileDataChunkContent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// chunk id, i.e. x of y, zero-indexed
 	ChunkId uint32 `protobuf:"varint,1,opt,name=chunk_id,json=chunkId,proto3" json:"chunk_id,omitempty"`
-	// chunk data, should be at most chunk_size
+	// chunk data, should be at most chunk_size once decompressed
 	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -361,31 +533,35 @@ func (x *FileDataChunkContent) GetData() []byte {
 	return nil
 }
 
-// Represents a request payload for a file overview
-type GetOverviewRequest struct {
+// Represents a delta instruction of a delta file transfer.
+// The file is rebuilt by applying the instructions in the order of their chunk ids.
+type FileDataChunkDelta struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Meta-information associated with a message
-	MessageMeta *MessageMeta `protobuf:"bytes,1,opt,name=message_meta,json=messageMeta,proto3" json:"message_meta,omitempty"`
-	// The config version of the overview you are requesting
-	ConfigVersion *ConfigVersion `protobuf:"bytes,2,opt,name=config_version,json=configVersion,proto3" json:"config_version,omitempty"`
+	// chunk id, i.e. x of y, zero-indexed
+	ChunkId uint32 `protobuf:"varint,1,opt,name=chunk_id,json=chunkId,proto3" json:"chunk_id,omitempty"`
+	// Types that are valid to be assigned to Instruction:
+	//
+	//	*FileDataChunkDelta_Copy
+	//	*FileDataChunkDelta_Data
+	Instruction   isFileDataChunkDelta_Instruction `protobuf_oneof:"instruction"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOverviewRequest) Reset() {
-	*x = GetOverviewRequest{}
+func (x *FileDataChunkDelta) Reset() {
+	*x = FileDataChunkDelta{}
 	mi := &file_mpi_v1_files_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOverviewRequest) String() string {
+func (x *FileDataChunkDelta) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOverviewRequest) ProtoMessage() {}
+func (*FileDataChunkDelta) ProtoMessage() {}
 
-func (x *GetOverviewRequest) ProtoReflect() protoreflect.Message {
+func (x *FileDataChunkDelta) ProtoReflect() protoreflect.Message {
 	mi := &file_mpi_v1_files_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -397,48 +573,87 @@ func (x *GetOverviewRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOverviewRequest.ProtoReflect.Descriptor instead.
-func (*GetOverviewRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use FileDataChunkDelta.ProtoReflect.Descriptor instead.
+func (*FileDataChunkDelta) Descriptor() ([]byte, []int) {
 	return file_mpi_v1_files_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetOverviewRequest) GetMessageMeta() *MessageMeta {
+func (x *FileDataChunkDelta) GetChunkId() uint32 {
 	if x != nil {
-		return x.MessageMeta
+		return x.ChunkId
+	}
+	return 0
+}
+
+func (x *FileDataChunkDelta) GetInstruction() isFileDataChunkDelta_Instruction {
+	if x != nil {
+		return x.Instruction
 	}
 	return nil
 }
 
-func (x *GetOverviewRequest) GetConfigVersion() *ConfigVersion {
+func (x *FileDataChunkDelta) GetCopy() *FileBlockCopy {
 	if x != nil {
-		return x.ConfigVersion
+		if x, ok := x.Instruction.(*FileDataChunkDelta_Copy); ok {
+			return x.Copy
+		}
 	}
 	return nil
 }
 
-// Represents the response payload to a GetOverviewRequest, requesting a list of logically grouped files e.g. configuration payload
-type GetOverviewResponse struct {
+func (x *FileDataChunkDelta) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Instruction.(*FileDataChunkDelta_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+type isFileDataChunkDelta_Instruction interface {
+	isFileDataChunkDelta_Instruction()
+}
+
+type FileDataChunkDelta_Copy struct {
+	// Copy blocks from the copy of the file on the data plane
+	Copy *FileBlockCopy `protobuf:"bytes,2,opt,name=copy,proto3,oneof"`
+}
+
+type FileDataChunkDelta_Data struct {
+	// Literal data that is not in the copy of the file on the data plane, should be at most chunk_size
+	// once decompressed
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3,oneof"`
+}
+
+func (*FileDataChunkDelta_Copy) isFileDataChunkDelta_Instruction() {}
+
+func (*FileDataChunkDelta_Data) isFileDataChunkDelta_Instruction() {}
+
+// Represents a run of consecutive blocks of the copy of the file on the data plane
+type FileBlockCopy struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The file overview of an instance
-	Overview      *FileOverview `protobuf:"bytes,1,opt,name=overview,proto3" json:"overview,omitempty"`
+	// index of the first block, zero-indexed
+	BlockIndex uint32 `protobuf:"varint,1,opt,name=block_index,json=blockIndex,proto3" json:"block_index,omitempty"`
+	// number of consecutive blocks
+	BlockCount    uint32 `protobuf:"varint,2,opt,name=block_count,json=blockCount,proto3" json:"block_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOverviewResponse) Reset() {
-	*x = GetOverviewResponse{}
+func (x *FileBlockCopy) Reset() {
+	*x = FileBlockCopy{}
 	mi := &file_mpi_v1_files_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOverviewResponse) String() string {
+func (x *FileBlockCopy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOverviewResponse) ProtoMessage() {}
+func (*FileBlockCopy) ProtoMessage() {}
 
-func (x *GetOverviewResponse) ProtoReflect() protoreflect.Message {
+func (x *FileBlockCopy) ProtoReflect() protoreflect.Message {
 	mi := &file_mpi_v1_files_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -450,43 +665,51 @@ func (x *GetOverviewResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOverviewResponse.ProtoReflect.Descriptor instead.
-func (*GetOverviewResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use FileBlockCopy.ProtoReflect.Descriptor instead.
+func (*FileBlockCopy) Descriptor() ([]byte, []int) {
 	return file_mpi_v1_files_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetOverviewResponse) GetOverview() *FileOverview {
+func (x *FileBlockCopy) GetBlockIndex() uint32 {
 	if x != nil {
-		return x.Overview
+		return x.BlockIndex
 	}
-	return nil
+	return 0
 }
 
-// Represents a list of logically grouped files that have changed e.g. configuration payload
-type UpdateOverviewRequest struct {
+func (x *FileBlockCopy) GetBlockCount() uint32 {
+	if x != nil {
+		return x.BlockCount
+	}
+	return 0
+}
+
+// Represents the block signatures of a file, used for delta file transfers.
+// The file is split into blocks of block_size bytes, only the last block can be undersized
+type FileSignature struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Meta-information associated with a message
-	MessageMeta *MessageMeta `protobuf:"bytes,1,opt,name=message_meta,json=messageMeta,proto3" json:"message_meta,omitempty"`
-	// The file overview of an instance
-	Overview      *FileOverview `protobuf:"bytes,2,opt,name=overview,proto3" json:"overview,omitempty"`
+	// size of the blocks in bytes
+	BlockSize uint32 `protobuf:"varint,1,opt,name=block_size,json=blockSize,proto3" json:"block_size,omitempty"`
+	// signatures of the blocks in the order of the blocks in the file
+	Blocks        []*BlockSignature `protobuf:"bytes,2,rep,name=blocks,proto3" json:"blocks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOverviewRequest) Reset() {
-	*x = UpdateOverviewRequest{}
+func (x *FileSignature) Reset() {
+	*x = FileSignature{}
 	mi := &file_mpi_v1_files_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOverviewRequest) String() string {
+func (x *FileSignature) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOverviewRequest) ProtoMessage() {}
+func (*FileSignature) ProtoMessage() {}
 
-func (x *UpdateOverviewRequest) ProtoReflect() protoreflect.Message {
+func (x *FileSignature) ProtoReflect() protoreflect.Message {
 	mi := &file_mpi_v1_files_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -498,48 +721,51 @@ func (x *UpdateOverviewRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOverviewRequest.ProtoReflect.Descriptor instead.
-func (*UpdateOverviewRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use FileSignature.ProtoReflect.Descriptor instead.
+func (*FileSignature) Descriptor() ([]byte, []int) {
 	return file_mpi_v1_files_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *UpdateOverviewRequest) GetMessageMeta() *MessageMeta {
+func (x *FileSignature) GetBlockSize() uint32 {
 	if x != nil {
-		return x.MessageMeta
+		return x.BlockSize
 	}
-	return nil
+	return 0
 }
 
-func (x *UpdateOverviewRequest) GetOverview() *FileOverview {
+func (x *FileSignature) GetBlocks() []*BlockSignature {
 	if x != nil {
-		return x.Overview
+		return x.Blocks
 	}
 	return nil
 }
 
-// Represents a the response from an UpdateOverviewRequest
-type UpdateOverviewResponse struct {
+// Represents the signature of a block of a file
+type BlockSignature struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The file overview with the list of files that were uploaded
-	Overview      *FileOverview `protobuf:"bytes,1,opt,name=overview,proto3" json:"overview,omitempty"`
+	// rolling checksum of the block, a = sum of the bytes mod 2^16, b = sum of the running values of a mod 2^16,
+	// weak_hash = a + b * 2^16
+	WeakHash uint32 `protobuf:"varint,1,opt,name=weak_hash,json=weakHash,proto3" json:"weak_hash,omitempty"`
+	// sha256 hash of the block
+	StrongHash    []byte `protobuf:"bytes,2,opt,name=strong_hash,json=strongHash,proto3" json:"strong_hash,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOverviewResponse) Reset() {
-	*x = UpdateOverviewResponse{}
+func (x *BlockSignature) Reset() {
+	*x = BlockSignature{}
 	mi := &file_mpi_v1_files_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOverviewResponse) String() string {
+func (x *BlockSignature) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOverviewResponse) ProtoMessage() {}
+func (*BlockSignature) ProtoMessage() {}
 
-func (x *UpdateOverviewResponse) ProtoReflect() protoreflect.Message {
+func (x *BlockSignature) ProtoReflect() protoreflect.Message {
 	mi := &file_mpi_v1_files_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -551,43 +777,50 @@ func (x *UpdateOverviewResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOverviewResponse.ProtoReflect.Descriptor instead.
-func (*UpdateOverviewResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BlockSignature.ProtoReflect.Descriptor instead.
+func (*BlockSignature) Descriptor() ([]byte, []int) {
 	return file_mpi_v1_files_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *UpdateOverviewResponse) GetOverview() *FileOverview {
+func (x *BlockSignature) GetWeakHash() uint32 {
 	if x != nil {
-		return x.Overview
+		return x.WeakHash
+	}
+	return 0
+}
+
+func (x *BlockSignature) GetStrongHash() []byte {
+	if x != nil {
+		return x.StrongHash
 	}
 	return nil
 }
 
-// Represents a specific configuration version associated with an instance
-type ConfigVersion struct {
+// Represents a request payload for a file overview
+type GetOverviewRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The instance identifier
-	InstanceId string `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
-	// The version of the configuration
-	Version       string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Meta-information associated with a message
+	MessageMeta *MessageMeta `protobuf:"bytes,1,opt,name=message_meta,json=messageMeta,proto3" json:"message_meta,omitempty"`
+	// The config version of the overview you are requesting
+	ConfigVersion *ConfigVersion `protobuf:"bytes,2,opt,name=config_version,json=configVersion,proto3" json:"config_version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConfigVersion) Reset() {
-	*x = ConfigVersion{}
+func (x *GetOverviewRequest) Reset() {
+	*x = GetOverviewRequest{}
 	mi := &file_mpi_v1_files_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConfigVersion) String() string {
+func (x *GetOverviewRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfigVersion) ProtoMessage() {}
+func (*GetOverviewRequest) ProtoMessage() {}
 
-func (x *ConfigVersion) ProtoReflect() protoreflect.Message {
+func (x *GetOverviewRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_mpi_v1_files_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -599,52 +832,48 @@ func (x *ConfigVersion) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfigVersion.ProtoReflect.Descriptor instead.
-func (*ConfigVersion) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetOverviewRequest.ProtoReflect.Descriptor instead.
+func (*GetOverviewRequest) Descriptor() ([]byte, []int) {
 	return file_mpi_v1_files_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ConfigVersion) GetInstanceId() string {
+func (x *GetOverviewRequest) GetMessageMeta() *MessageMeta {
 	if x != nil {
-		return x.InstanceId
+		return x.MessageMeta
 	}
-	return ""
+	return nil
 }
 
-func (x *ConfigVersion) GetVersion() string {
+func (x *GetOverviewRequest) GetConfigVersion() *ConfigVersion {
 	if x != nil {
-		return x.Version
+		return x.ConfigVersion
 	}
-	return ""
+	return nil
 }
 
-// Represents a collection of files
-type FileOverview struct {
+// Represents the response payload to a GetOverviewRequest, requesting a list of logically grouped files e.g. configuration payload
+type GetOverviewResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// A list of files
-	Files []*File `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
-	// The configuration version of the current set of files
-	ConfigVersion *ConfigVersion `protobuf:"bytes,2,opt,name=config_version,json=configVersion,proto3" json:"config_version,omitempty"`
-	// The config file path of an instance
-	ConfigPath    string `protobuf:"bytes,3,opt,name=config_path,json=configPath,proto3" json:"config_path,omitempty"`
+	// The file overview of an instance
+	Overview      *FileOverview `protobuf:"bytes,1,opt,name=overview,proto3" json:"overview,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileOverview) Reset() {
-	*x = FileOverview{}
+func (x *GetOverviewResponse) Reset() {
+	*x = GetOverviewResponse{}
 	mi := &file_mpi_v1_files_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileOverview) String() string {
+func (x *GetOverviewResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileOverview) ProtoMessage() {}
+func (*GetOverviewResponse) ProtoMessage() {}
 
-func (x *FileOverview) ProtoReflect() protoreflect.Message {
+func (x *GetOverviewResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_mpi_v1_files_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -656,27 +885,233 @@ func (x *FileOverview) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileOverview.ProtoReflect.Descriptor instead.
-func (*FileOverview) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetOverviewResponse.ProtoReflect.Descriptor instead.
+func (*GetOverviewResponse) Descriptor() ([]byte, []int) {
 	return file_mpi_v1_files_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *FileOverview) GetFiles() []*File {
-	if x != nil {
-		return x.Files
-	}
-	return nil
-}
-
-func (x *FileOverview) GetConfigVersion() *ConfigVersion {
+func (x *GetOverviewResponse) GetOverview() *FileOverview {
 	if x != nil {
-		return x.ConfigVersion
+		return x.Overview
 	}
 	return nil
 }
 
-func (x *FileOverview) GetConfigPath() string {
-	if x != nil {
+// Represents a list of logically grouped files that have changed e.g. configuration payload
+type UpdateOverviewRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Meta-information associated with a message
+	MessageMeta *MessageMeta `protobuf:"bytes,1,opt,name=message_meta,json=messageMeta,proto3" json:"message_meta,omitempty"`
+	// The file overview of an instance
+	Overview      *FileOverview `protobuf:"bytes,2,opt,name=overview,proto3" json:"overview,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOverviewRequest) Reset() {
+	*x = UpdateOverviewRequest{}
+	mi := &file_mpi_v1_files_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOverviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOverviewRequest) ProtoMessage() {}
+
+func (x *UpdateOverviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_files_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOverviewRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOverviewRequest) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateOverviewRequest) GetMessageMeta() *MessageMeta {
+	if x != nil {
+		return x.MessageMeta
+	}
+	return nil
+}
+
+func (x *UpdateOverviewRequest) GetOverview() *FileOverview {
+	if x != nil {
+		return x.Overview
+	}
+	return nil
+}
+
+// Represents a the response from an UpdateOverviewRequest
+type UpdateOverviewResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The file overview with the list of files that were uploaded
+	Overview      *FileOverview `protobuf:"bytes,1,opt,name=overview,proto3" json:"overview,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOverviewResponse) Reset() {
+	*x = UpdateOverviewResponse{}
+	mi := &file_mpi_v1_files_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOverviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOverviewResponse) ProtoMessage() {}
+
+func (x *UpdateOverviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_files_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOverviewResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOverviewResponse) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateOverviewResponse) GetOverview() *FileOverview {
+	if x != nil {
+		return x.Overview
+	}
+	return nil
+}
+
+// Represents a specific configuration version associated with an instance
+type ConfigVersion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The instance identifier
+	InstanceId string `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	// The version of the configuration
+	Version       string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigVersion) Reset() {
+	*x = ConfigVersion{}
+	mi := &file_mpi_v1_files_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigVersion) ProtoMessage() {}
+
+func (x *ConfigVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_files_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigVersion.ProtoReflect.Descriptor instead.
+func (*ConfigVersion) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ConfigVersion) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *ConfigVersion) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// Represents a collection of files
+type FileOverview struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A list of files
+	Files []*File `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	// The configuration version of the current set of files
+	ConfigVersion *ConfigVersion `protobuf:"bytes,2,opt,name=config_version,json=configVersion,proto3" json:"config_version,omitempty"`
+	// The config file path of an instance
+	ConfigPath    string `protobuf:"bytes,3,opt,name=config_path,json=configPath,proto3" json:"config_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileOverview) Reset() {
+	*x = FileOverview{}
+	mi := &file_mpi_v1_files_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileOverview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileOverview) ProtoMessage() {}
+
+func (x *FileOverview) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_files_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileOverview.ProtoReflect.Descriptor instead.
+func (*FileOverview) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FileOverview) GetFiles() []*File {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *FileOverview) GetConfigVersion() *ConfigVersion {
+	if x != nil {
+		return x.ConfigVersion
+	}
+	return nil
+}
+
+func (x *FileOverview) GetConfigPath() string {
+	if x != nil {
 		return x.ConfigPath
 	}
 	return ""
@@ -691,13 +1126,15 @@ type File struct {
 	Unmanaged bool `protobuf:"varint,2,opt,name=unmanaged,proto3" json:"unmanaged,omitempty"`
 	// external file source
 	ExternalDataSource *ExternalDataSource `protobuf:"bytes,3,opt,name=external_data_source,json=externalDataSource,proto3,oneof" json:"external_data_source,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// Set if the file is a template that is rendered by the agent before it is written
+	Template      *FileTemplate `protobuf:"bytes,4,opt,name=template,proto3,oneof" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *File) Reset() {
 	*x = File{}
-	mi := &file_mpi_v1_files_proto_msgTypes[9]
+	mi := &file_mpi_v1_files_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -709,7 +1146,7 @@ func (x *File) String() string {
 func (*File) ProtoMessage() {}
 
 func (x *File) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_files_proto_msgTypes[9]
+	mi := &file_mpi_v1_files_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -722,7 +1159,7 @@ func (x *File) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use File.ProtoReflect.Descriptor instead.
 func (*File) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_files_proto_rawDescGZIP(), []int{9}
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *File) GetFileMeta() *FileMeta {
@@ -746,17 +1183,101 @@ func (x *File) GetExternalDataSource() *ExternalDataSource {
 	return nil
 }
 
-type ExternalDataSource struct {
+func (x *File) GetTemplate() *FileTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+// A file that is rendered by the agent with the Go text/template syntax before the config is validated.
+// The hash and size in the file meta are of the template source. The variables available to the template are
+// the agent labels, host or container information, allowed environment variables and NGINX runtime information.
+type FileTemplate struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// URL to the location of an external file
-	Location      string `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	// The hash of the rendered file contents, sha256, base64 encoded. Set by the agent
+	RenderedHash string `protobuf:"bytes,1,opt,name=rendered_hash,json=renderedHash,proto3" json:"rendered_hash,omitempty"`
+	// The hash of the variables the file was rendered with, sha256, base64 encoded. Set by the agent
+	VariablesHash string `protobuf:"bytes,2,opt,name=variables_hash,json=variablesHash,proto3" json:"variables_hash,omitempty"`
+	// The size of the rendered file contents in bytes. Set by the agent
+	RenderedSize  int64 `protobuf:"varint,3,opt,name=rendered_size,json=renderedSize,proto3" json:"rendered_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *FileTemplate) Reset() {
+	*x = FileTemplate{}
+	mi := &file_mpi_v1_files_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileTemplate) ProtoMessage() {}
+
+func (x *FileTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_files_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileTemplate.ProtoReflect.Descriptor instead.
+func (*FileTemplate) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FileTemplate) GetRenderedHash() string {
+	if x != nil {
+		return x.RenderedHash
+	}
+	return ""
+}
+
+func (x *FileTemplate) GetVariablesHash() string {
+	if x != nil {
+		return x.VariablesHash
+	}
+	return ""
+}
+
+func (x *FileTemplate) GetRenderedSize() int64 {
+	if x != nil {
+		return x.RenderedSize
+	}
+	return 0
+}
+
+type ExternalDataSource struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// URL to the location of an external file. s3://bucket/key locations are downloaded from the S3-compatible
+	// object storage in the agent config.
+	Location string `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	// How often the file is downloaded again after a config apply. If the file has changed, NGINX is reloaded
+	// and the file is rolled back if the config is invalid. If not set, the file is only downloaded during a config apply.
+	RefreshInterval *durationpb.Duration `protobuf:"bytes,2,opt,name=refresh_interval,json=refreshInterval,proto3" json:"refresh_interval,omitempty"`
+	// The expected sha256 digest of the file contents, hex or base64 encoded. If set, the agent refuses to
+	// stage a downloaded file with a different digest.
+	Sha256 string `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	// URL to the location of a detached signature of the file contents. If set, the agent refuses to stage
+	// a downloaded file unless the signature is verified by one of the trusted public keys in the agent config.
+	// The signature is either an ed25519 signature or a cosign-style ECDSA signature, raw or base64 encoded.
+	SignatureLocation string `protobuf:"bytes,4,opt,name=signature_location,json=signatureLocation,proto3" json:"signature_location,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
 func (x *ExternalDataSource) Reset() {
 	*x = ExternalDataSource{}
-	mi := &file_mpi_v1_files_proto_msgTypes[10]
+	mi := &file_mpi_v1_files_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -768,7 +1289,7 @@ func (x *ExternalDataSource) String() string {
 func (*ExternalDataSource) ProtoMessage() {}
 
 func (x *ExternalDataSource) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_files_proto_msgTypes[10]
+	mi := &file_mpi_v1_files_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -781,7 +1302,7 @@ func (x *ExternalDataSource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExternalDataSource.ProtoReflect.Descriptor instead.
 func (*ExternalDataSource) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_files_proto_rawDescGZIP(), []int{10}
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ExternalDataSource) GetLocation() string {
@@ -791,20 +1312,49 @@ func (x *ExternalDataSource) GetLocation() string {
 	return ""
 }
 
+func (x *ExternalDataSource) GetRefreshInterval() *durationpb.Duration {
+	if x != nil {
+		return x.RefreshInterval
+	}
+	return nil
+}
+
+func (x *ExternalDataSource) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *ExternalDataSource) GetSignatureLocation() string {
+	if x != nil {
+		return x.SignatureLocation
+	}
+	return ""
+}
+
 // Represents the get file request
 type GetFileRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Meta-information associated with a message
 	MessageMeta *MessageMeta `protobuf:"bytes,1,opt,name=message_meta,json=messageMeta,proto3" json:"message_meta,omitempty"`
 	// Meta-information associated with the file
-	FileMeta      *FileMeta `protobuf:"bytes,2,opt,name=file_meta,json=fileMeta,proto3" json:"file_meta,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	FileMeta *FileMeta `protobuf:"bytes,2,opt,name=file_meta,json=fileMeta,proto3" json:"file_meta,omitempty"`
+	// Block signatures of the copy of the file on the data plane, only used by GetFileStream
+	Signature *FileSignature `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	// The number of bytes of the file content already received from an interrupted GetFileStream.
+	// The MP can resume the stream at this offset, see FileDataChunkHeader.offset.
+	Offset uint64 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	// The compressions the data plane accepts for the file contents, see FileContents.compression and
+	// FileDataChunkHeader.compression. If empty, the file contents must not be compressed.
+	AcceptedCompressions []Compression `protobuf:"varint,5,rep,packed,name=accepted_compressions,json=acceptedCompressions,proto3,enum=mpi.v1.Compression" json:"accepted_compressions,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *GetFileRequest) Reset() {
 	*x = GetFileRequest{}
-	mi := &file_mpi_v1_files_proto_msgTypes[11]
+	mi := &file_mpi_v1_files_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -816,7 +1366,7 @@ func (x *GetFileRequest) String() string {
 func (*GetFileRequest) ProtoMessage() {}
 
 func (x *GetFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_files_proto_msgTypes[11]
+	mi := &file_mpi_v1_files_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -829,7 +1379,7 @@ func (x *GetFileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFileRequest.ProtoReflect.Descriptor instead.
 func (*GetFileRequest) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_files_proto_rawDescGZIP(), []int{11}
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetFileRequest) GetMessageMeta() *MessageMeta {
@@ -846,6 +1396,27 @@ func (x *GetFileRequest) GetFileMeta() *FileMeta {
 	return nil
 }
 
+func (x *GetFileRequest) GetSignature() *FileSignature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *GetFileRequest) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetFileRequest) GetAcceptedCompressions() []Compression {
+	if x != nil {
+		return x.AcceptedCompressions
+	}
+	return nil
+}
+
 // Represents the response to a get file request
 type GetFileResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -857,7 +1428,7 @@ type GetFileResponse struct {
 
 func (x *GetFileResponse) Reset() {
 	*x = GetFileResponse{}
-	mi := &file_mpi_v1_files_proto_msgTypes[12]
+	mi := &file_mpi_v1_files_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -869,7 +1440,7 @@ func (x *GetFileResponse) String() string {
 func (*GetFileResponse) ProtoMessage() {}
 
 func (x *GetFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_files_proto_msgTypes[12]
+	mi := &file_mpi_v1_files_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -882,7 +1453,7 @@ func (x *GetFileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFileResponse.ProtoReflect.Descriptor instead.
 func (*GetFileResponse) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_files_proto_rawDescGZIP(), []int{12}
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetFileResponse) GetContents() *FileContents {
@@ -896,14 +1467,16 @@ func (x *GetFileResponse) GetContents() *FileContents {
 type FileContents struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Byte representation of a file without encoding
-	Contents      []byte `protobuf:"bytes,1,opt,name=contents,proto3" json:"contents,omitempty"`
+	Contents []byte `protobuf:"bytes,1,opt,name=contents,proto3" json:"contents,omitempty"`
+	// The compression of the contents. The hash and size of the file are those of the decompressed contents.
+	Compression   Compression `protobuf:"varint,2,opt,name=compression,proto3,enum=mpi.v1.Compression" json:"compression,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FileContents) Reset() {
 	*x = FileContents{}
-	mi := &file_mpi_v1_files_proto_msgTypes[13]
+	mi := &file_mpi_v1_files_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -915,7 +1488,7 @@ func (x *FileContents) String() string {
 func (*FileContents) ProtoMessage() {}
 
 func (x *FileContents) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_files_proto_msgTypes[13]
+	mi := &file_mpi_v1_files_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -928,7 +1501,7 @@ func (x *FileContents) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileContents.ProtoReflect.Descriptor instead.
 func (*FileContents) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_files_proto_rawDescGZIP(), []int{13}
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *FileContents) GetContents() []byte {
@@ -938,6 +1511,13 @@ func (x *FileContents) GetContents() []byte {
 	return nil
 }
 
+func (x *FileContents) GetCompression() Compression {
+	if x != nil {
+		return x.Compression
+	}
+	return Compression_COMPRESSION_UNSPECIFIED
+}
+
 // Meta information about the file, the name (including path) and hash
 type FileMeta struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -951,11 +1531,20 @@ type FileMeta struct {
 	Permissions string `protobuf:"bytes,4,opt,name=permissions,proto3" json:"permissions,omitempty"`
 	// The size of the file in bytes
 	Size int64 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	// The owner of the file, a user name or numeric user id. If not set, the owner of the file is not changed.
+	Owner string `protobuf:"bytes,7,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The group of the file, a group name or numeric group id. If not set, the group of the file is not changed.
+	Group string `protobuf:"bytes,8,opt,name=group,proto3" json:"group,omitempty"`
+	// The SELinux security context of the file, e.g. system_u:object_r:httpd_config_t:s0.
+	// If not set, the SELinux security context of the file is not changed.
+	SelinuxContext string `protobuf:"bytes,9,opt,name=selinux_context,json=selinuxContext,proto3" json:"selinux_context,omitempty"`
 	// additional file information
 	//
 	// Types that are valid to be assigned to FileType:
 	//
 	//	*FileMeta_CertificateMeta
+	//	*FileMeta_SymlinkMeta
+	//	*FileMeta_DirectoryMeta
 	FileType      isFileMeta_FileType `protobuf_oneof:"file_type"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -963,7 +1552,7 @@ type FileMeta struct {
 
 func (x *FileMeta) Reset() {
 	*x = FileMeta{}
-	mi := &file_mpi_v1_files_proto_msgTypes[14]
+	mi := &file_mpi_v1_files_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -975,7 +1564,159 @@ func (x *FileMeta) String() string {
 func (*FileMeta) ProtoMessage() {}
 
 func (x *FileMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_files_proto_msgTypes[14]
+	mi := &file_mpi_v1_files_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileMeta.ProtoReflect.Descriptor instead.
+func (*FileMeta) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FileMeta) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileMeta) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *FileMeta) GetModifiedTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModifiedTime
+	}
+	return nil
+}
+
+func (x *FileMeta) GetPermissions() string {
+	if x != nil {
+		return x.Permissions
+	}
+	return ""
+}
+
+func (x *FileMeta) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileMeta) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *FileMeta) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *FileMeta) GetSelinuxContext() string {
+	if x != nil {
+		return x.SelinuxContext
+	}
+	return ""
+}
+
+func (x *FileMeta) GetFileType() isFileMeta_FileType {
+	if x != nil {
+		return x.FileType
+	}
+	return nil
+}
+
+func (x *FileMeta) GetCertificateMeta() *CertificateMeta {
+	if x != nil {
+		if x, ok := x.FileType.(*FileMeta_CertificateMeta); ok {
+			return x.CertificateMeta
+		}
+	}
+	return nil
+}
+
+func (x *FileMeta) GetSymlinkMeta() *SymlinkMeta {
+	if x != nil {
+		if x, ok := x.FileType.(*FileMeta_SymlinkMeta); ok {
+			return x.SymlinkMeta
+		}
+	}
+	return nil
+}
+
+func (x *FileMeta) GetDirectoryMeta() *DirectoryMeta {
+	if x != nil {
+		if x, ok := x.FileType.(*FileMeta_DirectoryMeta); ok {
+			return x.DirectoryMeta
+		}
+	}
+	return nil
+}
+
+type isFileMeta_FileType interface {
+	isFileMeta_FileType()
+}
+
+type FileMeta_CertificateMeta struct {
+	CertificateMeta *CertificateMeta `protobuf:"bytes,6,opt,name=certificate_meta,json=certificateMeta,proto3,oneof"`
+}
+
+type FileMeta_SymlinkMeta struct {
+	SymlinkMeta *SymlinkMeta `protobuf:"bytes,10,opt,name=symlink_meta,json=symlinkMeta,proto3,oneof"`
+}
+
+type FileMeta_DirectoryMeta struct {
+	DirectoryMeta *DirectoryMeta `protobuf:"bytes,11,opt,name=directory_meta,json=directoryMeta,proto3,oneof"`
+}
+
+func (*FileMeta_CertificateMeta) isFileMeta_FileType() {}
+
+func (*FileMeta_SymlinkMeta) isFileMeta_FileType() {}
+
+func (*FileMeta_DirectoryMeta) isFileMeta_FileType() {}
+
+// Represents a symbolic link, the file has no contents and the hash and size are not set
+type SymlinkMeta struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The path the symbolic link points to, relative paths are resolved from the directory of the link.
+	// The target must be in the allowed directories.
+	Target        string `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SymlinkMeta) Reset() {
+	*x = SymlinkMeta{}
+	mi := &file_mpi_v1_files_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SymlinkMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SymlinkMeta) ProtoMessage() {}
+
+func (x *SymlinkMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_files_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -986,72 +1727,56 @@ func (x *FileMeta) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileMeta.ProtoReflect.Descriptor instead.
-func (*FileMeta) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_files_proto_rawDescGZIP(), []int{14}
-}
-
-func (x *FileMeta) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Deprecated: Use SymlinkMeta.ProtoReflect.Descriptor instead.
+func (*SymlinkMeta) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *FileMeta) GetHash() string {
+func (x *SymlinkMeta) GetTarget() string {
 	if x != nil {
-		return x.Hash
+		return x.Target
 	}
 	return ""
 }
 
-func (x *FileMeta) GetModifiedTime() *timestamppb.Timestamp {
-	if x != nil {
-		return x.ModifiedTime
-	}
-	return nil
+// Represents an empty directory, the file has no contents and the hash and size are not set.
+// Files in the directory are managed as separate files.
+type DirectoryMeta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FileMeta) GetPermissions() string {
-	if x != nil {
-		return x.Permissions
-	}
-	return ""
+func (x *DirectoryMeta) Reset() {
+	*x = DirectoryMeta{}
+	mi := &file_mpi_v1_files_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *FileMeta) GetSize() int64 {
-	if x != nil {
-		return x.Size
-	}
-	return 0
+func (x *DirectoryMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *FileMeta) GetFileType() isFileMeta_FileType {
-	if x != nil {
-		return x.FileType
-	}
-	return nil
-}
+func (*DirectoryMeta) ProtoMessage() {}
 
-func (x *FileMeta) GetCertificateMeta() *CertificateMeta {
+func (x *DirectoryMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_files_proto_msgTypes[21]
 	if x != nil {
-		if x, ok := x.FileType.(*FileMeta_CertificateMeta); ok {
-			return x.CertificateMeta
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
-}
-
-type isFileMeta_FileType interface {
-	isFileMeta_FileType()
+	return mi.MessageOf(x)
 }
 
-type FileMeta_CertificateMeta struct {
-	CertificateMeta *CertificateMeta `protobuf:"bytes,6,opt,name=certificate_meta,json=certificateMeta,proto3,oneof"`
+// Deprecated: Use DirectoryMeta.ProtoReflect.Descriptor instead.
+func (*DirectoryMeta) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{21}
 }
 
-func (*FileMeta_CertificateMeta) isFileMeta_FileType() {}
-
 // Represents the update file request
 type UpdateFileRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1067,7 +1792,7 @@ type UpdateFileRequest struct {
 
 func (x *UpdateFileRequest) Reset() {
 	*x = UpdateFileRequest{}
-	mi := &file_mpi_v1_files_proto_msgTypes[15]
+	mi := &file_mpi_v1_files_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1079,7 +1804,7 @@ func (x *UpdateFileRequest) String() string {
 func (*UpdateFileRequest) ProtoMessage() {}
 
 func (x *UpdateFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_files_proto_msgTypes[15]
+	mi := &file_mpi_v1_files_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1092,7 +1817,7 @@ func (x *UpdateFileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateFileRequest.ProtoReflect.Descriptor instead.
 func (*UpdateFileRequest) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_files_proto_rawDescGZIP(), []int{15}
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *UpdateFileRequest) GetFile() *File {
@@ -1127,7 +1852,7 @@ type UpdateFileResponse struct {
 
 func (x *UpdateFileResponse) Reset() {
 	*x = UpdateFileResponse{}
-	mi := &file_mpi_v1_files_proto_msgTypes[16]
+	mi := &file_mpi_v1_files_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1139,7 +1864,7 @@ func (x *UpdateFileResponse) String() string {
 func (*UpdateFileResponse) ProtoMessage() {}
 
 func (x *UpdateFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_files_proto_msgTypes[16]
+	mi := &file_mpi_v1_files_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1152,7 +1877,7 @@ func (x *UpdateFileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateFileResponse.ProtoReflect.Descriptor instead.
 func (*UpdateFileResponse) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_files_proto_rawDescGZIP(), []int{16}
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *UpdateFileResponse) GetFileMeta() *FileMeta {
@@ -1186,7 +1911,7 @@ type CertificateMeta struct {
 
 func (x *CertificateMeta) Reset() {
 	*x = CertificateMeta{}
-	mi := &file_mpi_v1_files_proto_msgTypes[17]
+	mi := &file_mpi_v1_files_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1198,7 +1923,7 @@ func (x *CertificateMeta) String() string {
 func (*CertificateMeta) ProtoMessage() {}
 
 func (x *CertificateMeta) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_files_proto_msgTypes[17]
+	mi := &file_mpi_v1_files_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1211,7 +1936,7 @@ func (x *CertificateMeta) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CertificateMeta.ProtoReflect.Descriptor instead.
 func (*CertificateMeta) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_files_proto_rawDescGZIP(), []int{17}
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *CertificateMeta) GetSerialNumber() string {
@@ -1276,7 +2001,7 @@ type CertificateDates struct {
 
 func (x *CertificateDates) Reset() {
 	*x = CertificateDates{}
-	mi := &file_mpi_v1_files_proto_msgTypes[18]
+	mi := &file_mpi_v1_files_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1288,7 +2013,7 @@ func (x *CertificateDates) String() string {
 func (*CertificateDates) ProtoMessage() {}
 
 func (x *CertificateDates) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_files_proto_msgTypes[18]
+	mi := &file_mpi_v1_files_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1301,7 +2026,7 @@ func (x *CertificateDates) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CertificateDates.ProtoReflect.Descriptor instead.
 func (*CertificateDates) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_files_proto_rawDescGZIP(), []int{18}
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *CertificateDates) GetNotBefore() int64 {
@@ -1331,7 +2056,7 @@ type SubjectAlternativeNames struct {
 
 func (x *SubjectAlternativeNames) Reset() {
 	*x = SubjectAlternativeNames{}
-	mi := &file_mpi_v1_files_proto_msgTypes[19]
+	mi := &file_mpi_v1_files_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1343,7 +2068,7 @@ func (x *SubjectAlternativeNames) String() string {
 func (*SubjectAlternativeNames) ProtoMessage() {}
 
 func (x *SubjectAlternativeNames) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_files_proto_msgTypes[19]
+	mi := &file_mpi_v1_files_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1356,7 +2081,7 @@ func (x *SubjectAlternativeNames) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubjectAlternativeNames.ProtoReflect.Descriptor instead.
 func (*SubjectAlternativeNames) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_files_proto_rawDescGZIP(), []int{19}
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *SubjectAlternativeNames) GetDnsNames() []string {
@@ -1373,6 +2098,271 @@ func (x *SubjectAlternativeNames) GetIpAddresses() []string {
 	return nil
 }
 
+// The changes a config apply would make to the files on disk
+type ConfigChangeSet struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The change for each file, including files that would not be changed
+	Changes       []*FileChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigChangeSet) Reset() {
+	*x = ConfigChangeSet{}
+	mi := &file_mpi_v1_files_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigChangeSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigChangeSet) ProtoMessage() {}
+
+func (x *ConfigChangeSet) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_files_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigChangeSet.ProtoReflect.Descriptor instead.
+func (*ConfigChangeSet) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ConfigChangeSet) GetChanges() []*FileChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// A change a config apply would make to a file
+type FileChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The full path of the file
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The action that would be performed on the file
+	Action FileAction `protobuf:"varint,2,opt,name=action,proto3,enum=mpi.v1.FileAction" json:"action,omitempty"`
+	// The hash of the file currently on disk, sha256, hex encoded
+	OldHash string `protobuf:"bytes,3,opt,name=old_hash,json=oldHash,proto3" json:"old_hash,omitempty"`
+	// The hash of the file in the request, sha256, hex encoded
+	NewHash string `protobuf:"bytes,4,opt,name=new_hash,json=newHash,proto3" json:"new_hash,omitempty"`
+	// The size of the file currently on disk in bytes
+	OldSize int64 `protobuf:"varint,5,opt,name=old_size,json=oldSize,proto3" json:"old_size,omitempty"`
+	// The size of the file in the request in bytes
+	NewSize int64 `protobuf:"varint,6,opt,name=new_size,json=newSize,proto3" json:"new_size,omitempty"`
+	// A unified diff of the file, only populated for text files under the size limit when a text diff is requested
+	Diff          string `protobuf:"bytes,7,opt,name=diff,proto3" json:"diff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileChange) Reset() {
+	*x = FileChange{}
+	mi := &file_mpi_v1_files_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChange) ProtoMessage() {}
+
+func (x *FileChange) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_files_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChange.ProtoReflect.Descriptor instead.
+func (*FileChange) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *FileChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileChange) GetAction() FileAction {
+	if x != nil {
+		return x.Action
+	}
+	return FileAction_FILE_ACTION_UNSPECIFIED
+}
+
+func (x *FileChange) GetOldHash() string {
+	if x != nil {
+		return x.OldHash
+	}
+	return ""
+}
+
+func (x *FileChange) GetNewHash() string {
+	if x != nil {
+		return x.NewHash
+	}
+	return ""
+}
+
+func (x *FileChange) GetOldSize() int64 {
+	if x != nil {
+		return x.OldSize
+	}
+	return 0
+}
+
+func (x *FileChange) GetNewSize() int64 {
+	if x != nil {
+		return x.NewSize
+	}
+	return 0
+}
+
+func (x *FileChange) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+// The config versions previously applied by the agent for an instance
+type ConfigHistory struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The stored config versions, oldest first
+	Versions      []*ConfigHistoryVersion `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigHistory) Reset() {
+	*x = ConfigHistory{}
+	mi := &file_mpi_v1_files_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigHistory) ProtoMessage() {}
+
+func (x *ConfigHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_files_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigHistory.ProtoReflect.Descriptor instead.
+func (*ConfigHistory) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ConfigHistory) GetVersions() []*ConfigHistoryVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+// A config version previously applied by the agent
+type ConfigHistoryVersion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The config version
+	ConfigVersion *ConfigVersion `protobuf:"bytes,1,opt,name=config_version,json=configVersion,proto3" json:"config_version,omitempty"`
+	// The time the config version was applied
+	AppliedTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=applied_time,json=appliedTime,proto3" json:"applied_time,omitempty"`
+	// The path of the main configuration file
+	ConfigPath string `protobuf:"bytes,3,opt,name=config_path,json=configPath,proto3" json:"config_path,omitempty"`
+	// The meta information of the files of the config version
+	Files         []*FileMeta `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigHistoryVersion) Reset() {
+	*x = ConfigHistoryVersion{}
+	mi := &file_mpi_v1_files_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigHistoryVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigHistoryVersion) ProtoMessage() {}
+
+func (x *ConfigHistoryVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_mpi_v1_files_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigHistoryVersion.ProtoReflect.Descriptor instead.
+func (*ConfigHistoryVersion) Descriptor() ([]byte, []int) {
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ConfigHistoryVersion) GetConfigVersion() *ConfigVersion {
+	if x != nil {
+		return x.ConfigVersion
+	}
+	return nil
+}
+
+func (x *ConfigHistoryVersion) GetAppliedTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AppliedTime
+	}
+	return nil
+}
+
+func (x *ConfigHistoryVersion) GetConfigPath() string {
+	if x != nil {
+		return x.ConfigPath
+	}
+	return ""
+}
+
+func (x *ConfigHistoryVersion) GetFiles() []*FileMeta {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
 // Represents the dates for which a certificate is valid as seen at https://pkg.go.dev/crypto/x509/pkix#Name
 type X509Name struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1408,7 +2398,7 @@ type X509Name struct {
 
 func (x *X509Name) Reset() {
 	*x = X509Name{}
-	mi := &file_mpi_v1_files_proto_msgTypes[20]
+	mi := &file_mpi_v1_files_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1420,7 +2410,7 @@ func (x *X509Name) String() string {
 func (*X509Name) ProtoMessage() {}
 
 func (x *X509Name) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_files_proto_msgTypes[20]
+	mi := &file_mpi_v1_files_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1433,7 +2423,7 @@ func (x *X509Name) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use X509Name.ProtoReflect.Descriptor instead.
 func (*X509Name) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_files_proto_rawDescGZIP(), []int{20}
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *X509Name) GetCountry() []string {
@@ -1525,7 +2515,7 @@ type AttributeTypeAndValue struct {
 
 func (x *AttributeTypeAndValue) Reset() {
 	*x = AttributeTypeAndValue{}
-	mi := &file_mpi_v1_files_proto_msgTypes[21]
+	mi := &file_mpi_v1_files_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1537,7 +2527,7 @@ func (x *AttributeTypeAndValue) String() string {
 func (*AttributeTypeAndValue) ProtoMessage() {}
 
 func (x *AttributeTypeAndValue) ProtoReflect() protoreflect.Message {
-	mi := &file_mpi_v1_files_proto_msgTypes[21]
+	mi := &file_mpi_v1_files_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1550,7 +2540,7 @@ func (x *AttributeTypeAndValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AttributeTypeAndValue.ProtoReflect.Descriptor instead.
 func (*AttributeTypeAndValue) Descriptor() ([]byte, []int) {
-	return file_mpi_v1_files_proto_rawDescGZIP(), []int{21}
+	return file_mpi_v1_files_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *AttributeTypeAndValue) GetType() string {
@@ -1571,20 +2561,42 @@ var File_mpi_v1_files_proto protoreflect.FileDescriptor
 
 const file_mpi_v1_files_proto_rawDesc = "" +
 	"\n" +
-	"\x12mpi/v1/files.proto\x12\x06mpi.v1\x1a\x13mpi/v1/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bbuf/validate/validate.proto\"\xb2\x01\n" +
+	"\x12mpi/v1/files.proto\x12\x06mpi.v1\x1a\x13mpi/v1/common.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bbuf/validate/validate.proto\"\xe6\x01\n" +
 	"\rFileDataChunk\x12'\n" +
 	"\x04meta\x18\x01 \x01(\v2\x13.mpi.v1.MessageMetaR\x04meta\x125\n" +
 	"\x06header\x18\x02 \x01(\v2\x1b.mpi.v1.FileDataChunkHeaderH\x00R\x06header\x128\n" +
-	"\acontent\x18\x03 \x01(\v2\x1c.mpi.v1.FileDataChunkContentH\x00R\acontentB\a\n" +
-	"\x05chunk\"\x8d\x01\n" +
+	"\acontent\x18\x03 \x01(\v2\x1c.mpi.v1.FileDataChunkContentH\x00R\acontent\x122\n" +
+	"\x05delta\x18\x04 \x01(\v2\x1a.mpi.v1.FileDataChunkDeltaH\x00R\x05deltaB\a\n" +
+	"\x05chunk\"\xf2\x01\n" +
 	"\x13FileDataChunkHeader\x12-\n" +
 	"\tfile_meta\x18\x01 \x01(\v2\x10.mpi.v1.FileMetaR\bfileMeta\x12\x1f\n" +
 	"\x06chunks\x18\x02 \x01(\rB\a\xbaH\x04*\x02 \x00R\x06chunks\x12&\n" +
 	"\n" +
-	"chunk_size\x18\x03 \x01(\rB\a\xbaH\x04*\x02 \x00R\tchunkSize\"E\n" +
+	"chunk_size\x18\x03 \x01(\rB\a\xbaH\x04*\x02 \x00R\tchunkSize\x12\x14\n" +
+	"\x05delta\x18\x04 \x01(\bR\x05delta\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x04R\x06offset\x125\n" +
+	"\vcompression\x18\x06 \x01(\x0e2\x13.mpi.v1.CompressionR\vcompression\"E\n" +
 	"\x14FileDataChunkContent\x12\x19\n" +
 	"\bchunk_id\x18\x01 \x01(\rR\achunkId\x12\x12\n" +
-	"\x04data\x18\x02 \x01(\fR\x04data\"\x8a\x01\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\x81\x01\n" +
+	"\x12FileDataChunkDelta\x12\x19\n" +
+	"\bchunk_id\x18\x01 \x01(\rR\achunkId\x12+\n" +
+	"\x04copy\x18\x02 \x01(\v2\x15.mpi.v1.FileBlockCopyH\x00R\x04copy\x12\x14\n" +
+	"\x04data\x18\x03 \x01(\fH\x00R\x04dataB\r\n" +
+	"\vinstruction\"Z\n" +
+	"\rFileBlockCopy\x12\x1f\n" +
+	"\vblock_index\x18\x01 \x01(\rR\n" +
+	"blockIndex\x12(\n" +
+	"\vblock_count\x18\x02 \x01(\rB\a\xbaH\x04*\x02 \x00R\n" +
+	"blockCount\"g\n" +
+	"\rFileSignature\x12&\n" +
+	"\n" +
+	"block_size\x18\x01 \x01(\rB\a\xbaH\x04*\x02 \x00R\tblockSize\x12.\n" +
+	"\x06blocks\x18\x02 \x03(\v2\x16.mpi.v1.BlockSignatureR\x06blocks\"N\n" +
+	"\x0eBlockSignature\x12\x1b\n" +
+	"\tweak_hash\x18\x01 \x01(\rR\bweakHash\x12\x1f\n" +
+	"\vstrong_hash\x18\x02 \x01(\fR\n" +
+	"strongHash\"\x8a\x01\n" +
 	"\x12GetOverviewRequest\x126\n" +
 	"\fmessage_meta\x18\x01 \x01(\v2\x13.mpi.v1.MessageMetaR\vmessageMeta\x12<\n" +
 	"\x0econfig_version\x18\x02 \x01(\v2\x15.mpi.v1.ConfigVersionR\rconfigVersion\"G\n" +
@@ -1603,29 +2615,51 @@ const file_mpi_v1_files_proto_rawDesc = "" +
 	"\x05files\x18\x01 \x03(\v2\f.mpi.v1.FileR\x05files\x12<\n" +
 	"\x0econfig_version\x18\x02 \x01(\v2\x15.mpi.v1.ConfigVersionR\rconfigVersion\x12\x1f\n" +
 	"\vconfig_path\x18\x03 \x01(\tR\n" +
-	"configPath\"\xbf\x01\n" +
+	"configPath\"\x83\x02\n" +
 	"\x04File\x12-\n" +
 	"\tfile_meta\x18\x01 \x01(\v2\x10.mpi.v1.FileMetaR\bfileMeta\x12\x1c\n" +
 	"\tunmanaged\x18\x02 \x01(\bR\tunmanaged\x12Q\n" +
-	"\x14external_data_source\x18\x03 \x01(\v2\x1a.mpi.v1.ExternalDataSourceH\x00R\x12externalDataSource\x88\x01\x01B\x17\n" +
-	"\x15_external_data_source\"0\n" +
+	"\x14external_data_source\x18\x03 \x01(\v2\x1a.mpi.v1.ExternalDataSourceH\x00R\x12externalDataSource\x88\x01\x01\x125\n" +
+	"\btemplate\x18\x04 \x01(\v2\x14.mpi.v1.FileTemplateH\x01R\btemplate\x88\x01\x01B\x17\n" +
+	"\x15_external_data_sourceB\v\n" +
+	"\t_template\"\x7f\n" +
+	"\fFileTemplate\x12#\n" +
+	"\rrendered_hash\x18\x01 \x01(\tR\frenderedHash\x12%\n" +
+	"\x0evariables_hash\x18\x02 \x01(\tR\rvariablesHash\x12#\n" +
+	"\rrendered_size\x18\x03 \x01(\x03R\frenderedSize\"\xbd\x01\n" +
 	"\x12ExternalDataSource\x12\x1a\n" +
-	"\blocation\x18\x01 \x01(\tR\blocation\"w\n" +
+	"\blocation\x18\x01 \x01(\tR\blocation\x12D\n" +
+	"\x10refresh_interval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x0frefreshInterval\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\x12-\n" +
+	"\x12signature_location\x18\x04 \x01(\tR\x11signatureLocation\"\x8e\x02\n" +
 	"\x0eGetFileRequest\x126\n" +
 	"\fmessage_meta\x18\x01 \x01(\v2\x13.mpi.v1.MessageMetaR\vmessageMeta\x12-\n" +
-	"\tfile_meta\x18\x02 \x01(\v2\x10.mpi.v1.FileMetaR\bfileMeta\"C\n" +
+	"\tfile_meta\x18\x02 \x01(\v2\x10.mpi.v1.FileMetaR\bfileMeta\x123\n" +
+	"\tsignature\x18\x03 \x01(\v2\x15.mpi.v1.FileSignatureR\tsignature\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x04R\x06offset\x12H\n" +
+	"\x15accepted_compressions\x18\x05 \x03(\x0e2\x13.mpi.v1.CompressionR\x14acceptedCompressions\"C\n" +
 	"\x0fGetFileResponse\x120\n" +
-	"\bcontents\x18\x01 \x01(\v2\x14.mpi.v1.FileContentsR\bcontents\"*\n" +
+	"\bcontents\x18\x01 \x01(\v2\x14.mpi.v1.FileContentsR\bcontents\"a\n" +
 	"\fFileContents\x12\x1a\n" +
-	"\bcontents\x18\x01 \x01(\fR\bcontents\"\x98\x02\n" +
+	"\bcontents\x18\x01 \x01(\fR\bcontents\x125\n" +
+	"\vcompression\x18\x02 \x01(\x0e2\x13.mpi.v1.CompressionR\vcompression\"\xe7\x03\n" +
 	"\bFileMeta\x12\x1c\n" +
 	"\x04name\x18\x01 \x01(\tB\b\xbaH\x05r\x03:\x01/R\x04name\x12\x12\n" +
 	"\x04hash\x18\x02 \x01(\tR\x04hash\x12?\n" +
 	"\rmodified_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fmodifiedTime\x122\n" +
 	"\vpermissions\x18\x04 \x01(\tB\x10\xbaH\rr\v2\t0[0-7]{3}R\vpermissions\x12\x12\n" +
-	"\x04size\x18\x05 \x01(\x03R\x04size\x12D\n" +
-	"\x10certificate_meta\x18\x06 \x01(\v2\x17.mpi.v1.CertificateMetaH\x00R\x0fcertificateMetaB\v\n" +
-	"\tfile_type\"\x9f\x01\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x14\n" +
+	"\x05owner\x18\a \x01(\tR\x05owner\x12\x14\n" +
+	"\x05group\x18\b \x01(\tR\x05group\x12'\n" +
+	"\x0fselinux_context\x18\t \x01(\tR\x0eselinuxContext\x12D\n" +
+	"\x10certificate_meta\x18\x06 \x01(\v2\x17.mpi.v1.CertificateMetaH\x00R\x0fcertificateMeta\x128\n" +
+	"\fsymlink_meta\x18\n" +
+	" \x01(\v2\x13.mpi.v1.SymlinkMetaH\x00R\vsymlinkMeta\x12>\n" +
+	"\x0edirectory_meta\x18\v \x01(\v2\x15.mpi.v1.DirectoryMetaH\x00R\rdirectoryMetaB\v\n" +
+	"\tfile_type\".\n" +
+	"\vSymlinkMeta\x12\x1f\n" +
+	"\x06target\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06target\"\x0f\n" +
+	"\rDirectoryMeta\"\x9f\x01\n" +
 	"\x11UpdateFileRequest\x12 \n" +
 	"\x04file\x18\x01 \x01(\v2\f.mpi.v1.FileR\x04file\x120\n" +
 	"\bcontents\x18\x02 \x01(\v2\x14.mpi.v1.FileContentsR\bcontents\x126\n" +
@@ -1646,7 +2680,26 @@ const file_mpi_v1_files_proto_rawDesc = "" +
 	"\tnot_after\x18\x02 \x01(\x03R\bnotAfter\"Y\n" +
 	"\x17SubjectAlternativeNames\x12\x1b\n" +
 	"\tdns_names\x18\x01 \x03(\tR\bdnsNames\x12!\n" +
-	"\fip_addresses\x18\x02 \x03(\tR\vipAddresses\"\x98\x04\n" +
+	"\fip_addresses\x18\x02 \x03(\tR\vipAddresses\"?\n" +
+	"\x0fConfigChangeSet\x12,\n" +
+	"\achanges\x18\x01 \x03(\v2\x12.mpi.v1.FileChangeR\achanges\"\xcc\x01\n" +
+	"\n" +
+	"FileChange\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12*\n" +
+	"\x06action\x18\x02 \x01(\x0e2\x12.mpi.v1.FileActionR\x06action\x12\x19\n" +
+	"\bold_hash\x18\x03 \x01(\tR\aoldHash\x12\x19\n" +
+	"\bnew_hash\x18\x04 \x01(\tR\anewHash\x12\x19\n" +
+	"\bold_size\x18\x05 \x01(\x03R\aoldSize\x12\x19\n" +
+	"\bnew_size\x18\x06 \x01(\x03R\anewSize\x12\x12\n" +
+	"\x04diff\x18\a \x01(\tR\x04diff\"I\n" +
+	"\rConfigHistory\x128\n" +
+	"\bversions\x18\x01 \x03(\v2\x1c.mpi.v1.ConfigHistoryVersionR\bversions\"\xdc\x01\n" +
+	"\x14ConfigHistoryVersion\x12<\n" +
+	"\x0econfig_version\x18\x01 \x01(\v2\x15.mpi.v1.ConfigVersionR\rconfigVersion\x12=\n" +
+	"\fapplied_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vappliedTime\x12\x1f\n" +
+	"\vconfig_path\x18\x03 \x01(\tR\n" +
+	"configPath\x12&\n" +
+	"\x05files\x18\x04 \x03(\v2\x10.mpi.v1.FileMetaR\x05files\"\x98\x04\n" +
 	"\bX509Name\x12(\n" +
 	"\acountry\x18\x01 \x03(\tB\x0e\xbaH\v\x92\x01\b\"\x06r\x04\x10\x02\x18\x02R\acountry\x120\n" +
 	"\forganization\x18\x02 \x03(\tB\f\xbaH\t\x92\x01\x06\"\x04r\x02\x10\x01R\forganization\x12=\n" +
@@ -1665,7 +2718,19 @@ const file_mpi_v1_files_proto_rawDesc = "" +
 	"extraNames\"S\n" +
 	"\x15AttributeTypeAndValue\x12\x1b\n" +
 	"\x04type\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04type\x12\x1d\n" +
-	"\x05value\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x05value*\x8a\x03\n" +
+	"\x05value\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x05value*\xa3\x01\n" +
+	"\n" +
+	"FileAction\x12\x1b\n" +
+	"\x17FILE_ACTION_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15FILE_ACTION_UNCHANGED\x10\x01\x12\x13\n" +
+	"\x0fFILE_ACTION_ADD\x10\x02\x12\x16\n" +
+	"\x12FILE_ACTION_UPDATE\x10\x03\x12\x16\n" +
+	"\x12FILE_ACTION_DELETE\x10\x04\x12\x18\n" +
+	"\x14FILE_ACTION_EXTERNAL\x10\x05*V\n" +
+	"\vCompression\x12\x1b\n" +
+	"\x17COMPRESSION_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10COMPRESSION_GZIP\x10\x01\x12\x14\n" +
+	"\x10COMPRESSION_ZSTD\x10\x02*\x8a\x03\n" +
 	"\x12SignatureAlgorithm\x12\x1f\n" +
 	"\x1bSIGNATURE_ALGORITHM_UNKNOWN\x10\x00\x12\x10\n" +
 	"\fMD2_WITH_RSA\x10\x01\x12\x10\n" +
@@ -1706,83 +2771,114 @@ func file_mpi_v1_files_proto_rawDescGZIP() []byte {
 	return file_mpi_v1_files_proto_rawDescData
 }
 
-var file_mpi_v1_files_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_mpi_v1_files_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_mpi_v1_files_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_mpi_v1_files_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_mpi_v1_files_proto_goTypes = []any{
-	(SignatureAlgorithm)(0),         // 0: mpi.v1.SignatureAlgorithm
-	(*FileDataChunk)(nil),           // 1: mpi.v1.FileDataChunk
-	(*FileDataChunkHeader)(nil),     // 2: mpi.v1.FileDataChunkHeader
-	(*FileDataChunkContent)(nil),    // 3: mpi.v1.FileDataChunkContent
-	(*GetOverviewRequest)(nil),      // 4: mpi.v1.GetOverviewRequest
-	(*GetOverviewResponse)(nil),     // 5: mpi.v1.GetOverviewResponse
-	(*UpdateOverviewRequest)(nil),   // 6: mpi.v1.UpdateOverviewRequest
-	(*UpdateOverviewResponse)(nil),  // 7: mpi.v1.UpdateOverviewResponse
-	(*ConfigVersion)(nil),           // 8: mpi.v1.ConfigVersion
-	(*FileOverview)(nil),            // 9: mpi.v1.FileOverview
-	(*File)(nil),                    // 10: mpi.v1.File
-	(*ExternalDataSource)(nil),      // 11: mpi.v1.ExternalDataSource
-	(*GetFileRequest)(nil),          // 12: mpi.v1.GetFileRequest
-	(*GetFileResponse)(nil),         // 13: mpi.v1.GetFileResponse
-	(*FileContents)(nil),            // 14: mpi.v1.FileContents
-	(*FileMeta)(nil),                // 15: mpi.v1.FileMeta
-	(*UpdateFileRequest)(nil),       // 16: mpi.v1.UpdateFileRequest
-	(*UpdateFileResponse)(nil),      // 17: mpi.v1.UpdateFileResponse
-	(*CertificateMeta)(nil),         // 18: mpi.v1.CertificateMeta
-	(*CertificateDates)(nil),        // 19: mpi.v1.CertificateDates
-	(*SubjectAlternativeNames)(nil), // 20: mpi.v1.SubjectAlternativeNames
-	(*X509Name)(nil),                // 21: mpi.v1.X509Name
-	(*AttributeTypeAndValue)(nil),   // 22: mpi.v1.AttributeTypeAndValue
-	(*MessageMeta)(nil),             // 23: mpi.v1.MessageMeta
-	(*timestamppb.Timestamp)(nil),   // 24: google.protobuf.Timestamp
+	(FileAction)(0),                 // 0: mpi.v1.FileAction
+	(Compression)(0),                // 1: mpi.v1.Compression
+	(SignatureAlgorithm)(0),         // 2: mpi.v1.SignatureAlgorithm
+	(*FileDataChunk)(nil),           // 3: mpi.v1.FileDataChunk
+	(*FileDataChunkHeader)(nil),     // 4: mpi.v1.FileDataChunkHeader
+	(*FileDataChunkContent)(nil),    // 5: mpi.v1.FileDataChunkContent
+	(*FileDataChunkDelta)(nil),      // 6: mpi.v1.FileDataChunkDelta
+	(*FileBlockCopy)(nil),           // 7: mpi.v1.FileBlockCopy
+	(*FileSignature)(nil),           // 8: mpi.v1.FileSignature
+	(*BlockSignature)(nil),          // 9: mpi.v1.BlockSignature
+	(*GetOverviewRequest)(nil),      // 10: mpi.v1.GetOverviewRequest
+	(*GetOverviewResponse)(nil),     // 11: mpi.v1.GetOverviewResponse
+	(*UpdateOverviewRequest)(nil),   // 12: mpi.v1.UpdateOverviewRequest
+	(*UpdateOverviewResponse)(nil),  // 13: mpi.v1.UpdateOverviewResponse
+	(*ConfigVersion)(nil),           // 14: mpi.v1.ConfigVersion
+	(*FileOverview)(nil),            // 15: mpi.v1.FileOverview
+	(*File)(nil),                    // 16: mpi.v1.File
+	(*FileTemplate)(nil),            // 17: mpi.v1.FileTemplate
+	(*ExternalDataSource)(nil),      // 18: mpi.v1.ExternalDataSource
+	(*GetFileRequest)(nil),          // 19: mpi.v1.GetFileRequest
+	(*GetFileResponse)(nil),         // 20: mpi.v1.GetFileResponse
+	(*FileContents)(nil),            // 21: mpi.v1.FileContents
+	(*FileMeta)(nil),                // 22: mpi.v1.FileMeta
+	(*SymlinkMeta)(nil),             // 23: mpi.v1.SymlinkMeta
+	(*DirectoryMeta)(nil),           // 24: mpi.v1.DirectoryMeta
+	(*UpdateFileRequest)(nil),       // 25: mpi.v1.UpdateFileRequest
+	(*UpdateFileResponse)(nil),      // 26: mpi.v1.UpdateFileResponse
+	(*CertificateMeta)(nil),         // 27: mpi.v1.CertificateMeta
+	(*CertificateDates)(nil),        // 28: mpi.v1.CertificateDates
+	(*SubjectAlternativeNames)(nil), // 29: mpi.v1.SubjectAlternativeNames
+	(*ConfigChangeSet)(nil),         // 30: mpi.v1.ConfigChangeSet
+	(*FileChange)(nil),              // 31: mpi.v1.FileChange
+	(*ConfigHistory)(nil),           // 32: mpi.v1.ConfigHistory
+	(*ConfigHistoryVersion)(nil),    // 33: mpi.v1.ConfigHistoryVersion
+	(*X509Name)(nil),                // 34: mpi.v1.X509Name
+	(*AttributeTypeAndValue)(nil),   // 35: mpi.v1.AttributeTypeAndValue
+	(*MessageMeta)(nil),             // 36: mpi.v1.MessageMeta
+	(*durationpb.Duration)(nil),     // 37: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),   // 38: google.protobuf.Timestamp
 }
 var file_mpi_v1_files_proto_depIdxs = []int32{
-	23, // 0: mpi.v1.FileDataChunk.meta:type_name -> mpi.v1.MessageMeta
-	2,  // 1: mpi.v1.FileDataChunk.header:type_name -> mpi.v1.FileDataChunkHeader
-	3,  // 2: mpi.v1.FileDataChunk.content:type_name -> mpi.v1.FileDataChunkContent
-	15, // 3: mpi.v1.FileDataChunkHeader.file_meta:type_name -> mpi.v1.FileMeta
-	23, // 4: mpi.v1.GetOverviewRequest.message_meta:type_name -> mpi.v1.MessageMeta
-	8,  // 5: mpi.v1.GetOverviewRequest.config_version:type_name -> mpi.v1.ConfigVersion
-	9,  // 6: mpi.v1.GetOverviewResponse.overview:type_name -> mpi.v1.FileOverview
-	23, // 7: mpi.v1.UpdateOverviewRequest.message_meta:type_name -> mpi.v1.MessageMeta
-	9,  // 8: mpi.v1.UpdateOverviewRequest.overview:type_name -> mpi.v1.FileOverview
-	9,  // 9: mpi.v1.UpdateOverviewResponse.overview:type_name -> mpi.v1.FileOverview
-	10, // 10: mpi.v1.FileOverview.files:type_name -> mpi.v1.File
-	8,  // 11: mpi.v1.FileOverview.config_version:type_name -> mpi.v1.ConfigVersion
-	15, // 12: mpi.v1.File.file_meta:type_name -> mpi.v1.FileMeta
-	11, // 13: mpi.v1.File.external_data_source:type_name -> mpi.v1.ExternalDataSource
-	23, // 14: mpi.v1.GetFileRequest.message_meta:type_name -> mpi.v1.MessageMeta
-	15, // 15: mpi.v1.GetFileRequest.file_meta:type_name -> mpi.v1.FileMeta
-	14, // 16: mpi.v1.GetFileResponse.contents:type_name -> mpi.v1.FileContents
-	24, // 17: mpi.v1.FileMeta.modified_time:type_name -> google.protobuf.Timestamp
-	18, // 18: mpi.v1.FileMeta.certificate_meta:type_name -> mpi.v1.CertificateMeta
-	10, // 19: mpi.v1.UpdateFileRequest.file:type_name -> mpi.v1.File
-	14, // 20: mpi.v1.UpdateFileRequest.contents:type_name -> mpi.v1.FileContents
-	23, // 21: mpi.v1.UpdateFileRequest.message_meta:type_name -> mpi.v1.MessageMeta
-	15, // 22: mpi.v1.UpdateFileResponse.file_meta:type_name -> mpi.v1.FileMeta
-	21, // 23: mpi.v1.CertificateMeta.issuer:type_name -> mpi.v1.X509Name
-	21, // 24: mpi.v1.CertificateMeta.subject:type_name -> mpi.v1.X509Name
-	20, // 25: mpi.v1.CertificateMeta.sans:type_name -> mpi.v1.SubjectAlternativeNames
-	19, // 26: mpi.v1.CertificateMeta.dates:type_name -> mpi.v1.CertificateDates
-	0,  // 27: mpi.v1.CertificateMeta.signature_algorithm:type_name -> mpi.v1.SignatureAlgorithm
-	22, // 28: mpi.v1.X509Name.names:type_name -> mpi.v1.AttributeTypeAndValue
-	22, // 29: mpi.v1.X509Name.extra_names:type_name -> mpi.v1.AttributeTypeAndValue
-	4,  // 30: mpi.v1.FileService.GetOverview:input_type -> mpi.v1.GetOverviewRequest
-	6,  // 31: mpi.v1.FileService.UpdateOverview:input_type -> mpi.v1.UpdateOverviewRequest
-	12, // 32: mpi.v1.FileService.GetFile:input_type -> mpi.v1.GetFileRequest
-	16, // 33: mpi.v1.FileService.UpdateFile:input_type -> mpi.v1.UpdateFileRequest
-	12, // 34: mpi.v1.FileService.GetFileStream:input_type -> mpi.v1.GetFileRequest
-	1,  // 35: mpi.v1.FileService.UpdateFileStream:input_type -> mpi.v1.FileDataChunk
-	5,  // 36: mpi.v1.FileService.GetOverview:output_type -> mpi.v1.GetOverviewResponse
-	7,  // 37: mpi.v1.FileService.UpdateOverview:output_type -> mpi.v1.UpdateOverviewResponse
-	13, // 38: mpi.v1.FileService.GetFile:output_type -> mpi.v1.GetFileResponse
-	17, // 39: mpi.v1.FileService.UpdateFile:output_type -> mpi.v1.UpdateFileResponse
-	1,  // 40: mpi.v1.FileService.GetFileStream:output_type -> mpi.v1.FileDataChunk
-	17, // 41: mpi.v1.FileService.UpdateFileStream:output_type -> mpi.v1.UpdateFileResponse
-	36, // [36:42] is the sub-list for method output_type
-	30, // [30:36] is the sub-list for method input_type
-	30, // [30:30] is the sub-list for extension type_name
-	30, // [30:30] is the sub-list for extension extendee
-	0,  // [0:30] is the sub-list for field type_name
+	36, // 0: mpi.v1.FileDataChunk.meta:type_name -> mpi.v1.MessageMeta
+	4,  // 1: mpi.v1.FileDataChunk.header:type_name -> mpi.v1.FileDataChunkHeader
+	5,  // 2: mpi.v1.FileDataChunk.content:type_name -> mpi.v1.FileDataChunkContent
+	6,  // 3: mpi.v1.FileDataChunk.delta:type_name -> mpi.v1.FileDataChunkDelta
+	22, // 4: mpi.v1.FileDataChunkHeader.file_meta:type_name -> mpi.v1.FileMeta
+	1,  // 5: mpi.v1.FileDataChunkHeader.compression:type_name -> mpi.v1.Compression
+	7,  // 6: mpi.v1.FileDataChunkDelta.copy:type_name -> mpi.v1.FileBlockCopy
+	9,  // 7: mpi.v1.FileSignature.blocks:type_name -> mpi.v1.BlockSignature
+	36, // 8: mpi.v1.GetOverviewRequest.message_meta:type_name -> mpi.v1.MessageMeta
+	14, // 9: mpi.v1.GetOverviewRequest.config_version:type_name -> mpi.v1.ConfigVersion
+	15, // 10: mpi.v1.GetOverviewResponse.overview:type_name -> mpi.v1.FileOverview
+	36, // 11: mpi.v1.UpdateOverviewRequest.message_meta:type_name -> mpi.v1.MessageMeta
+	15, // 12: mpi.v1.UpdateOverviewRequest.overview:type_name -> mpi.v1.FileOverview
+	15, // 13: mpi.v1.UpdateOverviewResponse.overview:type_name -> mpi.v1.FileOverview
+	16, // 14: mpi.v1.FileOverview.files:type_name -> mpi.v1.File
+	14, // 15: mpi.v1.FileOverview.config_version:type_name -> mpi.v1.ConfigVersion
+	22, // 16: mpi.v1.File.file_meta:type_name -> mpi.v1.FileMeta
+	18, // 17: mpi.v1.File.external_data_source:type_name -> mpi.v1.ExternalDataSource
+	17, // 18: mpi.v1.File.template:type_name -> mpi.v1.FileTemplate
+	37, // 19: mpi.v1.ExternalDataSource.refresh_interval:type_name -> google.protobuf.Duration
+	36, // 20: mpi.v1.GetFileRequest.message_meta:type_name -> mpi.v1.MessageMeta
+	22, // 21: mpi.v1.GetFileRequest.file_meta:type_name -> mpi.v1.FileMeta
+	8,  // 22: mpi.v1.GetFileRequest.signature:type_name -> mpi.v1.FileSignature
+	1,  // 23: mpi.v1.GetFileRequest.accepted_compressions:type_name -> mpi.v1.Compression
+	21, // 24: mpi.v1.GetFileResponse.contents:type_name -> mpi.v1.FileContents
+	1,  // 25: mpi.v1.FileContents.compression:type_name -> mpi.v1.Compression
+	38, // 26: mpi.v1.FileMeta.modified_time:type_name -> google.protobuf.Timestamp
+	27, // 27: mpi.v1.FileMeta.certificate_meta:type_name -> mpi.v1.CertificateMeta
+	23, // 28: mpi.v1.FileMeta.symlink_meta:type_name -> mpi.v1.SymlinkMeta
+	24, // 29: mpi.v1.FileMeta.directory_meta:type_name -> mpi.v1.DirectoryMeta
+	16, // 30: mpi.v1.UpdateFileRequest.file:type_name -> mpi.v1.File
+	21, // 31: mpi.v1.UpdateFileRequest.contents:type_name -> mpi.v1.FileContents
+	36, // 32: mpi.v1.UpdateFileRequest.message_meta:type_name -> mpi.v1.MessageMeta
+	22, // 33: mpi.v1.UpdateFileResponse.file_meta:type_name -> mpi.v1.FileMeta
+	34, // 34: mpi.v1.CertificateMeta.issuer:type_name -> mpi.v1.X509Name
+	34, // 35: mpi.v1.CertificateMeta.subject:type_name -> mpi.v1.X509Name
+	29, // 36: mpi.v1.CertificateMeta.sans:type_name -> mpi.v1.SubjectAlternativeNames
+	28, // 37: mpi.v1.CertificateMeta.dates:type_name -> mpi.v1.CertificateDates
+	2,  // 38: mpi.v1.CertificateMeta.signature_algorithm:type_name -> mpi.v1.SignatureAlgorithm
+	31, // 39: mpi.v1.ConfigChangeSet.changes:type_name -> mpi.v1.FileChange
+	0,  // 40: mpi.v1.FileChange.action:type_name -> mpi.v1.FileAction
+	33, // 41: mpi.v1.ConfigHistory.versions:type_name -> mpi.v1.ConfigHistoryVersion
+	14, // 42: mpi.v1.ConfigHistoryVersion.config_version:type_name -> mpi.v1.ConfigVersion
+	38, // 43: mpi.v1.ConfigHistoryVersion.applied_time:type_name -> google.protobuf.Timestamp
+	22, // 44: mpi.v1.ConfigHistoryVersion.files:type_name -> mpi.v1.FileMeta
+	35, // 45: mpi.v1.X509Name.names:type_name -> mpi.v1.AttributeTypeAndValue
+	35, // 46: mpi.v1.X509Name.extra_names:type_name -> mpi.v1.AttributeTypeAndValue
+	10, // 47: mpi.v1.FileService.GetOverview:input_type -> mpi.v1.GetOverviewRequest
+	12, // 48: mpi.v1.FileService.UpdateOverview:input_type -> mpi.v1.UpdateOverviewRequest
+	19, // 49: mpi.v1.FileService.GetFile:input_type -> mpi.v1.GetFileRequest
+	25, // 50: mpi.v1.FileService.UpdateFile:input_type -> mpi.v1.UpdateFileRequest
+	19, // 51: mpi.v1.FileService.GetFileStream:input_type -> mpi.v1.GetFileRequest
+	3,  // 52: mpi.v1.FileService.UpdateFileStream:input_type -> mpi.v1.FileDataChunk
+	11, // 53: mpi.v1.FileService.GetOverview:output_type -> mpi.v1.GetOverviewResponse
+	13, // 54: mpi.v1.FileService.UpdateOverview:output_type -> mpi.v1.UpdateOverviewResponse
+	20, // 55: mpi.v1.FileService.GetFile:output_type -> mpi.v1.GetFileResponse
+	26, // 56: mpi.v1.FileService.UpdateFile:output_type -> mpi.v1.UpdateFileResponse
+	3,  // 57: mpi.v1.FileService.GetFileStream:output_type -> mpi.v1.FileDataChunk
+	26, // 58: mpi.v1.FileService.UpdateFileStream:output_type -> mpi.v1.UpdateFileResponse
+	53, // [53:59] is the sub-list for method output_type
+	47, // [47:53] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_mpi_v1_files_proto_init() }
@@ -1794,18 +2890,25 @@ func file_mpi_v1_files_proto_init() {
 	file_mpi_v1_files_proto_msgTypes[0].OneofWrappers = []any{
 		(*FileDataChunk_Header)(nil),
 		(*FileDataChunk_Content)(nil),
+		(*FileDataChunk_Delta)(nil),
+	}
+	file_mpi_v1_files_proto_msgTypes[3].OneofWrappers = []any{
+		(*FileDataChunkDelta_Copy)(nil),
+		(*FileDataChunkDelta_Data)(nil),
 	}
-	file_mpi_v1_files_proto_msgTypes[9].OneofWrappers = []any{}
-	file_mpi_v1_files_proto_msgTypes[14].OneofWrappers = []any{
+	file_mpi_v1_files_proto_msgTypes[13].OneofWrappers = []any{}
+	file_mpi_v1_files_proto_msgTypes[19].OneofWrappers = []any{
 		(*FileMeta_CertificateMeta)(nil),
+		(*FileMeta_SymlinkMeta)(nil),
+		(*FileMeta_DirectoryMeta)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mpi_v1_files_proto_rawDesc), len(file_mpi_v1_files_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   22,
+			NumEnums:      3,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
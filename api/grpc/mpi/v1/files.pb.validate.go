@@ -169,6 +169,47 @@ func (m *FileDataChunk) validate(all bool) error {
 			}
 		}
 
+	case *FileDataChunk_Delta:
+		if v == nil {
+			err := FileDataChunkValidationError{
+				field:  "Chunk",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetDelta()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, FileDataChunkValidationError{
+						field:  "Delta",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, FileDataChunkValidationError{
+						field:  "Delta",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetDelta()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FileDataChunkValidationError{
+					field:  "Delta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	default:
 		_ = v // ensures v is used
 	}
@@ -306,6 +347,12 @@ func (m *FileDataChunkHeader) validate(all bool) error {
 
 	// no validation rules for ChunkSize
 
+	// no validation rules for Delta
+
+	// no validation rules for Offset
+
+	// no validation rules for Compression
+
 	if len(errors) > 0 {
 		return FileDataChunkHeaderMultiError(errors)
 	}
@@ -492,100 +539,102 @@ var _ interface {
 	ErrorName() string
 } = FileDataChunkContentValidationError{}
 
-// Validate checks the field values on GetOverviewRequest with the rules
+// Validate checks the field values on FileDataChunkDelta with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetOverviewRequest) Validate() error {
+func (m *FileDataChunkDelta) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetOverviewRequest with the rules
+// ValidateAll checks the field values on FileDataChunkDelta with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetOverviewRequestMultiError, or nil if none found.
-func (m *GetOverviewRequest) ValidateAll() error {
+// FileDataChunkDeltaMultiError, or nil if none found.
+func (m *FileDataChunkDelta) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetOverviewRequest) validate(all bool) error {
+func (m *FileDataChunkDelta) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetMessageMeta()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, GetOverviewRequestValidationError{
-					field:  "MessageMeta",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, GetOverviewRequestValidationError{
-					field:  "MessageMeta",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
+	// no validation rules for ChunkId
+
+	switch v := m.Instruction.(type) {
+	case *FileDataChunkDelta_Copy:
+		if v == nil {
+			err := FileDataChunkDeltaValidationError{
+				field:  "Instruction",
+				reason: "oneof value cannot be a typed-nil",
 			}
-		}
-	} else if v, ok := interface{}(m.GetMessageMeta()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return GetOverviewRequestValidationError{
-				field:  "MessageMeta",
-				reason: "embedded message failed validation",
-				cause:  err,
+			if !all {
+				return err
 			}
+			errors = append(errors, err)
 		}
-	}
 
-	if all {
-		switch v := interface{}(m.GetConfigVersion()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, GetOverviewRequestValidationError{
-					field:  "ConfigVersion",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
+		if all {
+			switch v := interface{}(m.GetCopy()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, FileDataChunkDeltaValidationError{
+						field:  "Copy",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, FileDataChunkDeltaValidationError{
+						field:  "Copy",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
 			}
-		case interface{ Validate() error }:
+		} else if v, ok := interface{}(m.GetCopy()).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				errors = append(errors, GetOverviewRequestValidationError{
-					field:  "ConfigVersion",
+				return FileDataChunkDeltaValidationError{
+					field:  "Copy",
 					reason: "embedded message failed validation",
 					cause:  err,
-				})
+				}
 			}
 		}
-	} else if v, ok := interface{}(m.GetConfigVersion()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return GetOverviewRequestValidationError{
-				field:  "ConfigVersion",
-				reason: "embedded message failed validation",
-				cause:  err,
+
+	case *FileDataChunkDelta_Data:
+		if v == nil {
+			err := FileDataChunkDeltaValidationError{
+				field:  "Instruction",
+				reason: "oneof value cannot be a typed-nil",
 			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
 		}
+		// no validation rules for Data
+	default:
+		_ = v // ensures v is used
 	}
 
 	if len(errors) > 0 {
-		return GetOverviewRequestMultiError(errors)
+		return FileDataChunkDeltaMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetOverviewRequestMultiError is an error wrapping multiple validation errors
-// returned by GetOverviewRequest.ValidateAll() if the designated constraints
-// aren't met.
-type GetOverviewRequestMultiError []error
+// FileDataChunkDeltaMultiError is an error wrapping multiple validation
+// errors returned by FileDataChunkDelta.ValidateAll() if the designated
+// constraints aren't met.
+type FileDataChunkDeltaMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetOverviewRequestMultiError) Error() string {
+func (m FileDataChunkDeltaMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -594,11 +643,11 @@ func (m GetOverviewRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetOverviewRequestMultiError) AllErrors() []error { return m }
+func (m FileDataChunkDeltaMultiError) AllErrors() []error { return m }
 
-// GetOverviewRequestValidationError is the validation error returned by
-// GetOverviewRequest.Validate if the designated constraints aren't met.
-type GetOverviewRequestValidationError struct {
+// FileDataChunkDeltaValidationError is the validation error returned by
+// FileDataChunkDelta.Validate if the designated constraints aren't met.
+type FileDataChunkDeltaValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -606,24 +655,24 @@ type GetOverviewRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetOverviewRequestValidationError) Field() string { return e.field }
+func (e FileDataChunkDeltaValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetOverviewRequestValidationError) Reason() string { return e.reason }
+func (e FileDataChunkDeltaValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetOverviewRequestValidationError) Cause() error { return e.cause }
+func (e FileDataChunkDeltaValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetOverviewRequestValidationError) Key() bool { return e.key }
+func (e FileDataChunkDeltaValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetOverviewRequestValidationError) ErrorName() string {
-	return "GetOverviewRequestValidationError"
+func (e FileDataChunkDeltaValidationError) ErrorName() string {
+	return "FileDataChunkDeltaValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e GetOverviewRequestValidationError) Error() string {
+func (e FileDataChunkDeltaValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -635,14 +684,14 @@ func (e GetOverviewRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetOverviewRequest.%s: %s%s",
+		"invalid %sFileDataChunkDelta.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetOverviewRequestValidationError{}
+var _ error = FileDataChunkDeltaValidationError{}
 
 var _ interface {
 	Field() string
@@ -650,73 +699,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetOverviewRequestValidationError{}
+} = FileDataChunkDeltaValidationError{}
 
-// Validate checks the field values on GetOverviewResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *GetOverviewResponse) Validate() error {
+// Validate checks the field values on FileBlockCopy with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *FileBlockCopy) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetOverviewResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// GetOverviewResponseMultiError, or nil if none found.
-func (m *GetOverviewResponse) ValidateAll() error {
+// ValidateAll checks the field values on FileBlockCopy with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FileBlockCopyMultiError, or
+// nil if none found.
+func (m *FileBlockCopy) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetOverviewResponse) validate(all bool) error {
+func (m *FileBlockCopy) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetOverview()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, GetOverviewResponseValidationError{
-					field:  "Overview",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, GetOverviewResponseValidationError{
-					field:  "Overview",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetOverview()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return GetOverviewResponseValidationError{
-				field:  "Overview",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for BlockIndex
+
+	// no validation rules for BlockCount
 
 	if len(errors) > 0 {
-		return GetOverviewResponseMultiError(errors)
+		return FileBlockCopyMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetOverviewResponseMultiError is an error wrapping multiple validation
-// errors returned by GetOverviewResponse.ValidateAll() if the designated
-// constraints aren't met.
-type GetOverviewResponseMultiError []error
+// FileBlockCopyMultiError is an error wrapping multiple validation errors
+// returned by FileBlockCopy.ValidateAll() if the designated constraints
+// aren't met.
+type FileBlockCopyMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetOverviewResponseMultiError) Error() string {
+func (m FileBlockCopyMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -725,11 +749,11 @@ func (m GetOverviewResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetOverviewResponseMultiError) AllErrors() []error { return m }
+func (m FileBlockCopyMultiError) AllErrors() []error { return m }
 
-// GetOverviewResponseValidationError is the validation error returned by
-// GetOverviewResponse.Validate if the designated constraints aren't met.
-type GetOverviewResponseValidationError struct {
+// FileBlockCopyValidationError is the validation error returned by
+// FileBlockCopy.Validate if the designated constraints aren't met.
+type FileBlockCopyValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -737,24 +761,22 @@ type GetOverviewResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetOverviewResponseValidationError) Field() string { return e.field }
+func (e FileBlockCopyValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetOverviewResponseValidationError) Reason() string { return e.reason }
+func (e FileBlockCopyValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetOverviewResponseValidationError) Cause() error { return e.cause }
+func (e FileBlockCopyValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetOverviewResponseValidationError) Key() bool { return e.key }
+func (e FileBlockCopyValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetOverviewResponseValidationError) ErrorName() string {
-	return "GetOverviewResponseValidationError"
-}
+func (e FileBlockCopyValidationError) ErrorName() string { return "FileBlockCopyValidationError" }
 
 // Error satisfies the builtin error interface
-func (e GetOverviewResponseValidationError) Error() string {
+func (e FileBlockCopyValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -766,14 +788,14 @@ func (e GetOverviewResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetOverviewResponse.%s: %s%s",
+		"invalid %sFileBlockCopy.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetOverviewResponseValidationError{}
+var _ error = FileBlockCopyValidationError{}
 
 var _ interface {
 	Field() string
@@ -781,102 +803,80 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetOverviewResponseValidationError{}
+} = FileBlockCopyValidationError{}
 
-// Validate checks the field values on UpdateOverviewRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *UpdateOverviewRequest) Validate() error {
+// Validate checks the field values on FileSignature with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *FileSignature) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UpdateOverviewRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// UpdateOverviewRequestMultiError, or nil if none found.
-func (m *UpdateOverviewRequest) ValidateAll() error {
+// ValidateAll checks the field values on FileSignature with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FileSignatureMultiError, or
+// nil if none found.
+func (m *FileSignature) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UpdateOverviewRequest) validate(all bool) error {
+func (m *FileSignature) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetMessageMeta()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UpdateOverviewRequestValidationError{
-					field:  "MessageMeta",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
+	// no validation rules for BlockSize
+
+	for idx, item := range m.GetBlocks() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, FileSignatureValidationError{
+						field:  fmt.Sprintf("Blocks[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, FileSignatureValidationError{
+						field:  fmt.Sprintf("Blocks[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
 			}
-		case interface{ Validate() error }:
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				errors = append(errors, UpdateOverviewRequestValidationError{
-					field:  "MessageMeta",
+				return FileSignatureValidationError{
+					field:  fmt.Sprintf("Blocks[%v]", idx),
 					reason: "embedded message failed validation",
 					cause:  err,
-				})
+				}
 			}
 		}
-	} else if v, ok := interface{}(m.GetMessageMeta()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return UpdateOverviewRequestValidationError{
-				field:  "MessageMeta",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
 
-	if all {
-		switch v := interface{}(m.GetOverview()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UpdateOverviewRequestValidationError{
-					field:  "Overview",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, UpdateOverviewRequestValidationError{
-					field:  "Overview",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetOverview()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return UpdateOverviewRequestValidationError{
-				field:  "Overview",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
 	}
 
 	if len(errors) > 0 {
-		return UpdateOverviewRequestMultiError(errors)
+		return FileSignatureMultiError(errors)
 	}
 
 	return nil
 }
 
-// UpdateOverviewRequestMultiError is an error wrapping multiple validation
-// errors returned by UpdateOverviewRequest.ValidateAll() if the designated
-// constraints aren't met.
-type UpdateOverviewRequestMultiError []error
+// FileSignatureMultiError is an error wrapping multiple validation errors
+// returned by FileSignature.ValidateAll() if the designated constraints
+// aren't met.
+type FileSignatureMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UpdateOverviewRequestMultiError) Error() string {
+func (m FileSignatureMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -885,11 +885,11 @@ func (m UpdateOverviewRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UpdateOverviewRequestMultiError) AllErrors() []error { return m }
+func (m FileSignatureMultiError) AllErrors() []error { return m }
 
-// UpdateOverviewRequestValidationError is the validation error returned by
-// UpdateOverviewRequest.Validate if the designated constraints aren't met.
-type UpdateOverviewRequestValidationError struct {
+// FileSignatureValidationError is the validation error returned by
+// FileSignature.Validate if the designated constraints aren't met.
+type FileSignatureValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -897,24 +897,22 @@ type UpdateOverviewRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdateOverviewRequestValidationError) Field() string { return e.field }
+func (e FileSignatureValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdateOverviewRequestValidationError) Reason() string { return e.reason }
+func (e FileSignatureValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdateOverviewRequestValidationError) Cause() error { return e.cause }
+func (e FileSignatureValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdateOverviewRequestValidationError) Key() bool { return e.key }
+func (e FileSignatureValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdateOverviewRequestValidationError) ErrorName() string {
-	return "UpdateOverviewRequestValidationError"
-}
+func (e FileSignatureValidationError) ErrorName() string { return "FileSignatureValidationError" }
 
 // Error satisfies the builtin error interface
-func (e UpdateOverviewRequestValidationError) Error() string {
+func (e FileSignatureValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -926,14 +924,14 @@ func (e UpdateOverviewRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdateOverviewRequest.%s: %s%s",
+		"invalid %sFileSignature.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdateOverviewRequestValidationError{}
+var _ error = FileSignatureValidationError{}
 
 var _ interface {
 	Field() string
@@ -941,73 +939,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdateOverviewRequestValidationError{}
+} = FileSignatureValidationError{}
 
-// Validate checks the field values on UpdateOverviewResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *UpdateOverviewResponse) Validate() error {
+// Validate checks the field values on BlockSignature with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *BlockSignature) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UpdateOverviewResponse with the rules
+// ValidateAll checks the field values on BlockSignature with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// UpdateOverviewResponseMultiError, or nil if none found.
-func (m *UpdateOverviewResponse) ValidateAll() error {
+// BlockSignatureMultiError, or nil if none found.
+func (m *BlockSignature) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UpdateOverviewResponse) validate(all bool) error {
+func (m *BlockSignature) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetOverview()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UpdateOverviewResponseValidationError{
-					field:  "Overview",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, UpdateOverviewResponseValidationError{
-					field:  "Overview",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetOverview()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return UpdateOverviewResponseValidationError{
-				field:  "Overview",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for WeakHash
+
+	// no validation rules for StrongHash
 
 	if len(errors) > 0 {
-		return UpdateOverviewResponseMultiError(errors)
+		return BlockSignatureMultiError(errors)
 	}
 
 	return nil
 }
 
-// UpdateOverviewResponseMultiError is an error wrapping multiple validation
-// errors returned by UpdateOverviewResponse.ValidateAll() if the designated
-// constraints aren't met.
-type UpdateOverviewResponseMultiError []error
+// BlockSignatureMultiError is an error wrapping multiple validation errors
+// returned by BlockSignature.ValidateAll() if the designated constraints
+// aren't met.
+type BlockSignatureMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UpdateOverviewResponseMultiError) Error() string {
+func (m BlockSignatureMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1016,11 +989,11 @@ func (m UpdateOverviewResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UpdateOverviewResponseMultiError) AllErrors() []error { return m }
+func (m BlockSignatureMultiError) AllErrors() []error { return m }
 
-// UpdateOverviewResponseValidationError is the validation error returned by
-// UpdateOverviewResponse.Validate if the designated constraints aren't met.
-type UpdateOverviewResponseValidationError struct {
+// BlockSignatureValidationError is the validation error returned by
+// BlockSignature.Validate if the designated constraints aren't met.
+type BlockSignatureValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1028,24 +1001,22 @@ type UpdateOverviewResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdateOverviewResponseValidationError) Field() string { return e.field }
+func (e BlockSignatureValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdateOverviewResponseValidationError) Reason() string { return e.reason }
+func (e BlockSignatureValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdateOverviewResponseValidationError) Cause() error { return e.cause }
+func (e BlockSignatureValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdateOverviewResponseValidationError) Key() bool { return e.key }
+func (e BlockSignatureValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdateOverviewResponseValidationError) ErrorName() string {
-	return "UpdateOverviewResponseValidationError"
-}
+func (e BlockSignatureValidationError) ErrorName() string { return "BlockSignatureValidationError" }
 
 // Error satisfies the builtin error interface
-func (e UpdateOverviewResponseValidationError) Error() string {
+func (e BlockSignatureValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1057,14 +1028,14 @@ func (e UpdateOverviewResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdateOverviewResponse.%s: %s%s",
+		"invalid %sBlockSignature.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdateOverviewResponseValidationError{}
+var _ error = BlockSignatureValidationError{}
 
 var _ interface {
 	Field() string
@@ -1072,48 +1043,102 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdateOverviewResponseValidationError{}
+} = BlockSignatureValidationError{}
 
-// Validate checks the field values on ConfigVersion with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *ConfigVersion) Validate() error {
+// Validate checks the field values on GetOverviewRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetOverviewRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ConfigVersion with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in ConfigVersionMultiError, or
-// nil if none found.
-func (m *ConfigVersion) ValidateAll() error {
+// ValidateAll checks the field values on GetOverviewRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetOverviewRequestMultiError, or nil if none found.
+func (m *GetOverviewRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ConfigVersion) validate(all bool) error {
+func (m *GetOverviewRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for InstanceId
+	if all {
+		switch v := interface{}(m.GetMessageMeta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetOverviewRequestValidationError{
+					field:  "MessageMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetOverviewRequestValidationError{
+					field:  "MessageMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMessageMeta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetOverviewRequestValidationError{
+				field:  "MessageMeta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
 
-	// no validation rules for Version
+	if all {
+		switch v := interface{}(m.GetConfigVersion()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetOverviewRequestValidationError{
+					field:  "ConfigVersion",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetOverviewRequestValidationError{
+					field:  "ConfigVersion",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetConfigVersion()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetOverviewRequestValidationError{
+				field:  "ConfigVersion",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
 
 	if len(errors) > 0 {
-		return ConfigVersionMultiError(errors)
+		return GetOverviewRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// ConfigVersionMultiError is an error wrapping multiple validation errors
-// returned by ConfigVersion.ValidateAll() if the designated constraints
+// GetOverviewRequestMultiError is an error wrapping multiple validation errors
+// returned by GetOverviewRequest.ValidateAll() if the designated constraints
 // aren't met.
-type ConfigVersionMultiError []error
+type GetOverviewRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ConfigVersionMultiError) Error() string {
+func (m GetOverviewRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1122,11 +1147,11 @@ func (m ConfigVersionMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ConfigVersionMultiError) AllErrors() []error { return m }
+func (m GetOverviewRequestMultiError) AllErrors() []error { return m }
 
-// ConfigVersionValidationError is the validation error returned by
-// ConfigVersion.Validate if the designated constraints aren't met.
-type ConfigVersionValidationError struct {
+// GetOverviewRequestValidationError is the validation error returned by
+// GetOverviewRequest.Validate if the designated constraints aren't met.
+type GetOverviewRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1134,22 +1159,24 @@ type ConfigVersionValidationError struct {
 }
 
 // Field function returns field value.
-func (e ConfigVersionValidationError) Field() string { return e.field }
+func (e GetOverviewRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ConfigVersionValidationError) Reason() string { return e.reason }
+func (e GetOverviewRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ConfigVersionValidationError) Cause() error { return e.cause }
+func (e GetOverviewRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ConfigVersionValidationError) Key() bool { return e.key }
+func (e GetOverviewRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ConfigVersionValidationError) ErrorName() string { return "ConfigVersionValidationError" }
+func (e GetOverviewRequestValidationError) ErrorName() string {
+	return "GetOverviewRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e ConfigVersionValidationError) Error() string {
+func (e GetOverviewRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1161,14 +1188,14 @@ func (e ConfigVersionValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sConfigVersion.%s: %s%s",
+		"invalid %sGetOverviewRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ConfigVersionValidationError{}
+var _ error = GetOverviewRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1176,108 +1203,73 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ConfigVersionValidationError{}
+} = GetOverviewRequestValidationError{}
 
-// Validate checks the field values on FileOverview with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *FileOverview) Validate() error {
+// Validate checks the field values on GetOverviewResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetOverviewResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on FileOverview with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in FileOverviewMultiError, or
-// nil if none found.
-func (m *FileOverview) ValidateAll() error {
+// ValidateAll checks the field values on GetOverviewResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetOverviewResponseMultiError, or nil if none found.
+func (m *GetOverviewResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *FileOverview) validate(all bool) error {
+func (m *GetOverviewResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	for idx, item := range m.GetFiles() {
-		_, _ = idx, item
-
-		if all {
-			switch v := interface{}(item).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, FileOverviewValidationError{
-						field:  fmt.Sprintf("Files[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, FileOverviewValidationError{
-						field:  fmt.Sprintf("Files[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return FileOverviewValidationError{
-					field:  fmt.Sprintf("Files[%v]", idx),
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
-
-	}
-
 	if all {
-		switch v := interface{}(m.GetConfigVersion()).(type) {
+		switch v := interface{}(m.GetOverview()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, FileOverviewValidationError{
-					field:  "ConfigVersion",
+				errors = append(errors, GetOverviewResponseValidationError{
+					field:  "Overview",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, FileOverviewValidationError{
-					field:  "ConfigVersion",
+				errors = append(errors, GetOverviewResponseValidationError{
+					field:  "Overview",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		}
-	} else if v, ok := interface{}(m.GetConfigVersion()).(interface{ Validate() error }); ok {
+	} else if v, ok := interface{}(m.GetOverview()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return FileOverviewValidationError{
-				field:  "ConfigVersion",
+			return GetOverviewResponseValidationError{
+				field:  "Overview",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
 		}
 	}
 
-	// no validation rules for ConfigPath
-
 	if len(errors) > 0 {
-		return FileOverviewMultiError(errors)
+		return GetOverviewResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// FileOverviewMultiError is an error wrapping multiple validation errors
-// returned by FileOverview.ValidateAll() if the designated constraints aren't met.
-type FileOverviewMultiError []error
+// GetOverviewResponseMultiError is an error wrapping multiple validation
+// errors returned by GetOverviewResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetOverviewResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m FileOverviewMultiError) Error() string {
+func (m GetOverviewResponseMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1286,11 +1278,11 @@ func (m FileOverviewMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m FileOverviewMultiError) AllErrors() []error { return m }
+func (m GetOverviewResponseMultiError) AllErrors() []error { return m }
 
-// FileOverviewValidationError is the validation error returned by
-// FileOverview.Validate if the designated constraints aren't met.
-type FileOverviewValidationError struct {
+// GetOverviewResponseValidationError is the validation error returned by
+// GetOverviewResponse.Validate if the designated constraints aren't met.
+type GetOverviewResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1298,22 +1290,24 @@ type FileOverviewValidationError struct {
 }
 
 // Field function returns field value.
-func (e FileOverviewValidationError) Field() string { return e.field }
+func (e GetOverviewResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e FileOverviewValidationError) Reason() string { return e.reason }
+func (e GetOverviewResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e FileOverviewValidationError) Cause() error { return e.cause }
+func (e GetOverviewResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e FileOverviewValidationError) Key() bool { return e.key }
+func (e GetOverviewResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e FileOverviewValidationError) ErrorName() string { return "FileOverviewValidationError" }
+func (e GetOverviewResponseValidationError) ErrorName() string {
+	return "GetOverviewResponseValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e FileOverviewValidationError) Error() string {
+func (e GetOverviewResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1325,14 +1319,14 @@ func (e FileOverviewValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sFileOverview.%s: %s%s",
+		"invalid %sGetOverviewResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = FileOverviewValidationError{}
+var _ error = GetOverviewResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1340,23 +1334,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = FileOverviewValidationError{}
+} = GetOverviewResponseValidationError{}
 
-// Validate checks the field values on File with the rules defined in the proto
-// definition for this message. If any rules are violated, the first error
-// encountered is returned, or nil if there are no violations.
-func (m *File) Validate() error {
+// Validate checks the field values on UpdateOverviewRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateOverviewRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on File with the rules defined in the
-// proto definition for this message. If any rules are violated, the result is
-// a list of violation errors wrapped in FileMultiError, or nil if none found.
-func (m *File) ValidateAll() error {
+// ValidateAll checks the field values on UpdateOverviewRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateOverviewRequestMultiError, or nil if none found.
+func (m *UpdateOverviewRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *File) validate(all bool) error {
+func (m *UpdateOverviewRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -1364,82 +1359,77 @@ func (m *File) validate(all bool) error {
 	var errors []error
 
 	if all {
-		switch v := interface{}(m.GetFileMeta()).(type) {
+		switch v := interface{}(m.GetMessageMeta()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, FileValidationError{
-					field:  "FileMeta",
+				errors = append(errors, UpdateOverviewRequestValidationError{
+					field:  "MessageMeta",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, FileValidationError{
-					field:  "FileMeta",
+				errors = append(errors, UpdateOverviewRequestValidationError{
+					field:  "MessageMeta",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		}
-	} else if v, ok := interface{}(m.GetFileMeta()).(interface{ Validate() error }); ok {
+	} else if v, ok := interface{}(m.GetMessageMeta()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return FileValidationError{
-				field:  "FileMeta",
+			return UpdateOverviewRequestValidationError{
+				field:  "MessageMeta",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
 		}
 	}
 
-	// no validation rules for Unmanaged
-
-	if m.ExternalDataSource != nil {
-
-		if all {
-			switch v := interface{}(m.GetExternalDataSource()).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, FileValidationError{
-						field:  "ExternalDataSource",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, FileValidationError{
-						field:  "ExternalDataSource",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
+	if all {
+		switch v := interface{}(m.GetOverview()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateOverviewRequestValidationError{
+					field:  "Overview",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
 			}
-		} else if v, ok := interface{}(m.GetExternalDataSource()).(interface{ Validate() error }); ok {
+		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				return FileValidationError{
-					field:  "ExternalDataSource",
+				errors = append(errors, UpdateOverviewRequestValidationError{
+					field:  "Overview",
 					reason: "embedded message failed validation",
 					cause:  err,
-				}
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOverview()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateOverviewRequestValidationError{
+				field:  "Overview",
+				reason: "embedded message failed validation",
+				cause:  err,
 			}
 		}
-
 	}
 
 	if len(errors) > 0 {
-		return FileMultiError(errors)
+		return UpdateOverviewRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// FileMultiError is an error wrapping multiple validation errors returned by
-// File.ValidateAll() if the designated constraints aren't met.
-type FileMultiError []error
+// UpdateOverviewRequestMultiError is an error wrapping multiple validation
+// errors returned by UpdateOverviewRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UpdateOverviewRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m FileMultiError) Error() string {
+func (m UpdateOverviewRequestMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1448,11 +1438,11 @@ func (m FileMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m FileMultiError) AllErrors() []error { return m }
+func (m UpdateOverviewRequestMultiError) AllErrors() []error { return m }
 
-// FileValidationError is the validation error returned by File.Validate if the
-// designated constraints aren't met.
-type FileValidationError struct {
+// UpdateOverviewRequestValidationError is the validation error returned by
+// UpdateOverviewRequest.Validate if the designated constraints aren't met.
+type UpdateOverviewRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1460,22 +1450,24 @@ type FileValidationError struct {
 }
 
 // Field function returns field value.
-func (e FileValidationError) Field() string { return e.field }
+func (e UpdateOverviewRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e FileValidationError) Reason() string { return e.reason }
+func (e UpdateOverviewRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e FileValidationError) Cause() error { return e.cause }
+func (e UpdateOverviewRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e FileValidationError) Key() bool { return e.key }
+func (e UpdateOverviewRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e FileValidationError) ErrorName() string { return "FileValidationError" }
+func (e UpdateOverviewRequestValidationError) ErrorName() string {
+	return "UpdateOverviewRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e FileValidationError) Error() string {
+func (e UpdateOverviewRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1487,14 +1479,14 @@ func (e FileValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sFile.%s: %s%s",
+		"invalid %sUpdateOverviewRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = FileValidationError{}
+var _ error = UpdateOverviewRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1502,46 +1494,73 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = FileValidationError{}
+} = UpdateOverviewRequestValidationError{}
 
-// Validate checks the field values on ExternalDataSource with the rules
+// Validate checks the field values on UpdateOverviewResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *ExternalDataSource) Validate() error {
+func (m *UpdateOverviewResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ExternalDataSource with the rules
+// ValidateAll checks the field values on UpdateOverviewResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ExternalDataSourceMultiError, or nil if none found.
-func (m *ExternalDataSource) ValidateAll() error {
+// UpdateOverviewResponseMultiError, or nil if none found.
+func (m *UpdateOverviewResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ExternalDataSource) validate(all bool) error {
+func (m *UpdateOverviewResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Location
+	if all {
+		switch v := interface{}(m.GetOverview()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateOverviewResponseValidationError{
+					field:  "Overview",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateOverviewResponseValidationError{
+					field:  "Overview",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOverview()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateOverviewResponseValidationError{
+				field:  "Overview",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
 
 	if len(errors) > 0 {
-		return ExternalDataSourceMultiError(errors)
+		return UpdateOverviewResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// ExternalDataSourceMultiError is an error wrapping multiple validation errors
-// returned by ExternalDataSource.ValidateAll() if the designated constraints
-// aren't met.
-type ExternalDataSourceMultiError []error
-
-// Error returns a concatenation of all the error messages it wraps.
-func (m ExternalDataSourceMultiError) Error() string {
+// UpdateOverviewResponseMultiError is an error wrapping multiple validation
+// errors returned by UpdateOverviewResponse.ValidateAll() if the designated
+// constraints aren't met.
+type UpdateOverviewResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateOverviewResponseMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1550,11 +1569,11 @@ func (m ExternalDataSourceMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ExternalDataSourceMultiError) AllErrors() []error { return m }
+func (m UpdateOverviewResponseMultiError) AllErrors() []error { return m }
 
-// ExternalDataSourceValidationError is the validation error returned by
-// ExternalDataSource.Validate if the designated constraints aren't met.
-type ExternalDataSourceValidationError struct {
+// UpdateOverviewResponseValidationError is the validation error returned by
+// UpdateOverviewResponse.Validate if the designated constraints aren't met.
+type UpdateOverviewResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1562,24 +1581,24 @@ type ExternalDataSourceValidationError struct {
 }
 
 // Field function returns field value.
-func (e ExternalDataSourceValidationError) Field() string { return e.field }
+func (e UpdateOverviewResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ExternalDataSourceValidationError) Reason() string { return e.reason }
+func (e UpdateOverviewResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ExternalDataSourceValidationError) Cause() error { return e.cause }
+func (e UpdateOverviewResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ExternalDataSourceValidationError) Key() bool { return e.key }
+func (e UpdateOverviewResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ExternalDataSourceValidationError) ErrorName() string {
-	return "ExternalDataSourceValidationError"
+func (e UpdateOverviewResponseValidationError) ErrorName() string {
+	return "UpdateOverviewResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ExternalDataSourceValidationError) Error() string {
+func (e UpdateOverviewResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1591,14 +1610,14 @@ func (e ExternalDataSourceValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sExternalDataSource.%s: %s%s",
+		"invalid %sUpdateOverviewResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ExternalDataSourceValidationError{}
+var _ error = UpdateOverviewResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1606,102 +1625,48 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ExternalDataSourceValidationError{}
+} = UpdateOverviewResponseValidationError{}
 
-// Validate checks the field values on GetFileRequest with the rules defined in
+// Validate checks the field values on ConfigVersion with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *GetFileRequest) Validate() error {
+func (m *ConfigVersion) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetFileRequest with the rules defined
+// ValidateAll checks the field values on ConfigVersion with the rules defined
 // in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in GetFileRequestMultiError,
-// or nil if none found.
-func (m *GetFileRequest) ValidateAll() error {
+// result is a list of violation errors wrapped in ConfigVersionMultiError, or
+// nil if none found.
+func (m *ConfigVersion) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetFileRequest) validate(all bool) error {
+func (m *ConfigVersion) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetMessageMeta()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, GetFileRequestValidationError{
-					field:  "MessageMeta",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, GetFileRequestValidationError{
-					field:  "MessageMeta",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetMessageMeta()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return GetFileRequestValidationError{
-				field:  "MessageMeta",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for InstanceId
 
-	if all {
-		switch v := interface{}(m.GetFileMeta()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, GetFileRequestValidationError{
-					field:  "FileMeta",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, GetFileRequestValidationError{
-					field:  "FileMeta",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetFileMeta()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return GetFileRequestValidationError{
-				field:  "FileMeta",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for Version
 
 	if len(errors) > 0 {
-		return GetFileRequestMultiError(errors)
+		return ConfigVersionMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetFileRequestMultiError is an error wrapping multiple validation errors
-// returned by GetFileRequest.ValidateAll() if the designated constraints
+// ConfigVersionMultiError is an error wrapping multiple validation errors
+// returned by ConfigVersion.ValidateAll() if the designated constraints
 // aren't met.
-type GetFileRequestMultiError []error
+type ConfigVersionMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetFileRequestMultiError) Error() string {
+func (m ConfigVersionMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1710,11 +1675,11 @@ func (m GetFileRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetFileRequestMultiError) AllErrors() []error { return m }
+func (m ConfigVersionMultiError) AllErrors() []error { return m }
 
-// GetFileRequestValidationError is the validation error returned by
-// GetFileRequest.Validate if the designated constraints aren't met.
-type GetFileRequestValidationError struct {
+// ConfigVersionValidationError is the validation error returned by
+// ConfigVersion.Validate if the designated constraints aren't met.
+type ConfigVersionValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1722,22 +1687,22 @@ type GetFileRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetFileRequestValidationError) Field() string { return e.field }
+func (e ConfigVersionValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetFileRequestValidationError) Reason() string { return e.reason }
+func (e ConfigVersionValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetFileRequestValidationError) Cause() error { return e.cause }
+func (e ConfigVersionValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetFileRequestValidationError) Key() bool { return e.key }
+func (e ConfigVersionValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetFileRequestValidationError) ErrorName() string { return "GetFileRequestValidationError" }
+func (e ConfigVersionValidationError) ErrorName() string { return "ConfigVersionValidationError" }
 
 // Error satisfies the builtin error interface
-func (e GetFileRequestValidationError) Error() string {
+func (e ConfigVersionValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1749,14 +1714,14 @@ func (e GetFileRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetFileRequest.%s: %s%s",
+		"invalid %sConfigVersion.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetFileRequestValidationError{}
+var _ error = ConfigVersionValidationError{}
 
 var _ interface {
 	Field() string
@@ -1764,73 +1729,108 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetFileRequestValidationError{}
+} = ConfigVersionValidationError{}
 
-// Validate checks the field values on GetFileResponse with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *GetFileResponse) Validate() error {
+// Validate checks the field values on FileOverview with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *FileOverview) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetFileResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// GetFileResponseMultiError, or nil if none found.
-func (m *GetFileResponse) ValidateAll() error {
+// ValidateAll checks the field values on FileOverview with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FileOverviewMultiError, or
+// nil if none found.
+func (m *FileOverview) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetFileResponse) validate(all bool) error {
+func (m *FileOverview) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
+	for idx, item := range m.GetFiles() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, FileOverviewValidationError{
+						field:  fmt.Sprintf("Files[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, FileOverviewValidationError{
+						field:  fmt.Sprintf("Files[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FileOverviewValidationError{
+					field:  fmt.Sprintf("Files[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if all {
-		switch v := interface{}(m.GetContents()).(type) {
+		switch v := interface{}(m.GetConfigVersion()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, GetFileResponseValidationError{
-					field:  "Contents",
+				errors = append(errors, FileOverviewValidationError{
+					field:  "ConfigVersion",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, GetFileResponseValidationError{
-					field:  "Contents",
+				errors = append(errors, FileOverviewValidationError{
+					field:  "ConfigVersion",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		}
-	} else if v, ok := interface{}(m.GetContents()).(interface{ Validate() error }); ok {
+	} else if v, ok := interface{}(m.GetConfigVersion()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return GetFileResponseValidationError{
-				field:  "Contents",
+			return FileOverviewValidationError{
+				field:  "ConfigVersion",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
 		}
 	}
 
+	// no validation rules for ConfigPath
+
 	if len(errors) > 0 {
-		return GetFileResponseMultiError(errors)
+		return FileOverviewMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetFileResponseMultiError is an error wrapping multiple validation errors
-// returned by GetFileResponse.ValidateAll() if the designated constraints
-// aren't met.
-type GetFileResponseMultiError []error
+// FileOverviewMultiError is an error wrapping multiple validation errors
+// returned by FileOverview.ValidateAll() if the designated constraints aren't met.
+type FileOverviewMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetFileResponseMultiError) Error() string {
+func (m FileOverviewMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1839,11 +1839,11 @@ func (m GetFileResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetFileResponseMultiError) AllErrors() []error { return m }
+func (m FileOverviewMultiError) AllErrors() []error { return m }
 
-// GetFileResponseValidationError is the validation error returned by
-// GetFileResponse.Validate if the designated constraints aren't met.
-type GetFileResponseValidationError struct {
+// FileOverviewValidationError is the validation error returned by
+// FileOverview.Validate if the designated constraints aren't met.
+type FileOverviewValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1851,22 +1851,22 @@ type GetFileResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetFileResponseValidationError) Field() string { return e.field }
+func (e FileOverviewValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetFileResponseValidationError) Reason() string { return e.reason }
+func (e FileOverviewValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetFileResponseValidationError) Cause() error { return e.cause }
+func (e FileOverviewValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetFileResponseValidationError) Key() bool { return e.key }
+func (e FileOverviewValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetFileResponseValidationError) ErrorName() string { return "GetFileResponseValidationError" }
+func (e FileOverviewValidationError) ErrorName() string { return "FileOverviewValidationError" }
 
 // Error satisfies the builtin error interface
-func (e GetFileResponseValidationError) Error() string {
+func (e FileOverviewValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1878,14 +1878,14 @@ func (e GetFileResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetFileResponse.%s: %s%s",
+		"invalid %sFileOverview.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetFileResponseValidationError{}
+var _ error = FileOverviewValidationError{}
 
 var _ interface {
 	Field() string
@@ -1893,45 +1893,139 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetFileResponseValidationError{}
+} = FileOverviewValidationError{}
 
-// Validate checks the field values on FileContents with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *FileContents) Validate() error {
+// Validate checks the field values on File with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *File) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on FileContents with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in FileContentsMultiError, or
-// nil if none found.
-func (m *FileContents) ValidateAll() error {
+// ValidateAll checks the field values on File with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in FileMultiError, or nil if none found.
+func (m *File) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *FileContents) validate(all bool) error {
+func (m *File) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Contents
+	if all {
+		switch v := interface{}(m.GetFileMeta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, FileValidationError{
+					field:  "FileMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, FileValidationError{
+					field:  "FileMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFileMeta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return FileValidationError{
+				field:  "FileMeta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Unmanaged
+
+	if m.ExternalDataSource != nil {
+
+		if all {
+			switch v := interface{}(m.GetExternalDataSource()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, FileValidationError{
+						field:  "ExternalDataSource",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, FileValidationError{
+						field:  "ExternalDataSource",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetExternalDataSource()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FileValidationError{
+					field:  "ExternalDataSource",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.Template != nil {
+
+		if all {
+			switch v := interface{}(m.GetTemplate()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, FileValidationError{
+						field:  "Template",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, FileValidationError{
+						field:  "Template",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetTemplate()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FileValidationError{
+					field:  "Template",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
 
 	if len(errors) > 0 {
-		return FileContentsMultiError(errors)
+		return FileMultiError(errors)
 	}
 
 	return nil
 }
 
-// FileContentsMultiError is an error wrapping multiple validation errors
-// returned by FileContents.ValidateAll() if the designated constraints aren't met.
-type FileContentsMultiError []error
+// FileMultiError is an error wrapping multiple validation errors returned by
+// File.ValidateAll() if the designated constraints aren't met.
+type FileMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m FileContentsMultiError) Error() string {
+func (m FileMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1940,11 +2034,11 @@ func (m FileContentsMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m FileContentsMultiError) AllErrors() []error { return m }
+func (m FileMultiError) AllErrors() []error { return m }
 
-// FileContentsValidationError is the validation error returned by
-// FileContents.Validate if the designated constraints aren't met.
-type FileContentsValidationError struct {
+// FileValidationError is the validation error returned by File.Validate if the
+// designated constraints aren't met.
+type FileValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1952,22 +2046,22 @@ type FileContentsValidationError struct {
 }
 
 // Field function returns field value.
-func (e FileContentsValidationError) Field() string { return e.field }
+func (e FileValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e FileContentsValidationError) Reason() string { return e.reason }
+func (e FileValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e FileContentsValidationError) Cause() error { return e.cause }
+func (e FileValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e FileContentsValidationError) Key() bool { return e.key }
+func (e FileValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e FileContentsValidationError) ErrorName() string { return "FileContentsValidationError" }
+func (e FileValidationError) ErrorName() string { return "FileValidationError" }
 
 // Error satisfies the builtin error interface
-func (e FileContentsValidationError) Error() string {
+func (e FileValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1979,14 +2073,14 @@ func (e FileContentsValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sFileContents.%s: %s%s",
+		"invalid %sFile.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = FileContentsValidationError{}
+var _ error = FileValidationError{}
 
 var _ interface {
 	Field() string
@@ -1994,126 +2088,50 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = FileContentsValidationError{}
+} = FileValidationError{}
 
-// Validate checks the field values on FileMeta with the rules defined in the
-// proto definition for this message. If any rules are violated, the first
+// Validate checks the field values on FileTemplate with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *FileMeta) Validate() error {
+func (m *FileTemplate) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on FileMeta with the rules defined in
-// the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in FileMetaMultiError, or nil
-// if none found.
-func (m *FileMeta) ValidateAll() error {
+// ValidateAll checks the field values on FileTemplate with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FileTemplateMultiError, or
+// nil if none found.
+func (m *FileTemplate) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *FileMeta) validate(all bool) error {
+func (m *FileTemplate) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Name
-
-	// no validation rules for Hash
-
-	if all {
-		switch v := interface{}(m.GetModifiedTime()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, FileMetaValidationError{
-					field:  "ModifiedTime",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, FileMetaValidationError{
-					field:  "ModifiedTime",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetModifiedTime()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return FileMetaValidationError{
-				field:  "ModifiedTime",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
-
-	// no validation rules for Permissions
-
-	// no validation rules for Size
-
-	switch v := m.FileType.(type) {
-	case *FileMeta_CertificateMeta:
-		if v == nil {
-			err := FileMetaValidationError{
-				field:  "FileType",
-				reason: "oneof value cannot be a typed-nil",
-			}
-			if !all {
-				return err
-			}
-			errors = append(errors, err)
-		}
+	// no validation rules for RenderedHash
 
-		if all {
-			switch v := interface{}(m.GetCertificateMeta()).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, FileMetaValidationError{
-						field:  "CertificateMeta",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, FileMetaValidationError{
-						field:  "CertificateMeta",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(m.GetCertificateMeta()).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return FileMetaValidationError{
-					field:  "CertificateMeta",
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
+	// no validation rules for VariablesHash
 
-	default:
-		_ = v // ensures v is used
-	}
+	// no validation rules for RenderedSize
 
 	if len(errors) > 0 {
-		return FileMetaMultiError(errors)
+		return FileTemplateMultiError(errors)
 	}
 
 	return nil
 }
 
-// FileMetaMultiError is an error wrapping multiple validation errors returned
-// by FileMeta.ValidateAll() if the designated constraints aren't met.
-type FileMetaMultiError []error
+// FileTemplateMultiError is an error wrapping multiple validation errors
+// returned by FileTemplate.ValidateAll() if the designated constraints aren't
+// met.
+type FileTemplateMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m FileMetaMultiError) Error() string {
+func (m FileTemplateMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2122,11 +2140,11 @@ func (m FileMetaMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m FileMetaMultiError) AllErrors() []error { return m }
+func (m FileTemplateMultiError) AllErrors() []error { return m }
 
-// FileMetaValidationError is the validation error returned by
-// FileMeta.Validate if the designated constraints aren't met.
-type FileMetaValidationError struct {
+// FileTemplateValidationError is the validation error returned by
+// FileTemplate.Validate if the designated constraints aren't met.
+type FileTemplateValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2134,22 +2152,22 @@ type FileMetaValidationError struct {
 }
 
 // Field function returns field value.
-func (e FileMetaValidationError) Field() string { return e.field }
+func (e FileTemplateValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e FileMetaValidationError) Reason() string { return e.reason }
+func (e FileTemplateValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e FileMetaValidationError) Cause() error { return e.cause }
+func (e FileTemplateValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e FileMetaValidationError) Key() bool { return e.key }
+func (e FileTemplateValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e FileMetaValidationError) ErrorName() string { return "FileMetaValidationError" }
+func (e FileTemplateValidationError) ErrorName() string { return "FileTemplateValidationError" }
 
 // Error satisfies the builtin error interface
-func (e FileMetaValidationError) Error() string {
+func (e FileTemplateValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2161,14 +2179,14 @@ func (e FileMetaValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sFileMeta.%s: %s%s",
+		"invalid %sFileTemplate.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = FileMetaValidationError{}
+var _ error = FileTemplateValidationError{}
 
 var _ interface {
 	Field() string
@@ -2176,53 +2194,1405 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = FileMetaValidationError{}
+} = FileTemplateValidationError{}
 
-// Validate checks the field values on UpdateFileRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *UpdateFileRequest) Validate() error {
+// Validate checks the field values on ExternalDataSource with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ExternalDataSource) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExternalDataSource with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ExternalDataSourceMultiError, or nil if none found.
+func (m *ExternalDataSource) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExternalDataSource) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Location
+
+	if all {
+		switch v := interface{}(m.GetRefreshInterval()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ExternalDataSourceValidationError{
+					field:  "RefreshInterval",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ExternalDataSourceValidationError{
+					field:  "RefreshInterval",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRefreshInterval()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ExternalDataSourceValidationError{
+				field:  "RefreshInterval",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Sha256
+
+	// no validation rules for SignatureLocation
+
+	if len(errors) > 0 {
+		return ExternalDataSourceMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExternalDataSourceMultiError is an error wrapping multiple validation errors
+// returned by ExternalDataSource.ValidateAll() if the designated constraints
+// aren't met.
+type ExternalDataSourceMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExternalDataSourceMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExternalDataSourceMultiError) AllErrors() []error { return m }
+
+// ExternalDataSourceValidationError is the validation error returned by
+// ExternalDataSource.Validate if the designated constraints aren't met.
+type ExternalDataSourceValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExternalDataSourceValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExternalDataSourceValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExternalDataSourceValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExternalDataSourceValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExternalDataSourceValidationError) ErrorName() string {
+	return "ExternalDataSourceValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ExternalDataSourceValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExternalDataSource.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExternalDataSourceValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExternalDataSourceValidationError{}
+
+// Validate checks the field values on GetFileRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *GetFileRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetFileRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in GetFileRequestMultiError,
+// or nil if none found.
+func (m *GetFileRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetFileRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetMessageMeta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetFileRequestValidationError{
+					field:  "MessageMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetFileRequestValidationError{
+					field:  "MessageMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMessageMeta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetFileRequestValidationError{
+				field:  "MessageMeta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetFileMeta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetFileRequestValidationError{
+					field:  "FileMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetFileRequestValidationError{
+					field:  "FileMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFileMeta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetFileRequestValidationError{
+				field:  "FileMeta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetSignature()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetFileRequestValidationError{
+					field:  "Signature",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetFileRequestValidationError{
+					field:  "Signature",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSignature()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetFileRequestValidationError{
+				field:  "Signature",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Offset
+
+	if len(errors) > 0 {
+		return GetFileRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetFileRequestMultiError is an error wrapping multiple validation errors
+// returned by GetFileRequest.ValidateAll() if the designated constraints
+// aren't met.
+type GetFileRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetFileRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetFileRequestMultiError) AllErrors() []error { return m }
+
+// GetFileRequestValidationError is the validation error returned by
+// GetFileRequest.Validate if the designated constraints aren't met.
+type GetFileRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetFileRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetFileRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetFileRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetFileRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetFileRequestValidationError) ErrorName() string { return "GetFileRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetFileRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetFileRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetFileRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetFileRequestValidationError{}
+
+// Validate checks the field values on GetFileResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetFileResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetFileResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetFileResponseMultiError, or nil if none found.
+func (m *GetFileResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetFileResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetContents()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetFileResponseValidationError{
+					field:  "Contents",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetFileResponseValidationError{
+					field:  "Contents",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetContents()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetFileResponseValidationError{
+				field:  "Contents",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetFileResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetFileResponseMultiError is an error wrapping multiple validation errors
+// returned by GetFileResponse.ValidateAll() if the designated constraints
+// aren't met.
+type GetFileResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetFileResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetFileResponseMultiError) AllErrors() []error { return m }
+
+// GetFileResponseValidationError is the validation error returned by
+// GetFileResponse.Validate if the designated constraints aren't met.
+type GetFileResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetFileResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetFileResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetFileResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetFileResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetFileResponseValidationError) ErrorName() string { return "GetFileResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetFileResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetFileResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetFileResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetFileResponseValidationError{}
+
+// Validate checks the field values on FileContents with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *FileContents) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FileContents with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FileContentsMultiError, or
+// nil if none found.
+func (m *FileContents) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FileContents) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Contents
+
+	// no validation rules for Compression
+
+	if len(errors) > 0 {
+		return FileContentsMultiError(errors)
+	}
+
+	return nil
+}
+
+// FileContentsMultiError is an error wrapping multiple validation errors
+// returned by FileContents.ValidateAll() if the designated constraints aren't met.
+type FileContentsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FileContentsMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FileContentsMultiError) AllErrors() []error { return m }
+
+// FileContentsValidationError is the validation error returned by
+// FileContents.Validate if the designated constraints aren't met.
+type FileContentsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FileContentsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FileContentsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FileContentsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FileContentsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FileContentsValidationError) ErrorName() string { return "FileContentsValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FileContentsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFileContents.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FileContentsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FileContentsValidationError{}
+
+// Validate checks the field values on FileMeta with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *FileMeta) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FileMeta with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FileMetaMultiError, or nil
+// if none found.
+func (m *FileMeta) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FileMeta) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	// no validation rules for Hash
+
+	if all {
+		switch v := interface{}(m.GetModifiedTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, FileMetaValidationError{
+					field:  "ModifiedTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, FileMetaValidationError{
+					field:  "ModifiedTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetModifiedTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return FileMetaValidationError{
+				field:  "ModifiedTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Permissions
+
+	// no validation rules for Size
+
+	// no validation rules for Owner
+
+	// no validation rules for Group
+
+	// no validation rules for SelinuxContext
+
+	switch v := m.FileType.(type) {
+	case *FileMeta_CertificateMeta:
+		if v == nil {
+			err := FileMetaValidationError{
+				field:  "FileType",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetCertificateMeta()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, FileMetaValidationError{
+						field:  "CertificateMeta",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, FileMetaValidationError{
+						field:  "CertificateMeta",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetCertificateMeta()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FileMetaValidationError{
+					field:  "CertificateMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *FileMeta_SymlinkMeta:
+		if v == nil {
+			err := FileMetaValidationError{
+				field:  "FileType",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetSymlinkMeta()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, FileMetaValidationError{
+						field:  "SymlinkMeta",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, FileMetaValidationError{
+						field:  "SymlinkMeta",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetSymlinkMeta()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FileMetaValidationError{
+					field:  "SymlinkMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *FileMeta_DirectoryMeta:
+		if v == nil {
+			err := FileMetaValidationError{
+				field:  "FileType",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetDirectoryMeta()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, FileMetaValidationError{
+						field:  "DirectoryMeta",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, FileMetaValidationError{
+						field:  "DirectoryMeta",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetDirectoryMeta()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FileMetaValidationError{
+					field:  "DirectoryMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+
+	if len(errors) > 0 {
+		return FileMetaMultiError(errors)
+	}
+
+	return nil
+}
+
+// FileMetaMultiError is an error wrapping multiple validation errors returned
+// by FileMeta.ValidateAll() if the designated constraints aren't met.
+type FileMetaMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FileMetaMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FileMetaMultiError) AllErrors() []error { return m }
+
+// FileMetaValidationError is the validation error returned by
+// FileMeta.Validate if the designated constraints aren't met.
+type FileMetaValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FileMetaValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FileMetaValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FileMetaValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FileMetaValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FileMetaValidationError) ErrorName() string { return "FileMetaValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FileMetaValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFileMeta.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FileMetaValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FileMetaValidationError{}
+
+// Validate checks the field values on SymlinkMeta with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SymlinkMeta) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SymlinkMeta with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SymlinkMetaMultiError, or
+// nil if none found.
+func (m *SymlinkMeta) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SymlinkMeta) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Target
+
+	if len(errors) > 0 {
+		return SymlinkMetaMultiError(errors)
+	}
+
+	return nil
+}
+
+// SymlinkMetaMultiError is an error wrapping multiple validation errors
+// returned by SymlinkMeta.ValidateAll() if the designated constraints aren't
+// met.
+type SymlinkMetaMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SymlinkMetaMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SymlinkMetaMultiError) AllErrors() []error { return m }
+
+// SymlinkMetaValidationError is the validation error returned by
+// SymlinkMeta.Validate if the designated constraints aren't met.
+type SymlinkMetaValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SymlinkMetaValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SymlinkMetaValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SymlinkMetaValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SymlinkMetaValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SymlinkMetaValidationError) ErrorName() string { return "SymlinkMetaValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SymlinkMetaValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSymlinkMeta.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SymlinkMetaValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SymlinkMetaValidationError{}
+
+// Validate checks the field values on DirectoryMeta with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *DirectoryMeta) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DirectoryMeta with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in DirectoryMetaMultiError, or
+// nil if none found.
+func (m *DirectoryMeta) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DirectoryMeta) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return DirectoryMetaMultiError(errors)
+	}
+
+	return nil
+}
+
+// DirectoryMetaMultiError is an error wrapping multiple validation errors
+// returned by DirectoryMeta.ValidateAll() if the designated constraints
+// aren't met.
+type DirectoryMetaMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DirectoryMetaMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DirectoryMetaMultiError) AllErrors() []error { return m }
+
+// DirectoryMetaValidationError is the validation error returned by
+// DirectoryMeta.Validate if the designated constraints aren't met.
+type DirectoryMetaValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DirectoryMetaValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DirectoryMetaValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DirectoryMetaValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DirectoryMetaValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DirectoryMetaValidationError) ErrorName() string { return "DirectoryMetaValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DirectoryMetaValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDirectoryMeta.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DirectoryMetaValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DirectoryMetaValidationError{}
+
+// Validate checks the field values on UpdateFileRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *UpdateFileRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateFileRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateFileRequestMultiError, or nil if none found.
+func (m *UpdateFileRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateFileRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetFile()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateFileRequestValidationError{
+					field:  "File",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateFileRequestValidationError{
+					field:  "File",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFile()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateFileRequestValidationError{
+				field:  "File",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetContents()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateFileRequestValidationError{
+					field:  "Contents",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateFileRequestValidationError{
+					field:  "Contents",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetContents()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateFileRequestValidationError{
+				field:  "Contents",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetMessageMeta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateFileRequestValidationError{
+					field:  "MessageMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateFileRequestValidationError{
+					field:  "MessageMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMessageMeta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateFileRequestValidationError{
+				field:  "MessageMeta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return UpdateFileRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateFileRequestMultiError is an error wrapping multiple validation errors
+// returned by UpdateFileRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateFileRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateFileRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateFileRequestMultiError) AllErrors() []error { return m }
+
+// UpdateFileRequestValidationError is the validation error returned by
+// UpdateFileRequest.Validate if the designated constraints aren't met.
+type UpdateFileRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateFileRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateFileRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateFileRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateFileRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateFileRequestValidationError) ErrorName() string {
+	return "UpdateFileRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateFileRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateFileRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateFileRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateFileRequestValidationError{}
+
+// Validate checks the field values on UpdateFileResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateFileResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateFileResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateFileResponseMultiError, or nil if none found.
+func (m *UpdateFileResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateFileResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetFileMeta()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateFileResponseValidationError{
+					field:  "FileMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateFileResponseValidationError{
+					field:  "FileMeta",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFileMeta()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateFileResponseValidationError{
+				field:  "FileMeta",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return UpdateFileResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateFileResponseMultiError is an error wrapping multiple validation errors
+// returned by UpdateFileResponse.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateFileResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateFileResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateFileResponseMultiError) AllErrors() []error { return m }
+
+// UpdateFileResponseValidationError is the validation error returned by
+// UpdateFileResponse.Validate if the designated constraints aren't met.
+type UpdateFileResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateFileResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateFileResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateFileResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateFileResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateFileResponseValidationError) ErrorName() string {
+	return "UpdateFileResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateFileResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateFileResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateFileResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateFileResponseValidationError{}
+
+// Validate checks the field values on CertificateMeta with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CertificateMeta) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UpdateFileRequest with the rules
+// ValidateAll checks the field values on CertificateMeta with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// UpdateFileRequestMultiError, or nil if none found.
-func (m *UpdateFileRequest) ValidateAll() error {
+// CertificateMetaMultiError, or nil if none found.
+func (m *CertificateMeta) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UpdateFileRequest) validate(all bool) error {
+func (m *CertificateMeta) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
+	// no validation rules for SerialNumber
+
 	if all {
-		switch v := interface{}(m.GetFile()).(type) {
+		switch v := interface{}(m.GetIssuer()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UpdateFileRequestValidationError{
-					field:  "File",
+				errors = append(errors, CertificateMetaValidationError{
+					field:  "Issuer",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, UpdateFileRequestValidationError{
-					field:  "File",
+				errors = append(errors, CertificateMetaValidationError{
+					field:  "Issuer",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		}
-	} else if v, ok := interface{}(m.GetFile()).(interface{ Validate() error }); ok {
+	} else if v, ok := interface{}(m.GetIssuer()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return UpdateFileRequestValidationError{
-				field:  "File",
+			return CertificateMetaValidationError{
+				field:  "Issuer",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
@@ -2230,28 +3600,28 @@ func (m *UpdateFileRequest) validate(all bool) error {
 	}
 
 	if all {
-		switch v := interface{}(m.GetContents()).(type) {
+		switch v := interface{}(m.GetSubject()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UpdateFileRequestValidationError{
-					field:  "Contents",
+				errors = append(errors, CertificateMetaValidationError{
+					field:  "Subject",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, UpdateFileRequestValidationError{
-					field:  "Contents",
+				errors = append(errors, CertificateMetaValidationError{
+					field:  "Subject",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		}
-	} else if v, ok := interface{}(m.GetContents()).(interface{ Validate() error }); ok {
+	} else if v, ok := interface{}(m.GetSubject()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return UpdateFileRequestValidationError{
-				field:  "Contents",
+			return CertificateMetaValidationError{
+				field:  "Subject",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
@@ -2259,48 +3629,185 @@ func (m *UpdateFileRequest) validate(all bool) error {
 	}
 
 	if all {
-		switch v := interface{}(m.GetMessageMeta()).(type) {
+		switch v := interface{}(m.GetSans()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UpdateFileRequestValidationError{
-					field:  "MessageMeta",
+				errors = append(errors, CertificateMetaValidationError{
+					field:  "Sans",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, UpdateFileRequestValidationError{
-					field:  "MessageMeta",
+				errors = append(errors, CertificateMetaValidationError{
+					field:  "Sans",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		}
-	} else if v, ok := interface{}(m.GetMessageMeta()).(interface{ Validate() error }); ok {
+	} else if v, ok := interface{}(m.GetSans()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return UpdateFileRequestValidationError{
-				field:  "MessageMeta",
+			return CertificateMetaValidationError{
+				field:  "Sans",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetDates()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CertificateMetaValidationError{
+					field:  "Dates",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CertificateMetaValidationError{
+					field:  "Dates",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDates()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CertificateMetaValidationError{
+				field:  "Dates",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
 		}
 	}
 
+	// no validation rules for SignatureAlgorithm
+
+	// no validation rules for PublicKeyAlgorithm
+
+	if len(errors) > 0 {
+		return CertificateMetaMultiError(errors)
+	}
+
+	return nil
+}
+
+// CertificateMetaMultiError is an error wrapping multiple validation errors
+// returned by CertificateMeta.ValidateAll() if the designated constraints
+// aren't met.
+type CertificateMetaMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CertificateMetaMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CertificateMetaMultiError) AllErrors() []error { return m }
+
+// CertificateMetaValidationError is the validation error returned by
+// CertificateMeta.Validate if the designated constraints aren't met.
+type CertificateMetaValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CertificateMetaValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CertificateMetaValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CertificateMetaValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CertificateMetaValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CertificateMetaValidationError) ErrorName() string { return "CertificateMetaValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CertificateMetaValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCertificateMeta.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CertificateMetaValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CertificateMetaValidationError{}
+
+// Validate checks the field values on CertificateDates with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CertificateDates) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CertificateDates with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CertificateDatesMultiError, or nil if none found.
+func (m *CertificateDates) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CertificateDates) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for NotBefore
+
+	// no validation rules for NotAfter
+
 	if len(errors) > 0 {
-		return UpdateFileRequestMultiError(errors)
+		return CertificateDatesMultiError(errors)
 	}
 
 	return nil
 }
 
-// UpdateFileRequestMultiError is an error wrapping multiple validation errors
-// returned by UpdateFileRequest.ValidateAll() if the designated constraints
+// CertificateDatesMultiError is an error wrapping multiple validation errors
+// returned by CertificateDates.ValidateAll() if the designated constraints
 // aren't met.
-type UpdateFileRequestMultiError []error
+type CertificateDatesMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UpdateFileRequestMultiError) Error() string {
+func (m CertificateDatesMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2309,11 +3816,11 @@ func (m UpdateFileRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UpdateFileRequestMultiError) AllErrors() []error { return m }
+func (m CertificateDatesMultiError) AllErrors() []error { return m }
 
-// UpdateFileRequestValidationError is the validation error returned by
-// UpdateFileRequest.Validate if the designated constraints aren't met.
-type UpdateFileRequestValidationError struct {
+// CertificateDatesValidationError is the validation error returned by
+// CertificateDates.Validate if the designated constraints aren't met.
+type CertificateDatesValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2321,24 +3828,22 @@ type UpdateFileRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdateFileRequestValidationError) Field() string { return e.field }
+func (e CertificateDatesValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdateFileRequestValidationError) Reason() string { return e.reason }
+func (e CertificateDatesValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdateFileRequestValidationError) Cause() error { return e.cause }
+func (e CertificateDatesValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdateFileRequestValidationError) Key() bool { return e.key }
+func (e CertificateDatesValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdateFileRequestValidationError) ErrorName() string {
-	return "UpdateFileRequestValidationError"
-}
+func (e CertificateDatesValidationError) ErrorName() string { return "CertificateDatesValidationError" }
 
 // Error satisfies the builtin error interface
-func (e UpdateFileRequestValidationError) Error() string {
+func (e CertificateDatesValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2350,14 +3855,14 @@ func (e UpdateFileRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdateFileRequest.%s: %s%s",
+		"invalid %sCertificateDates.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdateFileRequestValidationError{}
+var _ error = CertificateDatesValidationError{}
 
 var _ interface {
 	Field() string
@@ -2365,73 +3870,44 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdateFileRequestValidationError{}
+} = CertificateDatesValidationError{}
 
-// Validate checks the field values on UpdateFileResponse with the rules
+// Validate checks the field values on SubjectAlternativeNames with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *UpdateFileResponse) Validate() error {
+func (m *SubjectAlternativeNames) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UpdateFileResponse with the rules
-// defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on SubjectAlternativeNames with the
+// rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// UpdateFileResponseMultiError, or nil if none found.
-func (m *UpdateFileResponse) ValidateAll() error {
+// SubjectAlternativeNamesMultiError, or nil if none found.
+func (m *SubjectAlternativeNames) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UpdateFileResponse) validate(all bool) error {
+func (m *SubjectAlternativeNames) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetFileMeta()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UpdateFileResponseValidationError{
-					field:  "FileMeta",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, UpdateFileResponseValidationError{
-					field:  "FileMeta",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetFileMeta()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return UpdateFileResponseValidationError{
-				field:  "FileMeta",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
-
 	if len(errors) > 0 {
-		return UpdateFileResponseMultiError(errors)
+		return SubjectAlternativeNamesMultiError(errors)
 	}
 
 	return nil
 }
 
-// UpdateFileResponseMultiError is an error wrapping multiple validation errors
-// returned by UpdateFileResponse.ValidateAll() if the designated constraints
-// aren't met.
-type UpdateFileResponseMultiError []error
+// SubjectAlternativeNamesMultiError is an error wrapping multiple validation
+// errors returned by SubjectAlternativeNames.ValidateAll() if the designated
+// constraints aren't met.
+type SubjectAlternativeNamesMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UpdateFileResponseMultiError) Error() string {
+func (m SubjectAlternativeNamesMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2440,11 +3916,11 @@ func (m UpdateFileResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UpdateFileResponseMultiError) AllErrors() []error { return m }
+func (m SubjectAlternativeNamesMultiError) AllErrors() []error { return m }
 
-// UpdateFileResponseValidationError is the validation error returned by
-// UpdateFileResponse.Validate if the designated constraints aren't met.
-type UpdateFileResponseValidationError struct {
+// SubjectAlternativeNamesValidationError is the validation error returned by
+// SubjectAlternativeNames.Validate if the designated constraints aren't met.
+type SubjectAlternativeNamesValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2452,24 +3928,24 @@ type UpdateFileResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdateFileResponseValidationError) Field() string { return e.field }
+func (e SubjectAlternativeNamesValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdateFileResponseValidationError) Reason() string { return e.reason }
+func (e SubjectAlternativeNamesValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdateFileResponseValidationError) Cause() error { return e.cause }
+func (e SubjectAlternativeNamesValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdateFileResponseValidationError) Key() bool { return e.key }
+func (e SubjectAlternativeNamesValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdateFileResponseValidationError) ErrorName() string {
-	return "UpdateFileResponseValidationError"
+func (e SubjectAlternativeNamesValidationError) ErrorName() string {
+	return "SubjectAlternativeNamesValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e UpdateFileResponseValidationError) Error() string {
+func (e SubjectAlternativeNamesValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2481,14 +3957,14 @@ func (e UpdateFileResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdateFileResponse.%s: %s%s",
+		"invalid %sSubjectAlternativeNames.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdateFileResponseValidationError{}
+var _ error = SubjectAlternativeNamesValidationError{}
 
 var _ interface {
 	Field() string
@@ -2496,166 +3972,192 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdateFileResponseValidationError{}
+} = SubjectAlternativeNamesValidationError{}
 
-// Validate checks the field values on CertificateMeta with the rules defined
+// Validate checks the field values on ConfigChangeSet with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
-func (m *CertificateMeta) Validate() error {
+func (m *ConfigChangeSet) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on CertificateMeta with the rules
+// ValidateAll checks the field values on ConfigChangeSet with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// CertificateMetaMultiError, or nil if none found.
-func (m *CertificateMeta) ValidateAll() error {
+// ConfigChangeSetMultiError, or nil if none found.
+func (m *ConfigChangeSet) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *CertificateMeta) validate(all bool) error {
+func (m *ConfigChangeSet) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for SerialNumber
+	for idx, item := range m.GetChanges() {
+		_, _ = idx, item
 
-	if all {
-		switch v := interface{}(m.GetIssuer()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, CertificateMetaValidationError{
-					field:  "Issuer",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ConfigChangeSetValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ConfigChangeSetValidationError{
+						field:  fmt.Sprintf("Changes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
 			}
-		case interface{ Validate() error }:
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				errors = append(errors, CertificateMetaValidationError{
-					field:  "Issuer",
+				return ConfigChangeSetValidationError{
+					field:  fmt.Sprintf("Changes[%v]", idx),
 					reason: "embedded message failed validation",
 					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetIssuer()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return CertificateMetaValidationError{
-				field:  "Issuer",
-				reason: "embedded message failed validation",
-				cause:  err,
+				}
 			}
 		}
+
 	}
 
-	if all {
-		switch v := interface{}(m.GetSubject()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, CertificateMetaValidationError{
-					field:  "Subject",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, CertificateMetaValidationError{
-					field:  "Subject",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetSubject()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return CertificateMetaValidationError{
-				field:  "Subject",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
+	if len(errors) > 0 {
+		return ConfigChangeSetMultiError(errors)
 	}
 
-	if all {
-		switch v := interface{}(m.GetSans()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, CertificateMetaValidationError{
-					field:  "Sans",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, CertificateMetaValidationError{
-					field:  "Sans",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetSans()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return CertificateMetaValidationError{
-				field:  "Sans",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
+	return nil
+}
+
+// ConfigChangeSetMultiError is an error wrapping multiple validation errors
+// returned by ConfigChangeSet.ValidateAll() if the designated constraints
+// aren't met.
+type ConfigChangeSetMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ConfigChangeSetMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ConfigChangeSetMultiError) AllErrors() []error { return m }
+
+// ConfigChangeSetValidationError is the validation error returned by
+// ConfigChangeSet.Validate if the designated constraints aren't met.
+type ConfigChangeSetValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ConfigChangeSetValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ConfigChangeSetValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ConfigChangeSetValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ConfigChangeSetValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ConfigChangeSetValidationError) ErrorName() string { return "ConfigChangeSetValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ConfigChangeSetValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
 	}
 
-	if all {
-		switch v := interface{}(m.GetDates()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, CertificateMetaValidationError{
-					field:  "Dates",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, CertificateMetaValidationError{
-					field:  "Dates",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetDates()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return CertificateMetaValidationError{
-				field:  "Dates",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
+	key := ""
+	if e.key {
+		key = "key for "
 	}
 
-	// no validation rules for SignatureAlgorithm
+	return fmt.Sprintf(
+		"invalid %sConfigChangeSet.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
 
-	// no validation rules for PublicKeyAlgorithm
+var _ error = ConfigChangeSetValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ConfigChangeSetValidationError{}
+
+// Validate checks the field values on FileChange with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *FileChange) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FileChange with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FileChangeMultiError, or
+// nil if none found.
+func (m *FileChange) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FileChange) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	// no validation rules for Action
+
+	// no validation rules for OldHash
+
+	// no validation rules for NewHash
+
+	// no validation rules for OldSize
+
+	// no validation rules for NewSize
+
+	// no validation rules for Diff
 
 	if len(errors) > 0 {
-		return CertificateMetaMultiError(errors)
+		return FileChangeMultiError(errors)
 	}
 
 	return nil
 }
 
-// CertificateMetaMultiError is an error wrapping multiple validation errors
-// returned by CertificateMeta.ValidateAll() if the designated constraints
-// aren't met.
-type CertificateMetaMultiError []error
+// FileChangeMultiError is an error wrapping multiple validation errors
+// returned by FileChange.ValidateAll() if the designated constraints aren't
+// met.
+type FileChangeMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m CertificateMetaMultiError) Error() string {
+func (m FileChangeMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2664,11 +4166,11 @@ func (m CertificateMetaMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m CertificateMetaMultiError) AllErrors() []error { return m }
+func (m FileChangeMultiError) AllErrors() []error { return m }
 
-// CertificateMetaValidationError is the validation error returned by
-// CertificateMeta.Validate if the designated constraints aren't met.
-type CertificateMetaValidationError struct {
+// FileChangeValidationError is the validation error returned by
+// FileChange.Validate if the designated constraints aren't met.
+type FileChangeValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2676,22 +4178,22 @@ type CertificateMetaValidationError struct {
 }
 
 // Field function returns field value.
-func (e CertificateMetaValidationError) Field() string { return e.field }
+func (e FileChangeValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e CertificateMetaValidationError) Reason() string { return e.reason }
+func (e FileChangeValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e CertificateMetaValidationError) Cause() error { return e.cause }
+func (e FileChangeValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e CertificateMetaValidationError) Key() bool { return e.key }
+func (e FileChangeValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e CertificateMetaValidationError) ErrorName() string { return "CertificateMetaValidationError" }
+func (e FileChangeValidationError) ErrorName() string { return "FileChangeValidationError" }
 
 // Error satisfies the builtin error interface
-func (e CertificateMetaValidationError) Error() string {
+func (e FileChangeValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2703,14 +4205,14 @@ func (e CertificateMetaValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sCertificateMeta.%s: %s%s",
+		"invalid %sFileChange.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = CertificateMetaValidationError{}
+var _ error = FileChangeValidationError{}
 
 var _ interface {
 	Field() string
@@ -2718,48 +4220,78 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = CertificateMetaValidationError{}
+} = FileChangeValidationError{}
 
-// Validate checks the field values on CertificateDates with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *CertificateDates) Validate() error {
+// Validate checks the field values on ConfigHistory with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ConfigHistory) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on CertificateDates with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// CertificateDatesMultiError, or nil if none found.
-func (m *CertificateDates) ValidateAll() error {
+// ValidateAll checks the field values on ConfigHistory with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ConfigHistoryMultiError, or
+// nil if none found.
+func (m *ConfigHistory) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *CertificateDates) validate(all bool) error {
+func (m *ConfigHistory) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for NotBefore
+	for idx, item := range m.GetVersions() {
+		_, _ = idx, item
 
-	// no validation rules for NotAfter
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ConfigHistoryValidationError{
+						field:  fmt.Sprintf("Versions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ConfigHistoryValidationError{
+						field:  fmt.Sprintf("Versions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ConfigHistoryValidationError{
+					field:  fmt.Sprintf("Versions[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
 
 	if len(errors) > 0 {
-		return CertificateDatesMultiError(errors)
+		return ConfigHistoryMultiError(errors)
 	}
 
 	return nil
 }
 
-// CertificateDatesMultiError is an error wrapping multiple validation errors
-// returned by CertificateDates.ValidateAll() if the designated constraints
+// ConfigHistoryMultiError is an error wrapping multiple validation errors
+// returned by ConfigHistory.ValidateAll() if the designated constraints
 // aren't met.
-type CertificateDatesMultiError []error
+type ConfigHistoryMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m CertificateDatesMultiError) Error() string {
+func (m ConfigHistoryMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2768,11 +4300,11 @@ func (m CertificateDatesMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m CertificateDatesMultiError) AllErrors() []error { return m }
+func (m ConfigHistoryMultiError) AllErrors() []error { return m }
 
-// CertificateDatesValidationError is the validation error returned by
-// CertificateDates.Validate if the designated constraints aren't met.
-type CertificateDatesValidationError struct {
+// ConfigHistoryValidationError is the validation error returned by
+// ConfigHistory.Validate if the designated constraints aren't met.
+type ConfigHistoryValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2780,22 +4312,22 @@ type CertificateDatesValidationError struct {
 }
 
 // Field function returns field value.
-func (e CertificateDatesValidationError) Field() string { return e.field }
+func (e ConfigHistoryValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e CertificateDatesValidationError) Reason() string { return e.reason }
+func (e ConfigHistoryValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e CertificateDatesValidationError) Cause() error { return e.cause }
+func (e ConfigHistoryValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e CertificateDatesValidationError) Key() bool { return e.key }
+func (e ConfigHistoryValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e CertificateDatesValidationError) ErrorName() string { return "CertificateDatesValidationError" }
+func (e ConfigHistoryValidationError) ErrorName() string { return "ConfigHistoryValidationError" }
 
 // Error satisfies the builtin error interface
-func (e CertificateDatesValidationError) Error() string {
+func (e ConfigHistoryValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2807,14 +4339,14 @@ func (e CertificateDatesValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sCertificateDates.%s: %s%s",
+		"invalid %sConfigHistory.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = CertificateDatesValidationError{}
+var _ error = ConfigHistoryValidationError{}
 
 var _ interface {
 	Field() string
@@ -2822,44 +4354,138 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = CertificateDatesValidationError{}
+} = ConfigHistoryValidationError{}
 
-// Validate checks the field values on SubjectAlternativeNames with the rules
+// Validate checks the field values on ConfigHistoryVersion with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *SubjectAlternativeNames) Validate() error {
+func (m *ConfigHistoryVersion) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on SubjectAlternativeNames with the
-// rules defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on ConfigHistoryVersion with the rules
+// defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// SubjectAlternativeNamesMultiError, or nil if none found.
-func (m *SubjectAlternativeNames) ValidateAll() error {
+// ConfigHistoryVersionMultiError, or nil if none found.
+func (m *ConfigHistoryVersion) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *SubjectAlternativeNames) validate(all bool) error {
+func (m *ConfigHistoryVersion) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
+	if all {
+		switch v := interface{}(m.GetConfigVersion()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfigHistoryVersionValidationError{
+					field:  "ConfigVersion",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfigHistoryVersionValidationError{
+					field:  "ConfigVersion",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetConfigVersion()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfigHistoryVersionValidationError{
+				field:  "ConfigVersion",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetAppliedTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfigHistoryVersionValidationError{
+					field:  "AppliedTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfigHistoryVersionValidationError{
+					field:  "AppliedTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAppliedTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfigHistoryVersionValidationError{
+				field:  "AppliedTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for ConfigPath
+
+	for idx, item := range m.GetFiles() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ConfigHistoryVersionValidationError{
+						field:  fmt.Sprintf("Files[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ConfigHistoryVersionValidationError{
+						field:  fmt.Sprintf("Files[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ConfigHistoryVersionValidationError{
+					field:  fmt.Sprintf("Files[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
-		return SubjectAlternativeNamesMultiError(errors)
+		return ConfigHistoryVersionMultiError(errors)
 	}
 
 	return nil
 }
 
-// SubjectAlternativeNamesMultiError is an error wrapping multiple validation
-// errors returned by SubjectAlternativeNames.ValidateAll() if the designated
+// ConfigHistoryVersionMultiError is an error wrapping multiple validation
+// errors returned by ConfigHistoryVersion.ValidateAll() if the designated
 // constraints aren't met.
-type SubjectAlternativeNamesMultiError []error
+type ConfigHistoryVersionMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m SubjectAlternativeNamesMultiError) Error() string {
+func (m ConfigHistoryVersionMultiError) Error() string {
 	msgs := make([]string, 0, len(m))
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2868,11 +4494,11 @@ func (m SubjectAlternativeNamesMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m SubjectAlternativeNamesMultiError) AllErrors() []error { return m }
+func (m ConfigHistoryVersionMultiError) AllErrors() []error { return m }
 
-// SubjectAlternativeNamesValidationError is the validation error returned by
-// SubjectAlternativeNames.Validate if the designated constraints aren't met.
-type SubjectAlternativeNamesValidationError struct {
+// ConfigHistoryVersionValidationError is the validation error returned by
+// ConfigHistoryVersion.Validate if the designated constraints aren't met.
+type ConfigHistoryVersionValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2880,24 +4506,24 @@ type SubjectAlternativeNamesValidationError struct {
 }
 
 // Field function returns field value.
-func (e SubjectAlternativeNamesValidationError) Field() string { return e.field }
+func (e ConfigHistoryVersionValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e SubjectAlternativeNamesValidationError) Reason() string { return e.reason }
+func (e ConfigHistoryVersionValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e SubjectAlternativeNamesValidationError) Cause() error { return e.cause }
+func (e ConfigHistoryVersionValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e SubjectAlternativeNamesValidationError) Key() bool { return e.key }
+func (e ConfigHistoryVersionValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e SubjectAlternativeNamesValidationError) ErrorName() string {
-	return "SubjectAlternativeNamesValidationError"
+func (e ConfigHistoryVersionValidationError) ErrorName() string {
+	return "ConfigHistoryVersionValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e SubjectAlternativeNamesValidationError) Error() string {
+func (e ConfigHistoryVersionValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2909,14 +4535,14 @@ func (e SubjectAlternativeNamesValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sSubjectAlternativeNames.%s: %s%s",
+		"invalid %sConfigHistoryVersion.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = SubjectAlternativeNamesValidationError{}
+var _ error = ConfigHistoryVersionValidationError{}
 
 var _ interface {
 	Field() string
@@ -2924,7 +4550,7 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = SubjectAlternativeNamesValidationError{}
+} = ConfigHistoryVersionValidationError{}
 
 // Validate checks the field values on X509Name with the rules defined in the
 // proto definition for this message. If any rules are violated, the first
@@ -16,6 +16,11 @@ const (
 	CredentialUpdatedTopic           = "credential-updated"
 	ConnectionResetTopic             = "connection-reset"
 	ConfigApplyRequestTopic          = "config-apply-request"
+	QueueConfigApplyRequestTopic     = "queue-config-apply-request"
+	ConfigValidateRequestTopic       = "config-validate-request"
+	ConfigDiffRequestTopic           = "config-diff-request"
+	ConfigHistoryRequestTopic        = "config-history-request"
+	ExternalFileRefreshTopic         = "external-file-refresh"
 	WriteConfigSuccessfulTopic       = "write-config-successful"
 	EnableWatchersTopic              = "enable-watchers"
 	DataPlaneHealthRequestTopic      = "data-plane-health-request"
@@ -0,0 +1,217 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/pkg/files"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	commandJournalFileName          = "command_journal.json"
+	auxiliaryCommandJournalFileName = "auxiliary_command_journal.json"
+	maxCommandJournalEntries        = 100
+	maxCommandJournalResponses      = 10
+	journalFilePerm                 = 0o600
+)
+
+type (
+	// commandJournal keeps a bounded record of the management plane requests handled by the agent and the
+	// data plane responses sent for them, so that the outcome of a command can be reported on request.
+	// If a path is provided the journal is persisted to disk after every change.
+	commandJournal struct {
+		index   map[string]*commandJournalEntry
+		path    string
+		entries []*commandJournalEntry
+		mutex   sync.Mutex
+	}
+
+	commandJournalEntry struct {
+		StartTime     time.Time         `json:"start_time"`
+		EndTime       time.Time         `json:"end_time,omitzero"`
+		CorrelationID string            `json:"correlation_id"`
+		RequestType   string            `json:"request_type"`
+		InstanceID    string            `json:"instance_id,omitempty"`
+		Responses     []json.RawMessage `json:"responses,omitempty"`
+	}
+)
+
+func newCommandJournal(path string) *commandJournal {
+	return &commandJournal{
+		path:  path,
+		index: make(map[string]*commandJournalEntry),
+	}
+}
+
+// Load reads a previously persisted journal from disk. A missing journal file is not an error.
+func (cj *commandJournal) Load(ctx context.Context) error {
+	if cj.path == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(cj.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to read command journal %s: %w", cj.path, err)
+	}
+
+	var entries []*commandJournalEntry
+	if err = json.Unmarshal(content, &entries); err != nil {
+		return fmt.Errorf("unable to unmarshal command journal %s: %w", cj.path, err)
+	}
+
+	cj.mutex.Lock()
+	defer cj.mutex.Unlock()
+
+	cj.entries = nil
+	cj.index = make(map[string]*commandJournalEntry)
+	for _, entry := range entries {
+		if entry == nil || entry.CorrelationID == "" {
+			continue
+		}
+		cj.add(entry)
+	}
+
+	slog.DebugContext(ctx, "Loaded command journal", "path", cj.path, "entries", len(cj.entries))
+
+	return nil
+}
+
+// RecordRequest adds a journal entry for a management plane request that the agent is about to handle.
+func (cj *commandJournal) RecordRequest(
+	ctx context.Context,
+	correlationID string,
+	requestType mpi.DataPlaneResponse_RequestType,
+	instanceID string,
+) {
+	if correlationID == "" {
+		return
+	}
+
+	cj.mutex.Lock()
+	defer cj.mutex.Unlock()
+
+	if entry, ok := cj.index[correlationID]; ok {
+		slog.DebugContext(ctx, "Command already recorded in journal", "start_time", entry.StartTime)
+		return
+	}
+
+	cj.add(&commandJournalEntry{
+		CorrelationID: correlationID,
+		RequestType:   requestType.String(),
+		InstanceID:    instanceID,
+		StartTime:     time.Now().UTC(),
+	})
+
+	cj.persist(ctx)
+}
+
+// RecordResponse stores a data plane response against the journal entry with the same correlation ID.
+// A response with an OK or FAILURE status is the final response and completes the entry,
+// any other status is stored as an intermediate status of the command.
+func (cj *commandJournal) RecordResponse(ctx context.Context, response *mpi.DataPlaneResponse) {
+	cj.mutex.Lock()
+	defer cj.mutex.Unlock()
+
+	entry, ok := cj.index[response.GetMessageMeta().GetCorrelationId()]
+	if !ok || !entry.EndTime.IsZero() {
+		return
+	}
+
+	responseJSON, err := protojson.Marshal(response)
+	if err != nil {
+		slog.WarnContext(ctx, "Unable to marshal data plane response for command journal", "error", err)
+		return
+	}
+
+	if len(entry.Responses) >= maxCommandJournalResponses {
+		entry.Responses = entry.Responses[1:]
+	}
+	entry.Responses = append(entry.Responses, responseJSON)
+
+	if entry.InstanceID == "" {
+		entry.InstanceID = response.GetInstanceId()
+	}
+
+	if isFinalCommandStatus(response.GetCommandResponse().GetStatus()) {
+		entry.EndTime = time.Now().UTC()
+	}
+
+	cj.persist(ctx)
+}
+
+// Response returns the most recent data plane response recorded for a command and whether the command has
+// completed. If the command is unknown, found is false.
+func (cj *commandJournal) Response(
+	correlationID string,
+) (response *mpi.DataPlaneResponse, completed, found bool, err error) {
+	cj.mutex.Lock()
+	defer cj.mutex.Unlock()
+
+	entry, ok := cj.index[correlationID]
+	if !ok {
+		return nil, false, false, nil
+	}
+
+	completed = !entry.EndTime.IsZero()
+
+	if len(entry.Responses) == 0 {
+		return nil, completed, true, nil
+	}
+
+	response = &mpi.DataPlaneResponse{}
+	if err = protojson.Unmarshal(entry.Responses[len(entry.Responses)-1], response); err != nil {
+		return nil, completed, true, fmt.Errorf("unable to unmarshal stored data plane response: %w", err)
+	}
+
+	return response, completed, true, nil
+}
+
+func (cj *commandJournal) add(entry *commandJournalEntry) {
+	if len(cj.entries) >= maxCommandJournalEntries {
+		oldest := cj.entries[0]
+		delete(cj.index, oldest.CorrelationID)
+		cj.entries = cj.entries[1:]
+	}
+
+	cj.entries = append(cj.entries, entry)
+	cj.index[entry.CorrelationID] = entry
+}
+
+// persist writes the journal atomically, so a crash never leaves a partially written journal behind.
+// Must be called with the mutex held.
+func (cj *commandJournal) persist(ctx context.Context) {
+	if cj.path == "" {
+		return
+	}
+
+	journalJSON, err := json.MarshalIndent(cj.entries, "", "  ")
+	if err != nil {
+		slog.WarnContext(ctx, "Unable to marshal command journal", "error", err)
+		return
+	}
+
+	if err = files.WriteFileAtomically(cj.path, journalJSON, journalFilePerm); err != nil {
+		slog.WarnContext(ctx, "Unable to write command journal", "path", cj.path, "error", err)
+	}
+}
+
+func isFinalCommandStatus(status mpi.CommandResponse_CommandStatus) bool {
+	return status == mpi.CommandResponse_COMMAND_STATUS_OK || status == mpi.CommandResponse_COMMAND_STATUS_FAILURE
+}
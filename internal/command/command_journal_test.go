@@ -0,0 +1,171 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/model"
+	"github.com/nginx/agent/v3/pkg/id"
+	"github.com/nginx/agent/v3/test/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCommandJournal_RecordResponse(t *testing.T) {
+	ctx := context.Background()
+	journal := newCommandJournal("")
+
+	journal.RecordRequest(ctx, "correlation-id", mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST, "instance-id")
+
+	response, completed, found, err := journal.Response("correlation-id")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.False(t, completed)
+	assert.Nil(t, response)
+
+	rollingBackResponse := createJournalTestResponse(
+		"correlation-id", mpi.CommandResponse_COMMAND_STATUS_ERROR, "Config apply failed, rolling back config",
+	)
+	journal.RecordResponse(ctx, rollingBackResponse)
+
+	response, completed, found, err = journal.Response("correlation-id")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.False(t, completed)
+	assert.True(t, proto.Equal(rollingBackResponse, response))
+
+	finalResponse := createJournalTestResponse(
+		"correlation-id", mpi.CommandResponse_COMMAND_STATUS_FAILURE, "Config apply failed, rollback successful",
+	)
+	journal.RecordResponse(ctx, finalResponse)
+
+	response, completed, found, err = journal.Response("correlation-id")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.True(t, completed)
+	assert.True(t, proto.Equal(finalResponse, response))
+
+	entry := journal.index["correlation-id"]
+	assert.Equal(t, mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST.String(), entry.RequestType)
+	assert.Equal(t, "instance-id", entry.InstanceID)
+	assert.Len(t, entry.Responses, 2)
+	assert.False(t, entry.EndTime.Before(entry.StartTime))
+
+	// Responses received after the command has completed are ignored
+	journal.RecordResponse(ctx, rollingBackResponse)
+	response, _, _, err = journal.Response("correlation-id")
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(finalResponse, response))
+
+	// Responses for commands that are not in the journal are ignored
+	journal.RecordResponse(ctx, createJournalTestResponse(
+		"unknown-id", mpi.CommandResponse_COMMAND_STATUS_OK, "Successfully sent health status update",
+	))
+	_, _, found, err = journal.Response("unknown-id")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestCommandJournal_Bounded(t *testing.T) {
+	ctx := context.Background()
+	journal := newCommandJournal("")
+
+	for i := range maxCommandJournalEntries + 5 {
+		journal.RecordRequest(ctx, fmt.Sprintf("correlation-id-%d", i), mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST, "")
+	}
+
+	assert.Len(t, journal.entries, maxCommandJournalEntries)
+	assert.Len(t, journal.index, maxCommandJournalEntries)
+
+	_, _, found, err := journal.Response("correlation-id-0")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	_, _, found, err = journal.Response(fmt.Sprintf("correlation-id-%d", maxCommandJournalEntries+4))
+	require.NoError(t, err)
+	assert.True(t, found)
+}
+
+func TestCommandJournal_Load(t *testing.T) {
+	ctx := context.Background()
+	journalPath := filepath.Join(t.TempDir(), commandJournalFileName)
+
+	journal := newCommandJournal(journalPath)
+	journal.RecordRequest(ctx, "correlation-id", mpi.DataPlaneResponse_API_ACTION_REQUEST, "instance-id")
+	finalResponse := createJournalTestResponse(
+		"correlation-id", mpi.CommandResponse_COMMAND_STATUS_OK, "Successfully updated all upstream servers",
+	)
+	journal.RecordResponse(ctx, finalResponse)
+
+	assert.FileExists(t, journalPath)
+	assert.NoFileExists(t, journalPath+".tmp")
+
+	loadedJournal := newCommandJournal(journalPath)
+	require.NoError(t, loadedJournal.Load(ctx))
+
+	response, completed, found, err := loadedJournal.Response("correlation-id")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.True(t, completed)
+	assert.True(t, proto.Equal(finalResponse, response))
+}
+
+func TestCommandJournal_Load_Errors(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	missingJournal := newCommandJournal(filepath.Join(tempDir, "missing.json"))
+	require.NoError(t, missingJournal.Load(ctx))
+
+	invalidJournalPath := filepath.Join(tempDir, commandJournalFileName)
+	require.NoError(t, os.WriteFile(invalidJournalPath, []byte("invalid"), journalFilePerm))
+
+	invalidJournal := newCommandJournal(invalidJournalPath)
+	require.ErrorContains(t, invalidJournal.Load(ctx), "unable to unmarshal command journal")
+}
+
+func TestNewCommandPlugin_JournalPath(t *testing.T) {
+	agentConfig := types.AgentConfig()
+	agentConfig.LibDir = t.TempDir()
+
+	commandPlugin := NewCommandPlugin(agentConfig, nil, model.Command)
+	assert.Equal(t, filepath.Join(agentConfig.LibDir, commandJournalFileName), commandPlugin.journal.path)
+
+	auxiliaryCommandPlugin := NewCommandPlugin(agentConfig, nil, model.Auxiliary)
+	assert.Equal(
+		t,
+		filepath.Join(agentConfig.LibDir, auxiliaryCommandJournalFileName),
+		auxiliaryCommandPlugin.journal.path,
+	)
+}
+
+func createJournalTestResponse(
+	correlationID string,
+	status mpi.CommandResponse_CommandStatus,
+	message string,
+) *mpi.DataPlaneResponse {
+	return &mpi.DataPlaneResponse{
+		MessageMeta: &mpi.MessageMeta{
+			MessageId:     id.GenerateMessageID(),
+			CorrelationId: correlationID,
+			Timestamp:     timestamppb.Now(),
+		},
+		CommandResponse: &mpi.CommandResponse{
+			Status:  status,
+			Message: message,
+		},
+		InstanceId:  "instance-id",
+		RequestType: mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST,
+	}
+}
@@ -8,6 +8,7 @@ package command
 import (
 	"context"
 	"log/slog"
+	"path/filepath"
 	"sync"
 
 	"github.com/nginx/agent/v3/internal/model"
@@ -33,6 +34,7 @@ type (
 		UpdateDataPlaneStatus(ctx context.Context, resource *mpi.Resource) error
 		UpdateDataPlaneHealth(ctx context.Context, instanceHealths []*mpi.InstanceHealth) error
 		SendDataPlaneResponse(ctx context.Context, response *mpi.DataPlaneResponse) error
+		QueueConfigApplyRequest(ctx context.Context, request *mpi.ManagementPlaneRequest)
 		UpdateClient(ctx context.Context, client mpi.CommandServiceClient) error
 		Subscribe(ctx context.Context)
 		IsConnected() bool
@@ -46,6 +48,8 @@ type (
 		subscribeCancel   context.CancelFunc
 		conn              grpc.GrpcConnectionInterface
 		commandService    commandService
+		journal           *commandJournal
+		spool             *outboundSpool
 		subscribeChannel  chan *mpi.ManagementPlaneRequest
 		commandServerType model.ServerType
 		subscribeMutex    sync.Mutex
@@ -57,9 +61,23 @@ type (
 func NewCommandPlugin(agentConfig *config.Config, grpcConnection grpc.GrpcConnectionInterface,
 	commandServerType model.ServerType,
 ) *CommandPlugin {
+	journalPath := libDirFilePath(
+		agentConfig, commandServerType, commandJournalFileName, auxiliaryCommandJournalFileName,
+	)
+	spoolPath := libDirFilePath(
+		agentConfig, commandServerType, outboundSpoolFileName, auxiliaryOutboundSpoolFileName,
+	)
+
+	var spoolLimits *config.Spool
+	if agentConfig.Client != nil {
+		spoolLimits = agentConfig.Client.Spool
+	}
+
 	return &CommandPlugin{
 		agentConfig:       agentConfig,
 		conn:              grpcConnection,
+		journal:           newCommandJournal(journalPath),
+		spool:             newOutboundSpool(spoolPath, spoolLimits),
 		subscribeChannel:  make(chan *mpi.ManagementPlaneRequest),
 		commandServerType: commandServerType,
 	}
@@ -73,7 +91,24 @@ func (cp *CommandPlugin) Init(ctx context.Context, messagePipe bus.MessagePipeIn
 	slog.DebugContext(newCtx, "Starting command plugin")
 
 	cp.messagePipe = messagePipe
-	cp.commandService = NewCommandService(cp.conn.CommandServiceClient(), cp.agentConfig, cp.subscribeChannel)
+	commandService := NewCommandService(
+		cp.conn.CommandServiceClient(),
+		cp.agentConfig,
+		cp.subscribeChannel,
+		libDirFilePath(cp.agentConfig, cp.commandServerType, subscribeIndexFileName, auxiliarySubscribeIndexFileName),
+	)
+	// Messages spooled while the Subscribe stream was down are sent as soon as the stream is re-established,
+	// which does not always involve creating a new connection
+	commandService.OnSubscribeStreamCreated(cp.drainSpool)
+	cp.commandService = commandService
+
+	if err := cp.journal.Load(newCtx); err != nil {
+		slog.WarnContext(newCtx, "Unable to load command journal", "error", err)
+	}
+
+	if err := cp.spool.Load(newCtx); err != nil {
+		slog.WarnContext(newCtx, "Unable to load outbound spool", "error", err)
+	}
 
 	go cp.monitorSubscribeChannel(newCtx)
 
@@ -132,6 +167,10 @@ func (cp *CommandPlugin) Process(ctx context.Context, msg *bus.Message) {
 			cp.processDataPlaneHealth(ctxWithMetadata, msg)
 		case bus.DataPlaneResponseTopic:
 			cp.processDataPlaneResponse(ctxWithMetadata, msg)
+		case bus.ConnectionCreatedTopic:
+			cp.drainSpool(ctxWithMetadata)
+		case bus.QueueConfigApplyRequestTopic:
+			cp.processQueueConfigApplyRequest(ctxWithMetadata, msg)
 		default:
 			slog.DebugContext(ctxWithMetadata, "Command plugin received unknown topic", "topic", msg.Topic)
 		}
@@ -145,6 +184,8 @@ func (cp *CommandPlugin) Subscriptions() []string {
 		bus.InstanceHealthTopic,
 		bus.DataPlaneHealthResponseTopic,
 		bus.DataPlaneResponseTopic,
+		bus.ConnectionCreatedTopic,
+		bus.QueueConfigApplyRequestTopic,
 	}
 }
 
@@ -256,9 +297,18 @@ func (cp *CommandPlugin) processDataPlaneHealth(ctx context.Context, msg *bus.Me
 func (cp *CommandPlugin) processInstanceHealth(ctx context.Context, msg *bus.Message) {
 	slog.DebugContext(ctx, "Command plugin received instance health message")
 	if instances, ok := msg.Data.([]*mpi.InstanceHealth); ok {
+		if cp.spoolMessage(ctx) {
+			cp.spool.AddInstanceHealths(ctx, instances)
+			return
+		}
+
 		err := cp.commandService.UpdateDataPlaneHealth(ctx, instances)
 		if err != nil {
 			slog.ErrorContext(ctx, "Unable to update data plane health", "error", err)
+
+			if cp.spool.Enabled() {
+				cp.spool.AddInstanceHealths(ctx, instances)
+			}
 		}
 	}
 }
@@ -285,10 +335,16 @@ func (cp *CommandPlugin) processDataPlaneResponse(ctx context.Context, msg *bus.
 			)
 		}
 
-		err := cp.commandService.SendDataPlaneResponse(ctx, response)
-		if err != nil {
-			slog.ErrorContext(ctx, "Unable to send data plane response", "error", err)
-		}
+		cp.sendDataPlaneResponse(ctx, response)
+	}
+}
+
+// processQueueConfigApplyRequest queues a config apply request that was created by the agent itself, so that it
+// is applied in order with the config apply requests from the management plane.
+func (cp *CommandPlugin) processQueueConfigApplyRequest(ctx context.Context, msg *bus.Message) {
+	slog.DebugContext(ctx, "Command plugin received queue config apply request message")
+	if request, ok := msg.Data.(*mpi.ManagementPlaneRequest); ok {
+		cp.commandService.QueueConfigApplyRequest(ctx, request)
 	}
 }
 
@@ -354,6 +410,8 @@ func (cp *CommandPlugin) monitorSubscribeChannel(ctx context.Context) {
 			)
 			slog.DebugContext(newCtx, "Received management plane request", "request", message)
 
+			cp.recordRequest(newCtx, message)
+
 			switch message.GetRequest().(type) {
 			case *mpi.ManagementPlaneRequest_ConfigUploadRequest:
 				slog.InfoContext(ctx, "Received management plane config upload request")
@@ -369,6 +427,31 @@ func (cp *CommandPlugin) monitorSubscribeChannel(ctx context.Context) {
 				}
 				slog.InfoContext(ctx, "Received management plane config apply request")
 				cp.handleConfigApplyRequest(newCtx, message)
+			case *mpi.ManagementPlaneRequest_ConfigValidateRequest:
+				if cp.commandServerType != model.Command {
+					slog.WarnContext(newCtx, "Auxiliary command server can not perform config validate",
+						"command_server_type", cp.commandServerType.String())
+					cp.handleInvalidRequest(newCtx, message, "Config validate failed",
+						message.GetConfigValidateRequest().GetOverview().GetConfigVersion().GetInstanceId())
+
+					continue
+				}
+				slog.InfoContext(ctx, "Received management plane config validate request")
+				cp.handleConfigValidateRequest(newCtx, message)
+			case *mpi.ManagementPlaneRequest_ConfigHistoryRequest:
+				if cp.commandServerType != model.Command {
+					slog.WarnContext(newCtx, "Auxiliary command server can not perform config history",
+						"command_server_type", cp.commandServerType.String())
+					cp.handleInvalidRequest(newCtx, message, "Config history failed",
+						message.GetConfigHistoryRequest().GetInstanceId())
+
+					continue
+				}
+				slog.InfoContext(ctx, "Received management plane config history request")
+				cp.handleConfigHistoryRequest(newCtx, message)
+			case *mpi.ManagementPlaneRequest_ConfigDiffRequest:
+				slog.InfoContext(ctx, "Received management plane config diff request")
+				cp.handleConfigDiffRequest(newCtx, message)
 			case *mpi.ManagementPlaneRequest_HealthRequest:
 				// To prevent this type of request from spamming the logs too much, we use debug level
 				slog.DebugContext(ctx, "Received management plane health request")
@@ -395,6 +478,9 @@ func (cp *CommandPlugin) monitorSubscribeChannel(ctx context.Context) {
 				}
 
 				cp.messagePipe.Process(ctx, &bus.Message{Topic: bus.AgentConfigUpdateTopic, Data: message})
+			case *mpi.ManagementPlaneRequest_CommandStatusRequest:
+				slog.InfoContext(ctx, "Received management plane command status request")
+				cp.handleCommandStatusRequest(newCtx, message)
 			default:
 				slog.DebugContext(newCtx, "Management plane request not implemented yet")
 			}
@@ -413,7 +499,7 @@ func (cp *CommandPlugin) handleAPIActionRequest(ctx context.Context, message *mp
 			"request", message, "enabled_features", cfg.Features,
 		)
 
-		err := cp.commandService.SendDataPlaneResponse(ctx, &mpi.DataPlaneResponse{
+		cp.sendDataPlaneResponse(ctx, &mpi.DataPlaneResponse{
 			MessageMeta: message.GetMessageMeta(),
 			CommandResponse: &mpi.CommandResponse{
 				Status:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
@@ -422,9 +508,6 @@ func (cp *CommandPlugin) handleAPIActionRequest(ctx context.Context, message *mp
 			},
 			InstanceId: message.GetActionRequest().GetInstanceId(),
 		})
-		if err != nil {
-			slog.ErrorContext(ctx, "Unable to send data plane response", "error", err)
-		}
 	}
 }
 
@@ -439,7 +522,7 @@ func (cp *CommandPlugin) handleConfigApplyRequest(newCtx context.Context, messag
 			"request", message, "enabled_features", cfg.Features,
 		)
 
-		err := cp.commandService.SendDataPlaneResponse(newCtx, &mpi.DataPlaneResponse{
+		cp.sendDataPlaneResponse(newCtx, &mpi.DataPlaneResponse{
 			MessageMeta: message.GetMessageMeta(),
 			CommandResponse: &mpi.CommandResponse{
 				Status:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
@@ -448,9 +531,78 @@ func (cp *CommandPlugin) handleConfigApplyRequest(newCtx context.Context, messag
 			},
 			InstanceId: message.GetConfigApplyRequest().GetOverview().GetConfigVersion().GetInstanceId(),
 		})
-		if err != nil {
-			slog.ErrorContext(newCtx, "Unable to send data plane response", "error", err)
-		}
+	}
+}
+
+func (cp *CommandPlugin) handleConfigValidateRequest(newCtx context.Context, message *mpi.ManagementPlaneRequest) {
+	cfg := cp.config()
+	if cfg.IsFeatureEnabled(pkgConfig.FeatureConfiguration) {
+		cp.messagePipe.Process(newCtx, &bus.Message{Topic: bus.ConfigValidateRequestTopic, Data: message})
+	} else {
+		slog.WarnContext(
+			newCtx,
+			"Configuration feature disabled. Unable to process config validate request",
+			"request", message, "enabled_features", cfg.Features,
+		)
+
+		cp.sendDataPlaneResponse(newCtx, &mpi.DataPlaneResponse{
+			MessageMeta: message.GetMessageMeta(),
+			CommandResponse: &mpi.CommandResponse{
+				Status:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+				Message: "Config validate failed",
+				Error:   "Configuration feature is disabled",
+			},
+			InstanceId:  message.GetConfigValidateRequest().GetOverview().GetConfigVersion().GetInstanceId(),
+			RequestType: mpi.DataPlaneResponse_CONFIG_VALIDATE_REQUEST,
+		})
+	}
+}
+
+func (cp *CommandPlugin) handleConfigHistoryRequest(newCtx context.Context, message *mpi.ManagementPlaneRequest) {
+	cfg := cp.config()
+	if cfg.IsFeatureEnabled(pkgConfig.FeatureConfiguration) {
+		cp.messagePipe.Process(newCtx, &bus.Message{Topic: bus.ConfigHistoryRequestTopic, Data: message})
+	} else {
+		slog.WarnContext(
+			newCtx,
+			"Configuration feature disabled. Unable to process config history request",
+			"request", message, "enabled_features", cfg.Features,
+		)
+
+		cp.sendDataPlaneResponse(newCtx, &mpi.DataPlaneResponse{
+			MessageMeta: message.GetMessageMeta(),
+			CommandResponse: &mpi.CommandResponse{
+				Status:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+				Message: "Config history failed",
+				Error:   "Configuration feature is disabled",
+			},
+			InstanceId:  message.GetConfigHistoryRequest().GetInstanceId(),
+			RequestType: mpi.DataPlaneResponse_CONFIG_HISTORY_REQUEST,
+		})
+	}
+}
+
+func (cp *CommandPlugin) handleConfigDiffRequest(newCtx context.Context, message *mpi.ManagementPlaneRequest) {
+	cfg := cp.config()
+	if cfg.IsFeatureEnabled(pkgConfig.FeatureConfiguration) {
+		cp.messagePipe.Process(newCtx, &bus.Message{Topic: bus.ConfigDiffRequestTopic, Data: message})
+	} else {
+		slog.WarnContext(
+			newCtx,
+			"Configuration feature disabled. Unable to process config diff request",
+			"request", message, "enabled_features", cfg.Features,
+		)
+
+		cp.sendDataPlaneResponse(newCtx, &mpi.DataPlaneResponse{
+			MessageMeta: message.GetMessageMeta(),
+			CommandResponse: &mpi.CommandResponse{
+				Status:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+				Message: "Config diff failed",
+				Error:   "Configuration feature is disabled",
+			},
+			InstanceId:  message.GetConfigDiffRequest().GetOverview().GetConfigVersion().GetInstanceId(),
+			RequestType: mpi.DataPlaneResponse_CONFIG_DIFF_REQUEST,
+		})
 	}
 }
 
@@ -465,7 +617,7 @@ func (cp *CommandPlugin) handleConfigUploadRequest(newCtx context.Context, messa
 			"request", message, "enabled_features", cfg.Features,
 		)
 
-		err := cp.commandService.SendDataPlaneResponse(newCtx, &mpi.DataPlaneResponse{
+		cp.sendDataPlaneResponse(newCtx, &mpi.DataPlaneResponse{
 			MessageMeta: message.GetMessageMeta(),
 			CommandResponse: &mpi.CommandResponse{
 				Status:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
@@ -474,9 +626,6 @@ func (cp *CommandPlugin) handleConfigUploadRequest(newCtx context.Context, messa
 			},
 			InstanceId: message.GetConfigUploadRequest().GetOverview().GetConfigVersion().GetInstanceId(),
 		})
-		if err != nil {
-			slog.ErrorContext(newCtx, "Unable to send data plane response", "error", err)
-		}
 	}
 }
 
@@ -487,7 +636,7 @@ func (cp *CommandPlugin) handleHealthRequest(newCtx context.Context) {
 func (cp *CommandPlugin) handleInvalidRequest(ctx context.Context,
 	request *mpi.ManagementPlaneRequest, message, instanceID string,
 ) {
-	err := cp.commandService.SendDataPlaneResponse(ctx, &mpi.DataPlaneResponse{
+	cp.sendDataPlaneResponse(ctx, &mpi.DataPlaneResponse{
 		MessageMeta: request.GetMessageMeta(),
 		CommandResponse: &mpi.CommandResponse{
 			Status:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
@@ -496,11 +645,158 @@ func (cp *CommandPlugin) handleInvalidRequest(ctx context.Context,
 		},
 		InstanceId: instanceID,
 	})
+}
+
+func (cp *CommandPlugin) handleCommandStatusRequest(ctx context.Context, message *mpi.ManagementPlaneRequest) {
+	correlationID := message.GetCommandStatusRequest().GetCorrelationId()
+	if correlationID == "" {
+		correlationID = message.GetMessageMeta().GetCorrelationId()
+	}
+
+	storedResponse, completed, found, err := cp.journal.Response(correlationID)
+
+	var dataPlaneResponse *mpi.DataPlaneResponse
+
+	switch {
+	case err != nil:
+		slog.ErrorContext(ctx, "Unable to read command journal", "error", err)
+		dataPlaneResponse = cp.createDataPlaneResponse(
+			correlationID,
+			mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+			mpi.DataPlaneResponse_COMMAND_STATUS_REQUEST,
+			"Unable to get command status",
+			err.Error(),
+		)
+	case !found:
+		dataPlaneResponse = cp.createDataPlaneResponse(
+			correlationID,
+			mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+			mpi.DataPlaneResponse_COMMAND_STATUS_REQUEST,
+			"Unable to get command status",
+			"No command found with correlation ID "+correlationID,
+		)
+	case completed:
+		slog.DebugContext(ctx, "Replaying data plane response for completed command")
+		dataPlaneResponse = storedResponse
+	default:
+		statusMessage := "Command in progress"
+		if storedResponse != nil {
+			statusMessage = storedResponse.GetCommandResponse().GetMessage()
+		}
+
+		dataPlaneResponse = cp.createDataPlaneResponse(
+			correlationID,
+			mpi.CommandResponse_COMMAND_STATUS_IN_PROGRESS,
+			mpi.DataPlaneResponse_COMMAND_STATUS_REQUEST,
+			statusMessage,
+			"",
+		)
+		dataPlaneResponse.InstanceId = storedResponse.GetInstanceId()
+	}
+
+	err = cp.commandService.SendDataPlaneResponse(ctx, dataPlaneResponse)
 	if err != nil {
 		slog.ErrorContext(ctx, "Unable to send data plane response", "error", err)
 	}
 }
 
+// recordRequest adds a management plane request to the command journal. Health and command status requests
+// are not recorded since they are sent frequently and would quickly push other commands out of the journal.
+func (cp *CommandPlugin) recordRequest(ctx context.Context, message *mpi.ManagementPlaneRequest) {
+	var requestType mpi.DataPlaneResponse_RequestType
+	var instanceID string
+
+	switch request := message.GetRequest().(type) {
+	case *mpi.ManagementPlaneRequest_ConfigApplyRequest:
+		requestType = mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST
+		instanceID = request.ConfigApplyRequest.GetOverview().GetConfigVersion().GetInstanceId()
+	case *mpi.ManagementPlaneRequest_ConfigUploadRequest:
+		requestType = mpi.DataPlaneResponse_CONFIG_UPLOAD_REQUEST
+		instanceID = request.ConfigUploadRequest.GetOverview().GetConfigVersion().GetInstanceId()
+	case *mpi.ManagementPlaneRequest_ConfigValidateRequest:
+		requestType = mpi.DataPlaneResponse_CONFIG_VALIDATE_REQUEST
+		instanceID = request.ConfigValidateRequest.GetOverview().GetConfigVersion().GetInstanceId()
+	case *mpi.ManagementPlaneRequest_ConfigHistoryRequest:
+		requestType = mpi.DataPlaneResponse_CONFIG_HISTORY_REQUEST
+		instanceID = request.ConfigHistoryRequest.GetInstanceId()
+	case *mpi.ManagementPlaneRequest_ConfigDiffRequest:
+		requestType = mpi.DataPlaneResponse_CONFIG_DIFF_REQUEST
+		instanceID = request.ConfigDiffRequest.GetOverview().GetConfigVersion().GetInstanceId()
+	case *mpi.ManagementPlaneRequest_ActionRequest:
+		requestType = mpi.DataPlaneResponse_API_ACTION_REQUEST
+		instanceID = request.ActionRequest.GetInstanceId()
+	case *mpi.ManagementPlaneRequest_UpdateAgentConfigRequest:
+		requestType = mpi.DataPlaneResponse_UPDATE_AGENT_CONFIG_REQUEST
+	default:
+		return
+	}
+
+	cp.journal.RecordRequest(ctx, message.GetMessageMeta().GetCorrelationId(), requestType, instanceID)
+}
+
+// sendDataPlaneResponse records the response in the command journal before sending it to the management plane.
+// If the response can not be sent it is added to the outbound spool, except for responses to health requests
+// which are only relevant while the management plane is waiting for them.
+func (cp *CommandPlugin) sendDataPlaneResponse(ctx context.Context, response *mpi.DataPlaneResponse) {
+	cp.journal.RecordResponse(ctx, response)
+
+	spoolable := response.GetRequestType() != mpi.DataPlaneResponse_HEALTH_REQUEST
+	if spoolable && cp.spoolMessage(ctx) {
+		cp.spool.AddDataPlaneResponse(ctx, response)
+		return
+	}
+
+	err := cp.commandService.SendDataPlaneResponse(ctx, response)
+	if err != nil {
+		slog.ErrorContext(ctx, "Unable to send data plane response", "error", err)
+
+		if spoolable && cp.spool.Enabled() {
+			cp.spool.AddDataPlaneResponse(ctx, response)
+		}
+	}
+}
+
+// spoolMessage returns true if a message should be added to the outbound spool instead of being sent, because
+// the agent is disconnected or because older spooled messages could not be sent yet.
+func (cp *CommandPlugin) spoolMessage(ctx context.Context) bool {
+	if !cp.spool.Enabled() {
+		return false
+	}
+
+	if !cp.commandService.IsConnected() {
+		return true
+	}
+
+	if cp.spool.Len() == 0 {
+		return false
+	}
+
+	cp.drainSpool(ctx)
+
+	return cp.spool.Len() > 0
+}
+
+func (cp *CommandPlugin) drainSpool(ctx context.Context) {
+	if !cp.spool.Enabled() || cp.spool.Len() == 0 {
+		return
+	}
+
+	slog.InfoContext(
+		ctx,
+		"Sending messages stored while disconnected from management plane",
+		"messages", cp.spool.Len(),
+	)
+
+	if err := cp.spool.Drain(ctx, cp.commandService); err != nil {
+		slog.WarnContext(
+			ctx,
+			"Unable to send all stored messages to management plane",
+			"remaining_messages", cp.spool.Len(),
+			"error", err,
+		)
+	}
+}
+
 func (cp *CommandPlugin) createDataPlaneResponse(
 	correlationID string,
 	status mpi.CommandResponse_CommandStatus,
@@ -521,3 +817,22 @@ func (cp *CommandPlugin) createDataPlaneResponse(
 		RequestType: requestType,
 	}
 }
+
+// libDirFilePath returns the path of a file that the command plugin stores under the agent lib directory.
+// The auxiliary command plugin uses a separate file so that both plugins can run at the same time.
+// If no lib directory is configured an empty path is returned and nothing is persisted.
+func libDirFilePath(
+	agentConfig *config.Config,
+	commandServerType model.ServerType,
+	fileName, auxiliaryFileName string,
+) string {
+	if agentConfig.LibDir == "" {
+		return ""
+	}
+
+	if commandServerType == model.Auxiliary {
+		return filepath.Join(agentConfig.LibDir, auxiliaryFileName)
+	}
+
+	return filepath.Join(agentConfig.LibDir, fileName)
+}
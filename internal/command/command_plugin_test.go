@@ -8,7 +8,9 @@ package command
 import (
 	"bytes"
 	"context"
+	"errors"
 	"log/slog"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -17,6 +19,7 @@ import (
 	pkg "github.com/nginx/agent/v3/pkg/config"
 	"github.com/nginx/agent/v3/pkg/id"
 
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/nginx/agent/v3/internal/bus/busfakes"
@@ -53,6 +56,8 @@ func TestCommandPlugin_Subscriptions(t *testing.T) {
 			bus.InstanceHealthTopic,
 			bus.DataPlaneHealthResponseTopic,
 			bus.DataPlaneResponseTopic,
+			bus.ConnectionCreatedTopic,
+			bus.QueueConfigApplyRequestTopic,
 		},
 		subscriptions,
 	)
@@ -160,6 +165,20 @@ func TestCommandPlugin_Process(t *testing.T) {
 	require.Equal(t, 1, fakeCommandService.UpdateDataPlaneHealthCallCount())
 	require.Equal(t, 1, fakeCommandService.SendDataPlaneResponseCallCount())
 
+	configApplyRequest := &mpi.ManagementPlaneRequest{
+		MessageMeta: protos.CreateMessageMeta(),
+		Request: &mpi.ManagementPlaneRequest_ConfigApplyRequest{
+			ConfigApplyRequest: protos.CreateConfigApplyRequest(protos.FileOverview("/etc/nginx/nginx.conf", "")),
+		},
+	}
+	commandPlugin.Process(ctx, &bus.Message{
+		Topic: bus.QueueConfigApplyRequestTopic,
+		Data:  configApplyRequest,
+	})
+	require.Equal(t, 1, fakeCommandService.QueueConfigApplyRequestCallCount())
+	_, queuedRequest := fakeCommandService.QueueConfigApplyRequestArgsForCall(0)
+	assert.Equal(t, configApplyRequest, queuedRequest)
+
 	commandPlugin.Process(ctx, &bus.Message{
 		Topic: bus.ConnectionResetTopic,
 		Data:  commandPlugin.conn,
@@ -254,6 +273,39 @@ func TestCommandPlugin_monitorSubscribeChannel(t *testing.T) {
 			request:        "AgentConfigUpdateTopic",
 			configFeatures: config.DefaultFeatures(),
 		},
+		{
+			name: "Test 6: Config Validate Request",
+			managementPlaneRequest: &mpi.ManagementPlaneRequest{
+				Request: &mpi.ManagementPlaneRequest_ConfigValidateRequest{
+					ConfigValidateRequest: &mpi.ConfigValidateRequest{},
+				},
+			},
+			expectedTopic:  &bus.Message{Topic: bus.ConfigValidateRequestTopic},
+			request:        "ValidateRequest",
+			configFeatures: config.DefaultFeatures(),
+		},
+		{
+			name: "Test 7: Config Diff Request",
+			managementPlaneRequest: &mpi.ManagementPlaneRequest{
+				Request: &mpi.ManagementPlaneRequest_ConfigDiffRequest{
+					ConfigDiffRequest: &mpi.ConfigDiffRequest{},
+				},
+			},
+			expectedTopic:  &bus.Message{Topic: bus.ConfigDiffRequestTopic},
+			request:        "DiffRequest",
+			configFeatures: config.DefaultFeatures(),
+		},
+		{
+			name: "Test 8: Config History Request",
+			managementPlaneRequest: &mpi.ManagementPlaneRequest{
+				Request: &mpi.ManagementPlaneRequest_ConfigHistoryRequest{
+					ConfigHistoryRequest: &mpi.ConfigHistoryRequest{},
+				},
+			},
+			expectedTopic:  &bus.Message{Topic: bus.ConfigHistoryRequestTopic},
+			request:        "HistoryRequest",
+			configFeatures: config.DefaultFeatures(),
+		},
 	}
 
 	for _, test := range tests {
@@ -299,6 +351,15 @@ func TestCommandPlugin_monitorSubscribeChannel(t *testing.T) {
 			case "AgentConfigUpdateTopic":
 				assert.True(tt, ok)
 				require.NotNil(tt, mp.GetUpdateAgentConfigRequest())
+			case "ValidateRequest":
+				assert.True(tt, ok)
+				require.NotNil(tt, mp.GetConfigValidateRequest())
+			case "DiffRequest":
+				assert.True(tt, ok)
+				require.NotNil(tt, mp.GetConfigDiffRequest())
+			case "HistoryRequest":
+				assert.True(tt, ok)
+				require.NotNil(tt, mp.GetConfigHistoryRequest())
 			}
 		})
 	}
@@ -353,6 +414,48 @@ func TestCommandPlugin_FeatureDisabled(t *testing.T) {
 			request:        "APIActionRequest",
 			configFeatures: config.DefaultFeatures(),
 		},
+		{
+			name: "Test 4: Config Validate Request",
+			managementPlaneRequest: &mpi.ManagementPlaneRequest{
+				Request: &mpi.ManagementPlaneRequest_ConfigValidateRequest{
+					ConfigValidateRequest: &mpi.ConfigValidateRequest{},
+				},
+			},
+			expectedLog: "Configuration feature disabled. Unable to process config validate request",
+			request:     "ValidateRequest",
+			configFeatures: []string{
+				pkg.FeatureMetrics,
+				pkg.FeatureFileWatcher,
+			},
+		},
+		{
+			name: "Test 5: Config Diff Request",
+			managementPlaneRequest: &mpi.ManagementPlaneRequest{
+				Request: &mpi.ManagementPlaneRequest_ConfigDiffRequest{
+					ConfigDiffRequest: &mpi.ConfigDiffRequest{},
+				},
+			},
+			expectedLog: "Configuration feature disabled. Unable to process config diff request",
+			request:     "DiffRequest",
+			configFeatures: []string{
+				pkg.FeatureMetrics,
+				pkg.FeatureFileWatcher,
+			},
+		},
+		{
+			name: "Test 6: Config History Request",
+			managementPlaneRequest: &mpi.ManagementPlaneRequest{
+				Request: &mpi.ManagementPlaneRequest_ConfigHistoryRequest{
+					ConfigHistoryRequest: &mpi.ConfigHistoryRequest{},
+				},
+			},
+			expectedLog: "Configuration feature disabled. Unable to process config history request",
+			request:     "HistoryRequest",
+			configFeatures: []string{
+				pkg.FeatureMetrics,
+				pkg.FeatureFileWatcher,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -440,3 +543,175 @@ func Test_createDataPlaneResponse(t *testing.T) {
 	assert.Equal(t, expected.GetCommandResponse(), result.GetCommandResponse())
 	assert.Equal(t, expected.GetMessageMeta().GetCorrelationId(), result.GetMessageMeta().GetCorrelationId())
 }
+
+func TestCommandPlugin_CommandStatusRequest(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fakeCommandService := &commandfakes.FakeCommandService{}
+	messagePipe := busfakes.NewFakeMessagePipe()
+
+	agentConfig := types.AgentConfig()
+	agentConfig.LibDir = t.TempDir()
+
+	commandPlugin := NewCommandPlugin(agentConfig, &grpcfakes.FakeGrpcConnectionInterface{}, model.Command)
+	err := commandPlugin.Init(ctx, messagePipe)
+	require.NoError(t, err)
+	commandPlugin.commandService = fakeCommandService
+	defer commandPlugin.Close(ctx)
+
+	go commandPlugin.monitorSubscribeChannel(ctx)
+
+	commandStatusRequest := func(correlationID string) *mpi.ManagementPlaneRequest {
+		return &mpi.ManagementPlaneRequest{
+			MessageMeta: &mpi.MessageMeta{
+				MessageId:     id.GenerateMessageID(),
+				CorrelationId: "status-correlation-id",
+				Timestamp:     timestamppb.Now(),
+			},
+			Request: &mpi.ManagementPlaneRequest_CommandStatusRequest{
+				CommandStatusRequest: &mpi.CommandStatusRequest{CorrelationId: correlationID},
+			},
+		}
+	}
+
+	lastResponse := func(expectedCallCount int) *mpi.DataPlaneResponse {
+		assert.Eventually(
+			t,
+			func() bool { return fakeCommandService.SendDataPlaneResponseCallCount() == expectedCallCount },
+			2*time.Second,
+			10*time.Millisecond,
+		)
+		_, response := fakeCommandService.SendDataPlaneResponseArgsForCall(expectedCallCount - 1)
+
+		return response
+	}
+
+	// Unknown command
+	commandPlugin.subscribeChannel <- commandStatusRequest("apply-correlation-id")
+	response := lastResponse(1)
+	assert.Equal(t, mpi.CommandResponse_COMMAND_STATUS_FAILURE, response.GetCommandResponse().GetStatus())
+	assert.Equal(t, mpi.DataPlaneResponse_COMMAND_STATUS_REQUEST, response.GetRequestType())
+	assert.Equal(t, "apply-correlation-id", response.GetMessageMeta().GetCorrelationId())
+
+	// Command in progress
+	commandPlugin.subscribeChannel <- &mpi.ManagementPlaneRequest{
+		MessageMeta: &mpi.MessageMeta{CorrelationId: "apply-correlation-id"},
+		Request: &mpi.ManagementPlaneRequest_ConfigApplyRequest{
+			ConfigApplyRequest: &mpi.ConfigApplyRequest{},
+		},
+	}
+	assert.Eventually(
+		t,
+		func() bool { return len(messagePipe.Messages()) == 1 },
+		2*time.Second,
+		10*time.Millisecond,
+	)
+
+	commandPlugin.processDataPlaneResponse(ctx, &bus.Message{
+		Topic: bus.DataPlaneResponseTopic,
+		Data: commandPlugin.createDataPlaneResponse(
+			"apply-correlation-id",
+			mpi.CommandResponse_COMMAND_STATUS_ERROR,
+			mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST,
+			"Config apply failed, rolling back config",
+			"reload failed",
+		),
+	})
+
+	commandPlugin.subscribeChannel <- commandStatusRequest("apply-correlation-id")
+	response = lastResponse(3)
+	assert.Equal(t, mpi.CommandResponse_COMMAND_STATUS_IN_PROGRESS, response.GetCommandResponse().GetStatus())
+	assert.Equal(t, "Config apply failed, rolling back config", response.GetCommandResponse().GetMessage())
+	assert.Equal(t, mpi.DataPlaneResponse_COMMAND_STATUS_REQUEST, response.GetRequestType())
+
+	// Completed command, falling back to the correlation ID of the message meta
+	finalResponse := commandPlugin.createDataPlaneResponse(
+		"apply-correlation-id",
+		mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+		mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST,
+		"Config apply failed, rollback successful",
+		"reload failed",
+	)
+	commandPlugin.processDataPlaneResponse(ctx, &bus.Message{Topic: bus.DataPlaneResponseTopic, Data: finalResponse})
+
+	statusRequest := commandStatusRequest("")
+	statusRequest.MessageMeta.CorrelationId = "apply-correlation-id"
+	commandPlugin.subscribeChannel <- statusRequest
+	response = lastResponse(5)
+	assert.True(t, proto.Equal(finalResponse, response))
+}
+
+func TestCommandPlugin_recordRequest(t *testing.T) {
+	ctx := context.Background()
+
+	agentConfig := types.AgentConfig()
+	agentConfig.LibDir = t.TempDir()
+
+	commandPlugin := NewCommandPlugin(agentConfig, &grpcfakes.FakeGrpcConnectionInterface{}, model.Command)
+	require.NoError(t, commandPlugin.Init(ctx, busfakes.NewFakeMessagePipe()))
+	defer commandPlugin.Close(ctx)
+
+	commandPlugin.recordRequest(ctx, &mpi.ManagementPlaneRequest{
+		MessageMeta: &mpi.MessageMeta{CorrelationId: "status-correlation-id"},
+		Request:     &mpi.ManagementPlaneRequest_StatusRequest{StatusRequest: &mpi.StatusRequest{}},
+	})
+	commandPlugin.recordRequest(ctx, &mpi.ManagementPlaneRequest{
+		MessageMeta: &mpi.MessageMeta{CorrelationId: "apply-correlation-id"},
+		Request:     &mpi.ManagementPlaneRequest_ConfigApplyRequest{ConfigApplyRequest: &mpi.ConfigApplyRequest{}},
+	})
+
+	assert.NotContains(t, commandPlugin.journal.index, "status-correlation-id")
+	assert.Contains(t, commandPlugin.journal.index, "apply-correlation-id")
+}
+
+func TestCommandPlugin_OutboundSpool(t *testing.T) {
+	ctx := context.Background()
+	messagePipe := busfakes.NewFakeMessagePipe()
+	fakeCommandService := &commandfakes.FakeCommandService{}
+
+	agentConfig := types.AgentConfig()
+	agentConfig.LibDir = t.TempDir()
+	agentConfig.Client.Spool = &config.Spool{MaxSize: 1024 * 1024, MaxAge: time.Hour}
+
+	commandPlugin := NewCommandPlugin(agentConfig, &grpcfakes.FakeGrpcConnectionInterface{}, model.Command)
+	err := commandPlugin.Init(ctx, messagePipe)
+	require.NoError(t, err)
+	defer commandPlugin.Close(ctx)
+	commandPlugin.commandService = fakeCommandService
+
+	// Health reports are stored per instance
+	instanceCount := len(protos.InstanceHealths())
+
+	// Disconnected, messages are stored in the spool
+	fakeCommandService.IsConnectedReturns(false)
+
+	commandPlugin.Process(ctx, &bus.Message{Topic: bus.DataPlaneResponseTopic, Data: protos.OKDataPlaneResponse()})
+	commandPlugin.Process(ctx, &bus.Message{Topic: bus.InstanceHealthTopic, Data: protos.InstanceHealths()})
+	commandPlugin.Process(ctx, &bus.Message{Topic: bus.InstanceHealthTopic, Data: protos.InstanceHealths()})
+
+	assert.Equal(t, 0, fakeCommandService.SendDataPlaneResponseCallCount())
+	assert.Equal(t, 0, fakeCommandService.UpdateDataPlaneHealthCallCount())
+	assert.Equal(t, 1+instanceCount, commandPlugin.spool.Len())
+	assert.FileExists(t, filepath.Join(agentConfig.LibDir, outboundSpoolFileName))
+
+	// Connected again, the spool is drained in order
+	fakeCommandService.IsConnectedReturns(true)
+
+	commandPlugin.Process(ctx, &bus.Message{Topic: bus.ConnectionCreatedTopic})
+
+	assert.Equal(t, 0, commandPlugin.spool.Len())
+	assert.Equal(t, 1, fakeCommandService.SendDataPlaneResponseCallCount())
+	assert.Equal(t, instanceCount, fakeCommandService.UpdateDataPlaneHealthCallCount())
+
+	// A failed send stores the message in the spool and it is sent before the next message
+	fakeCommandService.SendDataPlaneResponseReturnsOnCall(1, errors.New("connection lost"))
+
+	commandPlugin.Process(ctx, &bus.Message{Topic: bus.DataPlaneResponseTopic, Data: protos.OKDataPlaneResponse()})
+	assert.Equal(t, 1, commandPlugin.spool.Len())
+
+	commandPlugin.Process(ctx, &bus.Message{Topic: bus.InstanceHealthTopic, Data: protos.InstanceHealths()})
+	assert.Equal(t, 0, commandPlugin.spool.Len())
+	assert.Equal(t, 3, fakeCommandService.SendDataPlaneResponseCallCount())
+	assert.Equal(t, instanceCount+1, fakeCommandService.UpdateDataPlaneHealthCallCount())
+}
@@ -12,6 +12,7 @@ import (
 	"log/slog"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/cenkalti/backoff/v7"
 
@@ -39,7 +40,10 @@ type (
 		connectionResetInProgress    *atomic.Bool
 		subscribeChannel             chan *mpi.ManagementPlaneRequest
 		configApplyRequestQueue      map[string][]*mpi.ManagementPlaneRequest // key is the instance ID
+		lastConfigApplyTimes         map[string]time.Time                     // key is the instance ID
 		resource                     *mpi.Resource
+		subscribeIndex               *subscribeIndex
+		subscribeStreamCreated       func(ctx context.Context)
 		subscribeClientMutex         sync.Mutex
 		configApplyRequestQueueMutex sync.Mutex
 		resourceMutex                sync.Mutex
@@ -52,7 +56,13 @@ func NewCommandService(
 	commandServiceClient mpi.CommandServiceClient,
 	agentConfig *config.Config,
 	subscribeChannel chan *mpi.ManagementPlaneRequest,
+	subscribeIndexPath string,
 ) *CommandService {
+	index := newSubscribeIndex(subscribeIndexPath)
+	if err := index.Load(); err != nil {
+		slog.Warn("Unable to load subscribe index", "error", err)
+	}
+
 	return &CommandService{
 		commandServiceClient:      commandServiceClient,
 		agentConfig:               agentConfig,
@@ -60,10 +70,18 @@ func NewCommandService(
 		connectionResetInProgress: &atomic.Bool{},
 		subscribeChannel:          subscribeChannel,
 		configApplyRequestQueue:   make(map[string][]*mpi.ManagementPlaneRequest),
+		lastConfigApplyTimes:      make(map[string]time.Time),
 		resource:                  &mpi.Resource{},
+		subscribeIndex:            index,
 	}
 }
 
+// OnSubscribeStreamCreated registers a callback that is called every time the Subscribe stream is (re-)established.
+// It must be called before the command service subscribes to the Management Plane.
+func (cs *CommandService) OnSubscribeStreamCreated(callback func(ctx context.Context)) {
+	cs.subscribeStreamCreated = callback
+}
+
 func (cs *CommandService) IsConnected() bool {
 	return cs.isConnected.Load()
 }
@@ -178,11 +196,14 @@ func (cs *CommandService) SendDataPlaneResponse(ctx context.Context, response *m
 		return err
 	}
 
-	return backoffHelpers.WaitUntil(
+	err = backoffHelpers.WaitUntil(
 		backOffCtx,
 		cfg.Client.Backoff,
 		cs.sendDataPlaneResponseCallback(ctx, response),
 	)
+	cs.completeResponse(ctx, response, err)
+
+	return err
 }
 
 func (cs *CommandService) Reconfigure(ctx context.Context, agentConfig *config.Config) error {
@@ -359,49 +380,135 @@ func (cs *CommandService) handleConfigApplyResponse(
 	cs.configApplyRequestQueue[instanceID] = cs.configApplyRequestQueue[instanceID][indexOfConfigApplyRequest+1:]
 	slog.DebugContext(ctx, "Removed config apply requests from queue", "queue", cs.configApplyRequestQueue[instanceID])
 
-	var nextPendingRequest *mpi.ManagementPlaneRequest
-	if len(cs.configApplyRequestQueue[instanceID]) > 0 {
-		nextPendingRequest = cs.configApplyRequestQueue[instanceID][0]
-	}
+	cs.lastConfigApplyTimes[instanceID] = time.Now()
+	hasPendingRequests := len(cs.configApplyRequestQueue[instanceID]) > 0
 
 	cs.configApplyRequestQueueMutex.Unlock()
 
 	// Send responses for the earlier queued requests outside the lock.
-	cfg := cs.config()
 	for _, req := range requestsToRespond {
-		newResponse := &mpi.DataPlaneResponse{
-			MessageMeta: &mpi.MessageMeta{
-				MessageId:     id.GenerateMessageID(),
-				CorrelationId: req.GetMessageMeta().GetCorrelationId(),
-				Timestamp:     timestamppb.Now(),
-			},
-			CommandResponse: response.GetCommandResponse(),
-			InstanceId:      instanceID,
-			RequestType:     response.GetRequestType(),
+		err := cs.sendQueuedConfigApplyResponse(ctx, req, response.GetCommandResponse(), response.GetRequestType())
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to send data plane response", "error", err)
+
+			return err
 		}
+	}
 
-		slog.DebugContext(ctx, "Sending data plane response for queued config apply request", "response", newResponse)
-		backOffCtx, backoffCancel := context.WithTimeout(ctx, cfg.Client.Backoff.MaxElapsedTime)
+	if hasPendingRequests {
+		cs.sendNextConfigApplyRequest(ctx, instanceID)
+	}
 
-		err := backoffHelpers.WaitUntil(
-			backOffCtx,
-			cfg.Client.Backoff,
-			cs.sendDataPlaneResponseCallback(ctx, newResponse),
-		)
-		backoffCancel()
+	return nil
+}
+
+// sendNextConfigApplyRequest sends the config apply request at the front of the queue of an instance.
+// If reload coalescing is enabled, the request is delayed until the minimum interval since the last config apply
+// of the instance has passed, and only the newest queued request is sent. The requests it supersedes are
+// responded to without being applied.
+func (cs *CommandService) sendNextConfigApplyRequest(ctx context.Context, instanceID string) {
+	reloadCoalescing := cs.reloadCoalescing()
+
+	cs.configApplyRequestQueueMutex.Lock()
+
+	queue := cs.configApplyRequestQueue[instanceID]
+	if len(queue) == 0 {
+		cs.configApplyRequestQueueMutex.Unlock()
+
+		return
+	}
+
+	var supersededRequests []*mpi.ManagementPlaneRequest
+
+	if reloadCoalescing != nil {
+		lastConfigApplyTime, ok := cs.lastConfigApplyTimes[instanceID]
+		if wait := time.Until(lastConfigApplyTime.Add(reloadCoalescing.MinInterval)); ok && wait > 0 {
+			cs.configApplyRequestQueueMutex.Unlock()
+
+			slog.DebugContext(ctx, "Delaying config apply request until the minimum reload interval has passed",
+				"instance_id", instanceID, "delay", wait)
+			// The delayed request must be sent even if the context is cancelled in the meantime, for example
+			// because the Subscribe stream is re-established. Otherwise the request stays at the front of the queue
+			// and blocks every later config apply request of the instance.
+			delayCtx := context.WithoutCancel(ctx)
+			time.AfterFunc(wait, func() {
+				cs.sendNextConfigApplyRequest(delayCtx, instanceID)
+			})
+
+			return
+		}
+
+		supersededRequests = queue[:len(queue)-1]
+		queue = queue[len(queue)-1:]
+		cs.configApplyRequestQueue[instanceID] = queue
+	}
 
+	nextRequest := queue[0]
+
+	cs.configApplyRequestQueueMutex.Unlock()
+
+	for _, request := range supersededRequests {
+		correlationID := nextRequest.GetMessageMeta().GetCorrelationId()
+		slog.InfoContext(ctx, "Skipping config apply request, a newer config apply request is queued",
+			"correlation_id", request.GetMessageMeta().GetCorrelationId(), "superseded_by", correlationID)
+
+		err := cs.sendQueuedConfigApplyResponse(ctx, request, &mpi.CommandResponse{
+			Status:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+			Message: "Config apply superseded",
+			Error:   "superseded by " + correlationID,
+		}, mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST)
 		if err != nil {
 			slog.ErrorContext(ctx, "Failed to send data plane response", "error", err)
-
-			return err
 		}
 	}
 
-	if nextPendingRequest != nil && !cs.connectionResetInProgress.Load() {
-		cs.subscribeChannel <- nextPendingRequest
+	if !cs.connectionResetInProgress.Load() {
+		cs.subscribeChannel <- nextRequest
 	}
+}
 
-	return nil
+// sendQueuedConfigApplyResponse responds to a queued config apply request that was not sent to be applied.
+func (cs *CommandService) sendQueuedConfigApplyResponse(
+	ctx context.Context,
+	request *mpi.ManagementPlaneRequest,
+	commandResponse *mpi.CommandResponse,
+	requestType mpi.DataPlaneResponse_RequestType,
+) error {
+	cfg := cs.config()
+	newResponse := &mpi.DataPlaneResponse{
+		MessageMeta: &mpi.MessageMeta{
+			MessageId:     id.GenerateMessageID(),
+			CorrelationId: request.GetMessageMeta().GetCorrelationId(),
+			Timestamp:     timestamppb.Now(),
+		},
+		CommandResponse: commandResponse,
+		InstanceId:      request.GetConfigApplyRequest().GetOverview().GetConfigVersion().GetInstanceId(),
+		RequestType:     requestType,
+	}
+
+	slog.DebugContext(ctx, "Sending data plane response for queued config apply request", "response", newResponse)
+	backOffCtx, backoffCancel := context.WithTimeout(ctx, cfg.Client.Backoff.MaxElapsedTime)
+	defer backoffCancel()
+
+	err := backoffHelpers.WaitUntil(
+		backOffCtx,
+		cfg.Client.Backoff,
+		cs.sendDataPlaneResponseCallback(ctx, newResponse),
+	)
+	cs.completeResponse(ctx, newResponse, err)
+
+	return err
+}
+
+// reloadCoalescing returns the reload coalescing config, or nil if reload coalescing is disabled.
+func (cs *CommandService) reloadCoalescing() *config.NginxReloadCoalescing {
+	cfg := cs.config()
+	if cfg.DataPlaneConfig == nil || cfg.DataPlaneConfig.Nginx == nil ||
+		cfg.DataPlaneConfig.Nginx.ReloadCoalescing == nil || !cfg.DataPlaneConfig.Nginx.ReloadCoalescing.Enabled {
+		return nil
+	}
+
+	return cfg.DataPlaneConfig.Nginx.ReloadCoalescing
 }
 
 // Retry callback for sending a data plane health status to the Management Plane.
@@ -450,6 +557,7 @@ func (cs *CommandService) receiveCallback(ctx context.Context) func() error {
 
 		cs.subscribeClientMutex.Lock()
 
+		streamCreated := false
 		if cs.subscribeClient == nil {
 			if cs.commandServiceClient == nil {
 				cs.subscribeClientMutex.Unlock()
@@ -469,11 +577,17 @@ func (cs *CommandService) receiveCallback(ctx context.Context) func() error {
 
 				return errors.New("subscribe service client not initialized yet")
 			}
+
+			streamCreated = true
 		}
 
 		localClient := cs.subscribeClient
 		cs.subscribeClientMutex.Unlock()
 
+		if streamCreated && cs.subscribeStreamCreated != nil {
+			go cs.subscribeStreamCreated(ctx)
+		}
+
 		request, recvError := localClient.Recv()
 		if recvError != nil {
 			cs.subscribeClientMutex.Lock()
@@ -483,19 +597,129 @@ func (cs *CommandService) receiveCallback(ctx context.Context) func() error {
 			return cs.handleSubscribeError(ctx, recvError, "receive message from subscribe stream")
 		}
 
+		if !cs.receiveSubscribeIndex(ctx, request) {
+			cs.acknowledgeRequests(ctx, request.GetMessageMeta().GetCorrelationId(), requestType(request))
+
+			return nil
+		}
+
 		if cs.isValidRequest(ctx, request) {
 			switch request.GetRequest().(type) {
 			case *mpi.ManagementPlaneRequest_ConfigApplyRequest:
-				cs.queueConfigApplyRequests(ctx, request)
+				cs.QueueConfigApplyRequest(ctx, request)
 			default:
 				cs.subscribeChannel <- request
 			}
 		}
 
+		if isProcessedOnReceipt(request) {
+			cs.completeRequest(ctx, request.GetMessageMeta().GetCorrelationId(), requestType(request), nil)
+		}
+
 		return nil
 	}
 }
 
+// receiveSubscribeIndex records the index of a management plane request as received and returns false if the
+// request was already processed or is still being processed. Requests without an index are always processed.
+// Indexes do not need to be sequential, so a gap in the received indexes is only reported.
+func (cs *CommandService) receiveSubscribeIndex(ctx context.Context, request *mpi.ManagementPlaneRequest) bool {
+	index := request.GetMessageMeta().GetIndex()
+	if index == 0 {
+		return true
+	}
+
+	isNewRequest, previousIndex := cs.subscribeIndex.Receive(index, request.GetMessageMeta().GetCorrelationId())
+	if !isNewRequest {
+		slog.InfoContext(
+			ctx,
+			"Dropping duplicate management plane request",
+			"index", index,
+			"last_index", cs.subscribeIndex.LastIndex(),
+		)
+
+		return false
+	}
+
+	if previousIndex > 0 && index != previousIndex+1 {
+		slog.WarnContext(
+			ctx,
+			"Gap detected in management plane request indexes",
+			"index", index,
+			"previous_index", previousIndex,
+		)
+	}
+
+	return true
+}
+
+// completeRequest records the management plane request with the correlation ID as processed once its final
+// data plane response was sent, and acknowledges it. If the response could not be sent, the request is not
+// acknowledged, so the Management Plane redelivers it.
+func (cs *CommandService) completeRequest(
+	ctx context.Context,
+	correlationID string,
+	requestType mpi.DataPlaneResponse_RequestType,
+	sendErr error,
+) {
+	if sendErr != nil {
+		cs.subscribeIndex.Fail(correlationID)
+
+		return
+	}
+
+	advanced, err := cs.subscribeIndex.Complete(correlationID)
+	if err != nil {
+		slog.WarnContext(ctx, "Unable to persist subscribe index", "last_index", cs.subscribeIndex.LastIndex(),
+			"error", err)
+	}
+
+	if advanced {
+		cs.acknowledgeRequests(ctx, correlationID, requestType)
+	}
+}
+
+// completeResponse completes the management plane request that a data plane response was sent for,
+// if it is the final response of the request.
+func (cs *CommandService) completeResponse(ctx context.Context, response *mpi.DataPlaneResponse, sendErr error) {
+	if response.GetRequestType() == mpi.DataPlaneResponse_COMMAND_STATUS_REQUEST ||
+		!isFinalCommandStatus(response.GetCommandResponse().GetStatus()) {
+		return
+	}
+
+	cs.completeRequest(ctx, response.GetMessageMeta().GetCorrelationId(), response.GetRequestType(), sendErr)
+}
+
+// acknowledgeRequests lets the Management Plane know that every request up to and including the last processed
+// index can be removed from its queue. If the acknowledgement fails, the next acknowledgement covers the same
+// requests, and a redelivered request is dropped as a duplicate and acknowledged again.
+func (cs *CommandService) acknowledgeRequests(
+	ctx context.Context,
+	correlationID string,
+	requestType mpi.DataPlaneResponse_RequestType,
+) {
+	ack := &mpi.DataPlaneResponse{
+		MessageMeta: &mpi.MessageMeta{
+			MessageId:     id.GenerateMessageID(),
+			CorrelationId: correlationID,
+			Timestamp:     timestamppb.Now(),
+		},
+		CommandResponse: &mpi.CommandResponse{
+			Status:  mpi.CommandResponse_COMMAND_STATUS_OK,
+			Message: "Management plane requests acknowledged",
+		},
+		RequestType: requestType,
+		AckIndex:    cs.subscribeIndex.LastIndex(),
+	}
+
+	slog.DebugContext(ctx, "Acknowledging management plane requests", "ack_index", ack.GetAckIndex())
+
+	err := cs.sendDataPlaneResponseCallback(ctx, ack)()
+	if err != nil {
+		slog.WarnContext(ctx, "Unable to acknowledge management plane requests", "error", err)
+	}
+}
+
 func (cs *CommandService) handleSubscribeError(ctx context.Context, err error, errorMsg string) error {
 	cs.isConnected.Store(false)
 
@@ -510,7 +734,9 @@ func (cs *CommandService) handleSubscribeError(ctx context.Context, err error, e
 	return err
 }
 
-func (cs *CommandService) queueConfigApplyRequests(ctx context.Context, request *mpi.ManagementPlaneRequest) {
+// QueueConfigApplyRequest adds a config apply request to the queue of its instance. Config apply requests of an
+// instance are applied one at a time, and are subject to reload coalescing.
+func (cs *CommandService) QueueConfigApplyRequest(ctx context.Context, request *mpi.ManagementPlaneRequest) {
 	cs.configApplyRequestQueueMutex.Lock()
 
 	instanceID := request.GetConfigApplyRequest().GetOverview().GetConfigVersion().GetInstanceId()
@@ -519,7 +745,7 @@ func (cs *CommandService) queueConfigApplyRequests(ctx context.Context, request
 	cs.configApplyRequestQueueMutex.Unlock()
 
 	if shouldSend {
-		cs.subscribeChannel <- request
+		cs.sendNextConfigApplyRequest(ctx, instanceID)
 	} else {
 		slog.DebugContext(
 			ctx,
@@ -625,3 +851,47 @@ func (cs *CommandService) config() *config.Config {
 
 	return cs.agentConfig
 }
+
+// isProcessedOnReceipt returns true for management plane requests that are not answered with a final data plane
+// response carrying their own correlation ID, so they are processed as soon as they are handed over.
+func isProcessedOnReceipt(request *mpi.ManagementPlaneRequest) bool {
+	switch request.GetRequest().(type) {
+	case *mpi.ManagementPlaneRequest_ConfigApplyRequest,
+		*mpi.ManagementPlaneRequest_ConfigUploadRequest,
+		*mpi.ManagementPlaneRequest_ConfigValidateRequest,
+		*mpi.ManagementPlaneRequest_ConfigHistoryRequest,
+		*mpi.ManagementPlaneRequest_ConfigDiffRequest,
+		*mpi.ManagementPlaneRequest_HealthRequest,
+		*mpi.ManagementPlaneRequest_ActionRequest,
+		*mpi.ManagementPlaneRequest_UpdateAgentConfigRequest:
+		return false
+	default:
+		return true
+	}
+}
+
+// requestType returns the request type that data plane responses to a management plane request are sent with.
+func requestType(request *mpi.ManagementPlaneRequest) mpi.DataPlaneResponse_RequestType {
+	switch request.GetRequest().(type) {
+	case *mpi.ManagementPlaneRequest_ConfigApplyRequest:
+		return mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST
+	case *mpi.ManagementPlaneRequest_ConfigUploadRequest:
+		return mpi.DataPlaneResponse_CONFIG_UPLOAD_REQUEST
+	case *mpi.ManagementPlaneRequest_ConfigValidateRequest:
+		return mpi.DataPlaneResponse_CONFIG_VALIDATE_REQUEST
+	case *mpi.ManagementPlaneRequest_ConfigHistoryRequest:
+		return mpi.DataPlaneResponse_CONFIG_HISTORY_REQUEST
+	case *mpi.ManagementPlaneRequest_ConfigDiffRequest:
+		return mpi.DataPlaneResponse_CONFIG_DIFF_REQUEST
+	case *mpi.ManagementPlaneRequest_HealthRequest:
+		return mpi.DataPlaneResponse_HEALTH_REQUEST
+	case *mpi.ManagementPlaneRequest_ActionRequest:
+		return mpi.DataPlaneResponse_API_ACTION_REQUEST
+	case *mpi.ManagementPlaneRequest_UpdateAgentConfigRequest:
+		return mpi.DataPlaneResponse_UPDATE_AGENT_CONFIG_REQUEST
+	case *mpi.ManagementPlaneRequest_CommandStatusRequest:
+		return mpi.DataPlaneResponse_COMMAND_STATUS_REQUEST
+	default:
+		return mpi.DataPlaneResponse_UNSPECIFIED_REQUEST
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/nginx/agent/v3/internal/config"
 	"github.com/nginx/agent/v3/internal/logger"
 	"github.com/nginx/agent/v3/test/helpers"
 	"github.com/nginx/agent/v3/test/stub"
@@ -76,6 +78,42 @@ func (*FakeConfigApplySubscribeClient) Recv() (*mpi.ManagementPlaneRequest, erro
 	}, nil
 }
 
+type FakeIndexedSubscribeClient struct {
+	grpc.ClientStream
+	requests  []*mpi.ManagementPlaneRequest
+	responses []*mpi.DataPlaneResponse
+	mutex     sync.Mutex
+}
+
+func (f *FakeIndexedSubscribeClient) Send(response *mpi.DataPlaneResponse) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.responses = append(f.responses, response)
+
+	return nil
+}
+
+func (f *FakeIndexedSubscribeClient) Recv() (*mpi.ManagementPlaneRequest, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.requests) == 0 {
+		return nil, errors.New("no more requests")
+	}
+
+	request := f.requests[0]
+	f.requests = f.requests[1:]
+
+	return request, nil
+}
+
+func (f *FakeIndexedSubscribeClient) Responses() []*mpi.DataPlaneResponse {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.responses
+}
+
 func TestCommandService_receiveCallback_configApplyRequest(t *testing.T) {
 	fakeSubscribeClient := &FakeConfigApplySubscribeClient{}
 	ctx := context.Background()
@@ -90,6 +128,7 @@ func TestCommandService_receiveCallback_configApplyRequest(t *testing.T) {
 		commandServiceClient,
 		types.AgentConfig(),
 		subscribeChannel,
+		"",
 	)
 	go commandService.Subscribe(subscribeCtx)
 	defer subscribeCancel()
@@ -122,6 +161,47 @@ func TestCommandService_receiveCallback_configApplyRequest(t *testing.T) {
 	assert.Len(t, commandService.configApplyRequestQueue, 1)
 }
 
+func TestCommandService_receiveCallback_subscribeStreamCreated(t *testing.T) {
+	ctx := context.Background()
+
+	fakeSubscribeClient := &FakeIndexedSubscribeClient{
+		requests: []*mpi.ManagementPlaneRequest{
+			{Request: &mpi.ManagementPlaneRequest_HealthRequest{HealthRequest: &mpi.HealthRequest{}}},
+			{Request: &mpi.ManagementPlaneRequest_HealthRequest{HealthRequest: &mpi.HealthRequest{}}},
+		},
+	}
+	commandServiceClient := &v1fakes.FakeCommandServiceClient{}
+	commandServiceClient.SubscribeReturns(fakeSubscribeClient, nil)
+
+	commandService := NewCommandService(
+		commandServiceClient,
+		types.AgentConfig(),
+		make(chan *mpi.ManagementPlaneRequest, 3),
+		"",
+	)
+
+	streamsCreated := make(chan struct{}, 2)
+	commandService.OnSubscribeStreamCreated(func(context.Context) {
+		streamsCreated <- struct{}{}
+	})
+
+	require.NoError(t, commandService.receiveCallback(ctx)())
+	require.NoError(t, commandService.receiveCallback(ctx)())
+
+	// The callback is only called when the stream is created, not for every received request
+	assert.Eventually(t, func() bool { return len(streamsCreated) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, commandServiceClient.SubscribeCallCount())
+
+	// Receiving fails once the stream is broken, and the next receive re-establishes the stream
+	require.Error(t, commandService.receiveCallback(ctx)())
+	fakeSubscribeClient.requests = append(fakeSubscribeClient.requests, &mpi.ManagementPlaneRequest{
+		Request: &mpi.ManagementPlaneRequest_HealthRequest{HealthRequest: &mpi.HealthRequest{}},
+	})
+	require.NoError(t, commandService.receiveCallback(ctx)())
+
+	assert.Eventually(t, func() bool { return len(streamsCreated) == 2 }, time.Second, 10*time.Millisecond)
+}
+
 func TestCommandService_UpdateDataPlaneStatus(t *testing.T) {
 	ctx := context.Background()
 
@@ -134,6 +214,7 @@ func TestCommandService_UpdateDataPlaneStatus(t *testing.T) {
 		commandServiceClient,
 		types.AgentConfig(),
 		make(chan *mpi.ManagementPlaneRequest),
+		"",
 	)
 	// Fail first time since there are no other instances besides the agent
 	err := commandService.UpdateDataPlaneStatus(ctx, protos.HostResource())
@@ -170,6 +251,7 @@ func TestCommandService_UpdateDataPlaneStatusSubscribeError(t *testing.T) {
 		commandServiceClient,
 		types.AgentConfig(),
 		make(chan *mpi.ManagementPlaneRequest),
+		"",
 	)
 
 	commandService.isConnected.Store(true)
@@ -190,6 +272,7 @@ func TestCommandService_CreateConnection(t *testing.T) {
 		commandServiceClient,
 		types.AgentConfig(),
 		make(chan *mpi.ManagementPlaneRequest),
+		"",
 	)
 
 	// connection created when no nginx instance found
@@ -206,6 +289,7 @@ func TestCommandService_UpdateClient(t *testing.T) {
 		commandServiceClient,
 		types.AgentConfig(),
 		make(chan *mpi.ManagementPlaneRequest),
+		"",
 	)
 	err := commandService.UpdateClient(ctx, commandServiceClient)
 	require.NoError(t, err)
@@ -220,6 +304,7 @@ func TestCommandService_UpdateDataPlaneHealth(t *testing.T) {
 		commandServiceClient,
 		types.AgentConfig(),
 		make(chan *mpi.ManagementPlaneRequest),
+		"",
 	)
 
 	// connection not created yet
@@ -250,6 +335,7 @@ func TestCommandService_SendDataPlaneResponse(t *testing.T) {
 		commandServiceClient,
 		types.AgentConfig(),
 		make(chan *mpi.ManagementPlaneRequest),
+		"",
 	)
 
 	commandService.subscribeClientMutex.Lock()
@@ -271,6 +357,7 @@ func TestCommandService_SendDataPlaneResponse_configApplyRequest(t *testing.T) {
 		commandServiceClient,
 		types.AgentConfig(),
 		subscribeChannel,
+		"",
 	)
 
 	request1 := &mpi.ManagementPlaneRequest{
@@ -398,6 +485,152 @@ func TestCommandService_SendDataPlaneResponse_configApplyRequest(t *testing.T) {
 	wg.Wait()
 }
 
+func TestCommandService_SendDataPlaneResponse_configApplyRequest_reloadCoalescing(t *testing.T) {
+	ctx := context.Background()
+	subscribeClient := &FakeIndexedSubscribeClient{}
+	subscribeChannel := make(chan *mpi.ManagementPlaneRequest)
+
+	agentConfig := types.AgentConfig()
+	agentConfig.DataPlaneConfig.Nginx.ReloadCoalescing = &config.NginxReloadCoalescing{
+		Enabled:     true,
+		MinInterval: 200 * time.Millisecond,
+	}
+
+	commandService := NewCommandService(
+		&v1fakes.FakeCommandServiceClient{},
+		agentConfig,
+		subscribeChannel,
+		"",
+	)
+	commandService.subscribeClient = subscribeClient
+
+	configApplyRequest := func(correlationID string) *mpi.ManagementPlaneRequest {
+		return &mpi.ManagementPlaneRequest{
+			MessageMeta: &mpi.MessageMeta{
+				MessageId:     uuid.NewString(),
+				CorrelationId: correlationID,
+				Timestamp:     timestamppb.Now(),
+			},
+			Request: &mpi.ManagementPlaneRequest_ConfigApplyRequest{
+				ConfigApplyRequest: &mpi.ConfigApplyRequest{
+					Overview: &mpi.FileOverview{
+						ConfigVersion: &mpi.ConfigVersion{
+							InstanceId: "12314",
+							Version:    correlationID,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	request1 := configApplyRequest("1")
+	request4 := configApplyRequest("4")
+
+	commandService.configApplyRequestQueueMutex.Lock()
+	commandService.configApplyRequestQueue = map[string][]*mpi.ManagementPlaneRequest{
+		"12314": {
+			request1,
+			configApplyRequest("2"),
+			configApplyRequest("3"),
+			request4,
+		},
+	}
+	commandService.configApplyRequestQueueMutex.Unlock()
+
+	responseTime := time.Now()
+	err := commandService.SendDataPlaneResponse(ctx, &mpi.DataPlaneResponse{
+		MessageMeta: &mpi.MessageMeta{
+			MessageId:     uuid.NewString(),
+			CorrelationId: request1.GetMessageMeta().GetCorrelationId(),
+			Timestamp:     timestamppb.Now(),
+		},
+		CommandResponse: &mpi.CommandResponse{
+			Status:  mpi.CommandResponse_COMMAND_STATUS_OK,
+			Message: "Success",
+		},
+		InstanceId: "12314",
+	})
+	require.NoError(t, err)
+
+	// only the newest request is applied, once the minimum reload interval has passed
+	select {
+	case requestFromChannel := <-subscribeChannel:
+		assert.Equal(t, request4, requestFromChannel)
+		assert.GreaterOrEqual(t, time.Since(responseTime), 200*time.Millisecond)
+	case <-time.After(2 * time.Second):
+		t.Fatal("config apply request was not sent")
+	}
+
+	responses := subscribeClient.Responses()
+	require.Len(t, responses, 3)
+
+	for i, correlationID := range []string{"2", "3"} {
+		response := responses[i+1]
+		assert.Equal(t, correlationID, response.GetMessageMeta().GetCorrelationId())
+		assert.Equal(t, "12314", response.GetInstanceId())
+		assert.Equal(t, mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST, response.GetRequestType())
+		assert.Equal(t, mpi.CommandResponse_COMMAND_STATUS_FAILURE, response.GetCommandResponse().GetStatus())
+		assert.Equal(t, "superseded by 4", response.GetCommandResponse().GetError())
+	}
+
+	commandService.configApplyRequestQueueMutex.Lock()
+	defer commandService.configApplyRequestQueueMutex.Unlock()
+	assert.Equal(t, []*mpi.ManagementPlaneRequest{request4}, commandService.configApplyRequestQueue["12314"])
+}
+
+func TestCommandService_sendNextConfigApplyRequest_contextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	subscribeChannel := make(chan *mpi.ManagementPlaneRequest)
+
+	agentConfig := types.AgentConfig()
+	agentConfig.DataPlaneConfig.Nginx.ReloadCoalescing = &config.NginxReloadCoalescing{
+		Enabled:     true,
+		MinInterval: 200 * time.Millisecond,
+	}
+
+	commandService := NewCommandService(
+		&v1fakes.FakeCommandServiceClient{},
+		agentConfig,
+		subscribeChannel,
+		"",
+	)
+
+	request := &mpi.ManagementPlaneRequest{
+		MessageMeta: &mpi.MessageMeta{
+			MessageId:     uuid.NewString(),
+			CorrelationId: uuid.NewString(),
+			Timestamp:     timestamppb.Now(),
+		},
+		Request: &mpi.ManagementPlaneRequest_ConfigApplyRequest{
+			ConfigApplyRequest: &mpi.ConfigApplyRequest{
+				Overview: &mpi.FileOverview{
+					ConfigVersion: &mpi.ConfigVersion{
+						InstanceId: "12314",
+					},
+				},
+			},
+		},
+	}
+
+	commandService.configApplyRequestQueueMutex.Lock()
+	commandService.configApplyRequestQueue["12314"] = []*mpi.ManagementPlaneRequest{request}
+	commandService.lastConfigApplyTimes["12314"] = time.Now()
+	commandService.configApplyRequestQueueMutex.Unlock()
+
+	commandService.sendNextConfigApplyRequest(ctx, "12314")
+
+	// the context is cancelled while the request is delayed, e.g. because the Subscribe stream is re-established
+	cancel()
+
+	select {
+	case requestFromChannel := <-subscribeChannel:
+		assert.Equal(t, request, requestFromChannel)
+	case <-time.After(2 * time.Second):
+		t.Fatal("delayed config apply request was not sent after the context was cancelled")
+	}
+}
+
 func TestCommandService_isValidRequest(t *testing.T) {
 	ctx := context.Background()
 	commandServiceClient := &v1fakes.FakeCommandServiceClient{}
@@ -407,6 +640,7 @@ func TestCommandService_isValidRequest(t *testing.T) {
 		commandServiceClient,
 		types.AgentConfig(),
 		make(chan *mpi.ManagementPlaneRequest),
+		"",
 	)
 
 	commandService.subscribeClientMutex.Lock()
@@ -544,9 +778,121 @@ func TestCommandService_handleSubscribeError(t *testing.T) {
 		commandServiceClient,
 		types.AgentConfig(),
 		make(chan *mpi.ManagementPlaneRequest),
+		"",
 	)
 	require.Error(t,
 		commandService.handleSubscribeError(ctx,
 			errors.New("an error occurred when attempting to subscribe"),
 			"Testing handleSubscribeError"))
 }
+
+func TestCommandService_receiveCallback_indexedRequests(t *testing.T) {
+	ctx := context.Background()
+	indexPath := filepath.Join(t.TempDir(), subscribeIndexFileName)
+
+	healthRequest := func(index int64) *mpi.ManagementPlaneRequest {
+		return &mpi.ManagementPlaneRequest{
+			MessageMeta: &mpi.MessageMeta{
+				MessageId:     uuid.NewString(),
+				CorrelationId: uuid.NewString(),
+				Timestamp:     timestamppb.Now(),
+				Index:         index,
+			},
+			Request: &mpi.ManagementPlaneRequest_HealthRequest{HealthRequest: &mpi.HealthRequest{}},
+		}
+	}
+
+	firstRequest := healthRequest(1)
+	secondRequest := healthRequest(2)
+	commandStatusRequest := &mpi.ManagementPlaneRequest{
+		MessageMeta: &mpi.MessageMeta{
+			MessageId:     uuid.NewString(),
+			CorrelationId: uuid.NewString(),
+			Timestamp:     timestamppb.Now(),
+			Index:         3,
+		},
+		Request: &mpi.ManagementPlaneRequest_CommandStatusRequest{
+			CommandStatusRequest: &mpi.CommandStatusRequest{},
+		},
+	}
+
+	fakeSubscribeClient := &FakeIndexedSubscribeClient{
+		requests: []*mpi.ManagementPlaneRequest{
+			firstRequest,
+			secondRequest,
+			secondRequest,
+			healthRequest(0),
+		},
+	}
+
+	subscribeChannel := make(chan *mpi.ManagementPlaneRequest, 5)
+	commandService := NewCommandService(
+		&v1fakes.FakeCommandServiceClient{},
+		types.AgentConfig(),
+		subscribeChannel,
+		indexPath,
+	)
+	commandService.subscribeClient = fakeSubscribeClient
+
+	for range 4 {
+		require.NoError(t, commandService.receiveCallback(ctx)())
+	}
+
+	// The duplicate request with index 2 is dropped
+	assert.Len(t, subscribeChannel, 3)
+	assert.Equal(t, int64(1), (<-subscribeChannel).GetMessageMeta().GetIndex())
+	assert.Equal(t, int64(2), (<-subscribeChannel).GetMessageMeta().GetIndex())
+	assert.Equal(t, int64(0), (<-subscribeChannel).GetMessageMeta().GetIndex())
+
+	// The duplicate is acknowledged, but nothing was processed yet
+	responses := fakeSubscribeClient.Responses()
+	require.Len(t, responses, 1)
+	assert.Equal(t, int64(0), responses[0].GetAckIndex())
+	assert.Equal(t, secondRequest.GetMessageMeta().GetCorrelationId(), responses[0].GetMessageMeta().GetCorrelationId())
+	assert.Equal(t, mpi.DataPlaneResponse_HEALTH_REQUEST, responses[0].GetRequestType())
+	assert.Equal(t, mpi.CommandResponse_COMMAND_STATUS_OK, responses[0].GetCommandResponse().GetStatus())
+	assert.Equal(t, int64(0), commandService.subscribeIndex.LastIndex())
+
+	finalResponse := func(request *mpi.ManagementPlaneRequest) *mpi.DataPlaneResponse {
+		return &mpi.DataPlaneResponse{
+			MessageMeta: &mpi.MessageMeta{
+				MessageId:     uuid.NewString(),
+				CorrelationId: request.GetMessageMeta().GetCorrelationId(),
+				Timestamp:     timestamppb.Now(),
+			},
+			CommandResponse: &mpi.CommandResponse{
+				Status: mpi.CommandResponse_COMMAND_STATUS_OK,
+			},
+			RequestType: mpi.DataPlaneResponse_HEALTH_REQUEST,
+		}
+	}
+
+	// Request 2 is not acknowledged while request 1 is still being processed
+	require.NoError(t, commandService.SendDataPlaneResponse(ctx, finalResponse(secondRequest)))
+	require.Len(t, fakeSubscribeClient.Responses(), 2)
+	assert.Equal(t, int64(0), commandService.subscribeIndex.LastIndex())
+
+	require.NoError(t, commandService.SendDataPlaneResponse(ctx, finalResponse(firstRequest)))
+	responses = fakeSubscribeClient.Responses()
+	require.Len(t, responses, 4)
+	assert.Equal(t, int64(2), responses[3].GetAckIndex())
+	assert.Equal(t, firstRequest.GetMessageMeta().GetCorrelationId(), responses[3].GetMessageMeta().GetCorrelationId())
+	assert.Equal(t, mpi.DataPlaneResponse_HEALTH_REQUEST, responses[3].GetRequestType())
+
+	// Command status requests are acknowledged as soon as they are received
+	fakeSubscribeClient.requests = append(fakeSubscribeClient.requests, commandStatusRequest)
+	require.NoError(t, commandService.receiveCallback(ctx)())
+	responses = fakeSubscribeClient.Responses()
+	require.Len(t, responses, 5)
+	assert.Equal(t, int64(3), responses[4].GetAckIndex())
+	assert.Equal(t, mpi.DataPlaneResponse_COMMAND_STATUS_REQUEST, responses[4].GetRequestType())
+
+	// The last processed index survives a restart
+	restartedCommandService := NewCommandService(
+		&v1fakes.FakeCommandServiceClient{},
+		types.AgentConfig(),
+		make(chan *mpi.ManagementPlaneRequest),
+		indexPath,
+	)
+	assert.Equal(t, int64(3), restartedCommandService.subscribeIndex.LastIndex())
+}
@@ -34,6 +34,12 @@ type FakeCommandService struct {
 	isConnectedReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	QueueConfigApplyRequestStub        func(context.Context, *v1.ManagementPlaneRequest)
+	queueConfigApplyRequestMutex       sync.RWMutex
+	queueConfigApplyRequestArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1.ManagementPlaneRequest
+	}
 	ReconfigureStub        func(context.Context, *config.Config) error
 	reconfigureMutex       sync.RWMutex
 	reconfigureArgsForCall []struct {
@@ -221,6 +227,39 @@ func (fake *FakeCommandService) IsConnectedReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeCommandService) QueueConfigApplyRequest(arg1 context.Context, arg2 *v1.ManagementPlaneRequest) {
+	fake.queueConfigApplyRequestMutex.Lock()
+	fake.queueConfigApplyRequestArgsForCall = append(fake.queueConfigApplyRequestArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1.ManagementPlaneRequest
+	}{arg1, arg2})
+	stub := fake.QueueConfigApplyRequestStub
+	fake.recordInvocation("QueueConfigApplyRequest", []interface{}{arg1, arg2})
+	fake.queueConfigApplyRequestMutex.Unlock()
+	if stub != nil {
+		fake.QueueConfigApplyRequestStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCommandService) QueueConfigApplyRequestCallCount() int {
+	fake.queueConfigApplyRequestMutex.RLock()
+	defer fake.queueConfigApplyRequestMutex.RUnlock()
+	return len(fake.queueConfigApplyRequestArgsForCall)
+}
+
+func (fake *FakeCommandService) QueueConfigApplyRequestCalls(stub func(context.Context, *v1.ManagementPlaneRequest)) {
+	fake.queueConfigApplyRequestMutex.Lock()
+	defer fake.queueConfigApplyRequestMutex.Unlock()
+	fake.QueueConfigApplyRequestStub = stub
+}
+
+func (fake *FakeCommandService) QueueConfigApplyRequestArgsForCall(i int) (context.Context, *v1.ManagementPlaneRequest) {
+	fake.queueConfigApplyRequestMutex.RLock()
+	defer fake.queueConfigApplyRequestMutex.RUnlock()
+	argsForCall := fake.queueConfigApplyRequestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeCommandService) Reconfigure(arg1 context.Context, arg2 *config.Config) error {
 	fake.reconfigureMutex.Lock()
 	ret, specificReturn := fake.reconfigureReturnsOnCall[len(fake.reconfigureArgsForCall)]
@@ -575,6 +614,8 @@ func (fake *FakeCommandService) Invocations() map[string][][]interface{} {
 	defer fake.createConnectionMutex.RUnlock()
 	fake.isConnectedMutex.RLock()
 	defer fake.isConnectedMutex.RUnlock()
+	fake.queueConfigApplyRequestMutex.RLock()
+	defer fake.queueConfigApplyRequestMutex.RUnlock()
 	fake.reconfigureMutex.RLock()
 	defer fake.reconfigureMutex.RUnlock()
 	fake.sendDataPlaneResponseMutex.RLock()
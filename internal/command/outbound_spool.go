@@ -0,0 +1,337 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/config"
+	"github.com/nginx/agent/v3/pkg/files"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	outboundSpoolFileName          = "outbound_spool.json"
+	auxiliaryOutboundSpoolFileName = "auxiliary_outbound_spool.json"
+
+	spoolEntryTypeDataPlaneResponse = "data_plane_response"
+	spoolEntryTypeInstanceHealth    = "instance_health"
+	outboundSpoolFilePerm           = 0o600
+)
+
+type (
+	// outboundSpool is a bounded FIFO queue of messages that could not be sent to the management plane
+	// because the agent was disconnected. Health reports are coalesced per instance, since only the latest
+	// health of an instance is relevant once the agent reconnects.
+	// If a path is provided the spool is persisted to disk after every change, so messages survive a restart.
+	outboundSpool struct {
+		limits     *config.Spool
+		path       string
+		entries    []*spoolEntry
+		size       int64
+		mutex      sync.Mutex
+		drainMutex sync.Mutex
+	}
+
+	spoolEntry struct {
+		Timestamp  time.Time       `json:"timestamp"`
+		Type       string          `json:"type"`
+		InstanceID string          `json:"instance_id,omitempty"`
+		Message    json.RawMessage `json:"message"`
+	}
+
+	spoolSender interface {
+		SendDataPlaneResponse(ctx context.Context, response *mpi.DataPlaneResponse) error
+		UpdateDataPlaneHealth(ctx context.Context, instanceHealths []*mpi.InstanceHealth) error
+	}
+)
+
+func newOutboundSpool(path string, limits *config.Spool) *outboundSpool {
+	return &outboundSpool{
+		path:   path,
+		limits: limits,
+	}
+}
+
+// Enabled returns false if no spool size is configured, in which case messages are never spooled.
+func (sp *outboundSpool) Enabled() bool {
+	return sp.limits != nil && sp.limits.MaxSize > 0
+}
+
+// Len returns the number of messages in the spool.
+func (sp *outboundSpool) Len() int {
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+
+	return len(sp.entries)
+}
+
+// Load reads a previously persisted spool from disk. A missing spool file is not an error.
+func (sp *outboundSpool) Load(ctx context.Context) error {
+	if sp.path == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(sp.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to read outbound spool %s: %w", sp.path, err)
+	}
+
+	var entries []*spoolEntry
+	if err = json.Unmarshal(content, &entries); err != nil {
+		return fmt.Errorf("unable to unmarshal outbound spool %s: %w", sp.path, err)
+	}
+
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+
+	sp.entries = nil
+	sp.size = 0
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+		sp.entries = append(sp.entries, entry)
+		sp.size += int64(len(entry.Message))
+	}
+
+	sp.enforceLimits(ctx)
+
+	slog.DebugContext(ctx, "Loaded outbound spool", "path", sp.path, "entries", len(sp.entries))
+
+	return nil
+}
+
+// AddDataPlaneResponse adds a data plane response to the end of the spool.
+func (sp *outboundSpool) AddDataPlaneResponse(ctx context.Context, response *mpi.DataPlaneResponse) {
+	message, err := marshalSpoolMessage(response)
+	if err != nil {
+		slog.WarnContext(ctx, "Unable to marshal data plane response for outbound spool", "error", err)
+		return
+	}
+
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+
+	sp.add(&spoolEntry{
+		Timestamp:  time.Now().UTC(),
+		Type:       spoolEntryTypeDataPlaneResponse,
+		InstanceID: response.GetInstanceId(),
+		Message:    message,
+	})
+
+	sp.enforceLimits(ctx)
+	sp.persist(ctx)
+}
+
+// AddInstanceHealths adds the health of each instance to the end of the spool, replacing any health report
+// for the same instance that is already in the spool.
+func (sp *outboundSpool) AddInstanceHealths(ctx context.Context, instanceHealths []*mpi.InstanceHealth) {
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+
+	for _, instanceHealth := range instanceHealths {
+		message, err := marshalSpoolMessage(instanceHealth)
+		if err != nil {
+			slog.WarnContext(ctx, "Unable to marshal instance health for outbound spool", "error", err)
+			continue
+		}
+
+		sp.removeInstanceHealth(instanceHealth.GetInstanceId())
+		sp.add(&spoolEntry{
+			Timestamp:  time.Now().UTC(),
+			Type:       spoolEntryTypeInstanceHealth,
+			InstanceID: instanceHealth.GetInstanceId(),
+			Message:    message,
+		})
+	}
+
+	sp.enforceLimits(ctx)
+	sp.persist(ctx)
+}
+
+// Drain sends the spooled messages to the management plane in the order they were added. Draining stops at the
+// first message that fails to send, so the remaining messages are kept for the next attempt.
+func (sp *outboundSpool) Drain(ctx context.Context, sender spoolSender) error {
+	sp.drainMutex.Lock()
+	defer sp.drainMutex.Unlock()
+
+	for {
+		entry := sp.next(ctx)
+		if entry == nil {
+			return nil
+		}
+
+		if err := sp.send(ctx, sender, entry); err != nil {
+			return err
+		}
+
+		sp.remove(ctx, entry)
+	}
+}
+
+func (sp *outboundSpool) next(ctx context.Context) *spoolEntry {
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+
+	if sp.enforceLimits(ctx) {
+		sp.persist(ctx)
+	}
+
+	if len(sp.entries) == 0 {
+		return nil
+	}
+
+	return sp.entries[0]
+}
+
+func (sp *outboundSpool) send(ctx context.Context, sender spoolSender, entry *spoolEntry) error {
+	switch entry.Type {
+	case spoolEntryTypeDataPlaneResponse:
+		response := &mpi.DataPlaneResponse{}
+		if err := protojson.Unmarshal(entry.Message, response); err != nil {
+			slog.WarnContext(ctx, "Dropping invalid data plane response from outbound spool", "error", err)
+			return nil
+		}
+
+		slog.DebugContext(ctx, "Sending spooled data plane response", "response", response)
+
+		return sender.SendDataPlaneResponse(ctx, response)
+	case spoolEntryTypeInstanceHealth:
+		instanceHealth := &mpi.InstanceHealth{}
+		if err := protojson.Unmarshal(entry.Message, instanceHealth); err != nil {
+			slog.WarnContext(ctx, "Dropping invalid instance health from outbound spool", "error", err)
+			return nil
+		}
+
+		slog.DebugContext(ctx, "Sending spooled instance health", "instance_health", instanceHealth)
+
+		return sender.UpdateDataPlaneHealth(ctx, []*mpi.InstanceHealth{instanceHealth})
+	default:
+		slog.WarnContext(ctx, "Dropping unknown message type from outbound spool", "type", entry.Type)
+
+		return nil
+	}
+}
+
+// remove deletes a sent entry from the spool. The entry might already be gone if it was superseded by
+// a newer health report while it was being sent.
+func (sp *outboundSpool) remove(ctx context.Context, entry *spoolEntry) {
+	sp.mutex.Lock()
+	defer sp.mutex.Unlock()
+
+	for index, spooledEntry := range sp.entries {
+		if spooledEntry == entry {
+			sp.deleteEntry(index)
+			sp.persist(ctx)
+
+			return
+		}
+	}
+}
+
+func (sp *outboundSpool) removeInstanceHealth(instanceID string) {
+	for index, entry := range sp.entries {
+		if entry.Type == spoolEntryTypeInstanceHealth && entry.InstanceID == instanceID {
+			sp.deleteEntry(index)
+
+			return
+		}
+	}
+}
+
+func (sp *outboundSpool) add(entry *spoolEntry) {
+	sp.entries = append(sp.entries, entry)
+	sp.size += int64(len(entry.Message))
+}
+
+func (sp *outboundSpool) deleteEntry(index int) {
+	sp.size -= int64(len(sp.entries[index].Message))
+	sp.entries = append(sp.entries[:index], sp.entries[index+1:]...)
+}
+
+// enforceLimits drops expired messages and then the oldest messages until the spool is within its size limit.
+// It returns true if any message was dropped. Must be called with the mutex held.
+func (sp *outboundSpool) enforceLimits(ctx context.Context) bool {
+	if sp.limits == nil {
+		return false
+	}
+
+	dropped := 0
+
+	if sp.limits.MaxAge > 0 {
+		cutoff := time.Now().UTC().Add(-sp.limits.MaxAge)
+		for len(sp.entries) > 0 && sp.entries[0].Timestamp.Before(cutoff) {
+			sp.deleteEntry(0)
+			dropped++
+		}
+	}
+
+	for len(sp.entries) > 0 && sp.size > sp.limits.MaxSize {
+		sp.deleteEntry(0)
+		dropped++
+	}
+
+	if dropped > 0 {
+		slog.WarnContext(
+			ctx,
+			"Dropped messages from outbound spool, spool limits exceeded",
+			"dropped", dropped,
+			"max_size", sp.limits.MaxSize,
+			"max_age", sp.limits.MaxAge,
+		)
+	}
+
+	return dropped > 0
+}
+
+// marshalSpoolMessage marshals a message in compact form. The output of protojson is not stable and may contain
+// extra whitespace, which is removed when the spool is persisted, so the size of a message would change on reload.
+func marshalSpoolMessage(message proto.Message) (json.RawMessage, error) {
+	messageJSON, err := protojson.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	compactJSON := &bytes.Buffer{}
+	if err = json.Compact(compactJSON, messageJSON); err != nil {
+		return nil, err
+	}
+
+	return compactJSON.Bytes(), nil
+}
+
+// persist writes the spool atomically, so a crash never leaves a partially written spool behind.
+// Must be called with the mutex held.
+func (sp *outboundSpool) persist(ctx context.Context) {
+	if sp.path == "" {
+		return
+	}
+
+	spoolJSON, err := json.Marshal(sp.entries)
+	if err != nil {
+		slog.WarnContext(ctx, "Unable to marshal outbound spool", "error", err)
+		return
+	}
+
+	if err = files.WriteFileAtomically(sp.path, spoolJSON, outboundSpoolFilePerm); err != nil {
+		slog.WarnContext(ctx, "Unable to write outbound spool", "path", sp.path, "error", err)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package command
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/command/commandfakes"
+	"github.com/nginx/agent/v3/internal/config"
+	"github.com/nginx/agent/v3/test/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOutboundSpool_Enabled(t *testing.T) {
+	assert.False(t, newOutboundSpool("", nil).Enabled())
+	assert.False(t, newOutboundSpool("", &config.Spool{MaxSize: 0, MaxAge: time.Hour}).Enabled())
+	assert.True(t, newOutboundSpool("", &config.Spool{MaxSize: 1024}).Enabled())
+}
+
+func TestOutboundSpool_Drain(t *testing.T) {
+	ctx := context.Background()
+	spool := newOutboundSpool("", &config.Spool{MaxSize: 1024 * 1024, MaxAge: time.Hour})
+
+	firstResponse := createJournalTestResponse(
+		"first", mpi.CommandResponse_COMMAND_STATUS_OK, "Config apply successful",
+	)
+	secondResponse := createJournalTestResponse(
+		"second", mpi.CommandResponse_COMMAND_STATUS_FAILURE, "Config apply failed",
+	)
+
+	unhealthy := protos.UnhealthyInstanceHealth()
+	healthy := protos.HealthyInstanceHealth()
+	healthy.InstanceId = unhealthy.GetInstanceId()
+
+	spool.AddDataPlaneResponse(ctx, firstResponse)
+	spool.AddInstanceHealths(ctx, []*mpi.InstanceHealth{unhealthy})
+	spool.AddDataPlaneResponse(ctx, secondResponse)
+	// Supersedes the unhealthy report for the same instance
+	spool.AddInstanceHealths(ctx, []*mpi.InstanceHealth{healthy})
+
+	assert.Equal(t, 3, spool.Len())
+
+	fakeCommandService := &commandfakes.FakeCommandService{}
+	fakeCommandService.SendDataPlaneResponseReturnsOnCall(1, errors.New("not connected"))
+
+	err := spool.Drain(ctx, fakeCommandService)
+	require.Error(t, err)
+	assert.Equal(t, 2, spool.Len())
+	assert.Equal(t, 2, fakeCommandService.SendDataPlaneResponseCallCount())
+
+	err = spool.Drain(ctx, fakeCommandService)
+	require.NoError(t, err)
+	assert.Equal(t, 0, spool.Len())
+
+	require.Equal(t, 3, fakeCommandService.SendDataPlaneResponseCallCount())
+	_, sentResponse := fakeCommandService.SendDataPlaneResponseArgsForCall(0)
+	assert.True(t, proto.Equal(firstResponse, sentResponse))
+	_, sentResponse = fakeCommandService.SendDataPlaneResponseArgsForCall(2)
+	assert.True(t, proto.Equal(secondResponse, sentResponse))
+
+	require.Equal(t, 1, fakeCommandService.UpdateDataPlaneHealthCallCount())
+	_, sentHealths := fakeCommandService.UpdateDataPlaneHealthArgsForCall(0)
+	require.Len(t, sentHealths, 1)
+	assert.True(t, proto.Equal(healthy, sentHealths[0]))
+}
+
+func TestOutboundSpool_Limits(t *testing.T) {
+	ctx := context.Background()
+
+	response := createJournalTestResponse("correlation-id", mpi.CommandResponse_COMMAND_STATUS_OK, "OK")
+	spool := newOutboundSpool("", &config.Spool{MaxSize: 1024 * 1024})
+	spool.AddDataPlaneResponse(ctx, response)
+	responseSize := spool.size
+
+	t.Run("Test 1: Max size", func(tt *testing.T) {
+		sizeLimitedSpool := newOutboundSpool("", &config.Spool{MaxSize: 2 * responseSize})
+		for range 5 {
+			sizeLimitedSpool.AddDataPlaneResponse(ctx, response)
+		}
+
+		assert.Equal(tt, 2, sizeLimitedSpool.Len())
+		assert.LessOrEqual(tt, sizeLimitedSpool.size, 2*responseSize)
+	})
+
+	t.Run("Test 2: Max age", func(tt *testing.T) {
+		ageLimitedSpool := newOutboundSpool("", &config.Spool{MaxSize: 1024 * 1024, MaxAge: time.Minute})
+		ageLimitedSpool.AddDataPlaneResponse(ctx, response)
+		ageLimitedSpool.entries[0].Timestamp = time.Now().UTC().Add(-time.Hour)
+		ageLimitedSpool.AddDataPlaneResponse(ctx, response)
+
+		assert.Equal(tt, 1, ageLimitedSpool.Len())
+	})
+}
+
+func TestOutboundSpool_Load(t *testing.T) {
+	ctx := context.Background()
+	spoolPath := filepath.Join(t.TempDir(), outboundSpoolFileName)
+	limits := &config.Spool{MaxSize: 1024 * 1024, MaxAge: time.Hour}
+
+	spool := newOutboundSpool(spoolPath, limits)
+	require.NoError(t, spool.Load(ctx))
+	spool.AddDataPlaneResponse(ctx, protos.OKDataPlaneResponse())
+	spool.AddInstanceHealths(ctx, protos.InstanceHealths())
+
+	assert.FileExists(t, spoolPath)
+
+	loadedSpool := newOutboundSpool(spoolPath, limits)
+	require.NoError(t, loadedSpool.Load(ctx))
+	assert.Equal(t, spool.Len(), loadedSpool.Len())
+	assert.Equal(t, spool.size, loadedSpool.size)
+
+	require.NoError(t, loadedSpool.Drain(ctx, &commandfakes.FakeCommandService{}))
+
+	reloadedSpool := newOutboundSpool(spoolPath, limits)
+	require.NoError(t, reloadedSpool.Load(ctx))
+	assert.Equal(t, 0, reloadedSpool.Len())
+}
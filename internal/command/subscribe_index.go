@@ -0,0 +1,186 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/nginx/agent/v3/pkg/files"
+)
+
+const (
+	subscribeIndexFileName          = "subscribe_index.json"
+	auxiliarySubscribeIndexFileName = "auxiliary_subscribe_index.json"
+	subscribeIndexFilePerm          = 0o600
+)
+
+type (
+	// subscribeIndex tracks the indexes of management plane requests received from the Subscribe stream,
+	// so that requests redelivered by the management plane after a reconnect or restart are only processed once.
+	// A request only counts as processed once its final data plane response has been sent. Since the management
+	// plane acknowledges requests cumulatively, the last processed index only advances past a request once every
+	// request received before it was processed as well.
+	// If a path is provided the last processed index is persisted to disk, since the management plane index
+	// never resets for the lifetime of an agent.
+	subscribeIndex struct {
+		pending        map[int64]*pendingRequest // key is the request index
+		correlationIDs map[string]int64          // key is the correlation ID of a pending request
+		path           string
+		lastIndex      int64
+		highestIndex   int64
+		mutex          sync.Mutex
+	}
+
+	pendingRequest struct {
+		correlationID  string
+		completed      bool
+		responseFailed bool
+	}
+
+	subscribeIndexFile struct {
+		LastIndex int64 `json:"last_index"`
+	}
+)
+
+func newSubscribeIndex(path string) *subscribeIndex {
+	return &subscribeIndex{
+		path:           path,
+		pending:        make(map[int64]*pendingRequest),
+		correlationIDs: make(map[string]int64),
+	}
+}
+
+// Load reads the last processed index from disk. A missing index file is not an error.
+func (si *subscribeIndex) Load() error {
+	if si.path == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(si.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to read subscribe index %s: %w", si.path, err)
+	}
+
+	var indexFile subscribeIndexFile
+	if err = json.Unmarshal(content, &indexFile); err != nil {
+		return fmt.Errorf("unable to unmarshal subscribe index %s: %w", si.path, err)
+	}
+
+	si.mutex.Lock()
+	defer si.mutex.Unlock()
+	si.lastIndex = indexFile.LastIndex
+	si.highestIndex = indexFile.LastIndex
+
+	return nil
+}
+
+// LastIndex returns the index of the last processed management plane request.
+func (si *subscribeIndex) LastIndex() int64 {
+	si.mutex.Lock()
+	defer si.mutex.Unlock()
+
+	return si.lastIndex
+}
+
+// Receive records a management plane request as received but not yet processed. It returns false if the request
+// was already processed or is still being processed, meaning the request is a duplicate. A request whose final
+// response could not be sent is processed again. The highest index received before this request is also returned,
+// so that gaps in the received indexes can be reported.
+func (si *subscribeIndex) Receive(index int64, correlationID string) (isNewRequest bool, previousIndex int64) {
+	si.mutex.Lock()
+	defer si.mutex.Unlock()
+
+	previousIndex = si.highestIndex
+
+	if index <= si.lastIndex {
+		return false, previousIndex
+	}
+
+	if request, ok := si.pending[index]; ok && !request.responseFailed {
+		return false, previousIndex
+	}
+
+	si.pending[index] = &pendingRequest{correlationID: correlationID}
+	si.correlationIDs[correlationID] = index
+	si.highestIndex = max(si.highestIndex, index)
+
+	return true, previousIndex
+}
+
+// Complete records the request with the correlation ID as processed and advances the last processed index past
+// every request that is processed. It returns true if the last processed index changed.
+func (si *subscribeIndex) Complete(correlationID string) (bool, error) {
+	si.mutex.Lock()
+	defer si.mutex.Unlock()
+
+	index, ok := si.correlationIDs[correlationID]
+	if !ok {
+		return false, nil
+	}
+
+	delete(si.correlationIDs, correlationID)
+	si.pending[index].completed = true
+
+	lastIndex := si.lastIndex
+	for _, pendingIndex := range slices.Sorted(maps.Keys(si.pending)) {
+		if !si.pending[pendingIndex].completed {
+			break
+		}
+
+		si.lastIndex = pendingIndex
+		delete(si.pending, pendingIndex)
+	}
+
+	if si.lastIndex == lastIndex {
+		return false, nil
+	}
+
+	return true, si.persist()
+}
+
+// Fail records that the final response of the request with the correlation ID could not be sent. The request is
+// not processed, so its index blocks the last processed index until the management plane redelivers it.
+func (si *subscribeIndex) Fail(correlationID string) {
+	si.mutex.Lock()
+	defer si.mutex.Unlock()
+
+	index, ok := si.correlationIDs[correlationID]
+	if !ok {
+		return
+	}
+
+	delete(si.correlationIDs, correlationID)
+	si.pending[index].responseFailed = true
+}
+
+// persist writes the index atomically, so a crash never leaves a partially written index behind.
+// Must be called with the mutex held.
+func (si *subscribeIndex) persist() error {
+	if si.path == "" {
+		return nil
+	}
+
+	indexJSON, err := json.Marshal(subscribeIndexFile{LastIndex: si.lastIndex})
+	if err != nil {
+		return fmt.Errorf("unable to marshal subscribe index: %w", err)
+	}
+
+	if err = files.WriteFileAtomically(si.path, indexJSON, subscribeIndexFilePerm); err != nil {
+		return fmt.Errorf("unable to write subscribe index: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribeIndex_Complete(t *testing.T) {
+	index := newSubscribeIndex("")
+
+	isNewRequest, previousIndex := index.Receive(5, "request-5")
+	assert.True(t, isNewRequest)
+	assert.Equal(t, int64(0), previousIndex)
+
+	isNewRequest, previousIndex = index.Receive(7, "request-7")
+	assert.True(t, isNewRequest)
+	assert.Equal(t, int64(5), previousIndex)
+
+	// Requests still being processed are duplicates
+	isNewRequest, _ = index.Receive(5, "request-5")
+	assert.False(t, isNewRequest)
+
+	// The last index does not advance past a request that is still being processed
+	advanced, err := index.Complete("request-7")
+	require.NoError(t, err)
+	assert.False(t, advanced)
+	assert.Equal(t, int64(0), index.LastIndex())
+
+	advanced, err = index.Complete("request-5")
+	require.NoError(t, err)
+	assert.True(t, advanced)
+	assert.Equal(t, int64(7), index.LastIndex())
+
+	isNewRequest, _ = index.Receive(3, "request-3")
+	assert.False(t, isNewRequest)
+
+	advanced, err = index.Complete("unknown")
+	require.NoError(t, err)
+	assert.False(t, advanced)
+}
+
+func TestSubscribeIndex_Fail(t *testing.T) {
+	index := newSubscribeIndex("")
+
+	isNewRequest, _ := index.Receive(1, "request-1")
+	assert.True(t, isNewRequest)
+
+	index.Fail("request-1")
+
+	advanced, err := index.Complete("request-1")
+	require.NoError(t, err)
+	assert.False(t, advanced)
+	assert.Equal(t, int64(0), index.LastIndex())
+
+	// A request whose response could not be sent is processed again when it is redelivered
+	isNewRequest, _ = index.Receive(1, "request-1")
+	assert.True(t, isNewRequest)
+
+	advanced, err = index.Complete("request-1")
+	require.NoError(t, err)
+	assert.True(t, advanced)
+	assert.Equal(t, int64(1), index.LastIndex())
+}
+
+func TestSubscribeIndex_Load(t *testing.T) {
+	indexPath := filepath.Join(t.TempDir(), subscribeIndexFileName)
+
+	index := newSubscribeIndex(indexPath)
+	require.NoError(t, index.Load())
+	assert.Equal(t, int64(0), index.LastIndex())
+
+	index.Receive(42, "request-42")
+	_, err := index.Complete("request-42")
+	require.NoError(t, err)
+	assert.FileExists(t, indexPath)
+	assert.NoFileExists(t, indexPath+".tmp")
+
+	loadedIndex := newSubscribeIndex(indexPath)
+	require.NoError(t, loadedIndex.Load())
+	assert.Equal(t, int64(42), loadedIndex.LastIndex())
+
+	isNewRequest, _ := loadedIndex.Receive(42, "request-42")
+	assert.False(t, isNewRequest)
+}
+
+func TestSubscribeIndex_Load_Error(t *testing.T) {
+	indexPath := filepath.Join(t.TempDir(), subscribeIndexFileName)
+	require.NoError(t, os.WriteFile(indexPath, []byte("invalid"), subscribeIndexFilePerm))
+
+	index := newSubscribeIndex(indexPath)
+	require.ErrorContains(t, index.Load(), "unable to unmarshal subscribe index")
+}
@@ -156,6 +156,8 @@ func ResolveConfig() (*Config, error) {
 		DataPlaneConfig:    resolveDataPlaneConfig(),
 		Client:             resolveClient(),
 		AllowedDirectories: allowedDirs,
+		AllowedFileOwners:  viperInstance.GetStringSlice(AllowedFileOwnersKey),
+		AllowedFileGroups:  viperInstance.GetStringSlice(AllowedFileGroupsKey),
 		Collector:          collector,
 		Command:            resolveCommand(),
 		AuxiliaryCommand:   resolveAuxiliaryCommand(),
@@ -437,12 +439,30 @@ func registerFlags() {
 		"A comma-separated list of paths that you want to grant NGINX Agent read/write access to. Allowed "+
 			"directories are case sensitive")
 
+	fs.StringSlice(AllowedFileOwnersKey,
+		[]string{},
+		"A comma-separated list of user names or ids that files written by NGINX Agent may be owned by. "+
+			"If empty, the owner of files can't be changed by the management plane")
+
+	fs.StringSlice(AllowedFileGroupsKey,
+		[]string{},
+		"A comma-separated list of group names or ids that files written by NGINX Agent may belong to. "+
+			"If empty, the group of files can't be changed by the management plane")
+
 	fs.Duration(
 		InstanceWatcherMonitoringFrequencyKey,
 		DefInstanceWatcherMonitoringFrequency,
 		"How often the NGINX Agent will check for instance changes.",
 	)
 
+	fs.Bool(
+		InstanceWatcherProcessEventsKey,
+		DefInstanceWatcherProcessEvents,
+		"Detect NGINX processes starting and exiting from Linux proc connector events instead of listing all "+
+			"processes every monitoring frequency. Falls back to polling if NGINX Agent does not have the "+
+			"CAP_NET_ADMIN capability or the kernel does not support the proc connector.",
+	)
+
 	fs.Duration(
 		InstanceHealthWatcherMonitoringFrequencyKey,
 		DefInstanceHealthWatcherMonitoringFrequency,
@@ -515,6 +535,42 @@ func registerExternalDataSourceFlags(fs *flag.FlagSet) {
 		DefExternalDataSourceMaxBytes,
 		"Maximum size in bytes for external data sources.",
 	)
+	fs.Duration(
+		ExternalDataSourceMinRefreshKey,
+		DefExternalDataSourceMinRefreshInterval,
+		"The minimum interval for refreshing external data source files.",
+	)
+	fs.StringSlice(
+		ExternalDataSourceTrustedKeysKey,
+		[]string{},
+		"List of paths to PEM encoded ed25519 or ECDSA public keys that are trusted to sign external data source files.",
+	)
+	fs.String(
+		ExternalDataSourceS3EndpointKey,
+		"",
+		"The endpoint of an S3-compatible object storage server, such as MinIO, for s3:// external data sources. "+
+			"If not set, AWS S3 is used.",
+	)
+	fs.String(
+		ExternalDataSourceS3RegionKey,
+		DefExternalDataSourceS3Region,
+		"The region used to sign requests to S3 for s3:// external data sources.",
+	)
+	fs.String(
+		ExternalDataSourceS3AccessKeyIDKey,
+		"",
+		"The access key ID used to sign requests to S3 for s3:// external data sources.",
+	)
+	fs.String(
+		ExternalDataSourceS3CredentialsKey,
+		"",
+		"Path to an AWS shared credentials file used to sign requests to S3 for s3:// external data sources.",
+	)
+	fs.String(
+		ExternalDataSourceS3ProfileKey,
+		DefExternalDataSourceS3Profile,
+		"The profile in the S3 credentials file.",
+	)
 }
 
 func registerDataPlaneFlags(fs *flag.FlagSet) {
@@ -528,6 +584,60 @@ func registerDataPlaneFlags(fs *flag.FlagSet) {
 		DefTreatErrorsAsWarnings,
 		"Warning messages in the NGINX errors logs after a NGINX reload will be treated as an error.",
 	)
+	fs.Int(
+		NginxConfigHistorySizeKey,
+		DefNginxConfigHistorySize,
+		"The number of applied config versions stored per NGINX instance, that can be rolled back to. "+
+			"A value of 0 disables the config history.",
+	)
+	fs.Int64(
+		NginxFileCacheSizeKey,
+		DefNginxFileCacheSize,
+		"The max size in bytes of the local cache of file contents, shared by all NGINX instances. "+
+			"File contents of the config versions in the config history are stored in addition to the cache. "+
+			"A value of 0 disables the file cache.",
+	)
+	fs.Duration(
+		NginxProbesTimeoutKey,
+		DefNginxProbesTimeout,
+		"The timeout of each probe run against NGINX after a config apply.",
+	)
+	fs.StringSlice(
+		NginxHooksPreValidateKey,
+		[]string{},
+		"Executables run during a config apply before the NGINX config is validated.",
+	)
+	fs.StringSlice(
+		NginxHooksPreReloadKey,
+		[]string{},
+		"Executables run during a config apply before NGINX is reloaded.",
+	)
+	fs.StringSlice(
+		NginxHooksPostReloadKey,
+		[]string{},
+		"Executables run during a config apply after NGINX is reloaded.",
+	)
+	fs.StringSlice(
+		NginxHooksPostRollbackKey,
+		[]string{},
+		"Executables run after a failed config apply is rolled back.",
+	)
+	fs.Duration(
+		NginxHooksTimeoutKey,
+		DefNginxHooksTimeout,
+		"The timeout of each hook run during a config apply.",
+	)
+	fs.Bool(
+		NginxReloadCoalescingEnabledKey,
+		false,
+		"Apply only the newest of the config apply requests queued for an NGINX instance.",
+	)
+	fs.Duration(
+		NginxReloadCoalescingMinIntervalKey,
+		DefNginxReloadCoalescingMinInterval,
+		"The minimum interval between config applies and external file refreshes of an NGINX instance, "+
+			"if reload coalescing is enabled.",
+	)
 
 	fs.String(
 		NginxApiURLKey,
@@ -553,6 +663,11 @@ func registerDataPlaneFlags(fs *flag.FlagSet) {
 			"collection or error monitoring. This includes absolute paths or regex patterns",
 	)
 
+	fs.StringSlice(
+		NginxTemplateEnvAllowlistKey, []string{},
+		"A comma-separated list of environment variables that are available to templated config files",
+	)
+
 	// NGINX Reload Backoff Flags
 	fs.Duration(
 		NginxReloadBackoffInitialIntervalKey,
@@ -663,6 +778,13 @@ func registerClientFlags(fs *flag.FlagSet) {
 		"File chunk size in bytes.",
 	)
 
+	fs.String(
+		ClientGRPCFileCompressionKey,
+		DefFileCompression,
+		"Compression of the file contents sent to the management plane, either gzip or zstd. "+
+			"No compression is used if not set.",
+	)
+
 	fs.Duration(
 		ClientGRPCConnectionResetTimeoutKey,
 		DefGRPCConnectionResetTimeout,
@@ -692,6 +814,19 @@ func registerClientFlags(fs *flag.FlagSet) {
 		"Timeout value in seconds, for downloading a file during a config apply.",
 	)
 
+	// Spool Flags
+	fs.Int64(
+		ClientSpoolMaxSizeKey,
+		DefClientSpoolMaxSize,
+		"The maximum size in bytes of messages stored on disk in the lib directory while disconnected "+
+			"from the management plane. The spool is disabled by default, a value greater than 0 enables it.",
+	)
+	fs.Duration(
+		ClientSpoolMaxAgeKey,
+		DefClientSpoolMaxAge,
+		"The maximum amount of time a message is stored while disconnected from the management plane.",
+	)
+
 	// Deprecated fields
 	markFieldDeprecated(
 		fs,
@@ -1153,7 +1288,10 @@ func resolveDataPlaneConfig() *DataPlaneConfig {
 		Nginx: &NginxDataPlaneConfig{
 			ReloadMonitoringPeriod: viperInstance.GetDuration(NginxReloadMonitoringPeriodKey),
 			TreatWarningsAsErrors:  viperInstance.GetBool(NginxTreatWarningsAsErrorsKey),
+			ConfigHistorySize:      viperInstance.GetInt(NginxConfigHistorySizeKey),
+			FileCacheSize:          viperInstance.GetInt64(NginxFileCacheSizeKey),
 			ExcludeLogs:            viperInstance.GetStringSlice(NginxExcludeLogsKey),
+			TemplateEnvAllowlist:   viperInstance.GetStringSlice(NginxTemplateEnvAllowlistKey),
 			API: &NginxAPI{
 				URL:    viperInstance.GetString(NginxApiURLKey),
 				Socket: viperInstance.GetString(NginxApiSocketKey),
@@ -1166,6 +1304,19 @@ func resolveDataPlaneConfig() *DataPlaneConfig {
 				RandomizationFactor: viperInstance.GetFloat64(NginxReloadBackoffRandomizationFactorKey),
 				Multiplier:          viperInstance.GetFloat64(NginxReloadBackoffMultiplierKey),
 			},
+			Probes:    resolveNginxProbes(),
+			Instances: resolveNginxInstances(),
+			Hooks: &NginxHooks{
+				PreValidate:  viperInstance.GetStringSlice(NginxHooksPreValidateKey),
+				PreReload:    viperInstance.GetStringSlice(NginxHooksPreReloadKey),
+				PostReload:   viperInstance.GetStringSlice(NginxHooksPostReloadKey),
+				PostRollback: viperInstance.GetStringSlice(NginxHooksPostRollbackKey),
+				Timeout:      viperInstance.GetDuration(NginxHooksTimeoutKey),
+			},
+			ReloadCoalescing: &NginxReloadCoalescing{
+				Enabled:     viperInstance.GetBool(NginxReloadCoalescingEnabledKey),
+				MinInterval: viperInstance.GetDuration(NginxReloadCoalescingMinIntervalKey),
+			},
 		},
 	}
 
@@ -1176,6 +1327,60 @@ func resolveDataPlaneConfig() *DataPlaneConfig {
 	return dataPlaneConfig
 }
 
+func resolveNginxInstances() []*NginxInstance {
+	if !viperInstance.IsSet(NginxInstancesKey) {
+		return nil
+	}
+
+	var nginxInstances []*NginxInstance
+	if err := resolveMapStructure(NginxInstancesKey, &nginxInstances); err != nil {
+		slog.Error("NGINX instances not configured due to invalid configuration", "error", err)
+		return nil
+	}
+
+	validInstances := make([]*NginxInstance, 0, len(nginxInstances))
+	for _, nginxInstance := range nginxInstances {
+		if nginxInstance == nil || nginxInstance.BinaryPath == "" || nginxInstance.ConfigPath == "" ||
+			nginxInstance.PIDFile == "" {
+			slog.Error("NGINX instance not configured, binary_path, config_path and pid_file are required",
+				"instance", nginxInstance)
+
+			continue
+		}
+
+		if nginxInstance.API != nil && nginxInstance.API.Socket != "" &&
+			!strings.HasPrefix(nginxInstance.API.Socket, "unix:") {
+			nginxInstance.API.Socket = "unix:" + nginxInstance.API.Socket
+		}
+
+		validInstances = append(validInstances, nginxInstance)
+	}
+
+	return validInstances
+}
+
+func resolveNginxProbes() *NginxProbes {
+	if !viperInstance.IsSet(NginxProbesHTTPKey) && !viperInstance.IsSet(NginxProbesTCPKey) {
+		return nil
+	}
+
+	probes := &NginxProbes{
+		Timeout: viperInstance.GetDuration(NginxProbesTimeoutKey),
+	}
+
+	if err := resolveMapStructure(NginxProbesHTTPKey, &probes.HTTP); err != nil {
+		slog.Error("HTTP probes not configured due to invalid configuration", "error", err)
+		probes.HTTP = nil
+	}
+
+	if err := resolveMapStructure(NginxProbesTCPKey, &probes.TCP); err != nil {
+		slog.Error("TCP probes not configured due to invalid configuration", "error", err)
+		probes.TCP = nil
+	}
+
+	return probes
+}
+
 func resolveClient() *Client {
 	return &Client{
 		HTTP: &HTTP{
@@ -1192,6 +1397,7 @@ func resolveClient() *Client {
 			MaxMessageSendSize:        viperInstance.GetInt(ClientGRPCMaxMessageSendSizeKey),
 			MaxFileSize:               viperInstance.GetUint32(ClientGRPCMaxFileSizeKey),
 			FileChunkSize:             viperInstance.GetUint32(ClientGRPCFileChunkSizeKey),
+			FileCompression:           viperInstance.GetString(ClientGRPCFileCompressionKey),
 			ResponseTimeout:           viperInstance.GetDuration(ClientGRPCResponseTimeoutKey),
 			MaxParallelFileOperations: viperInstance.GetInt(ClientGRPCMaxParallelFileOperationsKey),
 		},
@@ -1202,6 +1408,10 @@ func resolveClient() *Client {
 			RandomizationFactor: viperInstance.GetFloat64(ClientBackoffRandomizationFactorKey),
 			Multiplier:          viperInstance.GetFloat64(ClientBackoffMultiplierKey),
 		},
+		Spool: &Spool{
+			MaxSize: viperInstance.GetInt64(ClientSpoolMaxSizeKey),
+			MaxAge:  viperInstance.GetDuration(ClientSpoolMaxAgeKey),
+		},
 		FileDownloadTimeout: viperInstance.GetDuration(ClientFileDownloadTimeoutKey),
 	}
 }
@@ -1592,6 +1802,7 @@ func resolveWatchers() *Watchers {
 	return &Watchers{
 		InstanceWatcher: InstanceWatcher{
 			MonitoringFrequency: viperInstance.GetDuration(InstanceWatcherMonitoringFrequencyKey),
+			ProcessEvents:       viperInstance.GetBool(InstanceWatcherProcessEventsKey),
 		},
 		InstanceHealthWatcher: InstanceHealthWatcher{
 			MonitoringFrequency: viperInstance.GetDuration(InstanceHealthWatcherMonitoringFrequencyKey),
@@ -1662,10 +1873,20 @@ func resolveExternalDataSource() *ExternalDataSource {
 		URL: viperInstance.GetString(ExternalDataSourceProxyUrlKey),
 	}
 	externalDataSource := &ExternalDataSource{
-		ProxyURL:         proxyURLStruct,
-		AllowedDomains:   viperInstance.GetStringSlice(ExternalDataSourceAllowDomainsKey),
-		AllowedFileTypes: viperInstance.GetStringSlice(ExternalDataSourceAllowedFileTypesKey),
-		MaxBytes:         viperInstance.GetInt64(ExternalDataSourceMaxBytesKey),
+		ProxyURL:           proxyURLStruct,
+		AllowedDomains:     viperInstance.GetStringSlice(ExternalDataSourceAllowDomainsKey),
+		AllowedFileTypes:   viperInstance.GetStringSlice(ExternalDataSourceAllowedFileTypesKey),
+		MaxBytes:           viperInstance.GetInt64(ExternalDataSourceMaxBytesKey),
+		MinRefreshInterval: viperInstance.GetDuration(ExternalDataSourceMinRefreshKey),
+		TrustedKeys:        viperInstance.GetStringSlice(ExternalDataSourceTrustedKeysKey),
+		S3: &S3DataSource{
+			Endpoint:        viperInstance.GetString(ExternalDataSourceS3EndpointKey),
+			Region:          viperInstance.GetString(ExternalDataSourceS3RegionKey),
+			AccessKeyID:     viperInstance.GetString(ExternalDataSourceS3AccessKeyIDKey),
+			SecretAccessKey: viperInstance.GetString(ExternalDataSourceS3SecretKey),
+			CredentialsFile: viperInstance.GetString(ExternalDataSourceS3CredentialsKey),
+			Profile:         viperInstance.GetString(ExternalDataSourceS3ProfileKey),
+		},
 	}
 
 	if err := validateAllowedDomains(externalDataSource.AllowedDomains); err != nil {
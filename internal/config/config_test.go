@@ -91,12 +91,17 @@ func TestRegisterFlags(t *testing.T) {
 	t.Setenv("NGINX_AGENT_DATA_PLANE_API_HOST", "example.com")
 	t.Setenv("NGINX_AGENT_DATA_PLANE_API_PORT", "9090")
 	t.Setenv("NGINX_AGENT_CLIENT_GRPC_KEEPALIVE_TIMEOUT", "10s")
+	t.Setenv("NGINX_AGENT_EXTERNAL_DATA_SOURCE_S3_SECRET_ACCESS_KEY", "secret")
 	registerFlags()
 
 	assert.Equal(t, "warn", viperInstance.GetString(LogLevelKey))
 	assert.Equal(t, "/var/log/test/agent.log", viperInstance.GetString(LogPathKey))
 	assert.Equal(t, 10*time.Second, viperInstance.GetDuration(ClientKeepAliveTimeoutKey))
 
+	// the secret access key can be set in the environment, but not as a flag
+	assert.Equal(t, "secret", viperInstance.GetString(ExternalDataSourceS3SecretKey))
+	assert.Nil(t, RootCommand.Flags().Lookup(ExternalDataSourceS3SecretKey))
+
 	checkDefaultsClientValues(t, viperInstance)
 }
 
@@ -112,6 +117,9 @@ func checkDefaultsClientValues(t *testing.T, viperInstance *viper.Viper) {
 	assert.InDelta(t, DefBackoffMultiplier, viperInstance.GetFloat64(ClientBackoffMultiplierKey), 0.01)
 	assert.Equal(t, DefBackoffMaxElapsedTime, viperInstance.GetDuration(ClientBackoffMaxElapsedTimeKey))
 
+	assert.Equal(t, int64(DefClientSpoolMaxSize), viperInstance.GetInt64(ClientSpoolMaxSizeKey))
+	assert.Equal(t, DefClientSpoolMaxAge, viperInstance.GetDuration(ClientSpoolMaxAgeKey))
+
 	assert.Equal(t, DefGRPCKeepAliveTimeout, viperInstance.GetDuration(ClientKeepAliveTimeoutKey))
 	assert.Equal(t, DefGRPCKeepAliveTime, viperInstance.GetDuration(ClientKeepAliveTimeKey))
 	assert.Equal(t, DefGRPCKeepAlivePermitWithoutStream, viperInstance.GetBool(ClientKeepAlivePermitWithoutStreamKey))
@@ -561,6 +569,9 @@ func TestClient(t *testing.T) {
 	viperInstance.Set(ClientBackoffInitialIntervalKey, expected.Backoff.InitialInterval)
 	viperInstance.Set(ClientBackoffRandomizationFactorKey, expected.Backoff.RandomizationFactor)
 
+	viperInstance.Set(ClientSpoolMaxSizeKey, expected.Spool.MaxSize)
+	viperInstance.Set(ClientSpoolMaxAgeKey, expected.Spool.MaxAge)
+
 	// root keys for sections are set appropriately
 	assert.True(t, viperInstance.IsSet(ClientGRPCMaxMessageSizeKey))
 	assert.False(t, viperInstance.IsSet(ClientGRPCMaxMessageReceiveSizeKey))
@@ -1177,6 +1188,10 @@ func agentConfig() *Config {
 				RandomizationFactor: 0.5,
 				Multiplier:          1.5,
 			},
+			Spool: &Spool{
+				MaxSize: 1024,
+				MaxAge:  time.Hour,
+			},
 		},
 		AllowedDirectories: []string{
 			"/etc/nginx", "/etc/nginx-agent", "/usr/local/etc/nginx", "/var/run/nginx", "/var/log/nginx",
@@ -1229,6 +1244,7 @@ func createConfig() *Config {
 				MaxMessageSendSize:        1048575,
 				MaxFileSize:               485753,
 				FileChunkSize:             48575,
+				FileCompression:           "zstd",
 				MaxParallelFileOperations: 10,
 				ResponseTimeout:           30 * time.Second,
 			},
@@ -1239,16 +1255,49 @@ func createConfig() *Config {
 				RandomizationFactor: 1.5,
 				Multiplier:          2.5,
 			},
+			Spool: &Spool{
+				MaxSize: 2097152,
+				MaxAge:  12 * time.Hour,
+			},
 		},
 		AllowedDirectories: []string{
 			"/etc/nginx-agent", "/etc/nginx", "/usr/local/etc/nginx", "/var/run/nginx",
 			"/usr/share/nginx/modules", "/var/log/nginx", "/configs",
 		},
+		AllowedFileOwners: []string{"nginx"},
+		AllowedFileGroups: []string{"nginx", "101"},
 		DataPlaneConfig: &DataPlaneConfig{
 			Nginx: &NginxDataPlaneConfig{
 				ExcludeLogs:            []string{"/var/log/nginx/error.log", "^/var/log/nginx/.*.log$"},
+				TemplateEnvAllowlist:   []string{"DATACENTER"},
 				ReloadMonitoringPeriod: 30 * time.Second,
 				TreatWarningsAsErrors:  true,
+				ConfigHistorySize:      5,
+				FileCacheSize:          52428800,
+				Instances: []*NginxInstance{
+					{
+						BinaryPath: "/usr/sbin/nginx",
+						ConfigPath: "/etc/nginx/nginx.conf",
+						PIDFile:    "/run/nginx.pid",
+						API:        &NginxAPI{URL: "http://127.0.0.1:8080/api"},
+					},
+				},
+				Probes: &NginxProbes{
+					Timeout: 3 * time.Second,
+					HTTP: []*NginxHTTPProbe{
+						{URL: "http://127.0.0.1:80/health", ExpectedStatus: 200, ExpectedBody: "ok"},
+					},
+					TCP: []*NginxTCPProbe{{Address: "127.0.0.1:443"}},
+				},
+				Hooks: &NginxHooks{
+					PreReload:  []string{"/etc/nginx-agent/hooks/render-geoip.sh"},
+					PostReload: []string{"/etc/nginx-agent/hooks/notify.sh"},
+					Timeout:    10 * time.Second,
+				},
+				ReloadCoalescing: &NginxReloadCoalescing{
+					Enabled:     true,
+					MinInterval: 20 * time.Second,
+				},
 				ReloadBackoff: &BackOff{
 					InitialInterval:     100 * time.Millisecond,
 					MaxInterval:         20 * time.Second,
@@ -1454,6 +1503,7 @@ func createConfig() *Config {
 		Watchers: &Watchers{
 			InstanceWatcher: InstanceWatcher{
 				MonitoringFrequency: 10 * time.Second,
+				ProcessEvents:       true,
 			},
 			InstanceHealthWatcher: InstanceHealthWatcher{
 				MonitoringFrequency: 10 * time.Second,
@@ -1476,8 +1526,16 @@ func createConfig() *Config {
 			ProxyURL: ProxyURL{
 				URL: "http://proxy.example.com",
 			},
-			AllowedDomains: []string{"example.com", "api.example.com"},
-			MaxBytes:       1048576,
+			AllowedDomains:     []string{"example.com", "api.example.com"},
+			MaxBytes:           1048576,
+			MinRefreshInterval: 30 * time.Second,
+			TrustedKeys:        []string{"/etc/nginx-agent/keys/external-files.pub"},
+			S3: &S3DataSource{
+				Endpoint:        "http://minio.example.com:9000",
+				Region:          "eu-west-1",
+				CredentialsFile: "/etc/nginx-agent/s3-credentials",
+				Profile:         "minio",
+			},
 		},
 	}
 }
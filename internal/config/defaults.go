@@ -11,10 +11,15 @@ import (
 )
 
 const (
-	DefGracefulShutdownPeriod      = 5 * time.Second
-	DefNginxReloadMonitoringPeriod = 10 * time.Second
-	DefTreatErrorsAsWarnings       = false
-	DefNginxApiTlsCa               = ""
+	DefGracefulShutdownPeriod           = 5 * time.Second
+	DefNginxReloadMonitoringPeriod      = 10 * time.Second
+	DefTreatErrorsAsWarnings            = false
+	DefNginxConfigHistorySize           = 10
+	DefNginxFileCacheSize               = 100 * 1024 * 1024 // 100MB
+	DefNginxProbesTimeout               = 5 * time.Second
+	DefNginxHooksTimeout                = 30 * time.Second
+	DefNginxReloadCoalescingMinInterval = 5 * time.Second
+	DefNginxApiTlsCa                    = ""
 
 	// Nginx Reload Backoff defaults
 	DefNginxReloadBackoffInitialInterval     = 500 * time.Millisecond
@@ -65,6 +70,7 @@ const (
 	DefMaxMessageSendSize                = 4194304 // default 4 MB
 	DefMaxFileSize                uint32 = 1048576 // 1MB
 	DefFileChunkSize              uint32 = 524288  // 0.5MB
+	DefFileCompression                   = ""      // no compression
 	DefMaxParallelFileOperations         = 5
 	DefResponseTimeout                   = 10 * time.Second
 	DefGRPCConnectionResetTimeout        = 3 * time.Minute
@@ -86,8 +92,13 @@ const (
 
 	DefClientFileDownloadTimeout = 60 * time.Second
 
+	// Client Spool defaults, the spool is disabled unless a maximum size is configured
+	DefClientSpoolMaxSize = 0
+	DefClientSpoolMaxAge  = 24 * time.Hour
+
 	// Watcher defaults
 	DefInstanceWatcherMonitoringFrequency       = 5 * time.Second
+	DefInstanceWatcherProcessEvents             = false
 	DefInstanceHealthWatcherMonitoringFrequency = 5 * time.Second
 	DefFileWatcherMonitoringFrequency           = 5 * time.Second
 
@@ -122,6 +133,10 @@ const (
 
 	DefExternalDataSourceProxyUrl = ""
 	DefExternalDataSourceMaxBytes = 100 * 1024 * 1024 // default 100MB
+
+	DefExternalDataSourceMinRefreshInterval = 1 * time.Minute
+	DefExternalDataSourceS3Region           = "us-east-1"
+	DefExternalDataSourceS3Profile          = "default"
 )
 
 func DefaultFeatures() []string {
@@ -11,6 +11,8 @@ import (
 const (
 	ClientRootKey                               = "client"
 	AllowedDirectoriesKey                       = "allowed_directories"
+	AllowedFileOwnersKey                        = "allowed_file_owners"
+	AllowedFileGroupsKey                        = "allowed_file_groups"
 	ConfigPathKey                               = "path"
 	CommandRootKey                              = "command"
 	AuxiliaryCommandRootKey                     = "auxiliary_command"
@@ -22,6 +24,7 @@ const (
 	UUIDKey                                     = "uuid"
 	FeaturesKey                                 = "features"
 	InstanceWatcherMonitoringFrequencyKey       = "watchers_instance_watcher_monitoring_frequency"
+	InstanceWatcherProcessEventsKey             = "watchers_instance_watcher_process_events"
 	InstanceHealthWatcherMonitoringFrequencyKey = "watchers_instance_health_watcher_monitoring_frequency"
 	FileWatcherKey                              = "watchers_file_watcher"
 	LibDirPathKey                               = "lib_dir"
@@ -41,6 +44,7 @@ var (
 	ClientGRPCMaxMessageSizeKey            = pre(ClientRootKey) + "grpc_max_message_size"
 	ClientGRPCMaxFileSizeKey               = pre(ClientRootKey) + "grpc_max_file_size"
 	ClientGRPCFileChunkSizeKey             = pre(ClientRootKey) + "grpc_file_chunk_size"
+	ClientGRPCFileCompressionKey           = pre(ClientRootKey) + "grpc_file_compression"
 	ClientGRPCMaxParallelFileOperationsKey = pre(ClientRootKey) + "grpc_max_parallel_file_operations"
 	ClientGRPCConnectionResetTimeoutKey    = pre(ClientRootKey) + "grpc_connection_reset_timeout"
 	ClientGRPCResponseTimeoutKey           = pre(ClientRootKey) + "grpc_response_timeout"
@@ -52,6 +56,10 @@ var (
 	ClientBackoffMultiplierKey          = pre(ClientRootKey) + "backoff_multiplier"
 	ClientFileDownloadTimeoutKey        = pre(ClientRootKey) + "file_download_timeout"
 
+	ClientSpoolKey        = pre(ClientRootKey) + "spool"
+	ClientSpoolMaxSizeKey = pre(ClientSpoolKey) + "max_size"
+	ClientSpoolMaxAgeKey  = pre(ClientSpoolKey) + "max_age"
+
 	CollectorConfigPathKey                      = pre(CollectorRootKey) + "config_path"
 	CollectorAdditionalConfigPathsKey           = pre(CollectorRootKey) + "additional_config_paths"
 	CollectorExportersKey                       = pre(CollectorRootKey) + "exporters"
@@ -132,6 +140,22 @@ var (
 
 	NginxReloadMonitoringPeriodKey           = pre(DataPlaneConfigRootKey, "nginx") + "reload_monitoring_period"
 	NginxTreatWarningsAsErrorsKey            = pre(DataPlaneConfigRootKey, "nginx") + "treat_warnings_as_errors"
+	NginxConfigHistorySizeKey                = pre(DataPlaneConfigRootKey, "nginx") + "config_history_size"
+	NginxFileCacheSizeKey                    = pre(DataPlaneConfigRootKey, "nginx") + "file_cache_size"
+	NginxInstancesKey                        = pre(DataPlaneConfigRootKey, "nginx") + "instances"
+	NginxProbesKey                           = pre(DataPlaneConfigRootKey, "nginx") + "probes"
+	NginxProbesHTTPKey                       = pre(NginxProbesKey) + "http"
+	NginxProbesTCPKey                        = pre(NginxProbesKey) + "tcp"
+	NginxProbesTimeoutKey                    = pre(NginxProbesKey) + "timeout"
+	NginxHooksKey                            = pre(DataPlaneConfigRootKey, "nginx") + "hooks"
+	NginxHooksPreValidateKey                 = pre(NginxHooksKey) + "pre_validate"
+	NginxHooksPreReloadKey                   = pre(NginxHooksKey) + "pre_reload"
+	NginxHooksPostReloadKey                  = pre(NginxHooksKey) + "post_reload"
+	NginxHooksPostRollbackKey                = pre(NginxHooksKey) + "post_rollback"
+	NginxHooksTimeoutKey                     = pre(NginxHooksKey) + "timeout"
+	NginxReloadCoalescingKey                 = pre(DataPlaneConfigRootKey, "nginx") + "reload_coalescing"
+	NginxReloadCoalescingEnabledKey          = pre(NginxReloadCoalescingKey) + "enabled"
+	NginxReloadCoalescingMinIntervalKey      = pre(NginxReloadCoalescingKey) + "min_interval"
 	NginxReloadBackoffKey                    = pre(DataPlaneConfigRootKey, "nginx") + "reload_backoff"
 	NginxReloadBackoffInitialIntervalKey     = pre(NginxReloadBackoffKey) + "initial_interval"
 	NginxReloadBackoffMaxIntervalKey         = pre(NginxReloadBackoffKey) + "max_interval"
@@ -139,6 +163,7 @@ var (
 	NginxReloadBackoffRandomizationFactorKey = pre(NginxReloadBackoffKey) + "randomization_factor"
 	NginxReloadBackoffMultiplierKey          = pre(NginxReloadBackoffKey) + "multiplier"
 	NginxExcludeLogsKey                      = pre(DataPlaneConfigRootKey, "nginx") + "exclude_logs"
+	NginxTemplateEnvAllowlistKey             = pre(DataPlaneConfigRootKey, "nginx") + "template_env_allowlist"
 	NginxApiTlsCaKey                         = pre(DataPlaneConfigRootKey, "nginx") + "api_tls_ca"
 	NginxApiURLKey                           = pre(DataPlaneConfigRootKey, "nginx") + "api_url"
 	NginxApiSocketKey                        = pre(DataPlaneConfigRootKey, "nginx") + "api_socket"
@@ -153,6 +178,15 @@ var (
 	ExternalDataSourceMaxBytesKey         = pre(ExternalDataSourceRootKey) + "max_bytes"
 	ExternalDataSourceAllowDomainsKey     = pre(ExternalDataSourceRootKey) + "allowed_domains"
 	ExternalDataSourceAllowedFileTypesKey = pre(ExternalDataSourceRootKey) + "allowed_file_types"
+	ExternalDataSourceMinRefreshKey       = pre(ExternalDataSourceRootKey) + "min_refresh_interval"
+	ExternalDataSourceTrustedKeysKey      = pre(ExternalDataSourceRootKey) + "trusted_keys"
+	ExternalDataSourceS3Key               = pre(ExternalDataSourceRootKey) + "s3"
+	ExternalDataSourceS3EndpointKey       = pre(ExternalDataSourceS3Key) + "endpoint"
+	ExternalDataSourceS3RegionKey         = pre(ExternalDataSourceS3Key) + "region"
+	ExternalDataSourceS3AccessKeyIDKey    = pre(ExternalDataSourceS3Key) + "access_key_id"
+	ExternalDataSourceS3SecretKey         = pre(ExternalDataSourceS3Key) + "secret_access_key"
+	ExternalDataSourceS3CredentialsKey    = pre(ExternalDataSourceS3Key) + "credentials_file"
+	ExternalDataSourceS3ProfileKey        = pre(ExternalDataSourceS3Key) + "profile"
 )
 
 func pre(prefixes ...string) string {
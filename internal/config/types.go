@@ -51,6 +51,8 @@ type (
 		UUID               string              `yaml:"-"`
 		LibDir             string              `yaml:"-"`
 		AllowedDirectories []string            `yaml:"allowed_directories"  mapstructure:"allowed_directories"`
+		AllowedFileOwners  []string            `yaml:"allowed_file_owners"  mapstructure:"allowed_file_owners"`
+		AllowedFileGroups  []string            `yaml:"allowed_file_groups"  mapstructure:"allowed_file_groups"`
 		Features           []string            `yaml:"features"             mapstructure:"features"`
 	}
 
@@ -67,11 +69,71 @@ type (
 		Port string `yaml:"port" mapstructure:"port"`
 	}
 	NginxDataPlaneConfig struct {
-		ReloadBackoff          *BackOff      `yaml:"reload_backoff"           mapstructure:"reload_backoff"`
-		API                    *NginxAPI     `yaml:"api"                      mapstructure:"api"`
-		ExcludeLogs            []string      `yaml:"exclude_logs"             mapstructure:"exclude_logs"`
-		ReloadMonitoringPeriod time.Duration `yaml:"reload_monitoring_period" mapstructure:"reload_monitoring_period"`
-		TreatWarningsAsErrors  bool          `yaml:"treat_warnings_as_errors" mapstructure:"treat_warnings_as_errors"`
+		ReloadBackoff          *BackOff               `yaml:"reload_backoff"           mapstructure:"reload_backoff"`
+		API                    *NginxAPI              `yaml:"api"                      mapstructure:"api"`
+		Probes                 *NginxProbes           `yaml:"probes"                   mapstructure:"probes"`
+		Hooks                  *NginxHooks            `yaml:"hooks"                    mapstructure:"hooks"`
+		Instances              []*NginxInstance       `yaml:"instances"                mapstructure:"instances"`
+		ReloadCoalescing       *NginxReloadCoalescing `yaml:"reload_coalescing"        mapstructure:"reload_coalescing"`
+		ExcludeLogs            []string               `yaml:"exclude_logs"             mapstructure:"exclude_logs"`
+		TemplateEnvAllowlist   []string               `yaml:"template_env_allowlist"   mapstructure:"template_env_allowlist"`
+		ReloadMonitoringPeriod time.Duration          `yaml:"reload_monitoring_period" mapstructure:"reload_monitoring_period"`
+		FileCacheSize          int64                  `yaml:"file_cache_size"          mapstructure:"file_cache_size"`
+		ConfigHistorySize      int                    `yaml:"config_history_size"      mapstructure:"config_history_size"`
+		TreatWarningsAsErrors  bool                   `yaml:"treat_warnings_as_errors" mapstructure:"treat_warnings_as_errors"`
+	}
+
+	// NginxReloadCoalescing applies only the newest of the config apply requests queued for an instance, and
+	// waits at least the minimum interval between config applies of an instance, so that bursts of config apply
+	// requests don't reload NGINX for every request. Refreshes of external files also wait at least the minimum
+	// interval since the last reload of the instance, and external files that become due in the meantime are
+	// refreshed with a single reload.
+	NginxReloadCoalescing struct {
+		MinInterval time.Duration `yaml:"min_interval" mapstructure:"min_interval"`
+		Enabled     bool          `yaml:"enabled"      mapstructure:"enabled"`
+	}
+
+	// NginxProbes are checks run against NGINX after a config apply has reloaded NGINX. If a probe fails,
+	// the config apply is rolled back.
+	NginxProbes struct {
+		HTTP    []*NginxHTTPProbe `yaml:"http"    mapstructure:"http"`
+		TCP     []*NginxTCPProbe  `yaml:"tcp"     mapstructure:"tcp"`
+		Timeout time.Duration     `yaml:"timeout" mapstructure:"timeout"`
+	}
+
+	// NginxHTTPProbe sends a GET request to the URL. If no expected status is set, any status below 400 passes.
+	NginxHTTPProbe struct {
+		URL            string `yaml:"url"             mapstructure:"url"`
+		ExpectedBody   string `yaml:"expected_body"   mapstructure:"expected_body"`
+		ExpectedStatus int    `yaml:"expected_status" mapstructure:"expected_status"`
+		SkipVerify     bool   `yaml:"skip_verify"     mapstructure:"skip_verify"`
+	}
+
+	// NginxHooks are executables run at phases of a config apply. The executables must be in the allowed
+	// directories. They don't inherit the environment of the agent, only PATH and the NGINX_AGENT_HOOK_PHASE,
+	// NGINX_AGENT_INSTANCE_ID, NGINX_AGENT_CORRELATION_ID and NGINX_AGENT_CHANGED_FILES variables are set.
+	NginxHooks struct {
+		PreValidate  []string      `yaml:"pre_validate"  mapstructure:"pre_validate"`
+		PreReload    []string      `yaml:"pre_reload"    mapstructure:"pre_reload"`
+		PostReload   []string      `yaml:"post_reload"   mapstructure:"post_reload"`
+		PostRollback []string      `yaml:"post_rollback" mapstructure:"post_rollback"`
+		Timeout      time.Duration `yaml:"timeout"       mapstructure:"timeout"`
+	}
+
+	// NginxInstance is an NGINX instance declared in the agent configuration, for instances that can't be found by
+	// scanning processes, e.g. because of hidepid, a different PID namespace or a chroot. The instance is running
+	// while the PID file exists, and a reload signals the PID in the PID file. The process with the PID must be
+	// visible to the agent and run the binary, otherwise the instance is unhealthy and config applies fail.
+	NginxInstance struct {
+		API        *NginxAPI `yaml:"api"         mapstructure:"api"`
+		BinaryPath string    `yaml:"binary_path" mapstructure:"binary_path"`
+		ConfigPath string    `yaml:"config_path" mapstructure:"config_path"`
+		PIDFile    string    `yaml:"pid_file"    mapstructure:"pid_file"`
+	}
+
+	// NginxTCPProbe connects to the address, e.g. a listener of NGINX.
+	NginxTCPProbe struct {
+		Address string `yaml:"address" mapstructure:"address"`
 	}
 
 	NginxAPI struct {
@@ -84,9 +146,17 @@ type (
 		HTTP                *HTTP         `yaml:"http"                  mapstructure:"http"`
 		Grpc                *GRPC         `yaml:"grpc"                  mapstructure:"grpc"`
 		Backoff             *BackOff      `yaml:"backoff"               mapstructure:"backoff"`
+		Spool               *Spool        `yaml:"spool"                 mapstructure:"spool"`
 		FileDownloadTimeout time.Duration `yaml:"file_download_timeout" mapstructure:"file_download_timeout"`
 	}
 
+	// Spool configures the outbound queue used to store messages for the management plane while disconnected.
+	// The queue is persisted to disk in the lib directory and uses up to MaxSize bytes. It is disabled if MaxSize is 0.
+	Spool struct {
+		MaxSize int64         `yaml:"max_size" mapstructure:"max_size"`
+		MaxAge  time.Duration `yaml:"max_age"  mapstructure:"max_age"`
+	}
+
 	HTTP struct {
 		Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
 	}
@@ -110,6 +180,7 @@ type (
 		MaxMessageSendSize        int           `yaml:"max_message_send_size"        mapstructure:"max_message_send_size"`
 		MaxFileSize               uint32        `yaml:"max_file_size"                mapstructure:"max_file_size"`
 		FileChunkSize             uint32        `yaml:"file_chunk_size"              mapstructure:"file_chunk_size"`
+		FileCompression           string        `yaml:"file_compression"             mapstructure:"file_compression"`
 		MaxParallelFileOperations int           `yaml:"max_parallel_file_operations" mapstructure:"max_parallel_file_operations"`
 		ConnectionResetTimeout    time.Duration `yaml:"connection_reset_timeout"     mapstructure:"connection_reset_timeout"`
 	}
@@ -346,6 +417,8 @@ type (
 
 	InstanceWatcher struct {
 		MonitoringFrequency time.Duration `yaml:"monitoring_frequency" mapstructure:"monitoring_frequency"`
+		// detect NGINX processes from Linux proc connector events instead of polling all processes
+		ProcessEvents bool `yaml:"process_events" mapstructure:"process_events"`
 	}
 
 	InstanceHealthWatcher struct {
@@ -377,6 +450,26 @@ type (
 		AllowedDomains   []string `yaml:"allowed_domains"    mapstructure:"allowed_domains"`
 		AllowedFileTypes []string `yaml:"allowed_file_types" mapstructure:"allowed_file_types"`
 		MaxBytes         int64    `yaml:"max_bytes"          mapstructure:"max_bytes"`
+		// refresh intervals of external files that are shorter than the minimum are increased to the minimum
+		MinRefreshInterval time.Duration `yaml:"min_refresh_interval" mapstructure:"min_refresh_interval"`
+		// paths to PEM encoded public keys that are trusted to sign external files
+		TrustedKeys []string `yaml:"trusted_keys" mapstructure:"trusted_keys"`
+		// S3-compatible object storage for s3://bucket/key locations
+		S3 *S3DataSource `yaml:"s3" mapstructure:"s3"`
+	}
+
+	S3DataSource struct {
+		// endpoint of an S3-compatible server, such as MinIO. If not set, AWS S3 is used.
+		Endpoint string `yaml:"endpoint"                    mapstructure:"endpoint"`
+		Region   string `yaml:"region"                      mapstructure:"region"`
+		// the secret access key is set in the config file or environment only, there is no command line flag
+		// since the arguments of the agent are visible to all users in the process list
+		AccessKeyID     string `yaml:"access_key_id"               mapstructure:"access_key_id"`
+		SecretAccessKey string `yaml:"secret_access_key,omitempty" mapstructure:"secret_access_key"`
+		// path to an AWS shared credentials file, which is watched by the credential watcher so that
+		// rotated credentials are used without restarting the agent
+		CredentialsFile string `yaml:"credentials_file"            mapstructure:"credentials_file"`
+		Profile         string `yaml:"profile"                     mapstructure:"profile"`
 	}
 )
 
@@ -515,6 +608,22 @@ func checkDirIsAllowed(path string, allowedDirs []string) bool {
 	return checkDirIsAllowed(filepath.Dir(path), allowedDirs)
 }
 
+// NginxInstance returns the NGINX instance declared in the agent configuration with the binary and config path,
+// or nil if the instance is not declared
+func (c *Config) NginxInstance(binaryPath, configPath string) *NginxInstance {
+	if c.DataPlaneConfig == nil || c.DataPlaneConfig.Nginx == nil {
+		return nil
+	}
+
+	for _, nginxInstance := range c.DataPlaneConfig.Nginx.Instances {
+		if nginxInstance.BinaryPath == binaryPath && nginxInstance.ConfigPath == configPath {
+			return nginxInstance
+		}
+	}
+
+	return nil
+}
+
 func (c *Config) IsNginxApiUrlConfigured() bool {
 	if !c.IsNginxApiConfigured() {
 		return false
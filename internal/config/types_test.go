@@ -133,3 +133,21 @@ func TestTypes_isAllowedDir(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_NginxInstance(t *testing.T) {
+	nginxInstance := &NginxInstance{
+		BinaryPath: "/usr/sbin/nginx",
+		ConfigPath: "/etc/nginx/nginx.conf",
+		PIDFile:    "/run/nginx.pid",
+	}
+
+	agentConfig := &Config{
+		DataPlaneConfig: &DataPlaneConfig{
+			Nginx: &NginxDataPlaneConfig{Instances: []*NginxInstance{nginxInstance}},
+		},
+	}
+
+	require.Equal(t, nginxInstance, agentConfig.NginxInstance("/usr/sbin/nginx", "/etc/nginx/nginx.conf"))
+	require.Nil(t, agentConfig.NginxInstance("/usr/sbin/nginx", "/etc/nginx/other.conf"))
+	require.Nil(t, (&Config{}).NginxInstance("/usr/sbin/nginx", "/etc/nginx/nginx.conf"))
+}
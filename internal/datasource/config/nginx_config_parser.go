@@ -28,6 +28,7 @@ import (
 
 	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
 	"github.com/nginx/agent/v3/internal/config"
+	"github.com/nginx/agent/v3/internal/datasource/nginx"
 	"github.com/nginx/agent/v3/internal/model"
 	"github.com/nginx/agent/v3/pkg/files"
 	crossplane "github.com/nginxinc/nginx-go-crossplane"
@@ -68,6 +69,37 @@ var globFunction = func(path string) ([]string, error) {
 	return matches, nil
 }
 
+// rootGlobFunction matches the config files of an instance in another container in the root directory of the
+// instance, the matches are returned with their paths in the container
+func rootGlobFunction(rootPath string) func(path string) ([]string, error) {
+	return func(path string) ([]string, error) {
+		matches, err := globFunction(filepath.Join(rootPath, path))
+		if err != nil {
+			return nil, err
+		}
+
+		for i, match := range matches {
+			matches[i] = strings.TrimPrefix(match, rootPath)
+		}
+
+		return matches, nil
+	}
+}
+
+// fileMetaInRoot returns the metadata of a file of an instance in another container, read through the root
+// directory of the instance, with the path of the file in the container as name
+func fileMetaInRoot(
+	rootPath, fileName string,
+	fileMetaFunc func(filePath string) (*mpi.FileMeta, error),
+) (*mpi.FileMeta, error) {
+	fileMeta, err := fileMetaFunc(filepath.Join(rootPath, fileName))
+	if fileMeta != nil {
+		fileMeta.Name = fileName
+	}
+
+	return fileMeta, err
+}
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6@v6.11.2 -generate
 //counterfeiter:generate . ConfigParser
 
@@ -169,21 +201,33 @@ func (ncp *NginxConfigParser) Parse(ctx context.Context, instance *mpi.Instance)
 	)
 
 	lua := crossplane.Lua{}
-	payload, err := crossplane.Parse(configPath,
-		&crossplane.ParseOptions{
-			SingleFile:         false,
-			StopParsingOnError: true,
-			LexOptions: crossplane.LexOptions{
-				Lexers: []crossplane.RegisterLexer{lua.RegisterLexer()},
-			},
-			Glob: globFunction,
+	parseOptions := &crossplane.ParseOptions{
+		SingleFile:         false,
+		StopParsingOnError: true,
+		LexOptions: crossplane.LexOptions{
+			Lexers: []crossplane.RegisterLexer{lua.RegisterLexer()},
 		},
-	)
+		Glob: globFunction,
+	}
+
+	// the config files of an instance in another container are read through the root directory of the instance
+	rootPath := nginx.RootPath(instance.GetInstanceRuntime().GetProcessId())
+	if rootPath != "" {
+		slog.DebugContext(ctx, "Parsing NGINX config of instance in another container", "root_path", rootPath,
+			"container_id", instance.GetInstanceRuntime().GetContainerId())
+
+		parseOptions.Open = func(path string) (io.ReadCloser, error) {
+			return os.Open(filepath.Join(rootPath, path))
+		}
+		parseOptions.Glob = rootGlobFunction(rootPath)
+	}
+
+	payload, err := crossplane.Parse(configPath, parseOptions)
 	if err != nil {
 		return nil, err
 	}
 
-	return ncp.createNginxConfigContext(ctx, instance, payload, configPath)
+	return ncp.createNginxConfigContext(ctx, instance, payload, configPath, rootPath)
 }
 
 func (ncp *NginxConfigParser) FindStubStatusAPI(
@@ -231,9 +275,10 @@ func (ncp *NginxConfigParser) createNginxConfigContext(
 	ctx context.Context,
 	instance *mpi.Instance,
 	payload *crossplane.Payload,
-	configPath string,
+	configPath, rootPath string,
 ) (*model.NginxConfigContext, error) {
 	napEnabled := false
+	nginxAPI := ncp.nginxAPI(instance)
 
 	nginxConfigContext := &model.NginxConfigContext{
 		InstanceID: instance.GetInstanceMeta().GetInstanceId(),
@@ -284,7 +329,7 @@ func (ncp *NginxConfigParser) createNginxConfigContext(
 
 					if !ncp.ignoreLog(directive.Args[0]) {
 						accessLog := ncp.accessLog(directive.Args[0], ncp.accessLogDirectiveFormat(directive),
-							formatMap)
+							formatMap, rootPath)
 						nginxConfigContext.AccessLogs = ncp.addAccessLog(accessLog, nginxConfigContext.AccessLogs)
 					} else {
 						ignoredAccessLog[directive.Args[0]] = struct{}{}
@@ -297,7 +342,8 @@ func (ncp *NginxConfigParser) createNginxConfigContext(
 					}
 
 					if !ncp.ignoreLog(directive.Args[0]) {
-						errorLog := ncp.errorLog(directive.Args[0], ncp.errorLogDirectiveLevel(directive))
+						errorLog := ncp.errorLog(directive.Args[0], ncp.errorLogDirectiveLevel(directive),
+							rootPath)
 						nginxConfigContext.ErrorLogs = append(nginxConfigContext.ErrorLogs, errorLog)
 					} else {
 						ignoredErrorLog[directive.Args[0]] = struct{}{}
@@ -308,7 +354,7 @@ func (ncp *NginxConfigParser) createNginxConfigContext(
 				case "ssl_certificate", "proxy_ssl_certificate", "ssl_client_certificate",
 					"ssl_trusted_certificate":
 					if ncp.agentConfig.IsFeatureEnabled(pkg.FeatureCertificates) {
-						sslCertFile := ncp.sslCert(ctx, directive.Args[0], rootDir)
+						sslCertFile := ncp.sslCert(ctx, directive.Args[0], rootDir, rootPath)
 						if sslCertFile != nil && !ncp.isDuplicateFile(nginxConfigContext.Files, sslCertFile) {
 							slog.DebugContext(ctx, "Adding SSL certificate file", "ssl_cert", sslCertFile)
 							nginxConfigContext.Files = append(nginxConfigContext.Files, sslCertFile)
@@ -335,7 +381,7 @@ func (ncp *NginxConfigParser) createNginxConfigContext(
 			return nginxConfigContext, fmt.Errorf("traverse nginx config: %w", err)
 		}
 
-		if !ncp.agentConfig.IsNginxApiUrlConfigured() {
+		if nginxAPI.URL == "" {
 			stubStatuses := ncp.crossplaneConfigTraverseAPIDetails(
 				ctx, &conf, ncp.apiCallback, stubStatusAPIDirective,
 			)
@@ -351,7 +397,7 @@ func (ncp *NginxConfigParser) createNginxConfigContext(
 			}
 		}
 
-		fileMeta, err := files.FileMeta(conf.File)
+		fileMeta, err := fileMetaInRoot(rootPath, conf.File, files.FileMeta)
 		if err != nil {
 			slog.WarnContext(ctx, "Unable to get file metadata", "file_name", conf.File, "error", err)
 		} else {
@@ -368,35 +414,52 @@ func (ncp *NginxConfigParser) createNginxConfigContext(
 			"server configured on port %s", ncp.agentConfig.SyslogServer.Port))
 	}
 
-	if !ncp.agentConfig.IsNginxApiUrlConfigured() {
+	if nginxAPI.URL == "" {
 		nginxConfigContext.PlusAPIs = ncp.sortPlusAPIs(ctx, nginxConfigContext.PlusAPIs)
 		nginxConfigContext.StubStatus = ncp.FindStubStatusAPI(ctx, nginxConfigContext)
 		nginxConfigContext.PlusAPI = ncp.FindPlusAPI(ctx, nginxConfigContext)
 	} else {
-		nginxConfigContext = ncp.addApiToNginxConfigContext(ctx, nginxConfigContext)
+		nginxConfigContext = ncp.addApiToNginxConfigContext(ctx, nginxConfigContext, nginxAPI)
 	}
 
 	return nginxConfigContext, nil
 }
 
+// nginxAPI returns the NGINX API declared for the instance in the agent configuration, or the NGINX API of the
+// agent configuration if no API is declared for the instance
+func (ncp *NginxConfigParser) nginxAPI(instance *mpi.Instance) *config.NginxAPI {
+	nginxInstance := ncp.agentConfig.NginxInstance(instance.GetInstanceRuntime().GetBinaryPath(),
+		instance.GetInstanceRuntime().GetConfigPath())
+	if nginxInstance != nil && nginxInstance.API != nil && nginxInstance.API.URL != "" {
+		return nginxInstance.API
+	}
+
+	if ncp.agentConfig.IsNginxApiConfigured() {
+		return ncp.agentConfig.DataPlaneConfig.Nginx.API
+	}
+
+	return &config.NginxAPI{}
+}
+
 func (ncp *NginxConfigParser) addApiToNginxConfigContext(
 	ctx context.Context,
 	nginxConfigContext *model.NginxConfigContext,
+	nginxAPI *config.NginxAPI,
 ) *model.NginxConfigContext {
-	apiDetails, err := parseURL(ncp.agentConfig.DataPlaneConfig.Nginx.API.URL)
+	apiDetails, err := parseURL(nginxAPI.URL)
 	if err != nil {
 		slog.ErrorContext(
 			ctx,
 			"Configured NGINX API URL is invalid",
-			"url", ncp.agentConfig.DataPlaneConfig.Nginx.API.URL,
+			"url", nginxAPI.URL,
 			"error", err,
 		)
 
 		return nginxConfigContext
 	}
 
-	if ncp.agentConfig.IsNginxApiSocketConfigured() {
-		apiDetails.Listen = ncp.agentConfig.DataPlaneConfig.Nginx.API.Socket
+	if nginxAPI.Socket != "" {
+		apiDetails.Listen = nginxAPI.Socket
 	}
 
 	if ncp.pingAPIEndpoint(ctx, apiDetails, stubStatusAPIDirective) {
@@ -407,7 +470,7 @@ func (ncp *NginxConfigParser) addApiToNginxConfigContext(
 		slog.WarnContext(
 			ctx,
 			"Configured NGINX API URL is not reachable",
-			"url", ncp.agentConfig.DataPlaneConfig.Nginx.API.URL,
+			"url", nginxAPI.URL,
 		)
 	}
 
@@ -621,13 +684,15 @@ func (ncp *NginxConfigParser) isExcludeLog(path string) bool {
 	return false
 }
 
-func (ncp *NginxConfigParser) accessLog(file, format string, formatMap map[string]string) *model.AccessLog {
+func (ncp *NginxConfigParser) accessLog(file, format string, formatMap map[string]string,
+	rootPath string,
+) *model.AccessLog {
 	accessLog := &model.AccessLog{
 		Name:     file,
 		Readable: false,
 	}
 
-	info, err := os.Stat(file)
+	info, err := os.Stat(filepath.Join(rootPath, file))
 	if err == nil {
 		accessLog.Readable = true
 		accessLog.Permissions = files.Permissions(info.Mode())
@@ -638,13 +703,13 @@ func (ncp *NginxConfigParser) accessLog(file, format string, formatMap map[strin
 	return accessLog
 }
 
-func (ncp *NginxConfigParser) errorLog(file, level string) *model.ErrorLog {
+func (ncp *NginxConfigParser) errorLog(file, level, rootPath string) *model.ErrorLog {
 	errorLog := &model.ErrorLog{
 		Name:     file,
 		LogLevel: level,
 		Readable: false,
 	}
-	info, err := os.Stat(file)
+	info, err := os.Stat(filepath.Join(rootPath, file))
 	if err == nil {
 		errorLog.Permissions = files.Permissions(info.Mode())
 		errorLog.Readable = true
@@ -687,7 +752,7 @@ func (ncp *NginxConfigParser) updateLogFormat(
 	return accessLog
 }
 
-func (ncp *NginxConfigParser) sslCert(ctx context.Context, file, rootDir string) (sslCertFile *mpi.File) {
+func (ncp *NginxConfigParser) sslCert(ctx context.Context, file, rootDir, rootPath string) (sslCertFile *mpi.File) {
 	if strings.Contains(file, "$") {
 		slog.DebugContext(ctx, "Cannot process SSL certificate file path with variables", "file", file)
 		return nil
@@ -700,7 +765,7 @@ func (ncp *NginxConfigParser) sslCert(ctx context.Context, file, rootDir string)
 	if !ncp.agentConfig.IsDirectoryAllowed(file) {
 		slog.DebugContext(ctx, "File not in allowed directories", "file", file)
 	} else {
-		sslCertFileMeta, fileMetaErr := files.FileMetaWithCertificate(file)
+		sslCertFileMeta, fileMetaErr := fileMetaInRoot(rootPath, file, files.FileMetaWithCertificate)
 		if fileMetaErr != nil {
 			slog.ErrorContext(ctx, "Unable to get file metadata", "file", file, "error", fileMetaErr)
 		} else {
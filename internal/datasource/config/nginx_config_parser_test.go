@@ -14,6 +14,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
 	"sort"
 	"testing"
 
@@ -687,13 +688,38 @@ func TestNginxConfigParser_sslCert(t *testing.T) {
 	// Not in allowed directory
 	nginxConfig := NewNginxConfigParser(types.AgentConfig())
 	nginxConfig.agentConfig.AllowedDirectories = []string{}
-	sslCert := nginxConfig.sslCert(ctx, certFile, dir)
+	sslCert := nginxConfig.sslCert(ctx, certFile, dir, "")
 	assert.Nil(t, sslCert)
 
 	// In allowed directory
 	nginxConfig.agentConfig.AllowedDirectories = []string{dir}
-	sslCert = nginxConfig.sslCert(ctx, certFile, dir)
+	sslCert = nginxConfig.sslCert(ctx, certFile, dir, "")
 	assert.Equal(t, certFile, sslCert.GetFileMeta().GetName())
+
+	// Instance in another container
+	rootPath := t.TempDir()
+	helpers.CreateDirWithErrorCheck(t, filepath.Join(rootPath, "etc", "nginx"))
+	helpers.WriteCertFiles(t, filepath.Join(rootPath, "etc", "nginx"), certContents)
+
+	nginxConfig.agentConfig.AllowedDirectories = []string{"/etc/nginx"}
+	sslCert = nginxConfig.sslCert(ctx, "nginx.cert", "/etc/nginx", rootPath)
+	require.NotNil(t, sslCert)
+	assert.Equal(t, "/etc/nginx/nginx.cert", sslCert.GetFileMeta().GetName())
+	assert.NotNil(t, sslCert.GetFileMeta().GetCertificateMeta())
+}
+
+func TestRootGlobFunction(t *testing.T) {
+	rootPath := t.TempDir()
+	confDir := filepath.Join(rootPath, "etc", "nginx", "conf.d")
+	helpers.CreateDirWithErrorCheck(t, confDir)
+
+	for _, fileName := range []string{"default.conf", "upstreams.conf", ".hidden.conf"} {
+		require.NoError(t, os.WriteFile(filepath.Join(confDir, fileName), []byte{}, 0o600))
+	}
+
+	matches, err := rootGlobFunction(rootPath)("/etc/nginx/conf.d/*.conf")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/etc/nginx/conf.d/default.conf", "/etc/nginx/conf.d/upstreams.conf"}, matches)
 }
 
 func TestNginxConfigParser_SyslogServerParse(t *testing.T) {
@@ -821,6 +847,20 @@ func TestNginxConfigParser_PlusAPIParse(t *testing.T) {
 		URL:    "http://localhost/api/",
 		Socket: "unix:" + socket,
 	}
+	agentConfigWithInstanceOverride := types.AgentConfig()
+	agentConfigWithInstanceOverride.DataPlaneConfig.Nginx.API = &config.NginxAPI{
+		URL: "http://localhost:8080/api/",
+	}
+	agentConfigWithInstanceOverride.DataPlaneConfig.Nginx.Instances = []*config.NginxInstance{
+		{
+			BinaryPath: instance.GetInstanceRuntime().GetBinaryPath(),
+			ConfigPath: instance.GetInstanceRuntime().GetConfigPath(),
+			PIDFile:    "/var/run/nginx.pid",
+			API: &config.NginxAPI{
+				URL: fmt.Sprintf("http://localhost:%s/api/", fakeServerUrl.Port()),
+			},
+		},
+	}
 
 	tests := []struct {
 		agentConfig *config.Config
@@ -850,6 +890,13 @@ func TestNginxConfigParser_PlusAPIParse(t *testing.T) {
 			listen:      "unix:" + socket,
 			agentConfig: agentConfigWithUnixOverride,
 		},
+		{
+			name:        "Test 4: Override Plus API URL of declared instance in agent config",
+			content:     testconfig.NginxConfigWithPlusAPI("8080"),
+			url:         "http://localhost:" + fakeServerUrl.Port() + "/api/",
+			listen:      "localhost:" + fakeServerUrl.Port(),
+			agentConfig: agentConfigWithInstanceOverride,
+		},
 	}
 
 	for _, test := range tests {
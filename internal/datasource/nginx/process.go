@@ -11,10 +11,14 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/nginx/agent/v3/internal/model"
+	"github.com/nginx/agent/v3/pkg/host"
 	"github.com/nginx/agent/v3/pkg/host/exec"
 	"github.com/nginx/agent/v3/pkg/nginxprocess"
 )
@@ -24,14 +28,24 @@ const (
 	flagLen     = 1
 )
 
-var versionRegex = regexp.MustCompile(`(?P<name>\S+)\/(?P<version>.*)`)
+var (
+	versionRegex = regexp.MustCompile(`(?P<name>\S+)\/(?P<version>.*)`)
+	procDir      = "/proc"
+)
 
 func ProcessInfo(ctx context.Context, proc *nginxprocess.Process,
 	executer exec.ExecInterface,
 ) (*model.ProcessInfo, error) {
 	exePath := proc.Exe
+	rootPath := RootPath(proc.PID)
 
 	if exePath == "" {
+		// the NGINX exe found by the agent is not the exe of a process in another container
+		if rootPath != "" {
+			return nil, fmt.Errorf("unable to find NGINX exe for process %d in root directory %s", proc.PID,
+				rootPath)
+		}
+
 		exePath = Exe(ctx, executer)
 		if exePath == "" {
 			return nil, fmt.Errorf("unable to find NGINX exe for process %d", proc.PID)
@@ -42,7 +56,8 @@ func ProcessInfo(ctx context.Context, proc *nginxprocess.Process,
 
 	nginxInfo := &model.ProcessInfo{}
 
-	outputBuffer, err := executer.RunCmd(ctx, exePath, "-V")
+	name, args := Command(rootPath, exePath, "-V")
+	outputBuffer, err := executer.RunCmd(ctx, name, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -55,12 +70,104 @@ func ProcessInfo(ctx context.Context, proc *nginxprocess.Process,
 
 	nginxInfo.ExePath = exePath
 	nginxInfo.ProcessID = proc.PID
+	nginxInfo.RootPath = rootPath
+
+	if rootPath != "" {
+		nginxInfo.ContainerID, err = host.ProcessContainerID(proc.PID)
+		if err != nil {
+			slog.WarnContext(ctx, "Unable to get container ID of NGINX process", "pid", proc.PID, "error", err)
+		}
+	}
 
 	if nginxInfo.ConfPath = model.NginxConfPath(ctx, nginxInfo); confPath != "" {
 		nginxInfo.ConfPath = confPath
 	}
 
-	return nginxInfo, err
+	return nginxInfo, nil
+}
+
+// RootPath returns the root directory of a process that runs in another container than the agent, which the agent
+// can see because the containers share a PID namespace. Files of the process are read and written through its root
+// directory, /proc/<pid>/root. An empty string is returned if the process has the same root directory as the agent
+// or if the root directory can't be read.
+func RootPath(pid int32) string {
+	rootPath := filepath.Join(procDir, strconv.Itoa(int(pid)), "root")
+
+	rootInfo, err := os.Stat(rootPath)
+	if err != nil {
+		return ""
+	}
+
+	selfRootInfo, err := os.Stat(filepath.Join(procDir, "self", "root"))
+	if err != nil || os.SameFile(rootInfo, selfRootInfo) {
+		return ""
+	}
+
+	return rootPath
+}
+
+// CheckProcessExe returns an error if the process with the PID is not running the exe. The PID file of an NGINX
+// instance contains a process ID of the PID namespace of the instance, which is a different process, or no
+// process at all, if the agent runs in another PID namespace.
+func CheckProcessExe(pid int32, exePath string) error {
+	procExe := filepath.Join(procDir, strconv.Itoa(int(pid)), "exe")
+
+	procExeInfo, err := os.Stat(procExe)
+	if err != nil {
+		return fmt.Errorf("unable to read exe of process %d: %w", pid, err)
+	}
+
+	if exeInfo, statErr := os.Stat(exePath); statErr == nil && os.SameFile(procExeInfo, exeInfo) {
+		return nil
+	}
+
+	// the exe was replaced while the process is running, e.g. by a package upgrade
+	if link, linkErr := os.Readlink(procExe); linkErr == nil &&
+		sanitizeExeDeletedPath(link) == filepath.Clean(exePath) {
+		return nil
+	}
+
+	return fmt.Errorf("process %d is not running NGINX binary %s", pid, exePath)
+}
+
+// Command returns the command to run the NGINX exe with the given arguments. The exe of a process in another
+// container is run in the root directory of the process, so it loads its own libraries, modules and config.
+func Command(rootPath, exePath string, args ...string) (name string, commandArgs []string) {
+	if rootPath == "" {
+		return exePath, args
+	}
+
+	return "chroot", append([]string{rootPath, exePath}, args...)
+}
+
+// PathInRoot returns the path of a file in the root directory of a process in another container as seen by the
+// process, e.g. to pass the path of a file the agent created in the root directory to a command run by Command.
+func PathInRoot(rootPath, path string) (string, error) {
+	if rootPath == "" {
+		return path, nil
+	}
+
+	relPath, err := filepath.Rel(rootPath, path)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is not in root directory %s", path, rootPath)
+	}
+
+	return filepath.Join(string(filepath.Separator), relPath), nil
+}
+
+// PIDFromFile reads the process ID of the NGINX master process from the PID file
+func PIDFromFile(pidFile string) (int32, error) {
+	contents, err := os.ReadFile(pidFile)
+	if err != nil {
+		return 0, fmt.Errorf("unable to read PID file: %w", err)
+	}
+
+	pid, err := strconv.ParseInt(strings.TrimSpace(string(contents)), 10, 32)
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("invalid process ID in PID file %s: %q", pidFile, strings.TrimSpace(string(contents)))
+	}
+
+	return int32(pid), nil
 }
 
 func Exe(ctx context.Context, executer exec.ExecInterface) string {
@@ -9,10 +9,13 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/nginx/agent/v3/pkg/host/exec/execfakes"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetConfigPathFromCommand(t *testing.T) {
@@ -26,6 +29,95 @@ func TestGetConfigPathFromCommand(t *testing.T) {
 	assert.Empty(t, result)
 }
 
+func TestPIDFromFile(t *testing.T) {
+	pidFile := filepath.Join(t.TempDir(), "nginx.pid")
+
+	_, err := PIDFromFile(pidFile)
+	require.ErrorContains(t, err, "unable to read PID file")
+
+	require.NoError(t, os.WriteFile(pidFile, []byte("1234\n"), 0o600))
+	pid, err := PIDFromFile(pidFile)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1234), pid)
+
+	require.NoError(t, os.WriteFile(pidFile, []byte(""), 0o600))
+	_, err = PIDFromFile(pidFile)
+	require.ErrorContains(t, err, "invalid process ID in PID file")
+
+	require.NoError(t, os.WriteFile(pidFile, []byte("-1"), 0o600))
+	_, err = PIDFromFile(pidFile)
+	require.ErrorContains(t, err, "invalid process ID in PID file")
+}
+
+func TestRootPath(t *testing.T) {
+	agentRoot := t.TempDir()
+	containerRoot := t.TempDir()
+	testProcDir := t.TempDir()
+
+	for pid, root := range map[string]string{"self": agentRoot, "100": containerRoot, "200": agentRoot} {
+		require.NoError(t, os.Mkdir(filepath.Join(testProcDir, pid), 0o700))
+		require.NoError(t, os.Symlink(root, filepath.Join(testProcDir, pid, "root")))
+	}
+
+	defaultProcDir := procDir
+	procDir = testProcDir
+	defer func() { procDir = defaultProcDir }()
+
+	assert.Equal(t, filepath.Join(testProcDir, "100", "root"), RootPath(100))
+	assert.Empty(t, RootPath(200))
+	assert.Empty(t, RootPath(300))
+}
+
+func TestCheckProcessExe(t *testing.T) {
+	tempDir := t.TempDir()
+	testProcDir := t.TempDir()
+
+	nginxExe := filepath.Join(tempDir, "nginx")
+	otherExe := filepath.Join(tempDir, "other")
+	for _, exe := range []string{nginxExe, otherExe} {
+		require.NoError(t, os.WriteFile(exe, []byte{}, 0o700))
+	}
+
+	for pid, exe := range map[string]string{"100": nginxExe, "200": otherExe} {
+		require.NoError(t, os.Mkdir(filepath.Join(testProcDir, pid), 0o700))
+		require.NoError(t, os.Symlink(exe, filepath.Join(testProcDir, pid, "exe")))
+	}
+
+	defaultProcDir := procDir
+	procDir = testProcDir
+	defer func() { procDir = defaultProcDir }()
+
+	require.NoError(t, CheckProcessExe(100, nginxExe))
+	require.ErrorContains(t, CheckProcessExe(200, nginxExe), "process 200 is not running NGINX binary")
+	require.ErrorContains(t, CheckProcessExe(300, nginxExe), "unable to read exe of process 300")
+}
+
+func TestCommand(t *testing.T) {
+	name, args := Command("", "/usr/sbin/nginx", "-V")
+	assert.Equal(t, "/usr/sbin/nginx", name)
+	assert.Equal(t, []string{"-V"}, args)
+
+	name, args = Command("/proc/100/root", "/usr/sbin/nginx", "-t")
+	assert.Equal(t, "chroot", name)
+	assert.Equal(t, []string{"/proc/100/root", "/usr/sbin/nginx", "-t"}, args)
+}
+
+func TestPathInRoot(t *testing.T) {
+	path, err := PathInRoot("", "/tmp/staging/etc/nginx/nginx.conf")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/staging/etc/nginx/nginx.conf", path)
+
+	path, err = PathInRoot("/proc/100/root", "/proc/100/root/tmp/staging/etc/nginx/nginx.conf")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/staging/etc/nginx/nginx.conf", path)
+
+	_, err = PathInRoot("/proc/100/root", "/var/lib/nginx-agent/staging/etc/nginx/nginx.conf")
+	require.Error(t, err)
+
+	_, err = PathInRoot("/proc/100/root", "/proc/100/root-other/nginx.conf")
+	require.Error(t, err)
+}
+
 func TestNginxProcessParser_GetExe(t *testing.T) {
 	ctx := context.Background()
 
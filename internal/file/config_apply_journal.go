@@ -0,0 +1,169 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/config"
+	"github.com/nginx/agent/v3/internal/model"
+	"github.com/nginx/agent/v3/pkg/files"
+)
+
+const configApplyJournalFileName = "config_apply_journal.json"
+
+type (
+	// configApplyJournal is a write-ahead journal of the config apply in progress. Each phase of the config apply
+	// is persisted before the next phase starts, so a config apply that was interrupted because the agent was
+	// killed can be rolled back or completed when the agent starts again.
+	// The journal is stored in the lib directory, if no lib directory is configured nothing is journaled.
+	configApplyJournal struct {
+		agentConfig *config.Config
+		transaction *configApplyTransaction
+		mutex       sync.Mutex
+	}
+
+	configApplyTransaction struct {
+		StartTime time.Time `json:"start_time"`
+		// the manifest before the config apply, nil if there was no manifest
+		PreviousManifest map[string]*model.ManifestFile `json:"previous_manifest"`
+		CorrelationID    string                         `json:"correlation_id"`
+		InstanceID       string                         `json:"instance_id"`
+		// root directory of an instance in another container, see InstanceFiles
+		RootPath string                        `json:"root_path"`
+		Phase    model.ConfigApplyPhase        `json:"phase"`
+		Files    []*configApplyTransactionFile `json:"files"`
+	}
+
+	configApplyTransactionFile struct {
+		Name   string           `json:"name"`
+		Action model.FileAction `json:"action"`
+	}
+)
+
+func newConfigApplyJournal(agentConfig *config.Config) *configApplyJournal {
+	return &configApplyJournal{
+		agentConfig: agentConfig,
+	}
+}
+
+// Begin persists a new transaction for a config apply, replacing any transaction that was not completed.
+func (cj *configApplyJournal) Begin(transaction *configApplyTransaction) error {
+	cj.mutex.Lock()
+	defer cj.mutex.Unlock()
+
+	transaction.StartTime = time.Now().UTC()
+	transaction.Phase = model.ConfigApplyStarted
+	cj.transaction = transaction
+
+	return cj.persist()
+}
+
+// SetPhase records that a phase of the config apply in progress has finished.
+func (cj *configApplyJournal) SetPhase(phase model.ConfigApplyPhase) error {
+	cj.mutex.Lock()
+	defer cj.mutex.Unlock()
+
+	if cj.transaction == nil {
+		return nil
+	}
+
+	cj.transaction.Phase = phase
+
+	return cj.persist()
+}
+
+// Complete removes the transaction of the config apply in progress, once the config apply has finished
+// either successfully or with a rollback.
+func (cj *configApplyJournal) Complete() error {
+	cj.mutex.Lock()
+	defer cj.mutex.Unlock()
+
+	cj.transaction = nil
+
+	path := cj.path()
+	if path == "" {
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unable to remove config apply journal %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Load reads the transaction of a config apply that was not completed before the agent stopped.
+// If there is no such transaction, nil is returned.
+func (cj *configApplyJournal) Load() (*configApplyTransaction, error) {
+	path := cj.path()
+	if path == "" {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to read config apply journal %s: %w", path, err)
+	}
+
+	transaction := &configApplyTransaction{}
+	if err = json.Unmarshal(content, transaction); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal config apply journal %s: %w", path, err)
+	}
+
+	return transaction, nil
+}
+
+// persist writes the journal atomically, so the journal is never partially written, even if the host is rebooted.
+// Must be called with the mutex held.
+func (cj *configApplyJournal) persist() error {
+	path := cj.path()
+	if path == "" {
+		return nil
+	}
+
+	journalJSON, err := json.MarshalIndent(cj.transaction, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal config apply journal: %w", err)
+	}
+
+	if err = files.WriteFileAtomically(path, journalJSON, filePerm); err != nil {
+		return fmt.Errorf("unable to write config apply journal: %w", err)
+	}
+
+	return nil
+}
+
+func (cj *configApplyJournal) path() string {
+	if cj.agentConfig.LibDir == "" {
+		return ""
+	}
+
+	return filepath.Join(cj.agentConfig.LibDir, configApplyJournalFileName)
+}
+
+func (transaction *configApplyTransaction) fileActions() map[string]*model.FileCache {
+	fileActions := make(map[string]*model.FileCache, len(transaction.Files))
+	for _, file := range transaction.Files {
+		fileActions[file.Name] = &model.FileCache{
+			File:   &mpi.File{FileMeta: &mpi.FileMeta{Name: file.Name}},
+			Action: file.Action,
+		}
+	}
+
+	return fileActions
+}
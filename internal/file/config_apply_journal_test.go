@@ -0,0 +1,92 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nginx/agent/v3/internal/model"
+	"github.com/nginx/agent/v3/test/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigApplyJournal(t *testing.T) {
+	agentConfig := types.AgentConfig()
+	agentConfig.LibDir = t.TempDir()
+	journal := newConfigApplyJournal(agentConfig)
+	journalPath := filepath.Join(agentConfig.LibDir, configApplyJournalFileName)
+
+	transaction, err := journal.Load()
+	require.NoError(t, err)
+	assert.Nil(t, transaction)
+
+	// phases are only recorded for a config apply in progress
+	require.NoError(t, journal.SetPhase(model.ConfigApplyBackedUp))
+	assert.NoFileExists(t, journalPath)
+
+	require.NoError(t, journal.Begin(&configApplyTransaction{
+		CorrelationID: "correlation-id",
+		InstanceID:    "instance-id",
+		PreviousManifest: map[string]*model.ManifestFile{
+			"/etc/nginx/nginx.conf": {ManifestFileMeta: &model.ManifestFileMeta{Name: "/etc/nginx/nginx.conf"}},
+		},
+		Files: []*configApplyTransactionFile{
+			{Name: "/etc/nginx/nginx.conf", Action: model.Update},
+		},
+	}))
+
+	transaction, err = journal.Load()
+	require.NoError(t, err)
+	assert.Equal(t, model.ConfigApplyStarted, transaction.Phase)
+	assert.Equal(t, "correlation-id", transaction.CorrelationID)
+	assert.Equal(t, "instance-id", transaction.InstanceID)
+	assert.Contains(t, transaction.PreviousManifest, "/etc/nginx/nginx.conf")
+	assert.False(t, transaction.StartTime.IsZero())
+	assert.Equal(t, model.Update, transaction.fileActions()["/etc/nginx/nginx.conf"].Action)
+
+	require.NoError(t, journal.SetPhase(model.ConfigApplyMoved))
+
+	transaction, err = journal.Load()
+	require.NoError(t, err)
+	assert.Equal(t, model.ConfigApplyMoved, transaction.Phase)
+	assert.NoFileExists(t, journalPath+".tmp")
+
+	require.NoError(t, journal.Complete())
+	assert.NoFileExists(t, journalPath)
+
+	transaction, err = journal.Load()
+	require.NoError(t, err)
+	assert.Nil(t, transaction)
+}
+
+func TestConfigApplyJournal_Load_Corrupt(t *testing.T) {
+	agentConfig := types.AgentConfig()
+	agentConfig.LibDir = t.TempDir()
+	journal := newConfigApplyJournal(agentConfig)
+
+	require.NoError(t, os.WriteFile(filepath.Join(agentConfig.LibDir, configApplyJournalFileName),
+		[]byte("{"), 0o600))
+
+	_, err := journal.Load()
+	require.ErrorContains(t, err, "unable to unmarshal config apply journal")
+}
+
+func TestConfigApplyJournal_NoLibDir(t *testing.T) {
+	agentConfig := types.AgentConfig()
+	agentConfig.LibDir = ""
+	journal := newConfigApplyJournal(agentConfig)
+
+	require.NoError(t, journal.Begin(&configApplyTransaction{InstanceID: "instance-id"}))
+	require.NoError(t, journal.SetPhase(model.ConfigApplyMoved))
+
+	transaction, err := journal.Load()
+	require.NoError(t, err)
+	assert.Nil(t, transaction)
+	require.NoError(t, journal.Complete())
+}
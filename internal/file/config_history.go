@@ -0,0 +1,308 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/pkg/files"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	configHistoryDirName        = "config_history"
+	configHistoryIndexExtension = ".json"
+)
+
+var errConfigVersionNotFound = errors.New("config version not found")
+
+type (
+	// configHistory stores the config versions applied to each instance, so that an instance can be rolled back
+	// to an earlier config version. The content of the files is stored in the object store shared by all
+	// instances and addressed by the hash of the content, so files that are identical across versions or
+	// instances are only stored once. The config history uses the mutex of the object store, so that objects
+	// are never removed while a config version that references them is saved.
+	configHistory struct {
+		objects     *objectStore
+		dir         string
+		maxVersions int
+	}
+
+	configHistoryEntry struct {
+		AppliedTime time.Time       `json:"applied_time"`
+		Overview    json.RawMessage `json:"overview"`
+	}
+)
+
+func newConfigHistory(dir string, maxVersions int, objects *objectStore) *configHistory {
+	configHistory := &configHistory{
+		objects:     objects,
+		dir:         dir,
+		maxVersions: maxVersions,
+	}
+	objects.referenced = configHistory.referencedObjects
+
+	return configHistory
+}
+
+// Enabled returns false if no history size is configured, in which case config versions are never stored.
+func (ch *configHistory) Enabled() bool {
+	return ch.maxVersions > 0
+}
+
+// Save stores the content of the managed files of a config version that was applied to an instance.
+// Unmanaged files are not changed by a config apply and external files are downloaded from their data source,
+// so their content is not stored. If the version is already in the history it is moved to the end.
+func (ch *configHistory) Save(ctx context.Context, fileOverview *mpi.FileOverview) error {
+	if !ch.Enabled() {
+		return nil
+	}
+
+	instanceID := fileOverview.GetConfigVersion().GetInstanceId()
+	indexPath, err := ch.indexPath(instanceID)
+	if err != nil {
+		return err
+	}
+
+	overview, ok := proto.Clone(fileOverview).(*mpi.FileOverview)
+	if !ok {
+		return errors.New("unable to copy file overview")
+	}
+
+	if overview.GetConfigVersion().GetVersion() == "" {
+		overview.ConfigVersion.Version = files.GenerateConfigVersion(overview.GetFiles())
+	}
+
+	ch.objects.mutex.Lock()
+	defer ch.objects.mutex.Unlock()
+
+	for _, file := range overview.GetFiles() {
+		// the file on disk of a template is the rendered file, so the template source is not available to save
+		if file.GetUnmanaged() || file.GetExternalDataSource() != nil || !hasContents(file) ||
+			file.GetTemplate() != nil {
+			continue
+		}
+
+		if err = ch.saveObject(ctx, file); err != nil {
+			return err
+		}
+	}
+
+	overviewJSON, err := protojson.Marshal(overview)
+	if err != nil {
+		return fmt.Errorf("unable to marshal file overview: %w", err)
+	}
+
+	entries, err := ch.entries(indexPath)
+	if err != nil {
+		return err
+	}
+
+	version := overview.GetConfigVersion().GetVersion()
+	entries = removeConfigHistoryEntry(entries, version)
+	entries = append(entries, &configHistoryEntry{
+		AppliedTime: time.Now().UTC(),
+		Overview:    overviewJSON,
+	})
+
+	if len(entries) > ch.maxVersions {
+		entries = entries[len(entries)-ch.maxVersions:]
+	}
+
+	if err = ch.writeEntries(indexPath, entries); err != nil {
+		return err
+	}
+
+	slog.DebugContext(ctx, "Saved config version to config history", "instance_id", instanceID,
+		"version", version, "versions", len(entries))
+
+	ch.objects.collectGarbage(ctx)
+
+	return nil
+}
+
+// Versions returns the config versions stored for an instance, oldest first.
+func (ch *configHistory) Versions(instanceID string) ([]*mpi.ConfigHistoryVersion, error) {
+	indexPath, err := ch.indexPath(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ch.objects.mutex.Lock()
+	defer ch.objects.mutex.Unlock()
+
+	entries, err := ch.entries(indexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*mpi.ConfigHistoryVersion, 0, len(entries))
+	for _, entry := range entries {
+		overview, overviewErr := entry.fileOverview()
+		if overviewErr != nil {
+			return nil, overviewErr
+		}
+
+		fileMetas := make([]*mpi.FileMeta, 0, len(overview.GetFiles()))
+		for _, file := range overview.GetFiles() {
+			fileMetas = append(fileMetas, file.GetFileMeta())
+		}
+
+		versions = append(versions, &mpi.ConfigHistoryVersion{
+			ConfigVersion: overview.GetConfigVersion(),
+			AppliedTime:   timestamppb.New(entry.AppliedTime),
+			ConfigPath:    overview.GetConfigPath(),
+			Files:         fileMetas,
+		})
+	}
+
+	return versions, nil
+}
+
+// Overview returns the file overview of a stored config version of an instance.
+func (ch *configHistory) Overview(instanceID, version string) (*mpi.FileOverview, error) {
+	indexPath, err := ch.indexPath(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ch.objects.mutex.Lock()
+	defer ch.objects.mutex.Unlock()
+
+	entries, err := ch.entries(indexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		overview, overviewErr := entry.fileOverview()
+		if overviewErr != nil {
+			return nil, overviewErr
+		}
+
+		if overview.GetConfigVersion().GetVersion() == version {
+			return overview, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", errConfigVersionNotFound, version)
+}
+
+// saveObject copies the content of a file on disk to the object store. The hash of the file is updated to the hash
+// of the content on disk, in case the file was changed after the config apply. Must be called with the mutex held.
+func (ch *configHistory) saveObject(ctx context.Context, file *mpi.File) error {
+	content, err := os.ReadFile(file.GetFileMeta().GetName())
+	if err != nil {
+		return fmt.Errorf("unable to read file %s: %w", file.GetFileMeta().GetName(), err)
+	}
+
+	hash := files.GenerateHash(content)
+	file.FileMeta.Hash = hash
+	file.FileMeta.Size = int64(len(content))
+
+	return ch.objects.write(ctx, hash, content)
+}
+
+// referencedObjects returns the names of the objects referenced by a stored config version of any instance.
+// Must be called with the mutex held.
+func (ch *configHistory) referencedObjects(context.Context) (map[string]bool, error) {
+	indexFiles, err := filepath.Glob(filepath.Join(ch.dir, "*"+configHistoryIndexExtension))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list config history: %w", err)
+	}
+
+	referenced := make(map[string]bool)
+
+	for _, indexFile := range indexFiles {
+		entries, entriesErr := ch.entries(indexFile)
+		if entriesErr != nil {
+			return nil, entriesErr
+		}
+
+		for _, entry := range entries {
+			overview, overviewErr := entry.fileOverview()
+			if overviewErr != nil {
+				return nil, overviewErr
+			}
+
+			for _, file := range overview.GetFiles() {
+				if objectPath, pathErr := ch.objects.objectPath(file.GetFileMeta().GetHash()); pathErr == nil {
+					referenced[filepath.Base(objectPath)] = true
+				}
+			}
+		}
+	}
+
+	return referenced, nil
+}
+
+func (ch *configHistory) entries(indexPath string) ([]*configHistoryEntry, error) {
+	content, err := os.ReadFile(indexPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to read config history %s: %w", indexPath, err)
+	}
+
+	var entries []*configHistoryEntry
+	if err = json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal config history %s: %w", indexPath, err)
+	}
+
+	return entries, nil
+}
+
+func (ch *configHistory) writeEntries(indexPath string, entries []*configHistoryEntry) error {
+	entriesJSON, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal config history: %w", err)
+	}
+
+	return files.WriteFileAtomically(indexPath, entriesJSON, filePerm)
+}
+
+func (ch *configHistory) indexPath(instanceID string) (string, error) {
+	if instanceID == "" || strings.ContainsAny(instanceID, `/\`) || instanceID == "." || instanceID == ".." {
+		return "", fmt.Errorf("invalid instance id %q", instanceID)
+	}
+
+	return filepath.Join(ch.dir, instanceID+configHistoryIndexExtension), nil
+}
+
+func (entry *configHistoryEntry) fileOverview() (*mpi.FileOverview, error) {
+	overview := &mpi.FileOverview{}
+	if err := protojson.Unmarshal(entry.Overview, overview); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal stored file overview: %w", err)
+	}
+
+	return overview, nil
+}
+
+func removeConfigHistoryEntry(entries []*configHistoryEntry, version string) []*configHistoryEntry {
+	remaining := make([]*configHistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		overview, err := entry.fileOverview()
+		if err == nil && overview.GetConfigVersion().GetVersion() == version {
+			continue
+		}
+		remaining = append(remaining, entry)
+	}
+
+	return remaining
+}
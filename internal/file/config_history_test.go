@@ -0,0 +1,234 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/pkg/files"
+	"github.com/nginx/agent/v3/test/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigHistory_Save(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	history := newTestConfigHistory(t, 2, 0)
+
+	configPath := filepath.Join(tempDir, "nginx.conf")
+	mimeTypesPath := filepath.Join(tempDir, "mime.types")
+	require.NoError(t, os.WriteFile(mimeTypesPath, []byte("types {}\n"), 0o600))
+
+	saveVersion := func(version, content string) *mpi.FileOverview {
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0o600))
+
+		overview := configHistoryOverview(version, configPath, mimeTypesPath)
+		require.NoError(t, history.Save(ctx, overview))
+
+		return overview
+	}
+
+	saveVersion("v1", "worker_processes 1;\n")
+	saveVersion("v2", "worker_processes 2;\n")
+
+	versions, err := history.Versions("instance-1")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "v1", versions[0].GetConfigVersion().GetVersion())
+	assert.Equal(t, "v2", versions[1].GetConfigVersion().GetVersion())
+	assert.Equal(t, configPath, versions[1].GetConfigPath())
+	assert.Len(t, versions[1].GetFiles(), 2)
+	assert.NotNil(t, versions[1].GetAppliedTime())
+
+	// identical files are only stored once
+	assert.Len(t, configHistoryObjects(t, history), 3)
+
+	// applying a stored version again moves it to the end
+	saveVersion("v1", "worker_processes 1;\n")
+
+	versions, err = history.Versions("instance-1")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "v2", versions[0].GetConfigVersion().GetVersion())
+	assert.Equal(t, "v1", versions[1].GetConfigVersion().GetVersion())
+
+	// the oldest version is dropped and the objects only it referenced are removed
+	saveVersion("v3", "worker_processes 3;\n")
+
+	versions, err = history.Versions("instance-1")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "v1", versions[0].GetConfigVersion().GetVersion())
+	assert.Equal(t, "v3", versions[1].GetConfigVersion().GetVersion())
+	assert.Len(t, configHistoryObjects(t, history), 3)
+
+	restoredFileName := filepath.Join(tempDir, "restored.conf")
+	found, err := history.objects.Get(ctx, files.GenerateHash([]byte("worker_processes 2;\n")), restoredFileName,
+		"0640")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	found, err = history.objects.Get(ctx, files.GenerateHash([]byte("worker_processes 1;\n")), restoredFileName,
+		"0640")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	content, err := os.ReadFile(restoredFileName)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("worker_processes 1;\n"), content)
+}
+
+func TestConfigHistory_Save_CachedObjects(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	history := newTestConfigHistory(t, 1, 25)
+
+	configPath := filepath.Join(tempDir, "nginx.conf")
+	cachedPath := filepath.Join(tempDir, "cached.conf")
+	require.NoError(t, os.WriteFile(configPath, []byte("worker_processes 1;\n"), 0o600))
+	require.NoError(t, os.WriteFile(cachedPath, []byte("worker_processes auto;\n"), 0o600))
+
+	// objects referenced by the config history are not counted towards the cache size
+	require.NoError(t, history.Save(ctx, configHistoryOverview("v1", configPath)))
+	require.NoError(t, history.objects.Put(ctx, cachedPath))
+	assert.Len(t, configHistoryObjects(t, history), 2)
+
+	cachedObjectPath, err := history.objects.objectPath(files.GenerateHash([]byte("worker_processes auto;\n")))
+	require.NoError(t, err)
+
+	accessTime := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(cachedObjectPath, accessTime, accessTime))
+
+	// an object dropped from the config history stays in the cache, evicting the least recently used object
+	require.NoError(t, os.WriteFile(configPath, []byte("worker_processes 2;\n"), 0o600))
+	require.NoError(t, history.Save(ctx, configHistoryOverview("v2", configPath)))
+	assert.Len(t, configHistoryObjects(t, history), 2)
+	assert.NoFileExists(t, cachedObjectPath)
+
+	for _, content := range []string{"worker_processes 1;\n", "worker_processes 2;\n"} {
+		objectPath, pathErr := history.objects.objectPath(files.GenerateHash([]byte(content)))
+		require.NoError(t, pathErr)
+		assert.FileExists(t, objectPath)
+	}
+}
+
+func TestConfigHistory_Save_SkipsUnmanagedAndExternalFiles(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	history := newTestConfigHistory(t, 5, 0)
+
+	configPath := filepath.Join(tempDir, "nginx.conf")
+	require.NoError(t, os.WriteFile(configPath, []byte("worker_processes 1;\n"), 0o600))
+
+	overview := configHistoryOverview("v1", configPath)
+	overview.Files = append(overview.Files,
+		&mpi.File{
+			FileMeta:  protos.FileMeta(filepath.Join(tempDir, "unmanaged.conf"), ""),
+			Unmanaged: true,
+		},
+		&mpi.File{
+			FileMeta:           protos.FileMeta(filepath.Join(tempDir, "external.conf"), ""),
+			ExternalDataSource: &mpi.ExternalDataSource{Location: "https://example.com/external.conf"},
+		},
+	)
+
+	require.NoError(t, history.Save(ctx, overview))
+	assert.Len(t, configHistoryObjects(t, history), 1)
+
+	storedOverview, err := history.Overview("instance-1", "v1")
+	require.NoError(t, err)
+	require.Len(t, storedOverview.GetFiles(), 3)
+	assert.True(t, storedOverview.GetFiles()[1].GetUnmanaged())
+	assert.Equal(t, "https://example.com/external.conf",
+		storedOverview.GetFiles()[2].GetExternalDataSource().GetLocation())
+}
+
+func TestConfigHistory_Overview(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	history := newTestConfigHistory(t, 5, 0)
+
+	configPath := filepath.Join(tempDir, "nginx.conf")
+	content := []byte("worker_processes 1;\n")
+	require.NoError(t, os.WriteFile(configPath, content, 0o600))
+
+	// the hash of the stored file is the hash of the content on disk
+	overview := configHistoryOverview("v1", configPath)
+	overview.Files[0].FileMeta.Hash = "outdated"
+	require.NoError(t, history.Save(ctx, overview))
+
+	storedOverview, err := history.Overview("instance-1", "v1")
+	require.NoError(t, err)
+	assert.Equal(t, configPath, storedOverview.GetConfigPath())
+	assert.Equal(t, files.GenerateHash(content), storedOverview.GetFiles()[0].GetFileMeta().GetHash())
+	assert.Equal(t, int64(len(content)), storedOverview.GetFiles()[0].GetFileMeta().GetSize())
+
+	_, err = history.Overview("instance-1", "v2")
+	require.ErrorIs(t, err, errConfigVersionNotFound)
+
+	_, err = history.Overview("instance-2", "v1")
+	require.ErrorIs(t, err, errConfigVersionNotFound)
+
+	_, err = history.Overview("../instance-1", "v1")
+	require.ErrorContains(t, err, "invalid instance id")
+}
+
+func TestConfigHistory_Disabled(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	history := newTestConfigHistory(t, 0, 0)
+
+	configPath := filepath.Join(tempDir, "nginx.conf")
+	require.NoError(t, os.WriteFile(configPath, []byte("worker_processes 1;\n"), 0o600))
+
+	assert.False(t, history.Enabled())
+	require.NoError(t, history.Save(ctx, configHistoryOverview("v1", configPath)))
+	assert.NoDirExists(t, history.dir)
+	assert.NoDirExists(t, history.objects.dir)
+}
+
+func configHistoryOverview(version string, fileNames ...string) *mpi.FileOverview {
+	overview := &mpi.FileOverview{
+		ConfigVersion: &mpi.ConfigVersion{
+			InstanceId: "instance-1",
+			Version:    version,
+		},
+		ConfigPath: fileNames[0],
+	}
+
+	for _, fileName := range fileNames {
+		content, _ := os.ReadFile(fileName)
+		overview.Files = append(overview.Files, &mpi.File{
+			FileMeta: protos.FileMeta(fileName, files.GenerateHash(content)),
+		})
+	}
+
+	return overview
+}
+
+func newTestConfigHistory(t *testing.T, maxVersions int, maxCacheSize int64) *configHistory {
+	t.Helper()
+
+	libDir := t.TempDir()
+	objects := newObjectStore(filepath.Join(libDir, objectStoreDirName), maxCacheSize)
+
+	return newConfigHistory(filepath.Join(libDir, configHistoryDirName), maxVersions, objects)
+}
+
+func configHistoryObjects(t *testing.T, history *configHistory) []os.DirEntry {
+	t.Helper()
+
+	objects, err := os.ReadDir(history.objects.dir)
+	require.NoError(t, err)
+
+	return objects
+}
@@ -17,6 +17,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/gabriel-vasile/mimetype"
 	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
@@ -26,6 +27,7 @@ import (
 
 type ExternalFileOperator struct {
 	fileManagerService *FileManagerService
+	s3Credentials      *s3CredentialsCache
 }
 
 const (
@@ -36,7 +38,10 @@ const (
 )
 
 func NewExternalFileOperator(fms *FileManagerService) *ExternalFileOperator {
-	return &ExternalFileOperator{fileManagerService: fms}
+	return &ExternalFileOperator{
+		fileManagerService: fms,
+		s3Credentials:      &s3CredentialsCache{},
+	}
 }
 
 func (efo *ExternalFileOperator) DownloadExternalFile(ctx context.Context, fileAction *model.FileCache,
@@ -65,6 +70,10 @@ func (efo *ExternalFileOperator) DownloadExternalFile(ctx context.Context, fileA
 		slog.DebugContext(ctx, "External file unchanged (304), skipping disk write.",
 			"file", fileName)
 
+		if err := efo.verifyUnchangedFile(ctx, fileAction.File); err != nil {
+			return fmt.Errorf("downloaded file verification failed for %s: %w", fileName, err)
+		}
+
 		// preserve previous behavior: mark as unchanged so later rename is skipped
 		fileAction.Action = model.Unchanged
 
@@ -83,6 +92,10 @@ func (efo *ExternalFileOperator) DownloadExternalFile(ctx context.Context, fileA
 		return fmt.Errorf("downloaded file validation failed for %s: %w", fileName, err)
 	}
 
+	if err := efo.verifyDownloadedFile(ctx, fileAction.File, contentToWrite); err != nil {
+		return fmt.Errorf("downloaded file verification failed for %s: %w", fileName, err)
+	}
+
 	efo.fileManagerService.filesMutex.Lock()
 	efo.fileManagerService.externalFileHeaders[fileName] = headers
 	efo.fileManagerService.filesMutex.Unlock()
@@ -107,6 +120,57 @@ func (efo *ExternalFileOperator) DownloadExternalFile(ctx context.Context, fileA
 	return nil
 }
 
+// refreshExternalFile downloads an external file again, and writes it to the file path if it has changed since it
+// was last downloaded. Returns true if the file has changed.
+func (efo *ExternalFileOperator) refreshExternalFile(ctx context.Context, file *mpi.File, filePath string) (
+	bool, error,
+) {
+	fileName := file.GetFileMeta().GetName()
+	location := file.GetExternalDataSource().GetLocation()
+
+	content, headers, err := efo.downloadFileContent(ctx, file)
+	if err != nil {
+		return false, fmt.Errorf("failed to download file %s from %s: %w", fileName, location, err)
+	}
+
+	if headers.ETag != "" || headers.LastModified != "" {
+		efo.fileManagerService.filesMutex.Lock()
+		efo.fileManagerService.externalFileHeaders[fileName] = headers
+		efo.fileManagerService.filesMutex.Unlock()
+	}
+
+	if content == nil {
+		return false, nil
+	}
+
+	hash := files.GenerateHash(content)
+	fileMeta, metaErr := files.FileMeta(efo.fileManagerService.diskPath(fileName))
+	if metaErr == nil && fileMeta.GetHash() == hash {
+		slog.DebugContext(ctx, "External file unchanged", "file", fileName)
+		return false, nil
+	}
+
+	if err = efo.validateDownloadedFile(content, fileName); err != nil {
+		return false, fmt.Errorf("downloaded file validation failed for %s: %w", fileName, err)
+	}
+
+	if err = efo.verifyDownloadedFile(ctx, file, content); err != nil {
+		return false, fmt.Errorf("downloaded file verification failed for %s: %w", fileName, err)
+	}
+
+	if err = efo.fileManagerService.fileOperator.Write(ctx, content, filePath,
+		file.GetFileMeta().GetPermissions()); err != nil {
+		return false, fmt.Errorf("failed to write downloaded content to temp file %s: %w", filePath, err)
+	}
+
+	slog.InfoContext(ctx, "External file has changed",
+		"event_tag", externalFileEventTag,
+		"location", location,
+		"hash", hash)
+
+	return true, nil
+}
+
 //nolint:revive, cyclop // Can not break this function further without harming readability
 func (efo *ExternalFileOperator) downloadFileContent(ctx context.Context, file *mpi.File) (content []byte,
 	headers DownloadHeader, err error,
@@ -115,7 +179,9 @@ func (efo *ExternalFileOperator) downloadFileContent(ctx context.Context, file *
 	downloadURL := file.GetExternalDataSource().GetLocation()
 	externalConfig := efo.fileManagerService.agentConfig.ExternalDataSource
 
-	if !efo.isDomainAllowed(downloadURL, externalConfig.AllowedDomains) {
+	// the domain of an S3 location is checked once it is resolved to the URL of the S3 endpoint
+	if !strings.HasPrefix(downloadURL, s3Scheme+"://") &&
+		!efo.isDomainAllowed(downloadURL, externalConfig.AllowedDomains) {
 		return nil, DownloadHeader{}, fmt.Errorf("download URL %s is not in the allowed domains list", downloadURL)
 	}
 
@@ -125,7 +191,19 @@ func (efo *ExternalFileOperator) downloadFileContent(ctx context.Context, file *
 	}
 
 	originalScheme := pasrsedURl.Scheme
-	if originalScheme != httpScheme && originalScheme != httpsScheme {
+	switch originalScheme {
+	case httpScheme, httpsScheme:
+	case s3Scheme:
+		pasrsedURl, err = s3ObjectURL(pasrsedURl, externalConfig.S3)
+		if err != nil {
+			return nil, DownloadHeader{}, err
+		}
+
+		if !efo.isDomainAllowed(pasrsedURl.String(), externalConfig.AllowedDomains) {
+			return nil, DownloadHeader{}, fmt.Errorf("S3 URL %s of %s is not in the allowed domains list",
+				pasrsedURl.String(), downloadURL)
+		}
+	default:
 		pasrsedURl.Scheme = httpsScheme
 	}
 	networkURL := pasrsedURl.String()
@@ -140,7 +218,7 @@ func (efo *ExternalFileOperator) downloadFileContent(ctx context.Context, file *
 		return nil, DownloadHeader{}, fmt.Errorf("failed to create request for %s: %w", downloadURL, err)
 	}
 
-	if originalScheme != httpScheme && originalScheme != httpsScheme {
+	if originalScheme != httpScheme && originalScheme != httpsScheme && originalScheme != s3Scheme {
 		req.Header.Set("X-Original-Scheme", originalScheme)
 	}
 
@@ -150,6 +228,18 @@ func (efo *ExternalFileOperator) downloadFileContent(ctx context.Context, file *
 		slog.DebugContext(ctx, "No proxy configured; sending plain HTTP request without caching headers.")
 	}
 
+	// the request is signed last, since the signature covers the request headers
+	if originalScheme == s3Scheme {
+		credentials, credentialsErr := efo.s3Credentials.get(ctx, externalConfig.S3)
+		if credentialsErr != nil {
+			return nil, DownloadHeader{}, credentialsErr
+		}
+
+		if err = signS3Request(ctx, req, externalConfig.S3, credentials, time.Now()); err != nil {
+			return nil, DownloadHeader{}, err
+		}
+	}
+
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, DownloadHeader{}, fmt.Errorf("failed to execute download request for %s: %w", downloadURL, err)
@@ -260,6 +350,22 @@ func (efo *ExternalFileOperator) setupHTTPClient(ctx context.Context, proxyURLSt
 func (efo *ExternalFileOperator) addConditionalHeaders(ctx context.Context, req *http.Request, fileName string) {
 	slog.DebugContext(ctx, "Proxy configured; adding headers to GET request.")
 
+	// the headers of a file downloaded since the manifest file was written are more recent
+	efo.fileManagerService.filesMutex.RLock()
+	headers, found := efo.fileManagerService.externalFileHeaders[fileName]
+	efo.fileManagerService.filesMutex.RUnlock()
+
+	if found {
+		if headers.ETag != "" {
+			req.Header.Set("If-None-Match", headers.ETag)
+		}
+		if headers.LastModified != "" {
+			req.Header.Set("If-Modified-Since", headers.LastModified)
+		}
+
+		return
+	}
+
 	manifestFiles, _, manifestFileErr := efo.fileManagerService.manifestFile()
 
 	if manifestFileErr != nil && !errors.Is(manifestFileErr, os.ErrNotExist) {
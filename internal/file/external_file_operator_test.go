@@ -220,6 +220,68 @@ func TestFileManagerService_downloadExternalFiles(t *testing.T) {
 	}
 }
 
+func TestFileManagerService_RefreshExternalFiles(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	fileName := filepath.Join(tempDir, "blocklist.conf")
+
+	content := []byte("deny 192.0.2.1;")
+	require.NoError(t, os.WriteFile(fileName, content, 0o600))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(content)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{tempDir}
+	agentConfig.ExternalDataSource = &config.ExternalDataSource{
+		AllowedDomains: []string{u.Hostname()},
+	}
+
+	fileManagerService := NewFileManagerService(&v1fakes.FakeFileServiceClient{}, agentConfig, &sync.RWMutex{})
+	fileManagerService.agentConfig.LibDir = tempDir
+	fileManagerService.manifestFilePath = filepath.Join(tempDir, "manifest.json")
+
+	externalFiles := []*mpi.File{
+		{
+			FileMeta:           &mpi.FileMeta{Name: fileName, Permissions: "0600"},
+			ExternalDataSource: &mpi.ExternalDataSource{Location: ts.URL},
+		},
+	}
+
+	writeStatus, err := fileManagerService.RefreshExternalFiles(ctx, "instance-id", externalFiles)
+	require.NoError(t, err)
+	assert.Equal(t, model.NoChange, writeStatus)
+
+	content = []byte("deny 192.0.2.2;")
+
+	writeStatus, err = fileManagerService.RefreshExternalFiles(ctx, "instance-id", externalFiles)
+	require.NoError(t, err)
+	assert.Equal(t, model.OK, writeStatus)
+
+	data, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+	assert.NoFileExists(t, tempFilePath(fileName))
+
+	// the refreshed file is restored from its backup if NGINX fails to reload
+	err = fileManagerService.Rollback(ctx, "instance-id")
+	require.NoError(t, err)
+
+	data, err = os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, "deny 192.0.2.1;", string(data))
+	assert.NoFileExists(t, fileManagerService.manifestFilePath)
+
+	fileManagerService.ClearCache()
+	assert.NoFileExists(t, tempBackupFilePath(fileName))
+}
+
 func TestFileManagerService_DownloadFileContent_MaxBytesLimit(t *testing.T) {
 	ctx := context.Background()
 	fms := NewFileManagerService(nil, types.AgentConfig(), &sync.RWMutex{})
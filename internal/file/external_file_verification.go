@@ -0,0 +1,188 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/pkg/files"
+)
+
+const signatureFileExt = ".sig"
+
+var (
+	errDigestMismatch       = errors.New("sha256 digest does not match the expected digest")
+	errNoTrustedKeys        = errors.New("no trusted keys are configured to verify the signature")
+	errSignatureNotVerified = errors.New("signature is not verified by any of the trusted keys")
+)
+
+// verifyDownloadedFile verifies the sha256 digest and detached signature of a downloaded external file,
+// if they are set in the external data source of the file
+func (efo *ExternalFileOperator) verifyDownloadedFile(ctx context.Context, file *mpi.File, content []byte) error {
+	externalDataSource := file.GetExternalDataSource()
+
+	if externalDataSource.GetSha256() != "" {
+		if err := verifyDigest(externalDataSource.GetSha256(), files.GenerateHash(content)); err != nil {
+			return err
+		}
+	}
+
+	if externalDataSource.GetSignatureLocation() == "" {
+		return nil
+	}
+
+	trustedKeys, err := loadTrustedKeys(efo.fileManagerService.agentConfig.ExternalDataSource.TrustedKeys)
+	if err != nil {
+		return err
+	}
+
+	signature, err := efo.downloadSignature(ctx, file)
+	if err != nil {
+		return err
+	}
+
+	if err = verifySignature(content, signature, trustedKeys); err != nil {
+		return err
+	}
+
+	slog.DebugContext(ctx, "Verified signature of external file", "file", file.GetFileMeta().GetName(),
+		"signature_location", externalDataSource.GetSignatureLocation())
+
+	return nil
+}
+
+// verifyUnchangedFile verifies the sha256 digest and detached signature of an external file that has not been
+// modified since it was last downloaded, against the copy of the file on disk. The digest or signature may have
+// changed since the file was downloaded, so the file is verified again like a downloaded file.
+func (efo *ExternalFileOperator) verifyUnchangedFile(ctx context.Context, file *mpi.File) error {
+	if file.GetExternalDataSource().GetSha256() == "" && file.GetExternalDataSource().GetSignatureLocation() == "" {
+		return nil
+	}
+
+	content, err := os.ReadFile(efo.fileManagerService.diskPath(file.GetFileMeta().GetName()))
+	if err != nil {
+		return fmt.Errorf("unable to read file: %w", err)
+	}
+
+	return efo.verifyDownloadedFile(ctx, file, content)
+}
+
+func (efo *ExternalFileOperator) downloadSignature(ctx context.Context, file *mpi.File) ([]byte, error) {
+	location := file.GetExternalDataSource().GetSignatureLocation()
+
+	signature, _, err := efo.downloadFileContent(ctx, &mpi.File{
+		FileMeta:           &mpi.FileMeta{Name: file.GetFileMeta().GetName() + signatureFileExt},
+		ExternalDataSource: &mpi.ExternalDataSource{Location: location},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download signature from %s: %w", location, err)
+	}
+
+	if len(signature) == 0 {
+		return nil, fmt.Errorf("signature downloaded from %s is empty", location)
+	}
+
+	return decodeSignature(signature), nil
+}
+
+// verifyDigest compares an expected sha256 digest, which is either hex or base64 encoded, with a file hash
+func verifyDigest(expected, actualHash string) error {
+	expectedHash := expected
+	if decoded, err := hex.DecodeString(expected); err == nil && len(decoded) == sha256.Size {
+		expectedHash = base64.StdEncoding.EncodeToString(decoded)
+	}
+
+	if expectedHash != actualHash {
+		return fmt.Errorf("%w: expected %s, got %s", errDigestMismatch, expected, actualHash)
+	}
+
+	return nil
+}
+
+// verifySignature checks that the signature of the content is verified by at least one of the trusted keys.
+// ed25519 keys verify the content itself, while ECDSA keys verify the sha256 digest of the content, which is
+// the format of signatures created by cosign sign-blob.
+func verifySignature(content, signature []byte, trustedKeys []crypto.PublicKey) error {
+	digest := sha256.Sum256(content)
+
+	for _, trustedKey := range trustedKeys {
+		switch key := trustedKey.(type) {
+		case ed25519.PublicKey:
+			if ed25519.Verify(key, content, signature) {
+				return nil
+			}
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(key, digest[:], signature) {
+				return nil
+			}
+		}
+	}
+
+	return errSignatureNotVerified
+}
+
+// decodeSignature returns the decoded signature if it is base64 encoded, otherwise the signature is returned as is
+func decodeSignature(signature []byte) []byte {
+	trimmed := bytes.TrimSpace(signature)
+
+	decoded := make([]byte, base64.StdEncoding.DecodedLen(len(trimmed)))
+	n, err := base64.StdEncoding.Decode(decoded, trimmed)
+	if err != nil {
+		return signature
+	}
+
+	return decoded[:n]
+}
+
+// loadTrustedKeys reads the PEM encoded ed25519 and ECDSA public keys from the trusted key files
+func loadTrustedKeys(paths []string) ([]crypto.PublicKey, error) {
+	if len(paths) == 0 {
+		return nil, errNoTrustedKeys
+	}
+
+	trustedKeys := make([]crypto.PublicKey, 0, len(paths))
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read trusted key %s: %w", path, err)
+		}
+
+		block, _ := pem.Decode(content)
+		if block == nil {
+			return nil, fmt.Errorf("trusted key %s is not PEM encoded", path)
+		}
+
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse trusted key %s: %w", path, err)
+		}
+
+		switch key.(type) {
+		case ed25519.PublicKey, *ecdsa.PublicKey:
+			trustedKeys = append(trustedKeys, key)
+		default:
+			return nil, fmt.Errorf("trusted key %s has unsupported type %T, only ed25519 and ECDSA "+
+				"keys are supported", path, key)
+		}
+	}
+
+	return trustedKeys, nil
+}
@@ -0,0 +1,357 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/config"
+	"github.com/nginx/agent/v3/internal/model"
+	"github.com/nginx/agent/v3/pkg/files"
+	"github.com/nginx/agent/v3/test/types"
+)
+
+func writePublicKey(t *testing.T, dir, name string, key crypto.PublicKey) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	return path
+}
+
+func TestVerifyDigest(t *testing.T) {
+	content := []byte("deny 192.0.2.1;")
+	digest := sha256.Sum256(content)
+	hash := files.GenerateHash(content)
+
+	require.NoError(t, verifyDigest(hex.EncodeToString(digest[:]), hash))
+	require.NoError(t, verifyDigest(base64.StdEncoding.EncodeToString(digest[:]), hash))
+	require.ErrorIs(t, verifyDigest(hex.EncodeToString(digest[:]), files.GenerateHash([]byte("other"))),
+		errDigestMismatch)
+}
+
+func TestVerifySignature(t *testing.T) {
+	content := []byte("deny 192.0.2.1;")
+	digest := sha256.Sum256(content)
+
+	edPublicKey, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecdsaPrivateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	edSignature := ed25519.Sign(edPrivateKey, content)
+	ecdsaSignature, err := ecdsa.SignASN1(rand.Reader, ecdsaPrivateKey, digest[:])
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		signature   []byte
+		trustedKeys []crypto.PublicKey
+		verified    bool
+	}{
+		{
+			name:        "Test 1: ed25519 signature",
+			signature:   edSignature,
+			trustedKeys: []crypto.PublicKey{otherPublicKey, edPublicKey},
+			verified:    true,
+		},
+		{
+			name:        "Test 2: base64 encoded ed25519 signature",
+			signature:   []byte(base64.StdEncoding.EncodeToString(edSignature) + "\n"),
+			trustedKeys: []crypto.PublicKey{edPublicKey},
+			verified:    true,
+		},
+		{
+			name:        "Test 3: cosign signature",
+			signature:   []byte(base64.StdEncoding.EncodeToString(ecdsaSignature)),
+			trustedKeys: []crypto.PublicKey{&ecdsaPrivateKey.PublicKey},
+			verified:    true,
+		},
+		{
+			name:        "Test 4: untrusted key",
+			signature:   edSignature,
+			trustedKeys: []crypto.PublicKey{otherPublicKey, &ecdsaPrivateKey.PublicKey},
+			verified:    false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			err := verifySignature(content, decodeSignature(test.signature), test.trustedKeys)
+			if test.verified {
+				require.NoError(tt, err)
+			} else {
+				require.ErrorIs(tt, err, errSignatureNotVerified)
+			}
+		})
+	}
+}
+
+func TestLoadTrustedKeys(t *testing.T) {
+	tempDir := t.TempDir()
+
+	edPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecdsaPrivateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	edPath := writePublicKey(t, tempDir, "ed25519.pub", edPublicKey)
+	ecdsaPath := writePublicKey(t, tempDir, "cosign.pub", &ecdsaPrivateKey.PublicKey)
+	rsaPath := writePublicKey(t, tempDir, "rsa.pub", &rsaPrivateKey.PublicKey)
+
+	invalidPath := filepath.Join(tempDir, "invalid.pub")
+	require.NoError(t, os.WriteFile(invalidPath, []byte("invalid"), 0o600))
+
+	trustedKeys, err := loadTrustedKeys([]string{edPath, ecdsaPath})
+	require.NoError(t, err)
+	assert.Len(t, trustedKeys, 2)
+
+	_, err = loadTrustedKeys(nil)
+	require.ErrorIs(t, err, errNoTrustedKeys)
+
+	_, err = loadTrustedKeys([]string{rsaPath})
+	require.ErrorContains(t, err, "unsupported type")
+
+	_, err = loadTrustedKeys([]string{invalidPath})
+	require.ErrorContains(t, err, "not PEM encoded")
+
+	_, err = loadTrustedKeys([]string{filepath.Join(tempDir, "unknown.pub")})
+	require.ErrorContains(t, err, "unable to read trusted key")
+}
+
+func TestExternalFileOperator_DownloadExternalFile_Verification(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	content := []byte("deny 192.0.2.1;")
+	digest := sha256.Sum256(content)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, content))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+
+		if r.URL.Path == "/blocklist.conf.sig" {
+			_, _ = w.Write([]byte(signature))
+			return
+		}
+
+		_, _ = w.Write(content)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+
+	trustedKey := writePublicKey(t, tempDir, "trusted.pub", publicKey)
+	otherKey := writePublicKey(t, tempDir, "other.pub", otherPublicKey)
+
+	tests := []struct {
+		name              string
+		sha256            string
+		signatureLocation string
+		expectedError     string
+		trustedKeys       []string
+	}{
+		{
+			name:   "Test 1: digest matches",
+			sha256: hex.EncodeToString(digest[:]),
+		},
+		{
+			name:          "Test 2: digest does not match",
+			sha256:        hex.EncodeToString(make([]byte, sha256.Size)),
+			expectedError: errDigestMismatch.Error(),
+		},
+		{
+			name:              "Test 3: signature verified",
+			sha256:            hex.EncodeToString(digest[:]),
+			signatureLocation: ts.URL + "/blocklist.conf.sig",
+			trustedKeys:       []string{otherKey, trustedKey},
+		},
+		{
+			name:              "Test 4: signature not verified",
+			signatureLocation: ts.URL + "/blocklist.conf.sig",
+			trustedKeys:       []string{otherKey},
+			expectedError:     errSignatureNotVerified.Error(),
+		},
+		{
+			name:              "Test 5: no trusted keys",
+			signatureLocation: ts.URL + "/blocklist.conf.sig",
+			expectedError:     errNoTrustedKeys.Error(),
+		},
+		{
+			name:              "Test 6: signature location not allowed",
+			signatureLocation: "https://untrusted.example.com/blocklist.conf.sig",
+			trustedKeys:       []string{trustedKey},
+			expectedError:     "failed to download signature",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			agentConfig := types.AgentConfig()
+			agentConfig.ExternalDataSource = &config.ExternalDataSource{
+				AllowedDomains: []string{u.Hostname()},
+				TrustedKeys:    test.trustedKeys,
+			}
+
+			fileManagerService := NewFileManagerService(nil, agentConfig, &sync.RWMutex{})
+
+			fileName := filepath.Join(tt.TempDir(), "blocklist.conf")
+			fileAction := &model.FileCache{
+				File: &mpi.File{
+					FileMeta: &mpi.FileMeta{Name: fileName, Permissions: "0600"},
+					ExternalDataSource: &mpi.ExternalDataSource{
+						Location:          ts.URL + "/blocklist.conf",
+						Sha256:            test.sha256,
+						SignatureLocation: test.signatureLocation,
+					},
+				},
+				Action: model.ExternalFile,
+			}
+
+			err := fileManagerService.externalFileOperator.DownloadExternalFile(ctx, fileAction,
+				tempFilePath(fileName))
+			if test.expectedError != "" {
+				require.ErrorContains(tt, err, "downloaded file verification failed")
+				require.ErrorContains(tt, err, test.expectedError)
+				assert.NoFileExists(tt, tempFilePath(fileName))
+
+				return
+			}
+
+			require.NoError(tt, err)
+
+			data, err := os.ReadFile(tempFilePath(fileName))
+			require.NoError(tt, err)
+			assert.Equal(tt, content, data)
+		})
+	}
+}
+
+func TestExternalFileOperator_DownloadExternalFile_VerificationNotModified(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	content := []byte("deny 192.0.2.1;")
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, content))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/blocklist.conf.sig" {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(signature))
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+
+	trustedKey := writePublicKey(t, tempDir, "trusted.pub", publicKey)
+	otherKey := writePublicKey(t, tempDir, "other.pub", otherPublicKey)
+
+	// the file on disk is read from the root path of the instance
+	rootPath := t.TempDir()
+	fileName := "/etc/nginx/blocklist.conf"
+	require.NoError(t, os.MkdirAll(filepath.Join(rootPath, "etc", "nginx"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(rootPath, fileName), content, 0o600))
+
+	tests := []struct {
+		name          string
+		expectedError string
+		trustedKeys   []string
+	}{
+		{
+			name:        "Test 1: signature verified",
+			trustedKeys: []string{trustedKey},
+		},
+		{
+			name:          "Test 2: signature not verified",
+			trustedKeys:   []string{otherKey},
+			expectedError: errSignatureNotVerified.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			agentConfig := types.AgentConfig()
+			agentConfig.ExternalDataSource = &config.ExternalDataSource{
+				AllowedDomains: []string{u.Hostname()},
+				TrustedKeys:    test.trustedKeys,
+			}
+
+			fileManagerService := NewFileManagerService(nil, agentConfig, &sync.RWMutex{})
+			fileManagerService.SetRootPath(rootPath)
+
+			fileAction := &model.FileCache{
+				File: &mpi.File{
+					FileMeta: &mpi.FileMeta{Name: fileName, Permissions: "0600"},
+					ExternalDataSource: &mpi.ExternalDataSource{
+						Location:          ts.URL + "/blocklist.conf",
+						SignatureLocation: ts.URL + "/blocklist.conf.sig",
+					},
+				},
+				Action: model.ExternalFile,
+			}
+
+			err := fileManagerService.externalFileOperator.DownloadExternalFile(ctx, fileAction,
+				tempFilePath(filepath.Join(rootPath, fileName)))
+			if test.expectedError != "" {
+				require.ErrorContains(tt, err, "downloaded file verification failed")
+				require.ErrorContains(tt, err, test.expectedError)
+
+				return
+			}
+
+			require.NoError(tt, err)
+			assert.Equal(tt, model.Unchanged, fileAction.Action)
+		})
+	}
+}
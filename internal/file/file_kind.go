@@ -0,0 +1,235 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/model"
+	"github.com/nginx/agent/v3/pkg/files"
+)
+
+// maxSymlinks is the number of symbolic links that are followed to resolve a path, like filepath.EvalSymlinks
+const maxSymlinks = 255
+
+func isSymlink(file *mpi.File) bool {
+	return file.GetFileMeta().GetSymlinkMeta() != nil
+}
+
+func isDirectory(file *mpi.File) bool {
+	return file.GetFileMeta().GetDirectoryMeta() != nil
+}
+
+// hasContents returns false for symbolic links and directories, which are created on disk instead of downloaded
+func hasContents(file *mpi.File) bool {
+	return !isSymlink(file) && !isDirectory(file)
+}
+
+// symlinkTarget returns the cleaned absolute path a symbolic link points to
+func symlinkTarget(fileMeta *mpi.FileMeta) string {
+	target := fileMeta.GetSymlinkMeta().GetTarget()
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(fileMeta.GetName()), target)
+	}
+
+	return filepath.Clean(target)
+}
+
+// evalSymlinksInRoot returns the path of a file after the symbolic links in it are resolved like filepath.EvalSymlinks,
+// but in the root directory of an instance in another container, so an absolute link target is a path in the root
+// directory. The returned path is the path in the root directory.
+func evalSymlinksInRoot(rootPath, fileName string) (string, error) {
+	if rootPath == "" {
+		return filepath.EvalSymlinks(fileName)
+	}
+
+	resolved := string(filepath.Separator)
+	remaining := strings.Split(filepath.Clean(fileName), string(filepath.Separator))
+	links := 0
+
+	for len(remaining) > 0 {
+		name := remaining[0]
+		remaining = remaining[1:]
+
+		if name == "" || name == "." {
+			continue
+		}
+
+		if name == ".." {
+			resolved = filepath.Dir(resolved)
+			continue
+		}
+
+		next := filepath.Join(resolved, name)
+
+		fileInfo, err := os.Lstat(filepath.Join(rootPath, next))
+		if err != nil {
+			return "", err
+		}
+
+		if fileInfo.Mode()&os.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+
+		links++
+		if links > maxSymlinks {
+			return "", fmt.Errorf("too many symbolic links in %s", fileName)
+		}
+
+		target, err := os.Readlink(filepath.Join(rootPath, next))
+		if err != nil {
+			return "", err
+		}
+
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(resolved, target)
+		}
+
+		remaining = append(strings.Split(target, string(filepath.Separator)), remaining...)
+		resolved = string(filepath.Separator)
+	}
+
+	return resolved, nil
+}
+
+// linkOrDirectoryAction determines the file action for a symbolic link or directory by comparing it with the file
+// on disk, since there are no contents to compare the hashes of.
+func linkOrDirectoryAction(file *mpi.File) (model.FileAction, error) {
+	fileName := file.GetFileMeta().GetName()
+
+	fileInfo, err := os.Lstat(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return model.Add, nil
+	}
+
+	if err != nil {
+		return model.Unchanged, fmt.Errorf("unable to stat file %s: %w", fileName, err)
+	}
+
+	switch {
+	case isDirectory(file):
+		if fileInfo.IsDir() {
+			return model.Unchanged, nil
+		}
+	case fileInfo.IsDir():
+		return model.Unchanged, fmt.Errorf(
+			"unable to create symbolic link %s since a directory with the same name already exists",
+			fileName,
+		)
+	case fileInfo.Mode()&os.ModeSymlink != 0:
+		target, readErr := os.Readlink(fileName)
+		if readErr != nil {
+			return model.Unchanged, fmt.Errorf("unable to read symbolic link %s: %w", fileName, readErr)
+		}
+
+		if target == file.GetFileMeta().GetSymlinkMeta().GetTarget() {
+			return model.Unchanged, nil
+		}
+	}
+
+	return model.Update, nil
+}
+
+// createLinkOrDirectory creates a symbolic link or directory in place of the file on disk, which has already been
+// backed up. A symbolic link is created next to the file and renamed over it, so the file is replaced atomically.
+func createLinkOrDirectory(ctx context.Context, fileMeta *mpi.FileMeta) error {
+	fileName := fileMeta.GetName()
+
+	if err := os.MkdirAll(filepath.Dir(fileName), dirPerm); err != nil {
+		return fmt.Errorf("failed to create directories for %s: %w", fileName, err)
+	}
+
+	if fileMeta.GetDirectoryMeta() != nil {
+		return createDirectory(ctx, fileMeta)
+	}
+
+	tempLinkPath := tempFilePath(fileName)
+	removeTempFile(ctx, tempLinkPath)
+
+	if err := os.Symlink(fileMeta.GetSymlinkMeta().GetTarget(), tempLinkPath); err != nil {
+		return fmt.Errorf("failed to create symbolic link %s: %w", fileName, err)
+	}
+
+	if err := os.Rename(tempLinkPath, fileName); err != nil {
+		return fmt.Errorf("failed to rename symbolic link %s: %w", fileName, err)
+	}
+
+	slog.InfoContext(ctx, "Created symbolic link", "file", fileName, "target", fileMeta.GetSymlinkMeta().GetTarget())
+
+	return nil
+}
+
+func createDirectory(ctx context.Context, fileMeta *mpi.FileMeta) error {
+	fileName := fileMeta.GetName()
+
+	perm := os.FileMode(dirPerm)
+	if fileMeta.GetPermissions() != "" {
+		perm = files.FileMode(fileMeta.GetPermissions())
+	}
+
+	// a file that is replaced by the directory
+	if fileInfo, err := os.Lstat(fileName); err == nil && !fileInfo.IsDir() {
+		if err = os.Remove(fileName); err != nil {
+			return fmt.Errorf("failed to remove file %s: %w", fileName, err)
+		}
+	}
+
+	if err := os.Mkdir(fileName, perm); err != nil && !errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("failed to create directory %s: %w", fileName, err)
+	}
+
+	// the permissions passed to mkdir are masked by the umask
+	if err := os.Chmod(fileName, perm); err != nil {
+		return fmt.Errorf("failed to change directory permissions %s: %w", fileName, err)
+	}
+
+	slog.InfoContext(ctx, "Created directory", "file", fileName)
+
+	return nil
+}
+
+// removeDirectory removes an empty directory. A directory that is not empty is kept, since the files in it are not
+// managed as part of the directory.
+func removeDirectory(ctx context.Context, fileName string) error {
+	err := os.Remove(fileName)
+	if errors.Is(err, syscall.ENOTEMPTY) || errors.Is(err, syscall.EEXIST) {
+		slog.WarnContext(ctx, "Directory is not empty, skipping deletion", "file", fileName)
+		return nil
+	}
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error deleting directory: %s error: %w", fileName, err)
+	}
+
+	return nil
+}
+
+// removeAddedDirectories removes the directories that were added by a config apply that is rolled back. The deepest
+// directories are removed first, so that nested directories are already removed when their parent is.
+func removeAddedDirectories(ctx context.Context, directories []string) error {
+	slices.SortFunc(directories, func(a, b string) int {
+		return len(b) - len(a)
+	})
+
+	var rollbackErr error
+
+	for _, directory := range directories {
+		slog.InfoContext(ctx, "Deleting directory", "file", directory)
+		rollbackErr = errors.Join(rollbackErr, removeDirectory(ctx, directory))
+	}
+
+	return rollbackErr
+}
@@ -0,0 +1,191 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/model"
+)
+
+func symlinkFile(name, target string) *mpi.File {
+	return &mpi.File{
+		FileMeta: &mpi.FileMeta{
+			Name:     name,
+			FileType: &mpi.FileMeta_SymlinkMeta{SymlinkMeta: &mpi.SymlinkMeta{Target: target}},
+		},
+	}
+}
+
+func directoryFile(name string) *mpi.File {
+	return &mpi.File{
+		FileMeta: &mpi.FileMeta{
+			Name:        name,
+			Permissions: "0755",
+			FileType:    &mpi.FileMeta_DirectoryMeta{DirectoryMeta: &mpi.DirectoryMeta{}},
+		},
+	}
+}
+
+func TestSymlinkTarget(t *testing.T) {
+	assert.Equal(t, "/etc/nginx/sites-available/site.conf",
+		symlinkTarget(symlinkFile("/etc/nginx/sites-enabled/site.conf", "../sites-available/site.conf").GetFileMeta()))
+	assert.Equal(t, "/etc/nginx/sites-available/site.conf",
+		symlinkTarget(symlinkFile("/etc/nginx/sites-enabled/site.conf", "/etc/nginx/sites-available/site.conf").
+			GetFileMeta()))
+}
+
+func TestEvalSymlinksInRoot(t *testing.T) {
+	rootPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(rootPath, "etc", "nginx", "sites"), dirPerm))
+	require.NoError(t, os.Symlink("/etc/nginx/sites", filepath.Join(rootPath, "etc", "nginx", "absolute")))
+	require.NoError(t, os.Symlink("../nginx/sites", filepath.Join(rootPath, "etc", "nginx", "relative")))
+	require.NoError(t, os.Symlink("/etc/nginx/loop", filepath.Join(rootPath, "etc", "nginx", "loop")))
+
+	resolved, err := evalSymlinksInRoot(rootPath, "/etc/nginx/absolute")
+	require.NoError(t, err)
+	assert.Equal(t, "/etc/nginx/sites", resolved)
+
+	resolved, err = evalSymlinksInRoot(rootPath, "/etc/nginx/relative/../sites")
+	require.NoError(t, err)
+	assert.Equal(t, "/etc/nginx/sites", resolved)
+
+	_, err = evalSymlinksInRoot(rootPath, "/etc/nginx/loop")
+	require.EqualError(t, err, "too many symbolic links in /etc/nginx/loop")
+
+	_, err = evalSymlinksInRoot(rootPath, "/etc/nginx/missing")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestLinkOrDirectoryAction(t *testing.T) {
+	tempDir := t.TempDir()
+
+	regularFilePath := filepath.Join(tempDir, "nginx.conf")
+	require.NoError(t, os.WriteFile(regularFilePath, []byte("server {}"), 0o600))
+
+	linkPath := filepath.Join(tempDir, "site.conf")
+	require.NoError(t, os.Symlink("nginx.conf", linkPath))
+
+	dirPath := filepath.Join(tempDir, "conf.d")
+	require.NoError(t, os.Mkdir(dirPath, dirPerm))
+
+	tests := []struct {
+		file           *mpi.File
+		name           string
+		expectedError  string
+		expectedAction model.FileAction
+	}{
+		{
+			name:           "Test 1: new symbolic link",
+			file:           symlinkFile(filepath.Join(tempDir, "new.conf"), "nginx.conf"),
+			expectedAction: model.Add,
+		},
+		{
+			name:           "Test 2: unchanged symbolic link",
+			file:           symlinkFile(linkPath, "nginx.conf"),
+			expectedAction: model.Unchanged,
+		},
+		{
+			name:           "Test 3: symbolic link with new target",
+			file:           symlinkFile(linkPath, "other.conf"),
+			expectedAction: model.Update,
+		},
+		{
+			name:           "Test 4: symbolic link replacing a file",
+			file:           symlinkFile(regularFilePath, "other.conf"),
+			expectedAction: model.Update,
+		},
+		{
+			name:          "Test 5: symbolic link replacing a directory",
+			file:          symlinkFile(dirPath, "other.conf"),
+			expectedError: "unable to create symbolic link " + dirPath + " since a directory with the same name already exists",
+		},
+		{
+			name:           "Test 6: new directory",
+			file:           directoryFile(filepath.Join(tempDir, "new.d")),
+			expectedAction: model.Add,
+		},
+		{
+			name:           "Test 7: unchanged directory",
+			file:           directoryFile(dirPath),
+			expectedAction: model.Unchanged,
+		},
+		{
+			name:           "Test 8: directory replacing a file",
+			file:           directoryFile(regularFilePath),
+			expectedAction: model.Update,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			action, err := linkOrDirectoryAction(test.file)
+			if test.expectedError != "" {
+				require.EqualError(tt, err, test.expectedError)
+				return
+			}
+
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expectedAction, action)
+		})
+	}
+}
+
+func TestCreateLinkOrDirectory(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	linkPath := filepath.Join(tempDir, "sites-enabled", "site.conf")
+	require.NoError(t, createLinkOrDirectory(ctx, symlinkFile(linkPath, "../sites-available/site.conf").GetFileMeta()))
+
+	target, err := os.Readlink(linkPath)
+	require.NoError(t, err)
+	assert.Equal(t, "../sites-available/site.conf", target)
+
+	// an existing link is replaced
+	require.NoError(t, createLinkOrDirectory(ctx, symlinkFile(linkPath, "../sites-available/other.conf").GetFileMeta()))
+
+	target, err = os.Readlink(linkPath)
+	require.NoError(t, err)
+	assert.Equal(t, "../sites-available/other.conf", target)
+	assert.NoFileExists(t, tempFilePath(linkPath))
+
+	// an existing file is replaced
+	filePath := filepath.Join(tempDir, "conf.d")
+	require.NoError(t, os.WriteFile(filePath, []byte("server {}"), 0o600))
+	require.NoError(t, createLinkOrDirectory(ctx, directoryFile(filePath).GetFileMeta()))
+
+	fileInfo, err := os.Stat(filePath)
+	require.NoError(t, err)
+	assert.True(t, fileInfo.IsDir())
+	assert.Equal(t, os.FileMode(0o755), fileInfo.Mode().Perm())
+}
+
+func TestRemoveAddedDirectories(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	parentDir := filepath.Join(tempDir, "parent")
+	childDir := filepath.Join(parentDir, "child")
+	nonEmptyDir := filepath.Join(tempDir, "non-empty")
+
+	require.NoError(t, os.MkdirAll(childDir, dirPerm))
+	require.NoError(t, os.MkdirAll(nonEmptyDir, dirPerm))
+	require.NoError(t, os.WriteFile(filepath.Join(nonEmptyDir, "nginx.conf"), []byte("server {}"), 0o600))
+
+	err := removeAddedDirectories(ctx, []string{parentDir, nonEmptyDir, childDir, filepath.Join(tempDir, "missing")})
+	require.NoError(t, err)
+
+	assert.NoDirExists(t, parentDir)
+	assert.DirExists(t, nonEmptyDir)
+}
@@ -12,18 +12,23 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"sync"
 
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/nginx/agent/v3/internal/model"
 
 	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
 	"github.com/nginx/agent/v3/internal/config"
+	"github.com/nginx/agent/v3/internal/datasource/nginx"
+	"github.com/nginx/agent/v3/internal/logger"
 	"github.com/nginx/agent/v3/pkg/files"
 )
 
@@ -43,6 +48,9 @@ const (
 	executePerm = 0o111
 	// externalFileEventTag is used for internal event generation
 	externalFileEventTag = "ID-1310"
+	stagingDirPattern    = "config-validate-"
+	// maxTextDiffFileSize is the largest file a unified diff is created for in response to a config diff request
+	maxTextDiffFileSize = 256 * 1024
 )
 
 type DownloadHeader struct {
@@ -50,6 +58,17 @@ type DownloadHeader struct {
 	LastModified string
 }
 
+// InstanceFiles is the instance the files of a request are for. Requests for different instances are handled
+// concurrently, so requests that don't change the files on disk are passed the instance instead of using the
+// instance of the config apply in progress.
+type InstanceFiles struct {
+	// variables used to render templated files
+	TemplateData *TemplateData
+	// root directory of an instance in another container, the files of the instance are read and written through
+	// it, see nginx.RootPath. Empty if the instance runs in the same container as the agent.
+	RootPath string
+}
+
 type (
 	fileOperator interface {
 		Write(ctx context.Context, fileContent []byte, fileName, filePermissions string) error
@@ -60,6 +79,13 @@ type (
 			header *mpi.FileDataChunkHeader,
 			stream grpc.ServerStreamingClient[mpi.FileDataChunk],
 		) error
+		WriteChunkedFileDelta(
+			ctx context.Context,
+			fileName, filePermissions, basisFileName string,
+			header *mpi.FileDataChunkHeader,
+			signature *mpi.FileSignature,
+			stream grpc.ServerStreamingClient[mpi.FileDataChunk],
+		) error
 		ReadChunk(
 			ctx context.Context,
 			chunkSize uint32,
@@ -73,37 +99,58 @@ type (
 
 	fileServiceOperatorInterface interface {
 		File(ctx context.Context, file *mpi.File, tempFilePath, expectedHash string) error
+		FileContents(ctx context.Context, file *mpi.File) ([]byte, error)
 		UpdateOverview(ctx context.Context, instanceID string, filesToUpdate []*mpi.File, configPath string,
 			iteration int) error
-		ChunkedFile(ctx context.Context, file *mpi.File, tempFilePath, expectedHash string) error
+		ChunkedFile(ctx context.Context, file *mpi.File, tempFilePath, expectedHash, basisFilePath string) error
 		IsConnected() bool
 		UpdateFile(
 			ctx context.Context,
 			instanceID string,
 			fileToUpdate *mpi.File,
 		) error
+		UpdateFileContents(ctx context.Context, instanceID string, fileToUpdate *mpi.File, contents []byte) error
 		SetIsConnected(isConnected bool)
 		RenameFile(ctx context.Context, fileName, tempDir string) error
 		ValidateFileHash(ctx context.Context, fileName, expectedHash string) error
 		UpdateClient(ctx context.Context, fileServiceClient mpi.FileServiceClient)
+		RemovePartialFiles()
 	}
 
 	FileManagerServiceInterface interface {
 		ConfigApply(ctx context.Context, configApplyRequest *mpi.ConfigApplyRequest) (writeStatus model.WriteStatus,
 			err error)
+		RefreshExternalFiles(ctx context.Context, instanceID string, externalFiles []*mpi.File) (
+			writeStatus model.WriteStatus, err error)
 		Rollback(ctx context.Context, instanceID string) error
 		ClearCache()
 		ConfigUpload(ctx context.Context, configUploadRequest *mpi.ConfigUploadRequest) error
+		FileContents(ctx context.Context, file *mpi.File) ([]byte, error)
+		UploadFileContents(ctx context.Context, instanceID string, file *mpi.File, contents []byte) error
+		StageConfig(ctx context.Context, fileOverview *mpi.FileOverview, instanceFiles InstanceFiles) (
+			stagingDir string, err error)
+		ConfigDiff(ctx context.Context, configDiffRequest *mpi.ConfigDiffRequest, instanceFiles InstanceFiles) (
+			*mpi.ConfigChangeSet, error)
+		SaveConfigVersion(ctx context.Context, fileOverview *mpi.FileOverview) error
+		ConfigVersions(ctx context.Context, instanceID string) ([]*mpi.ConfigHistoryVersion, error)
+		ConfigVersionOverview(ctx context.Context, instanceID, version string) (*mpi.FileOverview, error)
+		SetConfigApplyPhase(ctx context.Context, phase model.ConfigApplyPhase)
+		SetTemplateData(templateData *TemplateData)
+		SetRootPath(rootPath string)
+		ChangedFiles() []string
+		RecoverConfigApply(ctx context.Context) *model.ConfigApplyRecovery
 		ConfigUpdate(ctx context.Context, nginxConfigContext *model.NginxConfigContext)
 		UpdateCurrentFilesOnDisk(ctx context.Context, updateFiles map[string]*mpi.File, referenced bool) error
 		DetermineFileActions(
 			ctx context.Context,
 			currentFiles map[string]*mpi.File,
 			modifiedFiles map[string]*model.FileCache,
+			instanceFiles InstanceFiles,
 		) (map[string]*model.FileCache, error)
 		IsConnected() bool
 		SetIsConnected(isConnected bool)
 		ResetClient(ctx context.Context, fileServiceClient mpi.FileServiceClient)
+		ResetExternalDataSourceCredentials(ctx context.Context)
 	}
 )
 
@@ -111,6 +158,9 @@ type FileManagerService struct {
 	manifestLock         *sync.RWMutex
 	agentConfig          *config.Config
 	externalFileOperator *ExternalFileOperator
+	configHistory        *configHistory
+	objectStore          *objectStore
+	configApplyJournal   *configApplyJournal
 	fileOperator         fileOperator
 	fileServiceOperator  fileServiceOperatorInterface
 	// map of files and the actions performed on them during config apply
@@ -119,22 +169,39 @@ type FileManagerService struct {
 	currentFilesOnDisk    map[string]*mpi.File // key is file path
 	previousManifestFiles map[string]*model.ManifestFile
 	externalFileHeaders   map[string]DownloadHeader
-	manifestFilePath      string
-	rollbackManifest      bool
-	filesMutex            sync.RWMutex
+	// instance of the config apply or external file refresh in progress
+	instanceFiles    InstanceFiles
+	manifestFilePath string
+	rollbackManifest bool
+	filesMutex       sync.RWMutex
 }
 
 func NewFileManagerService(fileServiceClient mpi.FileServiceClient, agentConfig *config.Config,
 	manifestLock *sync.RWMutex,
 ) *FileManagerService {
+	configHistorySize := 0
+	var fileCacheSize int64
+	if agentConfig.DataPlaneConfig != nil && agentConfig.DataPlaneConfig.Nginx != nil {
+		configHistorySize = agentConfig.DataPlaneConfig.Nginx.ConfigHistorySize
+		fileCacheSize = agentConfig.DataPlaneConfig.Nginx.FileCacheSize
+	}
+
+	objectStore := newObjectStore(filepath.Join(agentConfig.LibDir, objectStoreDirName), fileCacheSize)
+
 	fileManagerService := &FileManagerService{
-		agentConfig:           agentConfig,
+		agentConfig: agentConfig,
+		configHistory: newConfigHistory(
+			filepath.Join(agentConfig.LibDir, configHistoryDirName), configHistorySize, objectStore,
+		),
+		objectStore:           objectStore,
+		configApplyJournal:    newConfigApplyJournal(agentConfig),
 		fileOperator:          NewFileOperator(manifestLock),
 		fileServiceOperator:   NewFileServiceOperator(agentConfig, fileServiceClient, manifestLock),
 		fileActions:           make(map[string]*model.FileCache),
 		currentFilesOnDisk:    make(map[string]*mpi.File),
 		previousManifestFiles: make(map[string]*model.ManifestFile),
 		externalFileHeaders:   make(map[string]DownloadHeader),
+		instanceFiles:         InstanceFiles{TemplateData: NewTemplateData(agentConfig, nil, nil)},
 		rollbackManifest:      true,
 		manifestFilePath:      agentConfig.LibDir + "/manifest.json",
 		manifestLock:          manifestLock,
@@ -151,6 +218,13 @@ func (fms *FileManagerService) ResetClient(ctx context.Context, fileServiceClien
 	slog.DebugContext(ctx, "File manager service reset client successfully")
 }
 
+// ResetExternalDataSourceCredentials makes the next download from an external data source read the credentials
+// files again, after the credential watcher detected that they changed
+func (fms *FileManagerService) ResetExternalDataSourceCredentials(ctx context.Context) {
+	fms.externalFileOperator.s3Credentials.reset()
+	slog.DebugContext(ctx, "File manager service reset external data source credentials")
+}
+
 func (fms *FileManagerService) IsConnected() bool {
 	return fms.fileServiceOperator.IsConnected()
 }
@@ -159,6 +233,23 @@ func (fms *FileManagerService) SetIsConnected(isConnected bool) {
 	fms.fileServiceOperator.SetIsConnected(isConnected)
 }
 
+// SetTemplateData sets the variables used to render templated files of the instance the next config apply is for
+func (fms *FileManagerService) SetTemplateData(templateData *TemplateData) {
+	fms.filesMutex.Lock()
+	defer fms.filesMutex.Unlock()
+
+	fms.instanceFiles.TemplateData = templateData
+}
+
+// SetRootPath sets the root directory of the instance the next config apply or external file refresh is for.
+// The root directory is empty unless the instance runs in another container, see nginx.RootPath.
+func (fms *FileManagerService) SetRootPath(rootPath string) {
+	fms.filesMutex.Lock()
+	defer fms.filesMutex.Unlock()
+
+	fms.instanceFiles.RootPath = rootPath
+}
+
 func (fms *FileManagerService) ConfigApply(ctx context.Context,
 	configApplyRequest *mpi.ConfigApplyRequest,
 ) (status model.WriteStatus, err error) {
@@ -170,11 +261,17 @@ func (fms *FileManagerService) ConfigApply(ctx context.Context,
 	}
 
 	// check if any file in request is outside the allowed directories
-	allowedErr := fms.checkAllowedDirectory(fileOverview.GetFiles())
+	allowedErr := fms.checkAllowedDirectory(fileOverview.GetFiles(), fms.instanceFiles)
 	if allowedErr != nil {
 		return model.Error, allowedErr
 	}
 
+	// check if any file in request is owned by a user or group that is not allowed
+	ownershipErr := fms.checkAllowedFileOwnership(fileOverview.GetFiles())
+	if ownershipErr != nil {
+		return model.Error, ownershipErr
+	}
+
 	permissionErr := fms.validateAndUpdateFilePermissions(ctx, fileOverview.GetFiles())
 	if permissionErr != nil {
 		return model.RollbackRequired, permissionErr
@@ -184,6 +281,7 @@ func (fms *FileManagerService) ConfigApply(ctx context.Context,
 		ctx,
 		fms.currentFilesOnDisk,
 		ConvertToMapOfFileCache(fileOverview.GetFiles()),
+		fms.instanceFiles,
 	)
 
 	if compareErr != nil {
@@ -196,6 +294,11 @@ func (fms *FileManagerService) ConfigApply(ctx context.Context,
 
 	fms.fileActions = diffFiles
 
+	journalErr := fms.beginConfigApplyJournal(ctx, fileOverview.GetConfigVersion().GetInstanceId())
+	if journalErr != nil {
+		return model.Error, journalErr
+	}
+
 	slog.DebugContext(ctx, "Executing config apply file actions", "actions", diffFiles)
 
 	rollbackTempFilesErr := fms.backupFiles(ctx)
@@ -203,6 +306,8 @@ func (fms *FileManagerService) ConfigApply(ctx context.Context,
 		return model.Error, rollbackTempFilesErr
 	}
 
+	fms.SetConfigApplyPhase(ctx, model.ConfigApplyBackedUp)
+
 	fileErr := fms.executeFileActions(ctx)
 	if fileErr != nil {
 		fms.rollbackManifest = false
@@ -218,12 +323,63 @@ func (fms *FileManagerService) ConfigApply(ctx context.Context,
 	return model.OK, nil
 }
 
+// RefreshExternalFiles downloads external files again and replaces the files that have changed since they were
+// last downloaded. The replaced files are backed up, so they can be restored with Rollback if NGINX fails to
+// reload. The manifest file isn't changed by a refresh, so it is not rolled back.
+func (fms *FileManagerService) RefreshExternalFiles(ctx context.Context, instanceID string,
+	externalFiles []*mpi.File,
+) (model.WriteStatus, error) {
+	fms.fileActions = make(map[string]*model.FileCache)
+	fms.rollbackManifest = false
+
+	for _, externalFile := range externalFiles {
+		fileName := externalFile.GetFileMeta().GetName()
+
+		changed, err := fms.externalFileOperator.refreshExternalFile(ctx, externalFile,
+			tempFilePath(fms.diskPath(fileName)))
+		if err != nil {
+			fms.deleteTempFiles(ctx)
+			return model.Error, err
+		}
+
+		if changed {
+			fms.fileActions[fileName] = &model.FileCache{File: externalFile, Action: model.ExternalFile}
+		}
+	}
+
+	if len(fms.fileActions) == 0 {
+		return model.NoChange, nil
+	}
+
+	if err := fms.beginConfigApplyJournal(ctx, instanceID); err != nil {
+		fms.deleteTempFiles(ctx)
+		return model.Error, err
+	}
+
+	if err := fms.backupFiles(ctx); err != nil {
+		fms.deleteTempFiles(ctx)
+		return model.Error, err
+	}
+
+	fms.SetConfigApplyPhase(ctx, model.ConfigApplyBackedUp)
+
+	if err := fms.moveOrDeleteFiles(ctx, nil); err != nil {
+		fms.deleteTempFiles(ctx)
+		return model.RollbackRequired, err
+	}
+
+	fms.SetConfigApplyPhase(ctx, model.ConfigApplyMoved)
+
+	return model.OK, nil
+}
+
 func (fms *FileManagerService) ClearCache() {
 	slog.Debug("Clearing cache and backup files")
 
 	for _, fileAction := range fms.fileActions {
-		if fileAction.Action == model.Update || fileAction.Action == model.Delete {
-			tempFilePath := tempBackupFilePath(fileAction.File.GetFileMeta().GetName())
+		if fileAction.Action == model.Update || fileAction.Action == model.Delete ||
+			fileAction.Action == model.ExternalFile {
+			tempFilePath := tempBackupFilePath(fms.diskPath(fileAction.File.GetFileMeta().GetName()))
 			if err := os.Remove(tempFilePath); err != nil && !os.IsNotExist(err) {
 				slog.Warn("Unable to delete backup file",
 					"file", fileAction.File.GetFileMeta().GetName(),
@@ -235,6 +391,12 @@ func (fms *FileManagerService) ClearCache() {
 
 	clear(fms.fileActions)
 	clear(fms.previousManifestFiles)
+
+	fms.fileServiceOperator.RemovePartialFiles()
+
+	if err := fms.configApplyJournal.Complete(); err != nil {
+		slog.Warn("Unable to complete config apply journal", "error", err)
+	}
 }
 
 //nolint:revive,cyclop // cognitive-complexity of 13 max is 12, loop is needed cant be broken up
@@ -243,11 +405,23 @@ func (fms *FileManagerService) Rollback(ctx context.Context, instanceID string)
 
 	fms.filesMutex.Lock()
 	defer fms.filesMutex.Unlock()
+
+	var addedDirectories []string
+
 	for _, fileAction := range fms.fileActions {
 		switch fileAction.Action {
 		case model.Add:
+			// currentFilesOnDisk needs to be updated after rollback action is performed
+			if isDirectory(fileAction.File) {
+				addedDirectories = append(addedDirectories, fms.diskPath(fileAction.File.GetFileMeta().GetName()))
+				delete(fms.currentFilesOnDisk, fileAction.File.GetFileMeta().GetName())
+
+				continue
+			}
+
 			slog.InfoContext(ctx, "Deleting file", "file", fileAction.File.GetFileMeta().GetName())
-			if err := os.Remove(fileAction.File.GetFileMeta().GetName()); err != nil && !os.IsNotExist(err) {
+			if err := os.Remove(fms.diskPath(fileAction.File.GetFileMeta().GetName())); err != nil &&
+				!os.IsNotExist(err) {
 				return fmt.Errorf("error deleting file: %s error: %w", fileAction.File.GetFileMeta().GetName(), err)
 			}
 
@@ -256,6 +430,16 @@ func (fms *FileManagerService) Rollback(ctx context.Context, instanceID string)
 
 			continue
 		case model.Delete, model.Update, model.ExternalFile:
+			// directories are not backed up, a deleted directory is created again
+			if fileAction.Action == model.Delete && isDirectory(fileAction.File) {
+				if err := createDirectory(ctx, fms.diskFile(fileAction.File).GetFileMeta()); err != nil {
+					return err
+				}
+				fms.currentFilesOnDisk[fileAction.File.GetFileMeta().GetName()] = fileAction.File
+
+				continue
+			}
+
 			content, err := fms.restoreFiles(ctx, fileAction)
 			if err != nil {
 				return err
@@ -263,6 +447,8 @@ func (fms *FileManagerService) Rollback(ctx context.Context, instanceID string)
 
 			// currentFilesOnDisk needs to be updated after rollback action is performed
 			fileAction.File.FileMeta.Hash = files.GenerateHash(content)
+			// a restored template is the previously rendered file, not the template source
+			fileAction.File.Template = nil
 			fms.currentFilesOnDisk[fileAction.File.GetFileMeta().GetName()] = fileAction.File
 		case model.Unchanged:
 			fallthrough
@@ -271,6 +457,10 @@ func (fms *FileManagerService) Rollback(ctx context.Context, instanceID string)
 		}
 	}
 
+	if err := removeAddedDirectories(ctx, addedDirectories); err != nil {
+		return err
+	}
+
 	if fms.rollbackManifest {
 		slog.DebugContext(ctx, "Rolling back manifest file", "manifest_previous", fms.previousManifestFiles)
 		manifestFileErr := fms.fileOperator.WriteManifestFile(
@@ -326,7 +516,7 @@ func (fms *FileManagerService) ConfigUpload(ctx context.Context, configUploadReq
 	errGroup, errGroupCtx := errgroup.WithContext(ctx)
 	errGroup.SetLimit(fms.agentConfig.Client.Grpc.MaxParallelFileOperations)
 
-	dirErr := fms.checkAllowedDirectory(uploadFiles)
+	dirErr := fms.checkAllowedDirectory(uploadFiles, fms.instanceFiles)
 
 	if dirErr != nil {
 		return dirErr
@@ -334,6 +524,7 @@ func (fms *FileManagerService) ConfigUpload(ctx context.Context, configUploadReq
 
 	for _, file := range uploadFiles {
 		errGroup.Go(func() error {
+			file = fms.withFileOwnership(errGroupCtx, file)
 			err := fms.fileServiceOperator.UpdateFile(
 				errGroupCtx,
 				configUploadRequest.GetOverview().GetConfigVersion().GetInstanceId(),
@@ -356,14 +547,359 @@ func (fms *FileManagerService) ConfigUpload(ctx context.Context, configUploadReq
 	return errGroup.Wait()
 }
 
+// FileContents gets the contents of a file from the management plane without writing it to disk, for instances
+// that are not configured through files
+func (fms *FileManagerService) FileContents(ctx context.Context, file *mpi.File) ([]byte, error) {
+	return fms.fileServiceOperator.FileContents(ctx, file)
+}
+
+// UploadFileContents sends the contents of a file that is not on disk to the management plane, for instances
+// that are not configured through files
+func (fms *FileManagerService) UploadFileContents(ctx context.Context, instanceID string, file *mpi.File,
+	contents []byte,
+) error {
+	return fms.fileServiceOperator.UpdateFileContents(ctx, instanceID, file, contents)
+}
+
+// StageConfig downloads the files of a file overview into a new staging directory, so that the configuration can be
+// validated without changing the files on disk. Each file is staged under its absolute path inside the staging
+// directory. Unmanaged files and external files already on disk are copied from disk. The staging directory of an
+// instance in another container is created in the root directory of the instance, so that NGINX can read the staged
+// files. The manifest file and current files on disk are not updated. The caller must remove the staging directory.
+func (fms *FileManagerService) StageConfig(ctx context.Context, fileOverview *mpi.FileOverview,
+	instanceFiles InstanceFiles,
+) (string, error) {
+	if fileOverview == nil {
+		return "", errors.New("fileOverview is nil")
+	}
+
+	// check if any file in request is outside the allowed directories
+	allowedErr := fms.checkAllowedDirectory(fileOverview.GetFiles(), instanceFiles)
+	if allowedErr != nil {
+		return "", allowedErr
+	}
+
+	stagingDir, err := fms.createStagingDirectory(instanceFiles)
+	if err != nil {
+		return "", err
+	}
+
+	slog.DebugContext(ctx, "Staging config files", "staging_dir", stagingDir)
+
+	errGroup, errGroupCtx := errgroup.WithContext(ctx)
+	errGroup.SetLimit(fms.agentConfig.Client.Grpc.MaxParallelFileOperations)
+
+	for _, file := range fileOverview.GetFiles() {
+		if !hasContents(file) {
+			continue
+		}
+
+		errGroup.Go(func() error {
+			stagedFilePath := StagedFilePath(stagingDir, file.GetFileMeta().GetName())
+			if err := fms.stageFile(errGroupCtx, instanceFiles, file, stagedFilePath); err != nil {
+				return err
+			}
+
+			return fms.renderTemplateFile(errGroupCtx, file, stagedFilePath, instanceFiles.TemplateData)
+		})
+	}
+
+	stageErr := errGroup.Wait()
+	if stageErr == nil {
+		// symbolic links are staged after the files, since they point to a staged file if there is one
+		stageErr = fms.stageLinksAndDirectories(ctx, instanceFiles, fileOverview.GetFiles(), stagingDir)
+	}
+
+	if stageErr != nil {
+		if removeErr := os.RemoveAll(stagingDir); removeErr != nil {
+			slog.WarnContext(ctx, "Unable to remove staging directory", "staging_dir", stagingDir, "error", removeErr)
+		}
+
+		return "", stageErr
+	}
+
+	return stagingDir, nil
+}
+
+// ConfigDiff determines the changes a config apply of the file overview would make to the files on disk,
+// without changing any files or the manifest file. If requested, a unified diff is created for every changed
+// text file that is under the size limit. The files are compared with the files of the given instance on disk.
+func (fms *FileManagerService) ConfigDiff(ctx context.Context,
+	configDiffRequest *mpi.ConfigDiffRequest, instanceFiles InstanceFiles,
+) (*mpi.ConfigChangeSet, error) {
+	fileOverview := configDiffRequest.GetOverview()
+
+	if fileOverview == nil {
+		return nil, errors.New("fileOverview is nil")
+	}
+
+	// check if any file in request is outside the allowed directories
+	allowedErr := fms.checkAllowedDirectory(fileOverview.GetFiles(), instanceFiles)
+	if allowedErr != nil {
+		return nil, allowedErr
+	}
+
+	modifiedFiles := ConvertToMapOfFileCache(fileOverview.GetFiles())
+
+	fms.filesMutex.RLock()
+	currentFiles := maps.Clone(fms.currentFilesOnDisk)
+	fms.filesMutex.RUnlock()
+
+	diffFiles, compareErr := fms.DetermineFileActions(ctx, currentFiles, modifiedFiles, instanceFiles)
+	if compareErr != nil {
+		return nil, compareErr
+	}
+
+	// DetermineFileActions sets the action of every file in the request, deleted files are only in the diff
+	for fileName, diffFile := range diffFiles {
+		if diffFile.Action == model.Delete {
+			modifiedFiles[fileName] = diffFile
+		}
+	}
+
+	fileNames := slices.Sorted(maps.Keys(modifiedFiles))
+	changes := make([]*mpi.FileChange, len(fileNames))
+
+	errGroup, errGroupCtx := errgroup.WithContext(ctx)
+	errGroup.SetLimit(fms.agentConfig.Client.Grpc.MaxParallelFileOperations)
+
+	for index, fileName := range fileNames {
+		errGroup.Go(func() (err error) {
+			changes[index], err = fms.fileChange(
+				errGroupCtx, instanceFiles, modifiedFiles[fileName], configDiffRequest.GetIncludeTextDiff(),
+			)
+
+			return err
+		})
+	}
+
+	if err := errGroup.Wait(); err != nil {
+		return nil, err
+	}
+
+	return &mpi.ConfigChangeSet{Changes: changes}, nil
+}
+
+// SaveConfigVersion adds a successfully applied config version to the config history of the instance.
+func (fms *FileManagerService) SaveConfigVersion(ctx context.Context, fileOverview *mpi.FileOverview) error {
+	return fms.configHistory.Save(ctx, fileOverview)
+}
+
+// ConfigVersions returns the config versions in the config history of an instance, oldest first.
+func (fms *FileManagerService) ConfigVersions(ctx context.Context, instanceID string) (
+	[]*mpi.ConfigHistoryVersion, error,
+) {
+	if !fms.configHistory.Enabled() {
+		return nil, errors.New("config history is disabled")
+	}
+
+	slog.DebugContext(ctx, "Reading config history", "instance_id", instanceID)
+
+	return fms.configHistory.Versions(instanceID)
+}
+
+// ConfigVersionOverview returns the file overview of a config version in the config history of an instance,
+// which can be applied to roll back to that config version.
+func (fms *FileManagerService) ConfigVersionOverview(ctx context.Context, instanceID, version string) (
+	*mpi.FileOverview, error,
+) {
+	if !fms.configHistory.Enabled() {
+		return nil, errors.New("config history is disabled")
+	}
+
+	slog.DebugContext(ctx, "Reading config version from config history", "instance_id", instanceID,
+		"version", version)
+
+	return fms.configHistory.Overview(instanceID, version)
+}
+
+// ChangedFiles returns the names of the files added, updated or deleted by the config apply in progress.
+func (fms *FileManagerService) ChangedFiles() []string {
+	changedFiles := make([]string, 0, len(fms.fileActions))
+	for fileName, fileAction := range fms.fileActions {
+		if fileAction.Action != model.Unchanged {
+			changedFiles = append(changedFiles, fileName)
+		}
+	}
+
+	slices.Sort(changedFiles)
+
+	return changedFiles
+}
+
+// SetConfigApplyPhase records in the config apply journal that a phase of the config apply in progress has finished.
+func (fms *FileManagerService) SetConfigApplyPhase(ctx context.Context, phase model.ConfigApplyPhase) {
+	if err := fms.configApplyJournal.SetPhase(phase); err != nil {
+		slog.WarnContext(ctx, "Unable to update config apply journal", "phase", phase, "error", err)
+	}
+}
+
+// RecoverConfigApply finishes a config apply that was interrupted because the agent stopped. If NGINX was already
+// reloaded the config apply is completed, otherwise the files and the manifest file are rolled back.
+// Returns nil if no config apply was interrupted.
+func (fms *FileManagerService) RecoverConfigApply(ctx context.Context) *model.ConfigApplyRecovery {
+	transaction, err := fms.configApplyJournal.Load()
+	if err != nil {
+		slog.ErrorContext(ctx, "Unable to recover interrupted config apply", "error", err)
+
+		if completeErr := fms.configApplyJournal.Complete(); completeErr != nil {
+			slog.WarnContext(ctx, "Unable to complete config apply journal", "error", completeErr)
+		}
+
+		return nil
+	}
+
+	if transaction == nil {
+		return nil
+	}
+
+	slog.InfoContext(ctx, "Recovering config apply interrupted by agent restart",
+		"correlation_id", transaction.CorrelationID, "instance_id", transaction.InstanceID,
+		"phase", transaction.Phase, "start_time", transaction.StartTime, "root_path", transaction.RootPath)
+
+	recovery := &model.ConfigApplyRecovery{
+		CorrelationID: transaction.CorrelationID,
+		InstanceID:    transaction.InstanceID,
+		Phase:         transaction.Phase,
+	}
+
+	// the files of an instance in another container are rolled back in the root directory of the instance
+	fms.SetRootPath(transaction.RootPath)
+	defer fms.SetRootPath("")
+
+	fms.fileActions = transaction.fileActions()
+
+	switch {
+	case transaction.Phase == model.ConfigApplyReloaded:
+		recovery.Completed = true
+	case !rootPathExists(transaction.RootPath):
+		// the files are never rolled back in the root directory of the agent instead
+		recovery.Error = fmt.Errorf("root directory %s of instance is not available", transaction.RootPath)
+	default:
+		recovery.Error = fms.rollbackInterruptedConfigApply(ctx, transaction)
+		// NGINX might have loaded the new files if they were all moved into place, e.g. by a restart of NGINX
+		recovery.ReloadRequired = transaction.Phase == model.ConfigApplyMoved
+	}
+
+	fms.ClearCache()
+
+	return recovery
+}
+
+// rootPathExists returns true if the root directory of an instance in another container still exists, which it
+// doesn't if the instance stopped while the agent was not running
+func rootPathExists(rootPath string) bool {
+	if rootPath == "" {
+		return true
+	}
+
+	_, err := os.Stat(rootPath)
+
+	return err == nil
+}
+
+func (fms *FileManagerService) beginConfigApplyJournal(ctx context.Context, instanceID string) error {
+	previousManifest, _, manifestErr := fms.manifestFile()
+	if manifestErr != nil && !errors.Is(manifestErr, os.ErrNotExist) {
+		return fmt.Errorf("unable to read manifest file: %w", manifestErr)
+	}
+
+	transactionFiles := make([]*configApplyTransactionFile, 0, len(fms.fileActions))
+	for fileName, fileAction := range fms.fileActions {
+		transactionFiles = append(transactionFiles, &configApplyTransactionFile{
+			Name:   fileName,
+			Action: fileAction.Action,
+		})
+	}
+
+	err := fms.configApplyJournal.Begin(&configApplyTransaction{
+		PreviousManifest: previousManifest,
+		CorrelationID:    logger.CorrelationID(ctx),
+		InstanceID:       instanceID,
+		RootPath:         fms.instanceFiles.RootPath,
+		Files:            transactionFiles,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to begin config apply journal: %w", err)
+	}
+
+	return nil
+}
+
+// rollbackInterruptedConfigApply restores the backups of the files of an interrupted config apply and the
+// manifest file from before the config apply. Every file is rolled back even if an earlier file fails.
+func (fms *FileManagerService) rollbackInterruptedConfigApply(ctx context.Context,
+	transaction *configApplyTransaction,
+) error {
+	var rollbackErr error
+
+	var addedDirectories []string
+
+	for _, fileAction := range fms.fileActions {
+		fileName := fms.diskPath(fileAction.File.GetFileMeta().GetName())
+
+		switch fileAction.Action {
+		case model.Add:
+			if fileInfo, err := os.Lstat(fileName); err == nil && fileInfo.IsDir() {
+				addedDirectories = append(addedDirectories, fileName)
+				continue
+			}
+
+			slog.InfoContext(ctx, "Deleting file", "file", fileName)
+			if err := os.Remove(fileName); err != nil && !os.IsNotExist(err) {
+				rollbackErr = errors.Join(rollbackErr, fmt.Errorf("error deleting file: %s error: %w", fileName, err))
+			}
+		case model.Delete, model.Update, model.ExternalFile:
+			// directories are not backed up, a deleted directory is created again
+			previousFile := transaction.PreviousManifest[fileAction.File.GetFileMeta().GetName()]
+			if fileAction.Action == model.Delete && previousFile != nil && previousFile.ManifestFileMeta.Directory {
+				rollbackErr = errors.Join(rollbackErr,
+					createDirectory(ctx, fms.diskFile(fms.convertToFile(previousFile)).GetFileMeta()))
+				continue
+			}
+
+			// the file was not backed up yet, or didn't exist before the config apply
+			if _, err := os.Lstat(tempBackupFilePath(fileName)); err != nil {
+				continue
+			}
+
+			if _, err := fms.restoreFiles(ctx, fileAction); err != nil {
+				rollbackErr = errors.Join(rollbackErr, err)
+			}
+		case model.Unchanged:
+			fallthrough
+		default:
+			slog.DebugContext(ctx, "File Action not implemented")
+		}
+	}
+
+	rollbackErr = errors.Join(rollbackErr, removeAddedDirectories(ctx, addedDirectories))
+
+	fms.deleteTempFiles(ctx)
+
+	if transaction.PreviousManifest != nil {
+		slog.DebugContext(ctx, "Rolling back manifest file", "manifest_previous", transaction.PreviousManifest)
+		manifestFileErr := fms.fileOperator.WriteManifestFile(
+			ctx, transaction.PreviousManifest, fms.agentConfig.LibDir, fms.manifestFilePath,
+		)
+		if manifestFileErr != nil {
+			rollbackErr = errors.Join(rollbackErr, manifestFileErr)
+		}
+	}
+
+	return rollbackErr
+}
+
 // DetermineFileActions compares two sets of files to determine the file action for each file. Returns a map of files
-// that have changed and a map of the contents for each updated and deleted file. Key to both maps is file path
+// that have changed and a map of the contents for each updated and deleted file. Key to both maps is file path.
+// The files are compared with the files of the given instance on disk.
 //
 //nolint:gocognit,revive,cyclop // cognitive complexity is 23
 func (fms *FileManagerService) DetermineFileActions(
 	ctx context.Context,
 	currentFiles map[string]*mpi.File,
 	modifiedFiles map[string]*model.FileCache,
+	instanceFiles InstanceFiles,
 ) (
 	map[string]*model.FileCache,
 	error,
@@ -400,7 +936,7 @@ func (fms *FileManagerService) DetermineFileActions(
 		}
 
 		// if file doesn't exist on disk skip deletion
-		if _, err := os.Stat(fileName); os.IsNotExist(err) {
+		if _, err := os.Lstat(instanceFiles.diskPath(fileName)); os.IsNotExist(err) {
 			slog.DebugContext(ctx, "File already deleted, skipping", "file", fileName)
 			continue
 		}
@@ -435,6 +971,46 @@ func (fms *FileManagerService) DetermineFileActions(
 			continue
 		}
 
+		// Symbolic links and directories have no contents, so they are compared with the file on disk.
+		if !hasContents(modifiedFile.File) {
+			diskFile := instanceFiles.diskFile(modifiedFile.File)
+
+			action, err := linkOrDirectoryAction(diskFile)
+			if err == nil && action == model.Unchanged {
+				action, err = ownershipAction(diskFile.GetFileMeta(), instanceFiles.RootPath)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			modifiedFile.Action = action
+			if action != model.Unchanged {
+				slog.DebugContext(ctx, "Symbolic link or directory requires updating", "file_name", fileName)
+				fileDiff[fileName] = modifiedFile
+			}
+
+			continue
+		}
+
+		// Templates are rendered again if the template source, the template variables or the rendered file on
+		// disk have changed.
+		if modifiedFile.File.GetTemplate() != nil {
+			action, err := templateAction(instanceFiles.diskFile(modifiedFile.File), currentFile,
+				templateVariablesHash(instanceFiles.TemplateData))
+			if err != nil {
+				return nil, err
+			}
+
+			modifiedFile.Action = action
+			if action != model.Unchanged {
+				slog.DebugContext(ctx, "Template requires rendering", "file_name", fileName)
+				fileDiff[fileName] = modifiedFile
+			}
+
+			continue
+		}
+
 		// If file currently exists on disk, is being tracked in manifest and file hash is different.
 		// Treat it as a file update.
 		if ok && modifiedFile.File.GetFileMeta().GetHash() != currentFile.GetFileMeta().GetHash() {
@@ -445,7 +1021,7 @@ func (fms *FileManagerService) DetermineFileActions(
 			continue
 		}
 
-		fileStats, statErr := os.Stat(fileName)
+		fileStats, statErr := os.Stat(instanceFiles.diskPath(fileName))
 
 		// If file doesn't exist on disk.
 		// Treat it as adding a new file.
@@ -472,7 +1048,7 @@ func (fms *FileManagerService) DetermineFileActions(
 
 		// If file already exists on disk but is not being tracked in manifest and the file hash is different.
 		// Treat it as a file update.
-		metadataOfFileOnDisk, err := files.FileMeta(fileName)
+		metadataOfFileOnDisk, err := files.FileMeta(instanceFiles.diskPath(fileName))
 		if err != nil {
 			return nil, fmt.Errorf("unable to get file metadata for %s: %w", fileName, err)
 		}
@@ -481,6 +1057,20 @@ func (fms *FileManagerService) DetermineFileActions(
 			slog.DebugContext(ctx, "Untracked file requires updating", "file_name", fileName)
 			modifiedFile.Action = model.Update
 			fileDiff[fileName] = modifiedFile
+
+			continue
+		}
+
+		// The ownership of a file is not part of its hash, so it is compared with the file on disk.
+		action, err := ownershipAction(instanceFiles.diskFile(modifiedFile.File).GetFileMeta(), instanceFiles.RootPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if action == model.Update {
+			slog.DebugContext(ctx, "File ownership requires updating", "file_name", fileName)
+			modifiedFile.Action = model.Update
+			fileDiff[fileName] = modifiedFile
 		}
 	}
 
@@ -551,7 +1141,7 @@ func (fms *FileManagerService) UpdateManifestFile(ctx context.Context,
 			}
 		}
 		for manifestFileName, manifestFile := range manifestFiles {
-			updatedFiles[manifestFileName] = manifestFile
+			updatedFiles[manifestFileName] = keepTemplate(manifestFile, currentManifestFiles[manifestFileName])
 		}
 	} else {
 		updatedFiles = manifestFiles
@@ -566,9 +1156,10 @@ func (fms *FileManagerService) backupFiles(ctx context.Context) error {
 			continue
 		}
 
-		filePath := file.File.GetFileMeta().GetName()
+		filePath := fms.diskPath(file.File.GetFileMeta().GetName())
 
-		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		fileInfo, err := os.Lstat(filePath)
+		if os.IsNotExist(err) {
 			slog.DebugContext(ctx, "Unable to backup file content since file does not exist",
 				"file", filePath)
 
@@ -576,6 +1167,20 @@ func (fms *FileManagerService) backupFiles(ctx context.Context) error {
 		}
 
 		tempFilePath := tempBackupFilePath(filePath)
+
+		// directories have no contents to back up, and a symbolic link is backed up instead of the file it points to
+		if err == nil && fileInfo.IsDir() {
+			continue
+		} else if err == nil && fileInfo.Mode()&os.ModeSymlink != 0 {
+			slog.DebugContext(ctx, "Backing up symbolic link", "temp_path", tempFilePath)
+
+			if renameErr := os.Rename(filePath, tempFilePath); renameErr != nil {
+				return fmt.Errorf("failed to backup symbolic link %s: %w", filePath, renameErr)
+			}
+
+			continue
+		}
+
 		slog.DebugContext(ctx, "Attempting to backup file content since file exists", "temp_path", tempFilePath)
 
 		moveErr := fms.fileOperator.MoveFile(ctx, filePath, tempFilePath)
@@ -583,14 +1188,17 @@ func (fms *FileManagerService) backupFiles(ctx context.Context) error {
 		if moveErr != nil {
 			return moveErr
 		}
+
+		if cacheErr := fms.objectStore.Put(ctx, tempFilePath); cacheErr != nil {
+			slog.WarnContext(ctx, "Unable to add backup file to file cache", "file", filePath, "error", cacheErr)
+		}
 	}
 
 	return nil
 }
 
 func (fms *FileManagerService) restoreFiles(ctx context.Context, fileAction *model.FileCache) ([]byte, error) {
-	fileMeta := fileAction.File.GetFileMeta()
-	fileName := fileMeta.GetName()
+	fileName := fms.diskPath(fileAction.File.GetFileMeta().GetName())
 
 	tempFilePath := tempBackupFilePath(fileName)
 
@@ -601,15 +1209,27 @@ func (fms *FileManagerService) restoreFiles(ctx context.Context, fileAction *mod
 
 	slog.InfoContext(ctx, "Restoring file from it's backup", "file", fileName, "backup_file", tempFilePath)
 
+	// a directory that replaced the file has to be removed before the file can be restored
+	if fileInfo, err := os.Lstat(fileName); err == nil && fileInfo.IsDir() {
+		if err = os.Remove(fileName); err != nil {
+			return nil, fmt.Errorf("failed to remove directory %s: %w", fileName, err)
+		}
+	}
+
 	moveErr := os.Rename(tempFilePath, fileName)
 	if moveErr != nil {
 		return nil, fmt.Errorf("failed to rename file, %s to %s: %w", tempFilePath, fileName, moveErr)
 	}
 
-	content, readErr := os.ReadFile(fileMeta.GetName())
+	// the contents of a symbolic link are its target
+	if target, err := os.Readlink(fileName); err == nil {
+		return []byte(target), nil
+	}
+
+	content, readErr := os.ReadFile(fileName)
 	if readErr != nil {
 		return nil, fmt.Errorf("error reading file, unable to generate hash: %s error: %w",
-			fileMeta.GetName(), readErr)
+			fileName, readErr)
 	}
 
 	return content, nil
@@ -650,19 +1270,29 @@ func (fms *FileManagerService) executeFileActions(ctx context.Context) (actionEr
 		return downloadError
 	}
 
+	fms.SetConfigApplyPhase(ctx, model.ConfigApplyDownloaded)
+
 	// Remove temp files if there is a failure moving or deleting files
 	actionError = fms.moveOrDeleteFiles(ctx, actionError)
 	if actionError != nil {
 		fms.deleteTempFiles(ctx)
+
+		return actionError
 	}
 
-	return actionError
+	fms.SetConfigApplyPhase(ctx, model.ConfigApplyMoved)
+
+	return nil
 }
 
 //nolint:revive // adding error logs increased cog. complexity
 func (fms *FileManagerService) downloadUpdatedFilesToTempLocation(ctx context.Context) (updateError error) {
 	var downloadFiles []*model.FileCache
 	for _, fileAction := range fms.fileActions {
+		if !hasContents(fileAction.File) {
+			continue
+		}
+
 		if fileAction.Action == model.Add || fileAction.Action == model.Update ||
 			fileAction.Action == model.ExternalFile {
 			downloadFiles = append(downloadFiles, fileAction)
@@ -679,7 +1309,7 @@ func (fms *FileManagerService) downloadUpdatedFilesToTempLocation(ctx context.Co
 
 	for _, fileAction := range downloadFiles {
 		errGroup.Go(func() error {
-			tempFilePath := tempFilePath(fileAction.File.GetFileMeta().GetName())
+			tempFilePath := tempFilePath(fms.diskPath(fileAction.File.GetFileMeta().GetName()))
 
 			switch fileAction.Action {
 			case model.ExternalFile:
@@ -699,7 +1329,12 @@ func (fms *FileManagerService) downloadUpdatedFilesToTempLocation(ctx context.Co
 					"file", tempFilePath,
 				)
 
-				return fms.fileUpdate(errGroupCtx, fileAction.File, tempFilePath)
+				if err := fms.fileUpdate(errGroupCtx, fileAction.File, tempFilePath); err != nil {
+					return err
+				}
+
+				return fms.renderTemplateFile(errGroupCtx, fileAction.File, tempFilePath,
+					fms.instanceFiles.TemplateData)
 			case model.Delete, model.Unchanged: // had to add for linter
 				return nil
 			default:
@@ -716,10 +1351,21 @@ func (fms *FileManagerService) moveOrDeleteFiles(ctx context.Context, actionErro
 actionsLoop:
 	for _, fileAction := range fms.fileActions {
 		var err error
-		fileMeta := fileAction.File.GetFileMeta()
+		// the file meta with the path of the file on disk
+		fileMeta := fms.diskFile(fileAction.File).GetFileMeta()
 		tempFilePath := tempFilePath(fileMeta.GetName())
 		switch fileAction.Action {
 		case model.Delete:
+			if isDirectory(fileAction.File) {
+				slog.InfoContext(ctx, "Deleting directory", "file", fileMeta.GetName())
+				if err = removeDirectory(ctx, fileMeta.GetName()); err != nil {
+					actionError = err
+					break actionsLoop
+				}
+
+				continue
+			}
+
 			slog.InfoContext(ctx, "Deleting file", "file", fileMeta.GetName())
 			if err = os.Remove(fileMeta.GetName()); err != nil && !os.IsNotExist(err) {
 				actionError = fmt.Errorf("error deleting file: %s error: %w",
@@ -730,14 +1376,35 @@ actionsLoop:
 
 			continue
 		case model.Add, model.Update:
+			if !hasContents(fileAction.File) {
+				err = createLinkOrDirectory(ctx, fileMeta)
+				if err != nil {
+					actionError = err
+					break actionsLoop
+				}
+				err = setFileOwnership(ctx, fileMeta, fms.instanceFiles.RootPath)
+
+				break
+			}
+
 			err = fms.fileServiceOperator.RenameFile(ctx, tempFilePath, fileMeta.GetName())
 			if err != nil {
 				actionError = err
 				break actionsLoop
 			}
-			err = fms.fileServiceOperator.ValidateFileHash(ctx, fileMeta.GetName(), fileMeta.GetHash())
+			err = fms.fileServiceOperator.ValidateFileHash(ctx, fileMeta.GetName(), expectedFileHash(fileAction.File))
+			if err != nil {
+				actionError = err
+				break actionsLoop
+			}
+			err = setFileOwnership(ctx, fileMeta, fms.instanceFiles.RootPath)
 		case model.ExternalFile:
 			err = fms.fileServiceOperator.RenameFile(ctx, tempFilePath, fileMeta.GetName())
+			if err != nil {
+				actionError = err
+				break actionsLoop
+			}
+			err = setFileOwnership(ctx, fileMeta, fms.instanceFiles.RootPath)
 		case model.Unchanged:
 			slog.DebugContext(ctx, "File unchanged")
 		}
@@ -752,8 +1419,9 @@ actionsLoop:
 
 func (fms *FileManagerService) deleteTempFiles(ctx context.Context) {
 	for _, fileAction := range fms.fileActions {
-		if fileAction.Action == model.Add || fileAction.Action == model.Update {
-			tempFilePath := tempFilePath(fileAction.File.GetFileMeta().GetName())
+		if fileAction.Action == model.Add || fileAction.Action == model.Update ||
+			fileAction.Action == model.ExternalFile {
+			tempFilePath := tempFilePath(fms.diskPath(fileAction.File.GetFileMeta().GetName()))
 			if err := os.Remove(tempFilePath); err != nil && !os.IsNotExist(err) {
 				slog.ErrorContext(
 					ctx, "Error deleting temp file",
@@ -768,26 +1436,390 @@ func (fms *FileManagerService) deleteTempFiles(ctx context.Context) {
 func (fms *FileManagerService) fileUpdate(ctx context.Context, file *mpi.File, tempFilePath string) error {
 	expectedHash := fms.fileActions[file.GetFileMeta().GetName()].File.GetFileMeta().GetHash()
 
+	// files of a config version in the config history, and files that were downloaded or backed up before for
+	// any instance, are copied from the object store, so rolling back to a config version does not depend on the
+	// management plane still having the files
+	found, cacheErr := fms.objectStore.Get(ctx, expectedHash, tempFilePath, file.GetFileMeta().GetPermissions())
+	if cacheErr != nil {
+		slog.WarnContext(ctx, "Unable to read file from object store", "file", file.GetFileMeta().GetName(),
+			"error", cacheErr)
+	} else if found {
+		slog.DebugContext(ctx, "Restoring file from object store", "file", file.GetFileMeta().GetName())
+
+		return nil
+	}
+
+	var err error
 	if file.GetFileMeta().GetSize() <= int64(fms.agentConfig.Client.Grpc.MaxFileSize) {
-		return fms.fileServiceOperator.File(ctx, file, tempFilePath, expectedHash)
+		err = fms.fileServiceOperator.File(ctx, file, tempFilePath, expectedHash)
+	} else {
+		err = fms.fileServiceOperator.ChunkedFile(ctx, file, tempFilePath, expectedHash,
+			fms.diskPath(file.GetFileMeta().GetName()))
 	}
 
-	return fms.fileServiceOperator.ChunkedFile(ctx, file, tempFilePath, expectedHash)
+	if err != nil {
+		return err
+	}
+
+	if cacheErr = fms.objectStore.Put(ctx, tempFilePath); cacheErr != nil {
+		slog.WarnContext(ctx, "Unable to add file to file cache", "file", file.GetFileMeta().GetName(),
+			"error", cacheErr)
+	}
+
+	return nil
 }
 
-func (fms *FileManagerService) checkAllowedDirectory(checkFiles []*mpi.File) error {
+// renderTemplateFile renders a templated file in place, after the template source has been downloaded.
+// Files that are not templates are left unchanged.
+func (fms *FileManagerService) renderTemplateFile(ctx context.Context, file *mpi.File, filePath string,
+	templateData *TemplateData,
+) error {
+	if file.GetTemplate() == nil {
+		return nil
+	}
+
+	fileName := file.GetFileMeta().GetName()
+
+	source, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("unable to read template %s: %w", fileName, err)
+	}
+
+	rendered, err := renderTemplate(fileName, source, templateData, maxRenderedTemplateSize)
+	if err != nil {
+		return err
+	}
+
+	if err = fms.fileOperator.Write(ctx, rendered, filePath, file.GetFileMeta().GetPermissions()); err != nil {
+		return err
+	}
+
+	file.GetTemplate().RenderedHash = files.GenerateHash(rendered)
+	file.GetTemplate().RenderedSize = int64(len(rendered))
+	file.GetTemplate().VariablesHash = templateVariablesHash(templateData)
+
+	slog.DebugContext(ctx, "Rendered template", "file", fileName, "rendered_hash", file.GetTemplate().GetRenderedHash())
+
+	return nil
+}
+
+func (fms *FileManagerService) fileChange(ctx context.Context, instanceFiles InstanceFiles,
+	fileCache *model.FileCache, includeTextDiff bool,
+) (*mpi.FileChange, error) {
+	fileMeta := fileCache.File.GetFileMeta()
+
+	fileChange := &mpi.FileChange{
+		Name:   fileMeta.GetName(),
+		Action: convertToFileActionProto(fileCache.Action),
+	}
+
+	// symbolic links and directories have no contents to compare
+	if !hasContents(fileCache.File) {
+		return fileChange, nil
+	}
+
+	if fileCache.Action != model.Delete {
+		fileChange.NewHash = fileMeta.GetHash()
+		fileChange.NewSize = fileMeta.GetSize()
+	}
+
+	if fileCache.Action != model.Add {
+		if metaOnDisk, err := files.FileMeta(instanceFiles.diskPath(fileMeta.GetName())); err == nil {
+			fileChange.OldHash = metaOnDisk.GetHash()
+			fileChange.OldSize = metaOnDisk.GetSize()
+		}
+	}
+
+	if !includeTextDiff {
+		return fileChange, nil
+	}
+
+	switch fileCache.Action {
+	case model.Add, model.Update, model.Delete:
+		diff, err := fms.textDiff(ctx, instanceFiles, fileCache, fileChange.GetOldSize())
+		if err != nil {
+			return nil, err
+		}
+		fileChange.Diff = diff
+	case model.Unchanged, model.ExternalFile:
+		slog.DebugContext(ctx, "No text diff required", "file", fileMeta.GetName())
+	}
+
+	return fileChange, nil
+}
+
+// textDiff creates a unified diff between the file on disk and the file in the request. An empty diff is returned if
+// either version of the file is not a text file or is over the size limit.
+func (fms *FileManagerService) textDiff(ctx context.Context, instanceFiles InstanceFiles,
+	fileCache *model.FileCache, oldSize int64,
+) (string, error) {
+	fileMeta := fileCache.File.GetFileMeta()
+	oldName, newName := fileMeta.GetName(), fileMeta.GetName()
+
+	var oldContent, newContent []byte
+
+	if oldSize > maxTextDiffFileSize || fileMeta.GetSize() > maxTextDiffFileSize {
+		slog.DebugContext(ctx, "File too large to create a text diff", "file", fileMeta.GetName())
+		return "", nil
+	}
+
+	if fileCache.Action == model.Add {
+		oldName = ""
+	} else {
+		content, err := os.ReadFile(instanceFiles.diskPath(fileMeta.GetName()))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("unable to read file %s: %w", fileMeta.GetName(), err)
+		}
+		oldContent = content
+	}
+
+	if fileCache.Action == model.Delete {
+		newName = ""
+	} else {
+		content, err := fms.fileServiceOperator.FileContents(ctx, fileCache.File)
+		if err != nil {
+			return "", err
+		}
+
+		if files.GenerateHash(content) != fileMeta.GetHash() {
+			return "", fmt.Errorf("hash mismatch for file %s", fileMeta.GetName())
+		}
+
+		// the file on disk is compared with the rendered template instead of the template source
+		if fileCache.File.GetTemplate() != nil {
+			content, err = renderTemplate(fileMeta.GetName(), content, instanceFiles.TemplateData, maxTextDiffFileSize)
+			if errors.Is(err, errRenderedTemplateTooLarge) {
+				slog.DebugContext(ctx, "Rendered template too large to create a text diff", "file", fileMeta.GetName())
+				return "", nil
+			}
+
+			if err != nil {
+				return "", err
+			}
+		}
+		newContent = content
+	}
+
+	if len(newContent) > maxTextDiffFileSize || !isTextContent(oldContent) || !isTextContent(newContent) {
+		slog.DebugContext(ctx, "Unable to create a text diff for file", "file", fileMeta.GetName())
+		return "", nil
+	}
+
+	diff, err := unifiedDiff(oldName, newName, oldContent, newContent)
+	if errors.Is(err, errDiffTooLarge) {
+		slog.DebugContext(ctx, "Too many differences to create a text diff", "file", fileMeta.GetName())
+		return "", nil
+	}
+
+	return diff, err
+}
+
+func (fms *FileManagerService) createStagingDirectory(instanceFiles InstanceFiles) (string, error) {
+	baseDir := os.TempDir()
+	if instanceFiles.RootPath != "" {
+		baseDir = instanceFiles.diskPath(baseDir)
+	} else if fms.agentConfig.LibDir != "" {
+		baseDir = fms.agentConfig.LibDir
+	}
+
+	if err := os.MkdirAll(baseDir, dirPerm); err != nil {
+		return "", fmt.Errorf("failed to create directory %s: %w", baseDir, err)
+	}
+
+	stagingDir, err := os.MkdirTemp(baseDir, stagingDirPattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	// NGINX worker processes may need to read the staged files
+	if err = os.Chmod(stagingDir, dirPerm); err != nil {
+		return "", fmt.Errorf("failed to set permissions for staging directory %s: %w", stagingDir, err)
+	}
+
+	return stagingDir, nil
+}
+
+func (fms *FileManagerService) stageFile(ctx context.Context, instanceFiles InstanceFiles, file *mpi.File,
+	stagedFilePath string,
+) error {
+	fileMeta := file.GetFileMeta()
+
+	if file.GetUnmanaged() {
+		return fms.copyFileToStagingDirectory(ctx, instanceFiles.diskPath(fileMeta.GetName()), stagedFilePath)
+	}
+
+	if file.GetExternalDataSource() != nil {
+		// The previously downloaded file is used if it exists, since downloading the file again would
+		// update the cached download headers used by config apply.
+		if _, err := os.Stat(instanceFiles.diskPath(fileMeta.GetName())); err == nil {
+			return fms.copyFileToStagingDirectory(ctx, instanceFiles.diskPath(fileMeta.GetName()), stagedFilePath)
+		}
+
+		content, _, err := fms.externalFileOperator.downloadFileContent(ctx, file)
+		if err != nil {
+			return fmt.Errorf("failed to download file %s from %s: %w",
+				fileMeta.GetName(), file.GetExternalDataSource().GetLocation(), err)
+		}
+
+		return fms.fileOperator.Write(ctx, content, stagedFilePath, fileMeta.GetPermissions())
+	}
+
+	slog.DebugContext(ctx, "Downloading file to staging directory", "file", stagedFilePath)
+
+	if fileMeta.GetSize() <= int64(fms.agentConfig.Client.Grpc.MaxFileSize) {
+		return fms.fileServiceOperator.File(ctx, file, stagedFilePath, fileMeta.GetHash())
+	}
+
+	return fms.fileServiceOperator.ChunkedFile(ctx, file, stagedFilePath, fileMeta.GetHash(),
+		instanceFiles.diskPath(fileMeta.GetName()))
+}
+
+// stageLinksAndDirectories creates the symbolic links and directories of a file overview in the staging directory.
+// A symbolic link points to the staged target if the target is staged, otherwise to the target on disk.
+func (fms *FileManagerService) stageLinksAndDirectories(ctx context.Context, instanceFiles InstanceFiles,
+	stageFiles []*mpi.File, stagingDir string,
+) error {
+	// symbolic links are resolved by NGINX in the root directory of an instance in another container
+	stagingDirInRoot, err := nginx.PathInRoot(instanceFiles.RootPath, stagingDir)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range stageFiles {
+		if hasContents(file) {
+			continue
+		}
+
+		fileMeta := file.GetFileMeta()
+		stagedFilePath := StagedFilePath(stagingDir, fileMeta.GetName())
+
+		if err := os.MkdirAll(filepath.Dir(stagedFilePath), dirPerm); err != nil {
+			return fmt.Errorf("failed to create directories for %s: %w", stagedFilePath, err)
+		}
+
+		if isDirectory(file) {
+			if err := os.MkdirAll(stagedFilePath, dirPerm); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", stagedFilePath, err)
+			}
+
+			continue
+		}
+
+		target := symlinkTarget(fileMeta)
+		if _, err := os.Lstat(StagedFilePath(stagingDir, target)); err == nil {
+			target = StagedFilePath(stagingDirInRoot, target)
+		}
+
+		slog.DebugContext(ctx, "Staging symbolic link", "file", stagedFilePath, "target", target)
+
+		if err := os.Symlink(target, stagedFilePath); err != nil {
+			return fmt.Errorf("failed to create symbolic link %s: %w", stagedFilePath, err)
+		}
+	}
+
+	return nil
+}
+
+func (fms *FileManagerService) copyFileToStagingDirectory(ctx context.Context, fileName, stagedFilePath string) error {
+	fileInfo, err := os.Stat(fileName)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			slog.DebugContext(ctx, "File does not exist, skipping staging", "file", fileName)
+			return nil
+		}
+
+		return fmt.Errorf("unable to stat file %s: %w", fileName, err)
+	}
+
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("unable to read file %s: %w", fileName, err)
+	}
+
+	return fms.fileOperator.Write(ctx, content, stagedFilePath, files.Permissions(fileInfo.Mode()))
+}
+
+func (fms *FileManagerService) checkAllowedDirectory(checkFiles []*mpi.File, instanceFiles InstanceFiles) error {
 	for _, file := range checkFiles {
 		allowed := fms.agentConfig.IsDirectoryAllowed(file.GetFileMeta().GetName())
 		if !allowed {
 			return fmt.Errorf("file not in allowed directories %s", file.GetFileMeta().GetName())
 		}
+
+		if isSymlink(file) {
+			if err := fms.checkAllowedSymlinkTarget(file.GetFileMeta(), instanceFiles); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkAllowedSymlinkTarget checks that a symbolic link can't be used to access files outside the allowed directories.
+// If the target exists, the path it resolves to must be allowed as well, since the target can be a symbolic link.
+// The target is resolved in the root directory of the instance, like NGINX resolves it.
+func (fms *FileManagerService) checkAllowedSymlinkTarget(fileMeta *mpi.FileMeta, instanceFiles InstanceFiles) error {
+	target := symlinkTarget(fileMeta)
+	if !fms.agentConfig.IsDirectoryAllowed(target) {
+		return fmt.Errorf("symbolic link target not in allowed directories %s -> %s", fileMeta.GetName(), target)
+	}
+
+	resolvedTarget, err := evalSymlinksInRoot(instanceFiles.RootPath, target)
+	if err == nil && !fms.agentConfig.IsDirectoryAllowed(resolvedTarget) {
+		return fmt.Errorf("symbolic link target not in allowed directories %s -> %s", fileMeta.GetName(),
+			resolvedTarget)
 	}
 
 	return nil
 }
 
+func (fms *FileManagerService) checkAllowedFileOwnership(checkFiles []*mpi.File) error {
+	for _, file := range checkFiles {
+		err := checkFileOwnership(
+			file.GetFileMeta(),
+			fms.instanceFiles.RootPath,
+			fms.agentConfig.AllowedFileOwners,
+			fms.agentConfig.AllowedFileGroups,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withFileOwnership returns a copy of the file with the owner, group and SELinux context of the file on disk, so
+// that the management plane knows the current ownership of uploaded files.
+func (fms *FileManagerService) withFileOwnership(ctx context.Context, file *mpi.File) *mpi.File {
+	owner, group, selinuxContext, err := fileOwnership(
+		fms.diskPath(file.GetFileMeta().GetName()), fms.instanceFiles.RootPath,
+	)
+	if err != nil {
+		slog.WarnContext(ctx, "Unable to get file ownership", "file", file.GetFileMeta().GetName(), "error", err)
+		return file
+	}
+
+	fileWithOwnership, ok := proto.Clone(file).(*mpi.File)
+	if !ok {
+		return file
+	}
+
+	fileWithOwnership.FileMeta.Owner = owner
+	fileWithOwnership.FileMeta.Group = group
+	fileWithOwnership.FileMeta.SelinuxContext = selinuxContext
+
+	return fileWithOwnership
+}
+
 func (fms *FileManagerService) validateAndUpdateFilePermissions(ctx context.Context, fileList []*mpi.File) error {
 	for _, file := range fileList {
+		// directories need execute permissions to be traversed
+		if isDirectory(file) {
+			continue
+		}
+
 		if fms.areExecuteFilePermissionsSet(file) {
 			resetErr := fms.removeExecuteFilePermissions(ctx, file)
 			if resetErr != nil {
@@ -854,6 +1886,12 @@ func (fms *FileManagerService) convertToManifestFile(file *mpi.File, referenced
 			Hash:       file.GetFileMeta().GetHash(),
 			Referenced: referenced,
 			Unmanaged:  file.GetUnmanaged(),
+			LinkTarget: file.GetFileMeta().GetSymlinkMeta().GetTarget(),
+			Directory:  isDirectory(file),
+
+			RenderedHash:          file.GetTemplate().GetRenderedHash(),
+			RenderedSize:          file.GetTemplate().GetRenderedSize(),
+			TemplateVariablesHash: file.GetTemplate().GetVariablesHash(),
 		},
 	}
 }
@@ -869,7 +1907,7 @@ func (fms *FileManagerService) convertToFileMap(manifestFiles map[string]*model.
 }
 
 func (fms *FileManagerService) convertToFile(manifestFile *model.ManifestFile) *mpi.File {
-	return &mpi.File{
+	file := &mpi.File{
 		FileMeta: &mpi.FileMeta{
 			Name: manifestFile.ManifestFileMeta.Name,
 			Hash: manifestFile.ManifestFileMeta.Hash,
@@ -877,6 +1915,42 @@ func (fms *FileManagerService) convertToFile(manifestFile *model.ManifestFile) *
 		},
 		Unmanaged: manifestFile.ManifestFileMeta.Unmanaged,
 	}
+
+	switch {
+	case manifestFile.ManifestFileMeta.LinkTarget != "":
+		file.FileMeta.FileType = &mpi.FileMeta_SymlinkMeta{
+			SymlinkMeta: &mpi.SymlinkMeta{Target: manifestFile.ManifestFileMeta.LinkTarget},
+		}
+	case manifestFile.ManifestFileMeta.Directory:
+		file.FileMeta.FileType = &mpi.FileMeta_DirectoryMeta{DirectoryMeta: &mpi.DirectoryMeta{}}
+	}
+
+	if manifestFile.ManifestFileMeta.RenderedHash != "" {
+		file.Template = &mpi.FileTemplate{
+			RenderedHash:  manifestFile.ManifestFileMeta.RenderedHash,
+			RenderedSize:  manifestFile.ManifestFileMeta.RenderedSize,
+			VariablesHash: manifestFile.ManifestFileMeta.TemplateVariablesHash,
+		}
+	}
+
+	return file
+}
+
+func convertToFileActionProto(action model.FileAction) mpi.FileAction {
+	switch action {
+	case model.Add:
+		return mpi.FileAction_FILE_ACTION_ADD
+	case model.Update:
+		return mpi.FileAction_FILE_ACTION_UPDATE
+	case model.Delete:
+		return mpi.FileAction_FILE_ACTION_DELETE
+	case model.Unchanged:
+		return mpi.FileAction_FILE_ACTION_UNCHANGED
+	case model.ExternalFile:
+		return mpi.FileAction_FILE_ACTION_EXTERNAL
+	default:
+		return mpi.FileAction_FILE_ACTION_UNSPECIFIED
+	}
 }
 
 // ConvertToMapOfFiles converts a list of files to a map of file caches (file and action) with the file name as the key
@@ -891,6 +1965,47 @@ func ConvertToMapOfFileCache(convertFiles []*mpi.File) map[string]*model.FileCac
 	return filesMap
 }
 
+// StagedFilePath returns the path of a file inside a staging directory created by StageConfig
+func StagedFilePath(stagingDir, fileName string) string {
+	return filepath.Join(stagingDir, filepath.Clean(fileName))
+}
+
+// diskPath returns the path of a file of the config apply or external file refresh in progress on disk,
+// see InstanceFiles.diskPath
+func (fms *FileManagerService) diskPath(fileName string) string {
+	return fms.instanceFiles.diskPath(fileName)
+}
+
+// diskFile returns a copy of a file of the config apply or external file refresh in progress with the path of the
+// file on disk as name, see InstanceFiles.diskFile
+func (fms *FileManagerService) diskFile(file *mpi.File) *mpi.File {
+	return fms.instanceFiles.diskFile(file)
+}
+
+// diskPath returns the path of a file on disk, which is in the root directory of an instance in another container
+func (instanceFiles InstanceFiles) diskPath(fileName string) string {
+	if instanceFiles.RootPath == "" {
+		return fileName
+	}
+
+	return filepath.Join(instanceFiles.RootPath, fileName)
+}
+
+// diskFile returns a copy of a file with the path of the file on disk as name, see diskPath
+func (instanceFiles InstanceFiles) diskFile(file *mpi.File) *mpi.File {
+	if instanceFiles.RootPath == "" || file.GetFileMeta() == nil {
+		return file
+	}
+
+	diskFile, ok := proto.Clone(file).(*mpi.File)
+	if !ok {
+		return file
+	}
+	diskFile.FileMeta.Name = instanceFiles.diskPath(file.GetFileMeta().GetName())
+
+	return diskFile
+}
+
 func tempFilePath(fileName string) string {
 	tempFileName := "." + filepath.Base(fileName) + ".agent.tmp"
 	return filepath.Join(filepath.Dir(fileName), tempFileName)
@@ -11,9 +11,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/user"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/nginx/agent/v3/internal/model"
 
@@ -22,6 +25,7 @@ import (
 
 	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
 	"github.com/nginx/agent/v3/api/grpc/mpi/v1/v1fakes"
+	"github.com/nginx/agent/v3/internal/config"
 	"github.com/nginx/agent/v3/test/helpers"
 	"github.com/nginx/agent/v3/test/protos"
 	"github.com/nginx/agent/v3/test/types"
@@ -73,6 +77,55 @@ func TestFileManagerService_ConfigApply_Add(t *testing.T) {
 	assert.True(t, fileManagerService.rollbackManifest)
 }
 
+func TestFileManagerService_ConfigApply_RootPath(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	rootPath := t.TempDir()
+
+	filePath := "/etc/nginx/nginx.conf"
+	diskFilePath := filepath.Join(rootPath, filePath)
+	helpers.CreateDirWithErrorCheck(t, filepath.Dir(diskFilePath))
+
+	previousFileContent := []byte("location /previous {\n    return 200 \"Previous location\\n\";\n}")
+	require.NoError(t, os.WriteFile(diskFilePath, previousFileContent, 0o600))
+
+	fileContent := []byte("location /test {\n    return 200 \"Test location\\n\";\n}")
+	overview := protos.FileOverview(filePath, files.GenerateHash(fileContent))
+
+	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+	fakeFileServiceClient.GetFileReturns(&mpi.GetFileResponse{
+		Contents: &mpi.FileContents{
+			Contents: fileContent,
+		},
+	}, nil)
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{"/etc/nginx"}
+
+	fileManagerService := NewFileManagerService(fakeFileServiceClient, agentConfig, &sync.RWMutex{})
+	fileManagerService.agentConfig.LibDir = tempDir
+	fileManagerService.manifestFilePath = filepath.Join(tempDir, "manifest.json")
+	fileManagerService.SetRootPath(rootPath)
+
+	writeStatus, err := fileManagerService.ConfigApply(ctx, protos.CreateConfigApplyRequest(overview))
+	require.NoError(t, err)
+	assert.Equal(t, model.OK, writeStatus)
+	assert.Equal(t, model.Update, fileManagerService.fileActions[filePath].Action)
+
+	// the file is requested from the management plane with its path in the container
+	_, getFileRequest, _ := fakeFileServiceClient.GetFileArgsForCall(0)
+	assert.Equal(t, filePath, getFileRequest.GetFileMeta().GetName())
+
+	data, readErr := os.ReadFile(diskFilePath)
+	require.NoError(t, readErr)
+	assert.Equal(t, fileContent, data)
+
+	require.NoError(t, fileManagerService.Rollback(ctx, "instance-id"))
+
+	data, readErr = os.ReadFile(diskFilePath)
+	require.NoError(t, readErr)
+	assert.Equal(t, previousFileContent, data)
+}
+
 func TestFileManagerService_ConfigApply_Add_LargeFile(t *testing.T) {
 	ctx := context.Background()
 	tempDir := t.TempDir()
@@ -260,6 +313,84 @@ func TestFileManagerService_ConfigApply_Delete(t *testing.T) {
 	assert.True(t, fileManagerService.rollbackManifest)
 }
 
+func TestFileManagerService_ConfigApply_RestoreFromConfigHistory(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	filePath := filepath.Join(tempDir, "nginx.conf")
+	fileContent := []byte("worker_processes 1;\n")
+	overview := protos.FileOverview(filePath, files.GenerateHash(fileContent))
+
+	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{tempDir}
+	agentConfig.LibDir = t.TempDir()
+
+	fileManagerService := NewFileManagerService(fakeFileServiceClient, agentConfig, &sync.RWMutex{})
+
+	// store the config version in the config history and then remove it from disk
+	require.NoError(t, os.WriteFile(filePath, fileContent, 0o600))
+	require.NoError(t, fileManagerService.SaveConfigVersion(ctx, overview))
+	require.NoError(t, os.Remove(filePath))
+
+	writeStatus, err := fileManagerService.ConfigApply(ctx, protos.CreateConfigApplyRequest(overview))
+	require.NoError(t, err)
+	assert.Equal(t, model.OK, writeStatus)
+
+	data, readErr := os.ReadFile(filePath)
+	require.NoError(t, readErr)
+	assert.Equal(t, fileContent, data)
+	assert.Equal(t, 0, fakeFileServiceClient.GetFileCallCount())
+
+	transaction, err := fileManagerService.configApplyJournal.Load()
+	require.NoError(t, err)
+	assert.Equal(t, model.ConfigApplyMoved, transaction.Phase)
+
+	fileManagerService.ClearCache()
+	assert.NoFileExists(t, filepath.Join(agentConfig.LibDir, configApplyJournalFileName))
+}
+
+func TestFileManagerService_ConfigApply_RestoreFromFileCache(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	fileContent := []byte("geo $country { default ZZ; }\n")
+	firstFilePath := filepath.Join(tempDir, "instance-1", "geo.conf")
+	secondFilePath := filepath.Join(tempDir, "instance-2", "geo.conf")
+
+	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+	fakeFileServiceClient.GetFileReturns(&mpi.GetFileResponse{
+		Contents: &mpi.FileContents{
+			Contents: fileContent,
+		},
+	}, nil)
+
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{tempDir}
+	agentConfig.LibDir = t.TempDir()
+	agentConfig.DataPlaneConfig.Nginx.FileCacheSize = config.DefNginxFileCacheSize
+
+	fileManagerService := NewFileManagerService(fakeFileServiceClient, agentConfig, &sync.RWMutex{})
+
+	for _, filePath := range []string{firstFilePath, secondFilePath} {
+		overview := protos.FileOverview(filePath, files.GenerateHash(fileContent))
+
+		writeStatus, err := fileManagerService.ConfigApply(ctx, protos.CreateConfigApplyRequest(overview))
+		require.NoError(t, err)
+		assert.Equal(t, model.OK, writeStatus)
+
+		data, readErr := os.ReadFile(filePath)
+		require.NoError(t, readErr)
+		assert.Equal(t, fileContent, data)
+
+		fileManagerService.ClearCache()
+	}
+
+	// the file of the second instance is copied from the file cache instead of being downloaded again
+	assert.Equal(t, 1, fakeFileServiceClient.GetFileCallCount())
+}
+
 func TestFileManagerService_ConfigApply_Failed(t *testing.T) {
 	ctx := t.Context()
 	tempDir := t.TempDir()
@@ -342,6 +473,598 @@ func TestFileManagerService_ConfigApply_FileWithExecutePermissions(t *testing.T)
 	assert.True(t, fileManagerService.rollbackManifest)
 }
 
+func TestFileManagerService_ConfigApply_FileOwnership(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	filePath := filepath.Join(tempDir, "nginx.conf")
+
+	fileContent := []byte("location /test {\n    return 200 \"Test location\\n\";\n}")
+	fileHash := files.GenerateHash(fileContent)
+	defer helpers.RemoveFileWithErrorCheck(t, filePath)
+
+	currentUser, err := user.Current()
+	require.NoError(t, err)
+
+	overview := protos.FileOverview(filePath, fileHash)
+	overview.GetFiles()[0].GetFileMeta().Owner = currentUser.Uid
+	overview.GetFiles()[0].GetFileMeta().Group = currentUser.Gid
+
+	manifestDirPath := tempDir
+	manifestFilePath := filepath.Join(manifestDirPath, "manifest.json")
+	helpers.CreateFileWithErrorCheck(t, manifestDirPath, "manifest.json")
+
+	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+	fakeFileServiceClient.GetFileReturns(&mpi.GetFileResponse{
+		Contents: &mpi.FileContents{
+			Contents: fileContent,
+		},
+	}, nil)
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{tempDir}
+
+	fileManagerService := NewFileManagerService(fakeFileServiceClient, agentConfig, &sync.RWMutex{})
+	fileManagerService.agentConfig.LibDir = manifestDirPath
+	fileManagerService.manifestFilePath = manifestFilePath
+
+	request := protos.CreateConfigApplyRequest(overview)
+
+	t.Run("Test 1: owner not allowed", func(tt *testing.T) {
+		writeStatus, applyErr := fileManagerService.ConfigApply(ctx, request)
+		require.Error(tt, applyErr)
+		assert.Contains(tt, applyErr.Error(), "is not in allowed file owners")
+		assert.Equal(tt, model.Error, writeStatus)
+		assert.Equal(tt, 0, fakeFileServiceClient.GetFileCallCount())
+		assert.NoFileExists(tt, filePath)
+	})
+
+	t.Run("Test 2: owner allowed", func(tt *testing.T) {
+		fileManagerService.agentConfig.AllowedFileOwners = []string{currentUser.Uid}
+		fileManagerService.agentConfig.AllowedFileGroups = []string{currentUser.Gid}
+
+		writeStatus, applyErr := fileManagerService.ConfigApply(ctx, request)
+		require.NoError(tt, applyErr)
+		assert.Equal(tt, model.OK, writeStatus)
+
+		data, readErr := os.ReadFile(filePath)
+		require.NoError(tt, readErr)
+		assert.Equal(tt, fileContent, data)
+
+		owner, _, _, ownershipErr := fileOwnership(filePath, "")
+		require.NoError(tt, ownershipErr)
+		assert.Equal(tt, currentUser.Username, owner)
+	})
+}
+
+func TestFileManagerService_ConfigUpload_FileOwnership(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	filePath := filepath.Join(tempDir, "nginx.conf")
+	require.NoError(t, os.WriteFile(filePath, []byte("server {}"), 0o600))
+
+	currentUser, err := user.Current()
+	require.NoError(t, err)
+
+	overview := protos.FileOverview(filePath, files.GenerateHash([]byte("server {}")))
+
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{tempDir}
+	agentConfig.Client.Grpc.MaxFileSize = config.DefMaxFileSize
+
+	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+	fileManagerService := NewFileManagerService(fakeFileServiceClient, agentConfig, &sync.RWMutex{})
+	fileManagerService.SetIsConnected(true)
+
+	err = fileManagerService.ConfigUpload(ctx, &mpi.ConfigUploadRequest{Overview: overview})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, fakeFileServiceClient.UpdateFileCallCount())
+	_, updateFileRequest, _ := fakeFileServiceClient.UpdateFileArgsForCall(0)
+	uploadedFile := updateFileRequest.GetFile()
+	assert.Equal(t, currentUser.Username, uploadedFile.GetFileMeta().GetOwner())
+	assert.NotEmpty(t, uploadedFile.GetFileMeta().GetGroup())
+	assert.Empty(t, overview.GetFiles()[0].GetFileMeta().GetOwner())
+}
+
+func TestFileManagerService_ConfigApply_SymlinkAndDirectory(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	sitePath := filepath.Join(tempDir, "sites-available", "site.conf")
+	linkPath := filepath.Join(tempDir, "sites-enabled", "site.conf")
+	dirPath := filepath.Join(tempDir, "conf.d")
+
+	fileContent := []byte("server {\n    listen 8080;\n}")
+
+	overview := protos.FileOverview(sitePath, files.GenerateHash(fileContent))
+	siteFile := overview.GetFiles()[0]
+	overview.Files = append(overview.Files,
+		symlinkFile(linkPath, "../sites-available/site.conf"),
+		directoryFile(dirPath),
+	)
+
+	manifestFilePath := filepath.Join(tempDir, "manifest.json")
+	helpers.CreateFileWithErrorCheck(t, tempDir, "manifest.json")
+
+	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+	fakeFileServiceClient.GetFileReturns(&mpi.GetFileResponse{
+		Contents: &mpi.FileContents{
+			Contents: fileContent,
+		},
+	}, nil)
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{tempDir}
+
+	fileManagerService := NewFileManagerService(fakeFileServiceClient, agentConfig, &sync.RWMutex{})
+	fileManagerService.agentConfig.LibDir = tempDir
+	fileManagerService.manifestFilePath = manifestFilePath
+
+	writeStatus, err := fileManagerService.ConfigApply(ctx, protos.CreateConfigApplyRequest(overview))
+	require.NoError(t, err)
+	assert.Equal(t, model.OK, writeStatus)
+	assert.Equal(t, 1, fakeFileServiceClient.GetFileCallCount())
+
+	data, err := os.ReadFile(linkPath)
+	require.NoError(t, err)
+	assert.Equal(t, fileContent, data)
+	assert.DirExists(t, dirPath)
+
+	manifestFiles, _, err := fileManagerService.manifestFile()
+	require.NoError(t, err)
+	assert.Equal(t, "../sites-available/site.conf", manifestFiles[linkPath].ManifestFileMeta.LinkTarget)
+	assert.True(t, manifestFiles[dirPath].ManifestFileMeta.Directory)
+
+	// disabling the site deletes the symbolic link and directory, which are restored by a rollback
+	overview.Files = []*mpi.File{siteFile}
+
+	writeStatus, err = fileManagerService.ConfigApply(ctx, protos.CreateConfigApplyRequest(overview))
+	require.NoError(t, err)
+	assert.Equal(t, model.OK, writeStatus)
+	assert.Equal(t, model.Delete, fileManagerService.fileActions[linkPath].Action)
+
+	_, err = os.Lstat(linkPath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	assert.NoDirExists(t, dirPath)
+	assert.FileExists(t, sitePath)
+
+	err = fileManagerService.Rollback(ctx, overview.GetConfigVersion().GetInstanceId())
+	require.NoError(t, err)
+
+	target, err := os.Readlink(linkPath)
+	require.NoError(t, err)
+	assert.Equal(t, "../sites-available/site.conf", target)
+	assert.DirExists(t, dirPath)
+}
+
+func TestFileManagerService_ConfigApply_Template(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	filePath := filepath.Join(tempDir, "nginx.conf")
+	source := []byte("server_name {{ .Host.Hostname }};")
+
+	overview := &mpi.FileOverview{
+		Files:         []*mpi.File{templateFile(filePath, string(source))},
+		ConfigVersion: protos.CreateConfigVersion(),
+	}
+
+	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+	fakeFileServiceClient.GetFileReturns(&mpi.GetFileResponse{
+		Contents: &mpi.FileContents{
+			Contents: source,
+		},
+	}, nil)
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{tempDir}
+	agentConfig.Client.Grpc.MaxFileSize = config.DefMaxFileSize
+
+	fileManagerService := NewFileManagerService(fakeFileServiceClient, agentConfig, &sync.RWMutex{})
+	fileManagerService.agentConfig.LibDir = tempDir
+	fileManagerService.manifestFilePath = filepath.Join(tempDir, "manifest.json")
+	fileManagerService.SetTemplateData(&TemplateData{Host: TemplateHost{Hostname: "web-1"}})
+
+	writeStatus, err := fileManagerService.ConfigApply(ctx, protos.CreateConfigApplyRequest(overview))
+	require.NoError(t, err)
+	assert.Equal(t, model.OK, writeStatus)
+
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "server_name web-1;", string(data))
+
+	manifestFiles, _, err := fileManagerService.manifestFile()
+	require.NoError(t, err)
+	assert.Equal(t, files.GenerateHash(source), manifestFiles[filePath].ManifestFileMeta.Hash)
+	assert.Equal(t, files.GenerateHash(data), manifestFiles[filePath].ManifestFileMeta.RenderedHash)
+	assert.Equal(t, int64(len(data)), manifestFiles[filePath].ManifestFileMeta.RenderedSize)
+
+	// the manifest keeps the template source hash when it is updated with the rendered file from the NGINX config
+	renderedFile, err := files.FileMeta(filePath)
+	require.NoError(t, err)
+	err = fileManagerService.UpdateCurrentFilesOnDisk(ctx,
+		map[string]*mpi.File{filePath: {FileMeta: renderedFile}}, true)
+	require.NoError(t, err)
+
+	writeStatus, err = fileManagerService.ConfigApply(ctx,
+		protos.CreateConfigApplyRequest(&mpi.FileOverview{
+			Files:         []*mpi.File{templateFile(filePath, string(source))},
+			ConfigVersion: protos.CreateConfigVersion(),
+		}))
+	require.NoError(t, err)
+	assert.Equal(t, model.NoChange, writeStatus)
+
+	// the template is rendered again when the template variables change
+	fileManagerService.SetTemplateData(&TemplateData{Host: TemplateHost{Hostname: "web-2"}})
+
+	writeStatus, err = fileManagerService.ConfigApply(ctx,
+		protos.CreateConfigApplyRequest(&mpi.FileOverview{
+			Files:         []*mpi.File{templateFile(filePath, string(source))},
+			ConfigVersion: protos.CreateConfigVersion(),
+		}))
+	require.NoError(t, err)
+	assert.Equal(t, model.OK, writeStatus)
+
+	data, err = os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "server_name web-2;", string(data))
+}
+
+func TestFileManagerService_ConfigApply_SymlinkRollback(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	linkPath := filepath.Join(tempDir, "site.conf")
+	require.NoError(t, os.Symlink("old.conf", linkPath))
+
+	overview := &mpi.FileOverview{
+		Files:         []*mpi.File{symlinkFile(linkPath, "new.conf")},
+		ConfigVersion: protos.CreateConfigVersion(),
+	}
+
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{tempDir}
+
+	fileManagerService := NewFileManagerService(&v1fakes.FakeFileServiceClient{}, agentConfig, &sync.RWMutex{})
+	fileManagerService.agentConfig.LibDir = tempDir
+	fileManagerService.manifestFilePath = filepath.Join(tempDir, "manifest.json")
+
+	writeStatus, err := fileManagerService.ConfigApply(ctx, protos.CreateConfigApplyRequest(overview))
+	require.NoError(t, err)
+	assert.Equal(t, model.OK, writeStatus)
+	assert.Equal(t, model.Update, fileManagerService.fileActions[linkPath].Action)
+
+	target, err := os.Readlink(linkPath)
+	require.NoError(t, err)
+	assert.Equal(t, "new.conf", target)
+
+	err = fileManagerService.Rollback(ctx, overview.GetConfigVersion().GetInstanceId())
+	require.NoError(t, err)
+
+	target, err = os.Readlink(linkPath)
+	require.NoError(t, err)
+	assert.Equal(t, "old.conf", target)
+}
+
+func TestFileManagerService_StageConfig(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	libDir := t.TempDir()
+
+	filePath := filepath.Join(tempDir, "nginx.conf")
+	fileContent := []byte("include mime.types;")
+	fileHash := files.GenerateHash(fileContent)
+
+	unmanagedFile := helpers.CreateFileWithErrorCheck(t, tempDir, "mime.types")
+	_, writeErr := unmanagedFile.WriteString("types {}")
+	require.NoError(t, writeErr)
+	require.NoError(t, unmanagedFile.Close())
+
+	overview := protos.FileOverview(filePath, fileHash)
+	overview.Files = append(overview.Files, &mpi.File{
+		FileMeta:  protos.FileMeta(unmanagedFile.Name(), ""),
+		Unmanaged: true,
+	},
+		symlinkFile(filepath.Join(tempDir, "sites-enabled", "nginx.conf"), "../nginx.conf"),
+		directoryFile(filepath.Join(tempDir, "conf.d")),
+	)
+
+	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+	fakeFileServiceClient.GetFileReturns(&mpi.GetFileResponse{
+		Contents: &mpi.FileContents{
+			Contents: fileContent,
+		},
+	}, nil)
+
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{tempDir}
+	agentConfig.LibDir = libDir
+
+	fileManagerService := NewFileManagerService(fakeFileServiceClient, agentConfig, &sync.RWMutex{})
+
+	stagingDir, err := fileManagerService.StageConfig(ctx, overview, InstanceFiles{})
+	require.NoError(t, err)
+	assert.Equal(t, libDir, filepath.Dir(stagingDir))
+
+	stagedContent, readErr := os.ReadFile(StagedFilePath(stagingDir, filePath))
+	require.NoError(t, readErr)
+	assert.Equal(t, fileContent, stagedContent)
+
+	stagedUnmanagedContent, readErr := os.ReadFile(StagedFilePath(stagingDir, unmanagedFile.Name()))
+	require.NoError(t, readErr)
+	assert.Equal(t, []byte("types {}"), stagedUnmanagedContent)
+
+	// the staged symbolic link points to the staged file
+	stagedTarget, readErr := os.Readlink(StagedFilePath(stagingDir, filepath.Join(tempDir, "sites-enabled", "nginx.conf")))
+	require.NoError(t, readErr)
+	assert.Equal(t, StagedFilePath(stagingDir, filePath), stagedTarget)
+	assert.DirExists(t, StagedFilePath(stagingDir, filepath.Join(tempDir, "conf.d")))
+
+	assert.Equal(t, 1, fakeFileServiceClient.GetFileCallCount())
+	assert.NoFileExists(t, filePath)
+	assert.NoFileExists(t, fileManagerService.manifestFilePath)
+	assert.Empty(t, fileManagerService.fileActions)
+}
+
+func TestFileManagerService_StageConfig_RootPath(t *testing.T) {
+	ctx := context.Background()
+	rootPath := t.TempDir()
+
+	filePath := "/etc/nginx/nginx.conf"
+	fileContent := []byte("server_name {{ .Host.Hostname }};")
+
+	helpers.CreateDirWithErrorCheck(t, filepath.Join(rootPath, "etc", "nginx"))
+	unmanagedFilePath := "/etc/nginx/mime.types"
+	require.NoError(t, os.WriteFile(filepath.Join(rootPath, unmanagedFilePath), []byte("types {}"), 0o600))
+
+	overview := &mpi.FileOverview{
+		Files: []*mpi.File{
+			templateFile(filePath, string(fileContent)),
+			{
+				FileMeta:  protos.FileMeta(unmanagedFilePath, ""),
+				Unmanaged: true,
+			},
+			symlinkFile("/etc/nginx/sites-enabled/nginx.conf", "../nginx.conf"),
+		},
+		ConfigVersion: protos.CreateConfigVersion(),
+	}
+
+	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+	fakeFileServiceClient.GetFileReturns(&mpi.GetFileResponse{
+		Contents: &mpi.FileContents{
+			Contents: fileContent,
+		},
+	}, nil)
+
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{"/etc/nginx"}
+	agentConfig.LibDir = t.TempDir()
+	agentConfig.Client.Grpc.MaxFileSize = config.DefMaxFileSize
+
+	fileManagerService := NewFileManagerService(fakeFileServiceClient, agentConfig, &sync.RWMutex{})
+
+	stagingDir, err := fileManagerService.StageConfig(ctx, overview, InstanceFiles{
+		TemplateData: &TemplateData{Host: TemplateHost{Hostname: "web-1"}},
+		RootPath:     rootPath,
+	})
+	require.NoError(t, err)
+
+	// the instance of a config apply in progress is not changed
+	assert.Empty(t, fileManagerService.instanceFiles.RootPath)
+	assert.Empty(t, fileManagerService.instanceFiles.TemplateData.Host.Hostname)
+
+	// templates are rendered with the template variables of the instance
+	stagedContent, readErr := os.ReadFile(StagedFilePath(stagingDir, filePath))
+	require.NoError(t, readErr)
+	assert.Equal(t, "server_name web-1;", string(stagedContent))
+
+	// the staging directory is in the root directory of the instance
+	assert.Equal(t, filepath.Join(rootPath, os.TempDir()), filepath.Dir(stagingDir))
+	stagingDirInRoot := strings.TrimPrefix(stagingDir, rootPath)
+
+	// unmanaged files are copied from the root directory of the instance
+	stagedUnmanagedContent, readErr := os.ReadFile(StagedFilePath(stagingDir, unmanagedFilePath))
+	require.NoError(t, readErr)
+	assert.Equal(t, []byte("types {}"), stagedUnmanagedContent)
+
+	// the staged symbolic link points to the staged file as seen by NGINX in the root directory
+	stagedTarget, readErr := os.Readlink(StagedFilePath(stagingDir, "/etc/nginx/sites-enabled/nginx.conf"))
+	require.NoError(t, readErr)
+	assert.Equal(t, StagedFilePath(stagingDirInRoot, filePath), stagedTarget)
+
+	require.NoError(t, os.RemoveAll(stagingDir))
+}
+
+func TestFileManagerService_StageConfig_Failed(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	libDir := t.TempDir()
+
+	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+	fakeFileServiceClient.GetFileReturns(nil, errors.New("file not found"))
+
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{tempDir}
+	agentConfig.LibDir = libDir
+	agentConfig.Client.Backoff.MaxElapsedTime = 100 * time.Millisecond
+
+	fileManagerService := NewFileManagerService(fakeFileServiceClient, agentConfig, &sync.RWMutex{})
+
+	_, err := fileManagerService.StageConfig(ctx, protos.FileOverview("/unknown/nginx.conf", "hash"), InstanceFiles{})
+	require.ErrorContains(t, err, "file not in allowed directories")
+
+	_, err = fileManagerService.StageConfig(ctx, protos.FileOverview(filepath.Join(tempDir, "nginx.conf"), "hash"),
+		InstanceFiles{})
+	require.Error(t, err)
+
+	// staging directory is removed if staging fails
+	entries, readErr := os.ReadDir(libDir)
+	require.NoError(t, readErr)
+	assert.Empty(t, entries)
+}
+
+func TestFileManagerService_ConfigDiff(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	updatedFilePath := filepath.Join(tempDir, "nginx.conf")
+	oldContent := []byte("worker_processes 1;\n")
+	newContent := []byte("worker_processes auto;\n")
+	require.NoError(t, os.WriteFile(updatedFilePath, oldContent, 0o600))
+
+	unchangedFilePath := filepath.Join(tempDir, "mime.types")
+	unchangedContent := []byte("types {}\n")
+	require.NoError(t, os.WriteFile(unchangedFilePath, unchangedContent, 0o600))
+
+	deletedFilePath := filepath.Join(tempDir, "deleted.conf")
+	deletedContent := []byte("server {}\n")
+	require.NoError(t, os.WriteFile(deletedFilePath, deletedContent, 0o600))
+
+	addedFilePath := filepath.Join(tempDir, "added.conf")
+
+	overview := protos.FileOverview(updatedFilePath, files.GenerateHash(newContent))
+	overview.Files[0].FileMeta.Size = int64(len(newContent))
+	overview.Files = append(overview.Files,
+		&mpi.File{FileMeta: protos.FileMeta(unchangedFilePath, files.GenerateHash(unchangedContent))},
+		&mpi.File{FileMeta: protos.FileMeta(addedFilePath, files.GenerateHash(newContent))},
+	)
+
+	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+	fakeFileServiceClient.GetFileReturns(&mpi.GetFileResponse{
+		Contents: &mpi.FileContents{
+			Contents: newContent,
+		},
+	}, nil)
+
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{tempDir}
+	agentConfig.LibDir = t.TempDir()
+
+	fileManagerService := NewFileManagerService(fakeFileServiceClient, agentConfig, &sync.RWMutex{})
+	fileManagerService.currentFilesOnDisk = map[string]*mpi.File{
+		updatedFilePath:   {FileMeta: protos.FileMeta(updatedFilePath, files.GenerateHash(oldContent))},
+		unchangedFilePath: {FileMeta: protos.FileMeta(unchangedFilePath, files.GenerateHash(unchangedContent))},
+		deletedFilePath:   {FileMeta: protos.FileMeta(deletedFilePath, files.GenerateHash(deletedContent))},
+	}
+
+	changeSet, err := fileManagerService.ConfigDiff(ctx, &mpi.ConfigDiffRequest{
+		Overview:        overview,
+		IncludeTextDiff: true,
+	}, InstanceFiles{})
+	require.NoError(t, err)
+
+	changes := changeSet.GetChanges()
+	require.Len(t, changes, 4)
+
+	// changes are sorted by file name
+	assert.Equal(t, addedFilePath, changes[0].GetName())
+	assert.Equal(t, mpi.FileAction_FILE_ACTION_ADD, changes[0].GetAction())
+	assert.Empty(t, changes[0].GetOldHash())
+	assert.Equal(t, files.GenerateHash(newContent), changes[0].GetNewHash())
+	assert.Equal(t, "--- /dev/null\n+++ "+addedFilePath+"\n@@ -0,0 +1 @@\n+worker_processes auto;\n",
+		changes[0].GetDiff())
+
+	assert.Equal(t, deletedFilePath, changes[1].GetName())
+	assert.Equal(t, mpi.FileAction_FILE_ACTION_DELETE, changes[1].GetAction())
+	assert.Equal(t, files.GenerateHash(deletedContent), changes[1].GetOldHash())
+	assert.Equal(t, int64(len(deletedContent)), changes[1].GetOldSize())
+	assert.Empty(t, changes[1].GetNewHash())
+	assert.Equal(t, "--- "+deletedFilePath+"\n+++ /dev/null\n@@ -1 +0,0 @@\n-server {}\n", changes[1].GetDiff())
+
+	assert.Equal(t, unchangedFilePath, changes[2].GetName())
+	assert.Equal(t, mpi.FileAction_FILE_ACTION_UNCHANGED, changes[2].GetAction())
+	assert.Equal(t, changes[2].GetOldHash(), changes[2].GetNewHash())
+	assert.Empty(t, changes[2].GetDiff())
+
+	assert.Equal(t, updatedFilePath, changes[3].GetName())
+	assert.Equal(t, mpi.FileAction_FILE_ACTION_UPDATE, changes[3].GetAction())
+	assert.Equal(t, files.GenerateHash(oldContent), changes[3].GetOldHash())
+	assert.Equal(t, files.GenerateHash(newContent), changes[3].GetNewHash())
+	assert.Equal(t, int64(len(oldContent)), changes[3].GetOldSize())
+	assert.Equal(t, int64(len(newContent)), changes[3].GetNewSize())
+	assert.Equal(t, "--- "+updatedFilePath+"\n+++ "+updatedFilePath+"\n@@ -1 +1 @@\n"+
+		"-worker_processes 1;\n+worker_processes auto;\n", changes[3].GetDiff())
+
+	// nothing is written to disk
+	assert.Equal(t, 2, fakeFileServiceClient.GetFileCallCount())
+	assert.NoFileExists(t, addedFilePath)
+	assert.FileExists(t, deletedFilePath)
+	updatedContent, readErr := os.ReadFile(updatedFilePath)
+	require.NoError(t, readErr)
+	assert.Equal(t, oldContent, updatedContent)
+	assert.NoFileExists(t, fileManagerService.manifestFilePath)
+	assert.Empty(t, fileManagerService.fileActions)
+
+	// text diffs are only downloaded when requested
+	changeSet, err = fileManagerService.ConfigDiff(ctx, &mpi.ConfigDiffRequest{Overview: overview}, InstanceFiles{})
+	require.NoError(t, err)
+	require.Len(t, changeSet.GetChanges(), 4)
+	for _, change := range changeSet.GetChanges() {
+		assert.Empty(t, change.GetDiff())
+	}
+	assert.Equal(t, 2, fakeFileServiceClient.GetFileCallCount())
+}
+
+func TestFileManagerService_ConfigDiff_RootPath(t *testing.T) {
+	ctx := context.Background()
+	rootPath := t.TempDir()
+
+	filePath := "/etc/nginx/nginx.conf"
+	oldContent := []byte("worker_processes 1;\n")
+	newContent := []byte("worker_processes auto;\n")
+
+	helpers.CreateDirWithErrorCheck(t, filepath.Join(rootPath, "etc", "nginx"))
+	require.NoError(t, os.WriteFile(filepath.Join(rootPath, filePath), oldContent, 0o600))
+
+	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+	fakeFileServiceClient.GetFileReturns(&mpi.GetFileResponse{
+		Contents: &mpi.FileContents{
+			Contents: newContent,
+		},
+	}, nil)
+
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{"/etc/nginx"}
+	agentConfig.LibDir = t.TempDir()
+
+	fileManagerService := NewFileManagerService(fakeFileServiceClient, agentConfig, &sync.RWMutex{})
+
+	changeSet, err := fileManagerService.ConfigDiff(ctx, &mpi.ConfigDiffRequest{
+		Overview:        protos.FileOverview(filePath, files.GenerateHash(newContent)),
+		IncludeTextDiff: true,
+	}, InstanceFiles{RootPath: rootPath})
+	require.NoError(t, err)
+
+	// the file is compared with the file in the root directory of the instance
+	require.Len(t, changeSet.GetChanges(), 1)
+	change := changeSet.GetChanges()[0]
+	assert.Equal(t, filePath, change.GetName())
+	assert.Equal(t, mpi.FileAction_FILE_ACTION_UPDATE, change.GetAction())
+	assert.Equal(t, files.GenerateHash(oldContent), change.GetOldHash())
+	assert.Equal(t, "--- "+filePath+"\n+++ "+filePath+"\n@@ -1 +1 @@\n"+
+		"-worker_processes 1;\n+worker_processes auto;\n", change.GetDiff())
+
+	// the instance of a config apply in progress is not changed
+	assert.Empty(t, fileManagerService.instanceFiles.RootPath)
+}
+
+func TestFileManagerService_ConfigDiff_Failed(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{tempDir}
+
+	fileManagerService := NewFileManagerService(&v1fakes.FakeFileServiceClient{}, agentConfig, &sync.RWMutex{})
+
+	_, err := fileManagerService.ConfigDiff(ctx, &mpi.ConfigDiffRequest{}, InstanceFiles{})
+	require.ErrorContains(t, err, "fileOverview is nil")
+
+	_, err = fileManagerService.ConfigDiff(ctx, &mpi.ConfigDiffRequest{
+		Overview: protos.FileOverview("/unknown/nginx.conf", "hash"),
+	}, InstanceFiles{})
+	require.ErrorContains(t, err, "file not in allowed directories")
+}
+
 func TestFileManagerService_checkAllowedDirectory(t *testing.T) {
 	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
 	fileManagerService := NewFileManagerService(fakeFileServiceClient, types.AgentConfig(), &sync.RWMutex{})
@@ -370,10 +1093,65 @@ func TestFileManagerService_checkAllowedDirectory(t *testing.T) {
 		},
 	}
 
-	err := fileManagerService.checkAllowedDirectory(allowedFiles)
+	err := fileManagerService.checkAllowedDirectory(allowedFiles, InstanceFiles{})
 	require.NoError(t, err)
-	err = fileManagerService.checkAllowedDirectory(notAllowed)
+	err = fileManagerService.checkAllowedDirectory(notAllowed, InstanceFiles{})
 	require.Error(t, err)
+
+	err = fileManagerService.checkAllowedDirectory([]*mpi.File{
+		symlinkFile("/tmp/local/etc/nginx/sites-enabled/site.conf", "../sites-available/site.conf"),
+	}, InstanceFiles{})
+	require.NoError(t, err)
+
+	err = fileManagerService.checkAllowedDirectory([]*mpi.File{
+		symlinkFile("/tmp/local/etc/nginx/shadow", "../../../../etc/shadow"),
+	}, InstanceFiles{})
+	require.EqualError(t, err, "symbolic link target not in allowed directories "+
+		"/tmp/local/etc/nginx/shadow -> /etc/shadow")
+}
+
+func TestFileManagerService_checkAllowedDirectory_SymlinkTargetIsSymlink(t *testing.T) {
+	tempDir := t.TempDir()
+	allowedDir := filepath.Join(tempDir, "nginx")
+	require.NoError(t, os.Mkdir(allowedDir, dirPerm))
+
+	// a symbolic link in the allowed directories that points outside of them
+	require.NoError(t, os.Symlink(tempDir, filepath.Join(allowedDir, "escape")))
+
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{allowedDir}
+	fileManagerService := NewFileManagerService(&v1fakes.FakeFileServiceClient{}, agentConfig, &sync.RWMutex{})
+
+	err := fileManagerService.checkAllowedDirectory([]*mpi.File{
+		symlinkFile(filepath.Join(allowedDir, "site.conf"), "escape"),
+	}, InstanceFiles{})
+	require.EqualError(t, err, "symbolic link target not in allowed directories "+
+		filepath.Join(allowedDir, "site.conf")+" -> "+tempDir)
+}
+
+func TestFileManagerService_checkAllowedDirectory_SymlinkTargetInRoot(t *testing.T) {
+	rootPath := t.TempDir()
+	allowedDir := "/etc/nginx"
+	require.NoError(t, os.MkdirAll(filepath.Join(rootPath, allowedDir, "sites"), dirPerm))
+
+	// absolute symbolic links of the instance point to files in the root directory of the instance
+	require.NoError(t, os.Symlink("/etc", filepath.Join(rootPath, allowedDir, "escape")))
+	require.NoError(t, os.Symlink("/etc/nginx/sites", filepath.Join(rootPath, allowedDir, "conf.d")))
+
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{allowedDir}
+	fileManagerService := NewFileManagerService(&v1fakes.FakeFileServiceClient{}, agentConfig, &sync.RWMutex{})
+	instanceFiles := InstanceFiles{RootPath: rootPath}
+
+	err := fileManagerService.checkAllowedDirectory([]*mpi.File{
+		symlinkFile("/etc/nginx/sites-enabled", "conf.d"),
+	}, instanceFiles)
+	require.NoError(t, err)
+
+	err = fileManagerService.checkAllowedDirectory([]*mpi.File{
+		symlinkFile("/etc/nginx/site.conf", "escape"),
+	}, instanceFiles)
+	require.EqualError(t, err, "symbolic link target not in allowed directories /etc/nginx/site.conf -> /etc")
 }
 
 func TestFileManagerService_validateAndUpdateFilePermissions(t *testing.T) {
@@ -653,6 +1431,218 @@ func TestFileManagerService_Rollback(t *testing.T) {
 	defer helpers.RemoveFileWithErrorCheck(t, deleteFilePath)
 }
 
+func TestFileManagerService_RecoverConfigApply(t *testing.T) {
+	ctx := context.Background()
+	previousContent := []byte("worker_processes 1;\n")
+
+	tests := []struct {
+		name                   string
+		phase                  model.ConfigApplyPhase
+		expectedUpdatedContent []byte
+		expectAddedFile        bool
+		expectDeletedFile      bool
+		expectCompleted        bool
+		expectReloadRequired   bool
+	}{
+		{
+			name:                   "Test 1: Interrupted before files were moved",
+			phase:                  model.ConfigApplyDownloaded,
+			expectedUpdatedContent: previousContent,
+			expectDeletedFile:      true,
+		},
+		{
+			name:                   "Test 2: Interrupted before NGINX was reloaded",
+			phase:                  model.ConfigApplyMoved,
+			expectedUpdatedContent: previousContent,
+			expectDeletedFile:      true,
+			expectReloadRequired:   true,
+		},
+		{
+			name:                   "Test 3: Interrupted after NGINX was reloaded",
+			phase:                  model.ConfigApplyReloaded,
+			expectedUpdatedContent: []byte("worker_processes 2;\n"),
+			expectAddedFile:        true,
+			expectCompleted:        true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			tempDir := tt.TempDir()
+			updatedFilePath := filepath.Join(tempDir, "nginx.conf")
+			addedFilePath := filepath.Join(tempDir, "added.conf")
+			deletedFilePath := filepath.Join(tempDir, "deleted.conf")
+
+			agentConfig := types.AgentConfig()
+			agentConfig.AllowedDirectories = []string{tempDir}
+			agentConfig.LibDir = tt.TempDir()
+
+			// state of the files after the new config was moved into place
+			require.NoError(tt, os.WriteFile(updatedFilePath, []byte("worker_processes 2;\n"), 0o600))
+			require.NoError(tt, os.WriteFile(tempBackupFilePath(updatedFilePath), previousContent, 0o600))
+			require.NoError(tt, os.WriteFile(addedFilePath, []byte("include mime.types;\n"), 0o600))
+			require.NoError(tt, os.WriteFile(tempBackupFilePath(deletedFilePath), []byte("events {}\n"), 0o600))
+
+			previousManifest := map[string]*model.ManifestFile{
+				updatedFilePath: {ManifestFileMeta: &model.ManifestFileMeta{Name: updatedFilePath}},
+				deletedFilePath: {ManifestFileMeta: &model.ManifestFileMeta{Name: deletedFilePath}},
+			}
+
+			journal := newConfigApplyJournal(agentConfig)
+			require.NoError(tt, journal.Begin(&configApplyTransaction{
+				PreviousManifest: previousManifest,
+				CorrelationID:    "correlation-id",
+				InstanceID:       "instance-id",
+				Files: []*configApplyTransactionFile{
+					{Name: updatedFilePath, Action: model.Update},
+					{Name: addedFilePath, Action: model.Add},
+					{Name: deletedFilePath, Action: model.Delete},
+				},
+			}))
+			require.NoError(tt, journal.SetPhase(test.phase))
+
+			fileManagerService := NewFileManagerService(&v1fakes.FakeFileServiceClient{}, agentConfig,
+				&sync.RWMutex{})
+
+			recovery := fileManagerService.RecoverConfigApply(ctx)
+			require.NotNil(tt, recovery)
+			require.NoError(tt, recovery.Error)
+			assert.Equal(tt, "correlation-id", recovery.CorrelationID)
+			assert.Equal(tt, "instance-id", recovery.InstanceID)
+			assert.Equal(tt, test.phase, recovery.Phase)
+			assert.Equal(tt, test.expectCompleted, recovery.Completed)
+			assert.Equal(tt, test.expectReloadRequired, recovery.ReloadRequired)
+
+			updatedContent, err := os.ReadFile(updatedFilePath)
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expectedUpdatedContent, updatedContent)
+
+			if test.expectAddedFile {
+				assert.FileExists(tt, addedFilePath)
+			} else {
+				assert.NoFileExists(tt, addedFilePath)
+			}
+
+			if test.expectDeletedFile {
+				assert.FileExists(tt, deletedFilePath)
+			} else {
+				assert.NoFileExists(tt, deletedFilePath)
+			}
+
+			assert.NoFileExists(tt, tempBackupFilePath(updatedFilePath))
+			assert.NoFileExists(tt, tempBackupFilePath(deletedFilePath))
+			assert.NoFileExists(tt, filepath.Join(agentConfig.LibDir, configApplyJournalFileName))
+
+			if !test.expectCompleted {
+				manifestFiles, _, manifestErr := fileManagerService.manifestFile()
+				require.NoError(tt, manifestErr)
+				assert.Equal(tt, previousManifest, manifestFiles)
+			}
+
+			// the config apply is only recovered once
+			assert.Nil(tt, fileManagerService.RecoverConfigApply(ctx))
+		})
+	}
+}
+
+func TestFileManagerService_RecoverConfigApply_RootPath(t *testing.T) {
+	ctx := context.Background()
+	previousContent := []byte("worker_processes 1;\n")
+	rootPath := t.TempDir()
+	configDir := t.TempDir()
+	updatedFileName := filepath.Join(configDir, "nginx.conf")
+	addedFileName := filepath.Join(configDir, "added.conf")
+	updatedFilePath := filepath.Join(rootPath, updatedFileName)
+	addedFilePath := filepath.Join(rootPath, addedFileName)
+
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{configDir}
+	agentConfig.LibDir = t.TempDir()
+
+	// files of the agent with the same names as the files of the instance
+	require.NoError(t, os.WriteFile(updatedFileName, []byte("worker_processes 3;\n"), 0o600))
+	require.NoError(t, os.WriteFile(addedFileName, []byte("include agent.types;\n"), 0o600))
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(updatedFilePath), 0o755))
+	require.NoError(t, os.WriteFile(updatedFilePath, []byte("worker_processes 2;\n"), 0o600))
+	require.NoError(t, os.WriteFile(tempBackupFilePath(updatedFilePath), previousContent, 0o600))
+	require.NoError(t, os.WriteFile(addedFilePath, []byte("include mime.types;\n"), 0o600))
+
+	journal := newConfigApplyJournal(agentConfig)
+	require.NoError(t, journal.Begin(&configApplyTransaction{
+		PreviousManifest: map[string]*model.ManifestFile{
+			updatedFileName: {ManifestFileMeta: &model.ManifestFileMeta{Name: updatedFileName}},
+		},
+		CorrelationID: "correlation-id",
+		InstanceID:    "instance-id",
+		RootPath:      rootPath,
+		Files: []*configApplyTransactionFile{
+			{Name: updatedFileName, Action: model.Update},
+			{Name: addedFileName, Action: model.Add},
+		},
+	}))
+	require.NoError(t, journal.SetPhase(model.ConfigApplyMoved))
+
+	fileManagerService := NewFileManagerService(&v1fakes.FakeFileServiceClient{}, agentConfig, &sync.RWMutex{})
+
+	recovery := fileManagerService.RecoverConfigApply(ctx)
+	require.NotNil(t, recovery)
+	require.NoError(t, recovery.Error)
+	assert.True(t, recovery.ReloadRequired)
+	assert.Empty(t, fileManagerService.instanceFiles.RootPath)
+
+	updatedContent, err := os.ReadFile(updatedFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, previousContent, updatedContent)
+	assert.NoFileExists(t, addedFilePath)
+	assert.NoFileExists(t, tempBackupFilePath(updatedFilePath))
+
+	// the files of the agent are not touched
+	agentContent, err := os.ReadFile(updatedFileName)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("worker_processes 3;\n"), agentContent)
+	assert.FileExists(t, addedFileName)
+}
+
+func TestFileManagerService_RecoverConfigApply_RootPathNotAvailable(t *testing.T) {
+	configDir := t.TempDir()
+	addedFileName := filepath.Join(configDir, "added.conf")
+	require.NoError(t, os.WriteFile(addedFileName, []byte("include agent.types;\n"), 0o600))
+
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{configDir}
+	agentConfig.LibDir = t.TempDir()
+
+	journal := newConfigApplyJournal(agentConfig)
+	require.NoError(t, journal.Begin(&configApplyTransaction{
+		CorrelationID: "correlation-id",
+		InstanceID:    "instance-id",
+		RootPath:      filepath.Join(t.TempDir(), "stopped"),
+		Files:         []*configApplyTransactionFile{{Name: addedFileName, Action: model.Add}},
+	}))
+	require.NoError(t, journal.SetPhase(model.ConfigApplyMoved))
+
+	fileManagerService := NewFileManagerService(&v1fakes.FakeFileServiceClient{}, agentConfig, &sync.RWMutex{})
+
+	recovery := fileManagerService.RecoverConfigApply(context.Background())
+	require.NotNil(t, recovery)
+	require.Error(t, recovery.Error)
+	assert.FileExists(t, addedFileName)
+	assert.NoFileExists(t, filepath.Join(agentConfig.LibDir, configApplyJournalFileName))
+}
+
+func TestFileManagerService_RecoverConfigApply_CorruptJournal(t *testing.T) {
+	agentConfig := types.AgentConfig()
+	agentConfig.LibDir = t.TempDir()
+	journalPath := filepath.Join(agentConfig.LibDir, configApplyJournalFileName)
+	require.NoError(t, os.WriteFile(journalPath, []byte("{"), 0o600))
+
+	fileManagerService := NewFileManagerService(&v1fakes.FakeFileServiceClient{}, agentConfig, &sync.RWMutex{})
+
+	assert.Nil(t, fileManagerService.RecoverConfigApply(context.Background()))
+	assert.NoFileExists(t, journalPath)
+}
+
 func TestFileManagerService_DetermineFileActions(t *testing.T) {
 	ctx := context.Background()
 	tempDir := filepath.Clean(os.TempDir())
@@ -871,6 +1861,7 @@ func TestFileManagerService_DetermineFileActions(t *testing.T) {
 				ctx,
 				test.currentFiles,
 				test.modifiedFiles,
+				InstanceFiles{},
 			)
 
 			if test.expectedError != nil {
@@ -1244,10 +2235,51 @@ func TestFileManagerService_DetermineFileActions_ExternalFile(t *testing.T) {
 	fileManagerService := NewFileManagerService(fakeFileServiceClient, types.AgentConfig(), &sync.RWMutex{})
 	fileManagerService.agentConfig.AllowedDirectories = []string{tempDir}
 
-	diff, err := fileManagerService.DetermineFileActions(ctx, make(map[string]*mpi.File), modifiedFiles)
+	diff, err := fileManagerService.DetermineFileActions(ctx, make(map[string]*mpi.File), modifiedFiles,
+		InstanceFiles{})
 	require.NoError(t, err)
 
 	fc, ok := diff[fileName]
 	require.True(t, ok, "expected file to be present in diff")
 	assert.Equal(t, model.ExternalFile, fc.Action)
 }
+
+func TestFileManagerService_DetermineFileActions_FileOwnership(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	fileName := filepath.Join(tempDir, "nginx.conf")
+	fileContent := []byte("server {}")
+	require.NoError(t, os.WriteFile(fileName, fileContent, 0o600))
+
+	currentUser, err := user.Current()
+	require.NoError(t, err)
+
+	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+	fileManagerService := NewFileManagerService(fakeFileServiceClient, types.AgentConfig(), &sync.RWMutex{})
+	fileManagerService.agentConfig.AllowedDirectories = []string{tempDir}
+
+	currentFiles := map[string]*mpi.File{
+		fileName: {FileMeta: protos.FileMeta(fileName, files.GenerateHash(fileContent))},
+	}
+
+	for _, test := range []struct {
+		name           string
+		owner          string
+		expectedAction model.FileAction
+	}{
+		{name: "Test 1: same owner", owner: currentUser.Username, expectedAction: model.Unchanged},
+		{name: "Test 2: owner changed", owner: "54321", expectedAction: model.Update},
+	} {
+		t.Run(test.name, func(tt *testing.T) {
+			fileMeta := protos.FileMeta(fileName, files.GenerateHash(fileContent))
+			fileMeta.Owner = test.owner
+			modifiedFiles := map[string]*model.FileCache{
+				fileName: {File: &mpi.File{FileMeta: fileMeta}},
+			}
+
+			_, determineErr := fileManagerService.DetermineFileActions(ctx, currentFiles, modifiedFiles, InstanceFiles{})
+			require.NoError(tt, determineErr)
+			assert.Equal(tt, test.expectedAction, modifiedFiles[fileName].Action)
+		})
+	}
+}
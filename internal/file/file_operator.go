@@ -81,39 +81,26 @@ func (fo *FileOperator) WriteChunkedFile(
 	header *mpi.FileDataChunkHeader,
 	stream grpc.ServerStreamingClient[mpi.FileDataChunk],
 ) error {
-	createFileDirectoriesError := fo.CreateFileDirectories(ctx, fileName)
-	if createFileDirectoriesError != nil {
-		return createFileDirectoriesError
-	}
-
-	fileToWrite, err := os.Create(fileName)
+	fileToWrite, err := fo.createChunkedFile(ctx, fileName, filePermissions, header.GetOffset())
 	if err != nil {
 		return err
 	}
-	defer func() {
-		closeError := fileToWrite.Close()
-		if closeError != nil {
-			slog.WarnContext(
-				ctx, "Failed to close file",
-				"file", fileName,
-				"error", closeError,
-			)
-		}
-	}()
-
-	filePermission := files.FileMode(filePermissions)
-	if modeErr := os.Chmod(fileName, filePermission); modeErr != nil {
-		return fmt.Errorf("error setting permissions for %s file: %w", fileName, modeErr)
-	}
+	defer closeFile(ctx, fileToWrite)
 
-	slog.DebugContext(ctx, "Writing chunked file", "file", fileName)
+	slog.DebugContext(ctx, "Writing chunked file", "file", fileName, "offset", header.GetOffset())
 	for range header.GetChunks() {
 		chunk, recvError := stream.Recv()
 		if recvError != nil {
 			return recvError
 		}
 
-		_, chunkWriteError := fileToWrite.Write(chunk.GetContent().GetData())
+		data, decompressError := files.Decompress(chunk.GetContent().GetData(), header.GetCompression(),
+			int64(header.GetChunkSize()))
+		if decompressError != nil {
+			return fmt.Errorf("error decompressing chunk of file %s: %w", fileName, decompressError)
+		}
+
+		_, chunkWriteError := fileToWrite.Write(data)
 		if chunkWriteError != nil {
 			return fmt.Errorf("error writing chunk to file %s: %w", fileName, chunkWriteError)
 		}
@@ -122,6 +109,77 @@ func (fo *FileOperator) WriteChunkedFile(
 	return nil
 }
 
+// WriteChunkedFileDelta writes the file rebuilt from the delta instructions received from the stream and the
+// blocks of the basis file, which is the copy of the file that the signature was created from.
+func (fo *FileOperator) WriteChunkedFileDelta(
+	ctx context.Context,
+	fileName, filePermissions, basisFileName string,
+	header *mpi.FileDataChunkHeader,
+	signature *mpi.FileSignature,
+	stream grpc.ServerStreamingClient[mpi.FileDataChunk],
+) error {
+	basisFile, err := os.Open(basisFileName)
+	if err != nil {
+		return fmt.Errorf("error opening basis file %s: %w", basisFileName, err)
+	}
+	defer closeFile(ctx, basisFile)
+
+	fileToWrite, err := fo.createChunkedFile(ctx, fileName, filePermissions, 0)
+	if err != nil {
+		return err
+	}
+	defer closeFile(ctx, fileToWrite)
+
+	slog.DebugContext(ctx, "Writing chunked file from delta", "file", fileName, "basis_file", basisFileName)
+
+	if err = files.RecvChunkedFileDelta(stream, header, signature, basisFile, fileToWrite); err != nil {
+		return fmt.Errorf("error writing delta to file %s: %w", fileName, err)
+	}
+
+	return nil
+}
+
+// createChunkedFile creates the file a chunked file is written to. If the offset is not 0, the existing file is
+// opened to resume an interrupted download at the offset instead.
+func (fo *FileOperator) createChunkedFile(
+	ctx context.Context,
+	fileName, filePermissions string,
+	offset uint64,
+) (*os.File, error) {
+	createFileDirectoriesError := fo.CreateFileDirectories(ctx, fileName)
+	if createFileDirectoriesError != nil {
+		return nil, createFileDirectoriesError
+	}
+
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if offset > 0 {
+		flag = os.O_WRONLY
+	}
+
+	filePermission := files.FileMode(filePermissions)
+
+	fileToWrite, err := os.OpenFile(fileName, flag, filePermission)
+	if err != nil {
+		return nil, err
+	}
+
+	if modeErr := os.Chmod(fileName, filePermission); modeErr != nil {
+		closeFile(ctx, fileToWrite)
+
+		return nil, fmt.Errorf("error setting permissions for %s file: %w", fileName, modeErr)
+	}
+
+	if offset > 0 {
+		if resumeErr := resumeChunkedFile(fileToWrite, offset); resumeErr != nil {
+			closeFile(ctx, fileToWrite)
+
+			return nil, fmt.Errorf("error resuming file %s at offset %d: %w", fileName, offset, resumeErr)
+		}
+	}
+
+	return fileToWrite, nil
+}
+
 func (fo *FileOperator) ReadChunk(
 	ctx context.Context,
 	chunkSize uint32,
@@ -244,6 +302,26 @@ func (fo *FileOperator) MoveFile(ctx context.Context, sourcePath, destPath strin
 	return nil
 }
 
+// resumeChunkedFile discards any data after the offset and moves to the offset, so that writes continue there
+func resumeChunkedFile(file *os.File, offset uint64) error {
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	if uint64(fileInfo.Size()) < offset { //nolint:gosec // file sizes are not negative
+		return fmt.Errorf("file is smaller than the offset: %d bytes", fileInfo.Size())
+	}
+
+	if err = file.Truncate(int64(offset)); err != nil { //nolint:gosec // offset is less than the file size
+		return err
+	}
+
+	_, err = file.Seek(0, io.SeekEnd)
+
+	return err
+}
+
 func closeFile(ctx context.Context, file *os.File) {
 	err := file.Close()
 	if err != nil {
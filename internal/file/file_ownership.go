@@ -0,0 +1,274 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/model"
+)
+
+const (
+	passwdFile = "/etc/passwd"
+	groupFile  = "/etc/group"
+)
+
+// checkFileOwnership checks that the owner and group of a file, if set, are in the allowed file owners and groups.
+// The owner and group can be a name or a numeric id, and are allowed if either the name or the id is in the list.
+// Names are looked up in the root path of the instance, see lookupUser.
+func checkFileOwnership(fileMeta *mpi.FileMeta, rootPath string, allowedOwners, allowedGroups []string) error {
+	if owner := fileMeta.GetOwner(); owner != "" {
+		u, err := lookupUser(rootPath, owner)
+		if err != nil {
+			return fmt.Errorf("unknown owner %s for file %s: %w", owner, fileMeta.GetName(), err)
+		}
+
+		if !slices.Contains(allowedOwners, u.Username) && !slices.Contains(allowedOwners, u.Uid) {
+			return fmt.Errorf("owner %s of file %s is not in allowed file owners", owner, fileMeta.GetName())
+		}
+	}
+
+	if group := fileMeta.GetGroup(); group != "" {
+		g, err := lookupGroup(rootPath, group)
+		if err != nil {
+			return fmt.Errorf("unknown group %s for file %s: %w", group, fileMeta.GetName(), err)
+		}
+
+		if !slices.Contains(allowedGroups, g.Name) && !slices.Contains(allowedGroups, g.Gid) {
+			return fmt.Errorf("group %s of file %s is not in allowed file groups", group, fileMeta.GetName())
+		}
+	}
+
+	return nil
+}
+
+// setFileOwnership changes the owner, group and SELinux context of a file to the ones in the file meta.
+// Fields that are not set are left unchanged.
+func setFileOwnership(ctx context.Context, fileMeta *mpi.FileMeta, rootPath string) error {
+	uid, gid, err := fileOwnerIDs(fileMeta, rootPath)
+	if err != nil {
+		return err
+	}
+
+	if uid != -1 || gid != -1 {
+		if err = os.Lchown(fileMeta.GetName(), uid, gid); err != nil {
+			return fmt.Errorf("unable to change ownership of file %s: %w", fileMeta.GetName(), err)
+		}
+
+		slog.DebugContext(ctx, "Changed file ownership", "file", fileMeta.GetName(),
+			"owner", fileMeta.GetOwner(), "group", fileMeta.GetGroup())
+	}
+
+	if selinuxContext := fileMeta.GetSelinuxContext(); selinuxContext != "" {
+		if err = setFileSelinuxContext(fileMeta.GetName(), selinuxContext); err != nil {
+			return err
+		}
+
+		slog.DebugContext(ctx, "Changed file SELinux context", "file", fileMeta.GetName(),
+			"selinux_context", selinuxContext)
+	}
+
+	return nil
+}
+
+// ownershipAction returns Update if the owner, group or SELinux context in the file meta differ from the ones of
+// the file on disk. Owners and groups are compared by numeric id, since they can be set as a name or an id.
+func ownershipAction(fileMeta *mpi.FileMeta, rootPath string) (model.FileAction, error) {
+	if fileMeta.GetOwner() == "" && fileMeta.GetGroup() == "" && fileMeta.GetSelinuxContext() == "" {
+		return model.Unchanged, nil
+	}
+
+	uid, gid, err := fileOwnerIDs(fileMeta, rootPath)
+	if err != nil {
+		return model.Unchanged, err
+	}
+
+	stat, err := lstat(fileMeta.GetName())
+	if err != nil {
+		return model.Unchanged, err
+	}
+
+	if (uid != -1 && int64(uid) != int64(stat.Uid)) || (gid != -1 && int64(gid) != int64(stat.Gid)) {
+		return model.Update, nil
+	}
+
+	if selinuxContext := fileMeta.GetSelinuxContext(); selinuxContext != "" {
+		currentContext, contextErr := fileSelinuxContext(fileMeta.GetName())
+		if contextErr != nil {
+			return model.Unchanged, contextErr
+		}
+
+		if currentContext != selinuxContext {
+			return model.Update, nil
+		}
+	}
+
+	return model.Unchanged, nil
+}
+
+// fileOwnership returns the owner, group and SELinux context of a file. The owner and group are names, or numeric
+// ids if they have no name. The SELinux context is empty if the file system doesn't support SELinux labels.
+func fileOwnership(fileName, rootPath string) (owner, group, selinuxContext string, err error) {
+	stat, err := lstat(fileName)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	owner = strconv.FormatUint(uint64(stat.Uid), 10)
+	if u, lookupErr := lookupUser(rootPath, owner); lookupErr == nil {
+		owner = u.Username
+	}
+
+	group = strconv.FormatUint(uint64(stat.Gid), 10)
+	if g, lookupErr := lookupGroup(rootPath, group); lookupErr == nil {
+		group = g.Name
+	}
+
+	selinuxContext, err = fileSelinuxContext(fileName)
+
+	return owner, group, selinuxContext, err
+}
+
+// fileOwnerIDs returns the numeric ids of the owner and group in the file meta, or -1 if they are not set.
+func fileOwnerIDs(fileMeta *mpi.FileMeta, rootPath string) (uid, gid int, err error) {
+	uid, gid = -1, -1
+
+	if owner := fileMeta.GetOwner(); owner != "" {
+		u, lookupErr := lookupUser(rootPath, owner)
+		if lookupErr != nil {
+			return -1, -1, fmt.Errorf("unknown owner %s for file %s: %w", owner, fileMeta.GetName(), lookupErr)
+		}
+
+		if uid, err = strconv.Atoi(u.Uid); err != nil {
+			return -1, -1, fmt.Errorf("invalid uid %s for owner %s: %w", u.Uid, owner, err)
+		}
+	}
+
+	if group := fileMeta.GetGroup(); group != "" {
+		g, lookupErr := lookupGroup(rootPath, group)
+		if lookupErr != nil {
+			return -1, -1, fmt.Errorf("unknown group %s for file %s: %w", group, fileMeta.GetName(), lookupErr)
+		}
+
+		if gid, err = strconv.Atoi(g.Gid); err != nil {
+			return -1, -1, fmt.Errorf("invalid gid %s for group %s: %w", g.Gid, group, err)
+		}
+	}
+
+	return uid, gid, nil
+}
+
+func lstat(fileName string) (*syscall.Stat_t, error) {
+	fileInfo, err := os.Lstat(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to stat file %s: %w", fileName, err)
+	}
+
+	stat, ok := fileInfo.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil, fmt.Errorf("unable to get ownership of file %s", fileName)
+	}
+
+	return stat, nil
+}
+
+// lookupUser looks up a user by name or numeric id. A numeric id without a user name is returned as is.
+// If a root path is set, e.g. for an instance in a container, the user is looked up in the passwd file under the
+// root path instead of the users of the host.
+func lookupUser(rootPath, owner string) (*user.User, error) {
+	if rootPath != "" {
+		name, uid, err := lookupAccount(filepath.Join(rootPath, passwdFile), owner)
+		if err != nil {
+			return nil, err
+		}
+
+		if uid == "" {
+			return nil, user.UnknownUserError(owner)
+		}
+
+		return &user.User{Uid: uid, Username: name}, nil
+	}
+
+	if _, err := strconv.Atoi(owner); err == nil {
+		u, lookupErr := user.LookupId(owner)
+		if errors.As(lookupErr, new(user.UnknownUserIdError)) {
+			return &user.User{Uid: owner, Username: owner}, nil
+		}
+
+		return u, lookupErr
+	}
+
+	return user.Lookup(owner)
+}
+
+// lookupGroup looks up a group by name or numeric id. A numeric id without a group name is returned as is.
+// If a root path is set, the group is looked up in the group file under the root path, see lookupUser.
+func lookupGroup(rootPath, group string) (*user.Group, error) {
+	if rootPath != "" {
+		name, gid, err := lookupAccount(filepath.Join(rootPath, groupFile), group)
+		if err != nil {
+			return nil, err
+		}
+
+		if gid == "" {
+			return nil, user.UnknownGroupError(group)
+		}
+
+		return &user.Group{Gid: gid, Name: name}, nil
+	}
+
+	if _, err := strconv.Atoi(group); err == nil {
+		g, lookupErr := user.LookupGroupId(group)
+		if errors.As(lookupErr, new(user.UnknownGroupIdError)) {
+			return &user.Group{Gid: group, Name: group}, nil
+		}
+
+		return g, lookupErr
+	}
+
+	return user.LookupGroup(group)
+}
+
+// lookupAccount looks up a name or numeric id in a passwd or group file, which both have the name in the first and
+// the id in the third field of a line. A numeric id without an entry is returned as is, and the id is empty if
+// a name has no entry.
+func lookupAccount(fileName, nameOrID string) (name, id string, err error) {
+	_, atoiErr := strconv.Atoi(nameOrID)
+	isID := atoiErr == nil
+
+	content, err := os.ReadFile(fileName)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", "", fmt.Errorf("unable to read %s: %w", fileName, err)
+	}
+
+	for line := range strings.Lines(string(content)) {
+		fields := strings.Split(strings.TrimSpace(line), ":")
+		if len(fields) < 3 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		if (isID && fields[2] == nameOrID) || (!isID && fields[0] == nameOrID) {
+			return fields[0], fields[2], nil
+		}
+	}
+
+	if isID {
+		return nameOrID, nameOrID, nil
+	}
+
+	return "", "", nil
+}
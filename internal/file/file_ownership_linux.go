@@ -0,0 +1,50 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+const selinuxXattr = "security.selinux"
+
+func setFileSelinuxContext(fileName, selinuxContext string) error {
+	if err := unix.Lsetxattr(fileName, selinuxXattr, []byte(selinuxContext), 0); err != nil {
+		return fmt.Errorf("unable to set SELinux context %s of file %s: %w", selinuxContext, fileName, err)
+	}
+
+	return nil
+}
+
+// fileSelinuxContext returns the SELinux context of a file, which is empty if the file system doesn't support
+// SELinux labels
+func fileSelinuxContext(fileName string) (string, error) {
+	buf := make([]byte, unix.NAME_MAX)
+
+	size, err := unix.Lgetxattr(fileName, selinuxXattr, buf)
+	if errors.Is(err, unix.ERANGE) {
+		size, err = unix.Lgetxattr(fileName, selinuxXattr, nil)
+		if err == nil {
+			buf = make([]byte, size)
+			size, err = unix.Lgetxattr(fileName, selinuxXattr, buf)
+		}
+	}
+
+	if errors.Is(err, unix.ENODATA) || errors.Is(err, unix.ENOTSUP) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("unable to get SELinux context of file %s: %w", fileName, err)
+	}
+
+	// the context is NUL terminated
+	return string(bytes.TrimRight(buf[:size], "\x00")), nil
+}
@@ -0,0 +1,24 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+//go:build !linux
+
+package file
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// setFileSelinuxContext always fails, since SELinux is only available on Linux
+func setFileSelinuxContext(fileName, selinuxContext string) error {
+	return fmt.Errorf("unable to set SELinux context %s of file %s: SELinux is not supported on %s",
+		selinuxContext, fileName, runtime.GOOS)
+}
+
+// fileSelinuxContext always returns an empty context, since SELinux is only available on Linux
+func fileSelinuxContext(_ string) (string, error) {
+	return "", nil
+}
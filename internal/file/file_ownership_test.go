@@ -0,0 +1,219 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"context"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/model"
+)
+
+func TestCheckFileOwnership(t *testing.T) {
+	currentUser, err := user.Current()
+	require.NoError(t, err)
+	currentGroup, err := user.LookupGroupId(currentUser.Gid)
+	require.NoError(t, err)
+
+	tests := []struct {
+		fileMeta      *mpi.FileMeta
+		name          string
+		expectedError string
+		allowedOwners []string
+		allowedGroups []string
+	}{
+		{
+			name:     "Test 1: no owner or group",
+			fileMeta: &mpi.FileMeta{Name: "/etc/nginx/nginx.conf"},
+		},
+		{
+			name: "Test 2: owner and group names allowed",
+			fileMeta: &mpi.FileMeta{
+				Name:  "/etc/nginx/nginx.conf",
+				Owner: currentUser.Username,
+				Group: currentGroup.Name,
+			},
+			allowedOwners: []string{currentUser.Username},
+			allowedGroups: []string{currentGroup.Name},
+		},
+		{
+			name: "Test 3: owner and group ids allowed by name",
+			fileMeta: &mpi.FileMeta{
+				Name:  "/etc/nginx/nginx.conf",
+				Owner: currentUser.Uid,
+				Group: currentGroup.Gid,
+			},
+			allowedOwners: []string{currentUser.Username},
+			allowedGroups: []string{currentGroup.Name},
+		},
+		{
+			name: "Test 4: unknown numeric ids allowed by id",
+			fileMeta: &mpi.FileMeta{
+				Name:  "/etc/nginx/nginx.conf",
+				Owner: "54321",
+				Group: "54321",
+			},
+			allowedOwners: []string{"54321"},
+			allowedGroups: []string{"54321"},
+		},
+		{
+			name: "Test 5: owner not allowed",
+			fileMeta: &mpi.FileMeta{
+				Name:  "/etc/nginx/nginx.conf",
+				Owner: currentUser.Username,
+			},
+			allowedOwners: []string{"nginx"},
+			expectedError: "owner " + currentUser.Username + " of file /etc/nginx/nginx.conf is not in " +
+				"allowed file owners",
+		},
+		{
+			name: "Test 6: group not allowed",
+			fileMeta: &mpi.FileMeta{
+				Name:  "/etc/nginx/nginx.conf",
+				Group: currentGroup.Name,
+			},
+			expectedError: "group " + currentGroup.Name + " of file /etc/nginx/nginx.conf is not in " +
+				"allowed file groups",
+		},
+		{
+			name: "Test 7: unknown owner",
+			fileMeta: &mpi.FileMeta{
+				Name:  "/etc/nginx/nginx.conf",
+				Owner: "unknown-file-owner",
+			},
+			allowedOwners: []string{"unknown-file-owner"},
+			expectedError: "unknown owner unknown-file-owner for file /etc/nginx/nginx.conf: " +
+				"user: unknown user unknown-file-owner",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			checkErr := checkFileOwnership(test.fileMeta, "", test.allowedOwners, test.allowedGroups)
+			if test.expectedError == "" {
+				require.NoError(tt, checkErr)
+			} else {
+				require.EqualError(tt, checkErr, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestSetFileOwnership(t *testing.T) {
+	ctx := context.Background()
+	filePath := filepath.Join(t.TempDir(), "nginx.conf")
+	require.NoError(t, os.WriteFile(filePath, []byte("server {}"), 0o600))
+
+	currentUser, err := user.Current()
+	require.NoError(t, err)
+	currentGroup, err := user.LookupGroupId(currentUser.Gid)
+	require.NoError(t, err)
+
+	err = setFileOwnership(ctx, &mpi.FileMeta{
+		Name:  filePath,
+		Owner: currentUser.Uid,
+		Group: currentGroup.Name,
+	}, "")
+	require.NoError(t, err)
+
+	owner, group, _, err := fileOwnership(filePath, "")
+	require.NoError(t, err)
+	assert.Equal(t, currentUser.Username, owner)
+	assert.Equal(t, currentGroup.Name, group)
+
+	// unset fields leave the file unchanged
+	require.NoError(t, setFileOwnership(ctx, &mpi.FileMeta{Name: filePath}, ""))
+
+	err = setFileOwnership(ctx, &mpi.FileMeta{Name: filePath, Owner: "unknown-file-owner"}, "")
+	require.EqualError(t, err, "unknown owner unknown-file-owner for file "+filePath+
+		": user: unknown user unknown-file-owner")
+}
+
+func TestFileOwnership(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "nginx.conf")
+	require.NoError(t, os.WriteFile(filePath, []byte("server {}"), 0o600))
+
+	currentUser, err := user.Current()
+	require.NoError(t, err)
+
+	owner, group, _, err := fileOwnership(filePath, "")
+	require.NoError(t, err)
+	assert.Equal(t, currentUser.Username, owner)
+
+	currentGroup, err := user.LookupGroupId(strconv.Itoa(os.Getgid()))
+	require.NoError(t, err)
+	assert.Equal(t, currentGroup.Name, group)
+
+	_, _, _, err = fileOwnership(filepath.Join(t.TempDir(), "missing.conf"), "")
+	require.Error(t, err)
+}
+
+func TestOwnershipAction(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "nginx.conf")
+	require.NoError(t, os.WriteFile(filePath, []byte("server {}"), 0o600))
+
+	currentUser, err := user.Current()
+	require.NoError(t, err)
+
+	action, err := ownershipAction(&mpi.FileMeta{Name: filePath}, "")
+	require.NoError(t, err)
+	assert.Equal(t, model.Unchanged, action)
+
+	action, err = ownershipAction(&mpi.FileMeta{Name: filePath, Owner: currentUser.Username, Group: currentUser.Gid}, "")
+	require.NoError(t, err)
+	assert.Equal(t, model.Unchanged, action)
+
+	action, err = ownershipAction(&mpi.FileMeta{Name: filePath, Owner: "54321"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, model.Update, action)
+
+	_, err = ownershipAction(&mpi.FileMeta{Name: filePath, Group: "unknown-file-group"}, "")
+	require.Error(t, err)
+}
+
+func TestLookupUser_RootPath(t *testing.T) {
+	rootPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(rootPath, "etc"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(rootPath, passwdFile),
+		[]byte("root:x:0:0:root:/root:/bin/sh\nnginx:x:101:101:nginx:/var/cache/nginx:/sbin/nologin\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(rootPath, groupFile),
+		[]byte("root:x:0:\nnginx:x:101:nginx\n"), 0o600))
+
+	u, err := lookupUser(rootPath, "nginx")
+	require.NoError(t, err)
+	assert.Equal(t, &user.User{Uid: "101", Username: "nginx"}, u)
+
+	u, err = lookupUser(rootPath, "101")
+	require.NoError(t, err)
+	assert.Equal(t, "nginx", u.Username)
+
+	// numeric ids without a name in the root path are returned as is
+	u, err = lookupUser(rootPath, "54321")
+	require.NoError(t, err)
+	assert.Equal(t, &user.User{Uid: "54321", Username: "54321"}, u)
+
+	_, err = lookupUser(rootPath, "unknown-file-owner")
+	require.EqualError(t, err, "user: unknown user unknown-file-owner")
+
+	g, err := lookupGroup(rootPath, "nginx")
+	require.NoError(t, err)
+	assert.Equal(t, &user.Group{Gid: "101", Name: "nginx"}, g)
+
+	_, err = lookupGroup(rootPath, "unknown-file-group")
+	require.EqualError(t, err, "group: unknown group unknown-file-group")
+
+	err = checkFileOwnership(&mpi.FileMeta{Name: "/etc/nginx/nginx.conf", Owner: "nginx", Group: "nginx"},
+		rootPath, []string{"101"}, []string{"nginx"})
+	require.NoError(t, err)
+}
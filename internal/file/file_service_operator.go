@@ -16,6 +16,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 
@@ -28,9 +29,13 @@ import (
 	"github.com/nginx/agent/v3/pkg/files"
 	"github.com/nginx/agent/v3/pkg/id"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// partialFilesDirName is the directory in the lib directory that chunked files are received into
+const partialFilesDirName = "partial_files"
+
 // FileServiceOperator handles requests to the grpc file service
 type FileServiceOperator struct {
 	fileServiceClient   mpi.FileServiceClient
@@ -76,6 +81,26 @@ func (fso *FileServiceOperator) File(
 	file *mpi.File,
 	tempFilePath, expectedHash string,
 ) error {
+	contents, err := fso.FileContents(ctx, file)
+	if err != nil {
+		return err
+	}
+
+	if writeErr := fso.fileOperator.Write(
+		ctx,
+		contents,
+		tempFilePath,
+		file.GetFileMeta().GetPermissions(),
+	); writeErr != nil {
+		return writeErr
+	}
+
+	return fso.ValidateFileHash(ctx, tempFilePath, expectedHash)
+}
+
+// FileContents gets the contents of a file from the management plane without writing it to disk.
+// Compressed contents are decompressed, and rejected if they are larger than the max file size once decompressed.
+func (fso *FileServiceOperator) FileContents(ctx context.Context, file *mpi.File) ([]byte, error) {
 	slog.DebugContext(ctx, "Getting file", "file", file.GetFileMeta().GetName())
 
 	backOffCtx, backoffCancel := context.WithTimeout(ctx, fso.agentConfig.Client.Backoff.MaxElapsedTime)
@@ -91,7 +116,8 @@ func (fso *FileServiceOperator) File(
 				CorrelationId: logger.CorrelationID(ctx),
 				Timestamp:     timestamppb.Now(),
 			},
-			FileMeta: file.GetFileMeta(),
+			FileMeta:             file.GetFileMeta(),
+			AcceptedCompressions: files.SupportedCompressions,
 		})
 
 		validatedError := internalgrpc.ValidateGrpcError(err)
@@ -112,19 +138,19 @@ func (fso *FileServiceOperator) File(
 	)
 
 	if getFileErr != nil {
-		return fmt.Errorf("error getting file data for %s: %w", file.GetFileMeta(), getFileErr)
+		return nil, fmt.Errorf("error getting file data for %s: %w", file.GetFileMeta(), getFileErr)
 	}
 
-	if writeErr := fso.fileOperator.Write(
-		ctx,
+	contents, err := files.Decompress(
 		getFileResp.GetContents().GetContents(),
-		tempFilePath,
-		file.GetFileMeta().GetPermissions(),
-	); writeErr != nil {
-		return writeErr
+		getFileResp.GetContents().GetCompression(),
+		int64(fso.agentConfig.Client.Grpc.MaxFileSize),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting file data for %s: %w", file.GetFileMeta(), err)
 	}
 
-	return fso.ValidateFileHash(ctx, tempFilePath, expectedHash)
+	return contents, nil
 }
 
 func (fso *FileServiceOperator) UpdateOverview(
@@ -242,10 +268,64 @@ func (fso *FileServiceOperator) UpdateOverview(
 	return err
 }
 
+// ChunkedFile streams a file from the management plane. If there is a copy of the file on disk, its block
+// signatures are sent with the request, so that a management plane that supports delta file transfers only
+// sends the changed blocks. The copy of the file is read from the basis file path, which is the path of the file on
+// disk for an instance in another container. If the file can't be rebuilt from a delta, the full file is requested
+// instead.
+// The full file content is received into a partial file, keyed by the file hash, so that an interrupted stream
+// is resumed from the data already received instead of starting over.
 func (fso *FileServiceOperator) ChunkedFile(
-	ctx context.Context, file *mpi.File, tempFilePath, expectedHash string,
+	ctx context.Context, file *mpi.File, tempFilePath, expectedHash, basisFilePath string,
 ) error {
-	slog.DebugContext(ctx, "Getting chunked file", "file", file.GetFileMeta().GetName())
+	fileName := file.GetFileMeta().GetName()
+	partialFilePath := fso.partialFilePath(fileName, expectedHash)
+	removeStalePartialFiles(ctx, fileName, partialFilePath)
+
+	// a delta is only requested if there is no interrupted download of the file to resume
+	var signature *mpi.FileSignature
+	if partialFileSize(partialFilePath) == 0 {
+		signature = fso.fileSignature(ctx, basisFilePath)
+	}
+
+	backOffCtx, backoffCancel := context.WithTimeout(ctx, fso.agentConfig.Client.Backoff.MaxElapsedTime)
+	defer backoffCancel()
+
+	getChunkedFile := func() (struct{}, error) {
+		delta, err := fso.chunkedFile(ctx, file, tempFilePath, partialFilePath, basisFilePath, expectedHash,
+			signature)
+		if err != nil && delta {
+			slog.WarnContext(ctx, "Delta file transfer failed, falling back to full file transfer",
+				"file", fileName, "error", err)
+
+			signature = nil
+		}
+
+		return struct{}{}, err
+	}
+
+	_, err := backoff.Retry(
+		backOffCtx,
+		getChunkedFile,
+		backoffHelpers.RetryOptions(backOffCtx, fso.agentConfig.Client.Backoff)...,
+	)
+
+	return err
+}
+
+func (fso *FileServiceOperator) chunkedFile(
+	ctx context.Context,
+	file *mpi.File,
+	tempFilePath, partialFilePath, basisFilePath, expectedHash string,
+	signature *mpi.FileSignature,
+) (delta bool, err error) {
+	var offset uint64
+	if signature == nil {
+		offset = partialFileSize(partialFilePath)
+	}
+
+	slog.DebugContext(ctx, "Getting chunked file", "file", file.GetFileMeta().GetName(),
+		"signature_blocks", len(signature.GetBlocks()), "offset", offset)
 
 	grpcCtx, cancel := context.WithTimeout(ctx, fso.agentConfig.Client.FileDownloadTimeout)
 	defer cancel()
@@ -256,32 +336,127 @@ func (fso *FileServiceOperator) ChunkedFile(
 			CorrelationId: logger.CorrelationID(ctx),
 			Timestamp:     timestamppb.Now(),
 		},
-		FileMeta: file.GetFileMeta(),
+		FileMeta:             file.GetFileMeta(),
+		Signature:            signature,
+		Offset:               offset,
+		AcceptedCompressions: files.SupportedCompressions,
 	})
 	if err != nil {
-		return fmt.Errorf("error getting file stream for %s: %w", file.GetFileMeta().GetName(), err)
+		return false, fmt.Errorf("error getting file stream for %s: %w", file.GetFileMeta().GetName(), err)
 	}
 
 	// Get header chunk first
 	headerChunk, recvHeaderChunkError := stream.Recv()
 	if recvHeaderChunkError != nil {
-		return recvHeaderChunkError
+		return false, recvHeaderChunkError
 	}
 
 	slog.DebugContext(ctx, "File header chunk received", "header_chunk", headerChunk)
 
 	header := headerChunk.GetHeader()
 
-	writeChunkedFileError := fso.fileOperator.WriteChunkedFile(
-		ctx, tempFilePath, file.GetFileMeta().GetPermissions(), header, stream,
+	// the decompressed size of each chunk is at most the chunk size
+	if header.GetCompression() != mpi.Compression_COMPRESSION_UNSPECIFIED &&
+		header.GetChunkSize() > fso.agentConfig.Client.Grpc.MaxFileSize {
+		return false, backoff.Permanent(fmt.Errorf("error getting file stream for %s: chunk size %d exceeds "+
+			"max file size %d", file.GetFileMeta().GetName(), header.GetChunkSize(),
+			fso.agentConfig.Client.Grpc.MaxFileSize))
+	}
+
+	if header.GetDelta() {
+		return true, fso.writeChunkedFileDelta(ctx, file, tempFilePath, basisFilePath, expectedHash, header,
+			signature, stream)
+	}
+
+	// the management plane sends the full file if it doesn't support resuming a stream
+	if header.GetOffset() != 0 && header.GetOffset() != offset {
+		return false, backoff.Permanent(fmt.Errorf("error getting file stream for %s: unexpected offset %d, "+
+			"expected offset %d", file.GetFileMeta().GetName(), header.GetOffset(), offset))
+	}
+
+	err = fso.fileOperator.WriteChunkedFile(
+		ctx, partialFilePath, file.GetFileMeta().GetPermissions(), header, stream,
 	)
-	if writeChunkedFileError != nil {
-		return writeChunkedFileError
+	if err != nil {
+		return false, err
+	}
+
+	if err = fso.ValidateFileHash(ctx, partialFilePath, expectedHash); err != nil {
+		// the partial file can't be resumed, the next attempt downloads the full file again
+		if removeErr := os.Remove(partialFilePath); removeErr != nil {
+			slog.WarnContext(ctx, "Failed to remove partial file", "file", partialFilePath, "error", removeErr)
+		}
+
+		if header.GetOffset() == 0 {
+			return false, backoff.Permanent(err)
+		}
+
+		return false, err
+	}
+
+	// the partial file is in the lib directory, which can be on a different file system than the temp file
+	if err = fso.fileOperator.MoveFile(ctx, partialFilePath, tempFilePath); err != nil {
+		return false, err
+	}
+
+	if err = os.Remove(partialFilePath); err != nil {
+		slog.WarnContext(ctx, "Failed to remove partial file", "file", partialFilePath, "error", err)
+	}
+
+	return false, nil
+}
+
+func (fso *FileServiceOperator) writeChunkedFileDelta(
+	ctx context.Context,
+	file *mpi.File,
+	tempFilePath, basisFilePath, expectedHash string,
+	header *mpi.FileDataChunkHeader,
+	signature *mpi.FileSignature,
+	stream grpc.ServerStreamingClient[mpi.FileDataChunk],
+) error {
+	if signature == nil {
+		return backoff.Permanent(fmt.Errorf("error getting file stream for %s: unexpected delta file transfer",
+			file.GetFileMeta().GetName()))
+	}
+
+	err := fso.fileOperator.WriteChunkedFileDelta(ctx, tempFilePath, file.GetFileMeta().GetPermissions(),
+		basisFilePath, header, signature, stream)
+	if err != nil {
+		return err
 	}
 
 	return fso.ValidateFileHash(ctx, tempFilePath, expectedHash)
 }
 
+// fileSignature returns the block signatures of the copy of a file on disk, or nil if there is no copy of the
+// file or its signature is larger than the max file size.
+func (fso *FileServiceOperator) fileSignature(ctx context.Context, fileName string) *mpi.FileSignature {
+	fileInfo, err := os.Stat(fileName)
+	if err != nil || !fileInfo.Mode().IsRegular() || fileInfo.Size() == 0 {
+		return nil
+	}
+
+	basisFile, err := os.Open(fileName)
+	if err != nil {
+		slog.DebugContext(ctx, "Unable to open file to create signature", "file", fileName, "error", err)
+		return nil
+	}
+	defer closeFile(ctx, basisFile)
+
+	signature, err := files.Signature(bufio.NewReader(basisFile), files.SignatureBlockSize(fileInfo.Size()))
+	if err != nil {
+		slog.DebugContext(ctx, "Unable to create file signature", "file", fileName, "error", err)
+		return nil
+	}
+
+	if proto.Size(signature) > int(fso.agentConfig.Client.Grpc.MaxFileSize) {
+		slog.DebugContext(ctx, "File signature is too large to send", "file", fileName)
+		return nil
+	}
+
+	return signature
+}
+
 func (fso *FileServiceOperator) UpdateFile(
 	ctx context.Context,
 	instanceID string,
@@ -379,9 +554,44 @@ func (fso *FileServiceOperator) updateFiles(
 	return fso.UpdateOverview(ctx, instanceID, diffFiles, configPath, iteration)
 }
 
+// UpdateFileContents sends the contents of a file that is not on disk to the management plane
+func (fso *FileServiceOperator) UpdateFileContents(
+	ctx context.Context,
+	instanceID string,
+	fileToUpdate *mpi.File,
+	contents []byte,
+) error {
+	slog.InfoContext(
+		ctx,
+		"Sending file contents",
+		"file_name", fileToUpdate.GetFileMeta().GetName(),
+		"instance_id", instanceID,
+	)
+
+	if int64(len(contents)) > int64(fso.agentConfig.Client.Grpc.MaxFileSize) {
+		return fmt.Errorf("file %s of size %d is larger than the max file size %d",
+			fileToUpdate.GetFileMeta().GetName(), len(contents), fso.agentConfig.Client.Grpc.MaxFileSize)
+	}
+
+	return fso.sendUpdateFileContents(ctx, fileToUpdate, contents)
+}
+
 func (fso *FileServiceOperator) sendUpdateFileRequest(
 	ctx context.Context,
 	fileToUpdate *mpi.File,
+) error {
+	contents, err := os.ReadFile(fileToUpdate.GetFileMeta().GetName())
+	if err != nil {
+		return err
+	}
+
+	return fso.sendUpdateFileContents(ctx, fileToUpdate, contents)
+}
+
+func (fso *FileServiceOperator) sendUpdateFileContents(
+	ctx context.Context,
+	fileToUpdate *mpi.File,
+	contents []byte,
 ) error {
 	messageMeta := &mpi.MessageMeta{
 		MessageId:     id.GenerateMessageID(),
@@ -389,7 +599,9 @@ func (fso *FileServiceOperator) sendUpdateFileRequest(
 		Timestamp:     timestamppb.Now(),
 	}
 
-	contents, err := os.ReadFile(fileToUpdate.GetFileMeta().GetName())
+	compression := fso.fileCompression(ctx)
+
+	contents, err := files.Compress(contents, compression)
 	if err != nil {
 		return err
 	}
@@ -397,7 +609,8 @@ func (fso *FileServiceOperator) sendUpdateFileRequest(
 	request := &mpi.UpdateFileRequest{
 		File: fileToUpdate,
 		Contents: &mpi.FileContents{
-			Contents: contents,
+			Contents:    contents,
+			Compression: compression,
 		},
 		MessageMeta: messageMeta,
 	}
@@ -487,9 +700,10 @@ func (fso *FileServiceOperator) sendUpdateFileStreamHeader(
 
 	header := mpi.FileDataChunk_Header{
 		Header: &mpi.FileDataChunkHeader{
-			FileMeta:  fileToUpdate.GetFileMeta(),
-			Chunks:    numberOfChunks,
-			ChunkSize: chunkSize,
+			FileMeta:    fileToUpdate.GetFileMeta(),
+			Chunks:      numberOfChunks,
+			ChunkSize:   chunkSize,
+			Compression: fso.fileCompression(ctx),
 		},
 	}
 
@@ -551,6 +765,7 @@ func (fso *FileServiceOperator) sendFileUpdateStreamChunks(
 
 	var chunkID uint32
 
+	compression := fso.fileCompression(ctx)
 	reader := bufio.NewReader(f)
 	for {
 		chunk, readChunkError := fso.fileOperator.ReadChunk(ctx, chunkSize, reader, chunkID)
@@ -561,6 +776,11 @@ func (fso *FileServiceOperator) sendFileUpdateStreamChunks(
 			break
 		}
 
+		chunk.Content.Data, err = files.Compress(chunk.Content.GetData(), compression)
+		if err != nil {
+			return err
+		}
+
 		sendError := fso.sendFileUpdateStreamChunk(ctx, chunk, updateFileStreamClient)
 		if sendError != nil {
 			return sendError
@@ -636,6 +856,23 @@ func (fso *FileServiceOperator) setupIdentifiers(ctx context.Context, iteration
 	return newCtx, correlationID
 }
 
+// fileCompression returns the configured compression of the file contents sent to the management plane
+func (fso *FileServiceOperator) fileCompression(ctx context.Context) mpi.Compression {
+	switch fso.agentConfig.Client.Grpc.FileCompression {
+	case "":
+		return mpi.Compression_COMPRESSION_UNSPECIFIED
+	case "gzip":
+		return mpi.Compression_COMPRESSION_GZIP
+	case "zstd":
+		return mpi.Compression_COMPRESSION_ZSTD
+	default:
+		slog.WarnContext(ctx, "Unsupported file compression, sending file contents uncompressed",
+			"file_compression", fso.agentConfig.Client.Grpc.FileCompression)
+
+		return mpi.Compression_COMPRESSION_UNSPECIFIED
+	}
+}
+
 func (fso *FileServiceOperator) checkAllowedDirectory(checkFiles []*mpi.File) error {
 	for _, file := range checkFiles {
 		allowed := fso.agentConfig.IsDirectoryAllowed(file.GetFileMeta().GetName())
@@ -646,3 +883,62 @@ func (fso *FileServiceOperator) checkAllowedDirectory(checkFiles []*mpi.File) er
 
 	return nil
 }
+
+// RemovePartialFiles removes the partial files of chunked files. It is called once a config apply finishes or
+// fails, since a partial file is only resumed while the config apply is retrying the download.
+func (fso *FileServiceOperator) RemovePartialFiles() {
+	if err := os.RemoveAll(fso.partialFilesDir()); err != nil {
+		slog.Warn("Unable to remove partial files", "directory", fso.partialFilesDir(), "error", err)
+	}
+}
+
+// partialFilesDir returns the directory the partial files are written to. Partial files are not written next to
+// the file, so that NGINX never picks them up, for example through an include with a wildcard.
+func (fso *FileServiceOperator) partialFilesDir() string {
+	baseDir := os.TempDir()
+	if fso.agentConfig.LibDir != "" {
+		baseDir = fso.agentConfig.LibDir
+	}
+
+	return filepath.Join(baseDir, partialFilesDirName)
+}
+
+// partialFilePath returns the path of the file that the content of a chunked file is received into. The path
+// contains the file hash, so that an interrupted download is only resumed for the same file contents.
+func (fso *FileServiceOperator) partialFilePath(fileName, hash string) string {
+	hashKey := strings.NewReplacer("/", "_", "+", "-", "=", "").Replace(hash)
+
+	return filepath.Join(fso.partialFilesDir(), fileName+"."+hashKey+".partial")
+}
+
+func partialFileSize(partialFilePath string) uint64 {
+	fileInfo, err := os.Stat(partialFilePath)
+	if err != nil {
+		return 0
+	}
+
+	return uint64(fileInfo.Size()) //nolint:gosec // file sizes are not negative
+}
+
+// removeStalePartialFiles removes the partial files of interrupted downloads of other versions of a file
+func removeStalePartialFiles(ctx context.Context, fileName, partialFilePath string) {
+	pattern := filepath.Join(filepath.Dir(partialFilePath), filepath.Base(fileName)+".*.partial")
+
+	partialFiles, err := filepath.Glob(pattern)
+	if err != nil {
+		return
+	}
+
+	for _, stalePartialFile := range partialFiles {
+		if stalePartialFile == partialFilePath {
+			continue
+		}
+
+		slog.DebugContext(ctx, "Removing stale partial file", "file", stalePartialFile)
+
+		if removeErr := os.Remove(stalePartialFile); removeErr != nil {
+			slog.WarnContext(ctx, "Failed to remove stale partial file", "file", stalePartialFile,
+				"error", removeErr)
+		}
+	}
+}
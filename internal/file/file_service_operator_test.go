@@ -6,9 +6,13 @@
 package file
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -16,12 +20,14 @@ import (
 
 	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
 	"github.com/nginx/agent/v3/api/grpc/mpi/v1/v1fakes"
+	"github.com/nginx/agent/v3/internal/config"
 	"github.com/nginx/agent/v3/pkg/files"
 	"github.com/nginx/agent/v3/test/helpers"
 	"github.com/nginx/agent/v3/test/protos"
 	"github.com/nginx/agent/v3/test/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestFileServiceOperator_UpdateOverview(t *testing.T) {
@@ -195,3 +201,459 @@ func TestFileManagerService_UpdateFile_LargeFile(t *testing.T) {
 
 	helpers.RemoveFileWithErrorCheck(t, testFile.Name())
 }
+
+func TestFileManagerService_UpdateFile_Compression(t *testing.T) {
+	ctx := context.Background()
+	content := []byte(strings.Repeat("server { listen 80; }\n", 100))
+
+	testFile := filepath.Join(t.TempDir(), "nginx.conf")
+	require.NoError(t, os.WriteFile(testFile, content, 0o600))
+	fileMeta := protos.FileMeta(testFile, files.GenerateHash(content))
+	fileMeta.Size = int64(len(content))
+
+	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+
+	agentConfig := types.AgentConfig()
+	agentConfig.Client.Grpc.MaxFileSize = config.DefMaxFileSize
+	agentConfig.Client.Grpc.FileCompression = "gzip"
+
+	fileServiceOperator := NewFileServiceOperator(agentConfig, fakeFileServiceClient, &sync.RWMutex{})
+	fileServiceOperator.SetIsConnected(true)
+
+	err := fileServiceOperator.UpdateFile(ctx, "123", &mpi.File{FileMeta: fileMeta})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, fakeFileServiceClient.UpdateFileCallCount())
+	_, request, _ := fakeFileServiceClient.UpdateFileArgsForCall(0)
+	assert.Equal(t, mpi.Compression_COMPRESSION_GZIP, request.GetContents().GetCompression())
+	assert.Less(t, len(request.GetContents().GetContents()), len(content))
+
+	decompressed, err := files.Decompress(request.GetContents().GetContents(), mpi.Compression_COMPRESSION_GZIP,
+		int64(len(content)))
+	require.NoError(t, err)
+	assert.Equal(t, content, decompressed)
+}
+
+func TestFileServiceOperator_UpdateFileContents(t *testing.T) {
+	ctx := context.Background()
+	content := []byte(`{"listeners":{"*:8080":{"pass":"routes"}},"routes":[]}`)
+
+	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+
+	agentConfig := types.AgentConfig()
+	agentConfig.Client.Grpc.MaxFileSize = config.DefMaxFileSize
+
+	fileServiceOperator := NewFileServiceOperator(agentConfig, fakeFileServiceClient, &sync.RWMutex{})
+	fileServiceOperator.SetIsConnected(true)
+
+	file := &mpi.File{FileMeta: &mpi.FileMeta{Name: "/config", Hash: files.GenerateHash(content)}}
+
+	err := fileServiceOperator.UpdateFileContents(ctx, "123", file, content)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, fakeFileServiceClient.UpdateFileCallCount())
+	_, request, _ := fakeFileServiceClient.UpdateFileArgsForCall(0)
+	assert.True(t, proto.Equal(file, request.GetFile()))
+	assert.Equal(t, content, request.GetContents().GetContents())
+
+	agentConfig.Client.Grpc.MaxFileSize = 1
+	err = fileServiceOperator.UpdateFileContents(ctx, "123", file, content)
+	require.ErrorContains(t, err, "is larger than the max file size")
+	assert.Equal(t, 1, fakeFileServiceClient.UpdateFileCallCount())
+}
+
+func TestFileServiceOperator_FileContents_Compression(t *testing.T) {
+	ctx := context.Background()
+	content := []byte(strings.Repeat("server { listen 80; }\n", 100))
+
+	tests := []struct {
+		name             string
+		contents         []byte
+		expectedErrorMsg string
+	}{
+		{
+			name:     "Test 1: Compressed contents",
+			contents: content,
+		},
+		{
+			name:             "Test 2: Decompressed contents larger than max file size",
+			contents:         make([]byte, config.DefMaxFileSize+1),
+			expectedErrorMsg: "decompressed data exceeds max size of 1048576 bytes",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			compressed, err := files.Compress(test.contents, mpi.Compression_COMPRESSION_ZSTD)
+			require.NoError(tt, err)
+
+			fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+			fakeFileServiceClient.GetFileReturns(&mpi.GetFileResponse{
+				Contents: &mpi.FileContents{
+					Contents:    compressed,
+					Compression: mpi.Compression_COMPRESSION_ZSTD,
+				},
+			}, nil)
+
+			agentConfig := types.AgentConfig()
+			agentConfig.Client.Grpc.MaxFileSize = config.DefMaxFileSize
+
+			fileServiceOperator := NewFileServiceOperator(agentConfig, fakeFileServiceClient, &sync.RWMutex{})
+
+			contents, err := fileServiceOperator.FileContents(ctx, &mpi.File{
+				FileMeta: protos.FileMeta("/etc/nginx/nginx.conf", ""),
+			})
+
+			_, request, _ := fakeFileServiceClient.GetFileArgsForCall(0)
+			assert.Equal(tt, files.SupportedCompressions, request.GetAcceptedCompressions())
+
+			if test.expectedErrorMsg != "" {
+				require.ErrorContains(tt, err, test.expectedErrorMsg)
+				return
+			}
+
+			require.NoError(tt, err)
+			assert.Equal(tt, content, contents)
+		})
+	}
+}
+
+func TestFileServiceOperator_ChunkedFile_Compression(t *testing.T) {
+	ctx := context.Background()
+	content := []byte(strings.Repeat("a", 1000) + strings.Repeat("b", 1000) + strings.Repeat("c", 500))
+
+	tests := []struct {
+		name             string
+		chunkSize        uint32
+		expectedErrorMsg string
+	}{
+		{
+			name:      "Test 1: Compressed chunks",
+			chunkSize: 1000,
+		},
+		{
+			name:             "Test 2: Chunk size larger than max file size",
+			chunkSize:        config.DefMaxFileSize + 1,
+			expectedErrorMsg: "chunk size 1048577 exceeds max file size 1048576",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			tempDir := tt.TempDir()
+			fileName := filepath.Join(tempDir, "waf_bundle.tgz")
+			tempFilePath := filepath.Join(tempDir, "waf_bundle.tgz.tmp")
+
+			fileMeta := protos.FileMeta(fileName, files.GenerateHash(content))
+			fileMeta.Size = int64(len(content))
+
+			stream := &v1fakes.FakeFileService_GetFileStreamClient{}
+			stream.RecvReturnsOnCall(0, &mpi.FileDataChunk{
+				Chunk: &mpi.FileDataChunk_Header{Header: &mpi.FileDataChunkHeader{
+					FileMeta:    fileMeta,
+					Chunks:      3,
+					ChunkSize:   test.chunkSize,
+					Compression: mpi.Compression_COMPRESSION_GZIP,
+				}},
+			}, nil)
+			for i := range 3 {
+				compressed, err := files.Compress(content[i*1000:min((i+1)*1000, len(content))],
+					mpi.Compression_COMPRESSION_GZIP)
+				require.NoError(tt, err)
+				stream.RecvReturnsOnCall(i+1, chunkedFileContent(uint32(i), compressed), nil)
+			}
+
+			fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+			fakeFileServiceClient.GetFileStreamReturns(stream, nil)
+
+			agentConfig := types.AgentConfig()
+			agentConfig.Client.Grpc.MaxFileSize = config.DefMaxFileSize
+
+			fileServiceOperator := NewFileServiceOperator(agentConfig, fakeFileServiceClient, &sync.RWMutex{})
+
+			err := fileServiceOperator.ChunkedFile(ctx, &mpi.File{FileMeta: fileMeta}, tempFilePath,
+				fileMeta.GetHash(), fileMeta.GetName())
+
+			_, request, _ := fakeFileServiceClient.GetFileStreamArgsForCall(0)
+			assert.Equal(tt, files.SupportedCompressions, request.GetAcceptedCompressions())
+
+			if test.expectedErrorMsg != "" {
+				require.ErrorContains(tt, err, test.expectedErrorMsg)
+				assert.Equal(tt, 1, fakeFileServiceClient.GetFileStreamCallCount())
+
+				return
+			}
+
+			require.NoError(tt, err)
+
+			writtenContent, readErr := os.ReadFile(tempFilePath)
+			require.NoError(tt, readErr)
+			assert.Equal(tt, content, writtenContent)
+		})
+	}
+}
+
+func TestFileServiceOperator_ChunkedFile_Delta(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	basis := []byte(strings.Repeat("geo $country { default ZZ; }\n", 2000))
+	content := slices.Concat(basis[:30000], []byte("# updated\n"), basis[30000:])
+
+	fileName := filepath.Join(tempDir, "geo.conf")
+	require.NoError(t, os.WriteFile(fileName, basis, 0o600))
+	tempFilePath := filepath.Join(tempDir, "geo.conf.tmp")
+
+	fileMeta := protos.FileMeta(fileName, files.GenerateHash(content))
+	fileMeta.Size = int64(len(content))
+
+	signature, err := files.Signature(bytes.NewReader(basis), files.SignatureBlockSize(int64(len(basis))))
+	require.NoError(t, err)
+	instructions, err := files.Delta(signature, content, 1024)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                string
+		deltaInstructions   []*mpi.FileDataChunkDelta
+		expectedStreamCalls int
+	}{
+		{
+			name:                "Test 1: Delta file transfer",
+			deltaInstructions:   instructions,
+			expectedStreamCalls: 1,
+		},
+		{
+			name: "Test 2: Invalid delta falls back to full file transfer",
+			deltaInstructions: []*mpi.FileDataChunkDelta{
+				{Instruction: &mpi.FileDataChunkDelta_Copy{Copy: &mpi.FileBlockCopy{BlockIndex: 1000, BlockCount: 1}}},
+			},
+			expectedStreamCalls: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			deltaStream := &v1fakes.FakeFileService_GetFileStreamClient{}
+			deltaStream.RecvReturnsOnCall(0, &mpi.FileDataChunk{
+				Chunk: &mpi.FileDataChunk_Header{Header: &mpi.FileDataChunkHeader{
+					FileMeta:  fileMeta,
+					Chunks:    uint32(len(test.deltaInstructions)),
+					ChunkSize: 1024,
+					Delta:     true,
+				}},
+			}, nil)
+			for i, instruction := range test.deltaInstructions {
+				deltaStream.RecvReturnsOnCall(i+1, &mpi.FileDataChunk{
+					Chunk: &mpi.FileDataChunk_Delta{Delta: instruction},
+				}, nil)
+			}
+
+			fullStream := &v1fakes.FakeFileService_GetFileStreamClient{}
+			fullStream.RecvReturnsOnCall(0, &mpi.FileDataChunk{
+				Chunk: &mpi.FileDataChunk_Header{Header: &mpi.FileDataChunkHeader{
+					FileMeta:  fileMeta,
+					Chunks:    1,
+					ChunkSize: uint32(len(content)),
+				}},
+			}, nil)
+			fullStream.RecvReturnsOnCall(1, &mpi.FileDataChunk{
+				Chunk: &mpi.FileDataChunk_Content{Content: &mpi.FileDataChunkContent{Data: content}},
+			}, nil)
+
+			fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+			fakeFileServiceClient.GetFileStreamReturnsOnCall(0, deltaStream, nil)
+			fakeFileServiceClient.GetFileStreamReturnsOnCall(1, fullStream, nil)
+
+			agentConfig := types.AgentConfig()
+			agentConfig.Client.Grpc.MaxFileSize = config.DefMaxFileSize
+
+			fileServiceOperator := NewFileServiceOperator(agentConfig, fakeFileServiceClient, &sync.RWMutex{})
+
+			chunkedFileErr := fileServiceOperator.ChunkedFile(ctx, &mpi.File{FileMeta: fileMeta}, tempFilePath,
+				fileMeta.GetHash(), fileMeta.GetName())
+			require.NoError(tt, chunkedFileErr)
+
+			require.Equal(tt, test.expectedStreamCalls, fakeFileServiceClient.GetFileStreamCallCount())
+			_, request, _ := fakeFileServiceClient.GetFileStreamArgsForCall(0)
+			assert.True(tt, proto.Equal(signature, request.GetSignature()))
+
+			if test.expectedStreamCalls > 1 {
+				_, request, _ = fakeFileServiceClient.GetFileStreamArgsForCall(1)
+				assert.Nil(tt, request.GetSignature())
+			}
+
+			writtenContent, readErr := os.ReadFile(tempFilePath)
+			require.NoError(tt, readErr)
+			assert.Equal(tt, content, writtenContent)
+		})
+	}
+}
+
+func TestFileServiceOperator_ChunkedFile_DeltaRootPath(t *testing.T) {
+	ctx := context.Background()
+	rootPath := t.TempDir()
+
+	basis := []byte(strings.Repeat("geo $country { default ZZ; }\n", 2000))
+	content := slices.Concat(basis[:30000], []byte("# updated\n"), basis[30000:])
+
+	// the copy of the file of an instance in another container is in the root directory of the instance
+	fileName := "/etc/nginx/geo.conf"
+	basisFilePath := filepath.Join(rootPath, fileName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(basisFilePath), 0o755))
+	require.NoError(t, os.WriteFile(basisFilePath, basis, 0o600))
+	tempFilePath := basisFilePath + ".tmp"
+
+	fileMeta := protos.FileMeta(fileName, files.GenerateHash(content))
+	fileMeta.Size = int64(len(content))
+
+	signature, err := files.Signature(bytes.NewReader(basis), files.SignatureBlockSize(int64(len(basis))))
+	require.NoError(t, err)
+	instructions, err := files.Delta(signature, content, 1024)
+	require.NoError(t, err)
+
+	deltaStream := &v1fakes.FakeFileService_GetFileStreamClient{}
+	deltaStream.RecvReturnsOnCall(0, &mpi.FileDataChunk{
+		Chunk: &mpi.FileDataChunk_Header{Header: &mpi.FileDataChunkHeader{
+			FileMeta:  fileMeta,
+			Chunks:    uint32(len(instructions)),
+			ChunkSize: 1024,
+			Delta:     true,
+		}},
+	}, nil)
+	for i, instruction := range instructions {
+		deltaStream.RecvReturnsOnCall(i+1, &mpi.FileDataChunk{
+			Chunk: &mpi.FileDataChunk_Delta{Delta: instruction},
+		}, nil)
+	}
+
+	fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+	fakeFileServiceClient.GetFileStreamReturns(deltaStream, nil)
+
+	agentConfig := types.AgentConfig()
+	agentConfig.Client.Grpc.MaxFileSize = config.DefMaxFileSize
+
+	fileServiceOperator := NewFileServiceOperator(agentConfig, fakeFileServiceClient, &sync.RWMutex{})
+
+	err = fileServiceOperator.ChunkedFile(ctx, &mpi.File{FileMeta: fileMeta}, tempFilePath, fileMeta.GetHash(),
+		basisFilePath)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, fakeFileServiceClient.GetFileStreamCallCount())
+	_, request, _ := fakeFileServiceClient.GetFileStreamArgsForCall(0)
+	assert.Equal(t, fileName, request.GetFileMeta().GetName())
+	assert.True(t, proto.Equal(signature, request.GetSignature()))
+
+	writtenContent, err := os.ReadFile(tempFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, content, writtenContent)
+}
+
+func TestFileServiceOperator_ChunkedFile_Resume(t *testing.T) {
+	ctx := context.Background()
+	content := []byte(strings.Repeat("a", 1000) + strings.Repeat("b", 1000) + strings.Repeat("c", 500))
+
+	tests := []struct {
+		name         string
+		resumeOffset uint64
+	}{
+		{
+			name:         "Test 1: Stream is resumed at the offset",
+			resumeOffset: 1000,
+		},
+		{
+			name:         "Test 2: Management plane does not support resuming streams",
+			resumeOffset: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			tempDir := tt.TempDir()
+			fileName := filepath.Join(tempDir, "waf_bundle.tgz")
+			tempFilePath := filepath.Join(tempDir, "waf_bundle.tgz.tmp")
+
+			fileMeta := protos.FileMeta(fileName, files.GenerateHash(content))
+			fileMeta.Size = int64(len(content))
+
+			agentConfig := types.AgentConfig()
+			agentConfig.LibDir = tt.TempDir()
+			partialFilesOperator := NewFileServiceOperator(agentConfig, nil, &sync.RWMutex{})
+
+			stalePartialFile := partialFilesOperator.partialFilePath(fileName, "previous-hash")
+			require.NoError(tt, os.MkdirAll(filepath.Dir(stalePartialFile), 0o755))
+			require.NoError(tt, os.WriteFile(stalePartialFile, []byte("stale"), 0o600))
+
+			// the connection drops after the first chunk
+			interruptedStream := &v1fakes.FakeFileService_GetFileStreamClient{}
+			interruptedStream.RecvReturnsOnCall(0, chunkedFileHeader(fileMeta, 0, 3), nil)
+			interruptedStream.RecvReturnsOnCall(1, chunkedFileContent(0, content[:1000]), nil)
+			interruptedStream.RecvReturnsOnCall(2, nil, errors.New("connection reset"))
+
+			resumedStream := &v1fakes.FakeFileService_GetFileStreamClient{}
+			resumedStream.RecvReturnsOnCall(0, chunkedFileHeader(fileMeta, test.resumeOffset,
+				uint32(3-test.resumeOffset/1000)), nil)
+			for i, offset := 0, int(test.resumeOffset); offset < len(content); i, offset = i+1, offset+1000 {
+				resumedStream.RecvReturnsOnCall(i+1,
+					chunkedFileContent(uint32(i), content[offset:min(offset+1000, len(content))]), nil)
+			}
+
+			fakeFileServiceClient := &v1fakes.FakeFileServiceClient{}
+			fakeFileServiceClient.GetFileStreamReturnsOnCall(0, interruptedStream, nil)
+			fakeFileServiceClient.GetFileStreamReturnsOnCall(1, resumedStream, nil)
+
+			fileServiceOperator := NewFileServiceOperator(agentConfig, fakeFileServiceClient, &sync.RWMutex{})
+
+			err := fileServiceOperator.ChunkedFile(ctx, &mpi.File{FileMeta: fileMeta}, tempFilePath,
+				fileMeta.GetHash(), fileMeta.GetName())
+			require.NoError(tt, err)
+
+			require.Equal(tt, 2, fakeFileServiceClient.GetFileStreamCallCount())
+			_, request, _ := fakeFileServiceClient.GetFileStreamArgsForCall(0)
+			assert.Equal(tt, uint64(0), request.GetOffset())
+			_, request, _ = fakeFileServiceClient.GetFileStreamArgsForCall(1)
+			assert.Equal(tt, uint64(1000), request.GetOffset())
+
+			writtenContent, readErr := os.ReadFile(tempFilePath)
+			require.NoError(tt, readErr)
+			assert.Equal(tt, content, writtenContent)
+
+			assert.NoFileExists(tt, fileServiceOperator.partialFilePath(fileName, fileMeta.GetHash()))
+			assert.NoFileExists(tt, stalePartialFile)
+
+			// no partial file is written next to the file, where NGINX could pick it up
+			dirEntries, readDirErr := os.ReadDir(tempDir)
+			require.NoError(tt, readDirErr)
+			require.Len(tt, dirEntries, 1)
+			assert.Equal(tt, filepath.Base(tempFilePath), dirEntries[0].Name())
+
+			fileServiceOperator.RemovePartialFiles()
+			assert.NoDirExists(tt, filepath.Join(agentConfig.LibDir, partialFilesDirName))
+		})
+	}
+}
+
+func chunkedFileHeader(fileMeta *mpi.FileMeta, offset uint64, chunks uint32) *mpi.FileDataChunk {
+	return &mpi.FileDataChunk{
+		Chunk: &mpi.FileDataChunk_Header{Header: &mpi.FileDataChunkHeader{
+			FileMeta:  fileMeta,
+			Chunks:    chunks,
+			ChunkSize: 1000,
+			Offset:    offset,
+		}},
+	}
+}
+
+func chunkedFileContent(chunkID uint32, data []byte) *mpi.FileDataChunk {
+	return &mpi.FileDataChunk{
+		Chunk: &mpi.FileDataChunk_Content{Content: &mpi.FileDataChunkContent{ChunkId: chunkID, Data: data}},
+	}
+}
+
+func TestFileServiceOperator_partialFilePath(t *testing.T) {
+	agentConfig := types.AgentConfig()
+	agentConfig.LibDir = "/var/lib/nginx-agent"
+	fileServiceOperator := NewFileServiceOperator(agentConfig, nil, &sync.RWMutex{})
+
+	assert.Equal(t, "/var/lib/nginx-agent/partial_files/etc/nginx/nginx.conf.ab_c-d.partial",
+		fileServiceOperator.partialFilePath("/etc/nginx/nginx.conf", "ab/c+d=="))
+}
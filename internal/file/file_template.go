@@ -0,0 +1,256 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/config"
+	"github.com/nginx/agent/v3/internal/model"
+	"github.com/nginx/agent/v3/pkg/files"
+)
+
+// maxRenderedTemplateSize is the largest file a template is allowed to render to
+const maxRenderedTemplateSize = 10 * 1024 * 1024
+
+var errRenderedTemplateTooLarge = errors.New("rendered template exceeds maximum size")
+
+type (
+	// TemplateData contains the variables that are available to templated config files
+	TemplateData struct {
+		Labels map[string]any    `json:"labels"`
+		Env    map[string]string `json:"env"`
+		Host   TemplateHost      `json:"host"`
+		Nginx  TemplateNginx     `json:"nginx"`
+	}
+
+	TemplateHost struct {
+		ID          string `json:"id"`
+		Hostname    string `json:"hostname"`
+		OSName      string `json:"os_name"`
+		OSVersion   string `json:"os_version"`
+		IsContainer bool   `json:"is_container"`
+	}
+
+	TemplateNginx struct {
+		Version    string   `json:"version"`
+		BinaryPath string   `json:"binary_path"`
+		ConfigPath string   `json:"config_path"`
+		Modules    []string `json:"modules"`
+		IsPlus     bool     `json:"is_plus"`
+	}
+
+	// limitedWriter fails writes once more than limit bytes have been written, so a template can't render
+	// to an unbounded amount of data
+	limitedWriter struct {
+		buf   bytes.Buffer
+		limit int
+	}
+)
+
+// NewTemplateData returns the variables for rendering templated config files of an NGINX instance. Only the
+// environment variables in the template env allowlist are available to templates.
+func NewTemplateData(agentConfig *config.Config, resource *mpi.Resource, instance *mpi.Instance) *TemplateData {
+	data := &TemplateData{
+		Labels: make(map[string]any),
+		Env:    make(map[string]string),
+	}
+
+	if agentConfig != nil {
+		for key, value := range agentConfig.Labels {
+			data.Labels[key] = value
+		}
+
+		if agentConfig.DataPlaneConfig != nil && agentConfig.DataPlaneConfig.Nginx != nil {
+			for _, name := range agentConfig.DataPlaneConfig.Nginx.TemplateEnvAllowlist {
+				if value, ok := os.LookupEnv(name); ok {
+					data.Env[name] = value
+				}
+			}
+		}
+	}
+
+	switch {
+	case resource.GetHostInfo() != nil:
+		data.Host = TemplateHost{
+			ID:        resource.GetHostInfo().GetHostId(),
+			Hostname:  resource.GetHostInfo().GetHostname(),
+			OSName:    resource.GetHostInfo().GetReleaseInfo().GetName(),
+			OSVersion: resource.GetHostInfo().GetReleaseInfo().GetVersionId(),
+		}
+	case resource.GetContainerInfo() != nil:
+		data.Host = TemplateHost{
+			ID:          resource.GetContainerInfo().GetContainerId(),
+			Hostname:    resource.GetContainerInfo().GetHostname(),
+			OSName:      resource.GetContainerInfo().GetReleaseInfo().GetName(),
+			OSVersion:   resource.GetContainerInfo().GetReleaseInfo().GetVersionId(),
+			IsContainer: true,
+		}
+	}
+
+	runtime := instance.GetInstanceRuntime()
+	data.Nginx = TemplateNginx{
+		Version:    instance.GetInstanceMeta().GetVersion(),
+		BinaryPath: runtime.GetBinaryPath(),
+		ConfigPath: runtime.GetConfigPath(),
+		IsPlus:     instance.GetInstanceMeta().GetInstanceType() == mpi.InstanceMeta_INSTANCE_TYPE_NGINX_PLUS,
+	}
+
+	if runtime.GetNginxPlusRuntimeInfo() != nil {
+		data.Nginx.Modules = runtime.GetNginxPlusRuntimeInfo().GetLoadableModules()
+	} else {
+		data.Nginx.Modules = runtime.GetNginxRuntimeInfo().GetLoadableModules()
+	}
+
+	return data
+}
+
+// templateAction determines the file action for a templated file. A template is unchanged if the template source
+// and variables are the same as the ones it was last rendered with, and the rendered file on disk is unchanged.
+// The rendered hashes of an unchanged template are copied to the file, so they are kept in the manifest.
+func templateAction(file, currentFile *mpi.File, variablesHash string) (model.FileAction, error) {
+	fileName := file.GetFileMeta().GetName()
+
+	fileInfo, err := os.Stat(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return model.Add, nil
+	}
+
+	if err != nil {
+		return model.Unchanged, fmt.Errorf("unable to stat file %s: %w", fileName, err)
+	}
+
+	if fileInfo.IsDir() {
+		return model.Unchanged, fmt.Errorf(
+			"unable to create file %s since a directory with the same name already exists",
+			fileName,
+		)
+	}
+
+	if currentFile.GetTemplate() == nil ||
+		currentFile.GetFileMeta().GetHash() != file.GetFileMeta().GetHash() ||
+		currentFile.GetTemplate().GetVariablesHash() != variablesHash {
+		return model.Update, nil
+	}
+
+	metadataOfFileOnDisk, err := files.FileMeta(fileName)
+	if err != nil {
+		return model.Unchanged, fmt.Errorf("unable to get file metadata for %s: %w", fileName, err)
+	}
+
+	if metadataOfFileOnDisk.GetHash() != currentFile.GetTemplate().GetRenderedHash() {
+		return model.Update, nil
+	}
+
+	file.GetTemplate().RenderedHash = currentFile.GetTemplate().GetRenderedHash()
+	file.GetTemplate().RenderedSize = metadataOfFileOnDisk.GetSize()
+	file.GetTemplate().VariablesHash = variablesHash
+
+	return model.Unchanged, nil
+}
+
+// expectedFileHash returns the hash of a file once it is written to disk, which for a template is the hash
+// of the rendered file instead of the template source
+func expectedFileHash(file *mpi.File) string {
+	if file.GetTemplate() != nil {
+		return file.GetTemplate().GetRenderedHash()
+	}
+
+	return file.GetFileMeta().GetHash()
+}
+
+// keepTemplate keeps the template source hash of a rendered file in the manifest, when the manifest is updated
+// with the files referenced in the NGINX config, which are read from disk and so only have the rendered hash
+func keepTemplate(manifestFile, currentManifestFile *model.ManifestFile) *model.ManifestFile {
+	if currentManifestFile == nil || currentManifestFile.ManifestFileMeta.RenderedHash == "" ||
+		manifestFile.ManifestFileMeta.RenderedHash != "" ||
+		manifestFile.ManifestFileMeta.Hash != currentManifestFile.ManifestFileMeta.RenderedHash {
+		return manifestFile
+	}
+
+	manifestFile.ManifestFileMeta.RenderedSize = manifestFile.ManifestFileMeta.Size
+	manifestFile.ManifestFileMeta.Hash = currentManifestFile.ManifestFileMeta.Hash
+	manifestFile.ManifestFileMeta.Size = currentManifestFile.ManifestFileMeta.Size
+	manifestFile.ManifestFileMeta.RenderedHash = currentManifestFile.ManifestFileMeta.RenderedHash
+	manifestFile.ManifestFileMeta.TemplateVariablesHash = currentManifestFile.ManifestFileMeta.TemplateVariablesHash
+
+	return manifestFile
+}
+
+// templateVariablesHash returns a hash of the template variables, so that templates are rendered again when the
+// variables change even if the template source is unchanged
+func templateVariablesHash(data *TemplateData) string {
+	content, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+
+	return files.GenerateHash(content)
+}
+
+// renderTemplate renders a template using the Go text/template syntax. Templates only have access to the template
+// variables and a small set of string functions, and fail to render if they reference a variable that doesn't exist.
+func renderTemplate(name string, source []byte, data *TemplateData, maxSize int) ([]byte, error) {
+	tmpl, err := template.New(name).
+		Option("missingkey=error").
+		Funcs(templateFuncs()).
+		Parse(string(source))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse template %s: %w", name, err)
+	}
+
+	if data == nil {
+		data = &TemplateData{}
+	}
+
+	writer := &limitedWriter{limit: maxSize}
+	if err = tmpl.Execute(writer, data); err != nil {
+		return nil, fmt.Errorf("unable to render template %s: %w", name, err)
+	}
+
+	return writer.buf.Bytes(), nil
+}
+
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"default": func(defaultValue, value any) any {
+			if value == nil || value == "" {
+				return defaultValue
+			}
+
+			return value
+		},
+		"join":       func(sep string, elems []string) string { return strings.Join(elems, sep) },
+		"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+		"lower":      strings.ToLower,
+		"upper":      strings.ToUpper,
+		"trim":       strings.TrimSpace,
+		"replace":    func(old, replacement, s string) string { return strings.ReplaceAll(s, old, replacement) },
+		"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+		"quote":      func(s string) string { return fmt.Sprintf("%q", s) },
+		"hasModule":  func(module string, modules []string) bool { return slices.Contains(modules, module) },
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	}
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > w.limit {
+		return 0, errRenderedTemplateTooLarge
+	}
+
+	return w.buf.Write(p)
+}
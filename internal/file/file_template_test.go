@@ -0,0 +1,222 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/model"
+	"github.com/nginx/agent/v3/pkg/files"
+	"github.com/nginx/agent/v3/test/protos"
+	"github.com/nginx/agent/v3/test/types"
+)
+
+func templateFile(name, source string) *mpi.File {
+	return &mpi.File{
+		FileMeta: &mpi.FileMeta{
+			Name:        name,
+			Hash:        files.GenerateHash([]byte(source)),
+			Size:        int64(len(source)),
+			Permissions: "0644",
+		},
+		Template: &mpi.FileTemplate{},
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	data := &TemplateData{
+		Labels: map[string]any{"region": "eu-west-1"},
+		Env:    map[string]string{"DATACENTER": "dc1"},
+		Host:   TemplateHost{Hostname: "web-1"},
+		Nginx:  TemplateNginx{Version: "1.27.4", Modules: []string{"ngx_http_geoip_module"}},
+	}
+
+	tests := []struct {
+		name          string
+		source        string
+		expected      string
+		expectedError string
+		maxSize       int
+	}{
+		{
+			name:     "Test 1: variables",
+			source:   "server_name {{ .Host.Hostname }}; # {{ .Labels.region }} {{ .Env.DATACENTER }}",
+			expected: "server_name web-1; # eu-west-1 dc1",
+			maxSize:  maxRenderedTemplateSize,
+		},
+		{
+			name: "Test 2: functions",
+			source: `{{ upper .Env.DATACENTER }} {{ default "none" .Host.ID }} ` +
+				`{{ if hasModule "ngx_http_geoip_module" .Nginx.Modules }}geoip{{ end }}`,
+			expected: "DC1 none geoip",
+			maxSize:  maxRenderedTemplateSize,
+		},
+		{
+			name:          "Test 3: missing variable",
+			source:        "{{ .Env.UNKNOWN }}",
+			expectedError: "map has no entry for key \"UNKNOWN\"",
+			maxSize:       maxRenderedTemplateSize,
+		},
+		{
+			name:          "Test 4: invalid syntax",
+			source:        "{{ .Host.Hostname ",
+			expectedError: "unable to parse template",
+			maxSize:       maxRenderedTemplateSize,
+		},
+		{
+			name:          "Test 5: rendered template too large",
+			source:        "{{ .Host.Hostname }}{{ .Host.Hostname }}",
+			expectedError: errRenderedTemplateTooLarge.Error(),
+			maxSize:       8,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			rendered, err := renderTemplate("nginx.conf", []byte(test.source), data, test.maxSize)
+			if test.expectedError != "" {
+				require.ErrorContains(tt, err, test.expectedError)
+				return
+			}
+
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expected, string(rendered))
+		})
+	}
+}
+
+func TestNewTemplateData(t *testing.T) {
+	t.Setenv("DATACENTER", "dc1")
+	t.Setenv("SECRET", "password")
+
+	agentConfig := types.AgentConfig()
+	agentConfig.Labels = map[string]any{"region": "eu-west-1"}
+	agentConfig.DataPlaneConfig.Nginx.TemplateEnvAllowlist = []string{"DATACENTER"}
+
+	resource := protos.HostResource()
+	instance := protos.NginxOssInstance([]string{})
+
+	data := NewTemplateData(agentConfig, resource, instance)
+
+	assert.Equal(t, map[string]any{"region": "eu-west-1"}, data.Labels)
+	assert.Equal(t, map[string]string{"DATACENTER": "dc1"}, data.Env)
+	assert.Equal(t, resource.GetHostInfo().GetHostname(), data.Host.Hostname)
+	assert.False(t, data.Host.IsContainer)
+	assert.Equal(t, instance.GetInstanceMeta().GetVersion(), data.Nginx.Version)
+	assert.Equal(t, instance.GetInstanceRuntime().GetConfigPath(), data.Nginx.ConfigPath)
+	assert.False(t, data.Nginx.IsPlus)
+}
+
+func TestTemplateAction(t *testing.T) {
+	tempDir := t.TempDir()
+	source := "server_name {{ .Host.Hostname }};"
+	rendered := "server_name web-1;"
+
+	renderedPath := filepath.Join(tempDir, "nginx.conf")
+	require.NoError(t, os.WriteFile(renderedPath, []byte(rendered), 0o600))
+
+	variablesHash := templateVariablesHash(&TemplateData{Host: TemplateHost{Hostname: "web-1"}})
+
+	currentFile := templateFile(renderedPath, source)
+	currentFile.Template = &mpi.FileTemplate{
+		RenderedHash:  files.GenerateHash([]byte(rendered)),
+		VariablesHash: variablesHash,
+	}
+
+	tests := []struct {
+		file           *mpi.File
+		name           string
+		variablesHash  string
+		expectedAction model.FileAction
+	}{
+		{
+			name:           "Test 1: new template",
+			file:           templateFile(filepath.Join(tempDir, "new.conf"), source),
+			variablesHash:  variablesHash,
+			expectedAction: model.Add,
+		},
+		{
+			name:           "Test 2: unchanged template",
+			file:           templateFile(renderedPath, source),
+			variablesHash:  variablesHash,
+			expectedAction: model.Unchanged,
+		},
+		{
+			name:           "Test 3: template source changed",
+			file:           templateFile(renderedPath, "server_name {{ .Host.ID }};"),
+			variablesHash:  variablesHash,
+			expectedAction: model.Update,
+		},
+		{
+			name:           "Test 4: template variables changed",
+			file:           templateFile(renderedPath, source),
+			variablesHash:  templateVariablesHash(&TemplateData{Host: TemplateHost{Hostname: "web-2"}}),
+			expectedAction: model.Update,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			action, err := templateAction(test.file, currentFile, test.variablesHash)
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expectedAction, action)
+		})
+	}
+
+	// the rendered file on disk has been modified
+	require.NoError(t, os.WriteFile(renderedPath, []byte("server_name modified;"), 0o600))
+
+	action, err := templateAction(templateFile(renderedPath, source), currentFile, variablesHash)
+	require.NoError(t, err)
+	assert.Equal(t, model.Update, action)
+}
+
+func TestKeepTemplate(t *testing.T) {
+	currentManifestFile := &model.ManifestFile{
+		ManifestFileMeta: &model.ManifestFileMeta{
+			Name:                  "/etc/nginx/nginx.conf",
+			Hash:                  "source-hash",
+			Size:                  10,
+			RenderedHash:          "rendered-hash",
+			TemplateVariablesHash: "variables-hash",
+		},
+	}
+
+	renderedFile := &model.ManifestFile{
+		ManifestFileMeta: &model.ManifestFileMeta{
+			Name:       "/etc/nginx/nginx.conf",
+			Hash:       "rendered-hash",
+			Size:       20,
+			Referenced: true,
+		},
+	}
+
+	manifestFile := keepTemplate(renderedFile, currentManifestFile)
+	assert.Equal(t, "source-hash", manifestFile.ManifestFileMeta.Hash)
+	assert.Equal(t, int64(10), manifestFile.ManifestFileMeta.Size)
+	assert.Equal(t, "rendered-hash", manifestFile.ManifestFileMeta.RenderedHash)
+	assert.Equal(t, int64(20), manifestFile.ManifestFileMeta.RenderedSize)
+	assert.Equal(t, "variables-hash", manifestFile.ManifestFileMeta.TemplateVariablesHash)
+	assert.True(t, manifestFile.ManifestFileMeta.Referenced)
+
+	// a file that was modified on disk is no longer the rendered template
+	modifiedFile := &model.ManifestFile{
+		ManifestFileMeta: &model.ManifestFileMeta{
+			Name: "/etc/nginx/nginx.conf",
+			Hash: "modified-hash",
+		},
+	}
+
+	manifestFile = keepTemplate(modifiedFile, currentManifestFile)
+	assert.Equal(t, "modified-hash", manifestFile.ManifestFileMeta.Hash)
+	assert.Empty(t, manifestFile.ManifestFileMeta.RenderedHash)
+}
@@ -11,6 +11,16 @@ import (
 )
 
 type FakeFileManagerServiceInterface struct {
+	ChangedFilesStub        func() []string
+	changedFilesMutex       sync.RWMutex
+	changedFilesArgsForCall []struct {
+	}
+	changedFilesReturns struct {
+		result1 []string
+	}
+	changedFilesReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	ClearCacheStub        func()
 	clearCacheMutex       sync.RWMutex
 	clearCacheArgsForCall []struct {
@@ -29,6 +39,21 @@ type FakeFileManagerServiceInterface struct {
 		result1 model.WriteStatus
 		result2 error
 	}
+	ConfigDiffStub        func(context.Context, *v1.ConfigDiffRequest, file.InstanceFiles) (*v1.ConfigChangeSet, error)
+	configDiffMutex       sync.RWMutex
+	configDiffArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1.ConfigDiffRequest
+		arg3 file.InstanceFiles
+	}
+	configDiffReturns struct {
+		result1 *v1.ConfigChangeSet
+		result2 error
+	}
+	configDiffReturnsOnCall map[int]struct {
+		result1 *v1.ConfigChangeSet
+		result2 error
+	}
 	ConfigUpdateStub        func(context.Context, *model.NginxConfigContext)
 	configUpdateMutex       sync.RWMutex
 	configUpdateArgsForCall []struct {
@@ -47,12 +72,42 @@ type FakeFileManagerServiceInterface struct {
 	configUploadReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DetermineFileActionsStub        func(context.Context, map[string]*v1.File, map[string]*model.FileCache) (map[string]*model.FileCache, error)
+	ConfigVersionOverviewStub        func(context.Context, string, string) (*v1.FileOverview, error)
+	configVersionOverviewMutex       sync.RWMutex
+	configVersionOverviewArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	configVersionOverviewReturns struct {
+		result1 *v1.FileOverview
+		result2 error
+	}
+	configVersionOverviewReturnsOnCall map[int]struct {
+		result1 *v1.FileOverview
+		result2 error
+	}
+	ConfigVersionsStub        func(context.Context, string) ([]*v1.ConfigHistoryVersion, error)
+	configVersionsMutex       sync.RWMutex
+	configVersionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	configVersionsReturns struct {
+		result1 []*v1.ConfigHistoryVersion
+		result2 error
+	}
+	configVersionsReturnsOnCall map[int]struct {
+		result1 []*v1.ConfigHistoryVersion
+		result2 error
+	}
+	DetermineFileActionsStub        func(context.Context, map[string]*v1.File, map[string]*model.FileCache, file.InstanceFiles) (map[string]*model.FileCache, error)
 	determineFileActionsMutex       sync.RWMutex
 	determineFileActionsArgsForCall []struct {
 		arg1 context.Context
 		arg2 map[string]*v1.File
 		arg3 map[string]*model.FileCache
+		arg4 file.InstanceFiles
 	}
 	determineFileActionsReturns struct {
 		result1 map[string]*model.FileCache
@@ -62,6 +117,20 @@ type FakeFileManagerServiceInterface struct {
 		result1 map[string]*model.FileCache
 		result2 error
 	}
+	FileContentsStub        func(context.Context, *v1.File) ([]byte, error)
+	fileContentsMutex       sync.RWMutex
+	fileContentsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1.File
+	}
+	fileContentsReturns struct {
+		result1 []byte
+		result2 error
+	}
+	fileContentsReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	IsConnectedStub        func() bool
 	isConnectedMutex       sync.RWMutex
 	isConnectedArgsForCall []struct {
@@ -72,12 +141,43 @@ type FakeFileManagerServiceInterface struct {
 	isConnectedReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	RecoverConfigApplyStub        func(context.Context) *model.ConfigApplyRecovery
+	recoverConfigApplyMutex       sync.RWMutex
+	recoverConfigApplyArgsForCall []struct {
+		arg1 context.Context
+	}
+	recoverConfigApplyReturns struct {
+		result1 *model.ConfigApplyRecovery
+	}
+	recoverConfigApplyReturnsOnCall map[int]struct {
+		result1 *model.ConfigApplyRecovery
+	}
+	RefreshExternalFilesStub        func(context.Context, string, []*v1.File) (model.WriteStatus, error)
+	refreshExternalFilesMutex       sync.RWMutex
+	refreshExternalFilesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []*v1.File
+	}
+	refreshExternalFilesReturns struct {
+		result1 model.WriteStatus
+		result2 error
+	}
+	refreshExternalFilesReturnsOnCall map[int]struct {
+		result1 model.WriteStatus
+		result2 error
+	}
 	ResetClientStub        func(context.Context, v1.FileServiceClient)
 	resetClientMutex       sync.RWMutex
 	resetClientArgsForCall []struct {
 		arg1 context.Context
 		arg2 v1.FileServiceClient
 	}
+	ResetExternalDataSourceCredentialsStub        func(context.Context)
+	resetExternalDataSourceCredentialsMutex       sync.RWMutex
+	resetExternalDataSourceCredentialsArgsForCall []struct {
+		arg1 context.Context
+	}
 	RollbackStub        func(context.Context, string) error
 	rollbackMutex       sync.RWMutex
 	rollbackArgsForCall []struct {
@@ -90,11 +190,54 @@ type FakeFileManagerServiceInterface struct {
 	rollbackReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveConfigVersionStub        func(context.Context, *v1.FileOverview) error
+	saveConfigVersionMutex       sync.RWMutex
+	saveConfigVersionArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1.FileOverview
+	}
+	saveConfigVersionReturns struct {
+		result1 error
+	}
+	saveConfigVersionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetConfigApplyPhaseStub        func(context.Context, model.ConfigApplyPhase)
+	setConfigApplyPhaseMutex       sync.RWMutex
+	setConfigApplyPhaseArgsForCall []struct {
+		arg1 context.Context
+		arg2 model.ConfigApplyPhase
+	}
 	SetIsConnectedStub        func(bool)
 	setIsConnectedMutex       sync.RWMutex
 	setIsConnectedArgsForCall []struct {
 		arg1 bool
 	}
+	SetRootPathStub        func(string)
+	setRootPathMutex       sync.RWMutex
+	setRootPathArgsForCall []struct {
+		arg1 string
+	}
+	SetTemplateDataStub        func(*file.TemplateData)
+	setTemplateDataMutex       sync.RWMutex
+	setTemplateDataArgsForCall []struct {
+		arg1 *file.TemplateData
+	}
+	StageConfigStub        func(context.Context, *v1.FileOverview, file.InstanceFiles) (string, error)
+	stageConfigMutex       sync.RWMutex
+	stageConfigArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1.FileOverview
+		arg3 file.InstanceFiles
+	}
+	stageConfigReturns struct {
+		result1 string
+		result2 error
+	}
+	stageConfigReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	UpdateCurrentFilesOnDiskStub        func(context.Context, map[string]*v1.File, bool) error
 	updateCurrentFilesOnDiskMutex       sync.RWMutex
 	updateCurrentFilesOnDiskArgsForCall []struct {
@@ -108,10 +251,77 @@ type FakeFileManagerServiceInterface struct {
 	updateCurrentFilesOnDiskReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UploadFileContentsStub        func(context.Context, string, *v1.File, []byte) error
+	uploadFileContentsMutex       sync.RWMutex
+	uploadFileContentsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 *v1.File
+		arg4 []byte
+	}
+	uploadFileContentsReturns struct {
+		result1 error
+	}
+	uploadFileContentsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeFileManagerServiceInterface) ChangedFiles() []string {
+	fake.changedFilesMutex.Lock()
+	ret, specificReturn := fake.changedFilesReturnsOnCall[len(fake.changedFilesArgsForCall)]
+	fake.changedFilesArgsForCall = append(fake.changedFilesArgsForCall, struct {
+	}{})
+	stub := fake.ChangedFilesStub
+	fakeReturns := fake.changedFilesReturns
+	fake.recordInvocation("ChangedFiles", []interface{}{})
+	fake.changedFilesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFileManagerServiceInterface) ChangedFilesCallCount() int {
+	fake.changedFilesMutex.RLock()
+	defer fake.changedFilesMutex.RUnlock()
+	return len(fake.changedFilesArgsForCall)
+}
+
+func (fake *FakeFileManagerServiceInterface) ChangedFilesCalls(stub func() []string) {
+	fake.changedFilesMutex.Lock()
+	defer fake.changedFilesMutex.Unlock()
+	fake.ChangedFilesStub = stub
+}
+
+func (fake *FakeFileManagerServiceInterface) ChangedFilesReturns(result1 []string) {
+	fake.changedFilesMutex.Lock()
+	defer fake.changedFilesMutex.Unlock()
+	fake.ChangedFilesStub = nil
+	fake.changedFilesReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeFileManagerServiceInterface) ChangedFilesReturnsOnCall(i int, result1 []string) {
+	fake.changedFilesMutex.Lock()
+	defer fake.changedFilesMutex.Unlock()
+	fake.ChangedFilesStub = nil
+	if fake.changedFilesReturnsOnCall == nil {
+		fake.changedFilesReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.changedFilesReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *FakeFileManagerServiceInterface) ClearCache() {
 	fake.clearCacheMutex.Lock()
 	fake.clearCacheArgsForCall = append(fake.clearCacheArgsForCall, struct {
@@ -201,6 +411,72 @@ func (fake *FakeFileManagerServiceInterface) ConfigApplyReturnsOnCall(i int, res
 	}{result1, result2}
 }
 
+func (fake *FakeFileManagerServiceInterface) ConfigDiff(arg1 context.Context, arg2 *v1.ConfigDiffRequest, arg3 file.InstanceFiles) (*v1.ConfigChangeSet, error) {
+	fake.configDiffMutex.Lock()
+	ret, specificReturn := fake.configDiffReturnsOnCall[len(fake.configDiffArgsForCall)]
+	fake.configDiffArgsForCall = append(fake.configDiffArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1.ConfigDiffRequest
+		arg3 file.InstanceFiles
+	}{arg1, arg2, arg3})
+	stub := fake.ConfigDiffStub
+	fakeReturns := fake.configDiffReturns
+	fake.recordInvocation("ConfigDiff", []interface{}{arg1, arg2, arg3})
+	fake.configDiffMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeFileManagerServiceInterface) ConfigDiffCallCount() int {
+	fake.configDiffMutex.RLock()
+	defer fake.configDiffMutex.RUnlock()
+	return len(fake.configDiffArgsForCall)
+}
+
+func (fake *FakeFileManagerServiceInterface) ConfigDiffCalls(stub func(context.Context, *v1.ConfigDiffRequest, file.InstanceFiles) (*v1.ConfigChangeSet, error)) {
+	fake.configDiffMutex.Lock()
+	defer fake.configDiffMutex.Unlock()
+	fake.ConfigDiffStub = stub
+}
+
+func (fake *FakeFileManagerServiceInterface) ConfigDiffArgsForCall(i int) (context.Context, *v1.ConfigDiffRequest, file.InstanceFiles) {
+	fake.configDiffMutex.RLock()
+	defer fake.configDiffMutex.RUnlock()
+	argsForCall := fake.configDiffArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeFileManagerServiceInterface) ConfigDiffReturns(result1 *v1.ConfigChangeSet, result2 error) {
+	fake.configDiffMutex.Lock()
+	defer fake.configDiffMutex.Unlock()
+	fake.ConfigDiffStub = nil
+	fake.configDiffReturns = struct {
+		result1 *v1.ConfigChangeSet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFileManagerServiceInterface) ConfigDiffReturnsOnCall(i int, result1 *v1.ConfigChangeSet, result2 error) {
+	fake.configDiffMutex.Lock()
+	defer fake.configDiffMutex.Unlock()
+	fake.ConfigDiffStub = nil
+	if fake.configDiffReturnsOnCall == nil {
+		fake.configDiffReturnsOnCall = make(map[int]struct {
+			result1 *v1.ConfigChangeSet
+			result2 error
+		})
+	}
+	fake.configDiffReturnsOnCall[i] = struct {
+		result1 *v1.ConfigChangeSet
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeFileManagerServiceInterface) ConfigUpdate(arg1 context.Context, arg2 *model.NginxConfigContext) {
 	fake.configUpdateMutex.Lock()
 	fake.configUpdateArgsForCall = append(fake.configUpdateArgsForCall, struct {
@@ -296,20 +572,152 @@ func (fake *FakeFileManagerServiceInterface) ConfigUploadReturnsOnCall(i int, re
 	}{result1}
 }
 
-func (fake *FakeFileManagerServiceInterface) DetermineFileActions(arg1 context.Context, arg2 map[string]*v1.File, arg3 map[string]*model.FileCache) (map[string]*model.FileCache, error) {
+func (fake *FakeFileManagerServiceInterface) ConfigVersionOverview(arg1 context.Context, arg2 string, arg3 string) (*v1.FileOverview, error) {
+	fake.configVersionOverviewMutex.Lock()
+	ret, specificReturn := fake.configVersionOverviewReturnsOnCall[len(fake.configVersionOverviewArgsForCall)]
+	fake.configVersionOverviewArgsForCall = append(fake.configVersionOverviewArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ConfigVersionOverviewStub
+	fakeReturns := fake.configVersionOverviewReturns
+	fake.recordInvocation("ConfigVersionOverview", []interface{}{arg1, arg2, arg3})
+	fake.configVersionOverviewMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeFileManagerServiceInterface) ConfigVersionOverviewCallCount() int {
+	fake.configVersionOverviewMutex.RLock()
+	defer fake.configVersionOverviewMutex.RUnlock()
+	return len(fake.configVersionOverviewArgsForCall)
+}
+
+func (fake *FakeFileManagerServiceInterface) ConfigVersionOverviewCalls(stub func(context.Context, string, string) (*v1.FileOverview, error)) {
+	fake.configVersionOverviewMutex.Lock()
+	defer fake.configVersionOverviewMutex.Unlock()
+	fake.ConfigVersionOverviewStub = stub
+}
+
+func (fake *FakeFileManagerServiceInterface) ConfigVersionOverviewArgsForCall(i int) (context.Context, string, string) {
+	fake.configVersionOverviewMutex.RLock()
+	defer fake.configVersionOverviewMutex.RUnlock()
+	argsForCall := fake.configVersionOverviewArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeFileManagerServiceInterface) ConfigVersionOverviewReturns(result1 *v1.FileOverview, result2 error) {
+	fake.configVersionOverviewMutex.Lock()
+	defer fake.configVersionOverviewMutex.Unlock()
+	fake.ConfigVersionOverviewStub = nil
+	fake.configVersionOverviewReturns = struct {
+		result1 *v1.FileOverview
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFileManagerServiceInterface) ConfigVersionOverviewReturnsOnCall(i int, result1 *v1.FileOverview, result2 error) {
+	fake.configVersionOverviewMutex.Lock()
+	defer fake.configVersionOverviewMutex.Unlock()
+	fake.ConfigVersionOverviewStub = nil
+	if fake.configVersionOverviewReturnsOnCall == nil {
+		fake.configVersionOverviewReturnsOnCall = make(map[int]struct {
+			result1 *v1.FileOverview
+			result2 error
+		})
+	}
+	fake.configVersionOverviewReturnsOnCall[i] = struct {
+		result1 *v1.FileOverview
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFileManagerServiceInterface) ConfigVersions(arg1 context.Context, arg2 string) ([]*v1.ConfigHistoryVersion, error) {
+	fake.configVersionsMutex.Lock()
+	ret, specificReturn := fake.configVersionsReturnsOnCall[len(fake.configVersionsArgsForCall)]
+	fake.configVersionsArgsForCall = append(fake.configVersionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ConfigVersionsStub
+	fakeReturns := fake.configVersionsReturns
+	fake.recordInvocation("ConfigVersions", []interface{}{arg1, arg2})
+	fake.configVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeFileManagerServiceInterface) ConfigVersionsCallCount() int {
+	fake.configVersionsMutex.RLock()
+	defer fake.configVersionsMutex.RUnlock()
+	return len(fake.configVersionsArgsForCall)
+}
+
+func (fake *FakeFileManagerServiceInterface) ConfigVersionsCalls(stub func(context.Context, string) ([]*v1.ConfigHistoryVersion, error)) {
+	fake.configVersionsMutex.Lock()
+	defer fake.configVersionsMutex.Unlock()
+	fake.ConfigVersionsStub = stub
+}
+
+func (fake *FakeFileManagerServiceInterface) ConfigVersionsArgsForCall(i int) (context.Context, string) {
+	fake.configVersionsMutex.RLock()
+	defer fake.configVersionsMutex.RUnlock()
+	argsForCall := fake.configVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeFileManagerServiceInterface) ConfigVersionsReturns(result1 []*v1.ConfigHistoryVersion, result2 error) {
+	fake.configVersionsMutex.Lock()
+	defer fake.configVersionsMutex.Unlock()
+	fake.ConfigVersionsStub = nil
+	fake.configVersionsReturns = struct {
+		result1 []*v1.ConfigHistoryVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFileManagerServiceInterface) ConfigVersionsReturnsOnCall(i int, result1 []*v1.ConfigHistoryVersion, result2 error) {
+	fake.configVersionsMutex.Lock()
+	defer fake.configVersionsMutex.Unlock()
+	fake.ConfigVersionsStub = nil
+	if fake.configVersionsReturnsOnCall == nil {
+		fake.configVersionsReturnsOnCall = make(map[int]struct {
+			result1 []*v1.ConfigHistoryVersion
+			result2 error
+		})
+	}
+	fake.configVersionsReturnsOnCall[i] = struct {
+		result1 []*v1.ConfigHistoryVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFileManagerServiceInterface) DetermineFileActions(arg1 context.Context, arg2 map[string]*v1.File, arg3 map[string]*model.FileCache, arg4 file.InstanceFiles) (map[string]*model.FileCache, error) {
 	fake.determineFileActionsMutex.Lock()
 	ret, specificReturn := fake.determineFileActionsReturnsOnCall[len(fake.determineFileActionsArgsForCall)]
 	fake.determineFileActionsArgsForCall = append(fake.determineFileActionsArgsForCall, struct {
 		arg1 context.Context
 		arg2 map[string]*v1.File
 		arg3 map[string]*model.FileCache
-	}{arg1, arg2, arg3})
+		arg4 file.InstanceFiles
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.DetermineFileActionsStub
 	fakeReturns := fake.determineFileActionsReturns
-	fake.recordInvocation("DetermineFileActions", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("DetermineFileActions", []interface{}{arg1, arg2, arg3, arg4})
 	fake.determineFileActionsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -323,17 +731,17 @@ func (fake *FakeFileManagerServiceInterface) DetermineFileActionsCallCount() int
 	return len(fake.determineFileActionsArgsForCall)
 }
 
-func (fake *FakeFileManagerServiceInterface) DetermineFileActionsCalls(stub func(context.Context, map[string]*v1.File, map[string]*model.FileCache) (map[string]*model.FileCache, error)) {
+func (fake *FakeFileManagerServiceInterface) DetermineFileActionsCalls(stub func(context.Context, map[string]*v1.File, map[string]*model.FileCache, file.InstanceFiles) (map[string]*model.FileCache, error)) {
 	fake.determineFileActionsMutex.Lock()
 	defer fake.determineFileActionsMutex.Unlock()
 	fake.DetermineFileActionsStub = stub
 }
 
-func (fake *FakeFileManagerServiceInterface) DetermineFileActionsArgsForCall(i int) (context.Context, map[string]*v1.File, map[string]*model.FileCache) {
+func (fake *FakeFileManagerServiceInterface) DetermineFileActionsArgsForCall(i int) (context.Context, map[string]*v1.File, map[string]*model.FileCache, file.InstanceFiles) {
 	fake.determineFileActionsMutex.RLock()
 	defer fake.determineFileActionsMutex.RUnlock()
 	argsForCall := fake.determineFileActionsArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeFileManagerServiceInterface) DetermineFileActionsReturns(result1 map[string]*model.FileCache, result2 error) {
@@ -362,6 +770,71 @@ func (fake *FakeFileManagerServiceInterface) DetermineFileActionsReturnsOnCall(i
 	}{result1, result2}
 }
 
+func (fake *FakeFileManagerServiceInterface) FileContents(arg1 context.Context, arg2 *v1.File) ([]byte, error) {
+	fake.fileContentsMutex.Lock()
+	ret, specificReturn := fake.fileContentsReturnsOnCall[len(fake.fileContentsArgsForCall)]
+	fake.fileContentsArgsForCall = append(fake.fileContentsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1.File
+	}{arg1, arg2})
+	stub := fake.FileContentsStub
+	fakeReturns := fake.fileContentsReturns
+	fake.recordInvocation("FileContents", []interface{}{arg1, arg2})
+	fake.fileContentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeFileManagerServiceInterface) FileContentsCallCount() int {
+	fake.fileContentsMutex.RLock()
+	defer fake.fileContentsMutex.RUnlock()
+	return len(fake.fileContentsArgsForCall)
+}
+
+func (fake *FakeFileManagerServiceInterface) FileContentsCalls(stub func(context.Context, *v1.File) ([]byte, error)) {
+	fake.fileContentsMutex.Lock()
+	defer fake.fileContentsMutex.Unlock()
+	fake.FileContentsStub = stub
+}
+
+func (fake *FakeFileManagerServiceInterface) FileContentsArgsForCall(i int) (context.Context, *v1.File) {
+	fake.fileContentsMutex.RLock()
+	defer fake.fileContentsMutex.RUnlock()
+	argsForCall := fake.fileContentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeFileManagerServiceInterface) FileContentsReturns(result1 []byte, result2 error) {
+	fake.fileContentsMutex.Lock()
+	defer fake.fileContentsMutex.Unlock()
+	fake.FileContentsStub = nil
+	fake.fileContentsReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFileManagerServiceInterface) FileContentsReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.fileContentsMutex.Lock()
+	defer fake.fileContentsMutex.Unlock()
+	fake.FileContentsStub = nil
+	if fake.fileContentsReturnsOnCall == nil {
+		fake.fileContentsReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.fileContentsReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeFileManagerServiceInterface) IsConnected() bool {
 	fake.isConnectedMutex.Lock()
 	ret, specificReturn := fake.isConnectedReturnsOnCall[len(fake.isConnectedArgsForCall)]
@@ -415,6 +888,133 @@ func (fake *FakeFileManagerServiceInterface) IsConnectedReturnsOnCall(i int, res
 	}{result1}
 }
 
+func (fake *FakeFileManagerServiceInterface) RecoverConfigApply(arg1 context.Context) *model.ConfigApplyRecovery {
+	fake.recoverConfigApplyMutex.Lock()
+	ret, specificReturn := fake.recoverConfigApplyReturnsOnCall[len(fake.recoverConfigApplyArgsForCall)]
+	fake.recoverConfigApplyArgsForCall = append(fake.recoverConfigApplyArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.RecoverConfigApplyStub
+	fakeReturns := fake.recoverConfigApplyReturns
+	fake.recordInvocation("RecoverConfigApply", []interface{}{arg1})
+	fake.recoverConfigApplyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFileManagerServiceInterface) RecoverConfigApplyCallCount() int {
+	fake.recoverConfigApplyMutex.RLock()
+	defer fake.recoverConfigApplyMutex.RUnlock()
+	return len(fake.recoverConfigApplyArgsForCall)
+}
+
+func (fake *FakeFileManagerServiceInterface) RecoverConfigApplyCalls(stub func(context.Context) *model.ConfigApplyRecovery) {
+	fake.recoverConfigApplyMutex.Lock()
+	defer fake.recoverConfigApplyMutex.Unlock()
+	fake.RecoverConfigApplyStub = stub
+}
+
+func (fake *FakeFileManagerServiceInterface) RecoverConfigApplyArgsForCall(i int) context.Context {
+	fake.recoverConfigApplyMutex.RLock()
+	defer fake.recoverConfigApplyMutex.RUnlock()
+	argsForCall := fake.recoverConfigApplyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeFileManagerServiceInterface) RecoverConfigApplyReturns(result1 *model.ConfigApplyRecovery) {
+	fake.recoverConfigApplyMutex.Lock()
+	defer fake.recoverConfigApplyMutex.Unlock()
+	fake.RecoverConfigApplyStub = nil
+	fake.recoverConfigApplyReturns = struct {
+		result1 *model.ConfigApplyRecovery
+	}{result1}
+}
+
+func (fake *FakeFileManagerServiceInterface) RecoverConfigApplyReturnsOnCall(i int, result1 *model.ConfigApplyRecovery) {
+	fake.recoverConfigApplyMutex.Lock()
+	defer fake.recoverConfigApplyMutex.Unlock()
+	fake.RecoverConfigApplyStub = nil
+	if fake.recoverConfigApplyReturnsOnCall == nil {
+		fake.recoverConfigApplyReturnsOnCall = make(map[int]struct {
+			result1 *model.ConfigApplyRecovery
+		})
+	}
+	fake.recoverConfigApplyReturnsOnCall[i] = struct {
+		result1 *model.ConfigApplyRecovery
+	}{result1}
+}
+
+func (fake *FakeFileManagerServiceInterface) RefreshExternalFiles(arg1 context.Context, arg2 string, arg3 []*v1.File) (model.WriteStatus, error) {
+	fake.refreshExternalFilesMutex.Lock()
+	ret, specificReturn := fake.refreshExternalFilesReturnsOnCall[len(fake.refreshExternalFilesArgsForCall)]
+	fake.refreshExternalFilesArgsForCall = append(fake.refreshExternalFilesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []*v1.File
+	}{arg1, arg2, arg3})
+	stub := fake.RefreshExternalFilesStub
+	fakeReturns := fake.refreshExternalFilesReturns
+	fake.recordInvocation("RefreshExternalFiles", []interface{}{arg1, arg2, arg3})
+	fake.refreshExternalFilesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeFileManagerServiceInterface) RefreshExternalFilesCallCount() int {
+	fake.refreshExternalFilesMutex.RLock()
+	defer fake.refreshExternalFilesMutex.RUnlock()
+	return len(fake.refreshExternalFilesArgsForCall)
+}
+
+func (fake *FakeFileManagerServiceInterface) RefreshExternalFilesCalls(stub func(context.Context, string, []*v1.File) (model.WriteStatus, error)) {
+	fake.refreshExternalFilesMutex.Lock()
+	defer fake.refreshExternalFilesMutex.Unlock()
+	fake.RefreshExternalFilesStub = stub
+}
+
+func (fake *FakeFileManagerServiceInterface) RefreshExternalFilesArgsForCall(i int) (context.Context, string, []*v1.File) {
+	fake.refreshExternalFilesMutex.RLock()
+	defer fake.refreshExternalFilesMutex.RUnlock()
+	argsForCall := fake.refreshExternalFilesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeFileManagerServiceInterface) RefreshExternalFilesReturns(result1 model.WriteStatus, result2 error) {
+	fake.refreshExternalFilesMutex.Lock()
+	defer fake.refreshExternalFilesMutex.Unlock()
+	fake.RefreshExternalFilesStub = nil
+	fake.refreshExternalFilesReturns = struct {
+		result1 model.WriteStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFileManagerServiceInterface) RefreshExternalFilesReturnsOnCall(i int, result1 model.WriteStatus, result2 error) {
+	fake.refreshExternalFilesMutex.Lock()
+	defer fake.refreshExternalFilesMutex.Unlock()
+	fake.RefreshExternalFilesStub = nil
+	if fake.refreshExternalFilesReturnsOnCall == nil {
+		fake.refreshExternalFilesReturnsOnCall = make(map[int]struct {
+			result1 model.WriteStatus
+			result2 error
+		})
+	}
+	fake.refreshExternalFilesReturnsOnCall[i] = struct {
+		result1 model.WriteStatus
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeFileManagerServiceInterface) ResetClient(arg1 context.Context, arg2 v1.FileServiceClient) {
 	fake.resetClientMutex.Lock()
 	fake.resetClientArgsForCall = append(fake.resetClientArgsForCall, struct {
@@ -448,6 +1048,38 @@ func (fake *FakeFileManagerServiceInterface) ResetClientArgsForCall(i int) (cont
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeFileManagerServiceInterface) ResetExternalDataSourceCredentials(arg1 context.Context) {
+	fake.resetExternalDataSourceCredentialsMutex.Lock()
+	fake.resetExternalDataSourceCredentialsArgsForCall = append(fake.resetExternalDataSourceCredentialsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ResetExternalDataSourceCredentialsStub
+	fake.recordInvocation("ResetExternalDataSourceCredentials", []interface{}{arg1})
+	fake.resetExternalDataSourceCredentialsMutex.Unlock()
+	if stub != nil {
+		fake.ResetExternalDataSourceCredentialsStub(arg1)
+	}
+}
+
+func (fake *FakeFileManagerServiceInterface) ResetExternalDataSourceCredentialsCallCount() int {
+	fake.resetExternalDataSourceCredentialsMutex.RLock()
+	defer fake.resetExternalDataSourceCredentialsMutex.RUnlock()
+	return len(fake.resetExternalDataSourceCredentialsArgsForCall)
+}
+
+func (fake *FakeFileManagerServiceInterface) ResetExternalDataSourceCredentialsCalls(stub func(context.Context)) {
+	fake.resetExternalDataSourceCredentialsMutex.Lock()
+	defer fake.resetExternalDataSourceCredentialsMutex.Unlock()
+	fake.ResetExternalDataSourceCredentialsStub = stub
+}
+
+func (fake *FakeFileManagerServiceInterface) ResetExternalDataSourceCredentialsArgsForCall(i int) context.Context {
+	fake.resetExternalDataSourceCredentialsMutex.RLock()
+	defer fake.resetExternalDataSourceCredentialsMutex.RUnlock()
+	argsForCall := fake.resetExternalDataSourceCredentialsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeFileManagerServiceInterface) Rollback(arg1 context.Context, arg2 string) error {
 	fake.rollbackMutex.Lock()
 	ret, specificReturn := fake.rollbackReturnsOnCall[len(fake.rollbackArgsForCall)]
@@ -510,6 +1142,101 @@ func (fake *FakeFileManagerServiceInterface) RollbackReturnsOnCall(i int, result
 	}{result1}
 }
 
+func (fake *FakeFileManagerServiceInterface) SaveConfigVersion(arg1 context.Context, arg2 *v1.FileOverview) error {
+	fake.saveConfigVersionMutex.Lock()
+	ret, specificReturn := fake.saveConfigVersionReturnsOnCall[len(fake.saveConfigVersionArgsForCall)]
+	fake.saveConfigVersionArgsForCall = append(fake.saveConfigVersionArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1.FileOverview
+	}{arg1, arg2})
+	stub := fake.SaveConfigVersionStub
+	fakeReturns := fake.saveConfigVersionReturns
+	fake.recordInvocation("SaveConfigVersion", []interface{}{arg1, arg2})
+	fake.saveConfigVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFileManagerServiceInterface) SaveConfigVersionCallCount() int {
+	fake.saveConfigVersionMutex.RLock()
+	defer fake.saveConfigVersionMutex.RUnlock()
+	return len(fake.saveConfigVersionArgsForCall)
+}
+
+func (fake *FakeFileManagerServiceInterface) SaveConfigVersionCalls(stub func(context.Context, *v1.FileOverview) error) {
+	fake.saveConfigVersionMutex.Lock()
+	defer fake.saveConfigVersionMutex.Unlock()
+	fake.SaveConfigVersionStub = stub
+}
+
+func (fake *FakeFileManagerServiceInterface) SaveConfigVersionArgsForCall(i int) (context.Context, *v1.FileOverview) {
+	fake.saveConfigVersionMutex.RLock()
+	defer fake.saveConfigVersionMutex.RUnlock()
+	argsForCall := fake.saveConfigVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeFileManagerServiceInterface) SaveConfigVersionReturns(result1 error) {
+	fake.saveConfigVersionMutex.Lock()
+	defer fake.saveConfigVersionMutex.Unlock()
+	fake.SaveConfigVersionStub = nil
+	fake.saveConfigVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFileManagerServiceInterface) SaveConfigVersionReturnsOnCall(i int, result1 error) {
+	fake.saveConfigVersionMutex.Lock()
+	defer fake.saveConfigVersionMutex.Unlock()
+	fake.SaveConfigVersionStub = nil
+	if fake.saveConfigVersionReturnsOnCall == nil {
+		fake.saveConfigVersionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveConfigVersionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFileManagerServiceInterface) SetConfigApplyPhase(arg1 context.Context, arg2 model.ConfigApplyPhase) {
+	fake.setConfigApplyPhaseMutex.Lock()
+	fake.setConfigApplyPhaseArgsForCall = append(fake.setConfigApplyPhaseArgsForCall, struct {
+		arg1 context.Context
+		arg2 model.ConfigApplyPhase
+	}{arg1, arg2})
+	stub := fake.SetConfigApplyPhaseStub
+	fake.recordInvocation("SetConfigApplyPhase", []interface{}{arg1, arg2})
+	fake.setConfigApplyPhaseMutex.Unlock()
+	if stub != nil {
+		fake.SetConfigApplyPhaseStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeFileManagerServiceInterface) SetConfigApplyPhaseCallCount() int {
+	fake.setConfigApplyPhaseMutex.RLock()
+	defer fake.setConfigApplyPhaseMutex.RUnlock()
+	return len(fake.setConfigApplyPhaseArgsForCall)
+}
+
+func (fake *FakeFileManagerServiceInterface) SetConfigApplyPhaseCalls(stub func(context.Context, model.ConfigApplyPhase)) {
+	fake.setConfigApplyPhaseMutex.Lock()
+	defer fake.setConfigApplyPhaseMutex.Unlock()
+	fake.SetConfigApplyPhaseStub = stub
+}
+
+func (fake *FakeFileManagerServiceInterface) SetConfigApplyPhaseArgsForCall(i int) (context.Context, model.ConfigApplyPhase) {
+	fake.setConfigApplyPhaseMutex.RLock()
+	defer fake.setConfigApplyPhaseMutex.RUnlock()
+	argsForCall := fake.setConfigApplyPhaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeFileManagerServiceInterface) SetIsConnected(arg1 bool) {
 	fake.setIsConnectedMutex.Lock()
 	fake.setIsConnectedArgsForCall = append(fake.setIsConnectedArgsForCall, struct {
@@ -542,6 +1269,136 @@ func (fake *FakeFileManagerServiceInterface) SetIsConnectedArgsForCall(i int) bo
 	return argsForCall.arg1
 }
 
+func (fake *FakeFileManagerServiceInterface) SetRootPath(arg1 string) {
+	fake.setRootPathMutex.Lock()
+	fake.setRootPathArgsForCall = append(fake.setRootPathArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SetRootPathStub
+	fake.recordInvocation("SetRootPath", []interface{}{arg1})
+	fake.setRootPathMutex.Unlock()
+	if stub != nil {
+		fake.SetRootPathStub(arg1)
+	}
+}
+
+func (fake *FakeFileManagerServiceInterface) SetRootPathCallCount() int {
+	fake.setRootPathMutex.RLock()
+	defer fake.setRootPathMutex.RUnlock()
+	return len(fake.setRootPathArgsForCall)
+}
+
+func (fake *FakeFileManagerServiceInterface) SetRootPathCalls(stub func(string)) {
+	fake.setRootPathMutex.Lock()
+	defer fake.setRootPathMutex.Unlock()
+	fake.SetRootPathStub = stub
+}
+
+func (fake *FakeFileManagerServiceInterface) SetRootPathArgsForCall(i int) string {
+	fake.setRootPathMutex.RLock()
+	defer fake.setRootPathMutex.RUnlock()
+	argsForCall := fake.setRootPathArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeFileManagerServiceInterface) SetTemplateData(arg1 *file.TemplateData) {
+	fake.setTemplateDataMutex.Lock()
+	fake.setTemplateDataArgsForCall = append(fake.setTemplateDataArgsForCall, struct {
+		arg1 *file.TemplateData
+	}{arg1})
+	stub := fake.SetTemplateDataStub
+	fake.recordInvocation("SetTemplateData", []interface{}{arg1})
+	fake.setTemplateDataMutex.Unlock()
+	if stub != nil {
+		fake.SetTemplateDataStub(arg1)
+	}
+}
+
+func (fake *FakeFileManagerServiceInterface) SetTemplateDataCallCount() int {
+	fake.setTemplateDataMutex.RLock()
+	defer fake.setTemplateDataMutex.RUnlock()
+	return len(fake.setTemplateDataArgsForCall)
+}
+
+func (fake *FakeFileManagerServiceInterface) SetTemplateDataCalls(stub func(*file.TemplateData)) {
+	fake.setTemplateDataMutex.Lock()
+	defer fake.setTemplateDataMutex.Unlock()
+	fake.SetTemplateDataStub = stub
+}
+
+func (fake *FakeFileManagerServiceInterface) SetTemplateDataArgsForCall(i int) *file.TemplateData {
+	fake.setTemplateDataMutex.RLock()
+	defer fake.setTemplateDataMutex.RUnlock()
+	argsForCall := fake.setTemplateDataArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeFileManagerServiceInterface) StageConfig(arg1 context.Context, arg2 *v1.FileOverview, arg3 file.InstanceFiles) (string, error) {
+	fake.stageConfigMutex.Lock()
+	ret, specificReturn := fake.stageConfigReturnsOnCall[len(fake.stageConfigArgsForCall)]
+	fake.stageConfigArgsForCall = append(fake.stageConfigArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1.FileOverview
+		arg3 file.InstanceFiles
+	}{arg1, arg2, arg3})
+	stub := fake.StageConfigStub
+	fakeReturns := fake.stageConfigReturns
+	fake.recordInvocation("StageConfig", []interface{}{arg1, arg2, arg3})
+	fake.stageConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeFileManagerServiceInterface) StageConfigCallCount() int {
+	fake.stageConfigMutex.RLock()
+	defer fake.stageConfigMutex.RUnlock()
+	return len(fake.stageConfigArgsForCall)
+}
+
+func (fake *FakeFileManagerServiceInterface) StageConfigCalls(stub func(context.Context, *v1.FileOverview, file.InstanceFiles) (string, error)) {
+	fake.stageConfigMutex.Lock()
+	defer fake.stageConfigMutex.Unlock()
+	fake.StageConfigStub = stub
+}
+
+func (fake *FakeFileManagerServiceInterface) StageConfigArgsForCall(i int) (context.Context, *v1.FileOverview, file.InstanceFiles) {
+	fake.stageConfigMutex.RLock()
+	defer fake.stageConfigMutex.RUnlock()
+	argsForCall := fake.stageConfigArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeFileManagerServiceInterface) StageConfigReturns(result1 string, result2 error) {
+	fake.stageConfigMutex.Lock()
+	defer fake.stageConfigMutex.Unlock()
+	fake.StageConfigStub = nil
+	fake.stageConfigReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFileManagerServiceInterface) StageConfigReturnsOnCall(i int, result1 string, result2 error) {
+	fake.stageConfigMutex.Lock()
+	defer fake.stageConfigMutex.Unlock()
+	fake.StageConfigStub = nil
+	if fake.stageConfigReturnsOnCall == nil {
+		fake.stageConfigReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.stageConfigReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeFileManagerServiceInterface) UpdateCurrentFilesOnDisk(arg1 context.Context, arg2 map[string]*v1.File, arg3 bool) error {
 	fake.updateCurrentFilesOnDiskMutex.Lock()
 	ret, specificReturn := fake.updateCurrentFilesOnDiskReturnsOnCall[len(fake.updateCurrentFilesOnDiskArgsForCall)]
@@ -605,29 +1462,121 @@ func (fake *FakeFileManagerServiceInterface) UpdateCurrentFilesOnDiskReturnsOnCa
 	}{result1}
 }
 
+func (fake *FakeFileManagerServiceInterface) UploadFileContents(arg1 context.Context, arg2 string, arg3 *v1.File, arg4 []byte) error {
+	fake.uploadFileContentsMutex.Lock()
+	ret, specificReturn := fake.uploadFileContentsReturnsOnCall[len(fake.uploadFileContentsArgsForCall)]
+	fake.uploadFileContentsArgsForCall = append(fake.uploadFileContentsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 *v1.File
+		arg4 []byte
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UploadFileContentsStub
+	fakeReturns := fake.uploadFileContentsReturns
+	fake.recordInvocation("UploadFileContents", []interface{}{arg1, arg2, arg3, arg4})
+	fake.uploadFileContentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFileManagerServiceInterface) UploadFileContentsCallCount() int {
+	fake.uploadFileContentsMutex.RLock()
+	defer fake.uploadFileContentsMutex.RUnlock()
+	return len(fake.uploadFileContentsArgsForCall)
+}
+
+func (fake *FakeFileManagerServiceInterface) UploadFileContentsCalls(stub func(context.Context, string, *v1.File, []byte) error) {
+	fake.uploadFileContentsMutex.Lock()
+	defer fake.uploadFileContentsMutex.Unlock()
+	fake.UploadFileContentsStub = stub
+}
+
+func (fake *FakeFileManagerServiceInterface) UploadFileContentsArgsForCall(i int) (context.Context, string, *v1.File, []byte) {
+	fake.uploadFileContentsMutex.RLock()
+	defer fake.uploadFileContentsMutex.RUnlock()
+	argsForCall := fake.uploadFileContentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeFileManagerServiceInterface) UploadFileContentsReturns(result1 error) {
+	fake.uploadFileContentsMutex.Lock()
+	defer fake.uploadFileContentsMutex.Unlock()
+	fake.UploadFileContentsStub = nil
+	fake.uploadFileContentsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFileManagerServiceInterface) UploadFileContentsReturnsOnCall(i int, result1 error) {
+	fake.uploadFileContentsMutex.Lock()
+	defer fake.uploadFileContentsMutex.Unlock()
+	fake.UploadFileContentsStub = nil
+	if fake.uploadFileContentsReturnsOnCall == nil {
+		fake.uploadFileContentsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.uploadFileContentsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeFileManagerServiceInterface) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.changedFilesMutex.RLock()
+	defer fake.changedFilesMutex.RUnlock()
 	fake.clearCacheMutex.RLock()
 	defer fake.clearCacheMutex.RUnlock()
 	fake.configApplyMutex.RLock()
 	defer fake.configApplyMutex.RUnlock()
+	fake.configDiffMutex.RLock()
+	defer fake.configDiffMutex.RUnlock()
 	fake.configUpdateMutex.RLock()
 	defer fake.configUpdateMutex.RUnlock()
 	fake.configUploadMutex.RLock()
 	defer fake.configUploadMutex.RUnlock()
+	fake.configVersionOverviewMutex.RLock()
+	defer fake.configVersionOverviewMutex.RUnlock()
+	fake.configVersionsMutex.RLock()
+	defer fake.configVersionsMutex.RUnlock()
 	fake.determineFileActionsMutex.RLock()
 	defer fake.determineFileActionsMutex.RUnlock()
+	fake.fileContentsMutex.RLock()
+	defer fake.fileContentsMutex.RUnlock()
 	fake.isConnectedMutex.RLock()
 	defer fake.isConnectedMutex.RUnlock()
+	fake.recoverConfigApplyMutex.RLock()
+	defer fake.recoverConfigApplyMutex.RUnlock()
+	fake.refreshExternalFilesMutex.RLock()
+	defer fake.refreshExternalFilesMutex.RUnlock()
 	fake.resetClientMutex.RLock()
 	defer fake.resetClientMutex.RUnlock()
+	fake.resetExternalDataSourceCredentialsMutex.RLock()
+	defer fake.resetExternalDataSourceCredentialsMutex.RUnlock()
 	fake.rollbackMutex.RLock()
 	defer fake.rollbackMutex.RUnlock()
+	fake.saveConfigVersionMutex.RLock()
+	defer fake.saveConfigVersionMutex.RUnlock()
+	fake.setConfigApplyPhaseMutex.RLock()
+	defer fake.setConfigApplyPhaseMutex.RUnlock()
 	fake.setIsConnectedMutex.RLock()
 	defer fake.setIsConnectedMutex.RUnlock()
+	fake.setRootPathMutex.RLock()
+	defer fake.setRootPathMutex.RUnlock()
+	fake.setTemplateDataMutex.RLock()
+	defer fake.setTemplateDataMutex.RUnlock()
+	fake.stageConfigMutex.RLock()
+	defer fake.stageConfigMutex.RUnlock()
 	fake.updateCurrentFilesOnDiskMutex.RLock()
 	defer fake.updateCurrentFilesOnDiskMutex.RUnlock()
+	fake.uploadFileContentsMutex.RLock()
+	defer fake.uploadFileContentsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -82,6 +82,23 @@ type FakeFileOperator struct {
 	writeChunkedFileReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WriteChunkedFileDeltaStub        func(context.Context, string, string, string, *v1.FileDataChunkHeader, *v1.FileSignature, grpc.ServerStreamingClient[v1.FileDataChunk]) error
+	writeChunkedFileDeltaMutex       sync.RWMutex
+	writeChunkedFileDeltaArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 *v1.FileDataChunkHeader
+		arg6 *v1.FileSignature
+		arg7 grpc.ServerStreamingClient[v1.FileDataChunk]
+	}
+	writeChunkedFileDeltaReturns struct {
+		result1 error
+	}
+	writeChunkedFileDeltaReturnsOnCall map[int]struct {
+		result1 error
+	}
 	WriteManifestFileStub        func(context.Context, map[string]*model.ManifestFile, string, string) error
 	writeManifestFileMutex       sync.RWMutex
 	writeManifestFileArgsForCall []struct {
@@ -426,6 +443,73 @@ func (fake *FakeFileOperator) WriteChunkedFileReturnsOnCall(i int, result1 error
 	}{result1}
 }
 
+func (fake *FakeFileOperator) WriteChunkedFileDelta(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 *v1.FileDataChunkHeader, arg6 *v1.FileSignature, arg7 grpc.ServerStreamingClient[v1.FileDataChunk]) error {
+	fake.writeChunkedFileDeltaMutex.Lock()
+	ret, specificReturn := fake.writeChunkedFileDeltaReturnsOnCall[len(fake.writeChunkedFileDeltaArgsForCall)]
+	fake.writeChunkedFileDeltaArgsForCall = append(fake.writeChunkedFileDeltaArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 *v1.FileDataChunkHeader
+		arg6 *v1.FileSignature
+		arg7 grpc.ServerStreamingClient[v1.FileDataChunk]
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	stub := fake.WriteChunkedFileDeltaStub
+	fakeReturns := fake.writeChunkedFileDeltaReturns
+	fake.recordInvocation("WriteChunkedFileDelta", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.writeChunkedFileDeltaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFileOperator) WriteChunkedFileDeltaCallCount() int {
+	fake.writeChunkedFileDeltaMutex.RLock()
+	defer fake.writeChunkedFileDeltaMutex.RUnlock()
+	return len(fake.writeChunkedFileDeltaArgsForCall)
+}
+
+func (fake *FakeFileOperator) WriteChunkedFileDeltaCalls(stub func(context.Context, string, string, string, *v1.FileDataChunkHeader, *v1.FileSignature, grpc.ServerStreamingClient[v1.FileDataChunk]) error) {
+	fake.writeChunkedFileDeltaMutex.Lock()
+	defer fake.writeChunkedFileDeltaMutex.Unlock()
+	fake.WriteChunkedFileDeltaStub = stub
+}
+
+func (fake *FakeFileOperator) WriteChunkedFileDeltaArgsForCall(i int) (context.Context, string, string, string, *v1.FileDataChunkHeader, *v1.FileSignature, grpc.ServerStreamingClient[v1.FileDataChunk]) {
+	fake.writeChunkedFileDeltaMutex.RLock()
+	defer fake.writeChunkedFileDeltaMutex.RUnlock()
+	argsForCall := fake.writeChunkedFileDeltaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
+}
+
+func (fake *FakeFileOperator) WriteChunkedFileDeltaReturns(result1 error) {
+	fake.writeChunkedFileDeltaMutex.Lock()
+	defer fake.writeChunkedFileDeltaMutex.Unlock()
+	fake.WriteChunkedFileDeltaStub = nil
+	fake.writeChunkedFileDeltaReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFileOperator) WriteChunkedFileDeltaReturnsOnCall(i int, result1 error) {
+	fake.writeChunkedFileDeltaMutex.Lock()
+	defer fake.writeChunkedFileDeltaMutex.Unlock()
+	fake.WriteChunkedFileDeltaStub = nil
+	if fake.writeChunkedFileDeltaReturnsOnCall == nil {
+		fake.writeChunkedFileDeltaReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeChunkedFileDeltaReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeFileOperator) WriteManifestFile(arg1 context.Context, arg2 map[string]*model.ManifestFile, arg3 string, arg4 string) error {
 	fake.writeManifestFileMutex.Lock()
 	ret, specificReturn := fake.writeManifestFileReturnsOnCall[len(fake.writeManifestFileArgsForCall)]
@@ -503,6 +587,8 @@ func (fake *FakeFileOperator) Invocations() map[string][][]interface{} {
 	defer fake.writeMutex.RUnlock()
 	fake.writeChunkedFileMutex.RLock()
 	defer fake.writeChunkedFileMutex.RUnlock()
+	fake.writeChunkedFileDeltaMutex.RLock()
+	defer fake.writeChunkedFileDeltaMutex.RUnlock()
 	fake.writeManifestFileMutex.RLock()
 	defer fake.writeManifestFileMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -9,13 +9,14 @@ import (
 )
 
 type FakeFileServiceOperatorInterface struct {
-	ChunkedFileStub        func(context.Context, *v1.File, string, string) error
+	ChunkedFileStub        func(context.Context, *v1.File, string, string, string) error
 	chunkedFileMutex       sync.RWMutex
 	chunkedFileArgsForCall []struct {
 		arg1 context.Context
 		arg2 *v1.File
 		arg3 string
 		arg4 string
+		arg5 string
 	}
 	chunkedFileReturns struct {
 		result1 error
@@ -37,6 +38,20 @@ type FakeFileServiceOperatorInterface struct {
 	fileReturnsOnCall map[int]struct {
 		result1 error
 	}
+	FileContentsStub        func(context.Context, *v1.File) ([]byte, error)
+	fileContentsMutex       sync.RWMutex
+	fileContentsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1.File
+	}
+	fileContentsReturns struct {
+		result1 []byte
+		result2 error
+	}
+	fileContentsReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	IsConnectedStub        func() bool
 	isConnectedMutex       sync.RWMutex
 	isConnectedArgsForCall []struct {
@@ -47,6 +62,10 @@ type FakeFileServiceOperatorInterface struct {
 	isConnectedReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	RemovePartialFilesStub        func()
+	removePartialFilesMutex       sync.RWMutex
+	removePartialFilesArgsForCall []struct {
+	}
 	RenameFileStub        func(context.Context, string, string) error
 	renameFileMutex       sync.RWMutex
 	renameFileArgsForCall []struct {
@@ -84,6 +103,20 @@ type FakeFileServiceOperatorInterface struct {
 	updateFileReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateFileContentsStub        func(context.Context, string, *v1.File, []byte) error
+	updateFileContentsMutex       sync.RWMutex
+	updateFileContentsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 *v1.File
+		arg4 []byte
+	}
+	updateFileContentsReturns struct {
+		result1 error
+	}
+	updateFileContentsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateOverviewStub        func(context.Context, string, []*v1.File, string, int) error
 	updateOverviewMutex       sync.RWMutex
 	updateOverviewArgsForCall []struct {
@@ -116,7 +149,7 @@ type FakeFileServiceOperatorInterface struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeFileServiceOperatorInterface) ChunkedFile(arg1 context.Context, arg2 *v1.File, arg3 string, arg4 string) error {
+func (fake *FakeFileServiceOperatorInterface) ChunkedFile(arg1 context.Context, arg2 *v1.File, arg3 string, arg4 string, arg5 string) error {
 	fake.chunkedFileMutex.Lock()
 	ret, specificReturn := fake.chunkedFileReturnsOnCall[len(fake.chunkedFileArgsForCall)]
 	fake.chunkedFileArgsForCall = append(fake.chunkedFileArgsForCall, struct {
@@ -124,13 +157,14 @@ func (fake *FakeFileServiceOperatorInterface) ChunkedFile(arg1 context.Context,
 		arg2 *v1.File
 		arg3 string
 		arg4 string
-	}{arg1, arg2, arg3, arg4})
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.ChunkedFileStub
 	fakeReturns := fake.chunkedFileReturns
-	fake.recordInvocation("ChunkedFile", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("ChunkedFile", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.chunkedFileMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -144,17 +178,17 @@ func (fake *FakeFileServiceOperatorInterface) ChunkedFileCallCount() int {
 	return len(fake.chunkedFileArgsForCall)
 }
 
-func (fake *FakeFileServiceOperatorInterface) ChunkedFileCalls(stub func(context.Context, *v1.File, string, string) error) {
+func (fake *FakeFileServiceOperatorInterface) ChunkedFileCalls(stub func(context.Context, *v1.File, string, string, string) error) {
 	fake.chunkedFileMutex.Lock()
 	defer fake.chunkedFileMutex.Unlock()
 	fake.ChunkedFileStub = stub
 }
 
-func (fake *FakeFileServiceOperatorInterface) ChunkedFileArgsForCall(i int) (context.Context, *v1.File, string, string) {
+func (fake *FakeFileServiceOperatorInterface) ChunkedFileArgsForCall(i int) (context.Context, *v1.File, string, string, string) {
 	fake.chunkedFileMutex.RLock()
 	defer fake.chunkedFileMutex.RUnlock()
 	argsForCall := fake.chunkedFileArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeFileServiceOperatorInterface) ChunkedFileReturns(result1 error) {
@@ -244,6 +278,71 @@ func (fake *FakeFileServiceOperatorInterface) FileReturnsOnCall(i int, result1 e
 	}{result1}
 }
 
+func (fake *FakeFileServiceOperatorInterface) FileContents(arg1 context.Context, arg2 *v1.File) ([]byte, error) {
+	fake.fileContentsMutex.Lock()
+	ret, specificReturn := fake.fileContentsReturnsOnCall[len(fake.fileContentsArgsForCall)]
+	fake.fileContentsArgsForCall = append(fake.fileContentsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1.File
+	}{arg1, arg2})
+	stub := fake.FileContentsStub
+	fakeReturns := fake.fileContentsReturns
+	fake.recordInvocation("FileContents", []interface{}{arg1, arg2})
+	fake.fileContentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeFileServiceOperatorInterface) FileContentsCallCount() int {
+	fake.fileContentsMutex.RLock()
+	defer fake.fileContentsMutex.RUnlock()
+	return len(fake.fileContentsArgsForCall)
+}
+
+func (fake *FakeFileServiceOperatorInterface) FileContentsCalls(stub func(context.Context, *v1.File) ([]byte, error)) {
+	fake.fileContentsMutex.Lock()
+	defer fake.fileContentsMutex.Unlock()
+	fake.FileContentsStub = stub
+}
+
+func (fake *FakeFileServiceOperatorInterface) FileContentsArgsForCall(i int) (context.Context, *v1.File) {
+	fake.fileContentsMutex.RLock()
+	defer fake.fileContentsMutex.RUnlock()
+	argsForCall := fake.fileContentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeFileServiceOperatorInterface) FileContentsReturns(result1 []byte, result2 error) {
+	fake.fileContentsMutex.Lock()
+	defer fake.fileContentsMutex.Unlock()
+	fake.FileContentsStub = nil
+	fake.fileContentsReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFileServiceOperatorInterface) FileContentsReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.fileContentsMutex.Lock()
+	defer fake.fileContentsMutex.Unlock()
+	fake.FileContentsStub = nil
+	if fake.fileContentsReturnsOnCall == nil {
+		fake.fileContentsReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.fileContentsReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeFileServiceOperatorInterface) IsConnected() bool {
 	fake.isConnectedMutex.Lock()
 	ret, specificReturn := fake.isConnectedReturnsOnCall[len(fake.isConnectedArgsForCall)]
@@ -297,6 +396,30 @@ func (fake *FakeFileServiceOperatorInterface) IsConnectedReturnsOnCall(i int, re
 	}{result1}
 }
 
+func (fake *FakeFileServiceOperatorInterface) RemovePartialFiles() {
+	fake.removePartialFilesMutex.Lock()
+	fake.removePartialFilesArgsForCall = append(fake.removePartialFilesArgsForCall, struct {
+	}{})
+	stub := fake.RemovePartialFilesStub
+	fake.recordInvocation("RemovePartialFiles", []interface{}{})
+	fake.removePartialFilesMutex.Unlock()
+	if stub != nil {
+		fake.RemovePartialFilesStub()
+	}
+}
+
+func (fake *FakeFileServiceOperatorInterface) RemovePartialFilesCallCount() int {
+	fake.removePartialFilesMutex.RLock()
+	defer fake.removePartialFilesMutex.RUnlock()
+	return len(fake.removePartialFilesArgsForCall)
+}
+
+func (fake *FakeFileServiceOperatorInterface) RemovePartialFilesCalls(stub func()) {
+	fake.removePartialFilesMutex.Lock()
+	defer fake.removePartialFilesMutex.Unlock()
+	fake.RemovePartialFilesStub = stub
+}
+
 func (fake *FakeFileServiceOperatorInterface) RenameFile(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.renameFileMutex.Lock()
 	ret, specificReturn := fake.renameFileReturnsOnCall[len(fake.renameFileArgsForCall)]
@@ -488,6 +611,70 @@ func (fake *FakeFileServiceOperatorInterface) UpdateFileReturnsOnCall(i int, res
 	}{result1}
 }
 
+func (fake *FakeFileServiceOperatorInterface) UpdateFileContents(arg1 context.Context, arg2 string, arg3 *v1.File, arg4 []byte) error {
+	fake.updateFileContentsMutex.Lock()
+	ret, specificReturn := fake.updateFileContentsReturnsOnCall[len(fake.updateFileContentsArgsForCall)]
+	fake.updateFileContentsArgsForCall = append(fake.updateFileContentsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 *v1.File
+		arg4 []byte
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UpdateFileContentsStub
+	fakeReturns := fake.updateFileContentsReturns
+	fake.recordInvocation("UpdateFileContents", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateFileContentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFileServiceOperatorInterface) UpdateFileContentsCallCount() int {
+	fake.updateFileContentsMutex.RLock()
+	defer fake.updateFileContentsMutex.RUnlock()
+	return len(fake.updateFileContentsArgsForCall)
+}
+
+func (fake *FakeFileServiceOperatorInterface) UpdateFileContentsCalls(stub func(context.Context, string, *v1.File, []byte) error) {
+	fake.updateFileContentsMutex.Lock()
+	defer fake.updateFileContentsMutex.Unlock()
+	fake.UpdateFileContentsStub = stub
+}
+
+func (fake *FakeFileServiceOperatorInterface) UpdateFileContentsArgsForCall(i int) (context.Context, string, *v1.File, []byte) {
+	fake.updateFileContentsMutex.RLock()
+	defer fake.updateFileContentsMutex.RUnlock()
+	argsForCall := fake.updateFileContentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeFileServiceOperatorInterface) UpdateFileContentsReturns(result1 error) {
+	fake.updateFileContentsMutex.Lock()
+	defer fake.updateFileContentsMutex.Unlock()
+	fake.UpdateFileContentsStub = nil
+	fake.updateFileContentsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFileServiceOperatorInterface) UpdateFileContentsReturnsOnCall(i int, result1 error) {
+	fake.updateFileContentsMutex.Lock()
+	defer fake.updateFileContentsMutex.Unlock()
+	fake.UpdateFileContentsStub = nil
+	if fake.updateFileContentsReturnsOnCall == nil {
+		fake.updateFileContentsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateFileContentsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeFileServiceOperatorInterface) UpdateOverview(arg1 context.Context, arg2 string, arg3 []*v1.File, arg4 string, arg5 int) error {
 	var arg3Copy []*v1.File
 	if arg3 != nil {
@@ -628,8 +815,12 @@ func (fake *FakeFileServiceOperatorInterface) Invocations() map[string][][]inter
 	defer fake.chunkedFileMutex.RUnlock()
 	fake.fileMutex.RLock()
 	defer fake.fileMutex.RUnlock()
+	fake.fileContentsMutex.RLock()
+	defer fake.fileContentsMutex.RUnlock()
 	fake.isConnectedMutex.RLock()
 	defer fake.isConnectedMutex.RUnlock()
+	fake.removePartialFilesMutex.RLock()
+	defer fake.removePartialFilesMutex.RUnlock()
 	fake.renameFileMutex.RLock()
 	defer fake.renameFileMutex.RUnlock()
 	fake.setIsConnectedMutex.RLock()
@@ -638,6 +829,8 @@ func (fake *FakeFileServiceOperatorInterface) Invocations() map[string][][]inter
 	defer fake.updateClientMutex.RUnlock()
 	fake.updateFileMutex.RLock()
 	defer fake.updateFileMutex.RUnlock()
+	fake.updateFileContentsMutex.RLock()
+	defer fake.updateFileContentsMutex.RUnlock()
 	fake.updateOverviewMutex.RLock()
 	defer fake.updateOverviewMutex.RUnlock()
 	fake.validateFileHashMutex.RLock()
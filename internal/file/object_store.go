@@ -0,0 +1,269 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nginx/agent/v3/pkg/files"
+)
+
+const (
+	objectStoreDirName       = "objects"
+	objectStoreTempExtension = ".tmp"
+)
+
+// objectStore is a local store of file contents shared by all instances, addressed by the SHA-256 hash of the
+// content. It holds the file contents of the config versions stored in the config history, and caches the
+// contents of files that were downloaded or backed up, so that a file is not downloaded again, e.g. when several
+// instances include the same file or a config flips back to a previous version.
+// Objects referenced by the config history are kept for as long as the history references them. The size of the
+// other objects is bounded by removing the least recently used ones. The content is verified against its hash
+// every time it is read, and removed if it does not match.
+type objectStore struct {
+	// referenced returns the names of the objects that must not be removed. Called with the mutex held.
+	referenced   func(ctx context.Context) (map[string]bool, error)
+	dir          string
+	maxCacheSize int64
+	mutex        sync.Mutex
+}
+
+func newObjectStore(dir string, maxCacheSize int64) *objectStore {
+	return &objectStore{
+		dir:          dir,
+		maxCacheSize: maxCacheSize,
+		referenced: func(context.Context) (map[string]bool, error) {
+			return nil, nil
+		},
+	}
+}
+
+// CacheEnabled returns false if no cache size is configured, in which case only the file contents of the config
+// history are stored.
+func (s *objectStore) CacheEnabled() bool {
+	return s.maxCacheSize > 0
+}
+
+// Put copies the content of a file into the cache. The content is stored under the hash of the content read
+// from the file, so a file that was changed after it was downloaded is never stored under the wrong hash.
+func (s *objectStore) Put(ctx context.Context, fileName string) error {
+	if !s.CacheEnabled() {
+		return nil
+	}
+
+	src, err := os.Open(fileName)
+	if err != nil {
+		return fmt.Errorf("unable to open file %s: %w", fileName, err)
+	}
+	defer closeFile(ctx, src)
+
+	fileInfo, err := src.Stat()
+	if err != nil {
+		return fmt.Errorf("unable to stat file %s: %w", fileName, err)
+	}
+
+	if fileInfo.Size() > s.maxCacheSize {
+		slog.DebugContext(ctx, "File is larger than the file cache", "file", fileName, "size", fileInfo.Size())
+		return nil
+	}
+
+	if err = os.MkdirAll(s.dir, dirPerm); err != nil {
+		return fmt.Errorf("unable to create object store directory %s: %w", s.dir, err)
+	}
+
+	temp, err := os.CreateTemp(s.dir, "*"+objectStoreTempExtension)
+	if err != nil {
+		return fmt.Errorf("unable to create object: %w", err)
+	}
+	defer removeTempFile(ctx, temp.Name())
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(temp, hash), src)
+	closeFile(ctx, temp)
+
+	if err != nil {
+		return fmt.Errorf("unable to copy file %s to object store: %w", fileName, err)
+	}
+
+	objectPath := filepath.Join(s.dir, hex.EncodeToString(hash.Sum(nil)))
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err = os.Rename(temp.Name(), objectPath); err != nil {
+		return fmt.Errorf("unable to rename object %s: %w", temp.Name(), err)
+	}
+
+	slog.DebugContext(ctx, "Added file to file cache", "file", fileName,
+		"hash", base64.StdEncoding.EncodeToString(hash.Sum(nil)))
+
+	s.collectGarbage(ctx)
+
+	return nil
+}
+
+// Get copies the stored content with the given hash to the destination file. If no valid content is stored
+// for the hash, found is false and the destination file is not created.
+func (s *objectStore) Get(ctx context.Context, hash, fileName, filePermissions string) (found bool, err error) {
+	objectPath, err := s.objectPath(hash)
+	if err != nil {
+		return false, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	object, err := os.Open(objectPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("unable to open object %s: %w", objectPath, err)
+	}
+	defer closeFile(ctx, object)
+
+	if err = os.MkdirAll(filepath.Dir(fileName), dirPerm); err != nil {
+		return false, fmt.Errorf("unable to create directories for %s: %w", fileName, err)
+	}
+
+	dst, err := os.OpenFile(fileName, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, files.FileMode(filePermissions))
+	if err != nil {
+		return false, fmt.Errorf("unable to create file %s: %w", fileName, err)
+	}
+
+	contentHash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(dst, contentHash), object)
+	closeFile(ctx, dst)
+
+	if err != nil {
+		removeTempFile(ctx, fileName)
+		return false, fmt.Errorf("unable to copy object %s to %s: %w", objectPath, fileName, err)
+	}
+
+	if base64.StdEncoding.EncodeToString(contentHash.Sum(nil)) != hash {
+		slog.WarnContext(ctx, "Object does not match its hash, removing it", "path", objectPath)
+		removeTempFile(ctx, fileName)
+		removeTempFile(ctx, objectPath)
+
+		return false, nil
+	}
+
+	touchObject(ctx, objectPath)
+
+	return true, nil
+}
+
+// write stores content that is referenced by the config history. Must be called with the mutex held.
+func (s *objectStore) write(ctx context.Context, hash string, content []byte) error {
+	objectPath, err := s.objectPath(hash)
+	if err != nil {
+		return err
+	}
+
+	if _, err = os.Stat(objectPath); err == nil {
+		touchObject(ctx, objectPath)
+		return nil
+	}
+
+	return files.WriteFileAtomically(objectPath, content, filePerm)
+}
+
+// collectGarbage removes the least recently used objects that are not referenced by the config history, until
+// their size is at most the max cache size. Must be called with the mutex held.
+func (s *objectStore) collectGarbage(ctx context.Context) {
+	referenced, err := s.referenced(ctx)
+	if err != nil {
+		// objects might still be referenced by the unreadable config versions, so nothing is removed
+		slog.WarnContext(ctx, "Unable to read config history", "error", err)
+		return
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.WarnContext(ctx, "Unable to list object store", "error", err)
+		}
+
+		return
+	}
+
+	objects := make([]os.FileInfo, 0, len(entries))
+
+	var size int64
+
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), objectStoreTempExtension) || referenced[entry.Name()] {
+			continue
+		}
+
+		info, infoErr := entry.Info()
+		if infoErr != nil {
+			continue
+		}
+
+		objects = append(objects, info)
+		size += info.Size()
+	}
+
+	slices.SortFunc(objects, func(a, b os.FileInfo) int {
+		return a.ModTime().Compare(b.ModTime())
+	})
+
+	for _, object := range objects {
+		if size <= s.maxCacheSize {
+			return
+		}
+
+		objectPath := filepath.Join(s.dir, object.Name())
+		if removeErr := os.Remove(objectPath); removeErr != nil {
+			slog.WarnContext(ctx, "Unable to remove object", "path", objectPath, "error", removeErr)
+			continue
+		}
+
+		slog.DebugContext(ctx, "Removed least recently used object", "path", objectPath)
+		size -= object.Size()
+	}
+}
+
+// objectPath returns the path of the object for a file hash. Hashes are base64 encoded, which is not safe to use
+// in a file name, so the object is named after the hex encoding of the hash.
+func (s *objectStore) objectPath(hash string) (string, error) {
+	decodedHash, err := base64.StdEncoding.DecodeString(hash)
+	if err != nil || len(decodedHash) != sha256.Size {
+		return "", fmt.Errorf("invalid file hash %q", hash)
+	}
+
+	return filepath.Join(s.dir, hex.EncodeToString(decodedHash)), nil
+}
+
+// touchObject updates the modified time of an object, which is its last access time, used to remove the least
+// recently used objects.
+func touchObject(ctx context.Context, objectPath string) {
+	now := time.Now()
+	if err := os.Chtimes(objectPath, now, now); err != nil {
+		slog.DebugContext(ctx, "Unable to update object access time", "path", objectPath, "error", err)
+	}
+}
+
+func removeTempFile(ctx context.Context, fileName string) {
+	if err := os.Remove(fileName); err != nil && !errors.Is(err, os.ErrNotExist) {
+		slog.WarnContext(ctx, "Unable to remove file", "file", fileName, "error", err)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nginx/agent/v3/pkg/files"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectStore_PutAndGet(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	content := []byte("worker_processes 1;\n")
+	hash := files.GenerateHash(content)
+
+	fileName := filepath.Join(tempDir, "nginx.conf")
+	require.NoError(t, os.WriteFile(fileName, content, 0o600))
+
+	store := newObjectStore(filepath.Join(tempDir, objectStoreDirName), 1024)
+	require.NoError(t, store.Put(ctx, fileName))
+
+	restoredFileName := filepath.Join(tempDir, "restored", "nginx.conf")
+	found, err := store.Get(ctx, hash, restoredFileName, "0640")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	restoredContent, err := os.ReadFile(restoredFileName)
+	require.NoError(t, err)
+	assert.Equal(t, content, restoredContent)
+
+	fileInfo, err := os.Stat(restoredFileName)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), fileInfo.Mode().Perm())
+
+	found, err = store.Get(ctx, files.GenerateHash([]byte("other")), filepath.Join(tempDir, "other.conf"), "0640")
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.NoFileExists(t, filepath.Join(tempDir, "other.conf"))
+
+	_, err = store.Get(ctx, "invalid", restoredFileName, "0640")
+	require.EqualError(t, err, `invalid file hash "invalid"`)
+}
+
+func TestObjectStore_Get_CorruptObject(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	content := []byte("worker_processes 1;\n")
+	hash := files.GenerateHash(content)
+
+	fileName := filepath.Join(tempDir, "nginx.conf")
+	require.NoError(t, os.WriteFile(fileName, content, 0o600))
+
+	store := newObjectStore(filepath.Join(tempDir, objectStoreDirName), 1024)
+	require.NoError(t, store.Put(ctx, fileName))
+
+	objectPath, err := store.objectPath(hash)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(objectPath, []byte("corrupted"), 0o600))
+
+	restoredFileName := filepath.Join(tempDir, "restored.conf")
+	found, err := store.Get(ctx, hash, restoredFileName, "0640")
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.NoFileExists(t, restoredFileName)
+	assert.NoFileExists(t, objectPath)
+}
+
+func TestObjectStore_CollectGarbage(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	store := newObjectStore(filepath.Join(tempDir, objectStoreDirName), 250)
+
+	contents := [][]byte{
+		append(make([]byte, 100), 'a'),
+		append(make([]byte, 100), 'b'),
+		append(make([]byte, 100), 'c'),
+	}
+
+	for i, content := range contents {
+		fileName := filepath.Join(tempDir, "file.conf")
+		require.NoError(t, os.WriteFile(fileName, content, 0o600))
+		require.NoError(t, store.Put(ctx, fileName))
+
+		objectPath, err := store.objectPath(files.GenerateHash(content))
+		require.NoError(t, err)
+
+		accessTime := time.Now().Add(time.Duration(i-10) * time.Minute)
+		require.NoError(t, os.Chtimes(objectPath, accessTime, accessTime))
+
+		if i == 1 {
+			// the first content is used again, so the second content is the least recently used
+			found, err := store.Get(ctx, files.GenerateHash(contents[0]), filepath.Join(tempDir, "restored.conf"),
+				"0640")
+			require.NoError(t, err)
+			require.True(t, found)
+		}
+	}
+
+	for i, expectedFound := range []bool{true, false, true} {
+		objectPath, err := store.objectPath(files.GenerateHash(contents[i]))
+		require.NoError(t, err)
+
+		if expectedFound {
+			assert.FileExists(t, objectPath)
+		} else {
+			assert.NoFileExists(t, objectPath)
+		}
+	}
+}
+
+func TestObjectStore_CacheDisabled(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	fileName := filepath.Join(tempDir, "nginx.conf")
+	require.NoError(t, os.WriteFile(fileName, []byte("worker_processes 1;\n"), 0o600))
+
+	store := newObjectStore(filepath.Join(tempDir, objectStoreDirName), 0)
+	require.NoError(t, store.Put(ctx, fileName))
+	assert.NoDirExists(t, filepath.Join(tempDir, objectStoreDirName))
+
+	found, err := store.Get(ctx, files.GenerateHash([]byte("worker_processes 1;\n")), fileName, "0640")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
@@ -0,0 +1,182 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/nginx/agent/v3/internal/config"
+)
+
+const (
+	s3Scheme  = "s3"
+	s3Service = "s3"
+	// sha256 of an empty request body, which is the payload hash of a GET request
+	emptyPayloadHash    = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	amzContentSHA256Key = "X-Amz-Content-Sha256"
+)
+
+var errS3CredentialsMissing = errors.New("S3 credentials file does not contain an access key ID and secret access key")
+
+// s3CredentialsCache holds the credentials read from the S3 credentials file. The file is watched by the
+// credential watcher, which resets the cache when the file changes, so rotated credentials are read again.
+type s3CredentialsCache struct {
+	credentials     aws.Credentials
+	credentialsFile string
+	profile         string
+	mutex           sync.Mutex
+	loaded          bool
+}
+
+// s3ObjectURL returns the HTTP(S) URL of the object in an s3://bucket/key location. Objects are addressed
+// path-style on a configured endpoint, such as a MinIO server, otherwise virtual-hosted-style on AWS S3.
+func s3ObjectURL(location *url.URL, s3Config *config.S3DataSource) (*url.URL, error) {
+	bucket := location.Host
+	key := strings.TrimPrefix(location.Path, "/")
+
+	if bucket == "" || key == "" {
+		return nil, fmt.Errorf("S3 location %s must be in the format s3://bucket/key", location.String())
+	}
+
+	if s3Config == nil || s3Config.Endpoint == "" {
+		region := config.DefExternalDataSourceS3Region
+		if s3Config != nil && s3Config.Region != "" {
+			region = s3Config.Region
+		}
+
+		return &url.URL{
+			Scheme: httpsScheme,
+			Host:   fmt.Sprintf("%s.s3.%s.amazonaws.com", bucket, region),
+			Path:   "/" + key,
+		}, nil
+	}
+
+	endpoint, err := url.Parse(s3Config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint configured: %w", err)
+	}
+
+	if endpoint.Scheme != httpScheme && endpoint.Scheme != httpsScheme || endpoint.Host == "" {
+		return nil, fmt.Errorf("S3 endpoint %s must be an http or https URL", s3Config.Endpoint)
+	}
+
+	return &url.URL{
+		Scheme: endpoint.Scheme,
+		Host:   endpoint.Host,
+		Path:   strings.TrimSuffix(endpoint.Path, "/") + "/" + bucket + "/" + key,
+	}, nil
+}
+
+// signS3Request signs a request to S3 with AWS Signature Version 4. Requests are sent unsigned if no
+// credentials are configured, so that objects in public buckets can be downloaded.
+func signS3Request(ctx context.Context, req *http.Request, s3Config *config.S3DataSource,
+	credentials aws.Credentials, now time.Time,
+) error {
+	if !credentials.HasKeys() {
+		slog.DebugContext(ctx, "No S3 credentials configured; sending unsigned request", "url", req.URL.String())
+		return nil
+	}
+
+	region := config.DefExternalDataSourceS3Region
+	if s3Config.Region != "" {
+		region = s3Config.Region
+	}
+
+	req.Header.Set(amzContentSHA256Key, emptyPayloadHash)
+
+	if err := v4.NewSigner().SignHTTP(ctx, credentials, req, emptyPayloadHash, s3Service, region, now); err != nil {
+		return fmt.Errorf("failed to sign S3 request: %w", err)
+	}
+
+	return nil
+}
+
+// get returns the S3 credentials, reading the credentials file only if it was not read since the last reset.
+func (c *s3CredentialsCache) get(ctx context.Context, s3Config *config.S3DataSource) (aws.Credentials, error) {
+	if s3Config == nil || s3Config.AccessKeyID != "" || s3Config.CredentialsFile == "" {
+		return s3Credentials(ctx, s3Config)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.loaded && c.credentialsFile == s3Config.CredentialsFile && c.profile == s3Config.Profile {
+		return c.credentials, nil
+	}
+
+	credentials, err := s3Credentials(ctx, s3Config)
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+
+	c.credentials = credentials
+	c.credentialsFile = s3Config.CredentialsFile
+	c.profile = s3Config.Profile
+	c.loaded = true
+
+	return credentials, nil
+}
+
+// reset makes the next request read the credentials file again.
+func (c *s3CredentialsCache) reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.loaded = false
+	c.credentials = aws.Credentials{}
+}
+
+// s3Credentials returns the credentials from the agent config, or else from the profile in the S3 credentials
+// file.
+func s3Credentials(ctx context.Context, s3Config *config.S3DataSource) (aws.Credentials, error) {
+	if s3Config == nil {
+		return aws.Credentials{}, nil
+	}
+
+	if s3Config.AccessKeyID != "" {
+		return aws.Credentials{
+			AccessKeyID:     s3Config.AccessKeyID,
+			SecretAccessKey: s3Config.SecretAccessKey,
+		}, nil
+	}
+
+	if s3Config.CredentialsFile == "" {
+		return aws.Credentials{}, nil
+	}
+
+	profile := config.DefExternalDataSourceS3Profile
+	if s3Config.Profile != "" {
+		profile = s3Config.Profile
+	}
+
+	sharedConfig, err := awsconfig.LoadSharedConfigProfile(ctx, profile,
+		func(options *awsconfig.LoadSharedConfigOptions) {
+			options.CredentialsFiles = []string{s3Config.CredentialsFile}
+			options.ConfigFiles = []string{}
+		},
+	)
+	if err != nil {
+		return aws.Credentials{}, fmt.Errorf("unable to read S3 credentials file %s: %w",
+			s3Config.CredentialsFile, err)
+	}
+
+	if !sharedConfig.Credentials.HasKeys() {
+		return aws.Credentials{}, fmt.Errorf("%w: %s", errS3CredentialsMissing, s3Config.CredentialsFile)
+	}
+
+	return sharedConfig.Credentials, nil
+}
@@ -0,0 +1,244 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	diffContextLines = 3
+	// maxDiffEdits limits the work done to diff two files that are very different from each other
+	maxDiffEdits = 1000
+	devNull      = "/dev/null"
+)
+
+type diffOperation struct {
+	line string
+	kind byte
+}
+
+var errDiffTooLarge = errors.New("too many differences to create a diff")
+
+// isTextContent returns true if the content is valid UTF-8 and contains no NUL bytes
+func isTextContent(content []byte) bool {
+	return utf8.Valid(content) && !bytes.Contains(content, []byte{0})
+}
+
+// unifiedDiff returns a unified diff of two versions of a file in the format used by diff -u.
+// An empty old or new name is shown as /dev/null, for files that are added or deleted.
+func unifiedDiff(oldName, newName string, oldContent, newContent []byte) (string, error) {
+	operations, err := diffLines(splitLines(oldContent), splitLines(newContent))
+	if err != nil {
+		return "", err
+	}
+
+	if oldName == "" {
+		oldName = devNull
+	}
+
+	if newName == "" {
+		newName = devNull
+	}
+
+	// line numbers of each operation in the old and new content
+	oldLines := make([]int, len(operations)+1)
+	newLines := make([]int, len(operations)+1)
+	for index, operation := range operations {
+		oldLines[index+1] = oldLines[index]
+		newLines[index+1] = newLines[index]
+		if operation.kind != '+' {
+			oldLines[index+1]++
+		}
+		if operation.kind != '-' {
+			newLines[index+1]++
+		}
+	}
+
+	var diff strings.Builder
+
+	for index := 0; index < len(operations); {
+		for index < len(operations) && operations[index].kind == ' ' {
+			index++
+		}
+
+		if index == len(operations) {
+			break
+		}
+
+		if diff.Len() == 0 {
+			fmt.Fprintf(&diff, "--- %s\n+++ %s\n", oldName, newName)
+		}
+
+		start := max(index-diffContextLines, 0)
+		end := hunkEnd(operations, index)
+
+		fmt.Fprintf(
+			&diff,
+			"@@ -%s +%s @@\n",
+			hunkRange(oldLines[start], oldLines[end]-oldLines[start]),
+			hunkRange(newLines[start], newLines[end]-newLines[start]),
+		)
+
+		for _, operation := range operations[start:end] {
+			diff.WriteByte(operation.kind)
+			diff.WriteString(operation.line)
+
+			if !strings.HasSuffix(operation.line, "\n") {
+				diff.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+
+		index = end
+	}
+
+	return diff.String(), nil
+}
+
+// hunkEnd returns the end of the hunk starting with the change at index. Changes separated by no more than
+// twice the number of context lines are merged into one hunk.
+func hunkEnd(operations []diffOperation, index int) int {
+	for index < len(operations) {
+		if operations[index].kind != ' ' {
+			index++
+			continue
+		}
+
+		next := index
+		for next < len(operations) && operations[next].kind == ' ' {
+			next++
+		}
+
+		if next == len(operations) || next-index > 2*diffContextLines {
+			break
+		}
+
+		index = next
+	}
+
+	return min(index+diffContextLines, len(operations))
+}
+
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
+
+// splitLines splits content into lines, each line keeps its line ending
+func splitLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// editTrace holds the furthest x position reached on each diagonal before a number of edits was made.
+// Only the diagonals that can be reached with that number of edits are stored.
+type editTrace struct {
+	furthest []int
+	edits    int
+}
+
+func (et editTrace) x(diagonal int) int {
+	return et.furthest[diagonal+et.edits+1]
+}
+
+// diffLines finds the shortest edit script between two lists of lines using the Myers diff algorithm
+func diffLines(oldLines, newLines []string) ([]diffOperation, error) {
+	trace, err := shortestEditTrace(oldLines, newLines)
+	if err != nil {
+		return nil, err
+	}
+
+	operations := make([]diffOperation, 0, len(oldLines)+len(newLines))
+	x, y := len(oldLines), len(newLines)
+
+	for edits := len(trace) - 1; edits >= 0; edits-- {
+		furthest := trace[edits]
+		diagonal := x - y
+
+		previousDiagonal := diagonal - 1
+		if diagonal == -edits || (diagonal != edits && furthest.x(diagonal-1) < furthest.x(diagonal+1)) {
+			previousDiagonal = diagonal + 1
+		}
+
+		previousX := furthest.x(previousDiagonal)
+		previousY := previousX - previousDiagonal
+
+		for x > previousX && y > previousY {
+			operations = append(operations, diffOperation{kind: ' ', line: oldLines[x-1]})
+			x--
+			y--
+		}
+
+		if edits == 0 {
+			break
+		}
+
+		if x == previousX {
+			operations = append(operations, diffOperation{kind: '+', line: newLines[y-1]})
+			y--
+		} else {
+			operations = append(operations, diffOperation{kind: '-', line: oldLines[x-1]})
+			x--
+		}
+	}
+
+	slices.Reverse(operations)
+
+	return operations, nil
+}
+
+func shortestEditTrace(oldLines, newLines []string) ([]editTrace, error) {
+	oldLen, newLen := len(oldLines), len(newLines)
+	maxEdits := min(oldLen+newLen, maxDiffEdits)
+	offset := maxEdits + 1
+
+	// furthest x position reached on each diagonal, indexed by diagonal + offset
+	furthest := make([]int, 2*maxEdits+3)
+	trace := make([]editTrace, 0, maxEdits+1)
+
+	for edits := 0; edits <= maxEdits; edits++ {
+		trace = append(trace, editTrace{
+			furthest: slices.Clone(furthest[offset-edits-1 : offset+edits+2]),
+			edits:    edits,
+		})
+
+		for diagonal := -edits; diagonal <= edits; diagonal += 2 {
+			x := furthest[offset+diagonal-1] + 1
+			if diagonal == -edits || (diagonal != edits && furthest[offset+diagonal-1] < furthest[offset+diagonal+1]) {
+				x = furthest[offset+diagonal+1]
+			}
+
+			y := x - diagonal
+			for x < oldLen && y < newLen && oldLines[x] == newLines[y] {
+				x++
+				y++
+			}
+
+			furthest[offset+diagonal] = x
+
+			if x >= oldLen && y >= newLen {
+				return trace, nil
+			}
+		}
+	}
+
+	return nil, errDiffTooLarge
+}
@@ -0,0 +1,158 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package file
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name       string
+		oldName    string
+		newName    string
+		oldContent string
+		newContent string
+		expected   string
+	}{
+		{
+			name:       "Test 1: No changes",
+			oldName:    "/etc/nginx/nginx.conf",
+			newName:    "/etc/nginx/nginx.conf",
+			oldContent: "worker_processes 1;\n",
+			newContent: "worker_processes 1;\n",
+			expected:   "",
+		},
+		{
+			name:       "Test 2: Updated line",
+			oldName:    "/etc/nginx/nginx.conf",
+			newName:    "/etc/nginx/nginx.conf",
+			oldContent: "user nginx;\nworker_processes 1;\nerror_log /var/log/nginx/error.log;\n",
+			newContent: "user nginx;\nworker_processes auto;\nerror_log /var/log/nginx/error.log;\n",
+			expected: "--- /etc/nginx/nginx.conf\n" +
+				"+++ /etc/nginx/nginx.conf\n" +
+				"@@ -1,3 +1,3 @@\n" +
+				" user nginx;\n" +
+				"-worker_processes 1;\n" +
+				"+worker_processes auto;\n" +
+				" error_log /var/log/nginx/error.log;\n",
+		},
+		{
+			name:       "Test 3: Added file",
+			oldName:    "",
+			newName:    "/etc/nginx/conf.d/default.conf",
+			oldContent: "",
+			newContent: "server {\n}\n",
+			expected: "--- /dev/null\n" +
+				"+++ /etc/nginx/conf.d/default.conf\n" +
+				"@@ -0,0 +1,2 @@\n" +
+				"+server {\n" +
+				"+}\n",
+		},
+		{
+			name:       "Test 4: Deleted file",
+			oldName:    "/etc/nginx/conf.d/default.conf",
+			newName:    "",
+			oldContent: "server {}\n",
+			newContent: "",
+			expected: "--- /etc/nginx/conf.d/default.conf\n" +
+				"+++ /dev/null\n" +
+				"@@ -1 +0,0 @@\n" +
+				"-server {}\n",
+		},
+		{
+			name:       "Test 5: No newline at end of file",
+			oldName:    "/etc/nginx/mime.types",
+			newName:    "/etc/nginx/mime.types",
+			oldContent: "types {}",
+			newContent: "types {}\n",
+			expected: "--- /etc/nginx/mime.types\n" +
+				"+++ /etc/nginx/mime.types\n" +
+				"@@ -1 +1 @@\n" +
+				"-types {}\n" +
+				"\\ No newline at end of file\n" +
+				"+types {}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			diff, err := unifiedDiff(test.oldName, test.newName, []byte(test.oldContent), []byte(test.newContent))
+			require.NoError(tt, err)
+			assert.Equal(tt, test.expected, diff)
+		})
+	}
+}
+
+func TestUnifiedDiff_Hunks(t *testing.T) {
+	var oldLines, newLines []string
+	for i := 1; i <= 20; i++ {
+		oldLines = append(oldLines, fmt.Sprintf("line %d\n", i))
+		newLines = append(newLines, fmt.Sprintf("line %d\n", i))
+	}
+
+	// changes close together are merged into one hunk, changes far apart are in separate hunks
+	newLines[1] = "changed 2\n"
+	newLines[6] = "changed 7\n"
+	newLines[17] = "changed 18\n"
+
+	diff, err := unifiedDiff(
+		"/etc/nginx/nginx.conf",
+		"/etc/nginx/nginx.conf",
+		[]byte(strings.Join(oldLines, "")),
+		[]byte(strings.Join(newLines, "")),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "--- /etc/nginx/nginx.conf\n"+
+		"+++ /etc/nginx/nginx.conf\n"+
+		"@@ -1,10 +1,10 @@\n"+
+		" line 1\n"+
+		"-line 2\n"+
+		"+changed 2\n"+
+		" line 3\n"+
+		" line 4\n"+
+		" line 5\n"+
+		" line 6\n"+
+		"-line 7\n"+
+		"+changed 7\n"+
+		" line 8\n"+
+		" line 9\n"+
+		" line 10\n"+
+		"@@ -15,6 +15,6 @@\n"+
+		" line 15\n"+
+		" line 16\n"+
+		" line 17\n"+
+		"-line 18\n"+
+		"+changed 18\n"+
+		" line 19\n"+
+		" line 20\n",
+		diff,
+	)
+}
+
+func TestUnifiedDiff_TooLarge(t *testing.T) {
+	var oldContent, newContent strings.Builder
+	for i := range maxDiffEdits {
+		fmt.Fprintf(&oldContent, "old %d\n", i)
+		fmt.Fprintf(&newContent, "new %d\n", i)
+	}
+
+	_, err := unifiedDiff("/etc/nginx/nginx.conf", "/etc/nginx/nginx.conf",
+		[]byte(oldContent.String()), []byte(newContent.String()))
+	require.ErrorIs(t, err, errDiffTooLarge)
+}
+
+func TestIsTextContent(t *testing.T) {
+	assert.True(t, isTextContent([]byte("worker_processes 1;\n")))
+	assert.False(t, isTextContent([]byte{0x00, 0x01, 0x02}))
+	assert.False(t, isTextContent([]byte{0xff, 0xfe}))
+}
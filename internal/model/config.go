@@ -52,6 +52,16 @@ type ManifestFileMeta struct {
 	Referenced bool `json:"referenced"`
 	// File is not managed by the agent
 	Unmanaged bool `json:"unmanaged"`
+	// The target of a symbolic link, empty if the file is not a symbolic link
+	LinkTarget string `json:"link_target,omitempty"`
+	// File is an empty directory
+	Directory bool `json:"directory,omitempty"`
+	// The hash of the rendered file if the file is a template, in which case Hash is the hash of the template source
+	RenderedHash string `json:"rendered_hash,omitempty"`
+	// The size of the rendered file if the file is a template, in which case Size is the size of the template source
+	RenderedSize int64 `json:"rendered_size,omitempty"`
+	// The hash of the variables the template was rendered with
+	TemplateVariablesHash string `json:"template_variables_hash,omitempty"`
 }
 type ConfigApplyMessage struct {
 	Error         error
@@ -84,6 +94,49 @@ const (
 	OK
 )
 
+// ConfigApplyPhase is a step of a config apply that is recorded in the config apply journal. NGINX validates and
+// reloads the config in a single step, so there is no separate phase for the validation. A config apply is
+// committed by removing it from the journal.
+type ConfigApplyPhase string
+
+const (
+	ConfigApplyStarted    ConfigApplyPhase = "started"
+	ConfigApplyBackedUp   ConfigApplyPhase = "backed_up"
+	ConfigApplyDownloaded ConfigApplyPhase = "downloaded"
+	ConfigApplyMoved      ConfigApplyPhase = "moved"
+	ConfigApplyReloaded   ConfigApplyPhase = "reloaded"
+)
+
+// ConfigApplyRecovery is the outcome of recovering a config apply that was interrupted by an agent restart
+type ConfigApplyRecovery struct {
+	Error         error
+	CorrelationID string
+	InstanceID    string
+	Phase         ConfigApplyPhase
+	// the config was reloaded before the restart, so the config apply was completed instead of rolled back
+	Completed bool
+	// the files were rolled back after NGINX might have loaded them, so NGINX needs to be reloaded
+	ReloadRequired bool
+}
+
+// ConfigApplyHookPhase is a phase of a config apply at which the configured hooks are run
+type ConfigApplyHookPhase string
+
+const (
+	HookPreValidate  ConfigApplyHookPhase = "pre_validate"
+	HookPreReload    ConfigApplyHookPhase = "pre_reload"
+	HookPostReload   ConfigApplyHookPhase = "post_reload"
+	HookPostRollback ConfigApplyHookPhase = "post_rollback"
+)
+
+// ConfigApplyHooks describes the config apply that hooks are run for and collects the results of the hooks
+type ConfigApplyHooks struct {
+	CorrelationID string
+	InstanceID    string
+	ChangedFiles  []string
+	Results       []*v1.ConfigApplyHookResult
+}
+
 type ReloadSuccess struct {
 	ConfigContext     *NginxConfigContext
 	DataPlaneResponse *v1.DataPlaneResponse
@@ -94,6 +147,12 @@ type EnableWatchers struct {
 	InstanceID    string
 }
 
+// ExternalFileRefresh is a refresh of the external files of an instance that are due to be downloaded again
+type ExternalFileRefresh struct {
+	InstanceID string
+	Files      []*v1.File
+}
+
 //nolint:revive,cyclop // cyclomatic complexity is 16
 func (ncc *NginxConfigContext) Equal(otherNginxConfigContext *NginxConfigContext) bool {
 	if ncc.StubStatus != nil && otherNginxConfigContext.StubStatus != nil {
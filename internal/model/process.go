@@ -12,11 +12,14 @@ import (
 )
 
 type ProcessInfo struct {
-	ConfigureArgs   map[string]interface{}
-	Version         string
-	Prefix          string
-	ConfPath        string
-	ExePath         string
+	ConfigureArgs map[string]interface{}
+	Version       string
+	Prefix        string
+	ConfPath      string
+	ExePath       string
+	// root directory of a process in another container, see nginx.RootPath
+	RootPath        string
+	ContainerID     string
 	LoadableModules []string
 	DynamicModules  []string
 	ProcessID       int32
@@ -0,0 +1,257 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package nginx
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/bus"
+	"github.com/nginx/agent/v3/internal/config"
+	"github.com/nginx/agent/v3/internal/logger"
+	"github.com/nginx/agent/v3/internal/model"
+)
+
+// externalFileRefreshFrequency is how often the schedule is checked for external files that are due to be refreshed
+const externalFileRefreshFrequency = 5 * time.Second
+
+type (
+	// externalFileRefreshSchedule keeps track of when the external files of the last applied config of each
+	// instance are due to be downloaded again, and when NGINX was last reloaded for each instance, so that a
+	// refresh doesn't reload NGINX more often than the minimum reload interval. The zero value is an empty schedule.
+	externalFileRefreshSchedule struct {
+		files       map[string]*externalFileRefresh // key is file path
+		lastReloads map[string]time.Time            // key is instance ID
+		mutex       sync.Mutex
+	}
+
+	externalFileRefresh struct {
+		nextRefresh time.Time
+		file        *mpi.File
+		instanceID  string
+		interval    time.Duration
+	}
+)
+
+// update replaces the external files of an instance with the ones with a refresh interval in the file overview.
+// Refresh intervals shorter than the minimum refresh interval are increased to the minimum.
+func (s *externalFileRefreshSchedule) update(overview *mpi.FileOverview, minInterval time.Duration, now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instanceID := overview.GetConfigVersion().GetInstanceId()
+
+	for fileName, refresh := range s.files {
+		if refresh.instanceID == instanceID {
+			delete(s.files, fileName)
+		}
+	}
+
+	for _, file := range overview.GetFiles() {
+		refreshInterval := file.GetExternalDataSource().GetRefreshInterval()
+		if refreshInterval == nil || refreshInterval.AsDuration() <= 0 {
+			continue
+		}
+
+		if s.files == nil {
+			s.files = make(map[string]*externalFileRefresh)
+		}
+
+		interval := max(refreshInterval.AsDuration(), minInterval)
+		s.files[file.GetFileMeta().GetName()] = &externalFileRefresh{
+			file:        file,
+			instanceID:  instanceID,
+			interval:    interval,
+			nextRefresh: now.Add(interval),
+		}
+	}
+}
+
+// reloaded records that NGINX was reloaded for an instance
+func (s *externalFileRefreshSchedule) reloaded(instanceID string, now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.lastReloads == nil {
+		s.lastReloads = make(map[string]time.Time)
+	}
+
+	s.lastReloads[instanceID] = now
+}
+
+// due returns the external files that are due to be refreshed, grouped by instance ID, and schedules their
+// next refresh. The refresh of an instance that was reloaded less than the minimum reload interval ago is
+// postponed until the interval has passed, so that the files that become due in the meantime are refreshed
+// with a single reload.
+func (s *externalFileRefreshSchedule) due(now time.Time, minReloadInterval time.Duration) map[string][]*mpi.File {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	dueFiles := make(map[string][]*mpi.File)
+
+	for _, refresh := range s.files {
+		if now.Before(refresh.nextRefresh) {
+			continue
+		}
+
+		if nextReload := s.lastReloads[refresh.instanceID].Add(minReloadInterval); now.Before(nextReload) {
+			refresh.nextRefresh = nextReload
+			continue
+		}
+
+		dueFiles[refresh.instanceID] = append(dueFiles[refresh.instanceID], refresh.file)
+		refresh.nextRefresh = now.Add(refresh.interval)
+	}
+
+	return dueFiles
+}
+
+// updateExternalFileRefreshSchedule schedules the refresh of the external files of an applied config
+func (n *NginxPlugin) updateExternalFileRefreshSchedule(overview *mpi.FileOverview) {
+	minRefreshInterval := config.DefExternalDataSourceMinRefreshInterval
+	if n.agentConfig.ExternalDataSource != nil {
+		minRefreshInterval = n.agentConfig.ExternalDataSource.MinRefreshInterval
+	}
+
+	n.externalFileRefreshSchedule.update(overview, minRefreshInterval, time.Now())
+}
+
+// monitorExternalFileRefresh sends a message to refresh the external files of an instance when they are due.
+// The files are refreshed by the message handler, so that the watcher plugin disables the watchers while the
+// files are refreshed, like during a config apply.
+func (n *NginxPlugin) monitorExternalFileRefresh(ctx context.Context) {
+	ticker := time.NewTicker(externalFileRefreshFrequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			dueFiles := n.externalFileRefreshSchedule.due(time.Now(), n.minReloadInterval())
+			for instanceID, externalFiles := range dueFiles {
+				newCtx := context.WithValue(ctx, logger.CorrelationIDContextKey, logger.GenerateCorrelationID())
+				n.messagePipe.Process(newCtx, &bus.Message{
+					Topic: bus.ExternalFileRefreshTopic,
+					Data:  &model.ExternalFileRefresh{InstanceID: instanceID, Files: externalFiles},
+				})
+			}
+		}
+	}
+}
+
+// minReloadInterval returns the minimum interval between reloads of an instance if reload coalescing is enabled
+func (n *NginxPlugin) minReloadInterval() time.Duration {
+	n.agentConfigMutex.Lock()
+	defer n.agentConfigMutex.Unlock()
+
+	if n.agentConfig.DataPlaneConfig == nil || n.agentConfig.DataPlaneConfig.Nginx == nil ||
+		n.agentConfig.DataPlaneConfig.Nginx.ReloadCoalescing == nil ||
+		!n.agentConfig.DataPlaneConfig.Nginx.ReloadCoalescing.Enabled {
+		return 0
+	}
+
+	return n.agentConfig.DataPlaneConfig.Nginx.ReloadCoalescing.MinInterval
+}
+
+func (n *NginxPlugin) handleExternalFileRefresh(ctx context.Context, msg *bus.Message) {
+	externalFileRefresh, ok := msg.Data.(*model.ExternalFileRefresh)
+	if !ok {
+		slog.ErrorContext(ctx, "Unable to cast message payload to *model.ExternalFileRefresh", "payload",
+			msg.Data, "topic", msg.Topic)
+
+		return
+	}
+
+	n.refreshExternalFiles(ctx, externalFileRefresh.InstanceID, externalFileRefresh.Files)
+}
+
+// refreshExternalFiles downloads the external files of an instance again and reloads NGINX if any of them have
+// changed. The files are rolled back if NGINX fails to reload. A successful refresh is reported to the management
+// plane as an update of the NGINX config, once the watchers are enabled again. A refresh waits for a config apply
+// in progress to finish, since both use the file manager service.
+func (n *NginxPlugin) refreshExternalFiles(ctx context.Context, instanceID string, externalFiles []*mpi.File) {
+	slog.DebugContext(ctx, "Refreshing external files", "instance_id", instanceID)
+
+	n.configApplyMutex.Lock()
+	defer n.configApplyMutex.Unlock()
+
+	configContext := &model.NginxConfigContext{}
+	defer func() {
+		n.enableWatchers(ctx, configContext, instanceID)
+	}()
+
+	rootPath, err := n.instanceRootPath(n.nginxService.Instance(instanceID))
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to refresh external files", "instance_id", instanceID, "error", err)
+		return
+	}
+
+	n.fileManagerService.SetRootPath(rootPath)
+	writeStatus, err := n.fileManagerService.RefreshExternalFiles(ctx, instanceID, externalFiles)
+
+	switch writeStatus {
+	case model.NoChange:
+		slog.DebugContext(ctx, "External files unchanged", "instance_id", instanceID)
+		return
+	case model.Error:
+		slog.ErrorContext(ctx, "Failed to refresh external files", "instance_id", instanceID, "error", err)
+		n.fileManagerService.ClearCache()
+
+		return
+	case model.RollbackRequired:
+		slog.ErrorContext(ctx, "Failed to refresh external files, rolling back", "instance_id", instanceID,
+			"error", err)
+		n.rollbackExternalFileRefresh(ctx, instanceID, false)
+
+		return
+	case model.OK:
+	}
+
+	reloadedConfigContext, err := n.nginxService.ApplyConfig(ctx, instanceID, nil)
+	n.externalFileRefreshSchedule.reloaded(instanceID, time.Now())
+
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to reload NGINX with refreshed external files, rolling back",
+			"instance_id", instanceID, "error", err)
+		n.rollbackExternalFileRefresh(ctx, instanceID, true)
+
+		return
+	}
+
+	n.fileManagerService.SetConfigApplyPhase(ctx, model.ConfigApplyReloaded)
+	n.fileManagerService.ClearCache()
+
+	slog.InfoContext(ctx, "Reloaded NGINX with refreshed external files", "instance_id", instanceID)
+
+	configContext = reloadedConfigContext
+}
+
+func (n *NginxPlugin) rollbackExternalFileRefresh(ctx context.Context, instanceID string, reload bool) {
+	defer n.fileManagerService.ClearCache()
+
+	if err := n.fileManagerService.Rollback(ctx, instanceID); err != nil {
+		slog.ErrorContext(ctx, "Failed to roll back refreshed external files", "instance_id", instanceID,
+			"error", err)
+
+		return
+	}
+
+	if !reload {
+		return
+	}
+
+	_, err := n.nginxService.ApplyConfig(ctx, instanceID, nil)
+	n.externalFileRefreshSchedule.reloaded(instanceID, time.Now())
+
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to reload NGINX after rolling back refreshed external files",
+			"instance_id", instanceID, "error", err)
+	}
+}
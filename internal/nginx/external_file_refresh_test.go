@@ -0,0 +1,229 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package nginx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/bus"
+	"github.com/nginx/agent/v3/internal/bus/busfakes"
+	"github.com/nginx/agent/v3/internal/file/filefakes"
+	"github.com/nginx/agent/v3/internal/grpc/grpcfakes"
+	"github.com/nginx/agent/v3/internal/model"
+	"github.com/nginx/agent/v3/internal/nginx/nginxfakes"
+	"github.com/nginx/agent/v3/test/protos"
+	"github.com/nginx/agent/v3/test/types"
+)
+
+func externalFile(name string, refreshInterval time.Duration) *mpi.File {
+	file := &mpi.File{
+		FileMeta:           &mpi.FileMeta{Name: name},
+		ExternalDataSource: &mpi.ExternalDataSource{Location: "https://example.com" + name},
+	}
+
+	if refreshInterval > 0 {
+		file.ExternalDataSource.RefreshInterval = durationpb.New(refreshInterval)
+	}
+
+	return file
+}
+
+func TestExternalFileRefreshSchedule(t *testing.T) {
+	now := time.Now()
+
+	overview := protos.FileOverview("/etc/nginx/nginx.conf", "hash")
+	overview.Files = append(overview.Files,
+		externalFile("/etc/nginx/blocklist.conf", time.Minute),
+		externalFile("/etc/nginx/geoip.mmdb", time.Second),
+		externalFile("/etc/nginx/certs.pem", 0),
+	)
+
+	var schedule externalFileRefreshSchedule
+	assert.Empty(t, schedule.due(now, 0))
+
+	schedule.update(overview, 30*time.Second, now)
+	assert.Len(t, schedule.files, 2)
+	assert.Empty(t, schedule.due(now.Add(29*time.Second), 0))
+
+	// the refresh interval of the GeoIP database is increased to the minimum refresh interval
+	dueFiles := schedule.due(now.Add(30*time.Second), 0)
+	require.Len(t, dueFiles[overview.GetConfigVersion().GetInstanceId()], 1)
+	assert.Equal(t, "/etc/nginx/geoip.mmdb",
+		dueFiles[overview.GetConfigVersion().GetInstanceId()][0].GetFileMeta().GetName())
+
+	dueFiles = schedule.due(now.Add(time.Minute), 0)
+	assert.Len(t, dueFiles[overview.GetConfigVersion().GetInstanceId()], 2)
+	assert.Empty(t, schedule.due(now.Add(time.Minute), 0))
+
+	// external files removed from the config are no longer refreshed
+	overview.Files = overview.GetFiles()[:1]
+	schedule.update(overview, 30*time.Second, now)
+	assert.Empty(t, schedule.files)
+}
+
+func TestExternalFileRefreshSchedule_MinReloadInterval(t *testing.T) {
+	now := time.Now()
+	instanceID := protos.FileOverview("/etc/nginx/nginx.conf", "hash").GetConfigVersion().GetInstanceId()
+
+	overview := protos.FileOverview("/etc/nginx/nginx.conf", "hash")
+	overview.Files = append(overview.Files,
+		externalFile("/etc/nginx/blocklist.conf", time.Minute),
+		externalFile("/etc/nginx/geoip.mmdb", 2*time.Minute),
+	)
+
+	var schedule externalFileRefreshSchedule
+	schedule.update(overview, time.Minute, now)
+	schedule.reloaded(instanceID, now.Add(50*time.Second))
+
+	// the refresh is postponed until the minimum reload interval since the last reload has passed
+	assert.Empty(t, schedule.due(now.Add(time.Minute), 30*time.Second))
+	assert.Empty(t, schedule.due(now.Add(79*time.Second), 30*time.Second))
+	assert.Len(t, schedule.due(now.Add(80*time.Second), 30*time.Second)[instanceID], 1)
+
+	// files that become due while the refresh is postponed are refreshed together
+	schedule.reloaded(instanceID, now.Add(110*time.Second))
+	assert.Empty(t, schedule.due(now.Add(2*time.Minute), 30*time.Second))
+	assert.Len(t, schedule.due(now.Add(140*time.Second), 30*time.Second)[instanceID], 2)
+}
+
+func TestNginxPlugin_refreshExternalFiles(t *testing.T) {
+	ctx := context.Background()
+	instanceID := protos.NginxOssInstance([]string{}).GetInstanceMeta().GetInstanceId()
+	externalFiles := []*mpi.File{externalFile("/etc/nginx/blocklist.conf", time.Minute)}
+
+	tests := []struct {
+		applyConfigErr           error
+		refreshErr               error
+		name                     string
+		writeStatus              model.WriteStatus
+		expectedApplyConfigCalls int
+		expectedRollbackCalls    int
+		expectedConfigContext    bool
+	}{
+		{
+			name:        "Test 1: external files unchanged",
+			writeStatus: model.NoChange,
+		},
+		{
+			name:                     "Test 2: external files changed",
+			writeStatus:              model.OK,
+			expectedApplyConfigCalls: 1,
+			expectedConfigContext:    true,
+		},
+		{
+			name:                     "Test 3: NGINX fails to reload",
+			writeStatus:              model.OK,
+			applyConfigErr:           errors.New("failed validating config"),
+			expectedApplyConfigCalls: 2,
+			expectedRollbackCalls:    1,
+		},
+		{
+			name:                  "Test 4: files fail to move",
+			writeStatus:           model.RollbackRequired,
+			refreshErr:            errors.New("failed to rename file"),
+			expectedRollbackCalls: 1,
+		},
+		{
+			name:        "Test 5: download fails",
+			writeStatus: model.Error,
+			refreshErr:  errors.New("download failed with status code 404"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			fakeNginxService := &nginxfakes.FakeNginxServiceInterface{}
+			fakeNginxService.ApplyConfigReturns(&model.NginxConfigContext{InstanceID: instanceID},
+				test.applyConfigErr)
+
+			fakeFileManagerService := &filefakes.FakeFileManagerServiceInterface{}
+			fakeFileManagerService.RefreshExternalFilesReturns(test.writeStatus, test.refreshErr)
+
+			messagePipe := busfakes.NewFakeMessagePipe()
+			nginxPlugin := NewNginx(types.AgentConfig(), &grpcfakes.FakeGrpcConnectionInterface{}, model.Command,
+				&sync.RWMutex{})
+			require.NoError(tt, nginxPlugin.Init(ctx, messagePipe))
+			nginxPlugin.fileManagerService = fakeFileManagerService
+			nginxPlugin.nginxService = fakeNginxService
+
+			nginxPlugin.refreshExternalFiles(ctx, instanceID, externalFiles)
+
+			_, refreshedInstanceID, refreshedFiles := fakeFileManagerService.RefreshExternalFilesArgsForCall(0)
+			assert.Equal(tt, instanceID, refreshedInstanceID)
+			assert.Equal(tt, externalFiles, refreshedFiles)
+
+			assert.Equal(tt, test.expectedApplyConfigCalls, fakeNginxService.ApplyConfigCallCount())
+			assert.Equal(tt, test.expectedRollbackCalls, fakeFileManagerService.RollbackCallCount())
+
+			// the watchers are enabled again, with the reloaded config if the refresh was successful
+			messages := messagePipe.Messages()
+			require.Len(tt, messages, 1)
+			assert.Equal(tt, bus.EnableWatchersTopic, messages[0].Topic)
+
+			enableWatchers, ok := messages[0].Data.(*model.EnableWatchers)
+			require.True(tt, ok)
+			assert.Equal(tt, instanceID, enableWatchers.InstanceID)
+
+			if test.expectedConfigContext {
+				assert.Equal(tt, instanceID, enableWatchers.ConfigContext.InstanceID)
+			} else {
+				assert.Empty(tt, enableWatchers.ConfigContext.InstanceID)
+			}
+
+			if test.writeStatus != model.NoChange {
+				assert.Equal(tt, 1, fakeFileManagerService.ClearCacheCallCount())
+			}
+		})
+	}
+}
+
+func TestNginxPlugin_refreshExternalFiles_WaitsForConfigApply(t *testing.T) {
+	ctx := context.Background()
+	instanceID := protos.NginxOssInstance([]string{}).GetInstanceMeta().GetInstanceId()
+
+	fakeFileManagerService := &filefakes.FakeFileManagerServiceInterface{}
+	fakeFileManagerService.RefreshExternalFilesReturns(model.NoChange, nil)
+
+	nginxPlugin := NewNginx(types.AgentConfig(), &grpcfakes.FakeGrpcConnectionInterface{}, model.Command,
+		&sync.RWMutex{})
+	require.NoError(t, nginxPlugin.Init(ctx, busfakes.NewFakeMessagePipe()))
+	nginxPlugin.fileManagerService = fakeFileManagerService
+	nginxPlugin.nginxService = &nginxfakes.FakeNginxServiceInterface{}
+
+	// config apply in progress
+	nginxPlugin.configApplyMutex.Lock()
+
+	refreshed := make(chan struct{})
+	go func() {
+		nginxPlugin.refreshExternalFiles(ctx, instanceID,
+			[]*mpi.File{externalFile("/etc/nginx/blocklist.conf", time.Minute)})
+		close(refreshed)
+	}()
+
+	assert.Never(t, func() bool {
+		return fakeFileManagerService.RefreshExternalFilesCallCount() > 0 ||
+			fakeFileManagerService.SetRootPathCallCount() > 0
+	}, 100*time.Millisecond, 10*time.Millisecond)
+
+	nginxPlugin.configApplyMutex.Unlock()
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("external files were not refreshed after the config apply finished")
+	}
+
+	assert.Equal(t, 1, fakeFileManagerService.RefreshExternalFilesCallCount())
+}
@@ -0,0 +1,176 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package nginx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/config"
+	"github.com/nginx/agent/v3/internal/model"
+)
+
+const (
+	// maxHookOutputSize limits how much of the output of a hook is reported to the management plane
+	maxHookOutputSize = 64 * 1024
+
+	hookPhaseEnv         = "NGINX_AGENT_HOOK_PHASE"
+	hookInstanceIDEnv    = "NGINX_AGENT_INSTANCE_ID"
+	hookCorrelationIDEnv = "NGINX_AGENT_CORRELATION_ID"
+	// the changed files are separated by new lines
+	hookChangedFilesEnv = "NGINX_AGENT_CHANGED_FILES"
+
+	// defaultHookPath is used if the agent itself is started without a PATH
+	defaultHookPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+)
+
+type NginxHookOperator struct {
+	agentConfig *config.Config
+}
+
+var _ hookOperator = (*NginxHookOperator)(nil)
+
+func NewHookOperator(agentConfig *config.Config) *NginxHookOperator {
+	return &NginxHookOperator{
+		agentConfig: agentConfig,
+	}
+}
+
+// Run runs the hooks configured for a phase of a config apply one after another and adds their results to the
+// config apply hooks. The hooks after a failed hook are not run.
+func (h *NginxHookOperator) Run(ctx context.Context, phase model.ConfigApplyHookPhase,
+	hooks *model.ConfigApplyHooks,
+) error {
+	for _, path := range h.paths(phase) {
+		slog.InfoContext(ctx, "Running config apply hook", "phase", phase, "path", path)
+
+		result, err := h.runHook(ctx, phase, path, hooks)
+		hooks.Results = append(hooks.Results, result)
+
+		if err != nil {
+			return fmt.Errorf("%s hook %s failed: %w", phase, path, err)
+		}
+
+		slog.DebugContext(ctx, "Config apply hook successful", "phase", phase, "path", path,
+			"output", result.GetOutput())
+	}
+
+	return nil
+}
+
+func (h *NginxHookOperator) runHook(ctx context.Context, phase model.ConfigApplyHookPhase, path string,
+	hooks *model.ConfigApplyHooks,
+) (*mpi.ConfigApplyHookResult, error) {
+	result := &mpi.ConfigApplyHookResult{
+		Phase:    string(phase),
+		Path:     path,
+		ExitCode: -1,
+	}
+
+	if !filepath.IsAbs(path) {
+		return result, errors.New("hook is not in the allowed directories")
+	}
+
+	// a symlink in the allowed directories must not run an executable outside of them
+	resolvedPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return result, fmt.Errorf("unable to resolve hook: %w", err)
+	}
+
+	if !h.agentConfig.IsDirectoryAllowed(resolvedPath) {
+		return result, errors.New("hook is not in the allowed directories")
+	}
+
+	timeout := h.agentConfig.DataPlaneConfig.Nginx.Hooks.Timeout
+	if timeout <= 0 {
+		timeout = config.DefNginxHooksTimeout
+	}
+
+	hookCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(hookCtx, resolvedPath)
+	cmd.Env = hookEnv(phase, hooks)
+	// don't wait for processes started by the hook that keep the output open after the hook is stopped
+	cmd.WaitDelay = time.Second
+
+	output, err := cmd.CombinedOutput()
+	result.Output = truncateHookOutput(output)
+
+	if hookCtx.Err() != nil {
+		return result, fmt.Errorf("hook timed out after %s", timeout)
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		result.ExitCode = int32(exitErr.ExitCode()) //nolint:gosec // exit codes are in the range of an int32
+
+		return result, fmt.Errorf("exit code %d: %s", result.GetExitCode(), result.GetOutput())
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	result.ExitCode = 0
+
+	return result, nil
+}
+
+// hookEnv returns the environment of a hook. Hooks don't inherit the environment of the agent, since it can
+// contain credentials, so only the PATH and the hook variables are set.
+func hookEnv(phase model.ConfigApplyHookPhase, hooks *model.ConfigApplyHooks) []string {
+	path := os.Getenv("PATH")
+	if path == "" {
+		path = defaultHookPath
+	}
+
+	return []string{
+		"PATH=" + path,
+		hookPhaseEnv + "=" + string(phase),
+		hookInstanceIDEnv + "=" + hooks.InstanceID,
+		hookCorrelationIDEnv + "=" + hooks.CorrelationID,
+		hookChangedFilesEnv + "=" + strings.Join(hooks.ChangedFiles, "\n"),
+	}
+}
+
+func (h *NginxHookOperator) paths(phase model.ConfigApplyHookPhase) []string {
+	if h.agentConfig.DataPlaneConfig == nil || h.agentConfig.DataPlaneConfig.Nginx == nil ||
+		h.agentConfig.DataPlaneConfig.Nginx.Hooks == nil {
+		return nil
+	}
+
+	hooksConfig := h.agentConfig.DataPlaneConfig.Nginx.Hooks
+
+	switch phase {
+	case model.HookPreValidate:
+		return hooksConfig.PreValidate
+	case model.HookPreReload:
+		return hooksConfig.PreReload
+	case model.HookPostReload:
+		return hooksConfig.PostReload
+	case model.HookPostRollback:
+		return hooksConfig.PostRollback
+	default:
+		return nil
+	}
+}
+
+func truncateHookOutput(output []byte) string {
+	if len(output) <= maxHookOutputSize {
+		return strings.TrimSpace(string(output))
+	}
+
+	return strings.TrimSpace(string(output[:maxHookOutputSize])) + "\n... output truncated"
+}
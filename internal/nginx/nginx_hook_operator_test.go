@@ -0,0 +1,163 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package nginx
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nginx/agent/v3/internal/config"
+	"github.com/nginx/agent/v3/internal/model"
+	"github.com/nginx/agent/v3/test/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNginxHookOperator_Run(t *testing.T) {
+	ctx := context.Background()
+	// hooks are checked after symlinks are resolved, the temp directory can be a symlink
+	hooksDir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	envHook := createHook(t, hooksDir, "env.sh", "#!/bin/sh\n"+
+		"echo \"$NGINX_AGENT_HOOK_PHASE $NGINX_AGENT_INSTANCE_ID $NGINX_AGENT_CORRELATION_ID\"\n"+
+		"echo \"$NGINX_AGENT_CHANGED_FILES\"\n")
+	failedHook := createHook(t, hooksDir, "failed.sh", "#!/bin/sh\necho \"unable to drain\" >&2\nexit 3\n")
+	slowHook := createHook(t, hooksDir, "slow.sh", "#!/bin/sh\nsleep 10\n")
+	notAllowedHook := createHook(t, t.TempDir(), "not_allowed.sh", "#!/bin/sh\n")
+	agentEnvHook := createHook(t, hooksDir, "agent_env.sh", "#!/bin/sh\n"+
+		"echo \"secret=$NGINX_AGENT_HOOK_TEST_SECRET\"\n"+
+		"command -v sh > /dev/null && echo \"path set\"\n")
+	symlinkHook := filepath.Join(hooksDir, "symlink.sh")
+	require.NoError(t, os.Symlink(envHook, symlinkHook))
+	symlinkOutsideHook := filepath.Join(hooksDir, "symlink_outside.sh")
+	require.NoError(t, os.Symlink(notAllowedHook, symlinkOutsideHook))
+	missingHook := filepath.Join(hooksDir, "missing.sh")
+
+	t.Setenv("NGINX_AGENT_HOOK_TEST_SECRET", "secret")
+
+	tests := []struct {
+		name              string
+		hooks             []string
+		expectedError     string
+		expectedExitCodes []int32
+		expectedOutputs   []string
+	}{
+		{
+			name: "Test 1: No hooks",
+		},
+		{
+			name:              "Test 2: Successful hook",
+			hooks:             []string{envHook},
+			expectedExitCodes: []int32{0},
+			expectedOutputs:   []string{"pre_reload instance-id correlation-id\n/etc/nginx/a.conf\n/etc/nginx/b.conf"},
+		},
+		{
+			name:              "Test 3: Failed hook stops the remaining hooks",
+			hooks:             []string{failedHook, envHook},
+			expectedError:     "pre_reload hook " + failedHook + " failed: exit code 3: unable to drain",
+			expectedExitCodes: []int32{3},
+			expectedOutputs:   []string{"unable to drain"},
+		},
+		{
+			name:              "Test 4: Hook times out",
+			hooks:             []string{slowHook},
+			expectedError:     "pre_reload hook " + slowHook + " failed: hook timed out after 100ms",
+			expectedExitCodes: []int32{-1},
+			expectedOutputs:   []string{""},
+		},
+		{
+			name:              "Test 5: Hook outside allowed directories",
+			hooks:             []string{notAllowedHook},
+			expectedError:     "pre_reload hook " + notAllowedHook + " failed: hook is not in the allowed directories",
+			expectedExitCodes: []int32{-1},
+			expectedOutputs:   []string{""},
+		},
+		{
+			name:              "Test 6: Hook does not inherit the agent environment",
+			hooks:             []string{agentEnvHook},
+			expectedExitCodes: []int32{0},
+			expectedOutputs:   []string{"secret=\npath set"},
+		},
+		{
+			name:              "Test 7: Symlink to a hook in the allowed directories",
+			hooks:             []string{symlinkHook},
+			expectedExitCodes: []int32{0},
+			expectedOutputs:   []string{"pre_reload instance-id correlation-id\n/etc/nginx/a.conf\n/etc/nginx/b.conf"},
+		},
+		{
+			name:  "Test 8: Symlink to a hook outside allowed directories",
+			hooks: []string{symlinkOutsideHook},
+			expectedError: "pre_reload hook " + symlinkOutsideHook +
+				" failed: hook is not in the allowed directories",
+			expectedExitCodes: []int32{-1},
+			expectedOutputs:   []string{""},
+		},
+		{
+			name:  "Test 9: Hook does not exist",
+			hooks: []string{missingHook},
+			expectedError: "pre_reload hook " + missingHook + " failed: unable to resolve hook: lstat " +
+				missingHook + ": no such file or directory",
+			expectedExitCodes: []int32{-1},
+			expectedOutputs:   []string{""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			agentConfig := types.AgentConfig()
+			agentConfig.AllowedDirectories = []string{hooksDir}
+			agentConfig.DataPlaneConfig.Nginx.Hooks = &config.NginxHooks{
+				PreReload: test.hooks,
+				PostReload: []string{
+					createHook(tt, hooksDir, "post_reload.sh", "#!/bin/sh\nexit 1\n"),
+				},
+				Timeout: 100 * time.Millisecond,
+			}
+
+			hooks := &model.ConfigApplyHooks{
+				CorrelationID: "correlation-id",
+				InstanceID:    "instance-id",
+				ChangedFiles:  []string{"/etc/nginx/a.conf", "/etc/nginx/b.conf"},
+			}
+
+			runErr := NewHookOperator(agentConfig).Run(ctx, model.HookPreReload, hooks)
+			if test.expectedError == "" {
+				require.NoError(tt, runErr)
+			} else {
+				require.EqualError(tt, runErr, test.expectedError)
+			}
+
+			require.Len(tt, hooks.Results, len(test.expectedExitCodes))
+
+			for i, result := range hooks.Results {
+				assert.Equal(tt, string(model.HookPreReload), result.GetPhase())
+				assert.Equal(tt, test.hooks[i], result.GetPath())
+				assert.Equal(tt, test.expectedExitCodes[i], result.GetExitCode())
+				assert.Equal(tt, test.expectedOutputs[i], result.GetOutput())
+			}
+		})
+	}
+}
+
+func TestTruncateHookOutput(t *testing.T) {
+	assert.Equal(t, "output", truncateHookOutput([]byte("output\n")))
+
+	output := truncateHookOutput(make([]byte, maxHookOutputSize+1))
+	assert.Len(t, output, maxHookOutputSize+len("\n... output truncated"))
+}
+
+func createHook(t *testing.T, dir, name, script string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o700))
+
+	return path
+}
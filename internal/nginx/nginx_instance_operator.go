@@ -16,6 +16,7 @@ import (
 
 	"github.com/cenkalti/backoff/v7"
 	backoffHelpers "github.com/nginx/agent/v3/internal/backoff"
+	"github.com/nginx/agent/v3/internal/datasource/nginx"
 	"github.com/nginx/agent/v3/pkg/nginxprocess"
 
 	"github.com/nginx/agent/v3/pkg/host/exec"
@@ -48,7 +49,13 @@ func (i *NginxInstanceOperator) Validate(ctx context.Context, instance *mpi.Inst
 	slog.InfoContext(ctx, "Validating NGINX configuration")
 	exePath := instance.GetInstanceRuntime().GetBinaryPath()
 
-	out, err := i.executer.RunCmd(ctx, filepath.Clean(exePath), "-t")
+	rootPath, err := instanceRootPath(i.agentConfig, instance)
+	if err != nil {
+		return err
+	}
+
+	name, args := nginx.Command(rootPath, filepath.Clean(exePath), "-t")
+	out, err := i.executer.RunCmd(ctx, name, args...)
 	if err != nil {
 		return fmt.Errorf("NGINX config test failed %w: %s", err, out)
 	}
@@ -63,11 +70,50 @@ func (i *NginxInstanceOperator) Validate(ctx context.Context, instance *mpi.Inst
 	return nil
 }
 
+// ValidateConfigFile tests the NGINX configuration in the given file instead of the configuration of the instance
+// and returns the output of the configuration test. The file of an instance in another container must be in the
+// root directory of the instance.
+func (i *NginxInstanceOperator) ValidateConfigFile(ctx context.Context, instance *mpi.Instance,
+	configPath string,
+) (string, error) {
+	slog.InfoContext(ctx, "Validating NGINX configuration file", "config_path", configPath)
+	exePath := instance.GetInstanceRuntime().GetBinaryPath()
+
+	rootPath, err := instanceRootPath(i.agentConfig, instance)
+	if err != nil {
+		return "", err
+	}
+
+	configPathInRoot, err := nginx.PathInRoot(rootPath, configPath)
+	if err != nil {
+		return "", fmt.Errorf("unable to validate configuration file of NGINX instance: %w", err)
+	}
+
+	name, args := nginx.Command(rootPath, filepath.Clean(exePath), "-t", "-c", configPathInRoot)
+	out, err := i.executer.RunCmd(ctx, name, args...)
+	if err != nil {
+		return out.String(), fmt.Errorf("NGINX config test failed %w: %s", err, out)
+	}
+
+	err = i.validateConfigCheckResponse(out.Bytes())
+	if err != nil {
+		return out.String(), err
+	}
+
+	slog.InfoContext(ctx, "NGINX configuration file tested", "output", out)
+
+	return out.String(), nil
+}
+
 func (i *NginxInstanceOperator) Reload(ctx context.Context, instance *mpi.Instance) error {
 	var createdTime time.Time
 	var errorsFound error
 	pid := instance.GetInstanceRuntime().GetProcessId()
 
+	if err := checkDeclaredInstanceProcess(i.agentConfig, instance); err != nil {
+		return fmt.Errorf("unable to reload NGINX: %w", err)
+	}
+
 	slog.InfoContext(ctx, "Reloading NGINX master process", "pid", pid)
 
 	workers := i.nginxProcessOperator.NginxWorkerProcesses(ctx, pid)
@@ -123,6 +169,29 @@ func (i *NginxInstanceOperator) Reload(ctx context.Context, instance *mpi.Instan
 	return nil
 }
 
+// instanceRootPath returns the root directory of an instance in another container, see nginx.RootPath, after
+// checking the process of a declared instance with checkDeclaredInstanceProcess.
+func instanceRootPath(agentConfig *config.Config, instance *mpi.Instance) (string, error) {
+	if err := checkDeclaredInstanceProcess(agentConfig, instance); err != nil {
+		return "", err
+	}
+
+	return nginx.RootPath(instance.GetInstanceRuntime().GetProcessId()), nil
+}
+
+// checkDeclaredInstanceProcess returns an error if the process ID of an instance declared in the agent configuration
+// is not a process of the declared NGINX binary. The process ID is read from the PID file of the instance, which is
+// a different process in the PID namespace of the agent if the instance runs in another PID namespace, so the
+// process must not be signalled and its root directory must not be used.
+func checkDeclaredInstanceProcess(agentConfig *config.Config, instance *mpi.Instance) error {
+	runtime := instance.GetInstanceRuntime()
+	if agentConfig.NginxInstance(runtime.GetBinaryPath(), runtime.GetConfigPath()) == nil {
+		return nil
+	}
+
+	return nginx.CheckProcessExe(runtime.GetProcessId(), runtime.GetBinaryPath())
+}
+
 func (i *NginxInstanceOperator) checkWorkers(ctx context.Context, instanceID string, createdTime time.Time,
 	processes []*nginxprocess.Process,
 ) {
@@ -199,6 +268,16 @@ func (i *NginxInstanceOperator) errorLogs(instance *mpi.Instance) (errorLogs []s
 		errorLogs = instance.GetInstanceRuntime().GetNginxRuntimeInfo().GetErrorLogs()
 	}
 
+	// the error logs of an instance in another container are read through the root directory of the instance
+	if rootPath := nginx.RootPath(instance.GetInstanceRuntime().GetProcessId()); rootPath != "" {
+		rootErrorLogs := make([]string, 0, len(errorLogs))
+		for _, errorLog := range errorLogs {
+			rootErrorLogs = append(rootErrorLogs, filepath.Join(rootPath, errorLog))
+		}
+
+		return rootErrorLogs
+	}
+
 	return errorLogs
 }
 
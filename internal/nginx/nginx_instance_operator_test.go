@@ -10,6 +10,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"slices"
 	"sync"
 	"testing"
@@ -133,6 +135,58 @@ func TestInstanceOperator_Validate(t *testing.T) {
 	}
 }
 
+func TestInstanceOperator_ValidateConfigFile(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		out      *bytes.Buffer
+		err      error
+		expected error
+		name     string
+	}{
+		{
+			name:     "Test 1: Validate successful",
+			out:      bytes.NewBufferString("nginx: configuration file test is successful"),
+			err:      nil,
+			expected: nil,
+		},
+		{
+			name:     "Test 2: Validate failed",
+			out:      bytes.NewBufferString("[emerg]"),
+			err:      errors.New("error validating"),
+			expected: fmt.Errorf("NGINX config test failed %w: [emerg]", errors.New("error validating")),
+		},
+		{
+			name:     "Test 3: Validate Config failed",
+			out:      bytes.NewBufferString("nginx [emerg]"),
+			err:      nil,
+			expected: errors.New("error running nginx -t -c:\nnginx [emerg]"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expectedOutput := test.out.String()
+
+			mockExec := &execfakes.FakeExecInterface{}
+			mockExec.RunCmdReturns(test.out, test.err)
+
+			instance := protos.NginxOssInstance([]string{})
+
+			operator := NewInstanceOperator(types.AgentConfig())
+			operator.executer = mockExec
+
+			output, err := operator.ValidateConfigFile(ctx, instance, "/staging/etc/nginx/nginx.conf")
+
+			assert.Equal(t, test.expected, err)
+			assert.Equal(t, expectedOutput, output)
+
+			_, _, args := mockExec.RunCmdArgsForCall(0)
+			assert.Equal(t, []string{"-t", "-c", "/staging/etc/nginx/nginx.conf"}, args)
+		})
+	}
+}
+
 func TestInstanceOperator_Reload(t *testing.T) {
 	ctx := context.Background()
 
@@ -186,6 +240,36 @@ func TestInstanceOperator_Reload(t *testing.T) {
 	}
 }
 
+func TestInstanceOperator_Reload_DeclaredInstance(t *testing.T) {
+	ctx := context.Background()
+
+	// the PID file of the declared instance contains the ID of a process that is not running the NGINX binary,
+	// e.g. because NGINX runs in another PID namespace
+	instance := protos.NginxOssInstance([]string{})
+	instance.GetInstanceRuntime().ProcessId = int32(os.Getpid())
+
+	agentConfig := types.AgentConfig()
+	agentConfig.DataPlaneConfig.Nginx.Instances = []*config.NginxInstance{
+		{
+			BinaryPath: instance.GetInstanceRuntime().GetBinaryPath(),
+			ConfigPath: instance.GetInstanceRuntime().GetConfigPath(),
+			PIDFile:    filepath.Join(t.TempDir(), "nginx.pid"),
+		},
+	}
+
+	mockExec := &execfakes.FakeExecInterface{}
+	operator := NewInstanceOperator(agentConfig)
+	operator.executer = mockExec
+
+	err := operator.Reload(ctx, instance)
+	require.ErrorContains(t, err, "is not running NGINX binary")
+	assert.Equal(t, 0, mockExec.KillProcessCallCount())
+
+	err = operator.Validate(ctx, instance)
+	require.ErrorContains(t, err, "is not running NGINX binary")
+	assert.Equal(t, 0, mockExec.RunCmdCallCount())
+}
+
 func TestInstanceOperator_ReloadAndMonitor(t *testing.T) {
 	ctx := context.Background()
 
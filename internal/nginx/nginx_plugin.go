@@ -10,7 +10,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 	"sync"
+	"time"
 
 	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
 	"github.com/nginx/agent/v3/internal/bus"
@@ -38,7 +40,17 @@ type NginxPlugin struct {
 	manifestLock       *sync.RWMutex
 	conn               grpc.GrpcConnectionInterface
 	fileManagerService file.FileManagerServiceInterface
-	serverType         model.ServerType
+	// config apply interrupted by an agent restart, that is waiting for the instance to be discovered to
+	// reload NGINX with the rolled back config
+	pendingConfigApplyRecovery *model.ConfigApplyRecovery
+	// hooks of the config apply in progress, the results of the hooks are added to the config apply response
+	configApplyHooks *model.ConfigApplyHooks
+	// serializes the handlers that use the file manager service for an instance, since each topic is handled
+	// in its own goroutine: config apply and its rollback, external file refresh, config validate and config diff
+	configApplyMutex *sync.Mutex
+	// external files of the last applied config that are downloaded again periodically
+	externalFileRefreshSchedule externalFileRefreshSchedule
+	serverType                  model.ServerType
 }
 
 type errResponse struct {
@@ -64,6 +76,7 @@ func NewNginx(agentConfig *config.Config, grpcConnection grpc.GrpcConnectionInte
 		serverType:       serverType,
 		manifestLock:     manifestLock,
 		agentConfigMutex: &sync.Mutex{},
+		configApplyMutex: &sync.Mutex{},
 	}
 }
 
@@ -78,6 +91,11 @@ func (n *NginxPlugin) Init(ctx context.Context, messagePipe bus.MessagePipeInter
 	n.nginxService = NewNginxService(ctx, n.agentConfig)
 	n.fileManagerService = file.NewFileManagerService(n.conn.FileServiceClient(), n.agentConfig, n.manifestLock)
 
+	if n.serverType == model.Command {
+		n.recoverConfigApply(ctx)
+		go n.monitorExternalFileRefresh(ctx)
+	}
+
 	return nil
 }
 
@@ -125,6 +143,10 @@ func (n *NginxPlugin) Process(ctx context.Context, msg *bus.Message) {
 		n.nginxService.UpdateResource(ctx, resourceUpdate)
 		slog.DebugContext(ctx, "Nginx plugin received update resource message")
 
+		if n.pendingConfigApplyRecovery != nil {
+			n.reloadRecoveredConfigApply(ctxWithMetadata)
+		}
+
 		return
 	case bus.APIActionRequestTopic:
 		n.handleAPIActionRequest(ctx, msg)
@@ -149,6 +171,26 @@ func (n *NginxPlugin) Process(ctx context.Context, msg *bus.Message) {
 		if logger.ServerType(ctxWithMetadata) == n.serverType.String() {
 			n.handleConfigApplyRequest(ctxWithMetadata, msg)
 		}
+	case bus.ConfigValidateRequestTopic:
+		if logger.ServerType(ctxWithMetadata) == n.serverType.String() {
+			n.handleConfigValidateRequest(ctxWithMetadata, msg)
+		}
+	case bus.ConfigDiffRequestTopic:
+		if logger.ServerType(ctxWithMetadata) == n.serverType.String() {
+			n.handleConfigDiffRequest(ctxWithMetadata, msg)
+		}
+	case bus.ConfigHistoryRequestTopic:
+		if logger.ServerType(ctxWithMetadata) == n.serverType.String() {
+			n.handleConfigHistoryRequest(ctxWithMetadata, msg)
+		}
+	case bus.ExternalFileRefreshTopic:
+		if logger.ServerType(ctxWithMetadata) == n.serverType.String() {
+			n.handleExternalFileRefresh(ctxWithMetadata, msg)
+		}
+	case bus.CredentialUpdatedTopic:
+		if logger.ServerType(ctxWithMetadata) == n.serverType.String() {
+			n.fileManagerService.ResetExternalDataSourceCredentials(ctxWithMetadata)
+		}
 	default:
 		slog.DebugContext(ctx, "NGINX plugin received message with unknown topic", "topic", msg.Topic)
 	}
@@ -161,11 +203,19 @@ func (n *NginxPlugin) Subscriptions() []string {
 		bus.ConnectionCreatedTopic,
 		bus.NginxConfigUpdateTopic,
 		bus.ConfigUploadRequestTopic,
+		bus.ConfigDiffRequestTopic,
 		bus.ResourceUpdateTopic,
 	}
 
 	if n.serverType == model.Command {
-		subscriptions = append(subscriptions, bus.ConfigApplyRequestTopic)
+		subscriptions = append(
+			subscriptions,
+			bus.ConfigApplyRequestTopic,
+			bus.ConfigValidateRequestTopic,
+			bus.ConfigHistoryRequestTopic,
+			bus.ExternalFileRefreshTopic,
+			bus.CredentialUpdatedTopic,
+		)
 	}
 
 	return subscriptions
@@ -199,7 +249,14 @@ func (n *NginxPlugin) handleConfigUploadRequest(ctx context.Context, msg *bus.Me
 
 	correlationID := logger.CorrelationID(ctx)
 
-	updatingFilesError := n.fileManagerService.ConfigUpload(ctx, configUploadRequest)
+	instanceID := configUploadRequest.GetOverview().GetConfigVersion().GetInstanceId()
+
+	var updatingFilesError error
+	if instance := n.nginxService.Instance(instanceID); isUnitInstance(instance) {
+		updatingFilesError = n.uploadUnitConfig(ctx, instance)
+	} else {
+		updatingFilesError = n.fileManagerService.ConfigUpload(ctx, configUploadRequest)
+	}
 
 	dataplaneResponse := &mpi.DataPlaneResponse{
 		MessageMeta: &mpi.MessageMeta{
@@ -211,7 +268,7 @@ func (n *NginxPlugin) handleConfigUploadRequest(ctx context.Context, msg *bus.Me
 			Status:  mpi.CommandResponse_COMMAND_STATUS_OK,
 			Message: "Successfully updated all files",
 		},
-		InstanceId:  configUploadRequest.GetOverview().GetConfigVersion().GetInstanceId(),
+		InstanceId:  instanceID,
 		RequestType: mpi.DataPlaneResponse_CONFIG_UPLOAD_REQUEST,
 	}
 
@@ -356,6 +413,9 @@ func (n *NginxPlugin) handleNginxPlusActionRequest(ctx context.Context,
 func (n *NginxPlugin) handleConfigApplyRequest(ctx context.Context, msg *bus.Message) {
 	slog.DebugContext(ctx, "Nginx plugin received config apply request message")
 
+	n.configApplyMutex.Lock()
+	defer n.configApplyMutex.Unlock()
+
 	var dataplaneResponse *mpi.DataPlaneResponse
 	correlationID := logger.CorrelationID(ctx)
 
@@ -377,7 +437,25 @@ func (n *NginxPlugin) handleConfigApplyRequest(ctx context.Context, msg *bus.Mes
 	configApplyRequest := request.ConfigApplyRequest
 	instanceID := configApplyRequest.GetOverview().GetConfigVersion().GetInstanceId()
 
-	writeStatus, err := n.fileManagerService.ConfigApply(ctx, configApplyRequest)
+	instance := n.nginxService.Instance(instanceID)
+	if isUnitInstance(instance) {
+		n.applyUnitConfig(ctx, correlationID, instance, configApplyRequest.GetOverview())
+		return
+	}
+
+	writeStatus := model.Error
+	rootPath, err := n.instanceRootPath(instance)
+	if err == nil {
+		n.fileManagerService.SetTemplateData(n.nginxService.TemplateData(instanceID))
+		n.fileManagerService.SetRootPath(rootPath)
+		writeStatus, err = n.fileManagerService.ConfigApply(ctx, configApplyRequest)
+	}
+
+	n.configApplyHooks = &model.ConfigApplyHooks{
+		CorrelationID: correlationID,
+		InstanceID:    instanceID,
+		ChangedFiles:  n.fileManagerService.ChangedFiles(),
+	}
 
 	switch writeStatus {
 	case model.NoChange:
@@ -391,6 +469,8 @@ func (n *NginxPlugin) handleConfigApplyRequest(ctx context.Context, msg *bus.Mes
 			mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST,
 			instanceID,
 		)
+		dataplaneResponse.RenderedFiles = renderedFiles(configApplyRequest.GetOverview())
+		n.updateExternalFileRefreshSchedule(configApplyRequest.GetOverview())
 		n.completeConfigApply(ctx, &model.NginxConfigContext{}, dataplaneResponse)
 	case model.Error:
 		slog.ErrorContext(
@@ -455,6 +535,8 @@ func (n *NginxPlugin) handleConfigApplyRequest(ctx context.Context, msg *bus.Mes
 			return
 		}
 
+		n.runPostRollbackHooks(ctx)
+
 		dataplaneResponse = response.CreateDataPlaneResponse(
 			correlationID,
 			&mpi.CommandResponse{
@@ -467,12 +549,266 @@ func (n *NginxPlugin) handleConfigApplyRequest(ctx context.Context, msg *bus.Mes
 		n.completeConfigApply(ctx, &model.NginxConfigContext{}, dataplaneResponse)
 	case model.OK:
 		slog.DebugContext(ctx, "Changes required for config apply request")
-		n.applyConfig(ctx, correlationID, instanceID)
+		n.applyConfig(ctx, correlationID, configApplyRequest.GetOverview())
+	}
+}
+
+// handleConfigValidateRequest stages the files of the request and tests the staged configuration.
+// NGINX is not reloaded and the files on disk and the manifest file are not changed.
+func (n *NginxPlugin) handleConfigValidateRequest(ctx context.Context, msg *bus.Message) {
+	slog.DebugContext(ctx, "Nginx plugin received config validate request message")
+
+	n.configApplyMutex.Lock()
+	defer n.configApplyMutex.Unlock()
+
+	correlationID := logger.CorrelationID(ctx)
+
+	managementPlaneRequest, ok := msg.Data.(*mpi.ManagementPlaneRequest)
+	if !ok {
+		slog.ErrorContext(ctx, "Unable to cast message payload to *mpi.ManagementPlaneRequest", "payload", msg.Data)
+		return
+	}
+
+	request, requestOk := managementPlaneRequest.GetRequest().(*mpi.ManagementPlaneRequest_ConfigValidateRequest)
+	if !requestOk {
+		slog.ErrorContext(ctx, "Unable to cast message payload to *mpi.ManagementPlaneRequest_ConfigValidateRequest",
+			"payload", msg.Data)
+
+		return
+	}
+
+	overview := request.ConfigValidateRequest.GetOverview()
+	instanceID := overview.GetConfigVersion().GetInstanceId()
+
+	var stagingDir string
+	rootPath, err := n.instanceRootPath(n.nginxService.Instance(instanceID))
+	if err == nil {
+		stagingDir, err = n.fileManagerService.StageConfig(ctx, overview, file.InstanceFiles{
+			TemplateData: n.nginxService.TemplateData(instanceID),
+			RootPath:     rootPath,
+		})
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to stage config files", "instance_id", instanceID, "error", err)
+		dpResponse := response.CreateDataPlaneResponse(
+			correlationID,
+			&mpi.CommandResponse{
+				Status:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+				Message: "Config validate failed",
+				Error:   err.Error(),
+			},
+			mpi.DataPlaneResponse_CONFIG_VALIDATE_REQUEST,
+			instanceID,
+		)
+		n.messagePipe.Process(ctx, &bus.Message{Topic: bus.DataPlaneResponseTopic, Data: dpResponse})
+
+		return
 	}
+
+	defer func() {
+		if removeErr := os.RemoveAll(stagingDir); removeErr != nil {
+			slog.WarnContext(ctx, "Unable to remove staging directory", "staging_dir", stagingDir, "error", removeErr)
+		}
+	}()
+
+	output, warnings, err := n.nginxService.ValidateStagedConfig(
+		ctx, instanceID, stagingDir, overview.GetConfigPath(),
+	)
+
+	commandResponse := &mpi.CommandResponse{
+		Status:  mpi.CommandResponse_COMMAND_STATUS_OK,
+		Message: "Config validate successful",
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "Config validate failed", "instance_id", instanceID, "error", err)
+		commandResponse.Status = mpi.CommandResponse_COMMAND_STATUS_FAILURE
+		commandResponse.Message = "Config validate failed"
+		commandResponse.Error = err.Error()
+	}
+
+	dpResponse := response.CreateDataPlaneResponse(
+		correlationID,
+		commandResponse,
+		mpi.DataPlaneResponse_CONFIG_VALIDATE_REQUEST,
+		instanceID,
+	)
+	dpResponse.ConfigValidateResult = &mpi.ConfigValidateResult{
+		Output:   output,
+		Warnings: warnings,
+	}
+
+	n.messagePipe.Process(ctx, &bus.Message{Topic: bus.DataPlaneResponseTopic, Data: dpResponse})
 }
 
-func (n *NginxPlugin) applyConfig(ctx context.Context, correlationID, instanceID string) {
-	configContext, err := n.nginxService.ApplyConfig(ctx, instanceID)
+// handleConfigDiffRequest responds with the changes a config apply of the request would make to the files on disk.
+// Nothing is written to disk.
+func (n *NginxPlugin) handleConfigDiffRequest(ctx context.Context, msg *bus.Message) {
+	slog.DebugContext(ctx, "Nginx plugin received config diff request message")
+
+	n.configApplyMutex.Lock()
+	defer n.configApplyMutex.Unlock()
+
+	correlationID := logger.CorrelationID(ctx)
+
+	managementPlaneRequest, ok := msg.Data.(*mpi.ManagementPlaneRequest)
+	if !ok {
+		slog.ErrorContext(ctx, "Unable to cast message payload to *mpi.ManagementPlaneRequest", "payload", msg.Data)
+		return
+	}
+
+	request, requestOk := managementPlaneRequest.GetRequest().(*mpi.ManagementPlaneRequest_ConfigDiffRequest)
+	if !requestOk {
+		slog.ErrorContext(ctx, "Unable to cast message payload to *mpi.ManagementPlaneRequest_ConfigDiffRequest",
+			"payload", msg.Data)
+
+		return
+	}
+
+	instanceID := request.ConfigDiffRequest.GetOverview().GetConfigVersion().GetInstanceId()
+
+	var changeSet *mpi.ConfigChangeSet
+	rootPath, err := n.instanceRootPath(n.nginxService.Instance(instanceID))
+	if err == nil {
+		changeSet, err = n.fileManagerService.ConfigDiff(ctx, request.ConfigDiffRequest, file.InstanceFiles{
+			TemplateData: n.nginxService.TemplateData(instanceID),
+			RootPath:     rootPath,
+		})
+	}
+
+	commandResponse := &mpi.CommandResponse{
+		Status:  mpi.CommandResponse_COMMAND_STATUS_OK,
+		Message: "Config diff successful",
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "Config diff failed", "instance_id", instanceID, "error", err)
+		commandResponse.Status = mpi.CommandResponse_COMMAND_STATUS_FAILURE
+		commandResponse.Message = "Config diff failed"
+		commandResponse.Error = err.Error()
+	}
+
+	dpResponse := response.CreateDataPlaneResponse(
+		correlationID,
+		commandResponse,
+		mpi.DataPlaneResponse_CONFIG_DIFF_REQUEST,
+		instanceID,
+	)
+	dpResponse.ConfigChangeSet = changeSet
+
+	n.messagePipe.Process(ctx, &bus.Message{Topic: bus.DataPlaneResponseTopic, Data: dpResponse})
+}
+
+// handleConfigHistoryRequest responds with the config versions in the config history of an instance.
+// If a rollback version is set, the stored config version is applied instead. The rollback goes through the
+// same pipeline as a config apply request from the management plane and reports its result the same way.
+func (n *NginxPlugin) handleConfigHistoryRequest(ctx context.Context, msg *bus.Message) {
+	slog.DebugContext(ctx, "Nginx plugin received config history request message")
+
+	correlationID := logger.CorrelationID(ctx)
+
+	managementPlaneRequest, ok := msg.Data.(*mpi.ManagementPlaneRequest)
+	if !ok {
+		slog.ErrorContext(ctx, "Unable to cast message payload to *mpi.ManagementPlaneRequest", "payload", msg.Data)
+		return
+	}
+
+	request, requestOk := managementPlaneRequest.GetRequest().(*mpi.ManagementPlaneRequest_ConfigHistoryRequest)
+	if !requestOk {
+		slog.ErrorContext(ctx, "Unable to cast message payload to *mpi.ManagementPlaneRequest_ConfigHistoryRequest",
+			"payload", msg.Data)
+
+		return
+	}
+
+	instanceID := request.ConfigHistoryRequest.GetInstanceId()
+	rollbackVersion := request.ConfigHistoryRequest.GetRollbackVersion()
+
+	if rollbackVersion == "" {
+		n.sendConfigHistory(ctx, correlationID, instanceID)
+		return
+	}
+
+	overview, err := n.fileManagerService.ConfigVersionOverview(ctx, instanceID, rollbackVersion)
+	if err != nil {
+		slog.ErrorContext(ctx, "Unable to roll back to config version", "instance_id", instanceID,
+			"version", rollbackVersion, "error", err)
+		dpResponse := response.CreateDataPlaneResponse(
+			correlationID,
+			&mpi.CommandResponse{
+				Status:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+				Message: "Config rollback failed",
+				Error:   err.Error(),
+			},
+			mpi.DataPlaneResponse_CONFIG_HISTORY_REQUEST,
+			instanceID,
+		)
+		n.messagePipe.Process(ctx, &bus.Message{Topic: bus.DataPlaneResponseTopic, Data: dpResponse})
+
+		return
+	}
+
+	slog.InfoContext(ctx, "Rolling back to config version", "instance_id", instanceID, "version", rollbackVersion)
+
+	configApplyRequest := &mpi.ManagementPlaneRequest{
+		MessageMeta: managementPlaneRequest.GetMessageMeta(),
+		Request: &mpi.ManagementPlaneRequest_ConfigApplyRequest{
+			ConfigApplyRequest: &mpi.ConfigApplyRequest{
+				Overview: overview,
+			},
+		},
+	}
+
+	// The rollback is queued like any other config apply request, so it is applied in order and reload coalescing
+	// applies to it
+	n.messagePipe.Process(ctx, &bus.Message{Topic: bus.QueueConfigApplyRequestTopic, Data: configApplyRequest})
+}
+
+func (n *NginxPlugin) sendConfigHistory(ctx context.Context, correlationID, instanceID string) {
+	versions, err := n.fileManagerService.ConfigVersions(ctx, instanceID)
+
+	commandResponse := &mpi.CommandResponse{
+		Status:  mpi.CommandResponse_COMMAND_STATUS_OK,
+		Message: "Config history successful",
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "Unable to read config history", "instance_id", instanceID, "error", err)
+		commandResponse.Status = mpi.CommandResponse_COMMAND_STATUS_FAILURE
+		commandResponse.Message = "Config history failed"
+		commandResponse.Error = err.Error()
+	}
+
+	dpResponse := response.CreateDataPlaneResponse(
+		correlationID,
+		commandResponse,
+		mpi.DataPlaneResponse_CONFIG_HISTORY_REQUEST,
+		instanceID,
+	)
+	dpResponse.ConfigHistory = &mpi.ConfigHistory{
+		Versions: versions,
+	}
+
+	n.messagePipe.Process(ctx, &bus.Message{Topic: bus.DataPlaneResponseTopic, Data: dpResponse})
+}
+
+func (n *NginxPlugin) applyConfig(ctx context.Context, correlationID string, overview *mpi.FileOverview) {
+	instanceID := overview.GetConfigVersion().GetInstanceId()
+
+	configContext, err := n.nginxService.ApplyConfig(ctx, instanceID, n.configApplyHooks)
+	n.externalFileRefreshSchedule.reloaded(instanceID, time.Now())
+
+	if err == nil {
+		err = n.nginxService.RunHooks(ctx, model.HookPostReload, n.configApplyHooks)
+	}
+
+	if err == nil {
+		// a config can reload cleanly and still fail to serve traffic
+		if probeErr := n.nginxService.Probe(ctx); probeErr != nil {
+			err = fmt.Errorf("post-reload probes failed: %w", probeErr)
+		}
+	}
+
 	if err != nil {
 		slog.ErrorContext(
 			ctx,
@@ -497,6 +833,8 @@ func (n *NginxPlugin) applyConfig(ctx context.Context, correlationID, instanceID
 		return
 	}
 
+	n.fileManagerService.SetConfigApplyPhase(ctx, model.ConfigApplyReloaded)
+
 	dpResponse := response.CreateDataPlaneResponse(
 		correlationID,
 		&mpi.CommandResponse{
@@ -507,6 +845,14 @@ func (n *NginxPlugin) applyConfig(ctx context.Context, correlationID, instanceID
 		mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST,
 		instanceID,
 	)
+	dpResponse.RenderedFiles = renderedFiles(overview)
+
+	n.updateExternalFileRefreshSchedule(overview)
+
+	if saveErr := n.fileManagerService.SaveConfigVersion(ctx, overview); saveErr != nil {
+		slog.WarnContext(ctx, "Unable to save config version to config history", "instance_id", instanceID,
+			"error", saveErr)
+	}
 
 	if configContext.Files != nil {
 		slog.DebugContext(ctx, "Changes made during config apply, update files on disk")
@@ -523,6 +869,108 @@ func (n *NginxPlugin) applyConfig(ctx context.Context, correlationID, instanceID
 	n.completeConfigApply(ctx, configContext, dpResponse)
 }
 
+// renderedFiles returns the name and rendered hash of the templated files in a file overview, which are reported
+// back to the management plane since it only knows the hash of the template source
+func renderedFiles(overview *mpi.FileOverview) []*mpi.FileMeta {
+	var rendered []*mpi.FileMeta
+
+	for _, overviewFile := range overview.GetFiles() {
+		if overviewFile.GetTemplate() == nil {
+			continue
+		}
+
+		rendered = append(rendered, &mpi.FileMeta{
+			Name:        overviewFile.GetFileMeta().GetName(),
+			Hash:        overviewFile.GetTemplate().GetRenderedHash(),
+			Size:        overviewFile.GetTemplate().GetRenderedSize(),
+			Permissions: overviewFile.GetFileMeta().GetPermissions(),
+		})
+	}
+
+	return rendered
+}
+
+// recoverConfigApply finishes a config apply that was interrupted by an agent restart and reports the outcome
+// to the management plane. If NGINX needs to be reloaded with the rolled back config, the outcome is reported
+// once the instance is discovered and reloaded.
+func (n *NginxPlugin) recoverConfigApply(ctx context.Context) {
+	recovery := n.fileManagerService.RecoverConfigApply(ctx)
+	if recovery == nil {
+		return
+	}
+
+	if recovery.ReloadRequired && recovery.Error == nil {
+		slog.InfoContext(ctx, "Rolled back interrupted config apply, waiting for instance to reload NGINX",
+			"instance_id", recovery.InstanceID)
+		n.pendingConfigApplyRecovery = recovery
+
+		return
+	}
+
+	n.sendConfigApplyRecoveryResponse(ctx, recovery, nil)
+}
+
+func (n *NginxPlugin) reloadRecoveredConfigApply(ctx context.Context) {
+	n.configApplyMutex.Lock()
+	defer n.configApplyMutex.Unlock()
+
+	recovery := n.pendingConfigApplyRecovery
+	if n.nginxService.Instance(recovery.InstanceID) == nil {
+		slog.DebugContext(ctx, "Instance of interrupted config apply not found yet", "instance_id",
+			recovery.InstanceID)
+
+		return
+	}
+
+	n.pendingConfigApplyRecovery = nil
+
+	_, err := n.nginxService.ApplyConfig(ctx, recovery.InstanceID, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "Errors found during rollback of interrupted config apply", "error", err)
+	}
+
+	n.sendConfigApplyRecoveryResponse(ctx, recovery, err)
+}
+
+func (n *NginxPlugin) sendConfigApplyRecoveryResponse(ctx context.Context, recovery *model.ConfigApplyRecovery,
+	reloadErr error,
+) {
+	ctx = context.WithValue(ctx, logger.CorrelationIDContextKey,
+		slog.String(logger.CorrelationIDKey, recovery.CorrelationID))
+
+	commandResponse := &mpi.CommandResponse{
+		Status:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+		Message: "Config apply interrupted by agent restart, rollback successful",
+		Error:   fmt.Sprintf("config apply interrupted by agent restart after phase %q", recovery.Phase),
+	}
+
+	switch {
+	case recovery.Completed:
+		commandResponse = &mpi.CommandResponse{
+			Status:  mpi.CommandResponse_COMMAND_STATUS_OK,
+			Message: "Config apply completed after agent restart",
+		}
+	case recovery.Error != nil:
+		commandResponse.Message = "Config apply interrupted by agent restart, rollback failed"
+		commandResponse.Error = recovery.Error.Error()
+	case reloadErr != nil:
+		commandResponse.Message = "Config apply interrupted by agent restart, rollback failed"
+		commandResponse.Error = reloadErr.Error()
+	}
+
+	slog.InfoContext(ctx, "Recovered config apply interrupted by agent restart", "instance_id",
+		recovery.InstanceID, "message", commandResponse.GetMessage())
+
+	dpResponse := response.CreateDataPlaneResponse(
+		recovery.CorrelationID,
+		commandResponse,
+		mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST,
+		recovery.InstanceID,
+	)
+
+	n.messagePipe.Process(ctx, &bus.Message{Topic: bus.DataPlaneResponseTopic, Data: dpResponse})
+}
+
 func (n *NginxPlugin) writeRollbackConfig(ctx context.Context, correlationID, instanceID string, applyErr error) {
 	slog.DebugContext(ctx, "Starting rollback of config", "instance_id", instanceID)
 	if instanceID == "" {
@@ -570,7 +1018,9 @@ func (n *NginxPlugin) writeRollbackConfig(ctx context.Context, correlationID, in
 
 func (n *NginxPlugin) rollbackConfigApply(ctx context.Context, correlationID, instanceID string, applyErr error) {
 	slog.DebugContext(ctx, "Rolling back config apply, after config written", "instance_id", instanceID)
-	_, err := n.nginxService.ApplyConfig(ctx, instanceID)
+	_, err := n.nginxService.ApplyConfig(ctx, instanceID, nil)
+	n.runPostRollbackHooks(ctx)
+
 	if err != nil {
 		slog.ErrorContext(ctx, "Errors found during rollback, sending failure status", "error", err)
 
@@ -626,9 +1076,31 @@ func (n *NginxPlugin) completeConfigApply(ctx context.Context, configContext *mo
 ) {
 	n.fileManagerService.ClearCache()
 	n.enableWatchers(ctx, configContext, dpResponse.GetInstanceId())
+
+	if n.configApplyHooks != nil {
+		dpResponse.HookResults = n.configApplyHooks.Results
+		n.configApplyHooks = nil
+	}
+
 	n.messagePipe.Process(ctx, &bus.Message{Topic: bus.DataPlaneResponseTopic, Data: dpResponse})
 }
 
+// runPostRollbackHooks runs the post-rollback hooks after the files of a failed config apply were rolled back.
+// The config apply has already failed, so a failed hook is only reported in the hook results.
+func (n *NginxPlugin) runPostRollbackHooks(ctx context.Context) {
+	if err := n.nginxService.RunHooks(ctx, model.HookPostRollback, n.configApplyHooks); err != nil {
+		slog.ErrorContext(ctx, "Failed to run post-rollback hooks", "error", err)
+	}
+}
+
+// instanceRootPath returns the root directory of the instance that files are written to, see instanceRootPath
+func (n *NginxPlugin) instanceRootPath(instance *mpi.Instance) (string, error) {
+	n.agentConfigMutex.Lock()
+	defer n.agentConfigMutex.Unlock()
+
+	return instanceRootPath(n.agentConfig, instance)
+}
+
 func (n *NginxPlugin) enableWatchers(ctx context.Context, configContext *model.NginxConfigContext, instanceID string) {
 	enableWatcher := &model.EnableWatchers{
 		InstanceID:    instanceID,
@@ -11,11 +11,13 @@ import (
 	"encoding/json"
 	"errors"
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/nginx/agent/v3/api/grpc/mpi/v1/v1fakes"
+	"github.com/nginx/agent/v3/internal/file"
 	"github.com/nginx/agent/v3/internal/file/filefakes"
 	"github.com/nginx/agent/v3/internal/grpc/grpcfakes"
 	"github.com/nginx/agent/v3/pkg/files"
@@ -33,6 +35,7 @@ import (
 
 	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
 	"github.com/nginx/agent/v3/internal/bus"
+	"github.com/nginx/agent/v3/internal/config"
 	"github.com/nginx/agent/v3/internal/nginx/nginxfakes"
 	"github.com/nginx/agent/v3/test/protos"
 	"github.com/stretchr/testify/assert"
@@ -649,8 +652,13 @@ func TestNginx_Subscriptions(t *testing.T) {
 			bus.ConnectionCreatedTopic,
 			bus.NginxConfigUpdateTopic,
 			bus.ConfigUploadRequestTopic,
+			bus.ConfigDiffRequestTopic,
 			bus.ResourceUpdateTopic,
 			bus.ConfigApplyRequestTopic,
+			bus.ConfigValidateRequestTopic,
+			bus.ConfigHistoryRequestTopic,
+			bus.ExternalFileRefreshTopic,
+			bus.CredentialUpdatedTopic,
 		},
 		nginxPlugin.Subscriptions())
 
@@ -662,6 +670,7 @@ func TestNginx_Subscriptions(t *testing.T) {
 			bus.ConnectionCreatedTopic,
 			bus.NginxConfigUpdateTopic,
 			bus.ConfigUploadRequestTopic,
+			bus.ConfigDiffRequestTopic,
 			bus.ResourceUpdateTopic,
 		},
 		readNginxPlugin.Subscriptions())
@@ -694,6 +703,19 @@ func TestNginx_Init(t *testing.T) {
 	assert.Empty(t, messages)
 }
 
+func TestNginx_Process_CredentialUpdatedTopic(t *testing.T) {
+	ctx := context.Background()
+
+	fakeFileManagerService := &filefakes.FakeFileManagerServiceInterface{}
+	nginxPlugin := NewNginx(types.AgentConfig(), &grpcfakes.FakeGrpcConnectionInterface{}, model.Command,
+		&sync.RWMutex{})
+	nginxPlugin.fileManagerService = fakeFileManagerService
+
+	nginxPlugin.Process(ctx, &bus.Message{Topic: bus.CredentialUpdatedTopic})
+
+	assert.Equal(t, 1, fakeFileManagerService.ResetExternalDataSourceCredentialsCallCount())
+}
+
 func TestNginx_Process_handleConfigUploadRequest(t *testing.T) {
 	ctx := context.Background()
 
@@ -963,6 +985,14 @@ func TestNginx_Process_handleConfigApplyRequest(t *testing.T) {
 				assert.True(t, ok)
 				assert.Equal(t, mpi.CommandResponse_COMMAND_STATUS_OK, msg.GetCommandResponse().GetStatus())
 				assert.Equal(t, "Config apply successful", msg.GetCommandResponse().GetMessage())
+
+				require.Equal(t, 1, fakeFileManagerService.SaveConfigVersionCallCount())
+				_, savedOverview := fakeFileManagerService.SaveConfigVersionArgsForCall(0)
+				assert.Equal(t, test.message.GetConfigApplyRequest().GetOverview(), savedOverview)
+
+				require.Equal(t, 1, fakeFileManagerService.SetConfigApplyPhaseCallCount())
+				_, phase := fakeFileManagerService.SetConfigApplyPhaseArgsForCall(0)
+				assert.Equal(t, model.ConfigApplyReloaded, phase)
 			case test.configApplyStatus == model.RollbackRequired:
 				assert.Len(t, messages, 3)
 
@@ -1025,6 +1055,524 @@ func TestNginx_Process_handleConfigApplyRequest(t *testing.T) {
 	}
 }
 
+func TestNginx_renderedFiles(t *testing.T) {
+	overview := protos.FileOverview("/etc/nginx/nginx.conf", "source-hash")
+	overview.Files = append(overview.Files, &mpi.File{
+		FileMeta: &mpi.FileMeta{
+			Name:        "/etc/nginx/conf.d/default.conf",
+			Hash:        "template-hash",
+			Permissions: "0644",
+		},
+		Template: &mpi.FileTemplate{RenderedHash: "rendered-hash", RenderedSize: 42},
+	})
+
+	assert.Equal(t, []*mpi.FileMeta{
+		{
+			Name:        "/etc/nginx/conf.d/default.conf",
+			Hash:        "rendered-hash",
+			Size:        42,
+			Permissions: "0644",
+		},
+	}, renderedFiles(overview))
+}
+
+func TestNginx_Process_handleConfigApplyRequest_DeclaredInstance(t *testing.T) {
+	ctx := context.Background()
+
+	// the PID file of the declared instance contains the ID of a process that is not running the NGINX binary
+	instance := protos.NginxOssInstance([]string{})
+	instance.GetInstanceRuntime().ProcessId = int32(os.Getpid())
+
+	agentConfig := types.AgentConfig()
+	agentConfig.DataPlaneConfig.Nginx.Instances = []*config.NginxInstance{
+		{
+			BinaryPath: instance.GetInstanceRuntime().GetBinaryPath(),
+			ConfigPath: instance.GetInstanceRuntime().GetConfigPath(),
+			PIDFile:    filepath.Join(t.TempDir(), "nginx.pid"),
+		},
+	}
+
+	fakeNginxService := &nginxfakes.FakeNginxServiceInterface{}
+	fakeNginxService.InstanceReturns(instance)
+	fakeFileManagerService := &filefakes.FakeFileManagerServiceInterface{}
+	messagePipe := busfakes.NewFakeMessagePipe()
+
+	nginxPlugin := NewNginx(agentConfig, &grpcfakes.FakeGrpcConnectionInterface{}, model.Command, &sync.RWMutex{})
+	nginxPlugin.messagePipe = messagePipe
+	nginxPlugin.fileManagerService = fakeFileManagerService
+	nginxPlugin.nginxService = fakeNginxService
+
+	nginxPlugin.Process(ctx, &bus.Message{
+		Topic: bus.ConfigApplyRequestTopic,
+		Data: &mpi.ManagementPlaneRequest{
+			Request: &mpi.ManagementPlaneRequest_ConfigApplyRequest{
+				ConfigApplyRequest: protos.CreateConfigApplyRequest(&mpi.FileOverview{
+					ConfigVersion: &mpi.ConfigVersion{
+						InstanceId: instance.GetInstanceMeta().GetInstanceId(),
+					},
+				}),
+			},
+		},
+	})
+
+	assert.Equal(t, 0, fakeFileManagerService.ConfigApplyCallCount())
+
+	messages := messagePipe.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, bus.EnableWatchersTopic, messages[0].Topic)
+
+	dataPlaneResponse, ok := messages[1].Data.(*mpi.DataPlaneResponse)
+	require.True(t, ok)
+	assert.Equal(t, mpi.CommandResponse_COMMAND_STATUS_FAILURE, dataPlaneResponse.GetCommandResponse().GetStatus())
+	assert.Contains(t, dataPlaneResponse.GetCommandResponse().GetError(), "is not running NGINX binary")
+}
+
+func TestNginx_Process_handleConfigValidateRequest(t *testing.T) {
+	ctx := context.Background()
+
+	fakeGrpcConnection := &grpcfakes.FakeGrpcConnectionInterface{}
+	instanceID := protos.NginxOssInstance([]string{}).GetInstanceMeta().GetInstanceId()
+
+	tests := []struct {
+		stageErr         error
+		validateErr      error
+		name             string
+		expectedMessage  string
+		expectedOutput   string
+		expectedWarnings []string
+		expectedStatus   mpi.CommandResponse_CommandStatus
+	}{
+		{
+			name:             "Test 1: Config validate successful",
+			expectedStatus:   mpi.CommandResponse_COMMAND_STATUS_OK,
+			expectedMessage:  "Config validate successful",
+			expectedOutput:   "nginx: [warn] duplicate MIME type\nnginx: configuration file test is successful",
+			expectedWarnings: []string{"nginx: [warn] duplicate MIME type"},
+		},
+		{
+			name:            "Test 2: Config validate failed",
+			validateErr:     errors.New("error running nginx -t -c"),
+			expectedStatus:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+			expectedMessage: "Config validate failed",
+			expectedOutput:  "nginx: [emerg] unknown directive",
+		},
+		{
+			name:            "Test 3: Staging config files failed",
+			stageErr:        errors.New("file not in allowed directories"),
+			expectedStatus:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+			expectedMessage: "Config validate failed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			stagingDir := filepath.Join(tt.TempDir(), "config-validate-test")
+			require.NoError(tt, os.MkdirAll(stagingDir, 0o755))
+
+			fakeFileManagerService := &filefakes.FakeFileManagerServiceInterface{}
+			fakeFileManagerService.StageConfigReturns(stagingDir, test.stageErr)
+
+			templateData := &file.TemplateData{Host: file.TemplateHost{Hostname: "web-1"}}
+
+			fakeNginxService := &nginxfakes.FakeNginxServiceInterface{}
+			fakeNginxService.ValidateStagedConfigReturns(test.expectedOutput, test.expectedWarnings, test.validateErr)
+			fakeNginxService.TemplateDataReturns(templateData)
+
+			messagePipe := busfakes.NewFakeMessagePipe()
+
+			nginxPlugin := NewNginx(types.AgentConfig(), fakeGrpcConnection, model.Command, &sync.RWMutex{})
+			err := nginxPlugin.Init(ctx, messagePipe)
+			require.NoError(tt, err)
+			nginxPlugin.fileManagerService = fakeFileManagerService
+			nginxPlugin.nginxService = fakeNginxService
+
+			overview := protos.FileOverview("/etc/nginx/nginx.conf", "hash")
+			overview.ConfigVersion.InstanceId = instanceID
+
+			request := &mpi.ManagementPlaneRequest{
+				Request: &mpi.ManagementPlaneRequest_ConfigValidateRequest{
+					ConfigValidateRequest: &mpi.ConfigValidateRequest{
+						Overview: overview,
+					},
+				},
+			}
+
+			nginxPlugin.Process(ctx, &bus.Message{Topic: bus.ConfigValidateRequestTopic, Data: request})
+
+			messages := messagePipe.Messages()
+			require.Len(tt, messages, 1)
+			assert.Equal(tt, bus.DataPlaneResponseTopic, messages[0].Topic)
+
+			dataPlaneResponse, ok := messages[0].Data.(*mpi.DataPlaneResponse)
+			require.True(tt, ok)
+			assert.Equal(tt, mpi.DataPlaneResponse_CONFIG_VALIDATE_REQUEST, dataPlaneResponse.GetRequestType())
+			assert.Equal(tt, instanceID, dataPlaneResponse.GetInstanceId())
+			assert.Equal(tt, test.expectedStatus, dataPlaneResponse.GetCommandResponse().GetStatus())
+			assert.Equal(tt, test.expectedMessage, dataPlaneResponse.GetCommandResponse().GetMessage())
+			assert.Equal(tt, test.expectedOutput, dataPlaneResponse.GetConfigValidateResult().GetOutput())
+			assert.Equal(tt, test.expectedWarnings, dataPlaneResponse.GetConfigValidateResult().GetWarnings())
+
+			// the files are staged for the instance of the request, without changing the instance of a config apply
+			require.Equal(tt, 1, fakeFileManagerService.StageConfigCallCount())
+			_, _, instanceFiles := fakeFileManagerService.StageConfigArgsForCall(0)
+			assert.Empty(tt, instanceFiles.RootPath)
+			assert.Equal(tt, templateData, instanceFiles.TemplateData)
+			assert.Equal(tt, 0, fakeFileManagerService.SetRootPathCallCount())
+			assert.Equal(tt, 0, fakeFileManagerService.SetTemplateDataCallCount())
+
+			// NGINX is never reloaded and the files on disk are never updated
+			assert.Equal(tt, 0, fakeNginxService.ApplyConfigCallCount())
+			assert.Equal(tt, 0, fakeFileManagerService.ConfigApplyCallCount())
+			assert.Equal(tt, 0, fakeFileManagerService.UpdateCurrentFilesOnDiskCallCount())
+
+			if test.stageErr == nil {
+				assert.Equal(tt, 1, fakeNginxService.ValidateStagedConfigCallCount())
+				_, _, actualStagingDir, configPath := fakeNginxService.ValidateStagedConfigArgsForCall(0)
+				assert.Equal(tt, stagingDir, actualStagingDir)
+				assert.Equal(tt, overview.GetConfigPath(), configPath)
+				assert.NoDirExists(tt, stagingDir)
+			} else {
+				assert.Equal(tt, 0, fakeNginxService.ValidateStagedConfigCallCount())
+			}
+		})
+	}
+}
+
+func TestNginx_Process_handleConfigDiffRequest(t *testing.T) {
+	ctx := context.Background()
+
+	fakeGrpcConnection := &grpcfakes.FakeGrpcConnectionInterface{}
+	instanceID := protos.NginxOssInstance([]string{}).GetInstanceMeta().GetInstanceId()
+
+	changeSet := &mpi.ConfigChangeSet{
+		Changes: []*mpi.FileChange{
+			{
+				Name:    "/etc/nginx/nginx.conf",
+				Action:  mpi.FileAction_FILE_ACTION_UPDATE,
+				OldHash: "old-hash",
+				NewHash: "hash",
+			},
+		},
+	}
+
+	tests := []struct {
+		diffErr           error
+		expectedChangeSet *mpi.ConfigChangeSet
+		name              string
+		expectedMessage   string
+		serverType        model.ServerType
+		expectedStatus    mpi.CommandResponse_CommandStatus
+	}{
+		{
+			name:              "Test 1: Config diff successful",
+			serverType:        model.Command,
+			expectedChangeSet: changeSet,
+			expectedStatus:    mpi.CommandResponse_COMMAND_STATUS_OK,
+			expectedMessage:   "Config diff successful",
+		},
+		{
+			name:              "Test 2: Config diff successful on auxiliary server",
+			serverType:        model.Auxiliary,
+			expectedChangeSet: changeSet,
+			expectedStatus:    mpi.CommandResponse_COMMAND_STATUS_OK,
+			expectedMessage:   "Config diff successful",
+		},
+		{
+			name:            "Test 3: Config diff failed",
+			serverType:      model.Command,
+			diffErr:         errors.New("file not in allowed directories"),
+			expectedStatus:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+			expectedMessage: "Config diff failed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			fakeFileManagerService := &filefakes.FakeFileManagerServiceInterface{}
+			fakeFileManagerService.ConfigDiffReturns(test.expectedChangeSet, test.diffErr)
+
+			templateData := &file.TemplateData{Host: file.TemplateHost{Hostname: "web-1"}}
+
+			fakeNginxService := &nginxfakes.FakeNginxServiceInterface{}
+			fakeNginxService.TemplateDataReturns(templateData)
+
+			messagePipe := busfakes.NewFakeMessagePipe()
+
+			nginxPlugin := NewNginx(types.AgentConfig(), fakeGrpcConnection, test.serverType, &sync.RWMutex{})
+			err := nginxPlugin.Init(ctx, messagePipe)
+			require.NoError(tt, err)
+			nginxPlugin.fileManagerService = fakeFileManagerService
+			nginxPlugin.nginxService = fakeNginxService
+
+			overview := protos.FileOverview("/etc/nginx/nginx.conf", "hash")
+			overview.ConfigVersion.InstanceId = instanceID
+
+			request := &mpi.ManagementPlaneRequest{
+				Request: &mpi.ManagementPlaneRequest_ConfigDiffRequest{
+					ConfigDiffRequest: &mpi.ConfigDiffRequest{
+						Overview:        overview,
+						IncludeTextDiff: true,
+					},
+				},
+			}
+
+			nginxPlugin.Process(ctx, &bus.Message{Topic: bus.ConfigDiffRequestTopic, Data: request})
+
+			messages := messagePipe.Messages()
+			require.Len(tt, messages, 1)
+			assert.Equal(tt, bus.DataPlaneResponseTopic, messages[0].Topic)
+
+			dataPlaneResponse, ok := messages[0].Data.(*mpi.DataPlaneResponse)
+			require.True(tt, ok)
+			assert.Equal(tt, mpi.DataPlaneResponse_CONFIG_DIFF_REQUEST, dataPlaneResponse.GetRequestType())
+			assert.Equal(tt, instanceID, dataPlaneResponse.GetInstanceId())
+			assert.Equal(tt, test.expectedStatus, dataPlaneResponse.GetCommandResponse().GetStatus())
+			assert.Equal(tt, test.expectedMessage, dataPlaneResponse.GetCommandResponse().GetMessage())
+			assert.Equal(tt, test.expectedChangeSet, dataPlaneResponse.GetConfigChangeSet())
+
+			require.Equal(tt, 1, fakeFileManagerService.ConfigDiffCallCount())
+			_, diffRequest, instanceFiles := fakeFileManagerService.ConfigDiffArgsForCall(0)
+			assert.True(tt, diffRequest.GetIncludeTextDiff())
+
+			// the files are compared for the instance of the request, without changing the instance of a config apply
+			assert.Empty(tt, instanceFiles.RootPath)
+			assert.Equal(tt, templateData, instanceFiles.TemplateData)
+			assert.Equal(tt, 0, fakeFileManagerService.SetRootPathCallCount())
+			assert.Equal(tt, 0, fakeFileManagerService.SetTemplateDataCallCount())
+
+			// NGINX is never reloaded and the files on disk are never updated
+			assert.Equal(tt, 0, fakeNginxService.ApplyConfigCallCount())
+			assert.Equal(tt, 0, fakeFileManagerService.ConfigApplyCallCount())
+			assert.Equal(tt, 0, fakeFileManagerService.UpdateCurrentFilesOnDiskCallCount())
+		})
+	}
+}
+
+func TestNginx_Process_handleConfigHistoryRequest(t *testing.T) {
+	ctx := context.Background()
+
+	fakeGrpcConnection := &grpcfakes.FakeGrpcConnectionInterface{}
+	instanceID := protos.NginxOssInstance([]string{}).GetInstanceMeta().GetInstanceId()
+
+	versions := []*mpi.ConfigHistoryVersion{
+		{
+			ConfigVersion: &mpi.ConfigVersion{InstanceId: instanceID, Version: "v1"},
+			ConfigPath:    "/etc/nginx/nginx.conf",
+			Files:         []*mpi.FileMeta{protos.FileMeta("/etc/nginx/nginx.conf", "hash")},
+		},
+	}
+
+	t.Run("Test 1: List config versions", func(tt *testing.T) {
+		fakeFileManagerService := &filefakes.FakeFileManagerServiceInterface{}
+		fakeFileManagerService.ConfigVersionsReturns(versions, nil)
+		messagePipe := busfakes.NewFakeMessagePipe()
+
+		nginxPlugin := NewNginx(types.AgentConfig(), fakeGrpcConnection, model.Command, &sync.RWMutex{})
+		require.NoError(tt, nginxPlugin.Init(ctx, messagePipe))
+		nginxPlugin.fileManagerService = fakeFileManagerService
+
+		nginxPlugin.Process(ctx, &bus.Message{
+			Topic: bus.ConfigHistoryRequestTopic,
+			Data:  configHistoryRequest(instanceID, ""),
+		})
+
+		messages := messagePipe.Messages()
+		require.Len(tt, messages, 1)
+		assert.Equal(tt, bus.DataPlaneResponseTopic, messages[0].Topic)
+
+		dataPlaneResponse, ok := messages[0].Data.(*mpi.DataPlaneResponse)
+		require.True(tt, ok)
+		assert.Equal(tt, mpi.DataPlaneResponse_CONFIG_HISTORY_REQUEST, dataPlaneResponse.GetRequestType())
+		assert.Equal(tt, mpi.CommandResponse_COMMAND_STATUS_OK, dataPlaneResponse.GetCommandResponse().GetStatus())
+		assert.Equal(tt, versions, dataPlaneResponse.GetConfigHistory().GetVersions())
+		assert.Equal(tt, 0, fakeFileManagerService.ConfigApplyCallCount())
+	})
+
+	t.Run("Test 2: Roll back to config version", func(tt *testing.T) {
+		overview := protos.FileOverview("/etc/nginx/nginx.conf", "hash")
+		overview.ConfigVersion = versions[0].GetConfigVersion()
+
+		fakeFileManagerService := &filefakes.FakeFileManagerServiceInterface{}
+		fakeFileManagerService.ConfigVersionOverviewReturns(overview, nil)
+		messagePipe := busfakes.NewFakeMessagePipe()
+
+		nginxPlugin := NewNginx(types.AgentConfig(), fakeGrpcConnection, model.Command, &sync.RWMutex{})
+		require.NoError(tt, nginxPlugin.Init(ctx, messagePipe))
+		nginxPlugin.fileManagerService = fakeFileManagerService
+
+		request := configHistoryRequest(instanceID, "v1")
+		nginxPlugin.Process(ctx, &bus.Message{Topic: bus.ConfigHistoryRequestTopic, Data: request})
+
+		_, actualInstanceID, actualVersion := fakeFileManagerService.ConfigVersionOverviewArgsForCall(0)
+		assert.Equal(tt, instanceID, actualInstanceID)
+		assert.Equal(tt, "v1", actualVersion)
+
+		// the stored config version is queued like any other config apply request
+		messages := messagePipe.Messages()
+		require.Len(tt, messages, 1)
+		assert.Equal(tt, bus.QueueConfigApplyRequestTopic, messages[0].Topic)
+
+		configApplyRequest, ok := messages[0].Data.(*mpi.ManagementPlaneRequest)
+		require.True(tt, ok)
+		assert.Equal(tt, request.GetMessageMeta(), configApplyRequest.GetMessageMeta())
+		assert.Equal(tt, overview, configApplyRequest.GetConfigApplyRequest().GetOverview())
+	})
+
+	t.Run("Test 3: Roll back to unknown config version", func(tt *testing.T) {
+		fakeFileManagerService := &filefakes.FakeFileManagerServiceInterface{}
+		fakeFileManagerService.ConfigVersionOverviewReturns(nil, errors.New("config version not found: v2"))
+		messagePipe := busfakes.NewFakeMessagePipe()
+
+		nginxPlugin := NewNginx(types.AgentConfig(), fakeGrpcConnection, model.Command, &sync.RWMutex{})
+		require.NoError(tt, nginxPlugin.Init(ctx, messagePipe))
+		nginxPlugin.fileManagerService = fakeFileManagerService
+
+		nginxPlugin.Process(ctx, &bus.Message{
+			Topic: bus.ConfigHistoryRequestTopic,
+			Data:  configHistoryRequest(instanceID, "v2"),
+		})
+
+		messages := messagePipe.Messages()
+		require.Len(tt, messages, 1)
+		assert.Equal(tt, bus.DataPlaneResponseTopic, messages[0].Topic)
+
+		dataPlaneResponse, ok := messages[0].Data.(*mpi.DataPlaneResponse)
+		require.True(tt, ok)
+		assert.Equal(tt, mpi.DataPlaneResponse_CONFIG_HISTORY_REQUEST, dataPlaneResponse.GetRequestType())
+		assert.Equal(tt, mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+			dataPlaneResponse.GetCommandResponse().GetStatus())
+		assert.Equal(tt, "Config rollback failed", dataPlaneResponse.GetCommandResponse().GetMessage())
+	})
+}
+
+func configHistoryRequest(instanceID, rollbackVersion string) *mpi.ManagementPlaneRequest {
+	return &mpi.ManagementPlaneRequest{
+		MessageMeta: &mpi.MessageMeta{
+			MessageId:     "1",
+			CorrelationId: "dfsbhj6-bc92-30c1-a9c9-85591422068e",
+		},
+		Request: &mpi.ManagementPlaneRequest_ConfigHistoryRequest{
+			ConfigHistoryRequest: &mpi.ConfigHistoryRequest{
+				InstanceId:      instanceID,
+				RollbackVersion: rollbackVersion,
+			},
+		},
+	}
+}
+
+func TestNginxPlugin_recoverConfigApply(t *testing.T) {
+	ctx := context.Background()
+	instance := protos.NginxOssInstance([]string{})
+	instanceID := instance.GetInstanceMeta().GetInstanceId()
+
+	tests := []struct {
+		recovery        *model.ConfigApplyRecovery
+		reloadErr       error
+		name            string
+		expectedMessage string
+		expectedStatus  mpi.CommandResponse_CommandStatus
+	}{
+		{
+			name: "Test 1: Config apply completed",
+			recovery: &model.ConfigApplyRecovery{
+				Phase:     model.ConfigApplyReloaded,
+				Completed: true,
+			},
+			expectedStatus:  mpi.CommandResponse_COMMAND_STATUS_OK,
+			expectedMessage: "Config apply completed after agent restart",
+		},
+		{
+			name: "Test 2: Config apply rolled back",
+			recovery: &model.ConfigApplyRecovery{
+				Phase: model.ConfigApplyDownloaded,
+			},
+			expectedStatus:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+			expectedMessage: "Config apply interrupted by agent restart, rollback successful",
+		},
+		{
+			name: "Test 3: Config apply rollback failed",
+			recovery: &model.ConfigApplyRecovery{
+				Phase: model.ConfigApplyBackedUp,
+				Error: errors.New("failed to rename file"),
+			},
+			expectedStatus:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+			expectedMessage: "Config apply interrupted by agent restart, rollback failed",
+		},
+		{
+			name: "Test 4: Config apply rolled back and NGINX reloaded",
+			recovery: &model.ConfigApplyRecovery{
+				Phase:          model.ConfigApplyMoved,
+				ReloadRequired: true,
+			},
+			expectedStatus:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+			expectedMessage: "Config apply interrupted by agent restart, rollback successful",
+		},
+		{
+			name: "Test 5: Config apply rolled back and NGINX reload failed",
+			recovery: &model.ConfigApplyRecovery{
+				Phase:          model.ConfigApplyMoved,
+				ReloadRequired: true,
+			},
+			reloadErr:       errors.New("nginx: [emerg] unknown directive"),
+			expectedStatus:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+			expectedMessage: "Config apply interrupted by agent restart, rollback failed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			test.recovery.CorrelationID = "dfsbhj6-bc92-30c1-a9c9-85591422068e"
+			test.recovery.InstanceID = instanceID
+
+			fakeFileManagerService := &filefakes.FakeFileManagerServiceInterface{}
+			fakeFileManagerService.RecoverConfigApplyReturns(test.recovery)
+			fakeNginxService := &nginxfakes.FakeNginxServiceInterface{}
+			fakeNginxService.ApplyConfigReturns(&model.NginxConfigContext{}, test.reloadErr)
+			messagePipe := busfakes.NewFakeMessagePipe()
+
+			nginxPlugin := NewNginx(types.AgentConfig(), &grpcfakes.FakeGrpcConnectionInterface{}, model.Command,
+				&sync.RWMutex{})
+			require.NoError(tt, nginxPlugin.Init(ctx, messagePipe))
+			nginxPlugin.fileManagerService = fakeFileManagerService
+			nginxPlugin.nginxService = fakeNginxService
+
+			nginxPlugin.recoverConfigApply(ctx)
+
+			if test.recovery.ReloadRequired {
+				// NGINX is reloaded once the instance of the config apply is discovered
+				assert.Empty(tt, messagePipe.Messages())
+
+				nginxPlugin.Process(ctx, &bus.Message{Topic: bus.ResourceUpdateTopic, Data: protos.HostResource()})
+				assert.Empty(tt, messagePipe.Messages())
+				assert.Equal(tt, 0, fakeNginxService.ApplyConfigCallCount())
+
+				fakeNginxService.InstanceReturns(instance)
+				nginxPlugin.Process(ctx, &bus.Message{Topic: bus.ResourceUpdateTopic, Data: protos.HostResource()})
+				require.Equal(tt, 1, fakeNginxService.ApplyConfigCallCount())
+				_, reloadedInstanceID, reloadHooks := fakeNginxService.ApplyConfigArgsForCall(0)
+				assert.Equal(tt, instanceID, reloadedInstanceID)
+				// hooks are not run for the reload of a rolled back config
+				assert.Nil(tt, reloadHooks)
+				assert.Nil(tt, nginxPlugin.pendingConfigApplyRecovery)
+			} else {
+				assert.Equal(tt, 0, fakeNginxService.ApplyConfigCallCount())
+			}
+
+			messages := messagePipe.Messages()
+			require.Len(tt, messages, 1)
+			assert.Equal(tt, bus.DataPlaneResponseTopic, messages[0].Topic)
+
+			dataPlaneResponse, ok := messages[0].Data.(*mpi.DataPlaneResponse)
+			require.True(tt, ok)
+			assert.Equal(tt, test.recovery.CorrelationID, dataPlaneResponse.GetMessageMeta().GetCorrelationId())
+			assert.Equal(tt, instanceID, dataPlaneResponse.GetInstanceId())
+			assert.Equal(tt, mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST, dataPlaneResponse.GetRequestType())
+			assert.Equal(tt, test.expectedStatus, dataPlaneResponse.GetCommandResponse().GetStatus())
+			assert.Equal(tt, test.expectedMessage, dataPlaneResponse.GetCommandResponse().GetMessage())
+		})
+	}
+}
+
 func TestNginxPlugin_Failed_ConfigApply(t *testing.T) {
 	ctx := context.Background()
 
@@ -1075,8 +1623,10 @@ func TestNginxPlugin_Failed_ConfigApply(t *testing.T) {
 			nginxPlugin.nginxService = fakeNginxService
 			require.NoError(t, err)
 
-			nginxPlugin.applyConfig(ctx, "dfsbhj6-bc92-30c1-a9c9-85591422068e", protos.
-				NginxOssInstance([]string{}).GetInstanceMeta().GetInstanceId())
+			overview := protos.FileOverview("/etc/nginx/nginx.conf", "hash")
+			overview.ConfigVersion.InstanceId = protos.NginxOssInstance([]string{}).GetInstanceMeta().GetInstanceId()
+
+			nginxPlugin.applyConfig(ctx, "dfsbhj6-bc92-30c1-a9c9-85591422068e", overview)
 
 			messages := messagePipe.Messages()
 
@@ -1090,6 +1640,10 @@ func TestNginxPlugin_Failed_ConfigApply(t *testing.T) {
 			assert.Equal(t, "Config apply failed, rolling back config",
 				dataPlaneResponse.GetCommandResponse().GetMessage())
 
+			// a config version that failed to apply is never added to the config history
+			assert.Equal(t, 0, fakeFileManagerService.SaveConfigVersionCallCount())
+			assert.Equal(t, 0, fakeFileManagerService.SetConfigApplyPhaseCallCount())
+
 			if tt.rollbackError == nil && tt.rollbackWriteError == nil {
 				assert.Len(t, messages, 3)
 				assert.Equal(t, bus.EnableWatchersTopic, messages[1].Topic)
@@ -1133,6 +1687,123 @@ func TestNginxPlugin_Failed_ConfigApply(t *testing.T) {
 	}
 }
 
+func TestNginxPlugin_Failed_ConfigApply_Probes(t *testing.T) {
+	ctx := context.Background()
+
+	fakeNginxService := &nginxfakes.FakeNginxServiceInterface{}
+	fakeNginxService.ApplyConfigReturns(&model.NginxConfigContext{}, nil)
+	fakeNginxService.ProbeReturns(errors.New("HTTP probe http://127.0.0.1/health failed: expected status 200, " +
+		"got 502"))
+
+	fakeFileManagerService := &filefakes.FakeFileManagerServiceInterface{}
+	messagePipe := busfakes.NewFakeMessagePipe()
+
+	nginxPlugin := NewNginx(types.AgentConfig(), &grpcfakes.FakeGrpcConnectionInterface{}, model.Command,
+		&sync.RWMutex{})
+	require.NoError(t, nginxPlugin.Init(ctx, messagePipe))
+	nginxPlugin.fileManagerService = fakeFileManagerService
+	nginxPlugin.nginxService = fakeNginxService
+
+	overview := protos.FileOverview("/etc/nginx/nginx.conf", "hash")
+	overview.ConfigVersion.InstanceId = protos.NginxOssInstance([]string{}).GetInstanceMeta().GetInstanceId()
+
+	nginxPlugin.applyConfig(ctx, "dfsbhj6-bc92-30c1-a9c9-85591422068e", overview)
+
+	// the probes only run after the config apply, not after the rollback
+	assert.Equal(t, 1, fakeNginxService.ProbeCallCount())
+	assert.Equal(t, 2, fakeNginxService.ApplyConfigCallCount())
+	assert.Equal(t, 1, fakeFileManagerService.RollbackCallCount())
+	assert.Equal(t, 0, fakeFileManagerService.SaveConfigVersionCallCount())
+
+	messages := messagePipe.Messages()
+	require.Len(t, messages, 3)
+
+	dataPlaneResponse, ok := messages[0].Data.(*mpi.DataPlaneResponse)
+	require.True(t, ok)
+	assert.Equal(t, mpi.CommandResponse_COMMAND_STATUS_ERROR, dataPlaneResponse.GetCommandResponse().GetStatus())
+	assert.Equal(t, "post-reload probes failed: HTTP probe http://127.0.0.1/health failed: expected status 200, "+
+		"got 502", dataPlaneResponse.GetCommandResponse().GetError())
+
+	dataPlaneResponse, ok = messages[2].Data.(*mpi.DataPlaneResponse)
+	require.True(t, ok)
+	assert.Equal(t, "Config apply failed, rollback successful", dataPlaneResponse.GetCommandResponse().GetMessage())
+	assert.Contains(t, dataPlaneResponse.GetCommandResponse().GetError(), "expected status 200, got 502")
+}
+
+func TestNginxPlugin_Failed_ConfigApply_Hooks(t *testing.T) {
+	ctx := context.Background()
+
+	postReloadResult := &mpi.ConfigApplyHookResult{
+		Phase:    string(model.HookPostReload),
+		Path:     "/etc/nginx-agent/hooks/notify.sh",
+		ExitCode: 1,
+		Output:   "unable to notify",
+	}
+	postRollbackResult := &mpi.ConfigApplyHookResult{
+		Phase:    string(model.HookPostRollback),
+		Path:     "/etc/nginx-agent/hooks/alert.sh",
+		ExitCode: 0,
+	}
+
+	fakeNginxService := &nginxfakes.FakeNginxServiceInterface{}
+	fakeNginxService.ApplyConfigReturns(&model.NginxConfigContext{}, nil)
+	fakeNginxService.RunHooksCalls(func(_ context.Context, phase model.ConfigApplyHookPhase,
+		hooks *model.ConfigApplyHooks,
+	) error {
+		if phase == model.HookPostReload {
+			hooks.Results = append(hooks.Results, postReloadResult)
+
+			return errors.New("post_reload hook /etc/nginx-agent/hooks/notify.sh failed: exit code 1: " +
+				"unable to notify")
+		}
+
+		hooks.Results = append(hooks.Results, postRollbackResult)
+
+		return nil
+	})
+
+	fakeFileManagerService := &filefakes.FakeFileManagerServiceInterface{}
+	messagePipe := busfakes.NewFakeMessagePipe()
+
+	nginxPlugin := NewNginx(types.AgentConfig(), &grpcfakes.FakeGrpcConnectionInterface{}, model.Command,
+		&sync.RWMutex{})
+	require.NoError(t, nginxPlugin.Init(ctx, messagePipe))
+	nginxPlugin.fileManagerService = fakeFileManagerService
+	nginxPlugin.nginxService = fakeNginxService
+	nginxPlugin.configApplyHooks = &model.ConfigApplyHooks{
+		CorrelationID: "dfsbhj6-bc92-30c1-a9c9-85591422068e",
+		ChangedFiles:  []string{"/etc/nginx/nginx.conf"},
+	}
+
+	overview := protos.FileOverview("/etc/nginx/nginx.conf", "hash")
+	overview.ConfigVersion.InstanceId = protos.NginxOssInstance([]string{}).GetInstanceMeta().GetInstanceId()
+
+	nginxPlugin.applyConfig(ctx, "dfsbhj6-bc92-30c1-a9c9-85591422068e", overview)
+
+	assert.Equal(t, 0, fakeNginxService.ProbeCallCount())
+	assert.Equal(t, 2, fakeNginxService.ApplyConfigCallCount())
+	assert.Equal(t, 2, fakeNginxService.RunHooksCallCount())
+	assert.Equal(t, 1, fakeFileManagerService.RollbackCallCount())
+
+	// the hooks are not run again when reloading the rolled back config
+	_, _, rollbackHooks := fakeNginxService.ApplyConfigArgsForCall(1)
+	assert.Nil(t, rollbackHooks)
+
+	_, phase, _ := fakeNginxService.RunHooksArgsForCall(1)
+	assert.Equal(t, model.HookPostRollback, phase)
+	assert.Nil(t, nginxPlugin.configApplyHooks)
+
+	messages := messagePipe.Messages()
+	require.Len(t, messages, 3)
+
+	dataPlaneResponse, ok := messages[2].Data.(*mpi.DataPlaneResponse)
+	require.True(t, ok)
+	assert.Equal(t, "Config apply failed, rollback successful", dataPlaneResponse.GetCommandResponse().GetMessage())
+	assert.Contains(t, dataPlaneResponse.GetCommandResponse().GetError(), "unable to notify")
+	assert.Equal(t, []*mpi.ConfigApplyHookResult{postReloadResult, postRollbackResult},
+		dataPlaneResponse.GetHookResults())
+}
+
 func TestNginxPlugin_Process_NginxConfigUpdateTopic(t *testing.T) {
 	ctx := context.Background()
 
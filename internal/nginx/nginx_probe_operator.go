@@ -0,0 +1,158 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package nginx
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/nginx/agent/v3/internal/config"
+)
+
+// maxProbeBodySize limits how much of a response body is read to look for the expected body
+const maxProbeBodySize = 1024 * 1024
+
+type NginxProbeOperator struct {
+	probes *config.NginxProbes
+}
+
+var _ probeOperator = (*NginxProbeOperator)(nil)
+
+func NewProbeOperator(agentConfig *config.Config) *NginxProbeOperator {
+	var probes *config.NginxProbes
+	if agentConfig.DataPlaneConfig != nil && agentConfig.DataPlaneConfig.Nginx != nil {
+		probes = agentConfig.DataPlaneConfig.Nginx.Probes
+	}
+
+	return &NginxProbeOperator{
+		probes: probes,
+	}
+}
+
+// Probe runs all configured probes concurrently. The returned error contains the result of every failed probe.
+func (p *NginxProbeOperator) Probe(ctx context.Context) error {
+	if p.probes == nil || (len(p.probes.HTTP) == 0 && len(p.probes.TCP) == 0) {
+		return nil
+	}
+
+	slog.InfoContext(ctx, "Running NGINX probes", "http_probes", len(p.probes.HTTP),
+		"tcp_probes", len(p.probes.TCP))
+
+	var (
+		wg       sync.WaitGroup
+		mutex    sync.Mutex
+		probeErr error
+	)
+
+	addErr := func(err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		probeErr = errors.Join(probeErr, err)
+	}
+
+	for _, httpProbe := range p.probes.HTTP {
+		wg.Go(func() {
+			if err := p.probeHTTP(ctx, httpProbe); err != nil {
+				addErr(fmt.Errorf("HTTP probe %s failed: %w", httpProbe.URL, err))
+			}
+		})
+	}
+
+	for _, tcpProbe := range p.probes.TCP {
+		wg.Go(func() {
+			if err := p.probeTCP(ctx, tcpProbe); err != nil {
+				addErr(fmt.Errorf("TCP probe %s failed: %w", tcpProbe.Address, err))
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if probeErr != nil {
+		return probeErr
+	}
+
+	slog.InfoContext(ctx, "NGINX probes successful")
+
+	return nil
+}
+
+func (p *NginxProbeOperator) probeHTTP(ctx context.Context, probe *config.NginxHTTPProbe) error {
+	ctx, cancel := p.withTimeout(ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probe.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	transport := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: probe.SkipVerify, //nolint:gosec // skipping verification is configured per probe
+		},
+	}
+	defer transport.CloseIdleConnections()
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if probe.ExpectedStatus != 0 && resp.StatusCode != probe.ExpectedStatus {
+		return fmt.Errorf("expected status %d, got %d", probe.ExpectedStatus, resp.StatusCode)
+	}
+
+	if probe.ExpectedStatus == 0 && resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	if probe.ExpectedBody == "" {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxProbeBodySize))
+	if err != nil {
+		return fmt.Errorf("unable to read response body: %w", err)
+	}
+
+	if !strings.Contains(string(body), probe.ExpectedBody) {
+		return fmt.Errorf("response body does not contain %q", probe.ExpectedBody)
+	}
+
+	return nil
+}
+
+func (p *NginxProbeOperator) probeTCP(ctx context.Context, probe *config.NginxTCPProbe) error {
+	ctx, cancel := p.withTimeout(ctx)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+
+	conn, err := dialer.DialContext(ctx, "tcp", probe.Address)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+func (p *NginxProbeOperator) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.probes.Timeout <= 0 {
+		return context.WithTimeout(ctx, config.DefNginxProbesTimeout)
+	}
+
+	return context.WithTimeout(ctx, p.probes.Timeout)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package nginx
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nginx/agent/v3/internal/config"
+	"github.com/nginx/agent/v3/test/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNginxProbeOperator_Probe(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			_, _ = w.Write([]byte("status: ok"))
+			return
+		}
+
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddress := closedListener.Addr().String()
+	require.NoError(t, closedListener.Close())
+
+	tests := []struct {
+		probes        *config.NginxProbes
+		name          string
+		expectedError []string
+	}{
+		{
+			name: "Test 1: No probes",
+		},
+		{
+			name: "Test 2: Successful probes",
+			probes: &config.NginxProbes{
+				HTTP: []*config.NginxHTTPProbe{
+					{URL: server.URL + "/health"},
+					{URL: server.URL + "/health", ExpectedStatus: http.StatusOK, ExpectedBody: "ok"},
+					{URL: server.URL + "/upstream", ExpectedStatus: http.StatusBadGateway},
+				},
+				TCP: []*config.NginxTCPProbe{
+					{Address: listener.Addr().String()},
+				},
+			},
+		},
+		{
+			name: "Test 3: Failed probes",
+			probes: &config.NginxProbes{
+				HTTP: []*config.NginxHTTPProbe{
+					{URL: server.URL + "/upstream"},
+					{URL: server.URL + "/upstream", ExpectedStatus: http.StatusOK},
+					{URL: server.URL + "/health", ExpectedBody: "healthy"},
+				},
+				TCP: []*config.NginxTCPProbe{
+					{Address: listener.Addr().String()},
+					{Address: closedAddress},
+				},
+				Timeout: time.Second,
+			},
+			expectedError: []string{
+				"HTTP probe " + server.URL + "/upstream failed: unexpected status 502",
+				"HTTP probe " + server.URL + "/upstream failed: expected status 200, got 502",
+				"HTTP probe " + server.URL + "/health failed: response body does not contain \"healthy\"",
+				"TCP probe " + closedAddress + " failed",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			agentConfig := types.AgentConfig()
+			agentConfig.DataPlaneConfig.Nginx.Probes = test.probes
+
+			err := NewProbeOperator(agentConfig).Probe(ctx)
+
+			if len(test.expectedError) == 0 {
+				require.NoError(tt, err)
+				return
+			}
+
+			require.Error(tt, err)
+
+			for _, expectedError := range test.expectedError {
+				assert.Contains(tt, err.Error(), expectedError)
+			}
+
+			assert.NotContains(tt, err.Error(), listener.Addr().String())
+		})
+	}
+}
@@ -6,16 +6,20 @@
 package nginx
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"log/slog"
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -26,7 +30,9 @@ import (
 	"github.com/nginx/agent/v3/pkg/host"
 
 	parser "github.com/nginx/agent/v3/internal/datasource/config"
+	"github.com/nginx/agent/v3/internal/datasource/nginx"
 	datasource "github.com/nginx/agent/v3/internal/datasource/proto"
+	"github.com/nginx/agent/v3/internal/file"
 	"github.com/nginx/agent/v3/internal/model"
 
 	"google.golang.org/protobuf/proto"
@@ -46,6 +52,8 @@ const (
 	defaultPlusAPITimeout = 30 * time.Second
 )
 
+var includeRegex = regexp.MustCompile(`(\binclude\s+)(["']?)(/[^"';\s]+)(["']?)(\s*;)`)
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6@v6.11.2 -generate
 //counterfeiter:generate . nginxServiceInterface
 
@@ -58,10 +66,20 @@ const (
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6@v6.11.2 -generate
 //counterfeiter:generate . processOperator
 
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6@v6.11.2 -generate
+//counterfeiter:generate . probeOperator
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6@v6.11.2 -generate
+//counterfeiter:generate . hookOperator
+
 type nginxServiceInterface interface {
 	UpdateResource(ctx context.Context, resource *mpi.Resource) *mpi.Resource
-	ApplyConfig(ctx context.Context, instanceID string) (*model.NginxConfigContext, error)
+	ApplyConfig(ctx context.Context, instanceID string, hooks *model.ConfigApplyHooks) (*model.NginxConfigContext,
+		error)
+	Probe(ctx context.Context) error
+	RunHooks(ctx context.Context, phase model.ConfigApplyHookPhase, hooks *model.ConfigApplyHooks) error
 	Instance(instanceID string) *mpi.Instance
+	TemplateData(instanceID string) *file.TemplateData
 	GetHTTPUpstreamServers(ctx context.Context, instance *mpi.Instance, upstreams string) ([]client.UpstreamServer,
 		error)
 	UpdateHTTPUpstreamServers(ctx context.Context, instance *mpi.Instance, upstream string,
@@ -70,11 +88,16 @@ type nginxServiceInterface interface {
 	GetStreamUpstreams(ctx context.Context, instance *mpi.Instance) (*client.StreamUpstreams, error)
 	UpdateStreamServers(ctx context.Context, instance *mpi.Instance, upstream string,
 		upstreams []*structpb.Struct) (added, updated, deleted []client.StreamUpstreamServer, err error)
+	ValidateStagedConfig(ctx context.Context, instanceID, stagingDir, configPath string) (output string,
+		warnings []string, err error)
+	UnitConfig(ctx context.Context, instance *mpi.Instance) ([]byte, error)
+	UpdateUnitConfig(ctx context.Context, instance *mpi.Instance, unitConfig []byte) error
 }
 
 type (
 	instanceOperator interface {
 		Validate(ctx context.Context, instance *mpi.Instance) error
+		ValidateConfigFile(ctx context.Context, instance *mpi.Instance, configPath string) (string, error)
 		Reload(ctx context.Context, instance *mpi.Instance) error
 	}
 
@@ -88,6 +111,14 @@ type (
 		FindParentProcessID(ctx context.Context, instanceID string, nginxProcesses []*nginxprocess.Process,
 			executer exec.ExecInterface) (int32, error)
 	}
+
+	probeOperator interface {
+		Probe(ctx context.Context) error
+	}
+
+	hookOperator interface {
+		Run(ctx context.Context, phase model.ConfigApplyHookPhase, hooks *model.ConfigApplyHooks) error
+	}
 )
 
 type NginxService struct {
@@ -95,6 +126,8 @@ type NginxService struct {
 	nginxConfigParser parser.ConfigParser
 	agentConfig       *config.Config
 	instanceOperator  instanceOperator
+	probeOperator     probeOperator
+	hookOperator      hookOperator
 	info              host.InfoInterface
 	manifestFilePath  string
 	resourceMutex     sync.RWMutex
@@ -108,6 +141,8 @@ func NewNginxService(ctx context.Context, agentConfig *config.Config) *NginxServ
 		info:              host.NewInfo(),
 		operatorsMutex:    sync.Mutex{},
 		instanceOperator:  NewInstanceOperator(agentConfig),
+		probeOperator:     NewProbeOperator(agentConfig),
+		hookOperator:      NewHookOperator(agentConfig),
 		nginxConfigParser: parser.NewNginxConfigParser(agentConfig),
 		agentConfig:       agentConfig,
 		manifestFilePath:  agentConfig.LibDir + "/manifest.json",
@@ -132,6 +167,15 @@ func (n *NginxService) Instance(instanceID string) *mpi.Instance {
 	return nil
 }
 
+// TemplateData returns the variables for rendering templated config files of an instance
+func (n *NginxService) TemplateData(instanceID string) *file.TemplateData {
+	n.resourceMutex.RLock()
+	res := n.resource
+	n.resourceMutex.RUnlock()
+
+	return file.NewTemplateData(n.agentConfig, res, n.Instance(instanceID))
+}
+
 func (n *NginxService) UpdateResource(ctx context.Context, resource *mpi.Resource) *mpi.Resource {
 	slog.DebugContext(ctx, "Updating resource", "resource", resource)
 	n.resourceMutex.Lock()
@@ -142,7 +186,11 @@ func (n *NginxService) UpdateResource(ctx context.Context, resource *mpi.Resourc
 	return n.resource
 }
 
-func (n *NginxService) ApplyConfig(ctx context.Context, instanceID string) (*model.NginxConfigContext, error) {
+// ApplyConfig validates the config of the instance and reloads NGINX. If hooks are given, the pre-validate and
+// pre-reload hooks are run.
+func (n *NginxService) ApplyConfig(ctx context.Context, instanceID string, hooks *model.ConfigApplyHooks) (
+	*model.NginxConfigContext, error,
+) {
 	var instance *mpi.Instance
 
 	if n.instanceOperator == nil {
@@ -175,11 +223,19 @@ func (n *NginxService) ApplyConfig(ctx context.Context, instanceID string) (*mod
 
 	slog.DebugContext(ctx, "Updated Instance Runtime after parsing config", "instance", instance.GetInstanceRuntime())
 
+	if hookErr := n.RunHooks(ctx, model.HookPreValidate, hooks); hookErr != nil {
+		return nil, hookErr
+	}
+
 	valErr := n.instanceOperator.Validate(ctx, instance)
 	if valErr != nil {
 		return nil, fmt.Errorf("failed validating config %w", valErr)
 	}
 
+	if hookErr := n.RunHooks(ctx, model.HookPreReload, hooks); hookErr != nil {
+		return nil, hookErr
+	}
+
 	reloadErr := n.instanceOperator.Reload(ctx, instance)
 	if reloadErr != nil {
 		return nil, fmt.Errorf("failed to reload NGINX %w", reloadErr)
@@ -197,6 +253,72 @@ func (n *NginxService) ApplyConfig(ctx context.Context, instanceID string) (*mod
 	return nginxConfigContext, nil
 }
 
+// Probe runs the probes configured to verify NGINX after a config apply has reloaded NGINX.
+func (n *NginxService) Probe(ctx context.Context) error {
+	if n.probeOperator == nil {
+		return nil
+	}
+
+	return n.probeOperator.Probe(ctx)
+}
+
+// RunHooks runs the hooks configured for a phase of a config apply. If no hooks are given, nothing is run.
+func (n *NginxService) RunHooks(ctx context.Context, phase model.ConfigApplyHookPhase,
+	hooks *model.ConfigApplyHooks,
+) error {
+	if hooks == nil || n.hookOperator == nil {
+		return nil
+	}
+
+	return n.hookOperator.Run(ctx, phase, hooks)
+}
+
+// ValidateStagedConfig tests a configuration staged by the file manager service without changing the files on disk.
+// Absolute include paths inside the allowed directories are rewritten to point to the staging directory, so the
+// staged files are included instead of the files on disk. The staging directory is removed from the returned output.
+// The staging directory of an instance in another container is in the root directory of the instance.
+func (n *NginxService) ValidateStagedConfig(ctx context.Context, instanceID, stagingDir, configPath string) (
+	output string, warnings []string, err error,
+) {
+	if n.instanceOperator == nil {
+		return "", nil, errors.New("instance operator is nil")
+	}
+
+	instance := n.Instance(instanceID)
+	if instance == nil {
+		return "", nil, fmt.Errorf("instance %s not found", instanceID)
+	}
+
+	if configPath == "" {
+		configPath = instance.GetInstanceRuntime().GetConfigPath()
+	}
+
+	stagedConfigPath := file.StagedFilePath(stagingDir, configPath)
+	if _, statErr := os.Stat(stagedConfigPath); statErr != nil {
+		return "", nil, fmt.Errorf("config file %s not found in request: %w", configPath, statErr)
+	}
+
+	rootPath, err := instanceRootPath(n.agentConfig, instance)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// NGINX reads the staged files of an instance in another container through its own root directory
+	stagingDirInRoot, err := nginx.PathInRoot(rootPath, stagingDir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if rewriteErr := n.rewriteStagedIncludes(ctx, stagingDir, stagingDirInRoot); rewriteErr != nil {
+		return "", nil, fmt.Errorf("failed to rewrite include paths %w", rewriteErr)
+	}
+
+	output, err = n.instanceOperator.ValidateConfigFile(ctx, instance, stagedConfigPath)
+	output = strings.ReplaceAll(output, stagingDirInRoot, "")
+
+	return output, configTestWarnings(output), err
+}
+
 func (n *NginxService) GetHTTPUpstreamServers(ctx context.Context, instance *mpi.Instance,
 	upstream string,
 ) ([]client.UpstreamServer, error) {
@@ -361,6 +483,57 @@ func (n *NginxService) manifestFile() (map[string]*model.ManifestFile, error) {
 	return manifestFiles, nil
 }
 
+func (n *NginxService) rewriteStagedIncludes(ctx context.Context, stagingDir, stagingDirInRoot string) error {
+	return filepath.WalkDir(stagingDir, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		rewritten := includeRegex.ReplaceAllFunc(content, func(include []byte) []byte {
+			groups := includeRegex.FindSubmatch(include)
+			if !n.agentConfig.IsDirectoryAllowed(string(groups[3])) {
+				return include
+			}
+
+			return bytes.Join([][]byte{
+				groups[1], groups[2], []byte(file.StagedFilePath(stagingDirInRoot, string(groups[3]))), groups[4], groups[5],
+			}, nil)
+		})
+
+		if bytes.Equal(content, rewritten) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		slog.DebugContext(ctx, "Rewriting include paths in staged file", "file", path)
+
+		return os.WriteFile(path, rewritten, info.Mode().Perm())
+	})
+}
+
+func configTestWarnings(output string) (warnings []string) {
+	for line := range strings.Lines(output) {
+		if strings.Contains(line, "[warn]") {
+			warnings = append(warnings, strings.TrimSpace(line))
+		}
+	}
+
+	return warnings
+}
+
 func convertToUpstreamServer(upstreams []*structpb.Struct) []client.UpstreamServer {
 	var servers []client.UpstreamServer
 	res, err := json.Marshal(upstreams)
@@ -355,13 +355,162 @@ func TestResourceService_ApplyConfig(t *testing.T) {
 			}
 			resourceService.resource.Instances = instances
 
-			configContext, reloadError := resourceService.ApplyConfig(ctx, test.instanceID)
+			configContext, reloadError := resourceService.ApplyConfig(ctx, test.instanceID, nil)
 			assert.Equal(t, test.expected, reloadError)
 			t.Log("configContext:", configContext)
 		})
 	}
 }
 
+func TestNginxService_ApplyConfig_Hooks(t *testing.T) {
+	ctx := context.Background()
+	instance := protos.NginxOssInstance([]string{})
+
+	tests := []struct {
+		hooks               *model.ConfigApplyHooks
+		hookErr             error
+		name                string
+		failedPhase         model.ConfigApplyHookPhase
+		expectedPhases      []model.ConfigApplyHookPhase
+		expectedReloadCount int
+	}{
+		{
+			name:                "Test 1: No hooks",
+			expectedReloadCount: 1,
+		},
+		{
+			name:                "Test 2: Successful hooks",
+			hooks:               &model.ConfigApplyHooks{InstanceID: instance.GetInstanceMeta().GetInstanceId()},
+			expectedPhases:      []model.ConfigApplyHookPhase{model.HookPreValidate, model.HookPreReload},
+			expectedReloadCount: 1,
+		},
+		{
+			name:           "Test 3: Failed pre-reload hook",
+			hooks:          &model.ConfigApplyHooks{InstanceID: instance.GetInstanceMeta().GetInstanceId()},
+			hookErr:        errors.New("pre_reload hook /etc/nginx/hooks/drain.sh failed: exit code 1: draining"),
+			failedPhase:    model.HookPreReload,
+			expectedPhases: []model.ConfigApplyHookPhase{model.HookPreValidate, model.HookPreReload},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			instanceOp := &nginxfakes.FakeInstanceOperator{}
+			hookOp := &nginxfakes.FakeHookOperator{}
+			hookOp.RunCalls(func(_ context.Context, phase model.ConfigApplyHookPhase,
+				_ *model.ConfigApplyHooks,
+			) error {
+				if phase == test.failedPhase {
+					return test.hookErr
+				}
+
+				return nil
+			})
+
+			nginxParser := &configfakes.FakeConfigParser{}
+			nginxParser.ParseReturns(&model.NginxConfigContext{
+				StubStatus: &model.APIDetails{},
+				PlusAPI:    &model.APIDetails{},
+				InstanceID: instance.GetInstanceMeta().GetInstanceId(),
+			}, nil)
+			nginxParser.FindStubStatusAPIReturns(&model.APIDetails{})
+			nginxParser.FindPlusAPIReturns(&model.APIDetails{})
+
+			nginxService := NewNginxService(ctx, types.AgentConfig())
+			nginxService.instanceOperator = instanceOp
+			nginxService.hookOperator = hookOp
+			nginxService.nginxConfigParser = nginxParser
+			nginxService.resource.Instances = []*mpi.Instance{instance}
+
+			_, err := nginxService.ApplyConfig(ctx, instance.GetInstanceMeta().GetInstanceId(), test.hooks)
+			assert.Equal(tt, test.hookErr, err)
+
+			require.Equal(tt, len(test.expectedPhases), hookOp.RunCallCount())
+			for i, expectedPhase := range test.expectedPhases {
+				_, phase, hooks := hookOp.RunArgsForCall(i)
+				assert.Equal(tt, expectedPhase, phase)
+				assert.Equal(tt, test.hooks, hooks)
+			}
+
+			assert.Equal(tt, 1, instanceOp.ValidateCallCount())
+			assert.Equal(tt, test.expectedReloadCount, instanceOp.ReloadCallCount())
+		})
+	}
+}
+
+func TestNginxService_ValidateStagedConfig(t *testing.T) {
+	ctx := context.Background()
+	stagingDir := t.TempDir()
+
+	instance := protos.NginxOssInstance([]string{})
+	configPath := instance.GetInstanceRuntime().GetConfigPath()
+	stagedConfigPath := filepath.Join(stagingDir, configPath)
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(stagedConfigPath), 0o755))
+	require.NoError(t, os.WriteFile(stagedConfigPath, []byte(
+		"load_module modules/ngx_http_js_module.so;\n"+
+			"include /usr/share/nginx/modules/*.conf;\n"+
+			"http {\n"+
+			"    include mime.types;\n"+
+			"    include \"/etc/nginx/conf.d/*.conf\";\n"+
+			"}\n",
+	), 0o600))
+
+	instanceOp := &nginxfakes.FakeInstanceOperator{}
+	instanceOp.ValidateConfigFileReturns(
+		"nginx: [warn] duplicate extension \"js\" in "+stagingDir+"/etc/nginx/mime.types:8\n"+
+			"nginx: the configuration file "+stagedConfigPath+" syntax is ok\n",
+		nil,
+	)
+
+	agentConfig := types.AgentConfig()
+	agentConfig.AllowedDirectories = []string{"/etc/nginx"}
+
+	nginxService := NewNginxService(ctx, agentConfig)
+	nginxService.instanceOperator = instanceOp
+	nginxService.resource.Instances = []*mpi.Instance{instance}
+
+	output, warnings, err := nginxService.ValidateStagedConfig(
+		ctx, instance.GetInstanceMeta().GetInstanceId(), stagingDir, "",
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"nginx: [warn] duplicate extension \"js\" in /etc/nginx/mime.types:8\n"+
+			"nginx: the configuration file "+configPath+" syntax is ok\n",
+		output,
+	)
+	assert.Equal(t, []string{"nginx: [warn] duplicate extension \"js\" in /etc/nginx/mime.types:8"}, warnings)
+
+	_, _, actualConfigPath := instanceOp.ValidateConfigFileArgsForCall(0)
+	assert.Equal(t, stagedConfigPath, actualConfigPath)
+
+	// Only absolute includes inside the allowed directories are rewritten to the staging directory
+	stagedConfig, readErr := os.ReadFile(stagedConfigPath)
+	require.NoError(t, readErr)
+	assert.Equal(t,
+		"load_module modules/ngx_http_js_module.so;\n"+
+			"include /usr/share/nginx/modules/*.conf;\n"+
+			"http {\n"+
+			"    include mime.types;\n"+
+			"    include \""+stagingDir+"/etc/nginx/conf.d/*.conf\";\n"+
+			"}\n",
+		string(stagedConfig),
+	)
+
+	t.Run("Test 2: Config file not in request", func(tt *testing.T) {
+		_, _, validateErr := nginxService.ValidateStagedConfig(
+			ctx, instance.GetInstanceMeta().GetInstanceId(), stagingDir, "/etc/nginx/other.conf",
+		)
+		require.ErrorContains(tt, validateErr, "config file /etc/nginx/other.conf not found in request")
+	})
+
+	t.Run("Test 3: Unknown instance", func(tt *testing.T) {
+		_, _, validateErr := nginxService.ValidateStagedConfig(ctx, "unknown", stagingDir, "")
+		require.EqualError(tt, validateErr, "instance unknown not found")
+	})
+}
+
 func Test_updateConfigContextFiles(t *testing.T) {
 	ctx := t.Context()
 	resourceService := NewNginxService(ctx, types.AgentConfig())
@@ -33,6 +33,21 @@ type FakeInstanceOperator struct {
 	validateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ValidateConfigFileStub        func(context.Context, *v1.Instance, string) (string, error)
+	validateConfigFileMutex       sync.RWMutex
+	validateConfigFileArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1.Instance
+		arg3 string
+	}
+	validateConfigFileReturns struct {
+		result1 string
+		result2 error
+	}
+	validateConfigFileReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -161,6 +176,72 @@ func (fake *FakeInstanceOperator) ValidateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeInstanceOperator) ValidateConfigFile(arg1 context.Context, arg2 *v1.Instance, arg3 string) (string, error) {
+	fake.validateConfigFileMutex.Lock()
+	ret, specificReturn := fake.validateConfigFileReturnsOnCall[len(fake.validateConfigFileArgsForCall)]
+	fake.validateConfigFileArgsForCall = append(fake.validateConfigFileArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1.Instance
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ValidateConfigFileStub
+	fakeReturns := fake.validateConfigFileReturns
+	fake.recordInvocation("ValidateConfigFile", []interface{}{arg1, arg2, arg3})
+	fake.validateConfigFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeInstanceOperator) ValidateConfigFileCallCount() int {
+	fake.validateConfigFileMutex.RLock()
+	defer fake.validateConfigFileMutex.RUnlock()
+	return len(fake.validateConfigFileArgsForCall)
+}
+
+func (fake *FakeInstanceOperator) ValidateConfigFileCalls(stub func(context.Context, *v1.Instance, string) (string, error)) {
+	fake.validateConfigFileMutex.Lock()
+	defer fake.validateConfigFileMutex.Unlock()
+	fake.ValidateConfigFileStub = stub
+}
+
+func (fake *FakeInstanceOperator) ValidateConfigFileArgsForCall(i int) (context.Context, *v1.Instance, string) {
+	fake.validateConfigFileMutex.RLock()
+	defer fake.validateConfigFileMutex.RUnlock()
+	argsForCall := fake.validateConfigFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeInstanceOperator) ValidateConfigFileReturns(result1 string, result2 error) {
+	fake.validateConfigFileMutex.Lock()
+	defer fake.validateConfigFileMutex.Unlock()
+	fake.ValidateConfigFileStub = nil
+	fake.validateConfigFileReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInstanceOperator) ValidateConfigFileReturnsOnCall(i int, result1 string, result2 error) {
+	fake.validateConfigFileMutex.Lock()
+	defer fake.validateConfigFileMutex.Unlock()
+	fake.ValidateConfigFileStub = nil
+	if fake.validateConfigFileReturnsOnCall == nil {
+		fake.validateConfigFileReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.validateConfigFileReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeInstanceOperator) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -168,6 +249,8 @@ func (fake *FakeInstanceOperator) Invocations() map[string][][]interface{} {
 	defer fake.reloadMutex.RUnlock()
 	fake.validateMutex.RLock()
 	defer fake.validateMutex.RUnlock()
+	fake.validateConfigFileMutex.RLock()
+	defer fake.validateConfigFileMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
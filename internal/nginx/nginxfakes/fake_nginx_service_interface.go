@@ -6,17 +6,19 @@ import (
 	"sync"
 
 	v1 "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/file"
 	"github.com/nginx/agent/v3/internal/model"
 	"github.com/nginx/nginx-plus-go-client/v3/client"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type FakeNginxServiceInterface struct {
-	ApplyConfigStub        func(context.Context, string) (*model.NginxConfigContext, error)
+	ApplyConfigStub        func(context.Context, string, *model.ConfigApplyHooks) (*model.NginxConfigContext, error)
 	applyConfigMutex       sync.RWMutex
 	applyConfigArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
+		arg3 *model.ConfigApplyHooks
 	}
 	applyConfigReturns struct {
 		result1 *model.NginxConfigContext
@@ -80,6 +82,55 @@ type FakeNginxServiceInterface struct {
 	instanceReturnsOnCall map[int]struct {
 		result1 *v1.Instance
 	}
+	ProbeStub        func(context.Context) error
+	probeMutex       sync.RWMutex
+	probeArgsForCall []struct {
+		arg1 context.Context
+	}
+	probeReturns struct {
+		result1 error
+	}
+	probeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RunHooksStub        func(context.Context, model.ConfigApplyHookPhase, *model.ConfigApplyHooks) error
+	runHooksMutex       sync.RWMutex
+	runHooksArgsForCall []struct {
+		arg1 context.Context
+		arg2 model.ConfigApplyHookPhase
+		arg3 *model.ConfigApplyHooks
+	}
+	runHooksReturns struct {
+		result1 error
+	}
+	runHooksReturnsOnCall map[int]struct {
+		result1 error
+	}
+	TemplateDataStub        func(string) *file.TemplateData
+	templateDataMutex       sync.RWMutex
+	templateDataArgsForCall []struct {
+		arg1 string
+	}
+	templateDataReturns struct {
+		result1 *file.TemplateData
+	}
+	templateDataReturnsOnCall map[int]struct {
+		result1 *file.TemplateData
+	}
+	UnitConfigStub        func(context.Context, *v1.Instance) ([]byte, error)
+	unitConfigMutex       sync.RWMutex
+	unitConfigArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1.Instance
+	}
+	unitConfigReturns struct {
+		result1 []byte
+		result2 error
+	}
+	unitConfigReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	UpdateHTTPUpstreamServersStub        func(context.Context, *v1.Instance, string, []*structpb.Struct) ([]client.UpstreamServer, []client.UpstreamServer, []client.UpstreamServer, error)
 	updateHTTPUpstreamServersMutex       sync.RWMutex
 	updateHTTPUpstreamServersArgsForCall []struct {
@@ -132,23 +183,55 @@ type FakeNginxServiceInterface struct {
 		result3 []client.StreamUpstreamServer
 		result4 error
 	}
+	UpdateUnitConfigStub        func(context.Context, *v1.Instance, []byte) error
+	updateUnitConfigMutex       sync.RWMutex
+	updateUnitConfigArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1.Instance
+		arg3 []byte
+	}
+	updateUnitConfigReturns struct {
+		result1 error
+	}
+	updateUnitConfigReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ValidateStagedConfigStub        func(context.Context, string, string, string) (string, []string, error)
+	validateStagedConfigMutex       sync.RWMutex
+	validateStagedConfigArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	validateStagedConfigReturns struct {
+		result1 string
+		result2 []string
+		result3 error
+	}
+	validateStagedConfigReturnsOnCall map[int]struct {
+		result1 string
+		result2 []string
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeNginxServiceInterface) ApplyConfig(arg1 context.Context, arg2 string) (*model.NginxConfigContext, error) {
+func (fake *FakeNginxServiceInterface) ApplyConfig(arg1 context.Context, arg2 string, arg3 *model.ConfigApplyHooks) (*model.NginxConfigContext, error) {
 	fake.applyConfigMutex.Lock()
 	ret, specificReturn := fake.applyConfigReturnsOnCall[len(fake.applyConfigArgsForCall)]
 	fake.applyConfigArgsForCall = append(fake.applyConfigArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 *model.ConfigApplyHooks
+	}{arg1, arg2, arg3})
 	stub := fake.ApplyConfigStub
 	fakeReturns := fake.applyConfigReturns
-	fake.recordInvocation("ApplyConfig", []interface{}{arg1, arg2})
+	fake.recordInvocation("ApplyConfig", []interface{}{arg1, arg2, arg3})
 	fake.applyConfigMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -162,17 +245,17 @@ func (fake *FakeNginxServiceInterface) ApplyConfigCallCount() int {
 	return len(fake.applyConfigArgsForCall)
 }
 
-func (fake *FakeNginxServiceInterface) ApplyConfigCalls(stub func(context.Context, string) (*model.NginxConfigContext, error)) {
+func (fake *FakeNginxServiceInterface) ApplyConfigCalls(stub func(context.Context, string, *model.ConfigApplyHooks) (*model.NginxConfigContext, error)) {
 	fake.applyConfigMutex.Lock()
 	defer fake.applyConfigMutex.Unlock()
 	fake.ApplyConfigStub = stub
 }
 
-func (fake *FakeNginxServiceInterface) ApplyConfigArgsForCall(i int) (context.Context, string) {
+func (fake *FakeNginxServiceInterface) ApplyConfigArgsForCall(i int) (context.Context, string, *model.ConfigApplyHooks) {
 	fake.applyConfigMutex.RLock()
 	defer fake.applyConfigMutex.RUnlock()
 	argsForCall := fake.applyConfigArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeNginxServiceInterface) ApplyConfigReturns(result1 *model.NginxConfigContext, result2 error) {
@@ -458,6 +541,256 @@ func (fake *FakeNginxServiceInterface) InstanceReturnsOnCall(i int, result1 *v1.
 	}{result1}
 }
 
+func (fake *FakeNginxServiceInterface) Probe(arg1 context.Context) error {
+	fake.probeMutex.Lock()
+	ret, specificReturn := fake.probeReturnsOnCall[len(fake.probeArgsForCall)]
+	fake.probeArgsForCall = append(fake.probeArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ProbeStub
+	fakeReturns := fake.probeReturns
+	fake.recordInvocation("Probe", []interface{}{arg1})
+	fake.probeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNginxServiceInterface) ProbeCallCount() int {
+	fake.probeMutex.RLock()
+	defer fake.probeMutex.RUnlock()
+	return len(fake.probeArgsForCall)
+}
+
+func (fake *FakeNginxServiceInterface) ProbeCalls(stub func(context.Context) error) {
+	fake.probeMutex.Lock()
+	defer fake.probeMutex.Unlock()
+	fake.ProbeStub = stub
+}
+
+func (fake *FakeNginxServiceInterface) ProbeArgsForCall(i int) context.Context {
+	fake.probeMutex.RLock()
+	defer fake.probeMutex.RUnlock()
+	argsForCall := fake.probeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNginxServiceInterface) ProbeReturns(result1 error) {
+	fake.probeMutex.Lock()
+	defer fake.probeMutex.Unlock()
+	fake.ProbeStub = nil
+	fake.probeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNginxServiceInterface) ProbeReturnsOnCall(i int, result1 error) {
+	fake.probeMutex.Lock()
+	defer fake.probeMutex.Unlock()
+	fake.ProbeStub = nil
+	if fake.probeReturnsOnCall == nil {
+		fake.probeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.probeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNginxServiceInterface) RunHooks(arg1 context.Context, arg2 model.ConfigApplyHookPhase, arg3 *model.ConfigApplyHooks) error {
+	fake.runHooksMutex.Lock()
+	ret, specificReturn := fake.runHooksReturnsOnCall[len(fake.runHooksArgsForCall)]
+	fake.runHooksArgsForCall = append(fake.runHooksArgsForCall, struct {
+		arg1 context.Context
+		arg2 model.ConfigApplyHookPhase
+		arg3 *model.ConfigApplyHooks
+	}{arg1, arg2, arg3})
+	stub := fake.RunHooksStub
+	fakeReturns := fake.runHooksReturns
+	fake.recordInvocation("RunHooks", []interface{}{arg1, arg2, arg3})
+	fake.runHooksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNginxServiceInterface) RunHooksCallCount() int {
+	fake.runHooksMutex.RLock()
+	defer fake.runHooksMutex.RUnlock()
+	return len(fake.runHooksArgsForCall)
+}
+
+func (fake *FakeNginxServiceInterface) RunHooksCalls(stub func(context.Context, model.ConfigApplyHookPhase, *model.ConfigApplyHooks) error) {
+	fake.runHooksMutex.Lock()
+	defer fake.runHooksMutex.Unlock()
+	fake.RunHooksStub = stub
+}
+
+func (fake *FakeNginxServiceInterface) RunHooksArgsForCall(i int) (context.Context, model.ConfigApplyHookPhase, *model.ConfigApplyHooks) {
+	fake.runHooksMutex.RLock()
+	defer fake.runHooksMutex.RUnlock()
+	argsForCall := fake.runHooksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeNginxServiceInterface) RunHooksReturns(result1 error) {
+	fake.runHooksMutex.Lock()
+	defer fake.runHooksMutex.Unlock()
+	fake.RunHooksStub = nil
+	fake.runHooksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNginxServiceInterface) RunHooksReturnsOnCall(i int, result1 error) {
+	fake.runHooksMutex.Lock()
+	defer fake.runHooksMutex.Unlock()
+	fake.RunHooksStub = nil
+	if fake.runHooksReturnsOnCall == nil {
+		fake.runHooksReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.runHooksReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNginxServiceInterface) TemplateData(arg1 string) *file.TemplateData {
+	fake.templateDataMutex.Lock()
+	ret, specificReturn := fake.templateDataReturnsOnCall[len(fake.templateDataArgsForCall)]
+	fake.templateDataArgsForCall = append(fake.templateDataArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.TemplateDataStub
+	fakeReturns := fake.templateDataReturns
+	fake.recordInvocation("TemplateData", []interface{}{arg1})
+	fake.templateDataMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNginxServiceInterface) TemplateDataCallCount() int {
+	fake.templateDataMutex.RLock()
+	defer fake.templateDataMutex.RUnlock()
+	return len(fake.templateDataArgsForCall)
+}
+
+func (fake *FakeNginxServiceInterface) TemplateDataCalls(stub func(string) *file.TemplateData) {
+	fake.templateDataMutex.Lock()
+	defer fake.templateDataMutex.Unlock()
+	fake.TemplateDataStub = stub
+}
+
+func (fake *FakeNginxServiceInterface) TemplateDataArgsForCall(i int) string {
+	fake.templateDataMutex.RLock()
+	defer fake.templateDataMutex.RUnlock()
+	argsForCall := fake.templateDataArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeNginxServiceInterface) TemplateDataReturns(result1 *file.TemplateData) {
+	fake.templateDataMutex.Lock()
+	defer fake.templateDataMutex.Unlock()
+	fake.TemplateDataStub = nil
+	fake.templateDataReturns = struct {
+		result1 *file.TemplateData
+	}{result1}
+}
+
+func (fake *FakeNginxServiceInterface) TemplateDataReturnsOnCall(i int, result1 *file.TemplateData) {
+	fake.templateDataMutex.Lock()
+	defer fake.templateDataMutex.Unlock()
+	fake.TemplateDataStub = nil
+	if fake.templateDataReturnsOnCall == nil {
+		fake.templateDataReturnsOnCall = make(map[int]struct {
+			result1 *file.TemplateData
+		})
+	}
+	fake.templateDataReturnsOnCall[i] = struct {
+		result1 *file.TemplateData
+	}{result1}
+}
+
+func (fake *FakeNginxServiceInterface) UnitConfig(arg1 context.Context, arg2 *v1.Instance) ([]byte, error) {
+	fake.unitConfigMutex.Lock()
+	ret, specificReturn := fake.unitConfigReturnsOnCall[len(fake.unitConfigArgsForCall)]
+	fake.unitConfigArgsForCall = append(fake.unitConfigArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1.Instance
+	}{arg1, arg2})
+	stub := fake.UnitConfigStub
+	fakeReturns := fake.unitConfigReturns
+	fake.recordInvocation("UnitConfig", []interface{}{arg1, arg2})
+	fake.unitConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNginxServiceInterface) UnitConfigCallCount() int {
+	fake.unitConfigMutex.RLock()
+	defer fake.unitConfigMutex.RUnlock()
+	return len(fake.unitConfigArgsForCall)
+}
+
+func (fake *FakeNginxServiceInterface) UnitConfigCalls(stub func(context.Context, *v1.Instance) ([]byte, error)) {
+	fake.unitConfigMutex.Lock()
+	defer fake.unitConfigMutex.Unlock()
+	fake.UnitConfigStub = stub
+}
+
+func (fake *FakeNginxServiceInterface) UnitConfigArgsForCall(i int) (context.Context, *v1.Instance) {
+	fake.unitConfigMutex.RLock()
+	defer fake.unitConfigMutex.RUnlock()
+	argsForCall := fake.unitConfigArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNginxServiceInterface) UnitConfigReturns(result1 []byte, result2 error) {
+	fake.unitConfigMutex.Lock()
+	defer fake.unitConfigMutex.Unlock()
+	fake.UnitConfigStub = nil
+	fake.unitConfigReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNginxServiceInterface) UnitConfigReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.unitConfigMutex.Lock()
+	defer fake.unitConfigMutex.Unlock()
+	fake.UnitConfigStub = nil
+	if fake.unitConfigReturnsOnCall == nil {
+		fake.unitConfigReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.unitConfigReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeNginxServiceInterface) UpdateHTTPUpstreamServers(arg1 context.Context, arg2 *v1.Instance, arg3 string, arg4 []*structpb.Struct) ([]client.UpstreamServer, []client.UpstreamServer, []client.UpstreamServer, error) {
 	var arg4Copy []*structpb.Struct
 	if arg4 != nil {
@@ -676,6 +1009,139 @@ func (fake *FakeNginxServiceInterface) UpdateStreamServersReturnsOnCall(i int, r
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeNginxServiceInterface) UpdateUnitConfig(arg1 context.Context, arg2 *v1.Instance, arg3 []byte) error {
+	fake.updateUnitConfigMutex.Lock()
+	ret, specificReturn := fake.updateUnitConfigReturnsOnCall[len(fake.updateUnitConfigArgsForCall)]
+	fake.updateUnitConfigArgsForCall = append(fake.updateUnitConfigArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1.Instance
+		arg3 []byte
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateUnitConfigStub
+	fakeReturns := fake.updateUnitConfigReturns
+	fake.recordInvocation("UpdateUnitConfig", []interface{}{arg1, arg2, arg3})
+	fake.updateUnitConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNginxServiceInterface) UpdateUnitConfigCallCount() int {
+	fake.updateUnitConfigMutex.RLock()
+	defer fake.updateUnitConfigMutex.RUnlock()
+	return len(fake.updateUnitConfigArgsForCall)
+}
+
+func (fake *FakeNginxServiceInterface) UpdateUnitConfigCalls(stub func(context.Context, *v1.Instance, []byte) error) {
+	fake.updateUnitConfigMutex.Lock()
+	defer fake.updateUnitConfigMutex.Unlock()
+	fake.UpdateUnitConfigStub = stub
+}
+
+func (fake *FakeNginxServiceInterface) UpdateUnitConfigArgsForCall(i int) (context.Context, *v1.Instance, []byte) {
+	fake.updateUnitConfigMutex.RLock()
+	defer fake.updateUnitConfigMutex.RUnlock()
+	argsForCall := fake.updateUnitConfigArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeNginxServiceInterface) UpdateUnitConfigReturns(result1 error) {
+	fake.updateUnitConfigMutex.Lock()
+	defer fake.updateUnitConfigMutex.Unlock()
+	fake.UpdateUnitConfigStub = nil
+	fake.updateUnitConfigReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNginxServiceInterface) UpdateUnitConfigReturnsOnCall(i int, result1 error) {
+	fake.updateUnitConfigMutex.Lock()
+	defer fake.updateUnitConfigMutex.Unlock()
+	fake.UpdateUnitConfigStub = nil
+	if fake.updateUnitConfigReturnsOnCall == nil {
+		fake.updateUnitConfigReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateUnitConfigReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNginxServiceInterface) ValidateStagedConfig(arg1 context.Context, arg2 string, arg3 string, arg4 string) (string, []string, error) {
+	fake.validateStagedConfigMutex.Lock()
+	ret, specificReturn := fake.validateStagedConfigReturnsOnCall[len(fake.validateStagedConfigArgsForCall)]
+	fake.validateStagedConfigArgsForCall = append(fake.validateStagedConfigArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ValidateStagedConfigStub
+	fakeReturns := fake.validateStagedConfigReturns
+	fake.recordInvocation("ValidateStagedConfig", []interface{}{arg1, arg2, arg3, arg4})
+	fake.validateStagedConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeNginxServiceInterface) ValidateStagedConfigCallCount() int {
+	fake.validateStagedConfigMutex.RLock()
+	defer fake.validateStagedConfigMutex.RUnlock()
+	return len(fake.validateStagedConfigArgsForCall)
+}
+
+func (fake *FakeNginxServiceInterface) ValidateStagedConfigCalls(stub func(context.Context, string, string, string) (string, []string, error)) {
+	fake.validateStagedConfigMutex.Lock()
+	defer fake.validateStagedConfigMutex.Unlock()
+	fake.ValidateStagedConfigStub = stub
+}
+
+func (fake *FakeNginxServiceInterface) ValidateStagedConfigArgsForCall(i int) (context.Context, string, string, string) {
+	fake.validateStagedConfigMutex.RLock()
+	defer fake.validateStagedConfigMutex.RUnlock()
+	argsForCall := fake.validateStagedConfigArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeNginxServiceInterface) ValidateStagedConfigReturns(result1 string, result2 []string, result3 error) {
+	fake.validateStagedConfigMutex.Lock()
+	defer fake.validateStagedConfigMutex.Unlock()
+	fake.ValidateStagedConfigStub = nil
+	fake.validateStagedConfigReturns = struct {
+		result1 string
+		result2 []string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeNginxServiceInterface) ValidateStagedConfigReturnsOnCall(i int, result1 string, result2 []string, result3 error) {
+	fake.validateStagedConfigMutex.Lock()
+	defer fake.validateStagedConfigMutex.Unlock()
+	fake.ValidateStagedConfigStub = nil
+	if fake.validateStagedConfigReturnsOnCall == nil {
+		fake.validateStagedConfigReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 []string
+			result3 error
+		})
+	}
+	fake.validateStagedConfigReturnsOnCall[i] = struct {
+		result1 string
+		result2 []string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeNginxServiceInterface) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -689,12 +1155,24 @@ func (fake *FakeNginxServiceInterface) Invocations() map[string][][]interface{}
 	defer fake.getUpstreamsMutex.RUnlock()
 	fake.instanceMutex.RLock()
 	defer fake.instanceMutex.RUnlock()
+	fake.probeMutex.RLock()
+	defer fake.probeMutex.RUnlock()
+	fake.runHooksMutex.RLock()
+	defer fake.runHooksMutex.RUnlock()
+	fake.templateDataMutex.RLock()
+	defer fake.templateDataMutex.RUnlock()
+	fake.unitConfigMutex.RLock()
+	defer fake.unitConfigMutex.RUnlock()
 	fake.updateHTTPUpstreamServersMutex.RLock()
 	defer fake.updateHTTPUpstreamServersMutex.RUnlock()
 	fake.updateResourceMutex.RLock()
 	defer fake.updateResourceMutex.RUnlock()
 	fake.updateStreamServersMutex.RLock()
 	defer fake.updateStreamServersMutex.RUnlock()
+	fake.updateUnitConfigMutex.RLock()
+	defer fake.updateUnitConfigMutex.RUnlock()
+	fake.validateStagedConfigMutex.RLock()
+	defer fake.validateStagedConfigMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,194 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package nginx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/bus"
+	response "github.com/nginx/agent/v3/internal/datasource/proto"
+	"github.com/nginx/agent/v3/internal/model"
+	"github.com/nginx/agent/v3/pkg/files"
+)
+
+func isUnitInstance(instance *mpi.Instance) bool {
+	return instance.GetInstanceMeta().GetInstanceType() == mpi.InstanceMeta_INSTANCE_TYPE_UNIT
+}
+
+// applyUnitConfig applies the JSON config of an NGINX Unit instance through the Unit control API. The current
+// config is read before it is replaced, and restored if the new config can't be applied.
+func (n *NginxPlugin) applyUnitConfig(ctx context.Context, correlationID string, instance *mpi.Instance,
+	overview *mpi.FileOverview,
+) {
+	instanceID := instance.GetInstanceMeta().GetInstanceId()
+
+	unitConfig, err := n.unitConfigFromOverview(ctx, overview)
+	if err == nil {
+		var previousConfig []byte
+		previousConfig, err = n.nginxService.UnitConfig(ctx, instance)
+		if err == nil {
+			n.updateUnitConfig(ctx, correlationID, instance, unitConfig, previousConfig)
+			return
+		}
+	}
+
+	slog.ErrorContext(ctx, "Failed to apply NGINX Unit config", "instance_id", instanceID, "error", err)
+
+	dpResponse := response.CreateDataPlaneResponse(
+		correlationID,
+		&mpi.CommandResponse{
+			Status:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+			Message: "Config apply failed",
+			Error:   err.Error(),
+		},
+		mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST,
+		instanceID,
+	)
+
+	n.completeConfigApply(ctx, &model.NginxConfigContext{}, dpResponse)
+}
+
+func (n *NginxPlugin) updateUnitConfig(ctx context.Context, correlationID string, instance *mpi.Instance,
+	unitConfig, previousConfig []byte,
+) {
+	instanceID := instance.GetInstanceMeta().GetInstanceId()
+
+	if equal, _ := jsonEqual(unitConfig, previousConfig); equal {
+		slog.DebugContext(ctx, "No changes required for NGINX Unit config apply request")
+
+		dpResponse := response.CreateDataPlaneResponse(
+			correlationID,
+			&mpi.CommandResponse{
+				Status:  mpi.CommandResponse_COMMAND_STATUS_OK,
+				Message: "Config apply successful, no config to change",
+			},
+			mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST,
+			instanceID,
+		)
+		n.completeConfigApply(ctx, &model.NginxConfigContext{}, dpResponse)
+
+		return
+	}
+
+	applyErr := n.nginxService.UpdateUnitConfig(ctx, instance, unitConfig)
+	if applyErr == nil {
+		dpResponse := response.CreateDataPlaneResponse(
+			correlationID,
+			&mpi.CommandResponse{
+				Status:  mpi.CommandResponse_COMMAND_STATUS_OK,
+				Message: "Config apply successful",
+			},
+			mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST,
+			instanceID,
+		)
+		n.completeConfigApply(ctx, &model.NginxConfigContext{}, dpResponse)
+
+		return
+	}
+
+	slog.ErrorContext(ctx, "Failed to apply NGINX Unit config, rolling back", "instance_id", instanceID,
+		"error", applyErr)
+
+	dpResponse := response.CreateDataPlaneResponse(
+		correlationID,
+		&mpi.CommandResponse{
+			Status:  mpi.CommandResponse_COMMAND_STATUS_ERROR,
+			Message: "Config apply failed, rolling back config",
+			Error:   applyErr.Error(),
+		},
+		mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST,
+		instanceID,
+	)
+	n.messagePipe.Process(ctx, &bus.Message{Topic: bus.DataPlaneResponseTopic, Data: dpResponse})
+
+	if rollbackErr := n.nginxService.UpdateUnitConfig(ctx, instance, previousConfig); rollbackErr != nil {
+		slog.ErrorContext(ctx, "Failed to roll back NGINX Unit config", "instance_id", instanceID,
+			"error", rollbackErr)
+
+		combinedErr := errors.Join(
+			fmt.Errorf("config apply error: %w", applyErr),
+			fmt.Errorf("rollback error: %w", rollbackErr),
+		)
+
+		dpResponse = response.CreateDataPlaneResponse(
+			correlationID,
+			&mpi.CommandResponse{
+				Status:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+				Message: "Config apply failed, rollback failed",
+				Error:   combinedErr.Error(),
+			},
+			mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST,
+			instanceID,
+		)
+		n.completeConfigApply(ctx, &model.NginxConfigContext{}, dpResponse)
+
+		return
+	}
+
+	dpResponse = response.CreateDataPlaneResponse(
+		correlationID,
+		&mpi.CommandResponse{
+			Status:  mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+			Message: "Config apply failed, rollback successful",
+			Error:   applyErr.Error(),
+		},
+		mpi.DataPlaneResponse_CONFIG_APPLY_REQUEST,
+		instanceID,
+	)
+	n.completeConfigApply(ctx, &model.NginxConfigContext{}, dpResponse)
+}
+
+// unitConfigFromOverview gets the JSON config of an NGINX Unit instance, which is the only file in the overview
+// of a config apply request for Unit, from the management plane
+func (n *NginxPlugin) unitConfigFromOverview(ctx context.Context, overview *mpi.FileOverview) ([]byte, error) {
+	if len(overview.GetFiles()) != 1 || overview.GetFiles()[0].GetFileMeta().GetName() != unitConfigFileName {
+		return nil, fmt.Errorf("NGINX Unit config apply request must contain only the file %s",
+			unitConfigFileName)
+	}
+
+	unitConfigFile := overview.GetFiles()[0]
+
+	unitConfig, err := n.fileManagerService.FileContents(ctx, unitConfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if hash := files.GenerateHash(unitConfig); hash != unitConfigFile.GetFileMeta().GetHash() {
+		return nil, fmt.Errorf("NGINX Unit config hash does not match, expected hash: %s actual hash: %s",
+			unitConfigFile.GetFileMeta().GetHash(), hash)
+	}
+
+	if !json.Valid(unitConfig) {
+		return nil, errors.New("NGINX Unit config is not valid JSON")
+	}
+
+	return unitConfig, nil
+}
+
+// uploadUnitConfig sends the JSON config of an NGINX Unit instance, read from the Unit control API, to the
+// management plane
+func (n *NginxPlugin) uploadUnitConfig(ctx context.Context, instance *mpi.Instance) error {
+	unitConfig, err := n.nginxService.UnitConfig(ctx, instance)
+	if err != nil {
+		return err
+	}
+
+	unitConfigFile := &mpi.File{
+		FileMeta: &mpi.FileMeta{
+			Name: unitConfigFileName,
+			Hash: files.GenerateHash(unitConfig),
+			Size: int64(len(unitConfig)),
+		},
+	}
+
+	return n.fileManagerService.UploadFileContents(ctx, instance.GetInstanceMeta().GetInstanceId(),
+		unitConfigFile, unitConfig)
+}
@@ -0,0 +1,220 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package nginx
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/bus"
+	"github.com/nginx/agent/v3/internal/bus/busfakes"
+	"github.com/nginx/agent/v3/internal/file/filefakes"
+	"github.com/nginx/agent/v3/internal/grpc/grpcfakes"
+	"github.com/nginx/agent/v3/internal/model"
+	"github.com/nginx/agent/v3/pkg/files"
+	"github.com/nginx/agent/v3/test/protos"
+	"github.com/nginx/agent/v3/test/types"
+)
+
+func unitConfigOverview(instanceID, hash string) *mpi.FileOverview {
+	return &mpi.FileOverview{
+		Files: []*mpi.File{
+			{FileMeta: &mpi.FileMeta{Name: unitConfigFileName, Hash: hash}},
+		},
+		ConfigVersion: &mpi.ConfigVersion{InstanceId: instanceID, Version: "unit-config-version"},
+	}
+}
+
+func dataPlaneResponses(t *testing.T, messages []*bus.Message) []*mpi.DataPlaneResponse {
+	t.Helper()
+
+	var responses []*mpi.DataPlaneResponse
+	for _, message := range messages {
+		if message.Topic != bus.DataPlaneResponseTopic {
+			continue
+		}
+
+		response, ok := message.Data.(*mpi.DataPlaneResponse)
+		require.True(t, ok)
+		responses = append(responses, response)
+	}
+
+	return responses
+}
+
+func TestNginx_Process_handleConfigApplyRequest_Unit(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name             string
+		unitConfig       string
+		hash             string
+		readBack         string
+		expectedConfig   string
+		expectedMessages []string
+		expectedStatus   []mpi.CommandResponse_CommandStatus
+		expectedPuts     int
+	}{
+		{
+			name:             "Test 1: config applied",
+			unitConfig:       testUpdatedUnitConfig,
+			expectedConfig:   testUpdatedUnitConfig,
+			expectedMessages: []string{"Config apply successful"},
+			expectedStatus:   []mpi.CommandResponse_CommandStatus{mpi.CommandResponse_COMMAND_STATUS_OK},
+			expectedPuts:     1,
+		},
+		{
+			name:             "Test 2: config unchanged",
+			unitConfig:       testUnitConfig,
+			expectedConfig:   testUnitConfig,
+			expectedMessages: []string{"Config apply successful, no config to change"},
+			expectedStatus:   []mpi.CommandResponse_CommandStatus{mpi.CommandResponse_COMMAND_STATUS_OK},
+		},
+		{
+			name:           "Test 3: invalid config is rolled back",
+			unitConfig:     `{"listeners":{"*:8080":{}}}`,
+			expectedConfig: testUnitConfig,
+			expectedMessages: []string{
+				"Config apply failed, rolling back config",
+				"Config apply failed, rollback successful",
+			},
+			expectedStatus: []mpi.CommandResponse_CommandStatus{
+				mpi.CommandResponse_COMMAND_STATUS_ERROR,
+				mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+			},
+			expectedPuts: 2,
+		},
+		{
+			name:           "Test 4: rollback fails",
+			unitConfig:     testUpdatedUnitConfig,
+			readBack:       `{}`,
+			expectedConfig: testUnitConfig,
+			expectedMessages: []string{
+				"Config apply failed, rolling back config",
+				"Config apply failed, rollback failed",
+			},
+			expectedStatus: []mpi.CommandResponse_CommandStatus{
+				mpi.CommandResponse_COMMAND_STATUS_ERROR,
+				mpi.CommandResponse_COMMAND_STATUS_FAILURE,
+			},
+			expectedPuts: 2,
+		},
+		{
+			name:             "Test 5: hash does not match",
+			unitConfig:       testUpdatedUnitConfig,
+			hash:             files.GenerateHash([]byte(testUnitConfig)),
+			expectedConfig:   testUnitConfig,
+			expectedMessages: []string{"Config apply failed"},
+			expectedStatus:   []mpi.CommandResponse_CommandStatus{mpi.CommandResponse_COMMAND_STATUS_FAILURE},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			unitControlAPI := &fakeUnitControlAPI{config: testUnitConfig, readBack: test.readBack}
+			instance := unitInstance(unixUnitControlAPI(tt, unitControlAPI))
+			instanceID := instance.GetInstanceMeta().GetInstanceId()
+
+			hash := test.hash
+			if hash == "" {
+				hash = files.GenerateHash([]byte(test.unitConfig))
+			}
+
+			fakeFileManagerService := &filefakes.FakeFileManagerServiceInterface{}
+			fakeFileManagerService.FileContentsReturns([]byte(test.unitConfig), nil)
+			messagePipe := busfakes.NewFakeMessagePipe()
+
+			nginxPlugin := NewNginx(types.AgentConfig(), &grpcfakes.FakeGrpcConnectionInterface{}, model.Command,
+				&sync.RWMutex{})
+			require.NoError(tt, nginxPlugin.Init(ctx, messagePipe))
+			nginxPlugin.fileManagerService = fakeFileManagerService
+			nginxPlugin.nginxService = &NginxService{
+				agentConfig: types.AgentConfig(),
+				resource:    &mpi.Resource{Instances: []*mpi.Instance{instance}},
+			}
+
+			nginxPlugin.Process(ctx, &bus.Message{
+				Topic: bus.ConfigApplyRequestTopic,
+				Data: &mpi.ManagementPlaneRequest{
+					Request: &mpi.ManagementPlaneRequest_ConfigApplyRequest{
+						ConfigApplyRequest: protos.CreateConfigApplyRequest(unitConfigOverview(instanceID, hash)),
+					},
+				},
+			})
+
+			responses := dataPlaneResponses(tt, messagePipe.Messages())
+			require.Len(tt, responses, len(test.expectedMessages))
+			for i, response := range responses {
+				assert.Equal(tt, test.expectedMessages[i], response.GetCommandResponse().GetMessage())
+				assert.Equal(tt, test.expectedStatus[i], response.GetCommandResponse().GetStatus())
+				assert.Equal(tt, instanceID, response.GetInstanceId())
+			}
+
+			assert.Equal(tt, 0, fakeFileManagerService.ConfigApplyCallCount())
+			assert.Equal(tt, 1, fakeFileManagerService.ClearCacheCallCount())
+
+			puts := 0
+			for _, request := range unitControlAPI.requests {
+				if request == "PUT /config" {
+					puts++
+				}
+			}
+			assert.Equal(tt, test.expectedPuts, puts)
+
+			assert.JSONEq(tt, test.expectedConfig, unitControlAPI.config)
+		})
+	}
+}
+
+func TestNginx_Process_handleConfigUploadRequest_Unit(t *testing.T) {
+	ctx := context.Background()
+
+	unitControlAPI := &fakeUnitControlAPI{config: testUnitConfig}
+	instance := unitInstance(unixUnitControlAPI(t, unitControlAPI))
+	instanceID := instance.GetInstanceMeta().GetInstanceId()
+
+	fakeFileManagerService := &filefakes.FakeFileManagerServiceInterface{}
+	messagePipe := busfakes.NewFakeMessagePipe()
+
+	nginxPlugin := NewNginx(types.AgentConfig(), &grpcfakes.FakeGrpcConnectionInterface{}, model.Command,
+		&sync.RWMutex{})
+	require.NoError(t, nginxPlugin.Init(ctx, messagePipe))
+	nginxPlugin.fileManagerService = fakeFileManagerService
+	nginxPlugin.nginxService = &NginxService{
+		agentConfig: types.AgentConfig(),
+		resource:    &mpi.Resource{Instances: []*mpi.Instance{instance}},
+	}
+
+	nginxPlugin.Process(ctx, &bus.Message{
+		Topic: bus.ConfigUploadRequestTopic,
+		Data: &mpi.ManagementPlaneRequest{
+			Request: &mpi.ManagementPlaneRequest_ConfigUploadRequest{
+				ConfigUploadRequest: &mpi.ConfigUploadRequest{
+					Overview: unitConfigOverview(instanceID, ""),
+				},
+			},
+		},
+	})
+
+	responses := dataPlaneResponses(t, messagePipe.Messages())
+	require.Len(t, responses, 1)
+	assert.Equal(t, mpi.CommandResponse_COMMAND_STATUS_OK, responses[0].GetCommandResponse().GetStatus())
+	assert.Equal(t, instanceID, responses[0].GetInstanceId())
+
+	assert.Equal(t, 0, fakeFileManagerService.ConfigUploadCallCount())
+	require.Equal(t, 1, fakeFileManagerService.UploadFileContentsCallCount())
+
+	_, uploadInstanceID, uploadFile, contents := fakeFileManagerService.UploadFileContentsArgsForCall(0)
+	assert.Equal(t, instanceID, uploadInstanceID)
+	assert.Equal(t, unitConfigFileName, uploadFile.GetFileMeta().GetName())
+	assert.Equal(t, files.GenerateHash([]byte(testUnitConfig)), uploadFile.GetFileMeta().GetHash())
+	assert.Equal(t, testUnitConfig, string(contents))
+}
@@ -0,0 +1,147 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package nginx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+)
+
+const (
+	unixUnitControlAPIFormat = "http://unit-control-api%s"
+	unitConfigPath           = "/config"
+	// the name of the virtual file that holds the JSON config of an NGINX Unit instance in config apply and
+	// config upload requests, since Unit is configured through its control API and not through files
+	unitConfigFileName = unitConfigPath
+)
+
+var errUnitConfigNotApplied = errors.New("NGINX Unit config does not match the applied config")
+
+// unitAPIErr is the error returned by the NGINX Unit control API
+type unitAPIErr struct {
+	Error  string `json:"error"`
+	Detail string `json:"detail"`
+}
+
+// UnitConfig gets the JSON config of an NGINX Unit instance from its control API
+func (n *NginxService) UnitConfig(ctx context.Context, instance *mpi.Instance) ([]byte, error) {
+	return n.unitControlAPIRequest(ctx, instance, http.MethodGet, nil)
+}
+
+// UpdateUnitConfig replaces the JSON config of an NGINX Unit instance through its control API. Unit only applies
+// a config that is valid as a whole, so the config is read back afterwards to verify it was applied.
+func (n *NginxService) UpdateUnitConfig(ctx context.Context, instance *mpi.Instance, unitConfig []byte) error {
+	slog.InfoContext(ctx, "Updating NGINX Unit config", "instance_id", instance.GetInstanceMeta().GetInstanceId())
+
+	if _, err := n.unitControlAPIRequest(ctx, instance, http.MethodPut, unitConfig); err != nil {
+		return err
+	}
+
+	appliedConfig, err := n.UnitConfig(ctx, instance)
+	if err != nil {
+		return fmt.Errorf("unable to verify NGINX Unit config: %w", err)
+	}
+
+	equal, err := jsonEqual(unitConfig, appliedConfig)
+	if err != nil {
+		return fmt.Errorf("unable to verify NGINX Unit config: %w", err)
+	}
+
+	if !equal {
+		return errUnitConfigNotApplied
+	}
+
+	return nil
+}
+
+func (n *NginxService) unitControlAPIRequest(ctx context.Context, instance *mpi.Instance, method string,
+	body []byte,
+) ([]byte, error) {
+	controlSocket := instance.GetInstanceRuntime().GetUnitRuntimeInfo().GetControlSocket()
+	if controlSocket == "" {
+		return nil, errors.New("NGINX Unit control socket is not known")
+	}
+
+	timeout := n.agentConfig.Client.HTTP.Timeout
+	if timeout <= 0 {
+		timeout = defaultPlusAPITimeout
+	}
+
+	httpClient := &http.Client{Timeout: timeout}
+	endpoint := fmt.Sprintf(apiFormat, controlSocket, unitConfigPath)
+	if socketPath, ok := strings.CutPrefix(controlSocket, "unix:"); ok {
+		httpClient = socketClient(timeout, socketPath)
+		endpoint = fmt.Sprintf(unixUnitControlAPIFormat, unitConfigPath)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create NGINX Unit control API request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send NGINX Unit control API request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NGINX Unit control API response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, createUnitAPIError(resp.StatusCode, respBody)
+	}
+
+	return respBody, nil
+}
+
+// createUnitAPIError converts an error response of the NGINX Unit control API, e.g.
+// {"error": "Invalid configuration.", "detail": "Required parameter \"pass\" is missing."}
+func createUnitAPIError(statusCode int, body []byte) error {
+	var apiErr unitAPIErr
+	if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Error == "" {
+		return fmt.Errorf("NGINX Unit control API request failed with status code %d: %s", statusCode,
+			strings.TrimSpace(string(body)))
+	}
+
+	if apiErr.Detail == "" {
+		return fmt.Errorf("NGINX Unit control API request failed with status code %d: %s", statusCode,
+			apiErr.Error)
+	}
+
+	return fmt.Errorf("NGINX Unit control API request failed with status code %d: %s %s", statusCode,
+		apiErr.Error, apiErr.Detail)
+}
+
+func jsonEqual(a, b []byte) (bool, error) {
+	var aValue, bValue any
+
+	if err := json.Unmarshal(a, &aValue); err != nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal(b, &bValue); err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(aValue, bValue), nil
+}
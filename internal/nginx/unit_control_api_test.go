@@ -0,0 +1,222 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package nginx
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/test/types"
+)
+
+const (
+	testUnitConfig = `{"listeners":{"*:8080":{"pass":"applications/flask"}},` +
+		`"applications":{"flask":{"type":"python","path":"/www","module":"wsgi"}}}`
+	testUpdatedUnitConfig = `{"listeners":{"*:8080":{"pass":"routes"}},` +
+		`"routes":[{"action":{"return":200}}]}`
+)
+
+// fakeUnitControlAPI is a stand-in for the /config endpoint of the NGINX Unit control API. Like Unit, it rejects
+// listeners without a pass option and keeps the current config if a new config is rejected.
+type fakeUnitControlAPI struct {
+	config   string
+	requests []string
+	mutex    sync.Mutex
+	// config returned instead of the config once it was changed, to simulate a config that was not applied
+	readBack string
+	changed  bool
+}
+
+func (f *fakeUnitControlAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	if r.URL.Path != unitConfigPath {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": "Value doesn't exist."}`))
+
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if f.changed && f.readBack != "" {
+			_, _ = w.Write([]byte(f.readBack))
+			return
+		}
+
+		_, _ = w.Write([]byte(f.config))
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+
+		var unitConfig struct {
+			Listeners map[string]map[string]any `json:"listeners"`
+		}
+		if err := json.Unmarshal(body, &unitConfig); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "Invalid JSON."}`))
+
+			return
+		}
+
+		for name, listener := range unitConfig.Listeners {
+			if _, ok := listener["pass"]; !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error": "Invalid configuration.", "detail": "The \"` + name +
+					`\" listener must have the \"pass\" option set."}`))
+
+				return
+			}
+		}
+
+		f.config = string(body)
+		f.changed = true
+		_, _ = w.Write([]byte(`{"success": "Reconfiguration done."}`))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_, _ = w.Write([]byte(`{"error": "Invalid method."}`))
+	}
+}
+
+// unixUnitControlAPI serves the fake control API on a Unix socket and returns the control socket of the instance
+func unixUnitControlAPI(t *testing.T, handler http.Handler) string {
+	t.Helper()
+
+	// the temp dir of the test can be too long for a Unix socket path
+	socketDir, err := os.MkdirTemp("", "unit")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(socketDir) })
+
+	socketPath := filepath.Join(socketDir, "control.unit.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return "unix:" + socketPath
+}
+
+func unitInstance(controlSocket string) *mpi.Instance {
+	return &mpi.Instance{
+		InstanceMeta: &mpi.InstanceMeta{
+			InstanceId:   "unit-instance-id",
+			InstanceType: mpi.InstanceMeta_INSTANCE_TYPE_UNIT,
+			Version:      "1.34.1",
+		},
+		InstanceRuntime: &mpi.InstanceRuntime{
+			ProcessId:  1000,
+			BinaryPath: "/usr/sbin/unitd",
+			Details: &mpi.InstanceRuntime_UnitRuntimeInfo{
+				UnitRuntimeInfo: &mpi.UnitRuntimeInfo{
+					ControlSocket: controlSocket,
+					Version:       "1.34.1",
+				},
+			},
+		},
+	}
+}
+
+func TestNginxService_UnitConfig(t *testing.T) {
+	ctx := context.Background()
+	nginxService := &NginxService{agentConfig: types.AgentConfig()}
+
+	unitControlAPI := &fakeUnitControlAPI{config: testUnitConfig}
+
+	unitConfig, err := nginxService.UnitConfig(ctx, unitInstance(unixUnitControlAPI(t, unitControlAPI)))
+	require.NoError(t, err)
+	assert.JSONEq(t, testUnitConfig, string(unitConfig))
+
+	// the control API can also listen on a TCP socket
+	server := httptest.NewServer(unitControlAPI)
+	defer server.Close()
+
+	unitConfig, err = nginxService.UnitConfig(ctx, unitInstance(strings.TrimPrefix(server.URL, "http://")))
+	require.NoError(t, err)
+	assert.JSONEq(t, testUnitConfig, string(unitConfig))
+
+	_, err = nginxService.UnitConfig(ctx, unitInstance(""))
+	require.ErrorContains(t, err, "NGINX Unit control socket is not known")
+}
+
+func TestNginxService_UpdateUnitConfig(t *testing.T) {
+	ctx := context.Background()
+	nginxService := &NginxService{agentConfig: types.AgentConfig()}
+
+	tests := []struct {
+		name           string
+		unitConfig     string
+		readBack       string
+		expectedConfig string
+		expectedError  string
+	}{
+		{
+			name:           "Test 1: config applied",
+			unitConfig:     testUpdatedUnitConfig,
+			expectedConfig: testUpdatedUnitConfig,
+		},
+		{
+			name:           "Test 2: invalid config",
+			unitConfig:     `{"listeners":{"*:8080":{}}}`,
+			expectedConfig: testUnitConfig,
+			expectedError: "NGINX Unit control API request failed with status code 400: Invalid configuration. " +
+				`The "*:8080" listener must have the "pass" option set.`,
+		},
+		{
+			name:           "Test 3: config not applied",
+			unitConfig:     testUpdatedUnitConfig,
+			readBack:       testUnitConfig,
+			expectedConfig: testUpdatedUnitConfig,
+			expectedError:  errUnitConfigNotApplied.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			unitControlAPI := &fakeUnitControlAPI{config: testUnitConfig, readBack: test.readBack}
+			instance := unitInstance(unixUnitControlAPI(tt, unitControlAPI))
+
+			err := nginxService.UpdateUnitConfig(ctx, instance, []byte(test.unitConfig))
+			if test.expectedError != "" {
+				require.ErrorContains(tt, err, test.expectedError)
+			} else {
+				require.NoError(tt, err)
+			}
+
+			assert.JSONEq(tt, test.expectedConfig, unitControlAPI.config)
+		})
+	}
+}
+
+func TestCreateUnitAPIError(t *testing.T) {
+	err := createUnitAPIError(http.StatusBadRequest,
+		[]byte(`{"error": "Invalid configuration.", "detail": "Unknown parameter \"pas\"."}`))
+	require.EqualError(t, err, `NGINX Unit control API request failed with status code 400: `+
+		`Invalid configuration. Unknown parameter "pas".`)
+
+	err = createUnitAPIError(http.StatusNotFound, []byte(`{"error": "Value doesn't exist."}`))
+	require.EqualError(t, err, `NGINX Unit control API request failed with status code 404: Value doesn't exist.`)
+
+	err = createUnitAPIError(http.StatusInternalServerError, []byte("Internal Server Error\n"))
+	require.EqualError(t, err, `NGINX Unit control API request failed with status code 500: Internal Server Error`)
+}
@@ -35,6 +35,8 @@ type CredentialUpdateMessage struct {
 	CorrelationID  slog.Attr
 	GrpcConnection *grpc.GrpcConnection
 	ServerType     model.ServerType
+	// true if the credentials of the external data sources changed, in which case there is no new connection
+	ExternalDataSource bool
 }
 
 type CredentialWatcherService struct {
@@ -42,8 +44,11 @@ type CredentialWatcherService struct {
 	watcher           *fsnotify.Watcher
 	filesBeingWatched *sync.Map
 	filesChanged      *atomic.Bool
-	serverType        model.ServerType
-	watcherMutex      sync.Mutex
+	// credential files of the external data sources, which don't require a new connection when they change
+	dataSourceFiles        []string
+	dataSourceFilesChanged *atomic.Bool
+	serverType             model.ServerType
+	watcherMutex           sync.Mutex
 }
 
 func NewCredentialWatcherService(agentConfig *config.Config, serverType model.ServerType) *CredentialWatcherService {
@@ -51,11 +56,12 @@ func NewCredentialWatcherService(agentConfig *config.Config, serverType model.Se
 	filesChanged.Store(false)
 
 	return &CredentialWatcherService{
-		agentConfig:       agentConfig,
-		filesBeingWatched: &sync.Map{},
-		filesChanged:      filesChanged,
-		serverType:        serverType,
-		watcherMutex:      sync.Mutex{},
+		agentConfig:            agentConfig,
+		filesBeingWatched:      &sync.Map{},
+		filesChanged:           filesChanged,
+		dataSourceFilesChanged: &atomic.Bool{},
+		serverType:             serverType,
+		watcherMutex:           sync.Mutex{},
 	}
 }
 
@@ -84,6 +90,12 @@ func (cws *CredentialWatcherService) Watch(ctx context.Context, ch chan<- Creden
 	}
 
 	cws.watchFiles(newCtx, credentialPaths(commandServer))
+
+	// the external data sources are shared by the command and auxiliary servers, so only one watcher watches them
+	if cws.serverType == model.Command {
+		cws.dataSourceFiles = dataSourceCredentialPaths(cws.agentConfig.ExternalDataSource)
+		cws.watchFiles(newCtx, cws.dataSourceFiles)
+	}
 	cws.watcherMutex.Unlock()
 
 	for {
@@ -158,10 +170,31 @@ func (cws *CredentialWatcherService) handleEvent(ctx context.Context, event fsno
 		cws.addWatcher(ctx, event.Name)
 	}
 
+	if slices.Contains(cws.dataSourceFiles, event.Name) {
+		cws.dataSourceFilesChanged.Store(true)
+		return
+	}
+
 	cws.filesChanged.Store(true)
 }
 
 func (cws *CredentialWatcherService) checkForUpdates(ctx context.Context, ch chan<- CredentialUpdateMessage) {
+	if cws.dataSourceFilesChanged.Load() {
+		newCtx := context.WithValue(
+			ctx,
+			logger.CorrelationIDContextKey,
+			logger.GenerateCorrelationID(),
+		)
+
+		slog.InfoContext(newCtx, "Credential watcher has detected changes to external data source credentials")
+		ch <- CredentialUpdateMessage{
+			CorrelationID:      logger.CorrelationIDAttr(newCtx),
+			ServerType:         cws.serverType,
+			ExternalDataSource: true,
+		}
+		cws.dataSourceFilesChanged.Store(false)
+	}
+
 	if cws.filesChanged.Load() {
 		newCtx := context.WithValue(
 			ctx,
@@ -219,6 +252,14 @@ func credentialPaths(agentConfig *config.Command) []string {
 	return paths
 }
 
+func dataSourceCredentialPaths(externalDataSource *config.ExternalDataSource) []string {
+	if externalDataSource == nil || externalDataSource.S3 == nil || externalDataSource.S3.CredentialsFile == "" {
+		return nil
+	}
+
+	return []string{externalDataSource.S3.CredentialsFile}
+}
+
 func isEventSkippable(event fsnotify.Event) bool {
 	return event == emptyEvent ||
 		event.Name == "" ||
@@ -188,6 +188,35 @@ func TestCredentialWatcherService_handleEvent(t *testing.T) {
 	assert.True(t, cws.filesChanged.Load())
 }
 
+func TestCredentialWatcherService_handleEvent_DataSourceFiles(t *testing.T) {
+	ctx := context.Background()
+	cws := NewCredentialWatcherService(types.AgentConfig(), model.Command)
+	watcher, err := fsnotify.NewWatcher()
+	require.NoError(t, err)
+	cws.watcher = watcher
+	cws.dataSourceFiles = []string{"s3-credentials"}
+
+	cws.handleEvent(ctx, fsnotify.Event{Name: "s3-credentials", Op: fsnotify.Write})
+	assert.True(t, cws.dataSourceFilesChanged.Load())
+	assert.False(t, cws.filesChanged.Load())
+
+	ch := make(chan CredentialUpdateMessage, 1)
+	cws.checkForUpdates(ctx, ch)
+
+	update := <-ch
+	assert.True(t, update.ExternalDataSource)
+	assert.Nil(t, update.GrpcConnection)
+	assert.False(t, cws.dataSourceFilesChanged.Load())
+}
+
+func Test_dataSourceCredentialPaths(t *testing.T) {
+	assert.Nil(t, dataSourceCredentialPaths(nil))
+	assert.Nil(t, dataSourceCredentialPaths(&config.ExternalDataSource{S3: &config.S3DataSource{}}))
+	assert.Equal(t, []string{"/etc/nginx-agent/s3-credentials"}, dataSourceCredentialPaths(
+		&config.ExternalDataSource{S3: &config.S3DataSource{CredentialsFile: "/etc/nginx-agent/s3-credentials"}},
+	))
+}
+
 func Test_credentialPaths(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -44,7 +44,7 @@ type (
 
 func NewHealthWatcherService(agentConfig *config.Config) *HealthWatcherService {
 	return &HealthWatcherService{
-		watcher:     NewNginxHealthWatcher(),
+		watcher:     NewNginxHealthWatcher(agentConfig),
 		cache:       make(map[string]*mpi.InstanceHealth),
 		instances:   make(map[string]*mpi.Instance),
 		agentConfig: agentConfig,
@@ -9,6 +9,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/nginx/agent/v3/internal/config"
+	"github.com/nginx/agent/v3/internal/datasource/nginx"
 	"github.com/nginx/agent/v3/pkg/host/exec"
 
 	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
@@ -19,14 +21,16 @@ import (
 type NginxHealthWatcher struct {
 	executer        exec.ExecInterface
 	processOperator processwatcher.ProcessOperatorInterface
+	agentConfig     *config.Config
 }
 
 var _ healthWatcherOperator = (*NginxHealthWatcher)(nil)
 
-func NewNginxHealthWatcher() *NginxHealthWatcher {
+func NewNginxHealthWatcher(agentConfig *config.Config) *NginxHealthWatcher {
 	return &NginxHealthWatcher{
 		executer:        &exec.Exec{},
 		processOperator: processwatcher.NewProcessOperator(),
+		agentConfig:     agentConfig,
 	}
 }
 
@@ -36,6 +40,12 @@ func (nhw *NginxHealthWatcher) Health(ctx context.Context, instance *mpi.Instanc
 		InstanceHealthStatus: mpi.InstanceHealth_INSTANCE_HEALTH_STATUS_HEALTHY,
 	}
 
+	nginxInstance := nhw.agentConfig.NginxInstance(instance.GetInstanceRuntime().GetBinaryPath(),
+		instance.GetInstanceRuntime().GetConfigPath())
+	if nginxInstance != nil {
+		return declaredInstanceHealth(health, instance, nginxInstance.PIDFile), nil
+	}
+
 	proc, err := nhw.processOperator.Process(ctx, instance.GetInstanceRuntime().GetProcessId())
 	if nginxprocess.IsNotRunningErr(err) {
 		health.Description = fmt.Sprintf("PID: %d is not running", instance.GetInstanceRuntime().GetProcessId())
@@ -58,3 +68,33 @@ func (nhw *NginxHealthWatcher) Health(ctx context.Context, instance *mpi.Instanc
 
 	return health, nil
 }
+
+// declaredInstanceHealth checks the PID file of an instance declared in the agent configuration, and that the
+// process in the PID file is running the declared binary, since a stale PID file is left behind if NGINX is killed
+func declaredInstanceHealth(health *mpi.InstanceHealth, instance *mpi.Instance,
+	pidFile string,
+) *mpi.InstanceHealth {
+	pid, err := nginx.PIDFromFile(pidFile)
+	if err != nil {
+		health.Description = fmt.Sprintf("PID: %d is not running, %s", instance.GetInstanceRuntime().GetProcessId(),
+			err)
+		health.InstanceHealthStatus = mpi.InstanceHealth_INSTANCE_HEALTH_STATUS_UNHEALTHY
+
+		return health
+	}
+
+	if pid != instance.GetInstanceRuntime().GetProcessId() {
+		health.Description = fmt.Sprintf("PID: %d is not running, PID file %s contains PID %d",
+			instance.GetInstanceRuntime().GetProcessId(), pidFile, pid)
+		health.InstanceHealthStatus = mpi.InstanceHealth_INSTANCE_HEALTH_STATUS_UNHEALTHY
+
+		return health
+	}
+
+	if err = nginx.CheckProcessExe(pid, instance.GetInstanceRuntime().GetBinaryPath()); err != nil {
+		health.Description = fmt.Sprintf("PID: %d is not running, %s", pid, err)
+		health.InstanceHealthStatus = mpi.InstanceHealth_INSTANCE_HEALTH_STATUS_UNHEALTHY
+	}
+
+	return health
+}
@@ -8,14 +8,19 @@ package health
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/shirou/gopsutil/v4/process"
 
 	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/config"
 	"github.com/nginx/agent/v3/internal/watcher/process/processfakes"
 	"github.com/nginx/agent/v3/pkg/nginxprocess"
 	"github.com/nginx/agent/v3/test/protos"
+	"github.com/nginx/agent/v3/test/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
@@ -23,7 +28,7 @@ import (
 
 func TestNginxHealthWatcherOperator_Health(t *testing.T) {
 	ctx := context.Background()
-	nginxHealthWatcher := NewNginxHealthWatcher()
+	nginxHealthWatcher := NewNginxHealthWatcher(types.AgentConfig())
 	fakeProcessOperator := &processfakes.FakeProcessOperatorInterface{}
 	instance := protos.NginxOssInstance([]string{})
 	noChildrenInstance := protos.NginxOssInstance([]string{})
@@ -103,3 +108,68 @@ func TestNginxHealthWatcherOperator_Health(t *testing.T) {
 		})
 	}
 }
+
+func TestNginxHealthWatcherOperator_Health_DeclaredInstance(t *testing.T) {
+	ctx := context.Background()
+	instance := protos.NginxOssInstance([]string{})
+	instance.GetInstanceRuntime().InstanceChildren = []*mpi.InstanceChild{}
+
+	// the process of the instance is the test process, which is running the test binary
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	pid := int32(os.Getpid())
+	instance.GetInstanceRuntime().ProcessId = pid
+	instance.GetInstanceRuntime().BinaryPath = executable
+
+	// the PID file of an instance whose process is not running the instance binary is stale
+	staleInstance := protos.NginxOssInstance([]string{})
+	staleInstance.GetInstanceRuntime().ProcessId = pid
+	staleInstance.GetInstanceRuntime().BinaryPath = filepath.Join(t.TempDir(), "nginx")
+
+	pidFile := filepath.Join(t.TempDir(), "nginx.pid")
+
+	agentConfig := types.AgentConfig()
+	agentConfig.DataPlaneConfig.Nginx.Instances = []*config.NginxInstance{
+		{
+			BinaryPath: instance.GetInstanceRuntime().GetBinaryPath(),
+			ConfigPath: instance.GetInstanceRuntime().GetConfigPath(),
+			PIDFile:    pidFile,
+		},
+		{
+			BinaryPath: staleInstance.GetInstanceRuntime().GetBinaryPath(),
+			ConfigPath: staleInstance.GetInstanceRuntime().GetConfigPath(),
+			PIDFile:    pidFile,
+		},
+	}
+
+	fakeProcessOperator := &processfakes.FakeProcessOperatorInterface{}
+	fakeProcessOperator.ProcessReturns(nil, process.ErrorProcessNotRunning)
+
+	nginxHealthWatcher := NewNginxHealthWatcher(agentConfig)
+	nginxHealthWatcher.processOperator = fakeProcessOperator
+
+	// the worker processes of a declared instance aren't checked, since they might not be visible to the agent
+	require.NoError(t, os.WriteFile(pidFile, []byte(strconv.Itoa(int(pid))), 0o600))
+	instanceHealth, err := nginxHealthWatcher.Health(ctx, instance)
+	require.NoError(t, err)
+	assert.Equal(t, mpi.InstanceHealth_INSTANCE_HEALTH_STATUS_HEALTHY, instanceHealth.GetInstanceHealthStatus())
+	assert.Equal(t, 0, fakeProcessOperator.ProcessCallCount())
+
+	instanceHealth, err = nginxHealthWatcher.Health(ctx, staleInstance)
+	require.NoError(t, err)
+	assert.Equal(t, mpi.InstanceHealth_INSTANCE_HEALTH_STATUS_UNHEALTHY, instanceHealth.GetInstanceHealthStatus())
+	assert.Contains(t, instanceHealth.GetDescription(), "is not running NGINX binary")
+
+	require.NoError(t, os.WriteFile(pidFile, []byte("4321"), 0o600))
+	instanceHealth, err = nginxHealthWatcher.Health(ctx, instance)
+	require.NoError(t, err)
+	assert.Equal(t, mpi.InstanceHealth_INSTANCE_HEALTH_STATUS_UNHEALTHY, instanceHealth.GetInstanceHealthStatus())
+	assert.Equal(t, fmt.Sprintf("PID: %d is not running, PID file %s contains PID 4321", pid, pidFile),
+		instanceHealth.GetDescription())
+
+	require.NoError(t, os.Remove(pidFile))
+	instanceHealth, err = nginxHealthWatcher.Health(ctx, instance)
+	require.NoError(t, err)
+	assert.Equal(t, mpi.InstanceHealth_INSTANCE_HEALTH_STATUS_UNHEALTHY, instanceHealth.GetInstanceHealthStatus())
+	assert.Contains(t, instanceHealth.GetDescription(), "unable to read PID file")
+}
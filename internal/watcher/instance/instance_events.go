@@ -0,0 +1,260 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package instance
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/pkg/nginxprocess"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// instanceState is the part of an instance that is compared between checks for updates to detect instance events
+type instanceState struct {
+	version                string
+	attackSignatureVersion string
+	dynamicModules         []string
+	loadableModules        []string
+	// sorted process IDs of the worker processes of an NGINX instance that are not shutting down
+	workers   []int32
+	processID int32
+}
+
+// newInstanceStates returns the state of every instance except for the agent itself
+func newInstanceStates(
+	instances []*mpi.Instance,
+	processes []*nginxprocess.Process,
+) map[string]*instanceState {
+	states := make(map[string]*instanceState, len(instances))
+
+	for _, instance := range instances {
+		if instance.GetInstanceMeta().GetInstanceType() == mpi.InstanceMeta_INSTANCE_TYPE_AGENT {
+			continue
+		}
+
+		states[instance.GetInstanceMeta().GetInstanceId()] = newInstanceState(instance, processes)
+	}
+
+	return states
+}
+
+func newInstanceState(instance *mpi.Instance, processes []*nginxprocess.Process) *instanceState {
+	runtime := instance.GetInstanceRuntime()
+	state := &instanceState{
+		version:   instance.GetInstanceMeta().GetVersion(),
+		processID: runtime.GetProcessId(),
+	}
+
+	switch details := runtime.GetDetails().(type) {
+	case *mpi.InstanceRuntime_NginxRuntimeInfo:
+		state.dynamicModules = sortedModules(details.NginxRuntimeInfo.GetDynamicModules())
+		state.loadableModules = sortedModules(details.NginxRuntimeInfo.GetLoadableModules())
+		state.workers = workerProcessIDs(state.processID, processes)
+	case *mpi.InstanceRuntime_NginxPlusRuntimeInfo:
+		state.dynamicModules = sortedModules(details.NginxPlusRuntimeInfo.GetDynamicModules())
+		state.loadableModules = sortedModules(details.NginxPlusRuntimeInfo.GetLoadableModules())
+		state.workers = workerProcessIDs(state.processID, processes)
+	case *mpi.InstanceRuntime_UnitRuntimeInfo:
+		state.dynamicModules = sortedModules(details.UnitRuntimeInfo.GetModules())
+	case *mpi.InstanceRuntime_NginxAppProtectRuntimeInfo:
+		state.attackSignatureVersion = details.NginxAppProtectRuntimeInfo.GetAttackSignatureVersion()
+	}
+
+	return state
+}
+
+// instanceEvents compares the instance states of two checks for updates. A config reload is detected if all worker
+// processes of an NGINX instance were replaced while the master process kept running, unless the agent applied a
+// config since the previous check.
+func instanceEvents(
+	previousStates, currentStates map[string]*instanceState,
+	configApplied bool,
+) (events []*mpi.InstanceEvent) {
+	timestamp := timestamppb.Now()
+
+	for instanceID, current := range currentStates {
+		previous, ok := previousStates[instanceID]
+		if !ok {
+			events = append(events, instanceEvent(instanceID, mpi.InstanceEvent_INSTANCE_EVENT_TYPE_STARTED,
+				timestamp, lifecycleChanges(current, false)...))
+
+			continue
+		}
+
+		events = append(events, instanceChangeEvents(instanceID, previous, current, configApplied, timestamp)...)
+	}
+
+	for instanceID, previous := range previousStates {
+		if _, ok := currentStates[instanceID]; !ok {
+			events = append(events, instanceEvent(instanceID, mpi.InstanceEvent_INSTANCE_EVENT_TYPE_STOPPED,
+				timestamp, lifecycleChanges(previous, true)...))
+		}
+	}
+
+	slices.SortStableFunc(events, func(a, b *mpi.InstanceEvent) int {
+		return strings.Compare(a.GetInstanceId(), b.GetInstanceId())
+	})
+
+	return events
+}
+
+func instanceChangeEvents(
+	instanceID string,
+	previous, current *instanceState,
+	configApplied bool,
+	timestamp *timestamppb.Timestamp,
+) (events []*mpi.InstanceEvent) {
+	if previous.processID != current.processID {
+		events = append(events, instanceEvent(instanceID, mpi.InstanceEvent_INSTANCE_EVENT_TYPE_PROCESS_ID_CHANGED,
+			timestamp, processIDChange(previous.processID, current.processID)))
+	}
+
+	if previous.version != current.version {
+		events = append(events, instanceEvent(instanceID, mpi.InstanceEvent_INSTANCE_EVENT_TYPE_VERSION_CHANGED,
+			timestamp, &mpi.InstanceEventChange{Name: "version", Before: previous.version, After: current.version}))
+	}
+
+	var moduleChanges []*mpi.InstanceEventChange
+	if !slices.Equal(previous.dynamicModules, current.dynamicModules) {
+		moduleChanges = append(moduleChanges, modulesChange("dynamic_modules", previous.dynamicModules,
+			current.dynamicModules))
+	}
+
+	if !slices.Equal(previous.loadableModules, current.loadableModules) {
+		moduleChanges = append(moduleChanges, modulesChange("loadable_modules", previous.loadableModules,
+			current.loadableModules))
+	}
+
+	if len(moduleChanges) > 0 {
+		events = append(events, instanceEvent(instanceID, mpi.InstanceEvent_INSTANCE_EVENT_TYPE_MODULES_CHANGED,
+			timestamp, moduleChanges...))
+	}
+
+	if len(previous.workers) != len(current.workers) {
+		events = append(events, instanceEvent(instanceID, mpi.InstanceEvent_INSTANCE_EVENT_TYPE_WORKER_COUNT_CHANGED,
+			timestamp, &mpi.InstanceEventChange{
+				Name:   "worker_count",
+				Before: strconv.Itoa(len(previous.workers)),
+				After:  strconv.Itoa(len(current.workers)),
+			}))
+	}
+
+	if !configApplied && previous.processID == current.processID && workersReplaced(previous.workers, current.workers) {
+		events = append(events, instanceEvent(instanceID, mpi.InstanceEvent_INSTANCE_EVENT_TYPE_CONFIG_RELOADED,
+			timestamp, &mpi.InstanceEventChange{
+				Name:   "worker_process_ids",
+				Before: joinProcessIDs(previous.workers),
+				After:  joinProcessIDs(current.workers),
+			}))
+	}
+
+	if previous.attackSignatureVersion != current.attackSignatureVersion {
+		events = append(events, instanceEvent(instanceID,
+			mpi.InstanceEvent_INSTANCE_EVENT_TYPE_ATTACK_SIGNATURE_VERSION_CHANGED, timestamp,
+			&mpi.InstanceEventChange{
+				Name:   "attack_signature_version",
+				Before: previous.attackSignatureVersion,
+				After:  current.attackSignatureVersion,
+			}))
+	}
+
+	return events
+}
+
+func instanceEvent(
+	instanceID string,
+	eventType mpi.InstanceEvent_InstanceEventType,
+	timestamp *timestamppb.Timestamp,
+	changes ...*mpi.InstanceEventChange,
+) *mpi.InstanceEvent {
+	return &mpi.InstanceEvent{
+		InstanceId: instanceID,
+		EventType:  eventType,
+		Timestamp:  timestamp,
+		Changes:    changes,
+	}
+}
+
+// lifecycleChanges returns the values of a started instance as after values, or the values of a stopped instance
+// as before values
+func lifecycleChanges(state *instanceState, stopped bool) []*mpi.InstanceEventChange {
+	changes := []*mpi.InstanceEventChange{
+		{Name: "process_id", After: strconv.Itoa(int(state.processID))},
+		{Name: "version", After: state.version},
+	}
+
+	if stopped {
+		for _, change := range changes {
+			change.Before, change.After = change.After, ""
+		}
+	}
+
+	return changes
+}
+
+func processIDChange(before, after int32) *mpi.InstanceEventChange {
+	return &mpi.InstanceEventChange{
+		Name:   "process_id",
+		Before: strconv.Itoa(int(before)),
+		After:  strconv.Itoa(int(after)),
+	}
+}
+
+func modulesChange(name string, before, after []string) *mpi.InstanceEventChange {
+	return &mpi.InstanceEventChange{
+		Name:   name,
+		Before: strings.Join(before, ","),
+		After:  strings.Join(after, ","),
+	}
+}
+
+// workersReplaced returns true if there are workers before and after and none of the previous workers is left.
+// NGINX starts new workers on a reload and the old workers are shutting down until their connections are closed.
+func workersReplaced(before, after []int32) bool {
+	if len(before) == 0 || len(after) == 0 {
+		return false
+	}
+
+	for _, pid := range after {
+		if _, found := slices.BinarySearch(before, pid); found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func workerProcessIDs(masterPID int32, processes []*nginxprocess.Process) []int32 {
+	var workers []int32
+	for _, proc := range processes {
+		if proc.PPID == masterPID && proc.IsWorker() && !proc.IsShuttingDown() {
+			workers = append(workers, proc.PID)
+		}
+	}
+
+	slices.Sort(workers)
+
+	return workers
+}
+
+func sortedModules(modules []string) []string {
+	sorted := slices.Clone(modules)
+	slices.Sort(sorted)
+
+	return sorted
+}
+
+func joinProcessIDs(pids []int32) string {
+	values := make([]string, 0, len(pids))
+	for _, pid := range pids {
+		values = append(values, strconv.Itoa(int(pid)))
+	}
+
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,270 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package instance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/watcher/instance/instancefakes"
+	"github.com/nginx/agent/v3/internal/watcher/process/processfakes"
+	"github.com/nginx/agent/v3/pkg/host/exec/execfakes"
+	"github.com/nginx/agent/v3/pkg/nginxprocess"
+	"github.com/nginx/agent/v3/test/protos"
+	"github.com/nginx/agent/v3/test/types"
+)
+
+func TestInstanceEvents(t *testing.T) {
+	nginxState := &instanceState{
+		version:         "1.25.3",
+		dynamicModules:  []string{"http_ssl_module"},
+		loadableModules: []string{"ngx_http_js_module"},
+		workers:         []int32{101, 102},
+		processID:       100,
+	}
+
+	tests := []struct {
+		name           string
+		previousStates map[string]*instanceState
+		currentStates  map[string]*instanceState
+		expectedEvents []*mpi.InstanceEvent
+		configApplied  bool
+	}{
+		{
+			name:           "Test 1: no changes",
+			previousStates: map[string]*instanceState{"nginx": nginxState},
+			currentStates:  map[string]*instanceState{"nginx": nginxState},
+		},
+		{
+			name:           "Test 2: instance started",
+			previousStates: map[string]*instanceState{},
+			currentStates:  map[string]*instanceState{"nginx": nginxState},
+			expectedEvents: []*mpi.InstanceEvent{
+				{
+					InstanceId: "nginx",
+					EventType:  mpi.InstanceEvent_INSTANCE_EVENT_TYPE_STARTED,
+					Changes: []*mpi.InstanceEventChange{
+						{Name: "process_id", After: "100"},
+						{Name: "version", After: "1.25.3"},
+					},
+				},
+			},
+		},
+		{
+			name:           "Test 3: instance stopped",
+			previousStates: map[string]*instanceState{"nginx": nginxState},
+			currentStates:  map[string]*instanceState{},
+			expectedEvents: []*mpi.InstanceEvent{
+				{
+					InstanceId: "nginx",
+					EventType:  mpi.InstanceEvent_INSTANCE_EVENT_TYPE_STOPPED,
+					Changes: []*mpi.InstanceEventChange{
+						{Name: "process_id", Before: "100"},
+						{Name: "version", Before: "1.25.3"},
+					},
+				},
+			},
+		},
+		{
+			name:           "Test 4: instance restarted with a new version and modules",
+			previousStates: map[string]*instanceState{"nginx": nginxState},
+			currentStates: map[string]*instanceState{"nginx": {
+				version:         "1.27.4",
+				dynamicModules:  []string{"http_ssl_module", "http_v3_module"},
+				loadableModules: []string{"ngx_http_js_module"},
+				workers:         []int32{201},
+				processID:       200,
+			}},
+			expectedEvents: []*mpi.InstanceEvent{
+				{
+					InstanceId: "nginx",
+					EventType:  mpi.InstanceEvent_INSTANCE_EVENT_TYPE_PROCESS_ID_CHANGED,
+					Changes:    []*mpi.InstanceEventChange{{Name: "process_id", Before: "100", After: "200"}},
+				},
+				{
+					InstanceId: "nginx",
+					EventType:  mpi.InstanceEvent_INSTANCE_EVENT_TYPE_VERSION_CHANGED,
+					Changes:    []*mpi.InstanceEventChange{{Name: "version", Before: "1.25.3", After: "1.27.4"}},
+				},
+				{
+					InstanceId: "nginx",
+					EventType:  mpi.InstanceEvent_INSTANCE_EVENT_TYPE_MODULES_CHANGED,
+					Changes: []*mpi.InstanceEventChange{
+						{Name: "dynamic_modules", Before: "http_ssl_module", After: "http_ssl_module,http_v3_module"},
+					},
+				},
+				{
+					InstanceId: "nginx",
+					EventType:  mpi.InstanceEvent_INSTANCE_EVENT_TYPE_WORKER_COUNT_CHANGED,
+					Changes:    []*mpi.InstanceEventChange{{Name: "worker_count", Before: "2", After: "1"}},
+				},
+			},
+		},
+		{
+			name:           "Test 5: config reloaded",
+			previousStates: map[string]*instanceState{"nginx": nginxState},
+			currentStates: map[string]*instanceState{"nginx": {
+				version:         "1.25.3",
+				dynamicModules:  []string{"http_ssl_module"},
+				loadableModules: []string{"ngx_http_js_module"},
+				workers:         []int32{103, 104},
+				processID:       100,
+			}},
+			expectedEvents: []*mpi.InstanceEvent{
+				{
+					InstanceId: "nginx",
+					EventType:  mpi.InstanceEvent_INSTANCE_EVENT_TYPE_CONFIG_RELOADED,
+					Changes: []*mpi.InstanceEventChange{
+						{Name: "worker_process_ids", Before: "101,102", After: "103,104"},
+					},
+				},
+			},
+		},
+		{
+			name:           "Test 6: config reloaded by the agent",
+			previousStates: map[string]*instanceState{"nginx": nginxState},
+			currentStates: map[string]*instanceState{"nginx": {
+				version:         "1.25.3",
+				dynamicModules:  []string{"http_ssl_module"},
+				loadableModules: []string{"ngx_http_js_module"},
+				workers:         []int32{103, 104},
+				processID:       100,
+			}},
+			configApplied: true,
+		},
+		{
+			name:           "Test 7: one worker replaced",
+			previousStates: map[string]*instanceState{"nginx": nginxState},
+			currentStates: map[string]*instanceState{"nginx": {
+				version:         "1.25.3",
+				dynamicModules:  []string{"http_ssl_module"},
+				loadableModules: []string{"ngx_http_js_module"},
+				workers:         []int32{101, 103},
+				processID:       100,
+			}},
+		},
+		{
+			name:           "Test 8: attack signature version changed",
+			previousStates: map[string]*instanceState{"nap": {version: "5.6.0", attackSignatureVersion: "2024.11.28"}},
+			currentStates:  map[string]*instanceState{"nap": {version: "5.6.0", attackSignatureVersion: "2025.01.09"}},
+			expectedEvents: []*mpi.InstanceEvent{
+				{
+					InstanceId: "nap",
+					EventType:  mpi.InstanceEvent_INSTANCE_EVENT_TYPE_ATTACK_SIGNATURE_VERSION_CHANGED,
+					Changes: []*mpi.InstanceEventChange{
+						{Name: "attack_signature_version", Before: "2024.11.28", After: "2025.01.09"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			events := instanceEvents(test.previousStates, test.currentStates, test.configApplied)
+			require.Len(tt, events, len(test.expectedEvents))
+
+			for i, event := range events {
+				assert.NotNil(tt, event.GetTimestamp())
+				assert.Equal(tt, test.expectedEvents[i].GetInstanceId(), event.GetInstanceId())
+				assert.Equal(tt, test.expectedEvents[i].GetEventType(), event.GetEventType())
+				assert.Equal(tt, test.expectedEvents[i].GetChanges(), event.GetChanges())
+			}
+		})
+	}
+}
+
+func TestNewInstanceState(t *testing.T) {
+	nginxInstance := protos.NginxOssInstance([]string{"ngx_http_js_module"})
+	masterPID := nginxInstance.GetInstanceRuntime().GetProcessId()
+
+	processes := []*nginxprocess.Process{
+		{PID: masterPID, PPID: 1, Cmd: "nginx: master process nginx"},
+		{PID: 300, PPID: masterPID, Cmd: "nginx: worker process"},
+		{PID: 200, PPID: masterPID, Cmd: "nginx: worker process"},
+		{PID: 100, PPID: masterPID, Cmd: "nginx: worker process is shutting down"},
+		{PID: 400, PPID: masterPID, Cmd: "nginx: cache manager process"},
+		{PID: 500, PPID: 2, Cmd: "nginx: worker process"},
+	}
+
+	state := newInstanceState(nginxInstance, processes)
+	assert.Equal(t, masterPID, state.processID)
+	assert.Equal(t, "1.25.3", state.version)
+	assert.Equal(t, []int32{200, 300}, state.workers)
+	assert.Equal(t, []string{"ngx_http_js_module"}, state.loadableModules)
+	assert.Len(t, state.dynamicModules, 20)
+
+	napState := newInstanceState(protos.NginxAppProtectInstance(), nil)
+	assert.Equal(t, protos.NginxAppProtectInstance().GetInstanceRuntime().GetNginxAppProtectRuntimeInfo().
+		GetAttackSignatureVersion(), napState.attackSignatureVersion)
+	assert.Empty(t, napState.workers)
+}
+
+func TestInstanceWatcherService_instanceUpdates_Events(t *testing.T) {
+	ctx := context.Background()
+
+	nginxInstance := protos.NginxOssInstance([]string{})
+	masterPID := nginxInstance.GetInstanceRuntime().GetProcessId()
+	master := &nginxprocess.Process{PID: masterPID, PPID: 1, Cmd: "nginx: master process nginx"}
+
+	workers := func(pids ...int32) []*nginxprocess.Process {
+		processes := []*nginxprocess.Process{master}
+		for _, pid := range pids {
+			processes = append(processes, &nginxprocess.Process{PID: pid, PPID: masterPID, Cmd: "nginx: worker process"})
+		}
+
+		return processes
+	}
+
+	fakeProcessOperator := &processfakes.FakeProcessOperatorInterface{}
+	fakeProcessParser := &instancefakes.FakeProcessParser{}
+	fakeProcessParser.ParseReturns(map[string]*mpi.Instance{
+		nginxInstance.GetInstanceMeta().GetInstanceId(): nginxInstance,
+	})
+	fakeExec := &execfakes.FakeExecInterface{}
+	fakeExec.ExecutableReturns(defaultAgentPath, nil)
+
+	instanceWatcherService := NewInstanceWatcherService(types.AgentConfig())
+	instanceWatcherService.processOperator = fakeProcessOperator
+	instanceWatcherService.nginxParser = fakeProcessParser
+	instanceWatcherService.unitParser = &instancefakes.FakeProcessParser{}
+	instanceWatcherService.executer = fakeExec
+
+	// no events are sent for the instances found by the first check
+	fakeProcessOperator.ProcessesReturns(workers(101, 102), nil)
+	instanceUpdates, err := instanceWatcherService.instanceUpdates(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, instanceUpdates.Events)
+
+	fakeProcessOperator.ProcessesReturns(workers(103, 104), nil)
+	instanceUpdates, err = instanceWatcherService.instanceUpdates(ctx)
+	require.NoError(t, err)
+	require.Len(t, instanceUpdates.Events, 1)
+	assert.Equal(t, mpi.InstanceEvent_INSTANCE_EVENT_TYPE_CONFIG_RELOADED, instanceUpdates.Events[0].GetEventType())
+	assert.Equal(t, nginxInstance.GetInstanceMeta().GetInstanceId(), instanceUpdates.Events[0].GetInstanceId())
+
+	// a reload during a config apply of the agent is not reported
+	instanceWatcherService.SetEnabled(false)
+	instanceWatcherService.SetEnabled(true)
+
+	fakeProcessOperator.ProcessesReturns(workers(105, 106, 107), nil)
+	instanceUpdates, err = instanceWatcherService.instanceUpdates(ctx)
+	require.NoError(t, err)
+	require.Len(t, instanceUpdates.Events, 1)
+	assert.Equal(t, mpi.InstanceEvent_INSTANCE_EVENT_TYPE_WORKER_COUNT_CHANGED,
+		instanceUpdates.Events[0].GetEventType())
+
+	fakeProcessParser.ParseReturns(map[string]*mpi.Instance{})
+	fakeProcessOperator.ProcessesReturns(nil, nil)
+	instanceUpdates, err = instanceWatcherService.instanceUpdates(ctx)
+	require.NoError(t, err)
+	require.Len(t, instanceUpdates.Events, 1)
+	assert.Equal(t, mpi.InstanceEvent_INSTANCE_EVENT_TYPE_STOPPED, instanceUpdates.Events[0].GetEventType())
+}
@@ -6,6 +6,7 @@
 package instance
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"log/slog"
@@ -32,7 +33,12 @@ import (
 	"github.com/nginx/agent/v3/internal/model"
 )
 
-const defaultAgentPath = "/run/nginx-agent"
+const (
+	defaultAgentPath = "/run/nginx-agent"
+	// how long to wait for more process events before NGINX processes are looked up again, since NGINX forks
+	// several processes and workers only set their process title after they are forked
+	processEventsDelay = 500 * time.Millisecond
+)
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6@v6.11.2 -generate
 //counterfeiter:generate . processParser
@@ -47,6 +53,8 @@ type (
 		nginxAppProtectInstanceWatcher *NginxAppProtectInstanceWatcher
 		nginxConfigParser              parser.ConfigParser
 		nginxParser                    processParser
+		unitParser                     processParser
+		declaredInstanceParser         processParser
 		executer                       exec.ExecInterface
 		enabled                        *atomic.Bool
 		agentConfig                    *config.Config
@@ -57,17 +65,31 @@ type (
 		info                           host.InfoInterface
 		resource                       *mpi.Resource
 		processCache                   []*nginxprocess.Process
-		cacheMutex                     sync.Mutex
-		resourceMutex                  sync.Mutex
+		// states of the instances found by the previous check for updates, nil before the first check
+		instanceStates map[string]*instanceState
+		// pids of processes that changed according to process events, since the last check for updates
+		changedProcesses map[int32]struct{}
+		cacheMutex       sync.Mutex
+		resourceMutex    sync.Mutex
+		// NGINX processes are tracked with process events instead of listing all processes on every check
+		processEventsActive bool
+		// process events were lost, all processes are listed again on the next check
+		listAllProcesses bool
+		// a config was applied by the agent since the previous check for updates, so replaced workers are not
+		// reported as a config reload
+		configApplied atomic.Bool
 	}
 
 	InstanceUpdates struct {
 		UpdatedInstances []*mpi.Instance
+		Events           []*mpi.InstanceEvent
 	}
 
+	// ResourceUpdatesMessage contains the updated resource, which is nil if only instance events were detected
 	ResourceUpdatesMessage struct {
-		CorrelationID slog.Attr
-		Resource      *mpi.Resource
+		CorrelationID  slog.Attr
+		Resource       *mpi.Resource
+		InstanceEvents []*mpi.InstanceEvent
 	}
 
 	NginxConfigContextMessage struct {
@@ -87,6 +109,8 @@ func NewInstanceWatcherService(agentConfig *config.Config) *InstanceWatcherServi
 		nginxAppProtectInstanceWatcher: napWatcher,
 		processOperator:                process.NewProcessOperator(),
 		nginxParser:                    NewNginxProcessParser(),
+		unitParser:                     NewUnitProcessParser(),
+		declaredInstanceParser:         NewNginxInstanceParser(agentConfig),
 		nginxConfigParser:              parser.NewNginxConfigParser(agentConfig),
 		instanceCache:                  make(map[string]*mpi.Instance),
 		cacheMutex:                     sync.Mutex{},
@@ -97,12 +121,18 @@ func NewInstanceWatcherService(agentConfig *config.Config) *InstanceWatcherServi
 		resource:                       &mpi.Resource{},
 		enabled:                        enabled,
 		processCache:                   []*nginxprocess.Process{},
+		changedProcesses:               make(map[int32]struct{}),
 	}
 
 	return instanceWatcherService
 }
 
+// SetEnabled disables the instance watcher while a config is applied by the agent
 func (iw *InstanceWatcherService) SetEnabled(enabled bool) {
+	if !enabled {
+		iw.configApplied.Store(true)
+	}
+
 	iw.enabled.Store(enabled)
 }
 
@@ -123,19 +153,112 @@ func (iw *InstanceWatcherService) Watch(
 	instanceWatcherTicker := time.NewTicker(monitoringFrequency)
 	defer instanceWatcherTicker.Stop()
 
+	var processEvents <-chan process.ProcessEvent
+	if iw.agentConfig.Watchers.InstanceWatcher.ProcessEvents {
+		processEvents = iw.watchProcessEvents(ctx)
+	}
+
+	// fires once process events stop arriving, so that a burst of events results in one check for updates
+	var processEventsTimer <-chan time.Time
+
 	for {
 		select {
 		case <-ctx.Done():
 
 			return
 		case <-instanceWatcherTicker.C:
-			if iw.enabled.Load() {
-				iw.checkForUpdates(ctx)
-			} else {
-				slog.DebugContext(ctx, "Skipping check for instance updates, instance watcher is disabled")
+			iw.checkForUpdatesIfEnabled(ctx)
+		case event, ok := <-processEvents:
+			if !ok {
+				slog.WarnContext(ctx, "Process events stopped, falling back to polling for NGINX processes")
+				processEvents = nil
+				iw.setProcessEventsActive(false)
+
+				continue
 			}
+
+			if iw.handleProcessEvent(event) && processEventsTimer == nil {
+				processEventsTimer = time.After(processEventsDelay)
+			}
+		case <-processEventsTimer:
+			processEventsTimer = nil
+			iw.checkForUpdatesIfEnabled(ctx)
+		}
+	}
+}
+
+func (iw *InstanceWatcherService) checkForUpdatesIfEnabled(ctx context.Context) {
+	if iw.enabled.Load() {
+		iw.checkForUpdates(ctx)
+	} else {
+		slog.DebugContext(ctx, "Skipping check for instance updates, instance watcher is disabled")
+	}
+}
+
+// watchProcessEvents subscribes to process events, so that only NGINX processes that changed are looked up
+// instead of listing all processes every monitoring frequency. Returns nil if process events aren't available, in
+// which case processes are polled.
+func (iw *InstanceWatcherService) watchProcessEvents(ctx context.Context) <-chan process.ProcessEvent {
+	processEvents, err := iw.processOperator.ProcessEvents(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "Unable to receive process events, falling back to polling for NGINX processes",
+			"error", err)
+
+		return nil
+	}
+
+	slog.InfoContext(ctx, "Watching process events for NGINX process changes")
+	iw.setProcessEventsActive(true)
+
+	return processEvents
+}
+
+func (iw *InstanceWatcherService) setProcessEventsActive(active bool) {
+	iw.cacheMutex.Lock()
+	defer iw.cacheMutex.Unlock()
+
+	iw.processEventsActive = active
+	iw.listAllProcesses = true
+	clear(iw.changedProcesses)
+}
+
+// handleProcessEvent records the processes affected by a process event and returns true if NGINX processes
+// need to be looked up again. Exec events are only received for NGINX executables, forks and exits are only
+// relevant for known NGINX processes.
+func (iw *InstanceWatcherService) handleProcessEvent(event process.ProcessEvent) bool {
+	iw.cacheMutex.Lock()
+	defer iw.cacheMutex.Unlock()
+
+	switch event.Type {
+	case process.ProcessEventExec:
+		iw.changedProcesses[event.PID] = struct{}{}
+	case process.ProcessEventFork:
+		if !iw.isKnownProcess(event.ParentPID) {
+			return false
 		}
+		iw.changedProcesses[event.PID] = struct{}{}
+	case process.ProcessEventExit:
+		if !iw.isKnownProcess(event.PID) {
+			return false
+		}
+		iw.changedProcesses[event.PID] = struct{}{}
+	case process.ProcessEventsLost:
+		iw.listAllProcesses = true
+	default:
+		return false
 	}
+
+	return true
+}
+
+func (iw *InstanceWatcherService) isKnownProcess(pid int32) bool {
+	if _, ok := iw.changedProcesses[pid]; ok {
+		return true
+	}
+
+	return slices.ContainsFunc(iw.processCache, func(proc *nginxprocess.Process) bool {
+		return proc.PID == pid
+	})
 }
 
 func (iw *InstanceWatcherService) ReparseConfigs(ctx context.Context) {
@@ -258,14 +381,19 @@ func (iw *InstanceWatcherService) checkForUpdates(
 		}
 	}
 
-	if len(instanceUpdates.UpdatedInstances) > 0 {
-		iw.updateResourceInstanceList(ctx, instanceUpdates.UpdatedInstances)
+	if len(instanceUpdates.UpdatedInstances) > 0 || len(instanceUpdates.Events) > 0 {
+		resourceUpdatesMessage := ResourceUpdatesMessage{
+			CorrelationID:  correlationID,
+			InstanceEvents: instanceUpdates.Events,
+		}
+
+		if len(instanceUpdates.UpdatedInstances) > 0 {
+			iw.updateResourceInstanceList(ctx, instanceUpdates.UpdatedInstances)
+			resourceUpdatesMessage.Resource = iw.resource
+		}
 
 		select {
-		case iw.instancesChannel <- ResourceUpdatesMessage{
-			CorrelationID: correlationID,
-			Resource:      iw.resource,
-		}:
+		case iw.instancesChannel <- resourceUpdatesMessage:
 		case <-ctx.Done():
 			return
 		}
@@ -344,7 +472,7 @@ func (iw *InstanceWatcherService) instanceUpdates(ctx context.Context) (
 ) {
 	iw.cacheMutex.Lock()
 	defer iw.cacheMutex.Unlock()
-	nginxProcesses, err := iw.processOperator.Processes(ctx)
+	nginxProcesses, err := iw.nginxProcesses(ctx)
 	if err != nil {
 		return instanceUpdates, err
 	}
@@ -371,6 +499,20 @@ func (iw *InstanceWatcherService) instanceUpdates(ctx context.Context) (
 		instancesFound[instance.GetInstanceMeta().GetInstanceId()] = instance
 	}
 
+	unitInstances := iw.unitParser.Parse(ctx, nginxProcesses)
+	for _, instance := range unitInstances {
+		instancesFound[instance.GetInstanceMeta().GetInstanceId()] = instance
+	}
+
+	// declared instances are only added if their processes were not found, since the found processes also
+	// contain the workers of the instance
+	declaredInstances := iw.declaredInstanceParser.Parse(ctx, nginxProcesses)
+	for instanceID, instance := range declaredInstances {
+		if _, ok := instancesFound[instanceID]; !ok {
+			instancesFound[instanceID] = instance
+		}
+	}
+
 	if iw.nginxAppProtectInstanceWatcher.checkForAppProtectUpdates(ctx) ||
 		areInstanceDifferent(iw.instanceCache, instancesFound) {
 		var updatedInstances []*mpi.Instance
@@ -387,10 +529,94 @@ func (iw *InstanceWatcherService) instanceUpdates(ctx context.Context) (
 	}
 
 	iw.instanceCache = instancesFound
+	instanceUpdates.Events = iw.instanceEvents(instancesFound, nginxProcesses)
 
 	return instanceUpdates, nil
 }
 
+// instanceEvents returns the changes to the instances since the previous check for updates. No events are returned
+// for the first check, since the instances found when the agent starts are already reported in the resource.
+func (iw *InstanceWatcherService) instanceEvents(
+	instancesFound map[string]*mpi.Instance,
+	nginxProcesses []*nginxprocess.Process,
+) []*mpi.InstanceEvent {
+	instances := make([]*mpi.Instance, 0, len(instancesFound)+1)
+	for _, instance := range instancesFound {
+		instances = append(instances, instance)
+	}
+
+	if napInstance := iw.nginxAppProtectInstanceWatcher.NginxAppProtectInstance(); napInstance != nil {
+		instances = append(instances, napInstance)
+	}
+
+	previousStates := iw.instanceStates
+	iw.instanceStates = newInstanceStates(instances, nginxProcesses)
+	configApplied := iw.configApplied.Swap(false)
+
+	if previousStates == nil {
+		return nil
+	}
+
+	return instanceEvents(previousStates, iw.instanceStates, configApplied)
+}
+
+// nginxProcesses lists all NGINX processes, unless process events are received, in which case only the processes
+// that changed since the last check and the running workers are looked up again
+func (iw *InstanceWatcherService) nginxProcesses(ctx context.Context) ([]*nginxprocess.Process, error) {
+	if !iw.processEventsActive || iw.listAllProcesses {
+		nginxProcesses, err := iw.processOperator.Processes(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		iw.listAllProcesses = false
+		clear(iw.changedProcesses)
+
+		return nginxProcesses, nil
+	}
+
+	// workers change their title when they start shutting down on a reload, which is not a process event, so
+	// the cached workers are looked up again to not count old workers that are shutting down
+	for _, proc := range iw.processCache {
+		if proc.IsWorker() && !proc.IsShuttingDown() {
+			iw.changedProcesses[proc.PID] = struct{}{}
+		}
+	}
+
+	if len(iw.changedProcesses) == 0 {
+		return iw.processCache, nil
+	}
+
+	nginxProcesses := make([]*nginxprocess.Process, 0, len(iw.processCache)+len(iw.changedProcesses))
+	for _, proc := range iw.processCache {
+		if _, ok := iw.changedProcesses[proc.PID]; !ok {
+			nginxProcesses = append(nginxProcesses, proc)
+		}
+	}
+
+	for pid := range iw.changedProcesses {
+		proc, err := iw.processOperator.NginxProcess(ctx, pid)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+
+			// the process exited or is not an NGINX process
+			continue
+		}
+
+		nginxProcesses = append(nginxProcesses, proc)
+	}
+
+	clear(iw.changedProcesses)
+
+	slices.SortFunc(nginxProcesses, func(a, b *nginxprocess.Process) int {
+		return cmp.Compare(a.PID, b.PID)
+	})
+
+	return nginxProcesses, nil
+}
+
 func (iw *InstanceWatcherService) agentInstance(ctx context.Context) *mpi.Instance {
 	processPath, err := iw.executer.Executable()
 	if err != nil {
@@ -15,7 +15,9 @@ import (
 	"github.com/nginx/agent/v3/pkg/host/exec/execfakes"
 
 	"github.com/nginx/agent/v3/internal/watcher/instance/instancefakes"
+	"github.com/nginx/agent/v3/internal/watcher/process"
 	"github.com/nginx/agent/v3/internal/watcher/process/processfakes"
+	"github.com/nginx/agent/v3/pkg/nginxprocess"
 
 	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
 	"github.com/nginx/agent/v3/internal/model"
@@ -497,3 +499,103 @@ func compareInstances(t *testing.T, expected, actual []*mpi.Instance) {
 			actual[id].GetInstanceRuntime().GetProcessId())
 	}
 }
+
+func TestInstanceWatcherService_nginxProcesses_ProcessEvents(t *testing.T) {
+	ctx := context.Background()
+
+	master := &nginxprocess.Process{PID: 100, PPID: 1, Name: "nginx", Cmd: "nginx: master process nginx"}
+	worker := &nginxprocess.Process{PID: 101, PPID: 100, Name: "nginx", Cmd: "nginx: worker process"}
+	shuttingDownWorker := &nginxprocess.Process{
+		PID: 101, PPID: 100, Name: "nginx", Cmd: "nginx: worker process is shutting down",
+	}
+	newWorker := &nginxprocess.Process{PID: 102, PPID: 100, Name: "nginx", Cmd: "nginx: worker process"}
+
+	running := map[int32]*nginxprocess.Process{master.PID: master, worker.PID: worker}
+
+	fakeProcessOperator := &processfakes.FakeProcessOperatorInterface{}
+	fakeProcessOperator.ProcessesReturns([]*nginxprocess.Process{master, worker}, nil)
+	fakeProcessOperator.NginxProcessCalls(func(_ context.Context, pid int32) (*nginxprocess.Process, error) {
+		if proc, ok := running[pid]; ok {
+			return proc, nil
+		}
+
+		return nil, errors.New("process not found")
+	})
+	fakeProcessOperator.ProcessEventsReturns(make(chan process.ProcessEvent), nil)
+
+	instanceWatcherService := NewInstanceWatcherService(types.AgentConfig())
+	instanceWatcherService.processOperator = fakeProcessOperator
+	require.NotNil(t, instanceWatcherService.watchProcessEvents(ctx))
+
+	// all processes are listed when process events are first received
+	processes, err := instanceWatcherService.nginxProcesses(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*nginxprocess.Process{master, worker}, processes)
+	instanceWatcherService.processCache = processes
+
+	// events of processes that are not NGINX processes are ignored
+	assert.False(t, instanceWatcherService.handleProcessEvent(
+		process.ProcessEvent{Type: process.ProcessEventFork, PID: 200, ParentPID: 1}))
+	assert.False(t, instanceWatcherService.handleProcessEvent(
+		process.ProcessEvent{Type: process.ProcessEventExit, PID: 200}))
+
+	// only the title of the worker is read again
+	processes, err = instanceWatcherService.nginxProcesses(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*nginxprocess.Process{master, worker}, processes)
+	assert.Equal(t, 1, fakeProcessOperator.ProcessesCallCount())
+	assert.Equal(t, 1, fakeProcessOperator.NginxProcessCallCount())
+
+	// on a reload a new worker is forked and the old worker changes its title without a process event
+	running[worker.PID] = shuttingDownWorker
+	running[newWorker.PID] = newWorker
+	assert.True(t, instanceWatcherService.handleProcessEvent(
+		process.ProcessEvent{Type: process.ProcessEventFork, PID: newWorker.PID, ParentPID: master.PID}))
+
+	processes, err = instanceWatcherService.nginxProcesses(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*nginxprocess.Process{master, shuttingDownWorker, newWorker}, processes)
+	assert.Equal(t, []int32{newWorker.PID}, workerProcessIDs(master.PID, processes))
+	assert.Equal(t, 1, fakeProcessOperator.ProcessesCallCount())
+	assert.Equal(t, 3, fakeProcessOperator.NginxProcessCallCount())
+	instanceWatcherService.processCache = processes
+
+	// the old worker exits
+	delete(running, worker.PID)
+	assert.True(t, instanceWatcherService.handleProcessEvent(
+		process.ProcessEvent{Type: process.ProcessEventExit, PID: worker.PID}))
+
+	processes, err = instanceWatcherService.nginxProcesses(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*nginxprocess.Process{master, newWorker}, processes)
+	assert.Equal(t, 1, fakeProcessOperator.ProcessesCallCount())
+	assert.Equal(t, 5, fakeProcessOperator.NginxProcessCallCount())
+	instanceWatcherService.processCache = processes
+
+	// all processes are listed again after process events were lost
+	assert.True(t, instanceWatcherService.handleProcessEvent(process.ProcessEvent{Type: process.ProcessEventsLost}))
+
+	processes, err = instanceWatcherService.nginxProcesses(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*nginxprocess.Process{master, worker}, processes)
+	assert.Equal(t, 2, fakeProcessOperator.ProcessesCallCount())
+}
+
+func TestInstanceWatcherService_watchProcessEvents_Unsupported(t *testing.T) {
+	ctx := context.Background()
+
+	fakeProcessOperator := &processfakes.FakeProcessOperatorInterface{}
+	fakeProcessOperator.ProcessEventsReturns(nil, process.ErrProcessEventsUnsupported)
+
+	instanceWatcherService := NewInstanceWatcherService(types.AgentConfig())
+	instanceWatcherService.processOperator = fakeProcessOperator
+
+	assert.Nil(t, instanceWatcherService.watchProcessEvents(ctx))
+
+	// processes are polled
+	_, err := instanceWatcherService.nginxProcesses(ctx)
+	require.NoError(t, err)
+	_, err = instanceWatcherService.nginxProcesses(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, fakeProcessOperator.ProcessesCallCount())
+}
@@ -0,0 +1,84 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package instance
+
+import (
+	"context"
+	"log/slog"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/config"
+	"github.com/nginx/agent/v3/internal/datasource/nginx"
+	"github.com/nginx/agent/v3/internal/model"
+	"github.com/nginx/agent/v3/pkg/host/exec"
+	"github.com/nginx/agent/v3/pkg/nginxprocess"
+)
+
+// NginxInstanceParser creates the NGINX instances declared in the agent configuration, which can't be found by
+// scanning processes. An instance is running while its PID file contains a process ID.
+type NginxInstanceParser struct {
+	executer    exec.ExecInterface
+	agentConfig *config.Config
+}
+
+var _ processParser = (*NginxInstanceParser)(nil)
+
+func NewNginxInstanceParser(agentConfig *config.Config) *NginxInstanceParser {
+	return &NginxInstanceParser{
+		executer:    &exec.Exec{},
+		agentConfig: agentConfig,
+	}
+}
+
+// Parse ignores the processes, the declared instances are found with their PID files
+func (nip *NginxInstanceParser) Parse(ctx context.Context, _ []*nginxprocess.Process) map[string]*mpi.Instance {
+	instanceMap := make(map[string]*mpi.Instance)
+
+	if nip.agentConfig.DataPlaneConfig == nil || nip.agentConfig.DataPlaneConfig.Nginx == nil {
+		return instanceMap
+	}
+
+	for _, nginxInstance := range nip.agentConfig.DataPlaneConfig.Nginx.Instances {
+		pid, err := nginx.PIDFromFile(nginxInstance.PIDFile)
+		if err != nil {
+			slog.DebugContext(ctx, "Declared NGINX instance is not running", "config_path",
+				nginxInstance.ConfigPath, "pid_file", nginxInstance.PIDFile, "error", err)
+
+			continue
+		}
+
+		nginxInfo, err := nip.info(ctx, nginxInstance, pid)
+		if err != nil {
+			slog.WarnContext(ctx, "Unable to get NGINX info of declared NGINX instance", "binary_path",
+				nginxInstance.BinaryPath, "config_path", nginxInstance.ConfigPath, "error", err)
+
+			continue
+		}
+
+		instance := convertInfoToInstance(*nginxInfo)
+		instanceMap[instance.GetInstanceMeta().GetInstanceId()] = instance
+	}
+
+	return instanceMap
+}
+
+func (nip *NginxInstanceParser) info(ctx context.Context, nginxInstance *config.NginxInstance,
+	pid int32,
+) (*model.ProcessInfo, error) {
+	outputBuffer, err := nip.executer.RunCmd(ctx, nginxInstance.BinaryPath, "-V")
+	if err != nil {
+		return nil, err
+	}
+
+	nginxInfo := nginx.ParseNginxVersionCommandOutput(ctx, outputBuffer)
+	nginxInfo.ExePath = nginxInstance.BinaryPath
+	nginxInfo.ConfPath = nginxInstance.ConfigPath
+	nginxInfo.ProcessID = pid
+	nginxInfo.LoadableModules = loadableModules(nginxInfo)
+	nginxInfo.DynamicModules = dynamicModules(nginxInfo)
+
+	return nginxInfo, nil
+}
@@ -0,0 +1,128 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package instance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/internal/config"
+	"github.com/nginx/agent/v3/internal/watcher/instance/instancefakes"
+	"github.com/nginx/agent/v3/internal/watcher/process/processfakes"
+	"github.com/nginx/agent/v3/pkg/host/exec/execfakes"
+	"github.com/nginx/agent/v3/test/helpers"
+	"github.com/nginx/agent/v3/test/protos"
+	"github.com/nginx/agent/v3/test/types"
+)
+
+func TestNginxInstanceParser_Parse(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	noModulesPath := filepath.Join(tempDir, "modules")
+	helpers.CreateDirWithErrorCheck(t, noModulesPath)
+
+	expectedInstance := protos.NginxOssInstance(nil)
+	expectedInstance.GetInstanceRuntime().InstanceChildren = nil
+
+	pidFile := filepath.Join(tempDir, "nginx.pid")
+	require.NoError(t, os.WriteFile(pidFile,
+		[]byte(strconv.Itoa(int(expectedInstance.GetInstanceRuntime().GetProcessId()))+"\n"), 0o600))
+
+	agentConfig := types.AgentConfig()
+	agentConfig.DataPlaneConfig.Nginx.Instances = []*config.NginxInstance{
+		{
+			BinaryPath: expectedInstance.GetInstanceRuntime().GetBinaryPath(),
+			ConfigPath: expectedInstance.GetInstanceRuntime().GetConfigPath(),
+			PIDFile:    pidFile,
+		},
+		{
+			BinaryPath: "/usr/sbin/nginx",
+			ConfigPath: "/etc/nginx/nginx.conf",
+			PIDFile:    filepath.Join(tempDir, "stopped.pid"),
+		},
+	}
+
+	mockExec := &execfakes.FakeExecInterface{}
+	mockExec.RunCmdReturns(bytes.NewBufferString(`nginx version: nginx/1.25.3
+		built by clang 14.0.0 (clang-1400.0.29.202)
+		configure arguments: `+fmt.Sprintf(ossConfigArgs, noModulesPath)), nil)
+
+	nginxInstanceParser := NewNginxInstanceParser(agentConfig)
+	nginxInstanceParser.executer = mockExec
+
+	// the declared instance is found without any NGINX processes, the instance without a PID file isn't running
+	result := nginxInstanceParser.Parse(ctx, nil)
+	require.Len(t, result, 1)
+
+	instance := result[expectedInstance.GetInstanceMeta().GetInstanceId()]
+	require.NotNil(t, instance)
+	sort.Strings(instance.GetInstanceRuntime().GetNginxRuntimeInfo().GetDynamicModules())
+	assert.True(t, proto.Equal(expectedInstance, instance))
+
+	require.Equal(t, 1, mockExec.RunCmdCallCount())
+	_, cmd, args := mockExec.RunCmdArgsForCall(0)
+	assert.Equal(t, expectedInstance.GetInstanceRuntime().GetBinaryPath(), cmd)
+	assert.Equal(t, []string{"-V"}, args)
+
+	mockExec.RunCmdReturns(nil, errors.New("exec format error"))
+	assert.Empty(t, nginxInstanceParser.Parse(ctx, nil))
+}
+
+func TestInstanceWatcherService_instanceUpdates_DeclaredInstance(t *testing.T) {
+	ctx := context.Background()
+
+	nginxInstance := protos.NginxOssInstance([]string{})
+	declaredInstance := protos.NginxOssInstance([]string{})
+	declaredInstance.GetInstanceRuntime().InstanceChildren = nil
+	otherDeclaredInstance := protos.NginxPlusInstance([]string{})
+
+	fakeProcessParser := &instancefakes.FakeProcessParser{}
+	fakeProcessParser.ParseReturns(map[string]*mpi.Instance{
+		nginxInstance.GetInstanceMeta().GetInstanceId(): nginxInstance,
+	})
+
+	fakeDeclaredInstanceParser := &instancefakes.FakeProcessParser{}
+	fakeDeclaredInstanceParser.ParseReturns(map[string]*mpi.Instance{
+		declaredInstance.GetInstanceMeta().GetInstanceId():      declaredInstance,
+		otherDeclaredInstance.GetInstanceMeta().GetInstanceId(): otherDeclaredInstance,
+	})
+
+	fakeExec := &execfakes.FakeExecInterface{}
+	fakeExec.ExecutableReturns(defaultAgentPath, nil)
+
+	instanceWatcherService := NewInstanceWatcherService(types.AgentConfig())
+	instanceWatcherService.processOperator = &processfakes.FakeProcessOperatorInterface{}
+	instanceWatcherService.nginxParser = fakeProcessParser
+	instanceWatcherService.unitParser = &instancefakes.FakeProcessParser{}
+	instanceWatcherService.declaredInstanceParser = fakeDeclaredInstanceParser
+	instanceWatcherService.executer = fakeExec
+
+	instanceUpdates, err := instanceWatcherService.instanceUpdates(ctx)
+	require.NoError(t, err)
+	require.Len(t, instanceUpdates.UpdatedInstances, 3)
+
+	// an instance found by scanning processes is kept, since it contains the worker processes
+	for _, instance := range instanceUpdates.UpdatedInstances {
+		if instance.GetInstanceMeta().GetInstanceId() == nginxInstance.GetInstanceMeta().GetInstanceId() {
+			assert.Same(t, nginxInstance, instance)
+		}
+	}
+
+	assert.Contains(t, instanceWatcherService.instanceCache, otherDeclaredInstance.GetInstanceMeta().GetInstanceId())
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -183,9 +184,11 @@ func convertInfoToInstance(nginxInfo model.ProcessInfo) *mpi.Instance {
 		version = nginxInfo.Version
 	}
 
+	instanceRuntime.ContainerId = nginxInfo.ContainerID
+
 	return &mpi.Instance{
 		InstanceMeta: &mpi.InstanceMeta{
-			InstanceId:   id.Generate("%s_%s_%s", nginxInfo.ExePath, nginxInfo.ConfPath, nginxInfo.Prefix),
+			InstanceId:   instanceID(nginxInfo),
 			InstanceType: nginxType,
 			Version:      version,
 		},
@@ -193,6 +196,17 @@ func convertInfoToInstance(nginxInfo model.ProcessInfo) *mpi.Instance {
 	}
 }
 
+// instanceID returns the ID of an NGINX instance. Instances in other containers usually have the same paths, so the
+// container ID is part of their instance ID.
+func instanceID(nginxInfo model.ProcessInfo) string {
+	if nginxInfo.RootPath != "" {
+		return id.Generate("%s_%s_%s_%s", nginxInfo.ContainerID, nginxInfo.ExePath, nginxInfo.ConfPath,
+			nginxInfo.Prefix)
+	}
+
+	return id.Generate("%s_%s_%s", nginxInfo.ExePath, nginxInfo.ConfPath, nginxInfo.Prefix)
+}
+
 func loadableModules(nginxInfo *model.ProcessInfo) (modules []string) {
 	var err error
 	if mp, ok := nginxInfo.ConfigureArgs["modules-path"]; ok {
@@ -201,7 +215,7 @@ func loadableModules(nginxInfo *model.ProcessInfo) (modules []string) {
 			slog.Debug("Error parsing modules-path")
 			return modules
 		}
-		modules, err = readDirectory(modulePath, ".so")
+		modules, err = readDirectory(filepath.Join(nginxInfo.RootPath, modulePath), ".so")
 		if err != nil {
 			slog.Debug("Error reading module dir", "dir", modulePath, "error", err)
 			return modules
@@ -22,6 +22,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/pkg/id"
 	"github.com/nginx/agent/v3/pkg/nginxprocess"
 	"github.com/nginx/agent/v3/test/helpers"
 	"github.com/nginx/agent/v3/test/protos"
@@ -657,3 +658,42 @@ func TestReadDirectory(t *testing.T) {
 		assert.NotEqual(t, "readme.txt", f, "non-.so files must not be included")
 	}
 }
+
+func TestConvertInfoToInstance_OtherContainer(t *testing.T) {
+	rootPath := t.TempDir()
+	modulePath := "/usr/lib/nginx/modules"
+
+	helpers.CreateDirWithErrorCheck(t, filepath.Join(rootPath, modulePath))
+	require.NoError(t, os.WriteFile(filepath.Join(rootPath, modulePath, "ngx_http_js_module.so"), []byte{}, 0o600))
+
+	nginxInfo := model.ProcessInfo{
+		ConfigureArgs: map[string]interface{}{"modules-path": modulePath},
+		Version:       "1.25.3",
+		Prefix:        "/etc/nginx",
+		ConfPath:      "/etc/nginx/nginx.conf",
+		ExePath:       "/usr/sbin/nginx",
+		RootPath:      rootPath,
+		ContainerID:   "3d7b26ba-e8d1-35ae-8566-aed826a5208d",
+		ProcessID:     123,
+	}
+	nginxInfo.LoadableModules = loadableModules(&nginxInfo)
+	assert.Equal(t, []string{"ngx_http_js_module"}, nginxInfo.LoadableModules)
+
+	instance := convertInfoToInstance(nginxInfo)
+	assert.Equal(t, nginxInfo.ContainerID, instance.GetInstanceRuntime().GetContainerId())
+	assert.Equal(t, nginxInfo.ExePath, instance.GetInstanceRuntime().GetBinaryPath())
+	assert.Equal(t, nginxInfo.ConfPath, instance.GetInstanceRuntime().GetConfigPath())
+
+	// instances with the same paths in different containers have different IDs
+	otherContainerInfo := nginxInfo
+	otherContainerInfo.ContainerID = "17796e3d-8f28-3382-aa3c-130ee065d8ff"
+	assert.NotEqual(t, instance.GetInstanceMeta().GetInstanceId(),
+		convertInfoToInstance(otherContainerInfo).GetInstanceMeta().GetInstanceId())
+
+	// the instance ID of an instance in the container of the agent doesn't change
+	agentContainerInfo := nginxInfo
+	agentContainerInfo.RootPath = ""
+	agentContainerInfo.ContainerID = ""
+	assert.Equal(t, id.Generate("%s_%s_%s", nginxInfo.ExePath, nginxInfo.ConfPath, nginxInfo.Prefix),
+		convertInfoToInstance(agentContainerInfo).GetInstanceMeta().GetInstanceId())
+}
@@ -0,0 +1,231 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package instance
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/pkg/host/exec"
+	"github.com/nginx/agent/v3/pkg/id"
+	"github.com/nginx/agent/v3/pkg/nginxprocess"
+)
+
+const (
+	unitVersionPrefix      = "unit version:"
+	unitConfiguredAsPrefix = "configured as"
+	unitModuleExtension    = ".unit.so"
+	unitControlArg         = "control"
+	unitModulesDirArg      = "modulesdir"
+	unitModulesArg         = "modules"
+	// the control API socket of NGINX Unit if it isn't set when Unit is built or started
+	defaultUnitControlSocket = "unix:/var/run/control.unit.sock"
+)
+
+type (
+	// UnitProcessParser discovers NGINX Unit instances from the Unit main process and its controller,
+	// router and application prototype child processes
+	UnitProcessParser struct {
+		executer exec.ExecInterface
+	}
+
+	unitInfo struct {
+		version       string
+		exePath       string
+		controlSocket string
+		modules       []string
+		processID     int32
+	}
+)
+
+var _ processParser = (*UnitProcessParser)(nil)
+
+func NewUnitProcessParser() *UnitProcessParser {
+	return &UnitProcessParser{
+		executer: &exec.Exec{},
+	}
+}
+
+func (upp *UnitProcessParser) Parse(ctx context.Context, processes []*nginxprocess.Process) map[string]*mpi.Instance {
+	instanceMap := make(map[string]*mpi.Instance)    // key is instanceID
+	children := make(map[int32][]*mpi.InstanceChild) // key is pid of the main process
+
+	for _, proc := range processes {
+		if !proc.IsUnit() {
+			continue
+		}
+
+		if !proc.IsUnitMain() {
+			children[proc.PPID] = append(children[proc.PPID], &mpi.InstanceChild{ProcessId: proc.PID})
+			continue
+		}
+
+		info, err := upp.info(ctx, proc)
+		if err != nil {
+			slog.DebugContext(ctx, "Unable to get NGINX Unit info", "pid", proc.PID, "error", err)
+
+			continue
+		}
+
+		instance := convertUnitInfoToInstance(info)
+		instanceMap[instance.GetInstanceMeta().GetInstanceId()] = instance
+	}
+
+	for _, instance := range instanceMap {
+		if val, ok := children[instance.GetInstanceRuntime().GetProcessId()]; ok {
+			sort.Slice(val, func(i, j int) bool { return val[i].GetProcessId() < val[j].GetProcessId() })
+			instance.InstanceRuntime.InstanceChildren = val
+		}
+	}
+
+	return instanceMap
+}
+
+// info reads the version and build configuration of NGINX Unit from unitd --version. The control socket and
+// modules directory the main process was started with take precedence over the ones Unit was built with.
+func (upp *UnitProcessParser) info(ctx context.Context, proc *nginxprocess.Process) (*unitInfo, error) {
+	cmdArgs := unitCommandArgs(proc.Cmd)
+
+	exePath := proc.Exe
+	if exePath == "" {
+		exePath = cmdArgs[""]
+		if exePath == "" {
+			return nil, fmt.Errorf("unable to find NGINX Unit exe for process %d", proc.PID)
+		}
+	}
+
+	outputBuffer, err := upp.executer.RunCmd(ctx, exePath, "--version")
+	if err != nil {
+		return nil, err
+	}
+
+	version, configureArgs := parseUnitVersionOutput(outputBuffer)
+	if version == "" {
+		return nil, errors.New("unable to parse NGINX Unit version")
+	}
+
+	controlSocket := firstNonEmpty(cmdArgs[unitControlArg], configureArgs[unitControlArg], defaultUnitControlSocket)
+
+	info := &unitInfo{
+		version:       version,
+		exePath:       exePath,
+		controlSocket: controlSocket,
+		processID:     proc.PID,
+	}
+
+	modulesDir := firstNonEmpty(cmdArgs[unitModulesDirArg], cmdArgs[unitModulesArg],
+		configureArgs[unitModulesDirArg], configureArgs[unitModulesArg])
+	if modulesDir != "" {
+		info.modules, err = readDirectory(modulesDir, unitModuleExtension)
+		if err != nil {
+			slog.DebugContext(ctx, "Error reading NGINX Unit modules dir", "dir", modulesDir, "error", err)
+		}
+
+		sort.Strings(info.modules)
+	}
+
+	return info, nil
+}
+
+func convertUnitInfoToInstance(info *unitInfo) *mpi.Instance {
+	return &mpi.Instance{
+		InstanceMeta: &mpi.InstanceMeta{
+			InstanceId:   id.Generate("%s_%s", info.exePath, info.controlSocket),
+			InstanceType: mpi.InstanceMeta_INSTANCE_TYPE_UNIT,
+			Version:      info.version,
+		},
+		InstanceRuntime: &mpi.InstanceRuntime{
+			ProcessId:  info.processID,
+			BinaryPath: info.exePath,
+			Details: &mpi.InstanceRuntime_UnitRuntimeInfo{
+				UnitRuntimeInfo: &mpi.UnitRuntimeInfo{
+					ControlSocket: info.controlSocket,
+					Version:       info.version,
+					Modules:       info.modules,
+				},
+			},
+		},
+	}
+}
+
+// parseUnitVersionOutput parses the output of unitd --version, e.g.
+//
+//	unit version: 1.34.1
+//	configured as ./configure --prefix=/usr --control=unix:/var/run/control.unit.sock --modulesdir=/usr/lib/unit/modules
+func parseUnitVersionOutput(output *bytes.Buffer) (version string, configureArgs map[string]string) {
+	configureArgs = make(map[string]string)
+
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, unitVersionPrefix):
+			version = strings.TrimSpace(strings.TrimPrefix(line, unitVersionPrefix))
+		case strings.HasPrefix(line, unitConfiguredAsPrefix):
+			for _, arg := range strings.Fields(strings.TrimPrefix(line, unitConfiguredAsPrefix)) {
+				key, value, found := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+				if found {
+					configureArgs[key] = value
+				}
+			}
+		}
+	}
+
+	return version, configureArgs
+}
+
+// unitCommandArgs parses the arguments of the Unit main process, which are in brackets at the end of the
+// process title, e.g. "unit: main v1.34.1 [/usr/sbin/unitd --control unix:/var/run/control.unit.sock]".
+// The executable is stored with an empty key.
+func unitCommandArgs(cmd string) map[string]string {
+	args := make(map[string]string)
+
+	start := strings.Index(cmd, "[")
+	end := strings.LastIndex(cmd, "]")
+	if start == -1 || end <= start {
+		return args
+	}
+
+	fields := strings.Fields(cmd[start+1 : end])
+	for i := 0; i < len(fields); i++ {
+		if i == 0 && !strings.HasPrefix(fields[i], "--") {
+			args[""] = fields[i]
+			continue
+		}
+
+		if !strings.HasPrefix(fields[i], "--") {
+			continue
+		}
+
+		key, value, found := strings.Cut(strings.TrimPrefix(fields[i], "--"), "=")
+		if !found && i+1 < len(fields) && !strings.HasPrefix(fields[i+1], "--") {
+			value = fields[i+1]
+			i++
+		}
+
+		args[key] = value
+	}
+
+	return args
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
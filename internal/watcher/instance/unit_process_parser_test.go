@@ -0,0 +1,168 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package instance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	mpi "github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/pkg/host/exec/execfakes"
+	"github.com/nginx/agent/v3/pkg/id"
+	"github.com/nginx/agent/v3/pkg/nginxprocess"
+)
+
+const (
+	unitExePath              = "/usr/sbin/unitd"
+	unitVersionCommandOutput = "unit version: 1.34.1\n" +
+		"configured as ./configure --prefix=/usr --statedir=/var/lib/unit " +
+		"--control=unix:/var/run/control.unit.sock --runstatedir=/var/run --pid=/var/run/unit.pid " +
+		"--log=/var/log/unit.log --modulesdir=%s --openssl --njs\n"
+)
+
+func TestUnitProcessParser_Parse(t *testing.T) {
+	ctx := context.Background()
+	modulesDir := t.TempDir()
+
+	for _, module := range []string{"python3.11.unit.so", "php.unit.so", "README"} {
+		require.NoError(t, os.WriteFile(filepath.Join(modulesDir, module), []byte{}, 0o600))
+	}
+
+	processes := []*nginxprocess.Process{
+		{
+			PID:  1000,
+			PPID: 1,
+			Name: "unitd",
+			Cmd:  "unit: main v1.34.1 [" + unitExePath + " --no-daemon]",
+			Exe:  unitExePath,
+		},
+		{PID: 1001, PPID: 1000, Name: "unitd", Cmd: "unit: controller", Exe: unitExePath},
+		{PID: 1002, PPID: 1000, Name: "unitd", Cmd: "unit: router", Exe: unitExePath},
+		{PID: 1003, PPID: 1000, Name: "unitd", Cmd: `unit: "flask" prototype (isolated)`, Exe: unitExePath},
+		{
+			PID:  2000,
+			PPID: 1,
+			Name: "nginx",
+			Cmd:  "nginx: master process /usr/sbin/nginx -g daemon off;",
+			Exe:  "/usr/sbin/nginx",
+		},
+		{PID: 2001, PPID: 2000, Name: "nginx", Cmd: "nginx: worker process", Exe: "/usr/sbin/nginx"},
+	}
+
+	controlSocket := "unix:/var/run/control.unit.sock"
+	expectedInstance := &mpi.Instance{
+		InstanceMeta: &mpi.InstanceMeta{
+			InstanceId:   id.Generate("%s_%s", unitExePath, controlSocket),
+			InstanceType: mpi.InstanceMeta_INSTANCE_TYPE_UNIT,
+			Version:      "1.34.1",
+		},
+		InstanceRuntime: &mpi.InstanceRuntime{
+			ProcessId:  1000,
+			BinaryPath: unitExePath,
+			Details: &mpi.InstanceRuntime_UnitRuntimeInfo{
+				UnitRuntimeInfo: &mpi.UnitRuntimeInfo{
+					ControlSocket: controlSocket,
+					Version:       "1.34.1",
+					Modules:       []string{"php", "python3.11"},
+				},
+			},
+			InstanceChildren: []*mpi.InstanceChild{{ProcessId: 1001}, {ProcessId: 1002}, {ProcessId: 1003}},
+		},
+	}
+
+	mockExec := &execfakes.FakeExecInterface{}
+	mockExec.RunCmdReturns(bytes.NewBufferString(fmt.Sprintf(unitVersionCommandOutput, modulesDir)), nil)
+
+	parser := NewUnitProcessParser()
+	parser.executer = mockExec
+
+	instances := parser.Parse(ctx, processes)
+	require.Len(t, instances, 1)
+	assert.True(t, proto.Equal(expectedInstance, instances[expectedInstance.GetInstanceMeta().GetInstanceId()]))
+
+	_, cmd, args := mockExec.RunCmdArgsForCall(0)
+	assert.Equal(t, unitExePath, cmd)
+	assert.Equal(t, []string{"--version"}, args)
+}
+
+func TestUnitProcessParser_Parse_ControlSocket(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name                  string
+		cmd                   string
+		versionOutput         string
+		expectedControlSocket string
+	}{
+		{
+			name:                  "Test 1: control socket from command line",
+			cmd:                   "unit: main v1.34.1 [unitd --control unix:/tmp/control.sock --no-daemon]",
+			versionOutput:         fmt.Sprintf(unitVersionCommandOutput, ""),
+			expectedControlSocket: "unix:/tmp/control.sock",
+		},
+		{
+			name:                  "Test 2: control socket from command line with equals sign",
+			cmd:                   "unit: main v1.34.1 [unitd --control=127.0.0.1:8080]",
+			versionOutput:         fmt.Sprintf(unitVersionCommandOutput, ""),
+			expectedControlSocket: "127.0.0.1:8080",
+		},
+		{
+			name:                  "Test 3: control socket from build configuration",
+			cmd:                   "unit: main v1.34.1 [unitd]",
+			versionOutput:         fmt.Sprintf(unitVersionCommandOutput, ""),
+			expectedControlSocket: "unix:/var/run/control.unit.sock",
+		},
+		{
+			name:                  "Test 4: default control socket",
+			cmd:                   "unit: main v1.34.1 [unitd]",
+			versionOutput:         "unit version: 1.34.1\n",
+			expectedControlSocket: defaultUnitControlSocket,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			mockExec := &execfakes.FakeExecInterface{}
+			mockExec.RunCmdReturns(bytes.NewBufferString(test.versionOutput), nil)
+
+			parser := NewUnitProcessParser()
+			parser.executer = mockExec
+
+			instances := parser.Parse(ctx, []*nginxprocess.Process{
+				{PID: 1000, PPID: 1, Name: "unitd", Cmd: test.cmd},
+			})
+			require.Len(tt, instances, 1)
+
+			for _, instance := range instances {
+				assert.Equal(tt, test.expectedControlSocket,
+					instance.GetInstanceRuntime().GetUnitRuntimeInfo().GetControlSocket())
+				assert.Equal(tt, "unitd", instance.GetInstanceRuntime().GetBinaryPath())
+			}
+		})
+	}
+}
+
+func TestUnitProcessParser_Parse_VersionError(t *testing.T) {
+	mockExec := &execfakes.FakeExecInterface{}
+	mockExec.RunCmdReturns(nil, errors.New("exec format error"))
+
+	parser := NewUnitProcessParser()
+	parser.executer = mockExec
+
+	instances := parser.Parse(context.Background(), []*nginxprocess.Process{
+		{PID: 1000, PPID: 1, Name: "unitd", Cmd: "unit: main v1.34.1 [unitd]", Exe: unitExePath},
+	})
+	assert.Empty(t, instances)
+}
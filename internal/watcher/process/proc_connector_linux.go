@@ -0,0 +1,275 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package process
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Values from linux/connector.h and linux/cn_proc.h
+const (
+	cnIdxProc          = 0x1
+	cnValProc          = 0x1
+	procCnMcastListen  = 0x1
+	procEventNone      = 0x0
+	procEventFork      = 0x1
+	procEventExec      = 0x2
+	procEventExit      = 0x80000000
+	cnMsgSize          = 20 // struct cn_msg without data
+	procEventHeaderLen = 16 // what, cpu and timestamp_ns of struct proc_event
+	// size of the largest event data that is read, which are the pids and tgids of a fork event
+	procEventDataLen = 16
+
+	procConnectorBufferSize = 4096
+	// how long to wait for the kernel to acknowledge the subscription to process events
+	procConnectorAckTimeout = 2 * time.Second
+	// how often to check if the context is done while waiting for process events
+	procConnectorReadTimeout = time.Second
+)
+
+type procConnector struct {
+	fd int
+}
+
+// listenProcessEvents subscribes to fork, exec and exit events of the Linux proc connector. Exec events are only
+// sent for NGINX and NGINX Unit executables. The channel is closed if the events can no longer be received.
+func listenProcessEvents(ctx context.Context) (<-chan ProcessEvent, error) {
+	conn, err := newProcConnector()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProcessEventsUnsupported, err)
+	}
+
+	events := make(chan ProcessEvent, processEventsBufferSize)
+
+	go func() {
+		defer close(events)
+		defer conn.close()
+
+		conn.read(ctx, events)
+	}()
+
+	return events, nil
+}
+
+func newProcConnector() (*procConnector, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, unix.NETLINK_CONNECTOR)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create proc connector socket: %w", err)
+	}
+
+	conn := &procConnector{fd: fd}
+
+	if err = unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK, Groups: cnIdxProc}); err != nil {
+		conn.close()
+		return nil, fmt.Errorf("unable to bind proc connector socket: %w", err)
+	}
+
+	if err = conn.subscribe(); err != nil {
+		conn.close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// subscribe asks the kernel to send process events and waits for the acknowledgement, which contains an error
+// if the agent is not allowed to receive process events
+func (pc *procConnector) subscribe() error {
+	if err := pc.setReadTimeout(procConnectorAckTimeout); err != nil {
+		return err
+	}
+
+	err := unix.Sendto(pc.fd, subscribeMessage(), 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK})
+	if err != nil {
+		return fmt.Errorf("unable to subscribe to process events: %w", err)
+	}
+
+	buf := make([]byte, procConnectorBufferSize)
+	for {
+		n, _, recvErr := unix.Recvfrom(pc.fd, buf, 0)
+		if recvErr != nil {
+			return fmt.Errorf("no acknowledgement of the subscription to process events: %w", recvErr)
+		}
+
+		for _, event := range parseProcEvents(buf[:n]) {
+			if event.what != procEventNone {
+				continue
+			}
+
+			if event.ackErr != 0 {
+				return fmt.Errorf("subscription to process events rejected: %w", unix.Errno(event.ackErr))
+			}
+
+			return pc.setReadTimeout(procConnectorReadTimeout)
+		}
+	}
+}
+
+func (pc *procConnector) read(ctx context.Context, events chan<- ProcessEvent) {
+	buf := make([]byte, procConnectorBufferSize)
+
+	for ctx.Err() == nil {
+		n, _, err := unix.Recvfrom(pc.fd, buf, 0)
+		switch {
+		case errors.Is(err, unix.EAGAIN), errors.Is(err, unix.EINTR):
+			continue
+		case errors.Is(err, unix.ENOBUFS):
+			slog.DebugContext(ctx, "Process events were dropped")
+
+			if !sendProcessEvent(ctx, events, ProcessEvent{Type: ProcessEventsLost}) {
+				return
+			}
+
+			continue
+		case err != nil:
+			slog.WarnContext(ctx, "Unable to read process events", "error", err)
+			return
+		}
+
+		for _, event := range parseProcEvents(buf[:n]) {
+			processEvent, ok := event.processEvent()
+			if !ok {
+				continue
+			}
+
+			if !sendProcessEvent(ctx, events, processEvent) {
+				return
+			}
+		}
+	}
+}
+
+func (pc *procConnector) setReadTimeout(timeout time.Duration) error {
+	tv := unix.NsecToTimeval(timeout.Nanoseconds())
+	if err := unix.SetsockoptTimeval(pc.fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		return fmt.Errorf("unable to set proc connector read timeout: %w", err)
+	}
+
+	return nil
+}
+
+func (pc *procConnector) close() {
+	_ = unix.Close(pc.fd)
+}
+
+func sendProcessEvent(ctx context.Context, events chan<- ProcessEvent, event ProcessEvent) bool {
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// subscribeMessage is a netlink message containing a cn_msg with the PROC_CN_MCAST_LISTEN operation
+func subscribeMessage() []byte {
+	const opLen = 4
+	msgLen := unix.SizeofNlMsghdr + cnMsgSize + opLen
+	msg := make([]byte, msgLen)
+
+	binary.NativeEndian.PutUint32(msg[0:4], uint32(msgLen))
+	binary.NativeEndian.PutUint16(msg[4:6], unix.NLMSG_DONE)
+	binary.NativeEndian.PutUint32(msg[12:16], uint32(os.Getpid()))
+
+	cnMsg := msg[unix.SizeofNlMsghdr:]
+	binary.NativeEndian.PutUint32(cnMsg[0:4], cnIdxProc)
+	binary.NativeEndian.PutUint32(cnMsg[4:8], cnValProc)
+	binary.NativeEndian.PutUint16(cnMsg[16:18], opLen)
+	binary.NativeEndian.PutUint32(cnMsg[cnMsgSize:], procCnMcastListen)
+
+	return msg
+}
+
+type procEvent struct {
+	what uint32
+	// pid and tgid of the process, or of the child process of a fork event
+	pid, tgid uint32
+	// parent of a fork event
+	parentTgid uint32
+	ackErr     uint32
+}
+
+func (pe procEvent) processEvent() (ProcessEvent, bool) {
+	// events of threads other than the main thread of a process are ignored
+	if pe.pid != pe.tgid {
+		return ProcessEvent{}, false
+	}
+
+	switch pe.what {
+	case procEventFork:
+		return ProcessEvent{Type: ProcessEventFork, PID: int32(pe.pid), ParentPID: int32(pe.parentTgid)}, true
+	case procEventExec:
+		if !isNginxProcessName(processName(pe.pid)) {
+			return ProcessEvent{}, false
+		}
+
+		return ProcessEvent{Type: ProcessEventExec, PID: int32(pe.pid)}, true
+	case procEventExit:
+		return ProcessEvent{Type: ProcessEventExit, PID: int32(pe.pid)}, true
+	default:
+		return ProcessEvent{}, false
+	}
+}
+
+// parseProcEvents parses the proc_event structs in the netlink messages received from the proc connector
+func parseProcEvents(buf []byte) []procEvent {
+	messages, err := syscall.ParseNetlinkMessage(buf)
+	if err != nil {
+		return nil
+	}
+
+	events := make([]procEvent, 0, len(messages))
+	for _, message := range messages {
+		if len(message.Data) < cnMsgSize+procEventHeaderLen+procEventDataLen {
+			continue
+		}
+
+		if binary.NativeEndian.Uint32(message.Data[0:4]) != cnIdxProc ||
+			binary.NativeEndian.Uint32(message.Data[4:8]) != cnValProc {
+			continue
+		}
+
+		data := message.Data[cnMsgSize:]
+		event := procEvent{what: binary.NativeEndian.Uint32(data[0:4])}
+		eventData := data[procEventHeaderLen:]
+
+		switch event.what {
+		case procEventNone:
+			event.ackErr = binary.NativeEndian.Uint32(eventData[0:4])
+		case procEventFork:
+			event.parentTgid = binary.NativeEndian.Uint32(eventData[4:8])
+			event.pid = binary.NativeEndian.Uint32(eventData[8:12])
+			event.tgid = binary.NativeEndian.Uint32(eventData[12:16])
+		default:
+			event.pid = binary.NativeEndian.Uint32(eventData[0:4])
+			event.tgid = binary.NativeEndian.Uint32(eventData[4:8])
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func processName(pid uint32) string {
+	comm, err := os.ReadFile("/proc/" + strconv.FormatUint(uint64(pid), 10) + "/comm")
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(comm))
+}
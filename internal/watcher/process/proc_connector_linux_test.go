@@ -0,0 +1,109 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package process
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func procEventMessage(what uint32, eventData ...uint32) []byte {
+	// proc_event is padded to the size of its largest event data
+	const procEventSize = procEventHeaderLen + 24
+	msgLen := unix.SizeofNlMsghdr + cnMsgSize + procEventSize
+	msg := make([]byte, msgLen)
+
+	binary.NativeEndian.PutUint32(msg[0:4], uint32(msgLen))
+	binary.NativeEndian.PutUint16(msg[4:6], unix.NLMSG_DONE)
+
+	cnMsg := msg[unix.SizeofNlMsghdr:]
+	binary.NativeEndian.PutUint32(cnMsg[0:4], cnIdxProc)
+	binary.NativeEndian.PutUint32(cnMsg[4:8], cnValProc)
+	binary.NativeEndian.PutUint16(cnMsg[16:18], procEventSize)
+
+	procEvent := cnMsg[cnMsgSize:]
+	binary.NativeEndian.PutUint32(procEvent[0:4], what)
+	for i, value := range eventData {
+		binary.NativeEndian.PutUint32(procEvent[procEventHeaderLen+4*i:], value)
+	}
+
+	return msg
+}
+
+func TestParseProcEvents(t *testing.T) {
+	pid := uint32(os.Getpid())
+
+	buf := procEventMessage(procEventFork, 1, 1, 200, 200)
+	buf = append(buf, procEventMessage(procEventFork, 200, 200, 201, 200)...)
+	buf = append(buf, procEventMessage(procEventExec, pid, pid)...)
+	buf = append(buf, procEventMessage(procEventExit, 200, 200, 0, 17)...)
+	buf = append(buf, procEventMessage(procEventNone, uint32(unix.EPERM))...)
+
+	events := parseProcEvents(buf)
+	require.Len(t, events, 5)
+
+	processEvent, ok := events[0].processEvent()
+	assert.True(t, ok)
+	assert.Equal(t, ProcessEvent{Type: ProcessEventFork, PID: 200, ParentPID: 1}, processEvent)
+
+	// threads are ignored
+	_, ok = events[1].processEvent()
+	assert.False(t, ok)
+
+	// exec events are only sent for NGINX executables
+	_, ok = events[2].processEvent()
+	assert.False(t, ok)
+
+	processEvent, ok = events[3].processEvent()
+	assert.True(t, ok)
+	assert.Equal(t, ProcessEvent{Type: ProcessEventExit, PID: 200}, processEvent)
+
+	assert.Equal(t, uint32(procEventNone), events[4].what)
+	assert.Equal(t, uint32(unix.EPERM), events[4].ackErr)
+}
+
+func TestSubscribeMessage(t *testing.T) {
+	msg := subscribeMessage()
+	require.Len(t, msg, unix.SizeofNlMsghdr+cnMsgSize+4)
+
+	assert.Equal(t, uint32(len(msg)), binary.NativeEndian.Uint32(msg[0:4]))
+	assert.Equal(t, uint32(procCnMcastListen), binary.NativeEndian.Uint32(msg[len(msg)-4:]))
+}
+
+func TestProcessOperator_ProcessEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := NewProcessOperator().ProcessEvents(ctx)
+	if err != nil {
+		// requires CAP_NET_ADMIN and a kernel with the proc connector
+		require.ErrorIs(t, err, ErrProcessEventsUnsupported)
+		t.Skipf("Process events not supported: %v", err)
+	}
+
+	cmd := exec.CommandContext(ctx, "true")
+	require.NoError(t, cmd.Run())
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-events:
+			if event.Type == ProcessEventExit && event.PID == int32(cmd.Process.Pid) {
+				return
+			}
+		case <-timeout:
+			t.Fatal("Timed out waiting for process exit event")
+		}
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+//go:build !linux
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+// listenProcessEvents always fails, since the proc connector is only available on Linux
+func listenProcessEvents(_ context.Context) (<-chan ProcessEvent, error) {
+	return nil, fmt.Errorf("%w on %s", ErrProcessEventsUnsupported, runtime.GOOS)
+}
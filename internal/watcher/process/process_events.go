@@ -0,0 +1,40 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package process
+
+import "errors"
+
+// ProcessEventType is the type of change to a process reported by the kernel
+type ProcessEventType int
+
+const (
+	// ProcessEventFork is a new process that was forked from a parent process
+	ProcessEventFork ProcessEventType = iota + 1
+	// ProcessEventExec is an NGINX or NGINX Unit executable that was started by a process
+	ProcessEventExec
+	// ProcessEventExit is a process that exited
+	ProcessEventExit
+	// ProcessEventsLost is sent when events were dropped because they were not read fast enough, the processes
+	// have to be listed again
+	ProcessEventsLost
+)
+
+const processEventsBufferSize = 256
+
+// ErrProcessEventsUnsupported is returned if process events can't be received, because NGINX Agent does not have
+// the CAP_NET_ADMIN capability or the kernel does not support the proc connector
+var ErrProcessEventsUnsupported = errors.New("process events are not supported")
+
+type ProcessEvent struct {
+	Type ProcessEventType
+	PID  int32
+	// parent of a forked process
+	ParentPID int32
+}
+
+func isNginxProcessName(name string) bool {
+	return name == "nginx" || name == "nginx-debug" || name == "unitd"
+}
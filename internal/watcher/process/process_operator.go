@@ -16,7 +16,7 @@ import (
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6@v6.11.2 -generate
 //counterfeiter:generate . ProcessOperatorInterface
 type (
-	// ProcessOperator provides details about running NGINX processes.
+	// ProcessOperator provides details about running NGINX and NGINX Unit processes.
 	ProcessOperator struct{}
 
 	ProcessOperatorInterface interface {
@@ -25,6 +25,8 @@ type (
 			err error,
 		)
 		Process(ctx context.Context, pid int32) (*nginxprocess.Process, error)
+		NginxProcess(ctx context.Context, pid int32) (*nginxprocess.Process, error)
+		ProcessEvents(ctx context.Context) (<-chan ProcessEvent, error)
 	}
 )
 
@@ -43,7 +45,7 @@ func (pw *ProcessOperator) Processes(ctx context.Context) (
 		return nil, err
 	}
 
-	return nginxprocess.ListWithProcesses(ctx, processes)
+	return nginxprocess.ListWithProcesses(ctx, processes, nginxprocess.WithUnit(true))
 }
 
 func (pw *ProcessOperator) Process(ctx context.Context, pid int32) (*nginxprocess.Process, error) {
@@ -55,6 +57,18 @@ func (pw *ProcessOperator) Process(ctx context.Context, pid int32) (*nginxproces
 	return convertProcess(ctx, proc), nil
 }
 
+// NginxProcess returns the NGINX or NGINX Unit process with the pid. An error is returned if the process is not
+// running or is not an NGINX or NGINX Unit process.
+func (pw *ProcessOperator) NginxProcess(ctx context.Context, pid int32) (*nginxprocess.Process, error) {
+	return nginxprocess.Find(ctx, pid, nginxprocess.WithUnit(true))
+}
+
+// ProcessEvents returns a channel of process fork, exec and exit events from the Linux proc connector, so that
+// processes don't have to be polled. Returns ErrProcessEventsUnsupported if process events can't be received.
+func (pw *ProcessOperator) ProcessEvents(ctx context.Context) (<-chan ProcessEvent, error) {
+	return listenProcessEvents(ctx)
+}
+
 func convertProcess(ctx context.Context, proc *process.Process) *nginxprocess.Process {
 	ppid, _ := proc.PpidWithContext(ctx)
 	name, _ := proc.NameWithContext(ctx)
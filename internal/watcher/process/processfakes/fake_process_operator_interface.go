@@ -10,6 +10,20 @@ import (
 )
 
 type FakeProcessOperatorInterface struct {
+	NginxProcessStub        func(context.Context, int32) (*nginxprocess.Process, error)
+	nginxProcessMutex       sync.RWMutex
+	nginxProcessArgsForCall []struct {
+		arg1 context.Context
+		arg2 int32
+	}
+	nginxProcessReturns struct {
+		result1 *nginxprocess.Process
+		result2 error
+	}
+	nginxProcessReturnsOnCall map[int]struct {
+		result1 *nginxprocess.Process
+		result2 error
+	}
 	ProcessStub        func(context.Context, int32) (*nginxprocess.Process, error)
 	processMutex       sync.RWMutex
 	processArgsForCall []struct {
@@ -24,6 +38,19 @@ type FakeProcessOperatorInterface struct {
 		result1 *nginxprocess.Process
 		result2 error
 	}
+	ProcessEventsStub        func(context.Context) (<-chan process.ProcessEvent, error)
+	processEventsMutex       sync.RWMutex
+	processEventsArgsForCall []struct {
+		arg1 context.Context
+	}
+	processEventsReturns struct {
+		result1 <-chan process.ProcessEvent
+		result2 error
+	}
+	processEventsReturnsOnCall map[int]struct {
+		result1 <-chan process.ProcessEvent
+		result2 error
+	}
 	ProcessesStub        func(context.Context) ([]*nginxprocess.Process, error)
 	processesMutex       sync.RWMutex
 	processesArgsForCall []struct {
@@ -41,6 +68,71 @@ type FakeProcessOperatorInterface struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeProcessOperatorInterface) NginxProcess(arg1 context.Context, arg2 int32) (*nginxprocess.Process, error) {
+	fake.nginxProcessMutex.Lock()
+	ret, specificReturn := fake.nginxProcessReturnsOnCall[len(fake.nginxProcessArgsForCall)]
+	fake.nginxProcessArgsForCall = append(fake.nginxProcessArgsForCall, struct {
+		arg1 context.Context
+		arg2 int32
+	}{arg1, arg2})
+	stub := fake.NginxProcessStub
+	fakeReturns := fake.nginxProcessReturns
+	fake.recordInvocation("NginxProcess", []interface{}{arg1, arg2})
+	fake.nginxProcessMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeProcessOperatorInterface) NginxProcessCallCount() int {
+	fake.nginxProcessMutex.RLock()
+	defer fake.nginxProcessMutex.RUnlock()
+	return len(fake.nginxProcessArgsForCall)
+}
+
+func (fake *FakeProcessOperatorInterface) NginxProcessCalls(stub func(context.Context, int32) (*nginxprocess.Process, error)) {
+	fake.nginxProcessMutex.Lock()
+	defer fake.nginxProcessMutex.Unlock()
+	fake.NginxProcessStub = stub
+}
+
+func (fake *FakeProcessOperatorInterface) NginxProcessArgsForCall(i int) (context.Context, int32) {
+	fake.nginxProcessMutex.RLock()
+	defer fake.nginxProcessMutex.RUnlock()
+	argsForCall := fake.nginxProcessArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeProcessOperatorInterface) NginxProcessReturns(result1 *nginxprocess.Process, result2 error) {
+	fake.nginxProcessMutex.Lock()
+	defer fake.nginxProcessMutex.Unlock()
+	fake.NginxProcessStub = nil
+	fake.nginxProcessReturns = struct {
+		result1 *nginxprocess.Process
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeProcessOperatorInterface) NginxProcessReturnsOnCall(i int, result1 *nginxprocess.Process, result2 error) {
+	fake.nginxProcessMutex.Lock()
+	defer fake.nginxProcessMutex.Unlock()
+	fake.NginxProcessStub = nil
+	if fake.nginxProcessReturnsOnCall == nil {
+		fake.nginxProcessReturnsOnCall = make(map[int]struct {
+			result1 *nginxprocess.Process
+			result2 error
+		})
+	}
+	fake.nginxProcessReturnsOnCall[i] = struct {
+		result1 *nginxprocess.Process
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeProcessOperatorInterface) Process(arg1 context.Context, arg2 int32) (*nginxprocess.Process, error) {
 	fake.processMutex.Lock()
 	ret, specificReturn := fake.processReturnsOnCall[len(fake.processArgsForCall)]
@@ -106,6 +198,70 @@ func (fake *FakeProcessOperatorInterface) ProcessReturnsOnCall(i int, result1 *n
 	}{result1, result2}
 }
 
+func (fake *FakeProcessOperatorInterface) ProcessEvents(arg1 context.Context) (<-chan process.ProcessEvent, error) {
+	fake.processEventsMutex.Lock()
+	ret, specificReturn := fake.processEventsReturnsOnCall[len(fake.processEventsArgsForCall)]
+	fake.processEventsArgsForCall = append(fake.processEventsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ProcessEventsStub
+	fakeReturns := fake.processEventsReturns
+	fake.recordInvocation("ProcessEvents", []interface{}{arg1})
+	fake.processEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeProcessOperatorInterface) ProcessEventsCallCount() int {
+	fake.processEventsMutex.RLock()
+	defer fake.processEventsMutex.RUnlock()
+	return len(fake.processEventsArgsForCall)
+}
+
+func (fake *FakeProcessOperatorInterface) ProcessEventsCalls(stub func(context.Context) (<-chan process.ProcessEvent, error)) {
+	fake.processEventsMutex.Lock()
+	defer fake.processEventsMutex.Unlock()
+	fake.ProcessEventsStub = stub
+}
+
+func (fake *FakeProcessOperatorInterface) ProcessEventsArgsForCall(i int) context.Context {
+	fake.processEventsMutex.RLock()
+	defer fake.processEventsMutex.RUnlock()
+	argsForCall := fake.processEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeProcessOperatorInterface) ProcessEventsReturns(result1 <-chan process.ProcessEvent, result2 error) {
+	fake.processEventsMutex.Lock()
+	defer fake.processEventsMutex.Unlock()
+	fake.ProcessEventsStub = nil
+	fake.processEventsReturns = struct {
+		result1 <-chan process.ProcessEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeProcessOperatorInterface) ProcessEventsReturnsOnCall(i int, result1 <-chan process.ProcessEvent, result2 error) {
+	fake.processEventsMutex.Lock()
+	defer fake.processEventsMutex.Unlock()
+	fake.ProcessEventsStub = nil
+	if fake.processEventsReturnsOnCall == nil {
+		fake.processEventsReturnsOnCall = make(map[int]struct {
+			result1 <-chan process.ProcessEvent
+			result2 error
+		})
+	}
+	fake.processEventsReturnsOnCall[i] = struct {
+		result1 <-chan process.ProcessEvent
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeProcessOperatorInterface) Processes(arg1 context.Context) ([]*nginxprocess.Process, error) {
 	fake.processesMutex.Lock()
 	ret, specificReturn := fake.processesReturnsOnCall[len(fake.processesArgsForCall)]
@@ -173,8 +329,12 @@ func (fake *FakeProcessOperatorInterface) ProcessesReturnsOnCall(i int, result1
 func (fake *FakeProcessOperatorInterface) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.nginxProcessMutex.RLock()
+	defer fake.nginxProcessMutex.RUnlock()
 	fake.processMutex.RLock()
 	defer fake.processMutex.RUnlock()
+	fake.processEventsMutex.RLock()
+	defer fake.processEventsMutex.RUnlock()
 	fake.processesMutex.RLock()
 	defer fake.processesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -25,6 +25,8 @@ import (
 	"github.com/nginx/agent/v3/internal/config"
 	"github.com/nginx/agent/v3/internal/logger"
 	pkgConfig "github.com/nginx/agent/v3/pkg/config"
+	"github.com/nginx/agent/v3/pkg/id"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6@v6.11.2 -generate
@@ -167,6 +169,8 @@ func (w *Watcher) Process(ctx context.Context, msg *bus.Message) {
 	switch msg.Topic {
 	case bus.ConfigApplyRequestTopic:
 		w.handleConfigApplyRequest(ctx, msg)
+	case bus.ExternalFileRefreshTopic:
+		w.handleExternalFileRefresh(ctx, msg)
 	case bus.DataPlaneHealthRequestTopic:
 		w.handleHealthRequest(ctx)
 	case bus.EnableWatchersTopic:
@@ -181,6 +185,7 @@ func (w *Watcher) Process(ctx context.Context, msg *bus.Message) {
 func (*Watcher) Subscriptions() []string {
 	return []string{
 		bus.ConfigApplyRequestTopic,
+		bus.ExternalFileRefreshTopic,
 		bus.DataPlaneHealthRequestTopic,
 		bus.EnableWatchersTopic,
 		bus.AgentConfigUpdateTopic,
@@ -250,6 +255,26 @@ func (w *Watcher) handleConfigApplyRequest(ctx context.Context, msg *bus.Message
 
 	instanceID := request.ConfigApplyRequest.GetOverview().GetConfigVersion().GetInstanceId()
 
+	w.disableWatchers(ctx, instanceID)
+}
+
+// handleExternalFileRefresh disables the watchers while the external files of an instance are refreshed, so that
+// the changed files and the reload are not reported as changes made outside of the agent. The NGINX plugin
+// enables the watchers again once the refresh is done.
+func (w *Watcher) handleExternalFileRefresh(ctx context.Context, msg *bus.Message) {
+	slog.DebugContext(ctx, "Watcher plugin received external file refresh message")
+	externalFileRefresh, ok := msg.Data.(*model.ExternalFileRefresh)
+	if !ok {
+		slog.ErrorContext(ctx, "Unable to cast message payload to *model.ExternalFileRefresh",
+			"payload", msg.Data, "topic", msg.Topic)
+
+		return
+	}
+
+	w.disableWatchers(ctx, externalFileRefresh.InstanceID)
+}
+
+func (w *Watcher) disableWatchers(ctx context.Context, instanceID string) {
 	w.watcherMutex.Lock()
 	defer w.watcherMutex.Unlock()
 	w.instancesWithConfigApplyInProgress = append(w.instancesWithConfigApplyInProgress, instanceID)
@@ -330,6 +355,13 @@ func (w *Watcher) handleCredentialUpdate(ctx context.Context, message credential
 		logger.ServerTypeContextKey, slog.Any(logger.ServerTypeKey,
 			message.ServerType.String()))
 
+	if message.ExternalDataSource {
+		slog.DebugContext(newCtx, "Received credential update event for external data sources")
+		w.messagePipe.Process(newCtx, &bus.Message{Topic: bus.CredentialUpdatedTopic})
+
+		return
+	}
+
 	slog.DebugContext(newCtx, "Received credential update event for command server")
 	w.messagePipe.Process(newCtx, &bus.Message{
 		Topic: bus.ConnectionResetTopic, Data: message.GrpcConnection,
@@ -343,6 +375,29 @@ func (w *Watcher) handleInstanceUpdates(newCtx context.Context, message instance
 
 		w.messagePipe.Process(newCtx, &bus.Message{Topic: bus.ResourceUpdateTopic, Data: message.Resource})
 	}
+
+	for _, instanceEvent := range message.InstanceEvents {
+		slog.InfoContext(newCtx, "Instance event detected", "instance_id", instanceEvent.GetInstanceId(),
+			"event_type", instanceEvent.GetEventType(), "changes", instanceEvent.GetChanges())
+
+		w.messagePipe.Process(newCtx, &bus.Message{
+			Topic: bus.DataPlaneResponseTopic,
+			Data: &mpi.DataPlaneResponse{
+				MessageMeta: &mpi.MessageMeta{
+					MessageId:     id.GenerateMessageID(),
+					CorrelationId: logger.CorrelationID(newCtx),
+					Timestamp:     timestamppb.Now(),
+				},
+				CommandResponse: &mpi.CommandResponse{
+					Status:  mpi.CommandResponse_COMMAND_STATUS_OK,
+					Message: "Instance event detected",
+				},
+				InstanceId:    instanceEvent.GetInstanceId(),
+				RequestType:   mpi.DataPlaneResponse_INSTANCE_EVENT,
+				InstanceEvent: instanceEvent,
+			},
+		})
+	}
 }
 
 func (w *Watcher) handleAgentConfigUpdate(ctx context.Context, msg *bus.Message) {
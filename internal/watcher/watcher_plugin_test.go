@@ -104,6 +104,65 @@ func TestWatcher_Init(t *testing.T) {
 		messages[3])
 }
 
+func TestWatcher_handleCredentialUpdate_ExternalDataSource(t *testing.T) {
+	ctx := context.Background()
+
+	watcherPlugin := NewWatcher(types.AgentConfig())
+	messagePipe := busfakes.NewFakeMessagePipe()
+	watcherPlugin.messagePipe = messagePipe
+
+	watcherPlugin.handleCredentialUpdate(ctx, credentials.CredentialUpdateMessage{
+		CorrelationID:      logger.GenerateCorrelationID(),
+		ServerType:         model.Command,
+		ExternalDataSource: true,
+	})
+
+	// the connection is not reset if only the external data source credentials changed
+	messages := messagePipe.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, &bus.Message{Topic: bus.CredentialUpdatedTopic}, messages[0])
+}
+
+func TestWatcher_handleInstanceUpdates_InstanceEvents(t *testing.T) {
+	ctx := context.Background()
+
+	watcherPlugin := NewWatcher(types.AgentConfig())
+	messagePipe := busfakes.NewFakeMessagePipe()
+	watcherPlugin.messagePipe = messagePipe
+
+	instanceEvent := &mpi.InstanceEvent{
+		InstanceId: protos.NginxOssInstance([]string{}).GetInstanceMeta().GetInstanceId(),
+		EventType:  mpi.InstanceEvent_INSTANCE_EVENT_TYPE_CONFIG_RELOADED,
+		Changes: []*mpi.InstanceEventChange{
+			{Name: "worker_process_ids", Before: "101,102", After: "103,104"},
+		},
+	}
+
+	correlationID := logger.GenerateCorrelationID()
+	watcherPlugin.handleInstanceUpdates(
+		context.WithValue(ctx, logger.CorrelationIDContextKey, correlationID),
+		instance.ResourceUpdatesMessage{
+			CorrelationID:  correlationID,
+			InstanceEvents: []*mpi.InstanceEvent{instanceEvent},
+		},
+	)
+
+	// no resource update is sent if only instance events were detected
+	messages := messagePipe.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, bus.DataPlaneResponseTopic, messages[0].Topic)
+
+	response, ok := messages[0].Data.(*mpi.DataPlaneResponse)
+	require.True(t, ok)
+	assert.Equal(t, instanceEvent, response.GetInstanceEvent())
+	assert.Equal(t, instanceEvent.GetInstanceId(), response.GetInstanceId())
+	assert.Equal(t, correlationID.Value.String(), response.GetMessageMeta().GetCorrelationId())
+	assert.NotEmpty(t, response.GetMessageMeta().GetMessageId())
+	assert.Equal(t, mpi.DataPlaneResponse_INSTANCE_EVENT, response.GetRequestType())
+	assert.Equal(t, mpi.CommandResponse_COMMAND_STATUS_OK, response.GetCommandResponse().GetStatus())
+	assert.Equal(t, "Instance event detected", response.GetCommandResponse().GetMessage())
+}
+
 func TestWatcher_Info(t *testing.T) {
 	watcherPlugin := NewWatcher(types.AgentConfig())
 	assert.Equal(t, &bus.Info{Name: "watcher"}, watcherPlugin.Info())
@@ -148,6 +207,25 @@ func TestWatcher_Process_ConfigApplyRequestTopic(t *testing.T) {
 	assert.Len(t, watcherPlugin.instancesWithConfigApplyInProgress, 1)
 }
 
+func TestWatcher_Process_ExternalFileRefreshTopic(t *testing.T) {
+	ctx := context.Background()
+	instanceID := protos.NginxOssInstance([]string{}).GetInstanceMeta().GetInstanceId()
+	message := &bus.Message{
+		Topic: bus.ExternalFileRefreshTopic,
+		Data:  &model.ExternalFileRefresh{InstanceID: instanceID},
+	}
+
+	fakeWatcherService := &watcherfakes.FakeInstanceWatcherServiceInterface{}
+	watcherPlugin := NewWatcher(types.AgentConfig())
+	watcherPlugin.instanceWatcherService = fakeWatcherService
+
+	watcherPlugin.Process(ctx, message)
+
+	assert.Equal(t, []string{instanceID}, watcherPlugin.instancesWithConfigApplyInProgress)
+	require.Equal(t, 1, fakeWatcherService.SetEnabledCallCount())
+	assert.False(t, fakeWatcherService.SetEnabledArgsForCall(0))
+}
+
 func TestWatcher_Process_ConfigApplySuccessfulTopic(t *testing.T) {
 	ctx := context.Background()
 	data := protos.NginxOssInstance([]string{})
@@ -232,6 +310,7 @@ func TestWatcher_Subscriptions(t *testing.T) {
 		t,
 		[]string{
 			bus.ConfigApplyRequestTopic,
+			bus.ExternalFileRefreshTopic,
 			bus.DataPlaneHealthRequestTopic,
 			bus.EnableWatchersTopic,
 			bus.AgentConfigUpdateTopic,
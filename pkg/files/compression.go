@@ -0,0 +1,110 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package files
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/nginx/agent/v3/api/grpc/mpi/v1"
+)
+
+// SupportedCompressions are the compressions that can be used to compress file contents, in order of preference
+var SupportedCompressions = []v1.Compression{
+	v1.Compression_COMPRESSION_ZSTD,
+	v1.Compression_COMPRESSION_GZIP,
+}
+
+// NegotiateCompression returns the first of the SupportedCompressions that is accepted, or
+// [v1.Compression_COMPRESSION_UNSPECIFIED] if none are accepted.
+func NegotiateCompression(accepted []v1.Compression) v1.Compression {
+	for _, compression := range SupportedCompressions {
+		if slices.Contains(accepted, compression) {
+			return compression
+		}
+	}
+
+	return v1.Compression_COMPRESSION_UNSPECIFIED
+}
+
+// Compress returns the data compressed with the compression. If the compression is
+// [v1.Compression_COMPRESSION_UNSPECIFIED], the data is returned unchanged.
+func Compress(data []byte, compression v1.Compression) ([]byte, error) {
+	var buf bytes.Buffer
+
+	var writer io.WriteCloser
+
+	switch compression {
+	case v1.Compression_COMPRESSION_UNSPECIFIED:
+		return data, nil
+	case v1.Compression_COMPRESSION_GZIP:
+		writer = gzip.NewWriter(&buf)
+	case v1.Compression_COMPRESSION_ZSTD:
+		zstdWriter, err := zstd.NewWriter(&buf, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("unable to create zstd writer: %w", err)
+		}
+		writer = zstdWriter
+	default:
+		return nil, fmt.Errorf("unsupported compression: %s", compression)
+	}
+
+	if _, err := writer.Write(data); err != nil {
+		return nil, fmt.Errorf("unable to compress data with %s: %w", compression, err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("unable to compress data with %s: %w", compression, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decompress returns the data decompressed with the compression. If the compression is
+// [v1.Compression_COMPRESSION_UNSPECIFIED], the data is returned unchanged.
+// An error is returned if the decompressed data is larger than maxSize, so that a small payload can't
+// decompress to an unbounded amount of data.
+func Decompress(data []byte, compression v1.Compression, maxSize int64) ([]byte, error) {
+	var reader io.Reader
+
+	switch compression {
+	case v1.Compression_COMPRESSION_UNSPECIFIED:
+		return data, nil
+	case v1.Compression_COMPRESSION_GZIP:
+		gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress data with %s: %w", compression, err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	case v1.Compression_COMPRESSION_ZSTD:
+		zstdReader, err := zstd.NewReader(bytes.NewReader(data), zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress data with %s: %w", compression, err)
+		}
+		defer zstdReader.Close()
+		reader = zstdReader
+	default:
+		return nil, fmt.Errorf("unsupported compression: %s", compression)
+	}
+
+	// read one byte more than the max size to detect data that exceeds it
+	decompressed, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress data with %s: %w", compression, err)
+	}
+
+	if int64(len(decompressed)) > maxSize {
+		return nil, fmt.Errorf("decompressed data exceeds max size of %d bytes", maxSize)
+	}
+
+	return decompressed, nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package files_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/api/grpc/mpi/v1/v1fakes"
+	"github.com/nginx/agent/v3/pkg/files"
+)
+
+func TestCompress(t *testing.T) {
+	content := bytes.Repeat([]byte("server { listen 80; }\n"), 100)
+
+	tests := []struct {
+		name        string
+		compression v1.Compression
+	}{
+		{
+			name:        "Test 1: no compression",
+			compression: v1.Compression_COMPRESSION_UNSPECIFIED,
+		},
+		{
+			name:        "Test 2: gzip",
+			compression: v1.Compression_COMPRESSION_GZIP,
+		},
+		{
+			name:        "Test 3: zstd",
+			compression: v1.Compression_COMPRESSION_ZSTD,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			compressed, err := files.Compress(content, test.compression)
+			require.NoError(tt, err)
+
+			if test.compression == v1.Compression_COMPRESSION_UNSPECIFIED {
+				assert.Equal(tt, content, compressed)
+			} else {
+				assert.Less(tt, len(compressed), len(content))
+			}
+
+			decompressed, err := files.Decompress(compressed, test.compression, int64(len(content)))
+			require.NoError(tt, err)
+			assert.Equal(tt, content, decompressed)
+		})
+	}
+}
+
+func TestDecompress_Errors(t *testing.T) {
+	bomb := make([]byte, 10*1024*1024)
+
+	for _, compression := range files.SupportedCompressions {
+		t.Run(compression.String(), func(tt *testing.T) {
+			compressed, err := files.Compress(bomb, compression)
+			require.NoError(tt, err)
+
+			_, err = files.Decompress(compressed, compression, 1024)
+			require.EqualError(tt, err, "decompressed data exceeds max size of 1024 bytes")
+
+			_, err = files.Decompress([]byte("not compressed"), compression, 1024)
+			require.Error(tt, err)
+		})
+	}
+
+	_, err := files.Compress([]byte("data"), v1.Compression(10))
+	require.EqualError(t, err, "unsupported compression: 10")
+
+	_, err = files.Decompress([]byte("data"), v1.Compression(10), 1024)
+	require.EqualError(t, err, "unsupported compression: 10")
+}
+
+func TestNegotiateCompression(t *testing.T) {
+	assert.Equal(t, v1.Compression_COMPRESSION_UNSPECIFIED, files.NegotiateCompression(nil))
+	assert.Equal(t, v1.Compression_COMPRESSION_GZIP,
+		files.NegotiateCompression([]v1.Compression{v1.Compression_COMPRESSION_GZIP}))
+	assert.Equal(t, v1.Compression_COMPRESSION_ZSTD, files.NegotiateCompression(
+		[]v1.Compression{v1.Compression_COMPRESSION_GZIP, v1.Compression_COMPRESSION_ZSTD}))
+}
+
+func TestChunkedFile_Compression(t *testing.T) {
+	content := bytes.Repeat(randBytes(100), 40)
+
+	for _, compression := range files.SupportedCompressions {
+		t.Run(compression.String(), func(tt *testing.T) {
+			server := &v1fakes.FakeFileService_GetFileStreamServer{}
+			header := v1.FileDataChunk_Header{
+				Header: &v1.FileDataChunkHeader{
+					FileMeta:    &v1.FileMeta{Size: int64(len(content))},
+					Chunks:      3,
+					ChunkSize:   1500,
+					Compression: compression,
+				},
+			}
+
+			err := files.SendChunkedFile(&v1.MessageMeta{}, header, bytes.NewReader(content), server)
+			require.NoError(tt, err)
+			require.Equal(tt, 4, server.SendCallCount())
+
+			client := &v1fakes.FakeFileService_GetFileStreamClient{}
+			for i := range server.SendCallCount() {
+				chunk := server.SendArgsForCall(i)
+				if i > 0 {
+					assert.Less(tt, len(chunk.GetContent().GetData()), 1500)
+				}
+				client.RecvReturnsOnCall(i, chunk, nil)
+			}
+
+			var result bytes.Buffer
+			_, err = files.RecvChunkedFile(client, &result)
+			require.NoError(tt, err)
+			assert.Equal(tt, content, result.Bytes())
+		})
+	}
+}
+
+func TestRecvChunkedFile_DecompressionBomb(t *testing.T) {
+	compressed, err := files.Compress(make([]byte, 10*1024), v1.Compression_COMPRESSION_GZIP)
+	require.NoError(t, err)
+
+	client := &v1fakes.FakeFileService_GetFileStreamClient{}
+	client.RecvReturnsOnCall(0, &v1.FileDataChunk{
+		Chunk: &v1.FileDataChunk_Header{
+			Header: &v1.FileDataChunkHeader{
+				FileMeta:    &v1.FileMeta{Size: 1024},
+				Chunks:      1,
+				ChunkSize:   1024,
+				Compression: v1.Compression_COMPRESSION_GZIP,
+			},
+		},
+	}, nil)
+	client.RecvReturnsOnCall(1, &v1.FileDataChunk{
+		Chunk: &v1.FileDataChunk_Content{
+			Content: &v1.FileDataChunkContent{Data: compressed},
+		},
+	}, nil)
+
+	_, err = files.RecvChunkedFile(client, &bytes.Buffer{})
+	require.EqualError(t, err, "unable to decompress chunk id 0: decompressed data exceeds max size of 1024 bytes")
+}
@@ -0,0 +1,338 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package files
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"google.golang.org/grpc"
+
+	"github.com/nginx/agent/v3/api/grpc/mpi/v1"
+)
+
+const (
+	minSignatureBlockSize = 2 * 1024
+	maxSignatureBlockSize = 128 * 1024
+	// the block size is rounded to a multiple of this
+	signatureBlockSizeAlignment = 1024
+)
+
+// SignatureBlockSize returns the block size used for the signature of a file of the given size.
+// Like rsync, the block size grows with the square root of the file size, so that larger files don't have
+// a large number of block signatures.
+func SignatureBlockSize(fileSize int64) uint32 {
+	blockSize := int64(math.Sqrt(float64(fileSize)))
+	blockSize -= blockSize % signatureBlockSizeAlignment
+
+	return uint32(min(max(blockSize, minSignatureBlockSize), maxSignatureBlockSize)) //nolint:gosec // within range
+}
+
+// Signature reads the src and returns the [BlockSignature]s of its blocks, used to request a delta file transfer.
+func Signature(src io.Reader, blockSize uint32) (*v1.FileSignature, error) {
+	if blockSize == 0 {
+		return nil, errors.New("block size is zero")
+	}
+
+	signature := &v1.FileSignature{
+		BlockSize: blockSize,
+	}
+
+	buf := make([]byte, blockSize)
+	for {
+		n, err := io.ReadFull(src, buf)
+		if n > 0 {
+			signature.Blocks = append(signature.Blocks, blockSignature(buf[:n]))
+		}
+
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return signature, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to read block %d: %w", len(signature.GetBlocks()), err)
+		}
+	}
+}
+
+// Delta compares the src with the signature of the copy of the file on the receiving side, and returns the
+// [FileDataChunkDelta]s that rebuild the src from the blocks of that copy. Literal data is split into
+// instructions of at most chunkSize bytes.
+func Delta(signature *v1.FileSignature, src []byte, chunkSize uint32) ([]*v1.FileDataChunkDelta, error) {
+	blockSize := int(signature.GetBlockSize())
+	if blockSize == 0 || chunkSize == 0 {
+		return nil, fmt.Errorf("block size or chunk size is zero: %d, %d", blockSize, chunkSize)
+	}
+
+	blocks := make(map[uint32][]int, len(signature.GetBlocks()))
+	for index, block := range signature.GetBlocks() {
+		blocks[block.GetWeakHash()] = append(blocks[block.GetWeakHash()], index)
+	}
+
+	delta := &deltaBuilder{chunkSize: int(chunkSize)}
+	literalStart := 0
+	position := 0
+
+	var checksum rollingChecksum
+	if len(src) >= blockSize {
+		checksum = newRollingChecksum(src[:blockSize])
+	}
+
+	for position+blockSize <= len(src) {
+		if index, found := matchBlock(signature, blocks, checksum.sum(), src[position:position+blockSize]); found {
+			delta.addData(src[literalStart:position])
+			delta.addCopy(uint32(index)) //nolint:gosec // the number of blocks is within range
+
+			position += blockSize
+			literalStart = position
+
+			if position+blockSize <= len(src) {
+				checksum = newRollingChecksum(src[position : position+blockSize])
+			}
+
+			continue
+		}
+
+		if position+blockSize < len(src) {
+			checksum.roll(src[position], src[position+blockSize])
+		}
+		position++
+	}
+
+	delta.addData(src[literalStart:])
+
+	return delta.instructions, nil
+}
+
+// SendChunkedFileDelta sends the header and the delta instructions down the stream. The chunks of the header
+// is set to the number of delta instructions. If the header has a compression, the literal data of each
+// instruction is compressed independently.
+func SendChunkedFileDelta(
+	meta *v1.MessageMeta,
+	header *v1.FileDataChunkHeader,
+	instructions []*v1.FileDataChunkDelta,
+	dst grpc.ServerStreamingServer[v1.FileDataChunk],
+) error {
+	header.Delta = true
+	header.Chunks = uint32(len(instructions)) //nolint:gosec // the number of instructions is within range
+
+	if err := dst.Send(&v1.FileDataChunk{
+		Meta:  meta,
+		Chunk: &v1.FileDataChunk_Header{Header: header},
+	}); err != nil {
+		return fmt.Errorf("unable to send header chunk: %w", err)
+	}
+
+	for _, instruction := range instructions {
+		if instruction.GetCopy() == nil && header.GetCompression() != v1.Compression_COMPRESSION_UNSPECIFIED {
+			data, err := Compress(instruction.GetData(), header.GetCompression())
+			if err != nil {
+				return fmt.Errorf("unable to compress chunk id %d: %w", instruction.GetChunkId(), err)
+			}
+
+			instruction = &v1.FileDataChunkDelta{
+				ChunkId:     instruction.GetChunkId(),
+				Instruction: &v1.FileDataChunkDelta_Data{Data: data},
+			}
+		}
+
+		if err := dst.Send(&v1.FileDataChunk{
+			Meta:  meta,
+			Chunk: &v1.FileDataChunk_Delta{Delta: instruction},
+		}); err != nil {
+			return fmt.Errorf("unable to send chunk id %d: %w", instruction.GetChunkId(), err)
+		}
+	}
+
+	return nil
+}
+
+// RecvChunkedFileDelta receives the delta instructions described by the header from the stream, and writes
+// the file contents rebuilt from the blocks of the basis to the dst. The signature must be the signature of
+// the basis that was sent in the request. If the header has a compression, the literal data of each instruction
+// is decompressed, and must be at most the chunk size.
+func RecvChunkedFileDelta(
+	src grpc.ServerStreamingClient[v1.FileDataChunk],
+	header *v1.FileDataChunkHeader,
+	signature *v1.FileSignature,
+	basis io.ReaderAt,
+	dst io.Writer,
+) error {
+	if !header.GetDelta() {
+		return errors.New("header is not a delta header")
+	}
+
+	blockSize := int64(signature.GetBlockSize())
+	blockCount := uint64(len(signature.GetBlocks()))
+	total := header.GetFileMeta().GetSize()
+	buf := make([]byte, blockSize)
+
+	for i := range header.GetChunks() {
+		chunk, err := src.Recv()
+		if err != nil {
+			return fmt.Errorf("unable to receive chunk id %d: %w", i, err)
+		}
+
+		instruction := chunk.GetDelta()
+		if instruction == nil {
+			return fmt.Errorf("no delta in chunk id %d", i)
+		}
+
+		if instruction.GetChunkId() != i {
+			return fmt.Errorf("delta chunk id of %d does not match expected id of %d", instruction.GetChunkId(), i)
+		}
+
+		var written int64
+
+		switch {
+		case instruction.GetCopy() != nil:
+			blockCopy := instruction.GetCopy()
+			if uint64(blockCopy.GetBlockIndex())+uint64(blockCopy.GetBlockCount()) > blockCount {
+				return fmt.Errorf("delta chunk id %d copies blocks %d to %d, but there are only %d blocks", i,
+					blockCopy.GetBlockIndex(), blockCopy.GetBlockIndex()+blockCopy.GetBlockCount(), blockCount)
+			}
+
+			written, err = copyBlocks(basis, dst, buf, blockCopy)
+		default:
+			var data []byte
+			data, err = Decompress(instruction.GetData(), header.GetCompression(), int64(header.GetChunkSize()))
+			if err != nil {
+				return fmt.Errorf("unable to decompress chunk id %d: %w", i, err)
+			}
+
+			var n int
+			n, err = dst.Write(data)
+			written = int64(n)
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to write chunk id %d: %w", i, err)
+		}
+
+		total -= written
+		if total < 0 {
+			return fmt.Errorf("unexpected content: %d bytes more data than expected", -total)
+		}
+	}
+
+	if total > 0 {
+		return fmt.Errorf("unexpected content: unexpected end of content, expected additional %d bytes", total)
+	}
+
+	return nil
+}
+
+func copyBlocks(basis io.ReaderAt, dst io.Writer, buf []byte, blockCopy *v1.FileBlockCopy) (int64, error) {
+	var written int64
+
+	blockSize := int64(len(buf))
+	for index := range blockCopy.GetBlockCount() {
+		offset := int64(blockCopy.GetBlockIndex()+index) * blockSize
+
+		// only the last block of the basis can be undersized
+		n, err := basis.ReadAt(buf, offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return written, fmt.Errorf("unable to read block %d: %w", blockCopy.GetBlockIndex()+index, err)
+		}
+
+		if _, err = dst.Write(buf[:n]); err != nil {
+			return written, err
+		}
+		written += int64(n)
+	}
+
+	return written, nil
+}
+
+func blockSignature(block []byte) *v1.BlockSignature {
+	strongHash := sha256.Sum256(block)
+
+	return &v1.BlockSignature{
+		WeakHash:   newRollingChecksum(block).sum(),
+		StrongHash: strongHash[:],
+	}
+}
+
+func matchBlock(signature *v1.FileSignature, blocks map[uint32][]int, weakHash uint32, data []byte) (int, bool) {
+	candidates, found := blocks[weakHash]
+	if !found {
+		return 0, false
+	}
+
+	strongHash := sha256.Sum256(data)
+	for _, index := range candidates {
+		if bytes.Equal(signature.GetBlocks()[index].GetStrongHash(), strongHash[:]) {
+			return index, true
+		}
+	}
+
+	return 0, false
+}
+
+// rollingChecksum is the rsync rolling checksum of a block, that can be moved forward one byte at a time
+type rollingChecksum struct {
+	a         uint32
+	b         uint32
+	blockSize uint32
+}
+
+func newRollingChecksum(block []byte) rollingChecksum {
+	checksum := rollingChecksum{blockSize: uint32(len(block))} //nolint:gosec // block size is a uint32
+	for _, value := range block {
+		checksum.a += uint32(value)
+		checksum.b += checksum.a
+	}
+
+	return checksum
+}
+
+// roll removes the first byte of the block and appends the next byte
+func (c *rollingChecksum) roll(out, in byte) {
+	c.a = c.a - uint32(out) + uint32(in)
+	c.b = c.b - c.blockSize*uint32(out) + c.a
+}
+
+func (c rollingChecksum) sum() uint32 {
+	return (c.a & math.MaxUint16) | (c.b&math.MaxUint16)<<16
+}
+
+// deltaBuilder merges consecutive block copies and splits literal data into chunks
+type deltaBuilder struct {
+	instructions []*v1.FileDataChunkDelta
+	chunkSize    int
+}
+
+func (d *deltaBuilder) addCopy(index uint32) {
+	if len(d.instructions) > 0 {
+		last := d.instructions[len(d.instructions)-1].GetCopy()
+		if last != nil && last.GetBlockIndex()+last.GetBlockCount() == index {
+			last.BlockCount++
+
+			return
+		}
+	}
+
+	d.add(&v1.FileDataChunkDelta{
+		Instruction: &v1.FileDataChunkDelta_Copy{Copy: &v1.FileBlockCopy{BlockIndex: index, BlockCount: 1}},
+	})
+}
+
+func (d *deltaBuilder) addData(data []byte) {
+	for len(data) > 0 {
+		n := min(len(data), d.chunkSize)
+		d.add(&v1.FileDataChunkDelta{Instruction: &v1.FileDataChunkDelta_Data{Data: data[:n]}})
+		data = data[n:]
+	}
+}
+
+func (d *deltaBuilder) add(instruction *v1.FileDataChunkDelta) {
+	instruction.ChunkId = uint32(len(d.instructions)) //nolint:gosec // the number of instructions is within range
+	d.instructions = append(d.instructions, instruction)
+}
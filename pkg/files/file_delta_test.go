@@ -0,0 +1,247 @@
+// Copyright (c) F5, Inc.
+//
+// This source code is licensed under the Apache License, Version 2.0 license found in the
+// LICENSE file in the root directory of this source tree.
+
+package files_test
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nginx/agent/v3/api/grpc/mpi/v1"
+	"github.com/nginx/agent/v3/api/grpc/mpi/v1/v1fakes"
+	"github.com/nginx/agent/v3/pkg/files"
+)
+
+func TestSignatureBlockSize(t *testing.T) {
+	assert.Equal(t, uint32(2048), files.SignatureBlockSize(0))
+	assert.Equal(t, uint32(2048), files.SignatureBlockSize(1024*1024))
+	assert.Equal(t, uint32(7168), files.SignatureBlockSize(50*1024*1024))
+	assert.Equal(t, uint32(128*1024), files.SignatureBlockSize(100*1024*1024*1024))
+}
+
+func TestSignature(t *testing.T) {
+	content := randBytes(5000)
+
+	signature, err := files.Signature(bytes.NewReader(content), 2048)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint32(2048), signature.GetBlockSize())
+	require.Len(t, signature.GetBlocks(), 3)
+
+	// the signature of an identical block is identical
+	otherSignature, err := files.Signature(bytes.NewReader(content[2048:4096]), 2048)
+	require.NoError(t, err)
+	assert.Equal(t, signature.GetBlocks()[1], otherSignature.GetBlocks()[0])
+
+	_, err = files.Signature(bytes.NewReader(content), 0)
+	require.Error(t, err)
+}
+
+func TestDelta(t *testing.T) {
+	const blockSize = 1024
+
+	basis := randBytes(20 * blockSize)
+	insertion := randBytes(100)
+
+	tests := []struct {
+		name                string
+		content             []byte
+		expectedMaxLiterals int
+		expectedCopies      int
+	}{
+		{
+			name:           "Test 1: unchanged file",
+			content:        basis,
+			expectedCopies: 20,
+		},
+		{
+			name:                "Test 2: changed byte",
+			content:             slices.Concat(basis[:5000], []byte("#"), basis[5001:]),
+			expectedMaxLiterals: blockSize,
+			expectedCopies:      19,
+		},
+		{
+			name:                "Test 3: inserted data",
+			content:             slices.Concat(basis[:5000], insertion, basis[5000:]),
+			expectedMaxLiterals: blockSize + len(insertion),
+			expectedCopies:      19,
+		},
+		{
+			name:                "Test 4: removed data",
+			content:             slices.Concat(basis[:5000], basis[5100:]),
+			expectedMaxLiterals: blockSize,
+			expectedCopies:      19,
+		},
+		{
+			name:                "Test 5: unrelated file",
+			content:             randBytes(3000),
+			expectedMaxLiterals: 3000,
+		},
+		{
+			name: "Test 6: empty file",
+		},
+	}
+
+	signature, err := files.Signature(bytes.NewReader(basis), blockSize)
+	require.NoError(t, err)
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			instructions, deltaErr := files.Delta(signature, test.content, 512)
+			require.NoError(tt, deltaErr)
+
+			literals := 0
+			copies := 0
+
+			for i, instruction := range instructions {
+				assert.Equal(tt, uint32(i), instruction.GetChunkId())
+				assert.LessOrEqual(tt, len(instruction.GetData()), 512)
+
+				literals += len(instruction.GetData())
+				copies += int(instruction.GetCopy().GetBlockCount())
+			}
+
+			assert.LessOrEqual(tt, literals, test.expectedMaxLiterals)
+			assert.Equal(tt, test.expectedCopies, copies)
+
+			client := deltaStreamClient(instructions)
+			header := &v1.FileDataChunkHeader{
+				FileMeta: &v1.FileMeta{Size: int64(len(test.content))},
+				Chunks:   uint32(len(instructions)),
+				Delta:    true,
+			}
+
+			var result bytes.Buffer
+			recvErr := files.RecvChunkedFileDelta(client, header, signature, bytes.NewReader(basis), &result)
+			require.NoError(tt, recvErr)
+
+			assert.Equal(tt, files.GenerateHash(test.content), files.GenerateHash(result.Bytes()))
+		})
+	}
+}
+
+func TestSendChunkedFileDelta(t *testing.T) {
+	server := &v1fakes.FakeFileService_GetFileStreamServer{}
+	instructions := []*v1.FileDataChunkDelta{
+		{ChunkId: 0, Instruction: &v1.FileDataChunkDelta_Copy{Copy: &v1.FileBlockCopy{BlockCount: 2}}},
+		{ChunkId: 1, Instruction: &v1.FileDataChunkDelta_Data{Data: []byte("data")}},
+	}
+
+	err := files.SendChunkedFileDelta(&v1.MessageMeta{}, &v1.FileDataChunkHeader{ChunkSize: 512}, instructions,
+		server)
+	require.NoError(t, err)
+
+	require.Equal(t, 3, server.SendCallCount())
+	header := server.SendArgsForCall(0).GetHeader()
+	assert.True(t, header.GetDelta())
+	assert.Equal(t, uint32(2), header.GetChunks())
+	assert.Equal(t, instructions[1], server.SendArgsForCall(2).GetDelta())
+}
+
+func TestChunkedFileDelta_Compression(t *testing.T) {
+	basis := randBytes(4096)
+	content := slices.Concat(basis[:2048], bytes.Repeat([]byte("#"), 1000), basis[2048:])
+
+	signature, err := files.Signature(bytes.NewReader(basis), 1024)
+	require.NoError(t, err)
+
+	instructions, err := files.Delta(signature, content, 512)
+	require.NoError(t, err)
+
+	server := &v1fakes.FakeFileService_GetFileStreamServer{}
+	header := &v1.FileDataChunkHeader{
+		FileMeta:    &v1.FileMeta{Size: int64(len(content))},
+		ChunkSize:   512,
+		Compression: v1.Compression_COMPRESSION_ZSTD,
+	}
+
+	err = files.SendChunkedFileDelta(&v1.MessageMeta{}, header, instructions, server)
+	require.NoError(t, err)
+
+	client := &v1fakes.FakeFileService_GetFileStreamClient{}
+	for i := 1; i < server.SendCallCount(); i++ {
+		chunk := server.SendArgsForCall(i)
+		if chunk.GetDelta().GetCopy() == nil {
+			assert.Less(t, len(chunk.GetDelta().GetData()), 512)
+		}
+		client.RecvReturnsOnCall(i-1, chunk, nil)
+	}
+
+	var result bytes.Buffer
+	err = files.RecvChunkedFileDelta(client, header, signature, bytes.NewReader(basis), &result)
+	require.NoError(t, err)
+	assert.Equal(t, content, result.Bytes())
+}
+
+func TestRecvChunkedFileDelta_Errors(t *testing.T) {
+	basis := randBytes(2048)
+	signature, err := files.Signature(bytes.NewReader(basis), 1024)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		header            *v1.FileDataChunkHeader
+		instructions      []*v1.FileDataChunkDelta
+		expectedErrString string
+	}{
+		{
+			name:              "Test 1: not a delta header",
+			header:            &v1.FileDataChunkHeader{},
+			expectedErrString: "header is not a delta header",
+		},
+		{
+			name: "Test 2: copy out of range",
+			instructions: []*v1.FileDataChunkDelta{
+				{Instruction: &v1.FileDataChunkDelta_Copy{Copy: &v1.FileBlockCopy{BlockIndex: 1, BlockCount: 2}}},
+			},
+			expectedErrString: "copies blocks 1 to 3, but there are only 2 blocks",
+		},
+		{
+			name: "Test 3: unexpected chunk id",
+			instructions: []*v1.FileDataChunkDelta{
+				{ChunkId: 1, Instruction: &v1.FileDataChunkDelta_Data{Data: []byte("data")}},
+			},
+			expectedErrString: "delta chunk id of 1 does not match expected id of 0",
+		},
+		{
+			name: "Test 4: missing content",
+			instructions: []*v1.FileDataChunkDelta{
+				{Instruction: &v1.FileDataChunkDelta_Data{Data: []byte("data")}},
+			},
+			expectedErrString: "expected additional 2044 bytes",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			header := test.header
+			if header == nil {
+				header = &v1.FileDataChunkHeader{
+					FileMeta: &v1.FileMeta{Size: 2048},
+					Chunks:   uint32(len(test.instructions)),
+					Delta:    true,
+				}
+			}
+
+			recvErr := files.RecvChunkedFileDelta(deltaStreamClient(test.instructions), header, signature,
+				bytes.NewReader(basis), &bytes.Buffer{})
+			require.Error(tt, recvErr)
+			assert.Contains(tt, recvErr.Error(), test.expectedErrString)
+		})
+	}
+}
+
+func deltaStreamClient(instructions []*v1.FileDataChunkDelta) *v1fakes.FakeFileService_GetFileStreamClient {
+	client := &v1fakes.FakeFileService_GetFileStreamClient{}
+	for i, instruction := range instructions {
+		client.RecvReturnsOnCall(i, &v1.FileDataChunk{Chunk: &v1.FileDataChunk_Delta{Delta: instruction}}, nil)
+	}
+
+	return client
+}
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
@@ -23,7 +24,10 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-const permissions = 0o600
+const (
+	permissions    = 0o600
+	dirPermissions = 0o755
+)
 
 // FileMeta returns a proto FileMeta struct from a given file path.
 func FileMeta(filePath string) (*mpi.FileMeta, error) {
@@ -109,6 +113,40 @@ func Permissions(fileMode os.FileMode) string {
 	return fmt.Sprintf("%#o", fileMode.Perm())
 }
 
+// WriteFileAtomically writes and syncs the content to a temporary file first and then renames it to the path, so the
+// file is never partially written, even if the host is rebooted. Missing parent directories are created.
+func WriteFileAtomically(path string, content []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), dirPermissions); err != nil {
+		return fmt.Errorf("unable to create directory %s: %w", filepath.Dir(path), err)
+	}
+
+	tempPath := path + ".tmp"
+
+	tempFile, err := os.OpenFile(tempPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("unable to create file %s: %w", tempPath, err)
+	}
+
+	_, err = tempFile.Write(content)
+	if err == nil {
+		err = tempFile.Sync()
+	}
+
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to write file %s: %w", tempPath, err)
+	}
+
+	if err = os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("unable to rename file %s: %w", tempPath, err)
+	}
+
+	return nil
+}
+
 func FileMode(mode string) os.FileMode {
 	result, err := strconv.ParseInt(mode, 8, 32)
 	if err != nil {
@@ -119,7 +157,8 @@ func FileMode(mode string) os.FileMode {
 }
 
 // GenerateConfigVersion returns a unique config version for a set of files.
-// The config version is calculated by joining the file hashes together and generating a unique ID.
+// The config version is calculated by joining the file hashes, and the targets of symbolic links and the names of
+// directories, which have no contents, together and generating a unique ID.
 func GenerateConfigVersion(fileSlice []*mpi.File) string {
 	var sb strings.Builder
 
@@ -130,7 +169,14 @@ func GenerateConfigVersion(fileSlice []*mpi.File) string {
 	})
 
 	for _, file := range files {
-		sb.WriteString(file.GetFileMeta().GetHash())
+		switch {
+		case file.GetFileMeta().GetSymlinkMeta() != nil:
+			sb.WriteString(file.GetFileMeta().GetSymlinkMeta().GetTarget())
+		case file.GetFileMeta().GetDirectoryMeta() != nil:
+			sb.WriteString(file.GetFileMeta().GetName())
+		default:
+			sb.WriteString(file.GetFileMeta().GetHash())
+		}
 	}
 
 	return GenerateHash([]byte(sb.String()))
@@ -11,6 +11,7 @@ import (
 	"encoding/base64"
 	"net"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -80,6 +81,27 @@ func TestGetPermissions(t *testing.T) {
 	assert.Equal(t, "0600", permissions)
 }
 
+func TestWriteFileAtomically(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal", "journal.json")
+
+	require.NoError(t, WriteFileAtomically(path, []byte("first"), 0o600))
+	require.NoError(t, WriteFileAtomically(path, []byte("second"), 0o600))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), content)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// the temporary file is renamed
+	assert.NoFileExists(t, path+".tmp")
+
+	err = WriteFileAtomically(filepath.Join(path, "file.json"), []byte("content"), 0o600)
+	require.ErrorContains(t, err, "unable to create directory")
+}
+
 func Test_GenerateConfigVersion(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -124,6 +146,26 @@ func Test_GenerateConfigVersion(t *testing.T) {
 				return GenerateHash([]byte(hashes))
 			}(),
 		},
+		{
+			name: "Test 4: symbolic link and directory",
+			input: []*mpi.File{
+				{
+					FileMeta: &mpi.FileMeta{
+						Name: "/etc/nginx/sites-enabled/site.conf",
+						FileType: &mpi.FileMeta_SymlinkMeta{
+							SymlinkMeta: &mpi.SymlinkMeta{Target: "../sites-available/site.conf"},
+						},
+					},
+				},
+				{
+					FileMeta: &mpi.FileMeta{
+						Name:     "/etc/nginx/conf.d",
+						FileType: &mpi.FileMeta_DirectoryMeta{DirectoryMeta: &mpi.DirectoryMeta{}},
+					},
+				},
+			},
+			expected: GenerateHash([]byte("/etc/nginx/conf.d../sites-available/site.conf")),
+		},
 	}
 
 	for _, tt := range tests {
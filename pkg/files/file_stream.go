@@ -16,7 +16,8 @@ import (
 )
 
 // SendChunkedFile reads the src into [FileDataChunkContent]s, and sends a valid sequence of
-// [FileDataChunk]s down the stream.
+// [FileDataChunk]s down the stream. If the header has an offset, the src must be positioned at the offset.
+// If the header has a compression, the data of each chunk is compressed independently.
 func SendChunkedFile(
 	meta *v1.MessageMeta,
 	header v1.FileDataChunk_Header,
@@ -25,7 +26,7 @@ func SendChunkedFile(
 ) error {
 	chunkCount := int(header.Header.GetChunks())
 	chunkSize := int(header.Header.GetChunkSize())
-	total := int(header.Header.GetFileMeta().GetSize())
+	total := int(header.Header.GetFileMeta().GetSize()) - int(header.Header.GetOffset()) //nolint:gosec // file size
 	if chunkSize == 0 || chunkCount == 0 || total == 0 {
 		return fmt.Errorf("file size in header is zero: %+v", header.Header)
 	}
@@ -50,12 +51,16 @@ func SendChunkedFile(
 			// partial read
 			return fmt.Errorf("unable to read chunk id %d: %w", i, err)
 		}
+		data, err := Compress(buf[0:n], header.Header.GetCompression())
+		if err != nil {
+			return fmt.Errorf("unable to compress chunk id %d: %w", i, err)
+		}
 		if err = dst.Send(&v1.FileDataChunk{
 			Meta: meta,
 			Chunk: &v1.FileDataChunk_Content{
 				Content: &v1.FileDataChunkContent{
 					ChunkId: uint32(i),
-					Data:    buf[0:n],
+					Data:    data,
 				},
 			},
 		}); err != nil {
@@ -67,7 +72,8 @@ func SendChunkedFile(
 }
 
 // RecvChunkedFile receives [FileDataChunkContent]s from the stream and writes the file contents
-// to the dst.
+// to the dst. If the header has an offset, only the file contents after the offset are written.
+// If the header has a compression, the data of each chunk is decompressed, and must be at most the chunk size.
 func RecvChunkedFile(
 	src grpc.ServerStreamingClient[v1.FileDataChunk],
 	dst io.Writer,
@@ -87,18 +93,19 @@ func RecvChunkedFile(
 	header = headerChunk.GetFileMeta()
 	chunkCount := int(headerChunk.GetChunks())
 	chunkSize := int(headerChunk.GetChunkSize())
-	total := int(header.GetSize())
+	total := int(header.GetSize()) - int(headerChunk.GetOffset()) //nolint:gosec // file size
 
 	if chunkSize == 0 || chunkCount == 0 || total == 0 {
 		return header, fmt.Errorf("file size in header is zero: %+v", headerChunk)
 	}
 
-	return header, recvContents(src, dst, chunkCount, chunkSize, total)
+	return header, recvContents(src, dst, headerChunk.GetCompression(), chunkCount, chunkSize, total)
 }
 
 func recvContents(
 	src grpc.ServerStreamingClient[v1.FileDataChunk],
 	dst io.Writer,
+	compression v1.Compression,
 	chunkCount int,
 	chunkSize int,
 	totalSize int,
@@ -110,10 +117,10 @@ func recvContents(
 			return fmt.Errorf("unable to receive chunk id %d: %w", i, err)
 		}
 
-		if err = validateRecvChunk(chunk, chunkSize, chunkCount-1, i); err != nil {
+		data, err := validateRecvChunk(chunk, compression, chunkSize, chunkCount-1, i)
+		if err != nil {
 			return err
 		}
-		data := chunk.GetContent().GetData()
 		if _, err = dst.Write(data); err != nil {
 			return fmt.Errorf("unable to write chunk id %d: %w", i, err)
 		}
@@ -129,20 +136,28 @@ func recvContents(
 	return nil
 }
 
-func validateRecvChunk(chunk *v1.FileDataChunk, chunkSize, lastChunkIndex, chunkID int) error {
+// validateRecvChunk validates the chunk and returns its decompressed data
+func validateRecvChunk(
+	chunk *v1.FileDataChunk,
+	compression v1.Compression,
+	chunkSize, lastChunkIndex, chunkID int,
+) ([]byte, error) {
 	content := chunk.GetContent()
 	if content == nil {
-		return fmt.Errorf("no content in chunk id %d", chunkID)
+		return nil, fmt.Errorf("no content in chunk id %d", chunkID)
 	}
 	if content.GetChunkId() != uint32(chunkID) {
-		return fmt.Errorf("content chunk id of %d does not match expected id of %d",
+		return nil, fmt.Errorf("content chunk id of %d does not match expected id of %d",
 			content.GetChunkId(), chunkID)
 	}
-	data := content.GetData()
+	data, err := Decompress(content.GetData(), compression, int64(chunkSize))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress chunk id %d: %w", chunkID, err)
+	}
 	if len(data) != chunkSize && chunkID != lastChunkIndex {
-		return fmt.Errorf("content chunk size of %d does not match expected size of %d",
+		return nil, fmt.Errorf("content chunk size of %d does not match expected size of %d",
 			len(data), chunkSize)
 	}
 
-	return nil
+	return data, nil
 }
@@ -29,9 +29,11 @@ const (
 	containerEnvLocation   = "/run/.containerenv"
 	k8sServiceAcctLocation = "/var/run/secrets/kubernetes.io/serviceaccount"
 
-	selfCgroupLocation = "/proc/self/cgroup"
-	mountInfoLocation  = "/proc/self/mountinfo"
-	osReleaseLocation  = "/etc/os-release"
+	selfCgroupLocation     = "/proc/self/cgroup"
+	mountInfoLocation      = "/proc/self/mountinfo"
+	processCgroupFormat    = "/proc/%d/cgroup"
+	processMountInfoFormat = "/proc/%d/mountinfo"
+	osReleaseLocation      = "/etc/os-release"
 
 	ecsMetadataEnvV4 = "ECS_CONTAINER_METADATA_URI_V4"
 
@@ -42,6 +44,7 @@ const (
 	fargate    = "fargate" // AWS EKS Fargate
 
 	numberOfKeysAndValues = 2
+	numberOfCgroupFields  = 3
 	lengthOfContainerID   = 64
 
 	versionID = "VERSION_ID"
@@ -244,6 +247,51 @@ func (i *Info) containerID(ctx context.Context) (string, error) {
 	return "", errs
 }
 
+// ProcessContainerID returns the container ID of another process, for example an NGINX process in another container
+// of the same pod. The cgroup of the process is checked first, since all containers of a pod have the same sandbox
+// ID in their mount info.
+func ProcessContainerID(pid int32) (string, error) {
+	return processContainerID(fmt.Sprintf(processCgroupFormat, pid), fmt.Sprintf(processMountInfoFormat, pid))
+}
+
+func processContainerID(cgroupFile, mountInfo string) (string, error) {
+	containerID, cgroupErr := containerIDFromCgroup(cgroupFile)
+	if cgroupErr == nil {
+		return uuid.NewMD5(uuid.NameSpaceDNS, []byte(containerID)).String(), nil
+	}
+
+	containerID, mountInfoErr := containerIDFromMountInfo(mountInfo)
+	if mountInfoErr == nil && containerID != "" {
+		return uuid.NewMD5(uuid.NameSpaceDNS, []byte(containerID)).String(), nil
+	}
+
+	return "", errors.Join(cgroupErr, mountInfoErr)
+}
+
+// containerIDFromCgroup returns the container ID in the cgroup path of a process.
+// cgroupFile is the path: "/proc/<pid>/cgroup"
+func containerIDFromCgroup(cgroupFile string) (string, error) {
+	data, err := os.ReadFile(cgroupFile)
+	if err != nil {
+		return "", err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		// each line is hierarchy-ID:controller-list:cgroup-path
+		fields := strings.SplitN(strings.TrimSpace(scanner.Text()), ":", numberOfCgroupFields)
+		if len(fields) != numberOfCgroupFields {
+			continue
+		}
+
+		if containerID := containerIDFromPatterns(fields[2]); containerID != "" {
+			return containerID, nil
+		}
+	}
+
+	return "", fmt.Errorf("container ID not found in %s", cgroupFile)
+}
+
 // containsContainerReference checks if the cgroup file contains references to container runtimes.
 func containsContainerReference(cgroupFile string) (bool, error) {
 	data, err := os.ReadFile(cgroupFile)
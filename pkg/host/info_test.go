@@ -546,6 +546,63 @@ func TestInfo_ContainerInfo(t *testing.T) {
 	}
 }
 
+func TestProcessContainerID(t *testing.T) {
+	tests := []struct {
+		name              string
+		cgroup            string
+		mountInfo         string
+		expectContainerID string
+	}{
+		{
+			name: "Test 1: containerd container in a pod",
+			cgroup: "0::/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod214f3ba8_4b69_4bdb_" +
+				"a7d5_5ecc73f04ae9.slice/cri-containerd-d4e8e05a546c86b6443f101966c618e47753ed01fa9929cae00d3b692f7a9f80" +
+				".scope\n",
+			mountInfo:         envMountInfo[2],
+			expectContainerID: "17796e3d-8f28-3382-aa3c-130ee065d8ff",
+		},
+		{
+			name:              "Test 2: docker container in a cgroup namespace",
+			cgroup:            "0::/../f244832c5a58377c3f1c7581b311c5bd8479808741f3e912d8bea8afe6431cb4\n",
+			mountInfo:         envMountInfo[0],
+			expectContainerID: "d72eb414-1e7f-3167-923c-d56301d3e332",
+		},
+		{
+			name:              "Test 3: container ID from mount info",
+			cgroup:            "0::/\n",
+			mountInfo:         envMountInfo[2],
+			expectContainerID: "3d7b26ba-e8d1-35ae-8566-aed826a5208d",
+		},
+		{
+			name:              "Test 4: process is not in a container",
+			cgroup:            "0::/system.slice/nginx.service\n",
+			mountInfo:         envMountInfo[0],
+			expectContainerID: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			cgroupFile := helpers.CreateFileWithErrorCheck(tt, tt.TempDir(), "cgroup")
+			mountInfoFile := helpers.CreateFileWithErrorCheck(tt, tt.TempDir(), "mountinfo")
+
+			require.NoError(tt, os.WriteFile(cgroupFile.Name(), []byte(test.cgroup), 0o600))
+			require.NoError(tt, os.WriteFile(mountInfoFile.Name(), []byte(test.mountInfo), 0o600))
+
+			containerID, err := processContainerID(cgroupFile.Name(), mountInfoFile.Name())
+			if test.expectContainerID == "" {
+				require.Error(tt, err)
+			} else {
+				require.NoError(tt, err)
+			}
+			assert.Equal(tt, test.expectContainerID, containerID)
+		})
+	}
+
+	_, err := ProcessContainerID(-1)
+	require.Error(t, err)
+}
+
 func TestInfo_HostInfo(t *testing.T) {
 	ctx := context.Background()
 
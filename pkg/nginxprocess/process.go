@@ -39,6 +39,18 @@ func (p *Process) IsMaster() bool {
 		strings.HasPrefix(p.Cmd, "{nginx-debug} nginx: master")
 }
 
+// IsUnit returns true if the process is a NGINX Unit process, created using [WithUnit].
+func (p *Process) IsUnit() bool { return strings.HasPrefix(p.Cmd, unitCmdPrefix) }
+
+// IsUnitMain returns true if the process is a NGINX Unit main process.
+func (p *Process) IsUnitMain() bool { return strings.HasPrefix(p.Cmd, unitCmdPrefix+"main") }
+
+// IsUnitController returns true if the process is a NGINX Unit controller process.
+func (p *Process) IsUnitController() bool { return strings.HasPrefix(p.Cmd, unitCmdPrefix+"controller") }
+
+// IsUnitRouter returns true if the process is a NGINX Unit router process.
+func (p *Process) IsUnitRouter() bool { return strings.HasPrefix(p.Cmd, unitCmdPrefix+"router") }
+
 // IsShuttingDown returns true if the process is shutting down. This can identify workers that are in the process of a
 // graceful shutdown. See [changing NGINX configuration] for more details.
 //
@@ -55,8 +67,12 @@ func (p *Process) Equal(b *Process) bool {
 		p.Exe == b.Exe && p.Created.Equal(b.Created) && p.Status == b.Status
 }
 
+// unitCmdPrefix is the prefix of the command line of NGINX Unit processes, e.g. "unit: main v1.34.1 [unitd]"
+const unitCmdPrefix = "unit: "
+
 type options struct {
 	loadStatus bool
+	loadUnit   bool
 }
 
 // Option customizes how processes are gathered from the OS.
@@ -73,13 +89,21 @@ func WithStatus(v bool) Option {
 	return optionFunc(func(o *options) { o.loadStatus = v })
 }
 
+// WithUnit includes NGINX Unit processes, in addition to NGINX processes.
+//
+//nolint:ireturn // functional options can be opaque
+func WithUnit(v bool) Option {
+	return optionFunc(func(o *options) { o.loadUnit = v })
+}
+
 func convert(ctx context.Context, p *process.Process, o options) (*Process, error) {
 	if err := ctx.Err(); err != nil { // fail fast if we've canceled
 		return nil, err
 	}
 
 	name, _ := p.NameWithContext(ctx) // slow: shells out to ps
-	if name != "nginx" && name != "nginx-debug" {
+	isUnit := o.loadUnit && name == "unitd"
+	if name != "nginx" && name != "nginx-debug" && !isUnit {
 		return nil, errNotAnNginxProcess
 	}
 
@@ -90,7 +114,8 @@ func convert(ctx context.Context, p *process.Process, o options) (*Process, erro
 		return nil, errNotAnNginxProcess
 	}
 
-	if strings.HasPrefix(cmdLine, "nginx:") || strings.HasPrefix(cmdLine, "{nginx-debug} nginx:") {
+	if strings.HasPrefix(cmdLine, "nginx:") || strings.HasPrefix(cmdLine, "{nginx-debug} nginx:") ||
+		(isUnit && strings.HasPrefix(cmdLine, unitCmdPrefix)) {
 		var status string
 		if o.loadStatus {
 			flags, _ := p.StatusWithContext(ctx) // slow: shells out to ps
@@ -109,6 +109,54 @@ func TestProcess_IsNginxMaster(t *testing.T) {
 	}
 }
 
+func TestProcess_IsUnit(t *testing.T) {
+	t.Parallel()
+	testcases := map[string]struct {
+		cmd            string
+		wantUnit       bool
+		wantMain       bool
+		wantController bool
+		wantRouter     bool
+	}{
+		"Test 1: unit main": {
+			cmd:      "unit: main v1.34.1 [/usr/sbin/unitd --control unix:/var/run/control.unit.sock]",
+			wantUnit: true,
+			wantMain: true,
+		},
+		"Test 2: unit controller": {
+			cmd:            "unit: controller",
+			wantUnit:       true,
+			wantController: true,
+		},
+		"Test 3: unit router": {
+			cmd:        "unit: router",
+			wantUnit:   true,
+			wantRouter: true,
+		},
+		"Test 4: unit application": {
+			cmd:      `unit: "flask" application`,
+			wantUnit: true,
+		},
+		"Test 5: nginx master": {
+			cmd: "nginx: master process /usr/local/opt/nginx/bin/nginx -g daemon off;",
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			p := nginxprocess.Process{Cmd: tc.cmd}
+			require.Equal(t, tc.wantUnit, p.IsUnit())
+			require.Equal(t, tc.wantMain, p.IsUnitMain())
+			require.Equal(t, tc.wantController, p.IsUnitController())
+			require.Equal(t, tc.wantRouter, p.IsUnitRouter())
+			if tc.wantUnit {
+				require.False(t, p.IsMaster())
+				require.False(t, p.IsWorker())
+			}
+		})
+	}
+}
+
 func TestProcess_IsShuttingDown(t *testing.T) {
 	t.Parallel()
 	testcases := map[string]struct {
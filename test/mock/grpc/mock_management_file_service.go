@@ -131,9 +131,18 @@ func (mgs *FileService) GetFile(
 		return nil, status.Errorf(codes.Internal, "Failed to get file contents")
 	}
 
+	compression := files.NegotiateCompression(request.GetAcceptedCompressions())
+
+	bytes, err = files.Compress(bytes, compression)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to compress file contents", "full_file_path", fullFilePath, "error", err)
+		return nil, status.Errorf(codes.Internal, "Failed to compress file contents")
+	}
+
 	return &v1.GetFileResponse{
 		Contents: &v1.FileContents{
-			Contents: bytes,
+			Contents:    bytes,
+			Compression: compression,
 		},
 	}, nil
 }
@@ -155,21 +164,32 @@ func (mgs *FileService) GetFileStream(request *v1.GetFileRequest,
 		return status.Errorf(codes.NotFound, "File not found")
 	}
 
+	if request.GetSignature() != nil {
+		return mgs.sendGetFileStreamDelta(newCtx, fullFilePath, request, streamingServer)
+	}
+
+	// resume an interrupted stream at the offset of the request
+	offset := request.GetOffset()
+	if offset > uint64(request.GetFileMeta().GetSize()) {
+		return status.Errorf(codes.InvalidArgument, "Offset is larger than the file size")
+	}
+
+	compression := files.NegotiateCompression(request.GetAcceptedCompressions())
+
 	err := mgs.sendGetFileStreamHeader(newCtx, request.GetFileMeta(), mgs.agentConfig.Client.Grpc.FileChunkSize,
-		streamingServer)
+		offset, compression, streamingServer)
 	if err != nil {
 		return err
 	}
 
 	return mgs.sendGetFileStreamChunks(newCtx, fullFilePath, fileName, mgs.agentConfig.Client.Grpc.FileChunkSize,
-		streamingServer)
+		offset, compression, streamingServer)
 }
 
 func (mgs *FileService) UpdateFile(
 	ctx context.Context,
 	request *v1.UpdateFileRequest,
 ) (*v1.UpdateFileResponse, error) {
-	fileContents := request.GetContents().GetContents()
 	fileMeta := request.GetFile().GetFileMeta()
 	fileName := fileMeta.GetName()
 	fileHash := fileMeta.GetHash()
@@ -177,6 +197,13 @@ func (mgs *FileService) UpdateFile(
 
 	slog.InfoContext(ctx, "Updating file", "name", fileName, "hash", fileHash)
 
+	fileContents, err := files.Decompress(request.GetContents().GetContents(),
+		request.GetContents().GetCompression(), int64(mgs.agentConfig.Client.Grpc.MaxFileSize))
+	if err != nil {
+		slog.InfoContext(ctx, "Failed to decompress file contents", "name", fileName, "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to decompress file contents")
+	}
+
 	fullFilePath := mgs.findFile(request.GetFile().GetFileMeta())
 
 	if _, err := os.Stat(fullFilePath); os.IsNotExist(err) {
@@ -187,7 +214,7 @@ func (mgs *FileService) UpdateFile(
 		}
 	}
 
-	err := os.WriteFile(fullFilePath, fileContents, fileMode(filePermissions))
+	err = os.WriteFile(fullFilePath, fileContents, fileMode(filePermissions))
 	if err != nil {
 		slog.InfoContext(ctx, "Failed to create/update file", "full_file_path", fullFilePath, "error", err)
 		return nil, status.Errorf(codes.Internal, "Failed to create/update file")
@@ -253,7 +280,13 @@ func (mgs *FileService) WriteChunkFile(fileMeta *v1.FileMeta, header *v1.FileDat
 			return recvError
 		}
 
-		_, chunkWriteError := fileToWrite.Write(chunk.GetContent().GetData())
+		data, decompressError := files.Decompress(chunk.GetContent().GetData(), header.GetCompression(),
+			int64(header.GetChunkSize()))
+		if decompressError != nil {
+			return fmt.Errorf("error decompressing chunk of file %s: %w", fileMeta.GetName(), decompressError)
+		}
+
+		_, chunkWriteError := fileToWrite.Write(data)
 		if chunkWriteError != nil {
 			return fmt.Errorf("error writing chunk to file %s: %w", fileMeta.GetName(), chunkWriteError)
 		}
@@ -263,7 +296,7 @@ func (mgs *FileService) WriteChunkFile(fileMeta *v1.FileMeta, header *v1.FileDat
 }
 
 func (mgs *FileService) sendGetFileStreamChunks(ctx context.Context, fullFilePath, filePath string, chunkSize uint32,
-	streamingServer grpc.ServerStreamingServer[v1.FileDataChunk],
+	offset uint64, compression v1.Compression, streamingServer grpc.ServerStreamingServer[v1.FileDataChunk],
 ) error {
 	f, err := os.Open(fullFilePath)
 	defer func() {
@@ -280,6 +313,10 @@ func (mgs *FileService) sendGetFileStreamChunks(ctx context.Context, fullFilePat
 		return err
 	}
 
+	if _, err = f.Seek(int64(offset), io.SeekStart); err != nil {
+		return err
+	}
+
 	var chunkID uint32
 
 	reader := bufio.NewReader(f)
@@ -292,6 +329,11 @@ func (mgs *FileService) sendGetFileStreamChunks(ctx context.Context, fullFilePat
 			break
 		}
 
+		chunk.Content.Data, err = files.Compress(chunk.Content.GetData(), compression)
+		if err != nil {
+			return err
+		}
+
 		sendErr := mgs.sendGetFileStreamChunk(ctx, chunk, streamingServer)
 		if sendErr != nil {
 			return sendErr
@@ -303,9 +345,45 @@ func (mgs *FileService) sendGetFileStreamChunks(ctx context.Context, fullFilePat
 	return nil
 }
 
+// sendGetFileStreamDelta sends only the blocks of the file that are not in the copy of the file on the data plane
+func (mgs *FileService) sendGetFileStreamDelta(ctx context.Context, fullFilePath string,
+	request *v1.GetFileRequest, streamingServer grpc.ServerStreamingServer[v1.FileDataChunk],
+) error {
+	content, err := os.ReadFile(fullFilePath)
+	if err != nil {
+		return err
+	}
+
+	chunkSize := mgs.agentConfig.Client.Grpc.FileChunkSize
+
+	instructions, err := files.Delta(request.GetSignature(), content, chunkSize)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "Invalid file signature: %v", err)
+	}
+
+	slog.InfoContext(ctx, "Sending file delta", "name", request.GetFileMeta().GetName(),
+		"instructions", len(instructions))
+
+	return files.SendChunkedFileDelta(
+		&v1.MessageMeta{
+			MessageId:     id.GenerateMessageID(),
+			CorrelationId: logger.CorrelationID(ctx),
+			Timestamp:     timestamppb.Now(),
+		},
+		&v1.FileDataChunkHeader{
+			FileMeta:    request.GetFileMeta(),
+			ChunkSize:   chunkSize,
+			Compression: files.NegotiateCompression(request.GetAcceptedCompressions()),
+		},
+		instructions,
+		streamingServer,
+	)
+}
+
 func (mgs *FileService) sendGetFileStreamHeader(ctx context.Context,
 	fileToUpdate *v1.FileMeta,
-	chunkSize uint32, streamingServer grpc.ServerStreamingServer[v1.FileDataChunk],
+	chunkSize uint32, offset uint64, compression v1.Compression,
+	streamingServer grpc.ServerStreamingServer[v1.FileDataChunk],
 ) error {
 	messageMeta := &v1.MessageMeta{
 		MessageId:     id.GenerateMessageID(),
@@ -313,13 +391,15 @@ func (mgs *FileService) sendGetFileStreamHeader(ctx context.Context,
 		Timestamp:     timestamppb.Now(),
 	}
 
-	numberOfChunks := uint32(math.Ceil(float64(fileToUpdate.GetSize()) / float64(chunkSize)))
+	numberOfChunks := uint32(math.Ceil(float64(uint64(fileToUpdate.GetSize())-offset) / float64(chunkSize)))
 
 	header := v1.FileDataChunk_Header{
 		Header: &v1.FileDataChunkHeader{
-			FileMeta:  fileToUpdate,
-			Chunks:    numberOfChunks,
-			ChunkSize: chunkSize,
+			FileMeta:    fileToUpdate,
+			Chunks:      numberOfChunks,
+			ChunkSize:   chunkSize,
+			Offset:      offset,
+			Compression: compression,
 		},
 	}
 
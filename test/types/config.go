@@ -170,6 +170,7 @@ func AgentConfig() *config.Config {
 			Nginx: &config.NginxDataPlaneConfig{
 				TreatWarningsAsErrors:  true,
 				ReloadMonitoringPeriod: reloadMonitoringPeriod,
+				ConfigHistorySize:      config.DefNginxConfigHistorySize,
 				ExcludeLogs:            []string{},
 			},
 		},